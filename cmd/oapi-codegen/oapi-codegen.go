@@ -17,7 +17,6 @@ import (
 	"flag"
 	"fmt"
 	"os"
-	"os/exec"
 	"path"
 	"path/filepath"
 	"runtime/debug"
@@ -26,6 +25,7 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi/normalize"
 	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
 )
 
@@ -38,15 +38,25 @@ func errExit(format string, args ...interface{}) {
 }
 
 var (
-	flagOutputFile     string
-	flagConfigFile     string
-	flagOldConfigStyle bool
-	flagOutputConfig   bool
-	flagPrintVersion   bool
-	flagPackageName    string
-	flagPrintUsage     bool
-	flagGenerate       string
-	flagTemplatesDir   string
+	flagOutputFile      string
+	flagConfigFile      string
+	flagOldConfigStyle  bool
+	flagOutputConfig    bool
+	flagPrintVersion    bool
+	flagPackageName     string
+	flagPrintUsage      bool
+	flagGenerate        string
+	flagTemplatesDir    string
+	flagConvertSwagger2 bool
+	flagBasePath        string
+	flagProfile         string
+	flagListDeps        bool
+	flagUpdateScaffold  bool
+	flagDumpNormalized  string
+	flagDebugModel      string
+	flagCPUProfile      string
+	flagMemProfile      string
+	flagVerbose         bool
 
 	// Deprecated: The options below will be removed in a future
 	// release. Please use the new config file format.
@@ -66,6 +76,33 @@ type configuration struct {
 
 	// OutputFile is the filename to output.
 	OutputFile string `yaml:"output,omitempty"`
+
+	// ManifestFile is the filename to output a JSON manifest of the generated code to, if set.
+	ManifestFile string `yaml:"manifest,omitempty"`
+
+	// OutputFiles are the filenames to output some of the generate targets to, rather than OutputFile.
+	OutputFiles outputFiles `yaml:"output-files,omitempty"`
+
+	// TypeManifestFile is the filename to output a JSON manifest of the generated Go types, and how they map to JSON, to, if set.
+	TypeManifestFile string `yaml:"type-manifest,omitempty"`
+
+	// NamingReportFile is the filename to output a JSON report of the names which are changed from those they'd otherwise have, such as to resolve a collision, to, or `-` to output it to stderr, if set.
+	NamingReportFile string `yaml:"naming-report,omitempty"`
+
+	// APICompatReportFile is the filename to output a JSON report of the changes to the exported Go API of the generated code, from the code previously generated to the output files, to, or `-` to output it to stderr, if set.
+	APICompatReportFile string `yaml:"api-compat-report,omitempty"`
+
+	// JSONSchemaDir is the directory to output each of the component schemas to, as a standalone JSON Schema 2020-12 document, if set.
+	JSONSchemaDir string `yaml:"json-schema-dir,omitempty"`
+
+	// CacheDir is the directory to keep the generated code in, so that it's reused when nothing it depends on has changed, if set.
+	CacheDir string `yaml:"cache-dir,omitempty"`
+
+	// Normalize selects the passes which rewrite the spec's schemas into simpler, equivalent forms, before code is generated for it.
+	Normalize normalize.Config `yaml:"normalize,omitempty"`
+
+	// Profiles are named sets of options, one of which can be selected with -profile, to override the options above.
+	Profiles map[string]yaml.MapSlice `yaml:"profiles,omitempty"`
 }
 
 // oldConfiguration is deprecated. Please add no more flags here. It is here
@@ -95,9 +132,19 @@ func main() {
 	flag.BoolVar(&flagOutputConfig, "output-config", false, "When true, outputs a configuration file for oapi-codegen using current settings.")
 	flag.StringVar(&flagConfigFile, "config", "", "A YAML config file that controls oapi-codegen behavior.")
 	flag.BoolVar(&flagPrintVersion, "version", false, "When specified, print version and exit.")
+	flag.BoolVar(&flagUpdateScaffold, "update-scaffold", false, "When the `output-files.server-scaffold` file already exists, add a skeleton of each of the server's methods which it doesn't implement yet, rather than leaving it as it is.")
 	flag.StringVar(&flagPackageName, "package", "", "The package name for generated code.")
 	flag.BoolVar(&flagPrintUsage, "help", false, "Show this help and exit.")
 	flag.BoolVar(&flagPrintUsage, "h", false, "Same as -help.")
+	flag.BoolVar(&flagConvertSwagger2, "convert-swagger2", false, "Convert a Swagger 2.0 spec to OpenAPI 3.0 before generating code.")
+	flag.StringVar(&flagProfile, "profile", "", "The name of the profile in the config file to generate code with.")
+	flag.StringVar(&flagBasePath, "base-path", "", "The directory that relative references are resolved from, when the spec is read from stdin with `-`. Defaults to the working directory.")
+	flag.BoolVar(&flagListDeps, "list-deps", false, "Print the external files and URLs which the spec references, with the SHA-256 hash of each, and exit.")
+	flag.StringVar(&flagDebugModel, "debug-model", "", "Write the model the code is generated from, such as the Go type chosen for each schema, operation and parameter, and why types were renamed, as JSON, to the given file, or to stderr with `-`, to debug why a particular Go construct is generated.")
+	flag.StringVar(&flagDumpNormalized, "dump-normalized", "", "Write the spec, as it's rewritten by the `normalize` passes, as YAML, to the given file, or to stderr with `-`, to debug them.")
+	flag.StringVar(&flagCPUProfile, "cpuprofile", "", "Write a CPU profile of loading the spec and generating code to the given file, to be read with `go tool pprof`.")
+	flag.StringVar(&flagMemProfile, "memprofile", "", "Write a profile of the memory allocated while loading the spec and generating code to the given file, to be read with `go tool pprof`.")
+	flag.BoolVar(&flagVerbose, "verbose", false, "Log the decisions made while loading the spec and generating code, and how long each phase of it takes, such as loading the spec, generating the models, and formatting the code, to stderr.")
 
 	// All flags below are deprecated, and will be removed in a future release. Please do not
 	// update their behavior.
@@ -114,7 +161,21 @@ func main() {
 	flag.BoolVar(&flagAliasTypes, "alias-types", false, "Alias type declarations if possible.")
 	flag.BoolVar(&flagInitialismOverrides, "initialism-overrides", false, "Use initialism overrides.")
 
-	flag.Parse()
+	// `oapi-codegen overlay` writes an overlay of the changes between two specs, rather than generating code
+	if len(os.Args) > 1 && os.Args[1] == "overlay" {
+		if err := runOverlay(os.Args[2:], os.Stdout); err != nil {
+			errExit("%s\n", err)
+		}
+		return
+	}
+
+	// `oapi-codegen report` takes the same flags and spec, but reports on what would be generated rather than generating it
+	args := os.Args[1:]
+	reportMode := len(args) > 0 && args[0] == "report"
+	if reportMode {
+		args = args[1:]
+	}
+	_ = flag.CommandLine.Parse(args)
 
 	if flagPrintUsage {
 		flag.Usage()
@@ -137,11 +198,25 @@ func main() {
 	}
 
 	if flag.NArg() < 1 {
-		errExit("Please specify a path to a OpenAPI 3.0 spec file\n")
+		errExit("Please specify a path to a OpenAPI 3.0 spec file, or - to read it from stdin\n")
 	} else if flag.NArg() > 1 {
 		errExit("Only one OpenAPI 3.0 spec file is accepted and it must be the last CLI argument\n")
 	}
 
+	stopProfiling, err := startProfiling(flagCPUProfile, flagMemProfile)
+	if err != nil {
+		errExit("%s\n", err)
+	}
+	defer func() {
+		if err := stopProfiling(); err != nil {
+			errExit("%s\n", err)
+		}
+	}()
+
+	if flagProfile != "" && flagConfigFile == "" {
+		errExit("A profile can only be selected from a config file, given with -config\n")
+	}
+
 	// We will try to infer whether the user has an old-style config, or a new
 	// style. Start with the command line argument. If it's true, we know it's
 	// old config style.
@@ -217,6 +292,9 @@ func main() {
 			if err != nil {
 				errExit("error parsing'%s' as YAML: %v\n", flagConfigFile, err)
 			}
+			if err := opts.applyProfile(flagProfile); err != nil {
+				errExit("error reading config file '%s': %v\n", flagConfigFile, err)
+			}
 		} else {
 			// In the case where no config file is provided, we assume some
 			// defaults, so that when this is invoked very simply, it's similar
@@ -290,10 +368,21 @@ func main() {
 		return
 	}
 
+	if flagVerbose {
+		logger := verboseLogger(os.Stderr)
+		opts.Logger = logger
+		opts.Timings = logTimings(logger)
+	}
+
 	overlayOpts := util.LoadSwaggerWithOverlayOpts{
 		Path: opts.OutputOptions.Overlay.Path,
 		// default to strict, but can be overridden
-		Strict: true,
+		Strict:                       true,
+		ConvertSwagger2:              flagConvertSwagger2,
+		BasePath:                     flagBasePath,
+		KeepSingleMemberCompositions: opts.Compatibility.KeepSingleMemberCompositions,
+		Logger:                       opts.Logger,
+		Timings:                      opts.Timings,
 	}
 
 	if opts.OutputOptions.Overlay.Strict != nil {
@@ -305,29 +394,220 @@ func main() {
 		errExit("error loading swagger spec in %s\n: %s\n", flag.Arg(0), err)
 	}
 
-	if strings.HasPrefix(swagger.OpenAPI, "3.1.") {
+	for _, warning := range swagger.LoadResult().Warnings {
+		fmt.Fprintf(os.Stderr, "WARNING: %s\n", warning)
+	}
+
+	if swagger.IsOpenAPI31() {
 		fmt.Fprintln(os.Stderr, "INFO: Using OpenAPI 3.1.x specification with experimental support. Union types and webhooks are now supported.")
 	}
 
+	if flagListDeps {
+		if err := printDependencies(os.Stdout, swagger); err != nil {
+			errExit("error listing dependencies: %s\n", err)
+		}
+		return
+	}
+
+	passes, err := opts.Normalize.Build()
+	if err != nil {
+		errExit("configuration error: %s\n", err)
+	}
+	swagger, err = normalize.Normalize(swagger, passes...)
+	if err != nil {
+		errExit("error normalizing spec: %s\n", err)
+	}
+	if flagDumpNormalized != "" {
+		if err := writeNormalized(flagDumpNormalized, swagger); err != nil {
+			errExit("error writing normalized spec: %s\n", err)
+		}
+	}
+
 	if len(noVCSVersionOverride) > 0 {
 		opts.NoVCSVersionOverride = &noVCSVersionOverride
 	}
 
-	code, err := codegen.Generate(swagger, opts.Configuration)
-	if err != nil {
-		errExit("error generating code: %s\n", err)
+	if reportMode {
+		if err := writeReport(swagger, opts.Configuration, flagOutputFile); err != nil {
+			errExit("error generating report: %s\n", err)
+		}
+		return
 	}
 
-	if opts.OutputFile != "" {
-		if err := os.MkdirAll(filepath.Dir(opts.OutputFile), 0o755); err != nil {
+	if opts.Generate.Docs {
+		if opts.OutputFiles.Docs == "" {
+			errExit("configuration error: `docs` generates Markdown, rather than code, so needs a file of its own, with `output-files.docs`\n")
+		}
+		docs, err := codegen.GenerateDocs(swagger, opts.Configuration)
+		if err != nil {
+			errExit("error generating docs: %s\n", err)
+		}
+		if err := os.MkdirAll(filepath.Dir(opts.OutputFiles.Docs), 0o755); err != nil {
 			errExit("error unable to create directory: %s\n", err)
 		}
-		err = os.WriteFile(opts.OutputFile, []byte(code), 0o644)
+		if err := os.WriteFile(opts.OutputFiles.Docs, []byte(docs), 0o644); err != nil {
+			errExit("error writing docs to file: %s\n", err)
+		}
+	}
+
+	if opts.Generate.ServerScaffold {
+		if opts.OutputFiles.ServerScaffold == "" {
+			errExit("configuration error: `server-scaffold` is only generated once, so that it can be edited, so needs a file of its own, with `output-files.server-scaffold`\n")
+		}
+		if err := writeServerScaffold(swagger, opts.Configuration, opts.OutputFiles.ServerScaffold, flagUpdateScaffold); err != nil {
+			errExit("error generating server scaffold: %s\n", err)
+		}
+	}
+
+	if flagDebugModel != "" {
+		model, err := codegen.GenerateDebugModel(swagger, opts.Configuration)
 		if err != nil {
-			errExit("error writing generated code to file: %s\n", err)
+			errExit("error generating debug model: %s\n", err)
+		}
+		if err := writeJSONOrStderr(flagDebugModel, model); err != nil {
+			errExit("error writing debug model: %s\n", err)
+		}
+	}
+
+	if opts.TypeManifestFile != "" {
+		manifest, err := codegen.GenerateTypeManifest(swagger, opts.Configuration)
+		if err != nil {
+			errExit("error generating type manifest: %s\n", err)
+		}
+		if err := writeJSON(opts.TypeManifestFile, manifest); err != nil {
+			errExit("error writing type manifest: %s\n", err)
+		}
+	}
+
+	if opts.NamingReportFile != "" {
+		if err := writeNamingReport(swagger, opts.Configuration, opts.NamingReportFile); err != nil {
+			errExit("error writing naming report: %s\n", err)
+		}
+	}
+
+	if opts.JSONSchemaDir != "" {
+		if err := writeJSONSchemas(opts.JSONSchemaDir, swagger); err != nil {
+			errExit("error writing JSON Schemas: %s\n", err)
+		}
+	}
+
+	generations := opts.generations()
+	if opts.ManifestFile != "" && len(generations) > 1 {
+		errExit("configuration error: `manifest` can't be used along with `output-files`\n")
+	}
+	if opts.ManifestFile != "" && opts.OutputOptions.OneFilePerType {
+		errExit("configuration error: `manifest` can't be used along with `one-file-per-type`\n")
+	}
+	if opts.ManifestFile != "" && opts.OutputOptions.FailOnDeprecatedUsage {
+		errExit("configuration error: `manifest` can't be used along with `fail-on-deprecated-usage`\n")
+	}
+
+	// The test helpers are output to a `_test.go` file of their own, alongside the code
+	testHelpers := opts.Generate.ConformanceTests || opts.Generate.Fuzz
+	if opts.ManifestFile != "" && testHelpers {
+		errExit("configuration error: `manifest` can't be used along with `conformance-tests` or `fuzz`\n")
+	}
+	if testHelpers && (opts.OutputOptions.SkipFmt || codegen.Formatter(opts.OutputOptions.Formatter) == codegen.FormatterNone) {
+		errExit("configuration error: `conformance-tests` and `fuzz` need to format the code, to fix the imports of the `_test.go` file their test helpers are output to. Please remove `skip-fmt`, or set the `formatter`\n")
+	}
+
+	if opts.CacheDir != "" && opts.ManifestFile != "" {
+		errExit("configuration error: `cache-dir` can't be used along with `manifest`\n")
+	}
+	if opts.CacheDir != "" && opts.OutputOptions.OneFilePerType {
+		errExit("configuration error: `cache-dir` can't be used along with `one-file-per-type`\n")
+	}
+	if opts.CacheDir != "" && opts.OutputOptions.FailOnDeprecatedUsage {
+		errExit("configuration error: `cache-dir` can't be used along with `fail-on-deprecated-usage`\n")
+	}
+	if opts.CacheDir != "" && testHelpers {
+		errExit("configuration error: `cache-dir` can't be used along with `conformance-tests` or `fuzz`\n")
+	}
+
+	// The code previously generated is read before it's overwritten, so that its API can be compared with the code generated now
+	var previousAPI, currentAPI []string
+	for _, gen := range generations {
+		cfg := opts.Configuration
+		cfg.Generate = gen.generate
+
+		if opts.APICompatReportFile != "" {
+			if gen.outputFile == "" {
+				errExit("configuration error: `api-compat-report` needs the code to be output to a file, with `output`, to compare with\n")
+			}
+			previous, err := previousCode(gen.outputFile, opts.OutputOptions.OneFilePerType)
+			if err != nil {
+				errExit("error reading previously generated code: %s\n", err)
+			}
+			previousAPI = append(previousAPI, previous...)
+		}
+
+		if opts.OutputOptions.OneFilePerType || opts.OutputOptions.FailOnDeprecatedUsage || gen.generate.ConformanceTests || gen.generate.Fuzz {
+			if gen.outputFile == "" && opts.OutputOptions.OneFilePerType {
+				errExit("configuration error: `one-file-per-type` needs the code to be output to a file, with `output`\n")
+			}
+			if gen.outputFile == "" && (gen.generate.ConformanceTests || gen.generate.Fuzz) {
+				errExit("configuration error: `conformance-tests` and `fuzz` need the code to be output to a file, with `output`, as their test helpers are output to a `_test.go` file alongside it\n")
+			}
+			if gen.outputFile == "" {
+				errExit("configuration error: `fail-on-deprecated-usage` needs the code to be output to a file, with `output`\n")
+			}
+			files, err := codegen.GenerateFiles(swagger, cfg, gen.outputFile)
+			if err != nil {
+				errExit("error generating code: %s\n", err)
+			}
+			for _, code := range files {
+				currentAPI = append(currentAPI, code)
+			}
+			if err := writeFiles(gen.outputFile, files); err != nil {
+				errExit("%s\n", err)
+			}
+			continue
+		}
+
+		var code string
+		if opts.ManifestFile != "" {
+			var manifest *codegen.Manifest
+			code, manifest, err = codegen.GenerateWithManifest(swagger, cfg)
+			if err != nil {
+				errExit("error generating code: %s\n", err)
+			}
+			if err := writeJSON(opts.ManifestFile, manifest); err != nil {
+				errExit("error writing manifest: %s\n", err)
+			}
+		} else if opts.CacheDir != "" {
+			code, err = codegen.GenerateCached(swagger, cfg, opts.CacheDir)
+			if err != nil {
+				errExit("error generating code: %s\n", err)
+			}
+		} else {
+			code, err = codegen.Generate(swagger, cfg)
+			if err != nil {
+				errExit("error generating code: %s\n", err)
+			}
+		}
+
+		currentAPI = append(currentAPI, code)
+
+		if gen.outputFile != "" {
+			if err := os.MkdirAll(filepath.Dir(gen.outputFile), 0o755); err != nil {
+				errExit("error unable to create directory: %s\n", err)
+			}
+			err = os.WriteFile(gen.outputFile, []byte(code), 0o644)
+			if err != nil {
+				errExit("error writing generated code to file: %s\n", err)
+			}
+			if err := removeStaleTestFile(gen.outputFile); err != nil {
+				errExit("%s\n", err)
+			}
+		} else {
+			fmt.Print(code)
+		}
+	}
+
+	if opts.APICompatReportFile != "" {
+		if err := writeAPICompatReport(previousAPI, currentAPI, opts.APICompatReportFile); err != nil {
+			errExit("error writing API compatibility report: %s\n", err)
 		}
-	} else {
-		fmt.Print(code)
 	}
 }
 
@@ -367,38 +647,56 @@ func loadTemplateOverrides(templatesDir string) (map[string]string, error) {
 	return templates, nil
 }
 
-// detectPackageName detects and sets PackageName if not already set.
+// detectPackageName detects and sets PackageName if not already set, and
+// ensures that it matches the package of any other Go files in the
+// directory the code is output to.
 func detectPackageName(cfg *configuration) error {
-	if cfg.PackageName != "" {
-		return nil
-	}
+	outputFiles := cfg.outputFileNames()
 
-	if cfg.OutputFile != "" {
-		// Determine from the package name of the output file.
-		dir := filepath.Dir(cfg.PackageName)
-		cmd := exec.Command("go", "list", "-f", "{{.Name}}", dir)
-		out, err := cmd.CombinedOutput()
+	if cfg.PackageName == "" && len(outputFiles) > 0 {
+		dir := filepath.Dir(outputFiles[0])
+		// Determine from the package of the other files in the output directory.
+		name, _, err := existingPackage(dir, outputFiles)
 		if err != nil {
-			outStr := string(out)
-			switch {
-			case strings.Contains(outStr, "expected 'package', found 'EOF'"):
-				// Redirecting the output to current directory which hasn't
-				// written anything yet, ignore.
-			case strings.HasPrefix(outStr, "no Go files in"):
-				// No go files yet, ignore.
-			default:
-				// Unexpected failure report.
-				return fmt.Errorf("detect package name for %q output: %q: %w", dir, string(out), err)
+			return err
+		}
+		if name == "" {
+			// Otherwise, determine from the output directory's name, if it's
+			// inside a Go module.
+			name, err = modulePackageName(dir)
+			if err != nil {
+				return fmt.Errorf("detect package name for %q output: %w", dir, err)
 			}
-		} else {
-			cfg.PackageName = string(out)
-			return nil
 		}
+		cfg.PackageName = name
+	}
+
+	if cfg.PackageName == "" {
+		// Fallback to determining from the spec file name.
+		if flag.Arg(0) == util.StdinPath {
+			return fmt.Errorf("the package name can't be detected when the spec is read from stdin, please specify it with `package` in the configuration file, or the -package flag")
+		}
+		parts := strings.Split(filepath.Base(flag.Arg(0)), ".")
+		cfg.PackageName = codegen.LowercaseFirstCharacter(codegen.ToCamelCase(parts[0]))
 	}
 
-	// Fallback to determining from the spec file name.
-	parts := strings.Split(filepath.Base(flag.Arg(0)), ".")
-	cfg.PackageName = codegen.LowercaseFirstCharacter(codegen.ToCamelCase(parts[0]))
+	// The generated code won't compile alongside files from another package.
+	checked := make(map[string]bool)
+	for _, outputFile := range outputFiles {
+		dir := filepath.Dir(outputFile)
+		if checked[dir] {
+			continue
+		}
+		checked[dir] = true
+
+		name, file, err := existingPackage(dir, outputFiles)
+		if err != nil {
+			return err
+		}
+		if name != "" && name != cfg.PackageName {
+			return fmt.Errorf("the generated code would be in package `%s`, but %s is in package `%s`, so the code wouldn't compile. Set `package` to `%s` in the configuration file, or with the -package flag, or output the code to another directory", cfg.PackageName, file, name, name)
+		}
+	}
 
 	return nil
 }
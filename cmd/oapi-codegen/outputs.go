@@ -0,0 +1,309 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+// outputFiles configures a file of its own for some of the generate targets, rather than generating them into `output`
+type outputFiles struct {
+	// Models is the file to output the `models` target to, along with `server-urls`, `deepcopy`, `equal`, `validation`, `stringer`, `fuzz`, `json-schemas` and `spec-provenance`
+	Models string `yaml:"models,omitempty"`
+	// Client is the file to output the `client` target to, along with `validation`
+	Client string `yaml:"client,omitempty"`
+	// Server is the file to output the server targets to, such as `chi-server`, along with `strict-server`, `conformance-tests` and `url-helpers`
+	Server string `yaml:"server,omitempty"`
+	// EmbeddedSpec is the file to output the `embedded-spec` target to
+	EmbeddedSpec string `yaml:"embedded-spec,omitempty"`
+	// Docs is the file to output the Markdown reference generated by the `docs` target to, which, as it isn't code, always needs a file of its own
+	Docs string `yaml:"docs,omitempty"`
+	// ServerScaffold is the file to output the implementation generated by the `server-scaffold` target to, in the same package as the server, which is only written if it doesn't already exist, so that it can be edited
+	ServerScaffold string `yaml:"server-scaffold,omitempty"`
+}
+
+// generation is a file to output, with the generate targets to output to it
+type generation struct {
+	// outputFile is the file to output, or empty to output to stdout
+	outputFile string
+	generate   codegen.GenerateOptions
+}
+
+// generations splits the generate targets into those output to each of the OutputFiles, and the rest, which are output to the OutputFile
+func (c configuration) generations() []generation {
+	rest := c.Generate
+	// The docs are output separately, as they aren't code
+	rest.Docs = false
+	// The server scaffold is only output once, rather than being regenerated
+	rest.ServerScaffold = false
+	var generations []generation
+
+	if c.OutputFiles.Models != "" {
+		generations = append(generations, generation{
+			outputFile: c.OutputFiles.Models,
+			generate: codegen.GenerateOptions{
+				Models:     rest.Models,
+				ServerURLs: rest.ServerURLs,
+				DeepCopy:   rest.DeepCopy,
+				Equal:      rest.Equal,
+				Validation: rest.Validation,
+				Stringer:   rest.Stringer,
+				Fuzz:       rest.Fuzz,
+
+				JSONSchemas:    rest.JSONSchemas,
+				SpecProvenance: rest.SpecProvenance,
+			},
+		})
+		rest.Models, rest.ServerURLs, rest.DeepCopy, rest.Equal, rest.Stringer, rest.Fuzz, rest.JSONSchemas = false, false, false, false, false, false, false
+		rest.SpecProvenance = false
+	}
+
+	if c.OutputFiles.Client != "" {
+		generations = append(generations, generation{
+			outputFile: c.OutputFiles.Client,
+			generate: codegen.GenerateOptions{
+				Client:     rest.Client,
+				Validation: rest.Validation,
+			},
+		})
+		rest.Client = false
+	}
+
+	if c.OutputFiles.Server != "" {
+		generations = append(generations, generation{
+			outputFile: c.OutputFiles.Server,
+			generate: codegen.GenerateOptions{
+				IrisServer:    rest.IrisServer,
+				ChiServer:     rest.ChiServer,
+				FiberServer:   rest.FiberServer,
+				EchoServer:    rest.EchoServer,
+				GinServer:     rest.GinServer,
+				GorillaServer: rest.GorillaServer,
+				StdHTTPServer: rest.StdHTTPServer,
+				Strict:        rest.Strict,
+				// The conformance tests are sent to the server's handler
+				ConformanceTests: rest.ConformanceTests,
+				// The URL helpers are mostly used by servers generating links
+				URLHelpers: rest.URLHelpers,
+			},
+		})
+		rest.IrisServer, rest.ChiServer, rest.FiberServer, rest.EchoServer = false, false, false, false
+		rest.GinServer, rest.GorillaServer, rest.StdHTTPServer, rest.Strict = false, false, false, false
+		rest.ConformanceTests, rest.URLHelpers = false, false
+	}
+
+	if c.OutputFiles.EmbeddedSpec != "" {
+		generations = append(generations, generation{
+			outputFile: c.OutputFiles.EmbeddedSpec,
+			generate: codegen.GenerateOptions{
+				EmbeddedSpec: rest.EmbeddedSpec,
+			},
+		})
+		rest.EmbeddedSpec = false
+	}
+
+	// `validation` is output along with the models and the client, so only remains if one of them does
+	if (c.OutputFiles.Models != "" || c.OutputFiles.Client != "") && !rest.Models && !rest.Client {
+		rest.Validation = false
+	}
+
+	// Anything which hasn't been given a file of its own is output as usual
+	if rest != (codegen.GenerateOptions{}) || len(generations) == 0 {
+		generations = append(generations, generation{
+			outputFile: c.OutputFile,
+			generate:   rest,
+		})
+	}
+	return generations
+}
+
+// writeFiles writes the files generated for outputFile to its directory, removing any files which types were previously split out into, with `one-file-per-type`, or the test helpers were, which are no longer generated
+func writeFiles(outputFile string, files map[string]string) error {
+	dir := filepath.Dir(outputFile)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("error unable to create directory: %w", err)
+	}
+
+	for name, code := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(code), 0o644); err != nil {
+			return fmt.Errorf("error writing generated code to file: %w", err)
+		}
+	}
+
+	if _, ok := files[filepath.Base(codegen.TestFileName(outputFile))]; !ok {
+		if err := removeStaleTestFile(outputFile); err != nil {
+			return err
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("error reading output directory: %w", err)
+	}
+	for _, entry := range entries {
+		if _, ok := files[entry.Name()]; ok || entry.IsDir() || !strings.HasSuffix(entry.Name(), ".gen.go") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		code, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading %s: %w", path, err)
+		}
+		if codegen.IsTypeFile(string(code), outputFile) {
+			if err := os.Remove(path); err != nil {
+				return fmt.Errorf("error removing %s, which is no longer generated: %w", path, err)
+			}
+		}
+	}
+	return nil
+}
+
+// removeStaleTestFile removes the `_test.go` file which the test helpers were previously split out of outputFile into, once they're no longer generated
+func removeStaleTestFile(outputFile string) error {
+	path := codegen.TestFileName(outputFile)
+	code, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", path, err)
+	}
+	if !codegen.IsTestFile(string(code), outputFile) {
+		return nil
+	}
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("error removing %s, which is no longer generated: %w", path, err)
+	}
+	return nil
+}
+
+// writeJSONSchemas writes each of the spec's component schemas to the directory, as a standalone JSON Schema document named after the schema
+func writeJSONSchemas(dir string, spec *openapi.T) error {
+	documents, err := spec.JSONSchemas()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("error unable to create directory: %w", err)
+	}
+	for name, document := range documents {
+		if err := os.WriteFile(filepath.Join(dir, name+".json"), append(document, '\n'), 0o644); err != nil {
+			return fmt.Errorf("error writing JSON Schema of %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// writeNormalized writes the spec as YAML to outputFile, or to stderr if it's `-`, so that what the `normalize` passes rewrite it into can be checked
+func writeNormalized(outputFile string, spec *openapi.T) error {
+	rendered, err := spec.Render()
+	if err != nil {
+		return err
+	}
+	if outputFile == "-" {
+		_, err = os.Stderr.Write(rendered)
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(outputFile), 0o755); err != nil {
+		return fmt.Errorf("error unable to create directory: %w", err)
+	}
+	if err := os.WriteFile(outputFile, rendered, 0o644); err != nil {
+		return fmt.Errorf("error writing to file: %w", err)
+	}
+	return nil
+}
+
+// printDependencies prints each of the external documents which the spec references, as `sha256sum` does, so that build systems can declare them as inputs, and check whether they've changed. Files are printed relative to the working directory, where possible
+func printDependencies(w io.Writer, spec *openapi.T) error {
+	wd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("error getting working directory: %w", err)
+	}
+	for _, doc := range spec.ExternalDocuments() {
+		location := doc.Location
+		if !doc.Remote {
+			if rel, err := filepath.Rel(wd, location); err == nil {
+				location = rel
+			}
+		}
+		if _, err := fmt.Fprintf(w, "%s  %s\n", doc.SHA256, location); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeServerScaffold writes the scaffold of the server's implementation to outputFile, unless the file already exists, as it's only a starting point, which is then edited. With update, an existing file has a skeleton added of each of the server's methods which the implementation doesn't have yet
+func writeServerScaffold(swagger *openapi.T, cfg codegen.Configuration, outputFile string, update bool) error {
+	if _, err := os.Stat(outputFile); err == nil {
+		if !update {
+			return nil
+		}
+		return updateServerScaffold(swagger, cfg, outputFile)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("error checking for an existing scaffold: %w", err)
+	}
+
+	scaffold, err := codegen.GenerateServerScaffold(swagger, cfg)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(outputFile), 0o755); err != nil {
+		return fmt.Errorf("error unable to create directory: %w", err)
+	}
+	// The file is only created, rather than truncated, in case it was written since it was checked for
+	f, err := os.OpenFile(outputFile, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return fmt.Errorf("error creating scaffold file: %w", err)
+	}
+	if _, err := f.WriteString(scaffold); err != nil {
+		f.Close()
+		return fmt.Errorf("error writing scaffold to file: %w", err)
+	}
+	return f.Close()
+}
+
+// updateServerScaffold adds a skeleton of each of the server's methods which the implementation in outputFile, along with the other files of its package, doesn't have yet to outputFile, printing the name of each of the methods added to stderr
+func updateServerScaffold(swagger *openapi.T, cfg codegen.Configuration, outputFile string) error {
+	src, err := os.ReadFile(outputFile)
+	if err != nil {
+		return fmt.Errorf("error reading scaffold: %w", err)
+	}
+	otherFiles, err := filepath.Glob(filepath.Join(filepath.Dir(outputFile), "*.go"))
+	if err != nil {
+		return fmt.Errorf("error listing the scaffold's package: %w", err)
+	}
+	var others [][]byte
+	for _, other := range otherFiles {
+		if strings.HasSuffix(other, "_test.go") || filepath.Base(other) == filepath.Base(outputFile) {
+			continue
+		}
+		otherSrc, err := os.ReadFile(other)
+		if err != nil {
+			return fmt.Errorf("error reading the scaffold's package: %w", err)
+		}
+		others = append(others, otherSrc)
+	}
+
+	updated, added, err := codegen.UpdateServerScaffold(swagger, cfg, src, others...)
+	if err != nil {
+		return err
+	}
+	if len(added) == 0 {
+		return nil
+	}
+	if err := os.WriteFile(outputFile, updated, 0o644); err != nil {
+		return fmt.Errorf("error writing scaffold to file: %w", err)
+	}
+	for _, method := range added {
+		fmt.Fprintf(os.Stderr, "added %s to %s\n", method, outputFile)
+	}
+	return nil
+}
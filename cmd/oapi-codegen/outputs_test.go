@@ -0,0 +1,128 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
+)
+
+func TestGenerations(t *testing.T) {
+	t.Run("without output-files, everything is output to output", func(t *testing.T) {
+		c := configuration{
+			Configuration: codegen.Configuration{
+				Generate: codegen.GenerateOptions{Models: true, Client: true},
+			},
+			OutputFile: "api.gen.go",
+		}
+
+		assert.Equal(t, []generation{
+			{outputFile: "api.gen.go", generate: codegen.GenerateOptions{Models: true, Client: true}},
+		}, c.generations())
+	})
+
+	t.Run("each target is output to its own file", func(t *testing.T) {
+		c := configuration{
+			Configuration: codegen.Configuration{
+				Generate: codegen.GenerateOptions{
+					Models:    true,
+					Equal:     true,
+					Stringer:  true,
+					Fuzz:      true,
+					Client:    true,
+					ChiServer: true,
+					Strict:    true,
+
+					ConformanceTests: true,
+					URLHelpers:       true,
+				},
+			},
+			OutputFiles: outputFiles{
+				Models: "types.gen.go",
+				Client: "client.gen.go",
+				Server: "server.gen.go",
+			},
+		}
+
+		assert.Equal(t, []generation{
+			{outputFile: "types.gen.go", generate: codegen.GenerateOptions{Models: true, Equal: true, Stringer: true, Fuzz: true}},
+			{outputFile: "client.gen.go", generate: codegen.GenerateOptions{Client: true}},
+			{outputFile: "server.gen.go", generate: codegen.GenerateOptions{ChiServer: true, Strict: true, ConformanceTests: true, URLHelpers: true}},
+		}, c.generations())
+	})
+
+	t.Run("targets without their own file are output to output", func(t *testing.T) {
+		c := configuration{
+			Configuration: codegen.Configuration{
+				Generate: codegen.GenerateOptions{Models: true, EmbeddedSpec: true},
+			},
+			OutputFile: "spec.gen.go",
+			OutputFiles: outputFiles{
+				Models: "types.gen.go",
+			},
+		}
+
+		assert.Equal(t, []generation{
+			{outputFile: "types.gen.go", generate: codegen.GenerateOptions{Models: true}},
+			{outputFile: "spec.gen.go", generate: codegen.GenerateOptions{EmbeddedSpec: true}},
+		}, c.generations())
+	})
+
+	t.Run("docs aren't output with the code", func(t *testing.T) {
+		c := configuration{
+			Configuration: codegen.Configuration{
+				Generate: codegen.GenerateOptions{Models: true, Client: true, Docs: true},
+			},
+			OutputFile: "api.gen.go",
+			OutputFiles: outputFiles{
+				Docs: "API.md",
+			},
+		}
+
+		assert.Equal(t, []generation{
+			{outputFile: "api.gen.go", generate: codegen.GenerateOptions{Models: true, Client: true}},
+		}, c.generations())
+	})
+
+	t.Run("validation is output along with the models and the client", func(t *testing.T) {
+		c := configuration{
+			Configuration: codegen.Configuration{
+				Generate: codegen.GenerateOptions{Models: true, Client: true, Validation: true},
+			},
+			OutputFiles: outputFiles{
+				Models: "types.gen.go",
+				Client: "client.gen.go",
+			},
+		}
+
+		assert.Equal(t, []generation{
+			{outputFile: "types.gen.go", generate: codegen.GenerateOptions{Models: true, Validation: true}},
+			{outputFile: "client.gen.go", generate: codegen.GenerateOptions{Client: true, Validation: true}},
+		}, c.generations())
+	})
+}
+
+func TestRemoveStaleTestFile(t *testing.T) {
+	dir := t.TempDir()
+	outputFile := filepath.Join(dir, "api.gen.go")
+
+	files := map[string]string{
+		"api.gen.go":      "package api\n",
+		"api.gen_test.go": "package api\n\n// This file holds the test helpers split out of api.gen.go, as they import `testing`.\n",
+	}
+	require.NoError(t, writeFiles(outputFile, files))
+	assert.FileExists(t, filepath.Join(dir, "api.gen_test.go"))
+
+	// Once the test helpers are no longer generated, their file is removed
+	require.NoError(t, writeFiles(outputFile, map[string]string{"api.gen.go": "package api\n"}))
+	assert.NoFileExists(t, filepath.Join(dir, "api.gen_test.go"))
+
+	// A test file which wasn't generated is kept
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "api.gen_test.go"), []byte("package api\n"), 0o644))
+	require.NoError(t, removeStaleTestFile(outputFile))
+	assert.FileExists(t, filepath.Join(dir, "api.gen_test.go"))
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+// runOverlay writes an OpenAPI Overlay of the changes between the original and edited specs given in args, to the file given with `-o`, or to stdout, so that edits made directly to a spec can be kept as an overlay instead
+func runOverlay(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("overlay", flag.ContinueOnError)
+	title := flags.String("title", "Overlay", "The title of the overlay.")
+	outputFile := flags.String("o", "", "Where to output the overlay, stdout is default.")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: oapi-codegen overlay [-title title] [-o overlay.yaml] original.yaml edited.yaml")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return errors.New("the original spec and the edited spec must be given")
+	}
+
+	original, err := os.ReadFile(flags.Arg(0))
+	if err != nil {
+		return fmt.Errorf("error reading original spec: %w", err)
+	}
+	edited, err := os.ReadFile(flags.Arg(1))
+	if err != nil {
+		return fmt.Errorf("error reading edited spec: %w", err)
+	}
+	overlay, err := openapi.DiffOverlay(*title, original, edited)
+	if err != nil {
+		return fmt.Errorf("error generating overlay: %w", err)
+	}
+
+	if *outputFile == "" {
+		_, err = stdout.Write(overlay)
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(*outputFile), 0o755); err != nil {
+		return fmt.Errorf("error unable to create directory: %w", err)
+	}
+	if err := os.WriteFile(*outputFile, overlay, 0o644); err != nil {
+		return fmt.Errorf("error writing to file: %w", err)
+	}
+	return nil
+}
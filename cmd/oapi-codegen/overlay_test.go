@@ -0,0 +1,32 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunOverlay(t *testing.T) {
+	dir := t.TempDir()
+	original := filepath.Join(dir, "original.yaml")
+	require.NoError(t, os.WriteFile(original, []byte("openapi: 3.0.0\ninfo:\n  title: Pets\n  version: 1.0.0\npaths: {}\n"), 0o644))
+	edited := filepath.Join(dir, "edited.yaml")
+	require.NoError(t, os.WriteFile(edited, []byte("openapi: 3.0.0\ninfo:\n  title: Public pets\n  version: 1.0.0\npaths: {}\n"), 0o644))
+
+	var stdout bytes.Buffer
+	require.NoError(t, runOverlay([]string{"-title", "Rename", original, edited}, &stdout))
+	assert.Contains(t, stdout.String(), "title: Rename")
+	assert.Contains(t, stdout.String(), "update: Public pets")
+
+	output := filepath.Join(dir, "overlays", "overlay.yaml")
+	require.NoError(t, runOverlay([]string{"-o", output, original, edited}, &stdout))
+	written, err := os.ReadFile(output)
+	require.NoError(t, err)
+	assert.Contains(t, string(written), "update: Public pets")
+
+	require.ErrorContains(t, runOverlay([]string{original}, &stdout), "must be given")
+}
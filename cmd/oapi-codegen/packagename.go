@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+)
+
+// outputFileNames returns the files the generated code will be output to
+func (c configuration) outputFileNames() []string {
+	var names []string
+	for _, gen := range c.generations() {
+		if gen.outputFile != "" {
+			names = append(names, gen.outputFile)
+		}
+	}
+	return names
+}
+
+// existingPackage returns the package clause of the Go files already in dir, along with the file it was read from, ignoring the files in exclude, which are about to be overwritten, external test packages, and files excluded by build constraints. An empty name is returned if there are no other Go files in dir
+func existingPackage(dir string, exclude []string) (name, file string, err error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return "", "", nil
+	} else if err != nil {
+		return "", "", fmt.Errorf("error reading output directory %q: %w", dir, err)
+	}
+
+	excluded := make(map[string]bool, len(exclude))
+	for _, f := range exclude {
+		if abs, err := filepath.Abs(f); err == nil {
+			excluded[abs] = true
+		}
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".go" {
+			continue
+		}
+		if match, err := build.Default.MatchFile(dir, entry.Name()); err != nil || !match {
+			continue
+		}
+		names = append(names, filepath.Join(dir, entry.Name()))
+	}
+	sort.Strings(names)
+
+	for _, f := range names {
+		if abs, err := filepath.Abs(f); err == nil && excluded[abs] {
+			continue
+		}
+		parsed, err := parser.ParseFile(token.NewFileSet(), f, nil, parser.PackageClauseOnly)
+		if err != nil {
+			// A file which doesn't parse doesn't tell us anything about the package
+			continue
+		}
+		pkg := parsed.Name.Name
+		if strings.HasSuffix(f, "_test.go") && strings.HasSuffix(pkg, "_test") {
+			continue
+		}
+		return pkg, f, nil
+	}
+	return "", "", nil
+}
+
+// modulePackageName returns the package name that the Go toolchain would expect for dir, from the directory's name, or from the module path if dir is the module's root. An empty name is returned if dir isn't inside a Go module
+func modulePackageName(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for root := abs; ; {
+		buf, err := os.ReadFile(filepath.Join(root, "go.mod"))
+		if err == nil {
+			name := filepath.Base(abs)
+			if root == abs {
+				// The module's root is imported by the module path, which may have a major version suffix, such as `/v2`, which isn't part of the package name
+				if modulePath := modfile.ModulePath(buf); modulePath != "" {
+					prefix, _, _ := module.SplitPathVersion(modulePath)
+					name = path.Base(prefix)
+				}
+			}
+			return packageNameFromDirectory(name), nil
+		} else if !os.IsNotExist(err) {
+			return "", fmt.Errorf("error reading %q: %w", filepath.Join(root, "go.mod"), err)
+		}
+
+		parent := filepath.Dir(root)
+		if parent == root {
+			return "", nil
+		}
+		root = parent
+	}
+}
+
+// packageNameFromDirectory converts a directory name into a package name, such as `petstore` for `pet-store`, as package names are conventionally the lowercased directory name, without any punctuation
+func packageNameFromDirectory(dir string) string {
+	var sb strings.Builder
+	for _, r := range dir {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			sb.WriteRune(unicode.ToLower(r))
+		}
+	}
+	name := sb.String()
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		// Not a valid package name
+		return ""
+	}
+	return name
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, name, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(name), 0o755))
+	require.NoError(t, os.WriteFile(name, []byte(content), 0o644))
+}
+
+func TestExistingPackage(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "api.gen.go"), "package old\n")
+	writeFile(t, filepath.Join(dir, "api_test.go"), "package api_test\n")
+	writeFile(t, filepath.Join(dir, "tools.go"), "//go:build tools\n\npackage tools\n")
+
+	name, _, err := existingPackage(dir, []string{filepath.Join(dir, "api.gen.go")})
+	require.NoError(t, err)
+	assert.Empty(t, name, "the output file, external tests and files excluded by build constraints are ignored")
+
+	writeFile(t, filepath.Join(dir, "petstore.go"), "// Package petstore is a pet store\npackage petstore\n")
+
+	name, file, err := existingPackage(dir, []string{filepath.Join(dir, "api.gen.go")})
+	require.NoError(t, err)
+	assert.Equal(t, "petstore", name)
+	assert.Equal(t, filepath.Join(dir, "petstore.go"), file)
+
+	name, _, err = existingPackage(filepath.Join(dir, "missing"), nil)
+	require.NoError(t, err)
+	assert.Empty(t, name)
+}
+
+func TestModulePackageName(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "store")
+	writeFile(t, filepath.Join(root, "go.mod"), "module example.com/pet-store/v2\n\ngo 1.22\n")
+
+	name, err := modulePackageName(root)
+	require.NoError(t, err)
+	assert.Equal(t, "petstore", name, "the module root is named after the module path, without its major version")
+
+	name, err = modulePackageName(filepath.Join(root, "internal", "Pet-API"))
+	require.NoError(t, err)
+	assert.Equal(t, "petapi", name)
+
+	name, err = modulePackageName(t.TempDir())
+	require.NoError(t, err)
+	assert.Empty(t, name, "outside of a module")
+}
+
+func TestDetectPackageName(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "go.mod"), "module example.com/store\n\ngo 1.22\n")
+	writeFile(t, filepath.Join(dir, "api", "api.go"), "package api\n")
+
+	t.Run("detected from the other files in the output directory", func(t *testing.T) {
+		cfg := configuration{OutputFile: filepath.Join(dir, "api", "api.gen.go")}
+		require.NoError(t, detectPackageName(&cfg))
+		assert.Equal(t, "api", cfg.PackageName)
+	})
+
+	t.Run("detected from the output directory", func(t *testing.T) {
+		cfg := configuration{OutputFile: filepath.Join(dir, "pet_store", "api.gen.go")}
+		require.NoError(t, detectPackageName(&cfg))
+		assert.Equal(t, "pet_store", cfg.PackageName)
+	})
+
+	t.Run("conflicts with the other files in the output directory", func(t *testing.T) {
+		cfg := configuration{OutputFile: filepath.Join(dir, "api", "api.gen.go")}
+		cfg.PackageName = "petstore"
+		err := detectPackageName(&cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "the generated code would be in package `petstore`, but "+filepath.Join(dir, "api", "api.go")+" is in package `api`")
+	})
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
+)
+
+// applyProfile overlays the named profile onto the configuration, so that the profile's options replace those at the top level of the configuration file, which are shared by every profile
+func (c *configuration) applyProfile(name string) error {
+	profiles := c.Profiles
+	// A profile can't select another profile
+	c.Profiles = nil
+
+	if name == "" {
+		return nil
+	}
+
+	profile, ok := profiles[name]
+	if !ok {
+		names := codegen.SortedMapKeys(profiles)
+		if len(names) == 0 {
+			return fmt.Errorf("profile '%s' was selected, but the configuration doesn't define any `profiles`", name)
+		}
+		return fmt.Errorf("profile '%s' isn't defined in the configuration, which defines: %s", name, strings.Join(names, ", "))
+	}
+
+	buf, err := yaml.Marshal(profile)
+	if err != nil {
+		return fmt.Errorf("error marshaling profile '%s': %w", name, err)
+	}
+	if err := yaml.UnmarshalStrict(buf, c); err != nil {
+		return fmt.Errorf("error parsing profile '%s': %w", name, err)
+	}
+	c.Profiles = nil
+	return nil
+}
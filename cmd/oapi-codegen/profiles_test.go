@@ -0,0 +1,69 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+const profilesConfig = `
+package: api
+output-options:
+  skip-prune: true
+profiles:
+  types:
+    generate:
+      models: true
+    output: types.gen.go
+  server:
+    generate:
+      chi-server: true
+    output-options:
+      include-tags:
+        - pets
+    output: server.gen.go
+`
+
+func TestApplyProfile(t *testing.T) {
+	load := func(t *testing.T) configuration {
+		var c configuration
+		require.NoError(t, yaml.UnmarshalStrict([]byte(profilesConfig), &c))
+		return c
+	}
+
+	t.Run("the profile's options are merged with the shared options", func(t *testing.T) {
+		c := load(t)
+		require.NoError(t, c.applyProfile("server"))
+
+		assert.Equal(t, "api", c.PackageName)
+		assert.Equal(t, "server.gen.go", c.OutputFile)
+		assert.True(t, c.Generate.ChiServer)
+		assert.False(t, c.Generate.Models)
+		assert.True(t, c.OutputOptions.SkipPrune)
+		assert.Equal(t, []string{"pets"}, c.OutputOptions.IncludeTags)
+		assert.Nil(t, c.Profiles)
+	})
+
+	t.Run("without a profile, only the shared options are used", func(t *testing.T) {
+		c := load(t)
+		require.NoError(t, c.applyProfile(""))
+
+		assert.Equal(t, "api", c.PackageName)
+		assert.Empty(t, c.OutputFile)
+		assert.Nil(t, c.Profiles)
+	})
+
+	t.Run("an unknown profile is an error", func(t *testing.T) {
+		c := load(t)
+		err := c.applyProfile("client")
+		assert.EqualError(t, err, "profile 'client' isn't defined in the configuration, which defines: server, types")
+	})
+
+	t.Run("an unknown option in a profile is an error", func(t *testing.T) {
+		var c configuration
+		require.NoError(t, yaml.UnmarshalStrict([]byte("package: api\nprofiles:\n  types:\n    outptu: types.gen.go\n"), &c))
+		assert.Error(t, c.applyProfile("types"))
+	})
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"time"
+)
+
+// startProfiling starts writing a CPU profile to cpuProfile, with -cpuprofile, and returns a function to call once the code has been generated, which stops it, and writes a profile of the memory allocated to memProfile, with -memprofile. Either may be empty, to skip that profile
+func startProfiling(cpuProfile, memProfile string) (func() error, error) {
+	var cpuFile *os.File
+	if cpuProfile != "" {
+		f, err := os.Create(cpuProfile)
+		if err != nil {
+			return nil, fmt.Errorf("error creating CPU profile: %w", err)
+		}
+		if err := pprof.StartCPUProfile(f); err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("error starting CPU profile: %w", err)
+		}
+		cpuFile = f
+	}
+
+	return func() error {
+		var errs []error
+		if cpuFile != nil {
+			pprof.StopCPUProfile()
+			if err := cpuFile.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("error writing CPU profile: %w", err))
+			}
+		}
+		if memProfile != "" {
+			if err := writeMemProfile(memProfile); err != nil {
+				errs = append(errs, fmt.Errorf("error writing memory profile: %w", err))
+			}
+		}
+		return errors.Join(errs...)
+	}, nil
+}
+
+// writeMemProfile writes a profile of the memory allocated so far, and of what's still in use, to path, as `go test -memprofile` does
+func writeMemProfile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	// The profile is only up to date as of the last garbage collection
+	runtime.GC()
+	if err := pprof.Lookup("allocs").WriteTo(f, 0); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// verboseLogger returns a logger which writes the debug logs of loading the spec and generating code to w, with -verbose
+func verboseLogger(w io.Writer) *slog.Logger {
+	return slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
+
+// logTimings returns a function which logs how long each phase of loading the spec and generating code takes to logger, with -verbose
+func logTimings(logger *slog.Logger) func(phase string, elapsed time.Duration) {
+	return func(phase string, elapsed time.Duration) {
+		logger.Info("finished phase", "phase", phase, "elapsed", elapsed)
+	}
+}
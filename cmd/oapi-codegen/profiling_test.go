@@ -0,0 +1,40 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartProfiling(t *testing.T) {
+	dir := t.TempDir()
+	cpuProfile := filepath.Join(dir, "cpu.pprof")
+	memProfile := filepath.Join(dir, "mem.pprof")
+
+	stop, err := startProfiling(cpuProfile, memProfile)
+	require.NoError(t, err)
+	require.NoError(t, stop())
+
+	for _, path := range []string{cpuProfile, memProfile} {
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.NotZero(t, info.Size(), path)
+	}
+
+	t.Run("nothing is written without the flags", func(t *testing.T) {
+		stop, err := startProfiling("", "")
+		require.NoError(t, err)
+		require.NoError(t, stop())
+	})
+}
+
+func TestLogTimings(t *testing.T) {
+	var buf bytes.Buffer
+	logTimings(verboseLogger(&buf))("models", 1500*time.Millisecond)
+	assert.Contains(t, buf.String(), "level=INFO msg=\"finished phase\" phase=models elapsed=1.5s")
+}
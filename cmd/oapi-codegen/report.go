@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+// writeReport writes a JSON report of what would be generated for swagger to outputFile, or to stdout if it's empty
+func writeReport(swagger *openapi.T, cfg codegen.Configuration, outputFile string) error {
+	report, err := codegen.GenerateReport(swagger, cfg)
+	if err != nil {
+		return err
+	}
+	return writeJSON(outputFile, report)
+}
+
+// writeNamingReport writes a JSON report of the names which are changed when generating code for swagger to outputFile, or to stderr if it's `-`
+func writeNamingReport(swagger *openapi.T, cfg codegen.Configuration, outputFile string) error {
+	renamed, err := codegen.GenerateNamingReport(swagger, cfg)
+	if err != nil {
+		return err
+	}
+	return writeJSONOrStderr(outputFile, renamed)
+}
+
+// writeAPICompatReport writes a JSON report of the changes to the exported Go API of the generated code, from the code previously generated to the code generated now, to outputFile, or to stderr if it's `-`
+func writeAPICompatReport(previous, current []string, outputFile string) error {
+	changes, err := codegen.CompareGoAPI(previous, current)
+	if err != nil {
+		return err
+	}
+	return writeJSONOrStderr(outputFile, changes)
+}
+
+// previousCode returns the code previously generated to outputFile, if there is any, along with the code of any files its types were split out into with `one-file-per-type`
+func previousCode(outputFile string, oneFilePerType bool) ([]string, error) {
+	var code []string
+	if previous, err := os.ReadFile(outputFile); err == nil {
+		code = append(code, string(previous))
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("error reading %s: %w", outputFile, err)
+	}
+	if !oneFilePerType {
+		return code, nil
+	}
+
+	typeFiles, err := filepath.Glob(filepath.Join(filepath.Dir(outputFile), "*.gen.go"))
+	if err != nil {
+		return nil, fmt.Errorf("error listing the output directory: %w", err)
+	}
+	for _, typeFile := range typeFiles {
+		if filepath.Clean(typeFile) == filepath.Clean(outputFile) {
+			continue
+		}
+		previous, err := os.ReadFile(typeFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", typeFile, err)
+		}
+		if codegen.IsTypeFile(string(previous), outputFile) {
+			code = append(code, string(previous))
+		}
+	}
+	return code, nil
+}
+
+// writeJSONOrStderr writes v as indented JSON to outputFile, or to stderr if it's `-`
+func writeJSONOrStderr(outputFile string, v interface{}) error {
+	if outputFile != "-" {
+		return writeJSON(outputFile, v)
+	}
+	buf, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling as JSON: %w", err)
+	}
+	_, err = os.Stderr.Write(append(buf, '\n'))
+	return err
+}
+
+// writeJSON writes v as indented JSON to outputFile, or to stdout if it's empty
+func writeJSON(outputFile string, v interface{}) error {
+	buf, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling as JSON: %w", err)
+	}
+	buf = append(buf, '\n')
+
+	if outputFile == "" {
+		_, err = os.Stdout.Write(buf)
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(outputFile), 0o755); err != nil {
+		return fmt.Errorf("error unable to create directory: %w", err)
+	}
+	if err := os.WriteFile(outputFile, buf, 0o644); err != nil {
+		return fmt.Errorf("error writing to file: %w", err)
+	}
+	return nil
+}
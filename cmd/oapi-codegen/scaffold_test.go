@@ -0,0 +1,41 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+func TestWriteServerScaffold(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte("openapi: 3.0.0\ninfo:\n  title: Pets\n  version: 1.0.0\npaths:\n  /pets:\n    get:\n      operationId: listPets\n      responses:\n        '200':\n          description: OK\n"))
+	require.NoError(t, err)
+	cfg := codegen.Configuration{
+		PackageName: "api",
+		Generate:    codegen.GenerateOptions{ChiServer: true, ServerScaffold: true},
+	}
+	outputFile := filepath.Join(t.TempDir(), "api", "impl.gen.go")
+
+	require.NoError(t, writeServerScaffold(swagger, cfg, outputFile, false))
+	scaffold, err := os.ReadFile(outputFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(scaffold), "func (s *Server) ListPets(w http.ResponseWriter, r *http.Request)")
+
+	edited := []byte("package api\n\n// Server is implemented\ntype Server struct{}\n")
+	require.NoError(t, os.WriteFile(outputFile, edited, 0o644))
+	require.NoError(t, writeServerScaffold(swagger, cfg, outputFile, false))
+	scaffold, err = os.ReadFile(outputFile)
+	require.NoError(t, err)
+	assert.Equal(t, edited, scaffold, "an existing scaffold is never overwritten")
+
+	require.NoError(t, writeServerScaffold(swagger, cfg, outputFile, true))
+	scaffold, err = os.ReadFile(outputFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(scaffold), "// Server is implemented\ntype Server struct{}\n")
+	assert.Contains(t, string(scaffold), "func (s *Server) ListPets(w http.ResponseWriter, r *http.Request) {", "with update, the methods which aren't implemented yet are added")
+}
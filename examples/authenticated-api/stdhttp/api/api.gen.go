@@ -13,9 +13,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
 )
@@ -72,6 +74,9 @@ type Client struct {
 	// A list of callbacks for modifying requests which are generated before sending over
 	// the network.
 	RequestEditors []RequestEditorFn
+
+	// A logger which logs each request, along with its response.
+	Logger *slog.Logger
 }
 
 // ClientOption allows setting custom parameters during construction
@@ -118,6 +123,41 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 	}
 }
 
+// WithLogger logs each request, with its operation, method, path template,
+// parameters, status code and latency. The values of parameters which are
+// sensitive, as they're marked with `x-sensitive` or have the `password`
+// format, are redacted.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) error {
+		c.Logger = logger
+		return nil
+	}
+}
+
+// WithHeaders sets the given headers on every request, such as an API key
+// which every operation requires. Headers set by a request editor passed to
+// an operation take precedence.
+func WithHeaders(headers http.Header) ClientOption {
+	headers = headers.Clone()
+	return WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		for key, values := range headers {
+			req.Header.Del(key)
+			for _, value := range values {
+				req.Header.Add(key, value)
+			}
+		}
+		return nil
+	})
+}
+
+// WithUserAgent sets the User-Agent header of every request.
+func WithUserAgent(userAgent string) ClientOption {
+	return WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("User-Agent", userAgent)
+		return nil
+	})
+}
+
 // The interface specification for the client above.
 type ClientInterface interface {
 	// ListThings request
@@ -138,7 +178,7 @@ func (c *Client) ListThings(ctx context.Context, reqEditors ...RequestEditorFn)
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "ListThings", "/things", nil)
 }
 
 func (c *Client) AddThingWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -150,7 +190,7 @@ func (c *Client) AddThingWithBody(ctx context.Context, contentType string, body
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "AddThing", "/things", nil)
 }
 
 func (c *Client) AddThing(ctx context.Context, body AddThingJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -162,7 +202,7 @@ func (c *Client) AddThing(ctx context.Context, body AddThingJSONRequestBody, req
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "AddThing", "/things", nil)
 }
 
 // NewListThingsRequest generates requests for ListThings
@@ -232,6 +272,31 @@ func NewAddThingRequestWithBody(server string, contentType string, body io.Reade
 	return req, nil
 }
 
+// do sends the request, logging it with the Logger, if there is one. The
+// parameters of the request are only collected when they're logged.
+func (c *Client) do(req *http.Request, operationID, path string, params func() []slog.Attr) (*http.Response, error) {
+	if c.Logger == nil {
+		return c.Client.Do(req)
+	}
+	start := time.Now()
+	rsp, err := c.Client.Do(req)
+	attrs := []slog.Attr{
+		slog.String("operation", operationID),
+		slog.String("method", req.Method),
+		slog.String("path", path),
+		slog.Duration("latency", time.Since(start)),
+	}
+	if params != nil {
+		attrs = append(attrs, slog.Attr{Key: "params", Value: slog.GroupValue(params()...)})
+	}
+	if err != nil {
+		c.Logger.LogAttrs(req.Context(), slog.LevelError, "request failed", append(attrs, slog.Any("error", err))...)
+		return nil, err
+	}
+	c.Logger.LogAttrs(req.Context(), slog.LevelInfo, "request", append(attrs, slog.Int("status", rsp.StatusCode))...)
+	return rsp, nil
+}
+
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
 	for _, r := range c.RequestEditors {
 		if err := r(ctx, req); err != nil {
@@ -273,6 +338,29 @@ func WithBaseURL(baseURL string) ClientOption {
 	}
 }
 
+// ParseAs reads and closes the body of an HTTP response, decoding it as JSON
+// into a T. This is useful for responses which the typed fields of a
+// response don't cover, such as for a status code which isn't documented.
+func ParseAs[T any](rsp *http.Response) (T, error) {
+	var dest T
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return dest, err
+	}
+	return ResponseAs[T](bodyBytes)
+}
+
+// ResponseAs decodes the Body of a response returned by ClientWithResponses
+// as JSON into a T, such as `ResponseAs[Error](resp.Body)`.
+func ResponseAs[T any](body []byte) (T, error) {
+	var dest T
+	if err := json.Unmarshal(body, &dest); err != nil {
+		return dest, err
+	}
+	return dest, nil
+}
+
 // ClientWithResponsesInterface is the interface specification for the client with responses above.
 type ClientWithResponsesInterface interface {
 	// ListThingsWithResponse request
@@ -594,17 +682,18 @@ func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.H
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/8RUwW7bOBD9lcHsAnsRbCdZ7EE3B8kCDgq0aA3kEAcII44ttjLJDEdxjUD/XpCUHDVO",
-	"0/bUiy2Swzfz3rzhE1Zu650lKwHLJwxVTVuVPi+ZHccPz84Ti6G0XTlN8V9TqNh4Mc5imYMhnRW4drxV",
-	"giUaK2enWKDsPeUlbYixK3BLIajND4GG48PVIGzsBruuQKaH1jBpLG+wTziE33YFLusYeFS2VduU7W28",
-	"FHVAuTZSLy7iLdU079dY3jzh30xrLPGv6bNu0160aU7dFS9zGx1/x6r89+8rqryoxWi87W7jbqCqZSP7",
-	"TzFPhjwnxcTzVuq4uk+r/4cEV9dLLHIrY4J8+pywFvHYRWBj1+64BXML9FVtfUMw/7CAXW2qGtpAATIS",
-	"iPtCFkLlPAVQVsPV9RJUrKVAMdLEJLE0smIqJaQTzmXGxAIfiUNOdTKZTWbRD86TVd5giWdpq0CvpE5U",
-	"pxJlTZ8bkuNyP5K0bAMoaEwQcGvIFyZwTpVqA8V1ALLaO2MFtKNg/xFwj8RsdDymld007l41MEhdgBHo",
-	"uxGhI8O148Syp2Wcnawspto5LRcaS3xngixzxbGfwTsbcs9OZ7M8QFbIJiLK+6aHmn4Okc0wgck2Qtt0",
-	"8aee643aHVqsmNU+9/h7sV6KlGO8C68IO9c6Uk+BIC7qdCTxcixtOGgaUnDWdGUHUSFbMllmpO1dBit3",
-	"d9lTYCw41slo4Inj4IBa2R0bodckn2udRy8PEAU5d3r/W1r/wlgfizl/1sbYQCwTGMwY6ec90n3UzkgN",
-	"ysLiAseDLtxSd+SUkz/ulOWYwXLEAFprHlqKPMaPU3odx8/SDQ59ze/Ym7Ex4lsAAAD//9UBNUSMBgAA",
+	"H4sIAAAAAAAC/7xUwW7bMAz9FYIbsIuRpO2wg28p2gEpBmzYAvTQBKhqMbE2R1IpulkQ5N8HSUnjxgW6",
+	"XZaLFYt8fHx89BadJ6u8wRIvBqPBCAs0duGw3OITcTDOYoln+xsx0hCWOG6lJiumUkIaxt8mcP1brXxD",
+	"WKCmULHxkjPHFihfpbB1baoa2kABHkgxMYj7RRZC5TwFUFbDze0UVCs17gr0SuoQqQylNnaZjkuS+HCe",
+	"WMUiE40lfjFBpjnklMF3kpZtAAWNCQJuARlrAJdUqTZQ/B+ArPbOWAHtKNgPAu6JmI2O1zSzy8Y9qAYC",
+	"VS0b2RRgBJgeW8ORdiK9cAzqKIxxdjCzWCBT8M4GSuzPR6P4eEnxlBoWWDkrZFOnyvtmDzj8GWLCFkNV",
+	"00rFk2w8RQhmtYmzE1qlQu+ZFljiu2HlVt5ZshKGOSsMk1K3RurJFe7yr0DvwivCjrVO0f3Bah0bT4RB",
+	"XFSpJ/C0K2x4VjSk4KzozB4khcSOkgc6yt5nsHJ9n00CxoJjnZwDnnjheAVqZtdshLLgB0Qs77Z4mWwW",
+	"DYvlHR7AcL6bx8k8thTk0ulNfyjjY2/GBmIZwMFKkX5+R3oftTZSg7IwucKMa5g0lsIt/cMw3xwa5lmd",
+	"WOqsz37apTjtUITWmseWMtH/7bLI/Rh5LJGOucFyi56jB8Xk9qxaUYdCEM46dGW+y1HzXYHdqrGtpvm6",
+	"SEb4C22L09pGdyobK7QkxgKj55TkV58+9rgYHe21K/Ca2XFE6N5WThMWuKIQ1JJwXpzUTPdvVb047y1k",
+	"KgYpe3eE7wn3etohPI/osEA/0kYmVt01eoasRXzctxSGJeZPOhb7w+cD4ZvbKb4A7m9mFOzPAAoQf8aM",
+	"BgAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -8,9 +8,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 )
 
 // UpdateClientResponse defines model for UpdateClientResponse.
@@ -43,6 +45,9 @@ type Client struct {
 	// A list of callbacks for modifying requests which are generated before sending over
 	// the network.
 	RequestEditors []RequestEditorFn
+
+	// A logger which logs each request, along with its response.
+	Logger *slog.Logger
 }
 
 // ClientOption allows setting custom parameters during construction
@@ -89,6 +94,41 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 	}
 }
 
+// WithLogger logs each request, with its operation, method, path template,
+// parameters, status code and latency. The values of parameters which are
+// sensitive, as they're marked with `x-sensitive` or have the `password`
+// format, are redacted.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) error {
+		c.Logger = logger
+		return nil
+	}
+}
+
+// WithHeaders sets the given headers on every request, such as an API key
+// which every operation requires. Headers set by a request editor passed to
+// an operation take precedence.
+func WithHeaders(headers http.Header) ClientOption {
+	headers = headers.Clone()
+	return WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		for key, values := range headers {
+			req.Header.Del(key)
+			for _, value := range values {
+				req.Header.Add(key, value)
+			}
+		}
+		return nil
+	})
+}
+
+// WithUserAgent sets the User-Agent header of every request.
+func WithUserAgent(userAgent string) ClientOption {
+	return WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("User-Agent", userAgent)
+		return nil
+	})
+}
+
 // The interface specification for the client above.
 type ClientInterface interface {
 	// UpdateClient request
@@ -104,7 +144,7 @@ func (c *Client) UpdateClient(ctx context.Context, reqEditors ...RequestEditorFn
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "UpdateClient", "/client", nil)
 }
 
 // NewUpdateClientRequest generates requests for UpdateClient
@@ -134,6 +174,31 @@ func NewUpdateClientRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
+// do sends the request, logging it with the Logger, if there is one. The
+// parameters of the request are only collected when they're logged.
+func (c *Client) do(req *http.Request, operationID, path string, params func() []slog.Attr) (*http.Response, error) {
+	if c.Logger == nil {
+		return c.Client.Do(req)
+	}
+	start := time.Now()
+	rsp, err := c.Client.Do(req)
+	attrs := []slog.Attr{
+		slog.String("operation", operationID),
+		slog.String("method", req.Method),
+		slog.String("path", path),
+		slog.Duration("latency", time.Since(start)),
+	}
+	if params != nil {
+		attrs = append(attrs, slog.Attr{Key: "params", Value: slog.GroupValue(params()...)})
+	}
+	if err != nil {
+		c.Logger.LogAttrs(req.Context(), slog.LevelError, "request failed", append(attrs, slog.Any("error", err))...)
+		return nil, err
+	}
+	c.Logger.LogAttrs(req.Context(), slog.LevelInfo, "request", append(attrs, slog.Int("status", rsp.StatusCode))...)
+	return rsp, nil
+}
+
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
 	for _, r := range c.RequestEditors {
 		if err := r(ctx, req); err != nil {
@@ -175,6 +240,29 @@ func WithBaseURL(baseURL string) ClientOption {
 	}
 }
 
+// ParseAs reads and closes the body of an HTTP response, decoding it as JSON
+// into a T. This is useful for responses which the typed fields of a
+// response don't cover, such as for a status code which isn't documented.
+func ParseAs[T any](rsp *http.Response) (T, error) {
+	var dest T
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return dest, err
+	}
+	return ResponseAs[T](bodyBytes)
+}
+
+// ResponseAs decodes the Body of a response returned by ClientWithResponses
+// as JSON into a T, such as `ResponseAs[Error](resp.Body)`.
+func ResponseAs[T any](body []byte) (T, error) {
+	var dest T
+	if err := json.Unmarshal(body, &dest); err != nil {
+		return dest, err
+	}
+	return dest, nil
+}
+
 // ClientWithResponsesInterface is the interface specification for the client with responses above.
 type ClientWithResponsesInterface interface {
 	// UpdateClientWithResponse request
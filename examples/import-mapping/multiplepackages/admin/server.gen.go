@@ -16,6 +16,9 @@ import (
 type ServerInterface interface {
 	// Get a user's details
 	// (GET /admin/user/{id})
+	//
+	// Tagged `admin`: Admin API endpoints
+	// Tagged `user`: API endpoint that pertains to user data
 	GetUserById(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
 }
 
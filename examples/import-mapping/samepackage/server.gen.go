@@ -19,6 +19,9 @@ import (
 type ServerInterface interface {
 	// Get a user's details
 	// (GET /admin/user/{id})
+	//
+	// Tagged `admin`: Admin API endpoints
+	// Tagged `user`: API endpoint that pertains to user data
 	GetUserById(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
 }
 
@@ -207,6 +210,9 @@ func (response GetUserById200JSONResponse) VisitGetUserByIdResponse(w http.Respo
 type StrictServerInterface interface {
 	// Get a user's details
 	// (GET /admin/user/{id})
+	//
+	// Tagged `admin`: Admin API endpoints
+	// Tagged `user`: API endpoint that pertains to user data
 	GetUserById(ctx context.Context, request GetUserByIdRequestObject) (GetUserByIdResponseObject, error)
 }
 
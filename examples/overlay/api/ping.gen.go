@@ -261,16 +261,14 @@ func HandlerWithOptions(si ServerInterface, options GorillaServerOptions) http.H
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/6xVTW/bMAz9KwK3wwakUdbdfMvQYS0wLMbanoZikG0mVmGTmkT3Y4X/+yDZbrPWKwqs",
-	"J8f0ox4fHxndQcmtY0KSANkdhLLG1qSfOdMuPp1nh14spqizQxRvTOsahAxcxC1Abl18C+Ijol9AQKwi",
-	"ssJQeuvEMkEGZzWq+EVt2SupUVkS9GQa5Q1V3BKGsFSnx5vzr0fq2+ZMFajwxjXGElZKWHUBfXjgo64t",
-	"0Ee+K/QFB4yU47eCuUFDsICbg4kGMvEd9v0CPP7qrI81/hhUXdwfysUllgJ9hFnacjrTSpL7eRAeS7FU",
-	"2dIIqpqvx9KSpI1DWucnanOFvjG3Kjgs7TZCLZN6V4u4kGm9s1J3xbLkVm/WJ3pEH5zuo99DEhaG5n1Y",
-	"rparqJUdknEWMviYQgtwRupkkDZVa0mbTjiUphnt2qHER3QynXtSQQZfUNYRvN7Dxr4ExxQGuw9Xq/go",
-	"mQQpHWGca8bi9GWIZU1T83RYLAUxVA4vjwx75MADdN6Gv6copYvZhSF1tPYiRkf9aUy0x4By4EwI1+zT",
-	"NLpuphF5NzTiPCZ9jzn5lDJUiUE+cXX7H60glp8Vl12LJMNebNm3RiCDrrPVkwWamdkFRFFk2v0Zn/bt",
-	"UTvvkXPd7F/V5FdRtm/R88rukS+ck36WT9doGql/P7cbxyNkvlsvJZr+MCtsUPAp0VGK57O7dzhLNMvz",
-	"LxH5Kyz1W49byOCNfrgr9HhR6HRLzHTeoaiJNM1c3/8JAAD//3tzzkZsBgAA",
+	"H4sIAAAAAAAC/1xSzW7bPBB8FWK+79ACsunE6IW3oCgKowcbaG9BDgy1tphKXJZcpQ4Ev3uxktu00YUr",
+	"zi45P5zAmZLPEQ7b9Wa9QYOYjgw34ZlKjZzgcHNFJEpPcPh09kPuyQibmNoYvJDp+Kf+j5WMdGT2mdLd",
+	"YWf2z1R6/2JqphCP2ho5mXedSK7O2lOUbnxcBx7s/m5nr92rr393v0eD84qvkM+5j9TCoUoZg4yF2t8g",
+	"Lg2yl64qfZtjOmlxItGlUM2cKs3g7WajS0s1lJhlkXmgcuQyGG901BT6MVIVNAichNJ8ynz9wss+VR2b",
+	"UENHg9fq/0JHOPxnAw+ZEyWpdkGrPXA64aJfg0pF3YW7nzCWHg5qiLO25+D7jqu47Yeb2y2aNxS/dWRa",
+	"OvqxF7Mcssbl4W3Xx47CdyOdlzkLzSFWU8aUVNf+CxqNvcwqdmrlZ5JDnOk1sL4dYrJ+FK7B94uJCrxq",
+	"ehU9l7MyN0FeMsGBH58oqG1qYCya1T3mMB4a5KI3S1xiyPGfySpFNxrQ8sDgkPlK67w68Sr5QTf1mZTY",
+	"tpT+mPprAF39LjnJAgAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
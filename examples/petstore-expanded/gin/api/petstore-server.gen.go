@@ -172,34 +172,33 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+RXW48byc39K0R932OnNbEXedBTvB4vICBrT+LdvKzngdNNSVzUpafI0lgY6L8HrG7d",
-	"RvJsggRBgrzo0l3sOjznkMV+dl0KQ4oUVdz82Um3poD154ecU7YfQ04DZWWql7vUk333JF3mQTlFNx8X",
-	"Q73XuGXKAdXNHUd9+8Y1TrcDjX9pRdntGhdIBFfffND+9iFUNHNcud2ucZkeC2fq3fwXN224X36/a9xH",
-	"erojvcQdMVzZ7iMGgrQEXRMMpJcbNk5xdRn303Z4Pe4F0Lq7wZuwofeflm7+y7P7/0xLN3f/NzsKMZtU",
-	"mE257JqXyXB/CennyI+FgPtzXKdi/OG7K2K8QMq9u9/d7+wyx2UaJY+KXcVNAdm7ucOBlTD8UZ5wtaLc",
-	"cnLNRLH7PF6Dd3cL+IkwuMaVbEFr1UHms9lJ0K55kcU7EAyDpxqta1QoQgJo2YimTIACGIG+jss0QU8h",
-	"RdGMSrAk1JJJgGPl4NNA0Z70tr0BGajjJXdYt2qc546i0NEc7t2A3ZrgTXtzgfnp6anFertNeTWbYmX2",
-	"p8X7Dx8/f/jdm/amXWvw1TGUg3xafqa84Y6uJj6ra2YmB6s/Ze1uytM1bkNZRlZ+3960N/boNFDEgd3c",
-	"va2XGjegrqsnZsaQ/ViNFjvn9S+kJUcB9L5SCcucQqVItqIURq7tfxHKsDaWu45EQNOX+BEDCPXQpdhz",
-	"oKglAIm28CNSRxEFlMKQMgiuWJUFBAem2ECkDvI6xa4ICIWTBayAgbSFdxQJI6DCKuOGewQsq0INYAeM",
-	"XfFcQ1t4XzI+sJYMqecEPmUKDaQcMRPQihTI04QuUtdAV7IUsZLw1GmRFm4LCwQGLXlgaWAofsMRs+1F",
-	"OVnSDSjHjvsSFTaYuQj8WkRTC4sIa+xgbSBQhGDwqITQc6clGB2LsagsF+x5YOk4rgCjWjbH3D2visdD",
-	"5sMaM2nGPYm2HkLyJMoEHAbKPRtTf+UNhjEh9PxYMEDPaMxkFHi03DbkWSGmCJqypmyU8JJif9i9hbuM",
-	"JBTVYFLkcARQckTYJF90QIUNRYpogEdy7SNgyfaMRTw+eUl5Yn2JHXuWs03qDvbRHPXtQFKPnkzYvjEe",
-	"O8qolph9t/C5yECxZ2PZo5mnTz7lxhwo1Km5uWZZrWJZN7ChNXfFI1hry30J4PmBcmrhx5QfGKiwhNSf",
-	"ymC3q7E9dhwZ2y/xS/xMfVWiCCzJzOfTQ8o1gNLRMbloLqEFq42A9YET+Sy+ASpn1TJKDr6YD82dLdyt",
-	"Ucj7sTAGylN4pbnKSwpLLB0/lJFw3O9j607jN+Qn6XhDOWNzvrXVCXDfHAox8sO6hZ8VBvKeopLYyTEk",
-	"KWSVtC+iFowK3FeBFd2ey/2T9mlVJpsK5GCLWGIHmlm0HkwbVqQWfijSEZDWbtAXPlSBdQrpyFPmCmf0",
-	"7z4gmFsKVvN0JQhGCLiylMlParXw5zKGhuRNt1E9KqN3jlCaQ/MBLJ0VybhysueY9mSOqckcqtHMYgID",
-	"x+YIZSrcyMJ7wGIYOtbSs0EVQSi699kk5LjTGWl1vxbuToWpzE0Yh0zKJZx0rtE0pTnxt7Xe9oudcTY0",
-	"1PNu0bu5+4Fjb+dLPTayEUBZ6hRyflgorqzvw5K9UoaHrbNhwM3dY6G8PZ70ts6djg9L9ELNNEXWQUUp",
-	"1EPpcqwaL2DOuLX/ott6Dtq8Uieec0gBv3Kwvl7CA2UbcTJJ8Vpx5nq4fQOk58D6OsrfHFh39xYvgzWf",
-	"ms6bm5v9ZERxnOiGwU+zxexXMczP13h4bdwbZ70XzOwuRqSBFPZgxgFqicXrP4TnNRjj4H9l4xLp62DN",
-	"17r0uKZxUkLAvL0yYhi2IcmVYeR9JtQ61UV6srX7ca1OPnZKj9htiU183qcn6i/s/K43N0/Skuj3qd/+",
-	"y1jYz96XNNyRmumw7+3rAPvMYpoL7f5Jz/ymVf57rHEheL1fJ9bZM/e70SKe9Mor2njdYoXjytf3GnhA",
-	"a8RpdM3iFqRYTlc8clujR5u82vMWt9ZUhlHbCcvUUGzEPvYT7i+U/lYvuf6+ddlLvrvM2oCMKPr/JCFv",
-	"D2JUFbawuDV4r79ynCt20HFx+60D6vttvff367Uk7db/Nrn+Z8v4haKj+nUJ5c1epvP35v1re3vy7msv",
-	"sLv73d8CAAD//1ZpQth8EgAA",
+	"H4sIAAAAAAAC/9xXTXMbyQ39K6hOjpOh4t3Kgad4LW8Vq7K2Eu/mst4D1AOS2OqPcQNNWaXif0+hZ/gh",
+	"UfYqlZx8IakZoPGA94CGHlweKeHIbum+66/6K9c5Tuvslg9uR0U4J7d0f53fKGsgt3Qf7nCzoQI3pKK5",
+	"kOvcQOILjzo5vAbBOAaC1zcr0C0qVCEBhHH2ABTABPR5MtMMA8WcRAsqwZpQayEBTqBbgvcjJTvpu/4K",
+	"ZCTPa/bYQnVOqUR5v/5AZcfewG1VR1kuFjKB7Dkvms3Cdc7npOjVsksYz1Ox438mjK5zFJGDWzocWQnj",
+	"308Huc7VEp6N4fadC+wpCZ2d/npEvyV41V9duN7d3fXYXve5bBazryz+sXrz9t2Ht3951V/1W43B7fed",
+	"EyrGhlv++vDkmENB+7N0jc79b50bUbdiaJqV/dhQy11qjFju3dL9i7SWJIAhNG4umHxqAOuSYyNF7kUp",
+	"Tuza31WowNZ49Z5EQPPH9A4jCA3gcxo4UtIagUR7+AnJU0IBpTjmAoIbVmUBwZEpdZDIQ9nm5KuAUDwz",
+	"YAWMpD28pkSYABU2BXc8IGDdVOoAPTD6Gri59vCmFrxlrQXywBlCLhQ7yCVhIaANKVCgGV0i34GvRaoA",
+	"DxDIa5UerisLRAatZWTpYKxhxwmLxaKSLekOlJPnoSaFHRauAr9X0dzDKsEWPWwNBIoQjAGVEAb2WqOV",
+	"Y5WUTIWogAOPLJ7TBjCpZXPKPfCmBjxmPm6xkBY8FNHsIeZAokzAcaQysFXq37zDOCWEgT9VjDAwWmUK",
+	"Cnyy3HYUWCHlBJqL5mIl4TWl4Ri9h5uCJJTUYFLieAJQS0LY5VB1RIUdJUpogKfi2kfEWuyMVTqdvKYy",
+	"V32NngPLoyAtgn10J349SB4wkBE7dFZHTwXVErPvHj5UGSkNbFUOaOIZcsilMwUKeTU1tyybVCzrDna0",
+	"ZV8DAielMtQIgW+p5B5+yuWWgSpLzMM5Dfa6CTug58TYf0wf0wcaGhNVYE0mvpBvc2kOlE+KKVVLjT1Y",
+	"b0RUPRWfJXRA9VG3TJRDqKZDU2cPN1sUCmFqjJHK7N7K3OglhTVWz7d1Kjge4pjduf+Owkwd76gU7B6H",
+	"tj4BHrpjIya+3fbwi8JIIVBSkk+VYMxSqdCpiXqwUuChC6zpDrU8nHRIq1Wya0COskg1edDCopYL7FiR",
+	"evixiicgbdNgqHzsApsU4ilQ4QZn0u/BIZpaKjbx+BoFE0TcWMoUZrZ6+GedXGMOgQ/sUZ20c4LSHYcP",
+	"YPXWJJPlLM8p7Vkc85A5dqOJxQgGTt0Jyty4iYUPgMUweNY6sEEVQah60NlM5BTpUdFavB5uzolplZsx",
+	"joWUazybXJNoanembxu9/Ue7VfNo/cQ5rQa3dD9yGm6m22HEYgU4XEbzPae4kbY5uKX7VKncX9wjZmHX",
+	"/JqDUoFbsyj0qXKhwS3XGIQ6J3rf9ot1LnYRi99SRLuw9H6051gKmiMrRTl7Llo4bdx+v++OkAJH1q9j",
+	"iviZo838Gm+pQF5DIalBG9DSLr5nUT6FxdP0dl0Djjo9+u6V29stXEhGGz4N8EBrrEGnn+dYaqLPI3kl",
+	"m2Qll3lZodRscRzDvPIsfhdzeDiD8edCa7d0f1r4HMecKKkspreyeNsO21tp3Kurq8vAIykcIP43Qb9E",
+	"ydfA3JA2KA3NmOXJMvKmEGpbExPdwUh6wdiFxWFDnHYguK4TZDMpZEtLvqPhQs+vB5PzzC2J/pCH+8vK",
+	"3JCaEHAY7OsY5ZEktFT6PzH1ju4O9flmRfNicbSNdfHAw/7ra2vTwO09rK6/vLlONmgT2C5+EE6bQJPL",
+	"s3Puh/vV9RdHHQ+HoWLr9UXU1bXNkXGSzprUb5/RywsnyN++/6YnyMvEYAUOpPRYANft2dcFMBxtZsYf",
+	"6cD6eXUNUg3jMzNiijCNif9dCXMO35QUvn9eClOqg5vZOx1zitN+3kxNjSG8X7eyvmQ4dg9nFfzVKLD/",
+	"c4tRpzxVhYeXVPMpW7+ktmzyYKSZNsZJgb/tOzcHXz6O3VRwEb09vdhMnkZ7h5EeBepsQfpjv5/vR3oC",
+	"sHMTK0/Q+TyQ61wkEdw8g7O9f8EK8xRBCwbNe386/g+BT24H86mz/zMA5Rs1LHwSAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
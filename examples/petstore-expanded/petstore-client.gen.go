@@ -9,13 +9,24 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/oapi-codegen/runtime"
 )
 
+// Error defines model for Error.
+type Error struct {
+	// Code Error code
+	Code int32 `json:"code"`
+
+	// Message Error message
+	Message string `json:"message"`
+}
+
 // NewPet defines model for NewPet.
 type NewPet struct {
 	// Name Name of the pet
@@ -76,6 +87,9 @@ type Client struct {
 	// A list of callbacks for modifying requests which are generated before sending over
 	// the network.
 	RequestEditors []RequestEditorFn
+
+	// A logger which logs each request, along with its response.
+	Logger *slog.Logger
 }
 
 // ClientOption allows setting custom parameters during construction
@@ -122,6 +136,73 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 	}
 }
 
+// WithLogger logs each request, with its operation, method, path template,
+// parameters, status code and latency. The values of parameters which are
+// sensitive, as they're marked with `x-sensitive` or have the `password`
+// format, are redacted.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) error {
+		c.Logger = logger
+		return nil
+	}
+}
+
+// WithHeaders sets the given headers on every request, such as an API key
+// which every operation requires. Headers set by a request editor passed to
+// an operation take precedence.
+func WithHeaders(headers http.Header) ClientOption {
+	headers = headers.Clone()
+	return WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		for key, values := range headers {
+			req.Header.Del(key)
+			for _, value := range values {
+				req.Header.Add(key, value)
+			}
+		}
+		return nil
+	})
+}
+
+// WithUserAgent sets the User-Agent header of every request.
+func WithUserAgent(userAgent string) ClientOption {
+	return WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("User-Agent", userAgent)
+		return nil
+	})
+}
+
+// findPetsLogAttrs returns the parameters of a FindPets
+// request, which are logged along with it.
+func findPetsLogAttrs(params *FindPetsParams) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	if params == nil {
+		return logAttrs_
+	}
+	if params.Tags != nil {
+		logAttrs_ = append(logAttrs_, slog.Any("tags", *params.Tags))
+	}
+	if params.Limit != nil {
+		logAttrs_ = append(logAttrs_, slog.Any("limit", *params.Limit))
+	}
+	return logAttrs_
+}
+
+// deletePetLogAttrs returns the parameters of a DeletePet
+// request, which are logged along with it.
+func deletePetLogAttrs(id int64) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	logAttrs_ = append(logAttrs_, slog.Any("id", id))
+	return logAttrs_
+}
+
+// findPetByIDLogAttrs returns the parameters of a FindPetByID
+// request, which are logged along with it.
+func findPetByIDLogAttrs(id int64) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	logAttrs_ = append(logAttrs_, slog.Any("id", id))
+	return logAttrs_
+}
+
 // The interface specification for the client above.
 type ClientInterface interface {
 	// FindPets request
@@ -148,7 +229,7 @@ func (c *Client) FindPets(ctx context.Context, params *FindPetsParams, reqEditor
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "FindPets", "/pets", func() []slog.Attr { return findPetsLogAttrs(params) })
 }
 
 func (c *Client) AddPetWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -160,7 +241,7 @@ func (c *Client) AddPetWithBody(ctx context.Context, contentType string, body io
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "AddPet", "/pets", nil)
 }
 
 func (c *Client) AddPet(ctx context.Context, body AddPetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -172,7 +253,7 @@ func (c *Client) AddPet(ctx context.Context, body AddPetJSONRequestBody, reqEdit
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "AddPet", "/pets", nil)
 }
 
 func (c *Client) DeletePet(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -184,7 +265,7 @@ func (c *Client) DeletePet(ctx context.Context, id int64, reqEditors ...RequestE
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "DeletePet", "/pets/{id}", func() []slog.Attr { return deletePetLogAttrs(id) })
 }
 
 func (c *Client) FindPetByID(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -196,7 +277,7 @@ func (c *Client) FindPetByID(ctx context.Context, id int64, reqEditors ...Reques
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "FindPetByID", "/pets/{id}", func() []slog.Attr { return findPetByIDLogAttrs(id) })
 }
 
 // NewFindPetsRequest generates requests for FindPets
@@ -314,6 +395,7 @@ func NewDeletePetRequest(server string, id int64) (*http.Request, error) {
 	if err != nil {
 		return nil, err
 	}
+	pathParam0 = escapeDotSegments(pathParam0)
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
@@ -348,6 +430,7 @@ func NewFindPetByIDRequest(server string, id int64) (*http.Request, error) {
 	if err != nil {
 		return nil, err
 	}
+	pathParam0 = escapeDotSegments(pathParam0)
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
@@ -372,6 +455,31 @@ func NewFindPetByIDRequest(server string, id int64) (*http.Request, error) {
 	return req, nil
 }
 
+// do sends the request, logging it with the Logger, if there is one. The
+// parameters of the request are only collected when they're logged.
+func (c *Client) do(req *http.Request, operationID, path string, params func() []slog.Attr) (*http.Response, error) {
+	if c.Logger == nil {
+		return c.Client.Do(req)
+	}
+	start := time.Now()
+	rsp, err := c.Client.Do(req)
+	attrs := []slog.Attr{
+		slog.String("operation", operationID),
+		slog.String("method", req.Method),
+		slog.String("path", path),
+		slog.Duration("latency", time.Since(start)),
+	}
+	if params != nil {
+		attrs = append(attrs, slog.Attr{Key: "params", Value: slog.GroupValue(params()...)})
+	}
+	if err != nil {
+		c.Logger.LogAttrs(req.Context(), slog.LevelError, "request failed", append(attrs, slog.Any("error", err))...)
+		return nil, err
+	}
+	c.Logger.LogAttrs(req.Context(), slog.LevelInfo, "request", append(attrs, slog.Int("status", rsp.StatusCode))...)
+	return rsp, nil
+}
+
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
 	for _, r := range c.RequestEditors {
 		if err := r(ctx, req); err != nil {
@@ -386,6 +494,56 @@ func (c *Client) applyEditors(ctx context.Context, req *http.Request, additional
 	return nil
 }
 
+// escapePathParam escapes the value of a path parameter, so that it's sent as
+// the parameter in the path of a request. The value is escaped with
+// url.PathEscape, so that a `/`, `%`, `?` or unicode character in it is
+// percent-encoded, unless allowReserved is set, for a parameter with
+// `allowReserved: true`, when the reserved characters of RFC 3986, such as `/`,
+// and the percent-encoded octets in it are kept as they are, as they are by the
+// reserved expansion of RFC 6570.
+func escapePathParam(value string, allowReserved bool) string {
+	if allowReserved {
+		return escapeDotSegments(escapeReservedPathParam(value))
+	}
+	return escapeDotSegments(url.PathEscape(value))
+}
+
+// escapeDotSegments percent-encodes the segments of an escaped path parameter
+// which are `.` or `..`, so that they aren't removed from the path of a
+// request as dot-segments.
+func escapeDotSegments(escaped string) string {
+	segments := strings.Split(escaped, "/")
+	for i, segment := range segments {
+		if segment == "." || segment == ".." {
+			segments[i] = strings.ReplaceAll(segment, ".", "%2E")
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// escapeReservedPathParam percent-encodes the characters of value which can't
+// be in the path of a URL as they are, keeping its reserved characters and
+// percent-encoded octets, apart from `?` and `#`, which would end the path.
+func escapeReservedPathParam(value string) string {
+	const hexDigits = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', strings.IndexByte("-._~:/@!$&'()*+,;=[]", c) >= 0:
+			b.WriteByte(c)
+		case c == '%' && i+2 < len(value) && strings.IndexByte("0123456789ABCDEFabcdef", value[i+1]) >= 0 && strings.IndexByte("0123456789ABCDEFabcdef", value[i+2]) >= 0:
+			b.WriteString(value[i : i+3])
+			i += 2
+		default:
+			b.WriteByte('%')
+			b.WriteByte(hexDigits[c>>4])
+			b.WriteByte(hexDigits[c&15])
+		}
+	}
+	return b.String()
+}
+
 // ClientWithResponses builds on ClientInterface to offer response payloads
 type ClientWithResponses struct {
 	ClientInterface
@@ -413,6 +571,29 @@ func WithBaseURL(baseURL string) ClientOption {
 	}
 }
 
+// ParseAs reads and closes the body of an HTTP response, decoding it as JSON
+// into a T. This is useful for responses which the typed fields of a
+// response don't cover, such as for a status code which isn't documented.
+func ParseAs[T any](rsp *http.Response) (T, error) {
+	var dest T
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return dest, err
+	}
+	return ResponseAs[T](bodyBytes)
+}
+
+// ResponseAs decodes the Body of a response returned by ClientWithResponses
+// as JSON into a T, such as `ResponseAs[Error](resp.Body)`.
+func ResponseAs[T any](body []byte) (T, error) {
+	var dest T
+	if err := json.Unmarshal(body, &dest); err != nil {
+		return dest, err
+	}
+	return dest, nil
+}
+
 // ClientWithResponsesInterface is the interface specification for the client with responses above.
 type ClientWithResponsesInterface interface {
 	// FindPetsWithResponse request
@@ -434,6 +615,7 @@ type FindPetsResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
 	JSON200      *[]Pet
+	JSONDefault  *Error
 }
 
 // Status returns HTTPResponse.Status
@@ -456,6 +638,7 @@ type AddPetResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
 	JSON200      *Pet
+	JSONDefault  *Error
 }
 
 // Status returns HTTPResponse.Status
@@ -477,6 +660,7 @@ func (r AddPetResponse) StatusCode() int {
 type DeletePetResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
+	JSONDefault  *Error
 }
 
 // Status returns HTTPResponse.Status
@@ -499,6 +683,7 @@ type FindPetByIDResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
 	JSON200      *Pet
+	JSONDefault  *Error
 }
 
 // Status returns HTTPResponse.Status
@@ -582,6 +767,13 @@ func ParseFindPetsResponse(rsp *http.Response) (*FindPetsResponse, error) {
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
 	}
 
 	return response, nil
@@ -608,6 +800,13 @@ func ParseAddPetResponse(rsp *http.Response) (*AddPetResponse, error) {
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
 	}
 
 	return response, nil
@@ -626,6 +825,19 @@ func ParseDeletePetResponse(rsp *http.Response) (*DeletePetResponse, error) {
 		HTTPResponse: rsp,
 	}
 
+	switch {
+	case rsp.StatusCode == 204:
+		break // No content-type
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
 	return response, nil
 }
 
@@ -650,6 +862,13 @@ func ParseFindPetByIDResponse(rsp *http.Response) (*FindPetByIDResponse, error)
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
 	}
 
 	return response, nil
@@ -15,6 +15,7 @@ require (
 	golang.org/x/tools v0.35.0
 	gopkg.in/yaml.v2 v2.4.0
 	gopkg.in/yaml.v3 v3.0.1
+	mvdan.cc/gofumpt v0.8.0
 )
 
 require (
@@ -72,21 +72,21 @@ type ProductDetailsStatus string
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/5RVzY7bNhB+lQHbo2Bv0KIH3dI4DQwUqYE23UO86I7JkcWUGrLkaB3B8LsXpOQ/eIPs",
-	"+kSD4jfz/Qy5V9p3wTOxJFXvVdItdViWK4rJc16hc380qv68Vz9GalStfpifT82nI/Px+1X0gaJYSupQ",
-	"7VWk/3obyaj6s/rNxiQfsSNVqd9xWj4cHiplKOlog9hcT/3V2gQ2AUIokBXsrLTQIRsUHwdoMhAgG3CY",
-	"BBg7qmDTC/gCgQ6WizVz320ozqDA7XzvDGwIIkkfmQxsBkB4/EDyCEkGR/B2tZzBPUFHcUsgLU3l1xxO",
-	"nMZOkL20FOHPwhx2rdUteHYDhOifrKEER97QWHImzdasKiVDIFUrv/lCWtShUjeS1fsbLSgRYKQJCKRF",
-	"gRRI22Y4KZRJ0lA+Q+dOMlRZozWfuPdp4s3w+N7YS+ZAbIK3LBXsWooEhLrNJhyxRgbhqtWzofX+SC5J",
-	"tLzN5D74J4rcEcty8bF4kT9rfOxQVK0syy8/n0WxLLSlmA+esnGLevimiPdW2uXitWktGb0mNYJ8t81D",
-	"dZXt5eIlSYZI2kcDmM45bKLvAOFdJBQ62TCDpYD2LGg5rTm7mhM5hcA3gLC6HA5kQGPsFP9IyfdRE3z6",
-	"tFx8K3vRm17LbeR+xUQ5yHkXRrlunLfmSqS+t+Zc4xwBfoWLY8EFCVqXXuHjROPWSI1CWx+HW4bvjjuV",
-	"oq/YBZdbee9IS/RsdXqOirEpOBz+OVK6RlyMu+UqukK9t2ZLAqvonwNNgtI/P/Uw7mWry1U00awUcd/l",
-	"xJmITf6PWuxTLmpsyoGx3FM2IxCbXOah+r78t8GlJKBzEBofoTgx5ewcsotb0TJoHyNpgTETszWX7Bvq",
-	"PCeJKJSOPAJF2OEA4oG+CrEBnGJ2U+J8eR5y15YbX+JkpYibu0zwtnRXopHKQVWpJ4ppZPJmdje7y1r7",
-	"QIzBqlr9NLubvckKobRF/HlwqKn1zox31JaeGYu/0dny/iTYIQuggKP8/HgmyFAVJA+SWXf470i3gxZD",
-	"GMYJzMQxgy2NqtXqomS+SlLwnI4vQIO9Ky1kQ4nLEkNwVheA+ZfpYZ6ms37RiBxHq0h5ze2S/6H8/g8A",
-	"AP//YEsb5RgIAAA=",
+	"H4sIAAAAAAAC/5yV0W/bthPH/5UDf79HwUmxYQ9+6+quMDB0BpYuD3WwXMSTxY46crxTXCHw/z6QUmw5",
+	"CZZ2frFsHnl3n+/3qAcTIjFGZ5bmh8Xl4o2pjOMmmOWDuackLrBZmjeLy8WlqYw69WSW5opEBd56/1sD",
+	"dehiEKc58lCZiNpK3n0RPdbUBm8p5d870vwVIiXMwWtrlmYzi6mMJamTizom/QO9s6ghCeyRFVDBE4pC",
+	"YIKcpgIJoK0T6PAvEtCWOmgxxmGxZVOZRBIDC5VyLDXYex0f52niWQl1YCUuYRijd3Wp9eKL5NgHI3VL",
+	"Hean/ydqzNL876L0z8QqF+OqXGxSsH2tK1J0Xsxh+lTmFHs6rDxuKEngTcp01I0V6xAz63D3hWp9Rueq",
+	"JSHARNA48jZ3jwoSqXbNAAixnLiAq5aGEobeQyi70VeAbLe8D723cEfQC1m4GwAZbt9bp7cgOniCt5s1",
+	"ENsYHGsF+5YSAWHdgpPjWSPreFb6Ly6JfsSOZn2IJse7bJFf8V8WP4R7StwR63r1se/uKM3CHCvtik5N",
+	"SB3q+NdPP5pCd4T4Leic5A4eIVWwd9pCh1zsNkCTy8+IwGe/MXZUwV2vx55hvdoyl+oyYSdwRJlI+8QT",
+	"Trj9QHOYC7gm6CjtKJt1Sr/lE7yxEuSgLSX4vRgE9q2rWwjsB4gp3DtLAon+7l0iO6k/ioB5IM3y8yvu",
+	"fOq1Q/VgHs8zy88z9WZa3Rxujoivnbbr1feDhkR1SBZQTpiaFDpAeJcIlY5mW8BaIc8iOpYtZ+9mYJPV",
+	"QwMIm7l2yIDWukmdRBL6VBN8+rRefT+aAuTc0OvVt7mwOgO5Xj1iO78Qnt1B+T6FGoWgCQlKrVNfp6Zm",
+	"JnEMdUiJaoWxgsWWC2tLXWDRhDpeh9Mm2OMAGoC+KrEFBJmM9STFf/DS2Fgh9tQL5wStk+hx+JNfHPyn",
+	"zlmN0WX0TGXoK3axvHmund2RwiaFfFnUqLQLaXj9wHePkfPD3nuqNQV2dR4CI4rav6ROSzCuZedNWEvb",
+	"1bOsxH2XpbcJm7yOtbr73IJ1kv3suCeb4RDbvOPmcJg75PWZ+hnlmH/S8RlrZ18CcjRr3zub+31ZivFd",
+	"9c8APBL5fhgIAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
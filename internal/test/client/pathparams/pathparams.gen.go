@@ -0,0 +1,1097 @@
+// Package pathparams provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.0.0-00010101000000-000000000000 DO NOT EDIT.
+package pathparams
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/oapi-codegen/runtime"
+)
+
+// Filter defines model for Filter.
+type Filter struct {
+	Name *string `json:"name,omitempty"`
+}
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+
+	// A logger which logs each request, along with its response.
+	Logger *slog.Logger
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// WithLogger logs each request, with its operation, method, path template,
+// parameters, status code and latency. The values of parameters which are
+// sensitive, as they're marked with `x-sensitive` or have the `password`
+// format, are redacted.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) error {
+		c.Logger = logger
+		return nil
+	}
+}
+
+// WithHeaders sets the given headers on every request, such as an API key
+// which every operation requires. Headers set by a request editor passed to
+// an operation take precedence.
+func WithHeaders(headers http.Header) ClientOption {
+	headers = headers.Clone()
+	return WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		for key, values := range headers {
+			req.Header.Del(key)
+			for _, value := range values {
+				req.Header.Add(key, value)
+			}
+		}
+		return nil
+	})
+}
+
+// WithUserAgent sets the User-Agent header of every request.
+func WithUserAgent(userAgent string) ClientOption {
+	return WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("User-Agent", userAgent)
+		return nil
+	})
+}
+
+// getDirLogAttrs returns the parameters of a GetDir
+// request, which are logged along with it.
+func getDirLogAttrs(name string) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	logAttrs_ = append(logAttrs_, slog.Any("name", name))
+	return logAttrs_
+}
+
+// getFileLogAttrs returns the parameters of a GetFile
+// request, which are logged along with it.
+func getFileLogAttrs(name string) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	logAttrs_ = append(logAttrs_, slog.Any("name", name))
+	return logAttrs_
+}
+
+// getFilterLogAttrs returns the parameters of a GetFilter
+// request, which are logged along with it.
+func getFilterLogAttrs(filter Filter) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	logAttrs_ = append(logAttrs_, slog.Any("filter", filter))
+	return logAttrs_
+}
+
+// getIdsLogAttrs returns the parameters of a GetIds
+// request, which are logged along with it.
+func getIdsLogAttrs(ids []string) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	logAttrs_ = append(logAttrs_, slog.Any("ids", ids))
+	return logAttrs_
+}
+
+// getLabelLogAttrs returns the parameters of a GetLabel
+// request, which are logged along with it.
+func getLabelLogAttrs(ids []string) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	logAttrs_ = append(logAttrs_, slog.Any("ids", ids))
+	return logAttrs_
+}
+
+// getMatrixLogAttrs returns the parameters of a GetMatrix
+// request, which are logged along with it.
+func getMatrixLogAttrs(id string) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	logAttrs_ = append(logAttrs_, slog.Any("id", id))
+	return logAttrs_
+}
+
+// getRawLogAttrs returns the parameters of a GetRaw
+// request, which are logged along with it.
+func getRawLogAttrs(path string) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	logAttrs_ = append(logAttrs_, slog.Any("path", path))
+	return logAttrs_
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetDir request
+	GetDir(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetFile request
+	GetFile(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetFilter request
+	GetFilter(ctx context.Context, filter Filter, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetIds request
+	GetIds(ctx context.Context, ids []string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetLabel request
+	GetLabel(ctx context.Context, ids []string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetMatrix request
+	GetMatrix(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRaw request
+	GetRaw(ctx context.Context, path string, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetDir(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetDirRequest(c.Server, name)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.do(req, "GetDir", "/dirs/{name}/", func() []slog.Attr { return getDirLogAttrs(name) })
+}
+
+func (c *Client) GetFile(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetFileRequest(c.Server, name)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.do(req, "GetFile", "/files/{name}", func() []slog.Attr { return getFileLogAttrs(name) })
+}
+
+func (c *Client) GetFilter(ctx context.Context, filter Filter, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetFilterRequest(c.Server, filter)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.do(req, "GetFilter", "/filters/{filter}", func() []slog.Attr { return getFilterLogAttrs(filter) })
+}
+
+func (c *Client) GetIds(ctx context.Context, ids []string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetIdsRequest(c.Server, ids)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.do(req, "GetIds", "/ids/{ids}", func() []slog.Attr { return getIdsLogAttrs(ids) })
+}
+
+func (c *Client) GetLabel(ctx context.Context, ids []string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetLabelRequest(c.Server, ids)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.do(req, "GetLabel", "/label/{ids}", func() []slog.Attr { return getLabelLogAttrs(ids) })
+}
+
+func (c *Client) GetMatrix(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetMatrixRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.do(req, "GetMatrix", "/matrix/{id}", func() []slog.Attr { return getMatrixLogAttrs(id) })
+}
+
+func (c *Client) GetRaw(ctx context.Context, path string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRawRequest(c.Server, path)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.do(req, "GetRaw", "/raw/{path}", func() []slog.Attr { return getRawLogAttrs(path) })
+}
+
+// NewGetDirRequest generates requests for GetDir
+func NewGetDirRequest(server string, name string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+	pathParam0 = escapeDotSegments(pathParam0)
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/dirs/%s/", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetFileRequest generates requests for GetFile
+func NewGetFileRequest(server string, name string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+	pathParam0 = escapeDotSegments(pathParam0)
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/files/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetFilterRequest generates requests for GetFilter
+func NewGetFilterRequest(server string, filter Filter) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	var pathParamBuf0 []byte
+	pathParamBuf0, err = json.Marshal(filter)
+	if err != nil {
+		return nil, err
+	}
+	pathParam0 = escapePathParam(string(pathParamBuf0), false)
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/filters/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetIdsRequest generates requests for GetIds
+func NewGetIdsRequest(server string, ids []string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "ids", runtime.ParamLocationPath, ids)
+	if err != nil {
+		return nil, err
+	}
+	pathParam0 = escapeDotSegments(pathParam0)
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/ids/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetLabelRequest generates requests for GetLabel
+func NewGetLabelRequest(server string, ids []string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("label", true, "ids", runtime.ParamLocationPath, ids)
+	if err != nil {
+		return nil, err
+	}
+	pathParam0 = escapeDotSegments(pathParam0)
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/label/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetMatrixRequest generates requests for GetMatrix
+func NewGetMatrixRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("matrix", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+	pathParam0 = escapeDotSegments(pathParam0)
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/matrix/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetRawRequest generates requests for GetRaw
+func NewGetRawRequest(server string, path string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "path", runtime.ParamLocationUndefined, path)
+	if err != nil {
+		return nil, err
+	}
+	pathParam0 = escapePathParam(pathParam0, true)
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/raw/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// do sends the request, logging it with the Logger, if there is one. The
+// parameters of the request are only collected when they're logged.
+func (c *Client) do(req *http.Request, operationID, path string, params func() []slog.Attr) (*http.Response, error) {
+	if c.Logger == nil {
+		return c.Client.Do(req)
+	}
+	start := time.Now()
+	rsp, err := c.Client.Do(req)
+	attrs := []slog.Attr{
+		slog.String("operation", operationID),
+		slog.String("method", req.Method),
+		slog.String("path", path),
+		slog.Duration("latency", time.Since(start)),
+	}
+	if params != nil {
+		attrs = append(attrs, slog.Attr{Key: "params", Value: slog.GroupValue(params()...)})
+	}
+	if err != nil {
+		c.Logger.LogAttrs(req.Context(), slog.LevelError, "request failed", append(attrs, slog.Any("error", err))...)
+		return nil, err
+	}
+	c.Logger.LogAttrs(req.Context(), slog.LevelInfo, "request", append(attrs, slog.Int("status", rsp.StatusCode))...)
+	return rsp, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// escapePathParam escapes the value of a path parameter, so that it's sent as
+// the parameter in the path of a request. The value is escaped with
+// url.PathEscape, so that a `/`, `%`, `?` or unicode character in it is
+// percent-encoded, unless allowReserved is set, for a parameter with
+// `allowReserved: true`, when the reserved characters of RFC 3986, such as `/`,
+// and the percent-encoded octets in it are kept as they are, as they are by the
+// reserved expansion of RFC 6570.
+func escapePathParam(value string, allowReserved bool) string {
+	if allowReserved {
+		return escapeDotSegments(escapeReservedPathParam(value))
+	}
+	return escapeDotSegments(url.PathEscape(value))
+}
+
+// escapeDotSegments percent-encodes the segments of an escaped path parameter
+// which are `.` or `..`, so that they aren't removed from the path of a
+// request as dot-segments.
+func escapeDotSegments(escaped string) string {
+	segments := strings.Split(escaped, "/")
+	for i, segment := range segments {
+		if segment == "." || segment == ".." {
+			segments[i] = strings.ReplaceAll(segment, ".", "%2E")
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// escapeReservedPathParam percent-encodes the characters of value which can't
+// be in the path of a URL as they are, keeping its reserved characters and
+// percent-encoded octets, apart from `?` and `#`, which would end the path.
+func escapeReservedPathParam(value string) string {
+	const hexDigits = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', strings.IndexByte("-._~:/@!$&'()*+,;=[]", c) >= 0:
+			b.WriteByte(c)
+		case c == '%' && i+2 < len(value) && strings.IndexByte("0123456789ABCDEFabcdef", value[i+1]) >= 0 && strings.IndexByte("0123456789ABCDEFabcdef", value[i+2]) >= 0:
+			b.WriteString(value[i : i+3])
+			i += 2
+		default:
+			b.WriteByte('%')
+			b.WriteByte(hexDigits[c>>4])
+			b.WriteByte(hexDigits[c&15])
+		}
+	}
+	return b.String()
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ParseAs reads and closes the body of an HTTP response, decoding it as JSON
+// into a T. This is useful for responses which the typed fields of a
+// response don't cover, such as for a status code which isn't documented.
+func ParseAs[T any](rsp *http.Response) (T, error) {
+	var dest T
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return dest, err
+	}
+	return ResponseAs[T](bodyBytes)
+}
+
+// ResponseAs decodes the Body of a response returned by ClientWithResponses
+// as JSON into a T, such as `ResponseAs[Error](resp.Body)`.
+func ResponseAs[T any](body []byte) (T, error) {
+	var dest T
+	if err := json.Unmarshal(body, &dest); err != nil {
+		return dest, err
+	}
+	return dest, nil
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetDirWithResponse request
+	GetDirWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*GetDirResponse, error)
+
+	// GetFileWithResponse request
+	GetFileWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*GetFileResponse, error)
+
+	// GetFilterWithResponse request
+	GetFilterWithResponse(ctx context.Context, filter Filter, reqEditors ...RequestEditorFn) (*GetFilterResponse, error)
+
+	// GetIdsWithResponse request
+	GetIdsWithResponse(ctx context.Context, ids []string, reqEditors ...RequestEditorFn) (*GetIdsResponse, error)
+
+	// GetLabelWithResponse request
+	GetLabelWithResponse(ctx context.Context, ids []string, reqEditors ...RequestEditorFn) (*GetLabelResponse, error)
+
+	// GetMatrixWithResponse request
+	GetMatrixWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetMatrixResponse, error)
+
+	// GetRawWithResponse request
+	GetRawWithResponse(ctx context.Context, path string, reqEditors ...RequestEditorFn) (*GetRawResponse, error)
+}
+
+type GetDirResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r GetDirResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetDirResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetFileResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r GetFileResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetFileResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetFilterResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r GetFilterResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetFilterResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetIdsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r GetIdsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetIdsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetLabelResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r GetLabelResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetLabelResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetMatrixResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r GetMatrixResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetMatrixResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRawResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRawResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRawResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetDirWithResponse request returning *GetDirResponse
+func (c *ClientWithResponses) GetDirWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*GetDirResponse, error) {
+	rsp, err := c.GetDir(ctx, name, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetDirResponse(rsp)
+}
+
+// GetFileWithResponse request returning *GetFileResponse
+func (c *ClientWithResponses) GetFileWithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*GetFileResponse, error) {
+	rsp, err := c.GetFile(ctx, name, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetFileResponse(rsp)
+}
+
+// GetFilterWithResponse request returning *GetFilterResponse
+func (c *ClientWithResponses) GetFilterWithResponse(ctx context.Context, filter Filter, reqEditors ...RequestEditorFn) (*GetFilterResponse, error) {
+	rsp, err := c.GetFilter(ctx, filter, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetFilterResponse(rsp)
+}
+
+// GetIdsWithResponse request returning *GetIdsResponse
+func (c *ClientWithResponses) GetIdsWithResponse(ctx context.Context, ids []string, reqEditors ...RequestEditorFn) (*GetIdsResponse, error) {
+	rsp, err := c.GetIds(ctx, ids, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetIdsResponse(rsp)
+}
+
+// GetLabelWithResponse request returning *GetLabelResponse
+func (c *ClientWithResponses) GetLabelWithResponse(ctx context.Context, ids []string, reqEditors ...RequestEditorFn) (*GetLabelResponse, error) {
+	rsp, err := c.GetLabel(ctx, ids, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetLabelResponse(rsp)
+}
+
+// GetMatrixWithResponse request returning *GetMatrixResponse
+func (c *ClientWithResponses) GetMatrixWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetMatrixResponse, error) {
+	rsp, err := c.GetMatrix(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetMatrixResponse(rsp)
+}
+
+// GetRawWithResponse request returning *GetRawResponse
+func (c *ClientWithResponses) GetRawWithResponse(ctx context.Context, path string, reqEditors ...RequestEditorFn) (*GetRawResponse, error) {
+	rsp, err := c.GetRaw(ctx, path, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRawResponse(rsp)
+}
+
+// ParseGetDirResponse parses an HTTP response from a GetDirWithResponse call
+func ParseGetDirResponse(rsp *http.Response) (*GetDirResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetDirResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetFileResponse parses an HTTP response from a GetFileWithResponse call
+func ParseGetFileResponse(rsp *http.Response) (*GetFileResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetFileResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetFilterResponse parses an HTTP response from a GetFilterWithResponse call
+func ParseGetFilterResponse(rsp *http.Response) (*GetFilterResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetFilterResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetIdsResponse parses an HTTP response from a GetIdsWithResponse call
+func ParseGetIdsResponse(rsp *http.Response) (*GetIdsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetIdsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetLabelResponse parses an HTTP response from a GetLabelWithResponse call
+func ParseGetLabelResponse(rsp *http.Response) (*GetLabelResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetLabelResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetMatrixResponse parses an HTTP response from a GetMatrixWithResponse call
+func ParseGetMatrixResponse(rsp *http.Response) (*GetMatrixResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetMatrixResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetRawResponse parses an HTTP response from a GetRawWithResponse call
+func ParseGetRawResponse(rsp *http.Response) (*GetRawResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetRawResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// URLForGetDir returns the path of a request to GetDir, `/dirs/{name}/`,
+// with its path parameters styled and escaped as they are in requests.
+func URLForGetDir(name string) string {
+	return fmt.Sprintf("/dirs/%s/", styleURLPathParam("simple", false, "name", name, false))
+}
+
+// URLForGetFile returns the path of a request to GetFile, `/files/{name}`,
+// with its path parameters styled and escaped as they are in requests.
+func URLForGetFile(name string) string {
+	return fmt.Sprintf("/files/%s", styleURLPathParam("simple", false, "name", name, false))
+}
+
+// URLForGetFilter returns the path of a request to GetFilter, `/filters/{filter}`,
+// with its path parameters styled and escaped as they are in requests.
+func URLForGetFilter(filter Filter) string {
+	return fmt.Sprintf("/filters/%s", jsonURLPathParam(filter, false))
+}
+
+// URLForGetIds returns the path of a request to GetIds, `/ids/{ids}`,
+// with its path parameters styled and escaped as they are in requests.
+func URLForGetIds(ids []string) string {
+	return fmt.Sprintf("/ids/%s", styleURLPathParam("simple", false, "ids", ids, false))
+}
+
+// URLForGetLabel returns the path of a request to GetLabel, `/label/{ids}`,
+// with its path parameters styled and escaped as they are in requests.
+func URLForGetLabel(ids []string) string {
+	return fmt.Sprintf("/label/%s", styleURLPathParam("label", true, "ids", ids, false))
+}
+
+// URLForGetMatrix returns the path of a request to GetMatrix, `/matrix/{id}`,
+// with its path parameters styled and escaped as they are in requests.
+func URLForGetMatrix(id string) string {
+	return fmt.Sprintf("/matrix/%s", styleURLPathParam("matrix", false, "id", id, false))
+}
+
+// URLForGetRaw returns the path of a request to GetRaw, `/raw/{path}`,
+// with its path parameters styled and escaped as they are in requests.
+func URLForGetRaw(path string) string {
+	return fmt.Sprintf("/raw/%s", styleURLPathParam("simple", false, "path", path, true))
+}
+
+// styleURLPathParam styles and escapes the value of a path parameter, as the
+// client does, falling back to formatting it with fmt.Sprint if it can't be
+// styled.
+func styleURLPathParam(style string, explode bool, paramName string, value interface{}, allowReserved bool) string {
+	location := runtime.ParamLocationPath
+	if allowReserved {
+		location = runtime.ParamLocationUndefined
+	}
+	styled, err := runtime.StyleParamWithLocation(style, explode, paramName, location, value)
+	if err != nil {
+		return escapePathParam(fmt.Sprint(value), allowReserved)
+	}
+	if allowReserved {
+		return escapePathParam(styled, true)
+	}
+	return escapeDotSegments(styled)
+}
+
+// jsonURLPathParam encodes the value of a path parameter as JSON, and escapes
+// it, falling back to formatting it with fmt.Sprint if it can't be encoded.
+func jsonURLPathParam(value interface{}, allowReserved bool) string {
+	buf, err := json.Marshal(value)
+	if err != nil {
+		return escapePathParam(fmt.Sprint(value), allowReserved)
+	}
+	return escapePathParam(string(buf), allowReserved)
+}
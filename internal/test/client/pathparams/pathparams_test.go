@@ -0,0 +1,70 @@
+package pathparams
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const server = "https://example.com/api/"
+
+// TestPathParamEscaping checks the path of the requests the client generates, and the paths the URL helpers return, against a suite of values which need escaping
+func TestPathParamEscaping(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+
+	vectors := []struct {
+		name     string
+		request  func() (*http.Request, error)
+		urlFor   string
+		expected string
+	}{
+		// Parameters are escaped with url.PathEscape
+		{"plain", func() (*http.Request, error) { return NewGetFileRequest(server, "plain") }, URLForGetFile("plain"), "/files/plain"},
+		{"slash", func() (*http.Request, error) { return NewGetFileRequest(server, "a/b") }, URLForGetFile("a/b"), "/files/a%2Fb"},
+		{"percent", func() (*http.Request, error) { return NewGetFileRequest(server, "100%") }, URLForGetFile("100%"), "/files/100%25"},
+		{"percent-encoded", func() (*http.Request, error) { return NewGetFileRequest(server, "%2F") }, URLForGetFile("%2F"), "/files/%252F"},
+		{"space", func() (*http.Request, error) { return NewGetFileRequest(server, "a b") }, URLForGetFile("a b"), "/files/a%20b"},
+		{"query and fragment", func() (*http.Request, error) { return NewGetFileRequest(server, "a?b#c") }, URLForGetFile("a?b#c"), "/files/a%3Fb%23c"},
+		{"unicode", func() (*http.Request, error) { return NewGetFileRequest(server, "café") }, URLForGetFile("café"), "/files/caf%C3%A9"},
+		{"unicode without ASCII", func() (*http.Request, error) { return NewGetFileRequest(server, "日本") }, URLForGetFile("日本"), "/files/%E6%97%A5%E6%9C%AC"},
+		{"semicolon and comma", func() (*http.Request, error) { return NewGetFileRequest(server, "a;b=c,d") }, URLForGetFile("a;b=c,d"), "/files/a%3Bb=c%2Cd"},
+		{"dot", func() (*http.Request, error) { return NewGetFileRequest(server, ".") }, URLForGetFile("."), "/files/%2E"},
+		{"dot-dot", func() (*http.Request, error) { return NewGetFileRequest(server, "..") }, URLForGetFile(".."), "/files/%2E%2E"},
+		{"dots", func() (*http.Request, error) { return NewGetFileRequest(server, "...") }, URLForGetFile("..."), "/files/..."},
+
+		// A trailing slash is kept after the parameter
+		{"trailing slash", func() (*http.Request, error) { return NewGetDirRequest(server, "a/b") }, URLForGetDir("a/b"), "/dirs/a%2Fb/"},
+		{"trailing slash after dot-dot", func() (*http.Request, error) { return NewGetDirRequest(server, "..") }, URLForGetDir(".."), "/dirs/%2E%2E/"},
+
+		// Reserved characters are kept with `allowReserved`
+		{"reserved slash", func() (*http.Request, error) { return NewGetRawRequest(server, "a/b/c") }, URLForGetRaw("a/b/c"), "/raw/a/b/c"},
+		{"reserved characters", func() (*http.Request, error) { return NewGetRawRequest(server, ":@!$&'()*+,;=") }, URLForGetRaw(":@!$&'()*+,;="), "/raw/:@!$&'()*+,;="},
+		{"reserved percent-encoded", func() (*http.Request, error) { return NewGetRawRequest(server, "a%2Fb") }, URLForGetRaw("a%2Fb"), "/raw/a%2Fb"},
+		{"reserved percent", func() (*http.Request, error) { return NewGetRawRequest(server, "100%") }, URLForGetRaw("100%"), "/raw/100%25"},
+		{"reserved invalid percent-encoding", func() (*http.Request, error) { return NewGetRawRequest(server, "%zz") }, URLForGetRaw("%zz"), "/raw/%25zz"},
+		{"reserved query and fragment", func() (*http.Request, error) { return NewGetRawRequest(server, "a?b#c") }, URLForGetRaw("a?b#c"), "/raw/a%3Fb%23c"},
+		{"reserved space", func() (*http.Request, error) { return NewGetRawRequest(server, "a b") }, URLForGetRaw("a b"), "/raw/a%20b"},
+		{"reserved unicode", func() (*http.Request, error) { return NewGetRawRequest(server, "café/日本") }, URLForGetRaw("café/日本"), "/raw/caf%C3%A9/%E6%97%A5%E6%9C%AC"},
+		{"reserved dot-segments", func() (*http.Request, error) { return NewGetRawRequest(server, "a/../b/.") }, URLForGetRaw("a/../b/."), "/raw/a/%2E%2E/b/%2E"},
+
+		// Each element of an array is escaped, but not the separators between them
+		{"simple array", func() (*http.Request, error) { return NewGetIdsRequest(server, []string{"a", "b/c", "d e"}) }, URLForGetIds([]string{"a", "b/c", "d e"}), "/ids/a,b%2Fc,d%20e"},
+		{"matrix", func() (*http.Request, error) { return NewGetMatrixRequest(server, "a/b") }, URLForGetMatrix("a/b"), "/matrix/;id=a%2Fb"},
+		{"matrix unicode", func() (*http.Request, error) { return NewGetMatrixRequest(server, "ü") }, URLForGetMatrix("ü"), "/matrix/;id=%C3%BC"},
+		{"label", func() (*http.Request, error) { return NewGetLabelRequest(server, []string{"a", "b?"}) }, URLForGetLabel([]string{"a", "b?"}), "/label/.a.b%3F"},
+
+		// A parameter encoded as JSON is escaped too
+		{"json", func() (*http.Request, error) { return NewGetFilterRequest(server, Filter{Name: strPtr("a/b")}) }, URLForGetFilter(Filter{Name: strPtr("a/b")}), "/filters/%7B%22name%22:%22a%2Fb%22%7D"},
+	}
+
+	for _, v := range vectors {
+		t.Run(v.name, func(t *testing.T) {
+			req, err := v.request()
+			require.NoError(t, err)
+			assert.Equal(t, "/api"+v.expected, req.URL.EscapedPath())
+			assert.Equal(t, v.expected, v.urlFor)
+		})
+	}
+}
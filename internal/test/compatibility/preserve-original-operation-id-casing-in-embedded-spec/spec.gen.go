@@ -16,10 +16,10 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/7zPwUr1MBAF4Ff5Oev03v66y+4iLkQQwQeQ3HRsR9tkyAzCpeTdJenKF3B1woEcvtnB",
-	"6SPD7zC2leCx3f6pUITDNxXlnODx/zSeRlSHLJSCMDzue+UgwRZt/89C1nKilYzaKwuVYJzT0wQPW1gH",
-	"1iEMX3QN10uaHsJG6/vby+X5EQ6FVHJS6mN349gi5mSU+mwQWTn2ufOnNtUOjQttoeNv0uxqhdOMWqvD",
-	"RBoLix0XHN18EH/DZrJXsj8R1Fp/AgAA//9pDRH+cQEAAA==",
+	"H4sIAAAAAAAC/7yOwUoDQRBEf0XqPElWvc0tiAcRRPADZDLbZlt3e5rpRgjL/LvMBk/ePVVT1KPfiqIk",
+	"SRkR9/thPyCA5aMgrnD2mRCxXG5MKSPgm6pxEUTcbtsWoMkn6+uDkvc8X6Mo1eRc5GlE7OUrOQIqmRYx",
+	"2pC7YeiRizjJRiXVmfPGHT6tf1pheaIl9csv2n3MK8sZrbWAkSxXVr9a/XYzOf2V8Iltx7ZLuy86pdNR",
+	"xoe00Pz+9nJ8fvwXt9bazwDaj8bScQEAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
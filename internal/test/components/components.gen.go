@@ -2223,3 +2223,27 @@ func (a OneOfObject13) MarshalJSON() ([]byte, error) {
 	}
 	return json.Marshal(object)
 }
+
+// String formats the SchemaObject as `%+v` does, other than the values of its
+// sensitive fields, which are redacted so that they aren't leaked into logs
+func (t SchemaObject) String() string {
+	return fmt.Sprintf("{FirstName:%+v ReadOnlyRequiredProp:%+v Role:%+v WriteOnlyRequiredProp:[REDACTED]}", t.FirstName, t.ReadOnlyRequiredProp, t.Role)
+}
+
+// GoString formats the SchemaObject as `%#v` does, other than the values of its
+// sensitive fields, which are redacted
+func (t SchemaObject) GoString() string {
+	return fmt.Sprintf("components.SchemaObject{FirstName:%#v, ReadOnlyRequiredProp:%#v, Role:%#v, WriteOnlyRequiredProp:\"[REDACTED]\"}", t.FirstName, t.ReadOnlyRequiredProp, t.Role)
+}
+
+// String formats the SchemaObjectNullable as `%+v` does, other than the values of its
+// sensitive fields, which are redacted so that they aren't leaked into logs
+func (t SchemaObjectNullable) String() string {
+	return fmt.Sprintf("{FirstName:%+v ReadOnlyRequiredProp:%+v Role:%+v WriteOnlyRequiredProp:[REDACTED]}", t.FirstName, t.ReadOnlyRequiredProp, t.Role)
+}
+
+// GoString formats the SchemaObjectNullable as `%#v` does, other than the values of its
+// sensitive fields, which are redacted
+func (t SchemaObjectNullable) GoString() string {
+	return fmt.Sprintf("components.SchemaObjectNullable{FirstName:%#v, ReadOnlyRequiredProp:%#v, Role:%#v, WriteOnlyRequiredProp:\"[REDACTED]\"}", t.FirstName, t.ReadOnlyRequiredProp, t.Role)
+}
@@ -24,10 +24,9 @@ type ObjectA struct {
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/0TOQWrFIBAG4KuUv12KpmTnLrlAe4NiZJLYRh1UCiF494fmwXPjDz8z31yw0XMMFEqG",
-	"vpDtTt70+LX8ki1Ti5wiUyqOehGMp/aXkwkauSQXNlSB2Cd+llZ+JFqhIaViY//MRrPKTFaexh/v6oWq",
-	"p6hubkZtT8CFNXbElaMp3/eWtwkC/5SyiwEan3KQQ6eZgmEHjVEOcoQAm7K3c2t9BAAA///cRrjF5AAA",
-	"AA==",
+	"H4sIAAAAAAAC/0TOQYrEIBAF0KsMf2YpmiE7d8kFZm7QGKkkdkctVBpCyN0bTUO7+vKpenUgMgXDDhq9",
+	"7GQPARfmCH2guLIRNP6NfZiFvgYIPCllFwM0fmUnO5wCbMqaoY9TwEbPMVAo9Y9sV/Kmxb/pTrYMNXKK",
+	"TKk4akUwnpq1c6VySS4sdWtsE7eplj+JZmhIqfg6ZVSZycrd+O1bfVD1FtXFjTjbew0A2UoULuQAAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
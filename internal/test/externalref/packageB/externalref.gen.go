@@ -21,9 +21,9 @@ type ObjectB struct {
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/xzNMQrDMAyF4auUNxuTks1jLtBewTVqoraRhC0KJfjuxdGiH77hHSi6mwqJN6QDrWy0",
-	"5zNvjxcVX0ZaVaPqTCdI3ml8/xkhoXllWdHHBbA89UT2z9B7Lu+80mVBwJdqYxUkXOMUJ/QANZJsjIQ5",
-	"TnFGgGXfxkzv/wAAAP//KdWjnJwAAAA=",
+	"H4sIAAAAAAAC/xzNwQrCMBAE0F+ROYdQ6S3H/oD+Qgxru2p3l2QRpOTfpZnTG+YwB9RIsjES5jjFGQEs",
+	"T0U64OwfQsI9l3de6bIg4Eu1sQoSrnGKE3qAZd8a0tEDiu6mQuJnRysb7Xnw9nhR8eWkVTWqzjQGyTuN",
+	"r58REppXlhV95D8A5FAtYJwAAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -203,58 +203,58 @@ type UpdateUserFormdataRequestBody = User
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xb/2/cNrL/V/jUB/QVkFdrO325LnBAnTgtfOcmRp3c9WAbBVealdhIpEpSu94a/t8P",
-	"Q1JafV1rba/vijY/JIpEcoYzn/lK7p0XiiwXHLhW3uzOk/BrAUq/ERED8+ICNP4TCq6Bm0ea5ykLqWaC",
-	"B78owfGdChPIKD79r4SFN/O+CDbrBvarCnCt+3u/scJtlu66wL3vRaBCyXJcwZshj0TMf4FQE51QTThA",
-	"pIgWZA6ERhFE+KwTIEoLCd69731SIE+kpOud9sY0ZOohHnFpJKHXOXgzjxoqPUyfM6WJWJBCgXTcG+G4",
-	"dZDMSRRJUOYxlyIHqZ1SQqYN53BLszxFMhc0FeQk1cKrKCstGY+RFaWphub4tyf9AyVYfW9Gvjp+Tc7X",
-	"WgveN+M3ljeHf/PqeHrYHbkRiNuq7znNc5oZObm94siTnP0IKhdcQc/eRWTeLoTMqPZmHuP6+GhDkXEN",
-	"sVVBBkrR2IzuMG5f3O3M5xdfRLCgRWqV9ZZqiIVcd9lkUUMuh36D4f9/1cuwpVEX56mI1SOkGZZ8GSYL",
-	"pUUGsssk3QBsFLZLQLbhPaBMQNZXkuY5RN5MywKQnbZkpvhnlHjQVLoiWkASF+vHCKmUCw79IKM+CaEQ",
-	"UtB1qMyFSIFyr28ro7aRgz5rTfzmL69fH42a/GtBuW6b/2t/jDmohOWnzhNUwyOq4UCzDIYcR2Hk0PRd",
-	"Rljk0n71PeBF5s2uvDylIUSecfBSLM1jBClbgoTIu/FrSquN2FVtwmgKx7nY1NJYzSS3Ybky3UfrsQvF",
-	"SMQx65VknggtPsm0aWvNYe2NlnPqMmnZW8e4hjSWgyb2G2G8Fgs3yqNLylI6T/FdDjyyHCmRGs11/SeN",
-	"x/uNjzT2xu7h3jdJCEPIzK6sLOryu3kIH7nLMpDogFveQbm7olNTO8qkAR3ykFGWNjHzi0j4t+b9JBRZ",
-	"H3YWTCr9vgO2v4mkNyQ/Dswp7aVBM1C9eKZKrYRskvIOj45ffT0Afw4jx6KXvxyAMUq15ncGouuA/+sP",
-	"HzoBo6vdHVFhMj1jdRAWkun1JeLdhdac/fwZjBdiyHkCNDJUyhDpvm+sImd/h7WLD8Y4f6aFTgxcU7Gy",
-	"6M0wPWU2VS10IiT7zSSr6CVmXqJ1rmZBUC5wPFErGscgJ0wEAicE5Sy0KRWKHFzWT6MZzvJm5pmsRSGJ",
-	"eYEBnGkov2YiYou1+YSOxIyjYSgK7jLzUmRI6Mi+gluNgk9PRdij0u8Yj4goNMmEBELn+Hhp2fZ8r6g2",
-	"NguCzW4MzvlClOk7DXXNvFCWGmj2bXNCk+7HhCnCFKFEGSgQrCIuUWzkEuQSJJlTBRER1l1+yIGfXJyR",
-	"48mUqBxCtnB1woSQf4mChJSTRXcr19zthVBNrjr7uPm/zquvJuTMktQJkxFhGqQhhMUCvrauXEjwyQq+",
-	"XAJRK6bDZFPlRKBYjNxIpYkJtDRM/ueal2xysSIJpDnBYJCZOGzm4fZWCegEJGH6S0Xma5LRz4zHJEwo",
-	"j0FtKCwYZ4YpphWkCyJk+Q3z88k1/4iF2IqufbJiOiGYYSC/hoE2UcZJDBwkTX1CeUTgNhcKiBIZlJvm",
-	"sCILoLqQYID34eTyeHLNr/klDioULIqUpIx/VrNrfkCuPiZ1hUrIhWJayLUVOBpJzHRSzNHnlsI/oDmr",
-	"nksb+qpaTolChpbh2v4XuPM6sZ0pBPNUzIOMKg0yUDIMMsp4IMHSU4HIgdOcTdY0S7/yfC9lIbjKyPmS",
-	"k5yGCZCjybRtMavVakLN14mQceCmquD87O2795fvDo4m00mis9REZ5CZ+rBA8LMQ+qwuMEMC9FlMG+dZ",
-	"gvvC7YUc1A3F870lSGXt7XAynRy+RkJuQ97MO55MJ+irc6oT4xrQdZmoKZTuuoqTKCLUQAFtoFHTm1Wt",
-	"nWBujUMxO/RrDY31fvsYB6vV6gCj0EEhU+BoBtF/vjXyVgLVUJNaK/vbpFom+8IXtvA26jiaTv/rmz+X",
-	"RRiCUmj/FQQQZa+mX3cRdMaXNGURYTwvXLvFhW5vdnXXjrxX9djn16Lkzf2N76kiyyjWGdtxaRPlK5OW",
-	"3mB0L3qQ/SmPjJo4gVumNLpcXGq+JmdRB9t28J/w7soNuP6jgXw6DPKzU6IKZAQiO/ZVdyyGLS40WYiC",
-	"R4Nm8w9czyYhcBuCff1c1tOP/Y7l3PsmOgSYZL1ZbwqEGHrs6Yci1QyTOlfzLmlagDLJxxwIZh8sgsim",
-	"JqHIMkoU5FRSDRGx+b/qmB0mqhjnKuIYtyTNQINURgAtnTVIV41p15cOBVcsAgmRSSEWLNUm4YXbPDUd",
-	"ToSqb2uHXwuQ603poEryG1wvaKrAr2GsbFLOGnX9o2p91NaTDGZUi8AAv9M1f8CSnrByx8TUY0ysDq9n",
-	"MwgEmjIpFQaAStvbzOGj68ZsNwZcYScbmJBPykw79PHvI/P3sc15wZjqZIuZGKYeMBIcgxZh8U/m63Em",
-	"oO3SWwxgoMfWBcKf6B5Et6bxXqHttNgP7DvTJr+33JUt+CbUTs17mwi1UPZAz2ULcDpdoL6QyYwbd3w5",
-	"iGIRs6Flm/zt3KNO6MGmXAeaW5WFec/zKsuKVxHaG4/9fmfzI+hCctNWYTxOwc1tKu570Beg36yNhLb6",
-	"h7NTIhZlWi3N2i8n799DZqheKDNsY6rqb17doIk8wR9Ulc5pX7XkGgEDFdA/mU6+EzLbAUbtCwKFWSva",
-	"H6o6DuQ9tX2tMey04p47DdnBW7ks9HHkqtxjmGDXR71Y2W1R4PxTo+azSQ2qhkRU04diTFDkqaDRWeYu",
-	"CwyBDgd9x2zWPNppWQm/ILxOosi0JmlKfgBNnQD61EurkbWBO4TGm7H9BxFq0AdKS6BZ0+NV+5szTmXf",
-	"Kf79Puv1+k2T0c71meBrQaeIaYgj8vpQajvEjC+Ba3eo/UDYzWiO6HOVSSgi27Z39wYYqL5ofFYReKKw",
-	"N5C6aBx8jjieax62PVob9cDUkHcpIuss7H4Z9NdYtnNUV4Go7of0tqYvUhqWbVYztN2DaorcDLdXTvbT",
-	"w/tQXZJ4xi7ewKK7ZDHlEnu16orI+GRpXNCqsOTUzZ2usSBuZ8kDEAruzD+dyqZ1KCoksdRLIREt18RZ",
-	"Czk7VTbEmYSfXBfT6XFIDqfT6YSc8LVOGI8JnYslmJdESMIFd7Nxapq6EzdtD7RASiG7nsFWACVOR8Q8",
-	"RLwVSSfTsFsdyLOcTF6yXhqTA9t7Tq0suFUfkbyQYUJVufF2JlviYKBeeoym/0q+RpWa/6CGJ+SDObR1",
-	"rcamequGrZr0eX6zxfGV2IByF2BOoF9SuftwGM/h2F6sQNsOTltZjYMmuqhCbQtv5rJEiP6Op2tjzYKD",
-	"aeEkQFIRx4B+0txl7qLMnkG6Szb7CHb2yvXzxrr+NXdBxCfVDwgrjqh58bsF7qp9v1cJPcduhpOyCoru",
-	"DLpwt6wc+Mx/N9gLQjMKC/pzprZcArCrKZLW7s87HxmzJXAbr0l5yXAIiqqkdGbC++OB+aQfBewzC9qf",
-	"zodOI2uofSRIRqt1CEWpiBmvVUlN9Z/jV+eIth9LJBavBEOYSe/swv11dHWx8GmNZSRa3qvc0ES/GqZA",
-	"JdFwqwc4qK5j7l6/PwF93QuTW6HWqexH+RLftfENhz8dvLvNmQR1cLLQNmA1lzBHyYyTTx/fklUCnGjx",
-	"GTgBO8vrTTm2XIG/972fDn7E7+csYz3IDmmaYkkpSWKuQqapWEFURkbn8vrTnN4y2Ehla2pQgi2ooLLJ",
-	"FBoWdS5iZUHMeHknZa00ZNvNR9j7KUP2IwpdRfL+gLWz6RtGRaFJWEgJXLcSCqJAKYuEIbbvSqFsLaqe",
-	"lMTYZH+s7zBuY7cSqNeJtNPkh7uvI5DzcGpprlk/UPb0h3O/Hzjfg0HNm/V7d53/cQJ0ZYY9lkaKh42z",
-	"aLJHyf4+IvOjio1nwsX3oK29zteb2NmHkN4bcE8yTnsSMMY4m7jar13+0Qudnst5Lhw1f4z7bIHEERwo",
-	"NEyjWC5LXNh70gHNWbA89lBjbkKb8LslyE1LrdD2Rw8Xtru/w28btv+aof7jpe6Rjtk6orW6aG0q+h05",
-	"cPwj+2Vb+mGOSjV12g+lEpRb1om98eOYm/t/BwAA///tv7mIYT4AAA==",
+	"H4sIAAAAAAAC/+xbfW/cNtL/KnzYB+gVkFdrO7lcFzigmzgp9s6Njdq+6yE2Cq40K7GRSIWkvN4a+u6H",
+	"ISWtdqV93+Taovkj1krD4ZD8zSvJZyozECzjdEDPe/3eGfUoFxNJB8/UcJMAHdCbKYsiUOQajDZSATkh",
+	"VxmI4fWInPf61KMh6EDxzHAp6IDexlwTrgkjmqVZAtiO3NiGN6AeQZEx0xASKYiJocmK6AwCPuEBQ1Y9",
+	"Qv4jcxIwQSZchETmhqTIhY1lbu5FJRYz5ENsTDbwfe1e9bh8+Evr1Tc9MnJdmpirkHADynZE5MS+zsAQ",
+	"O0CPTOHrRyB6yk0QQ0iMtAQhaB6hNEobwrJMSRbE/3cvKjGFnJIYkozkmvA0U/IRbDsc3jQGE4Mi3Hyt",
+	"yXhGUvaRi4gEMRMR6HkPEy64FYobDcmESFV9C2QIvXtxGzNDpmzmkSk3MTE8tfJaAZY75YJEIECxxCNM",
+	"hASeMqmBaJlCNWgBUzIBZnIFGumvhjfnvXtxL26QKNcwyROScPFRD+7FCflwGzcXVEEmNTdSzdyE64Hv",
+	"R9zE+bgXyLSa/BOW8fo5K1H0Tc1Oy1wFTuDG+Cc48mZnO/fgjxM59lOmDShfq8BPGRe+Atef9kvk92Ys",
+	"Tb6hHjWgUn01QZDyAOiAtiDkWxKfejSQwrDAoJpAynhCB5Rl3ABLv5uT08KjCQ9AaEBCwVLkOsxYEAM5",
+	"s6qTq2Te0XQ67TH7tSdV5JdNtX85evP2/c3bk7NevxebNEG+j6C007fTXr93+grfaatemg4+PJeMfZyX",
+	"x3NaPHg0YybWKIefgRU8y+0fwyJsQvHtg0d1nqZMzeiA3mUhM0CYIPDEtUG4Is2yvneTIcZHIfWozEo1",
+	"G4U18bVlo+BTDtq8luEM5VjLFYSxXLnTYLu+5TKAsMNgWZaUlsP/RSOPZ6qDGFKGT/+vYEIH9Cs/kGkm",
+	"BQijffdV+yhNUXgLHJ7S5EAGJ9Pp9GQiVXqSqwQEam+4K8vCTRJX2NSoHOwLnUmEBbI46/fbU3eTBwFo",
+	"jYpbT/7quTp4pPtMNvJ40SX7SDyyhIdkdEF0jp1ASC3tizYtWgYhDZnIXJRUL9tU/0J+zs7DUwDudWG1",
+	"JcgVNzOrLpXN+JnlJkZtmCpuYICvLVJZ6J4fiofCo5nU61VnGIaEWduKoC0NeAXaRfnWki4qzzAMt9Cc",
+	"Nwqs5tQ8/9SZP5DOvFytM1ygRT8I2oVnvYOPAdfr2Y1hJrdzFsF6vL/jItQ2QES7r127ZaT/kCeGY0Do",
+	"vpNHluSgbeAyBoKRCw8hdGFNINOUEQ0ZU8xASLRRXES6pRLYMXZbC4t+TrEUTOUIS69by8RRlE85qFlL",
+	"wJsFuQwGWgJc+DfG+EtoHoKC0MYmE54YULSJtglLNHgUnrJEhuDQ5zXW2MwyJwqOhXoURJ7idLJHxhM2",
+	"TgClBxG6r1omIc5yCBOWJ4YOGnRF8bANqvVhqC7lZUoxnCxuINVbK+F6uB/AeqPvaMLrmOpwy6I9lMGS",
+	"rVQF/LqTBvTInbbNTj38/8z+f+6iZbDRV2+Nktw6YbpVpJR0jYJgc9QGh30ynu0D/9aaL6pF8Se212Db",
+	"sOiYwH7OwIzCYltU14H9RQsaP4LJlbBZPxdRAmWqsAjE78Fcg3k9G4UrQWgFqlCIKUurp9EF5q5lsKRs",
+	"t3TZ53fAjgsDkTXYGGAw41799cUXsqW/16h6GWUs4z9/BHx+KLyjx80u60MULQeuziji0pGQGbY6sfw3",
+	"N/E7qdLjIKyKAXQZBOS2m/BAwHm1MPbPWpv7nqWwhTirooxmZzvEQId0uKxOnztoxSEkYGAtsi4sSYms",
+	"FnrcV5dcdaOmwn05eTGwsDv622YhNkMQtZDbyLMc23Et3ForgQt/fB/j51kiWThKWWRXaqMtcPSa2Lom",
+	"NmqrPBK84wmsXLUdvYlD99GUm4WhrWWy5AcwrDRba3RvWNOTRoMdEPbQKg2scE8yMGBOtFHA0s7gpc5R",
+	"6uGNucB1KcoM+7jucjdvN8z4j6UAVdB0EEotsc/FIwgsZXdEQ5ZiEZ5VwOMclWvKYV3+Ow+RUpYh6hyd",
+	"Lexb+/opZ8Jww0F3RU6jWr4vMf8lDuT4FwgM9RpYvlbI0Eq5SR/Oz2jRtUDNIKK5BFKFoLqMQ8f8Xycs",
+	"sPVh28gmQKyzPl0S2nKYo10qiC3OtCW/UnPrvlmbdkOv431Q8WwFi8PKZxXT/71+NyXZKnhYhpD/bP90",
+	"ZzYdWHK5Ta6CmGkoQdKd5ryTiri+qykiRs1ICX8yutAuULX+k9zn/f558HfyErfx7A8gp/0eubJbgWWd",
+	"acqTpNymMzCvUetelxGwU7M2gSpHvoXTc+NshXgTsJuev/OcqltFDsHhgXmVZbaQWXUGrh3odMHp58Mn",
+	"Oe33+z0yFDMT4+4dG+MeMr5E2AopytbLUMUNW1BKKr0inq6s6KFARWO9AqxuAsMvGR7vudhoonLd7d7s",
+	"+4U1Lzdx7IfOAxZYM5QimdlJkAJsqTEGksgoArSMtm3bhjjGd7rLvXXtI4WWD6njgKMYeNv9QR7wTh/f",
+	"AZY8W/6vLsB/SR94tCkqGtjzA7umWCK55NrsAkVNEq4NamOunSkwMYn4IwjnhUlV7uwC0daNV2FVVyKP",
+	"kPyAwGyf2ux8Ig8CRuF98a3HThDthcMFFCUy4qIjsmpj51JG2hkQLqqt7Zk2kLbW+hJ53uk1/gLZbC6X",
+	"4YEi2yGS2qzASbtvpYZpPZUq3NxpRTnvE41wkABTxMCT2SOT3zN8crUp2+ynkx+ZgZNLnvIOmAYsSTTJ",
+	"QJFY5oqwJJFTCCtPUvqeLf2oTfU8+tPJ26eMK9Anw4kB1e7TnurhgtzdvsEzcYIY+RHwaIZtRb1tShHI",
+	"48TwFFwQteOWz3yVt7MVNf3GMLBCqF9jYR4oLKqPzM32+oMHH4NcKRBmyb0TDVq7RW9pk8xN7eX3t1kN",
+	"sZ+r4RVbif49GCfjeDZXyK6UBqV8PXvvvm6n+p2xIiqhVfpVGY3bOkVepwv7pWSb6PEomvlbs+lb4nlz",
+	"vHun27nN0vHCNkLKI37Hj3Ed452cSSeiFtG0e8axpi678bBj6TSPf3Lrjxx7r8ip29ArU+rjQ88xPhx6",
+	"q43Z3vjbNr89ROudO65Wd7789vGqqu4u15azhYoyD7dJ2j0KT/Z6AR2c9guv3OnZseG3f3v16qzwaFl4",
+	"n20T5TTavyo8qmOeXTADjaZrAxav2oVdcx4swxI0rrC9Y/BoH0NI+CMoKI+EtYsN9fm3Wrx583JVar1w",
+	"/Y6lTIC5w7ClAWiWZuz7N7k2Mv1My4b/Cm+uEF1TUg9mAnGUz3AoLAwVaL1vOjcsm7dGXfK1UehUsSyr",
+	"T5C2Zyio5gU/DVsCrZgjbRSAadB1jPPF+StyOTPGZYzBIio76K9ZIskwMbLC1oZ5fDNEwl95tp7s2xfn",
+	"/VNarJokBw5mICo3zI4NjsKjmzFxISPdIWNQyYWf7vTnMzrbIdfEziEUHrVXh95vbPEPGdvVT9hW1CwF",
+	"C+fyJso62l9kLL6zdHh9BhvVGe7adqdn5y9eWvJYCtiSFqfnZtnarTGsHd6lbdVO2+udVwHJLYuOtdQr",
+	"8dfu3jD39hrMsXpfBtpmkIUyirj1L0FDK9fZwVp73aoaeacSvf05zeVZqFg0J2jJinplRLaf3cbFXcN6",
+	"s1vddMy6CT53FxA5Lgbhi9cLPlTnt+bz99Cal/JwX/PQwkaUBDJsUq0uuXglSQulHk1BaxZ1fWsh+Kuv",
+	"qji7vj/hcpVSnBLZ7SNKTvx2kMrCcH5t8rd48aTyDEOLwNbYLhvl6j32Pg6rMjdOS9zgR7cGS4da5gjC",
+	"32g9J4mcWkqeomyu4IcfpeK/Wknv6suGeIuyYnjea1xwtNz8qhXYepzMnASNUzQDmsqQT2YEf6GGzGwB",
+	"MQhkLszCGZuBfXbf7YuiPEFdngCZT1bG/2nP1609cFfMrcjCKa+W93j7CGq+n5kbJ8G1OwIETwYdd3Ih",
+	"A91e/HfN28bLdzSbtzsXjlZ23u2yOSrqQX132obWO8pQjgAHoM32MnVmt1dVuqpLrs55PuwjUHkDe604",
+	"/x0AAZ9FV2E+AAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -46,21 +46,20 @@ type DefaultError = Error
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/7SV34/jNBDH/5WR4TFq0+siobwVDtAiAacDnk6rlRtPmjmcsc+edLes+r8jO2nTH6tb",
-	"7qR9ahPPeL7zmR95UrXrvGNkiap6UgGjdxwxP9yUi/RTOxZkSX+195ZqLeR4/jE6Tu/wUXfe4mBpUFU3",
-	"5aJQxnWaWFVq1UurCtVhjHqDqlK3vNWWDOheWmQZr1OFCqjzlQeL+9W5xb5QsW6x0ynUtwEbValv5pP+",
-	"+XAa5z+F4ILa7wsl+Chzb7OSpxPvo2b164MARcBHTwGNKpTsfHofJRBv1D7dYjDWgXwWUam/OSl3gf5F",
-	"M4NbNkkfRpBWC/jgtmTQQB3QJO3axnQ/O4GcVMriplx+Hdfl57iu6hpjhLfIlBM54hwO7seDV6F4GftF",
-	"iD+7sCZjkK8IBvzUYxSoNSdoa4QJd4a3KL8K3qI8gXcG7hfHeMorP78KpjHSi3TeY+2CAXZgHW8wgN5q",
-	"snpts6632OjeyhD4ZRRflsaVljEaYDKAw36AxgXQvBteRyAGaRFW724zivHWFPQHHfEo1QfnMQgN+2Wo",
-	"zNNFwL9ahJ4NBrsj3kAr4iGKlj5CdigmoN+VZaEaFzotqlLEsnwz4SUW3GBIxA51fy7UcAYPLQbMOQyJ",
-	"UgQXaEOsJalogutARzDYEKOB9S7bRgxbqs80qesCnzTbpYIVREp+MFokkMgbS7GFwXKdwk+6NJs0Gmku",
-	"Akofkhhx2aB2HPsOw5ma1QZPRsmmKZVWMyy+f16naKMld4s2hpJKbd+dVe3K6SIj3sHkCgZFk41J4zpL",
-	"3OIOzYBSMHTP0JzBbQM+YESWAh7I2jFV6LQH18A/uEvLtEfwmkI8zffYYrvfdZdknj6moqb1kjf9/pi+",
-	"W3/EWnLfHk+rD2pstrF3phreXTkW6tjg2to/GlV9+PywTTOxvysuhuKwhq47ZTjJQwDRY00N1Yfaj+hO",
-	"26OPQ2tQ/g41A2J81HX64MUeZ/Bn63prsi3Tpx7hgaQlBg3HpKdGep+j3/84ULlcYf8P3XHJXjNMVxA3",
-	"LncYSQ75mzNoU3m3GOJA4c2snJWJuPPI2pOq1HJWzpaqUF5Lmwim9YMhueQ69MGqSs3V/m7/XwAAAP//",
-	"TAbhS+0IAAA=",
+	"H4sIAAAAAAAC/7SVUW/bNhCA/8rhtkfBduoOGPTmrduQAduKbnsqgoAWT9Z1FMmQJyeaof8+kFJluR6a",
+	"AkH9ZIrHu48fqdMJnSerPGOJ29VmtcUC2dYOyxMKiyEs8TenyUQs8EghsrNY4qvVZrXBocBIIT3F8v0J",
+	"u2CwxDUOdwV6JU3E8jQUWLnWO0tW0hgDRe9spDx4Q7XqjPwUggtprClWgb2MRaZZoDQNHxdC7QIo24+P",
+	"I7AFaQh2b28x1bJCVlIu5b3hSqVc6w8xJTxhrBpqVfr3baAaS/xmfaZbj7NxPeIMwzAU+HqzvQb72YU9",
+	"a012BbdWpyIUQRolEOihoyhQKWudwJ5AddK4wP+SvsQTepK1N4o/AZPeE5YYJbA9YIH0pFqfj2FXVRQj",
+	"vCHLpDHBvXiLi/QnrJwmLF9vtgVq12Yw3HXSYIEtxagO1xAFBlK58DRxP9ON8m6u5f1tz0qu/PngjqxJ",
+	"QxVIkxVWJgJHSDKPyvCLJf76KCkfPXkOX9Xizecs3tq8mXw50i7H6kudU8T97jJi8nqzufb6jioXNFgH",
+	"xtkDBVBHxUbtDb3U2S/O0tczdbNZmLqwlOsunEwcWcGUOOWZu4cy5o86d6LPofygIk04dyn5Q5dvQvl+",
+	"BJphziQzwl3x0ZLbf6BKsEAfnKcgTFNzU5OWT21entUOxkhIJSF6qrjmCsTlVpZ6KlcEyurUSFIX6SLp",
+	"NM35paj7HEdPqkq3OXa0gj8b1xmdYy0/dASPLA1bUDDv53yi73L1+x/ThrPOs5Ty9EVOnlUxnu4sgq3Q",
+	"gcKVib+aBKwpmJ7tARoRD1GUdBGm0rULrZIxxfbVchvfbTbD4r48Lz1yWgjTivTlIHswHBsYI/cJIZvN",
+	"X5yF/0DSBTueQQqonI1dS2GJg7sDLRq/oZibmoWb73GYFT6LmYyMsfDYUKAFEEdwgQ9slSTSOrgWVARN",
+	"NSe2fb+8PhdomEWJ0kqWL/t8dkprTvWVeXtxipeow5VS28N5KWgSxSYmSfvs6Eg96RFRKLT/Q7mC2xp8",
+	"oEhWCnhkYybX0CoProZ/qE+dvyPwikPEixYyXbn+d9UmzOUwyZov8jD+/hsAzFh5f+0IAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
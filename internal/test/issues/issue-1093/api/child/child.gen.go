@@ -142,11 +142,11 @@ func (sh *strictHandler) GetPets(ctx *gin.Context) {
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/2SQQUvGMAyG/0p59Ti2qbf+AfH2Id7UQ+kyV+na0GTCx9h/l3aIoKem8CbPk+wIac6w",
-	"OyYSXwJryAkWL0sQ45cQJyNM3oSVc1Exa54oiplLXo0uZNgVStoy6KBBI8GiNaLDFxU55931I44OmSk5",
-	"DrB46Md+RAd2ukjlD0zaig/S/z7PpFtJYpj0Fy5XUaql0/bfhIpZnBjnPYkYzW8JDVpcnfM0weKR9FJJ",
-	"HQoJ5yTUqPfjWB+fk1JqAo45Bt8ah0+pFjvEL7S6Wt0WmmFx7t87Dv3VrfFm8HnlnCipDGdYhgspjuPo",
-	"/qzkjGzNc96i+XGpwRoVKvV4sK87thJhMZxHPd6P7wAAAP//mF1lqLQBAAA=",
+	"H4sIAAAAAAAC/2SRwWrkMBBEf0XU7lHY3s1NPxByG0JuSQ5CbscKstSo24HBzL8HyYQcRqeSVFU8ug8U",
+	"puw5wuFhmIYJFjEvBe7ATBJqZI0lw+FljWLCGtNshCmYuHGpKmYrMyUxSy2b0ZUM+0pZuwcWGjURHHoQ",
+	"Fl9U5ez7N0y4WQjV9gb3emCvCQ7j6b29W7DXVRrKyKRdfJDeoz2T7jWLYdJfDrmKUpNe+30Xqmb1YnwI",
+	"JGK0vGVYVBIuWai3/5+m+3ZvZO+RZU/mxw6LULJS7jieOcXgW2D8lJY6IGGlzTf1t9ICh3Mwg+c4XP2W",
+	"/oyhbFwyZZXxNMt4IcWtHdv2Unvj0wyHR9JLG0H7+x4ABVz3trQBAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -8,11 +8,14 @@ import (
 	"compress/gzip"
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
@@ -44,6 +47,9 @@ type Client struct {
 	// A list of callbacks for modifying requests which are generated before sending over
 	// the network.
 	RequestEditors []RequestEditorFn
+
+	// A logger which logs each request, along with its response.
+	Logger *slog.Logger
 }
 
 // ClientOption allows setting custom parameters during construction
@@ -90,6 +96,49 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 	}
 }
 
+// WithLogger logs each request, with its operation, method, path template,
+// parameters, status code and latency. The values of parameters which are
+// sensitive, as they're marked with `x-sensitive` or have the `password`
+// format, are redacted.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) error {
+		c.Logger = logger
+		return nil
+	}
+}
+
+// WithHeaders sets the given headers on every request, such as an API key
+// which every operation requires. Headers set by a request editor passed to
+// an operation take precedence.
+func WithHeaders(headers http.Header) ClientOption {
+	headers = headers.Clone()
+	return WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		for key, values := range headers {
+			req.Header.Del(key)
+			for _, value := range values {
+				req.Header.Add(key, value)
+			}
+		}
+		return nil
+	})
+}
+
+// WithUserAgent sets the User-Agent header of every request.
+func WithUserAgent(userAgent string) ClientOption {
+	return WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("User-Agent", userAgent)
+		return nil
+	})
+}
+
+// getSimplePrimitiveLogAttrs returns the parameters of a GetSimplePrimitive
+// request, which are logged along with it.
+func getSimplePrimitiveLogAttrs(param string) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	logAttrs_ = append(logAttrs_, slog.Any("param", param))
+	return logAttrs_
+}
+
 // The interface specification for the client above.
 type ClientInterface interface {
 	// GetSimplePrimitive request
@@ -105,7 +154,7 @@ func (c *Client) GetSimplePrimitive(ctx context.Context, param string, reqEditor
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "GetSimplePrimitive", "/simplePrimitive/{param}", func() []slog.Attr { return getSimplePrimitiveLogAttrs(param) })
 }
 
 // NewGetSimplePrimitiveRequest generates requests for GetSimplePrimitive
@@ -118,6 +167,7 @@ func NewGetSimplePrimitiveRequest(server string, param string) (*http.Request, e
 	if err != nil {
 		return nil, err
 	}
+	pathParam0 = escapeDotSegments(pathParam0)
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
@@ -142,6 +192,31 @@ func NewGetSimplePrimitiveRequest(server string, param string) (*http.Request, e
 	return req, nil
 }
 
+// do sends the request, logging it with the Logger, if there is one. The
+// parameters of the request are only collected when they're logged.
+func (c *Client) do(req *http.Request, operationID, path string, params func() []slog.Attr) (*http.Response, error) {
+	if c.Logger == nil {
+		return c.Client.Do(req)
+	}
+	start := time.Now()
+	rsp, err := c.Client.Do(req)
+	attrs := []slog.Attr{
+		slog.String("operation", operationID),
+		slog.String("method", req.Method),
+		slog.String("path", path),
+		slog.Duration("latency", time.Since(start)),
+	}
+	if params != nil {
+		attrs = append(attrs, slog.Attr{Key: "params", Value: slog.GroupValue(params()...)})
+	}
+	if err != nil {
+		c.Logger.LogAttrs(req.Context(), slog.LevelError, "request failed", append(attrs, slog.Any("error", err))...)
+		return nil, err
+	}
+	c.Logger.LogAttrs(req.Context(), slog.LevelInfo, "request", append(attrs, slog.Int("status", rsp.StatusCode))...)
+	return rsp, nil
+}
+
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
 	for _, r := range c.RequestEditors {
 		if err := r(ctx, req); err != nil {
@@ -156,6 +231,56 @@ func (c *Client) applyEditors(ctx context.Context, req *http.Request, additional
 	return nil
 }
 
+// escapePathParam escapes the value of a path parameter, so that it's sent as
+// the parameter in the path of a request. The value is escaped with
+// url.PathEscape, so that a `/`, `%`, `?` or unicode character in it is
+// percent-encoded, unless allowReserved is set, for a parameter with
+// `allowReserved: true`, when the reserved characters of RFC 3986, such as `/`,
+// and the percent-encoded octets in it are kept as they are, as they are by the
+// reserved expansion of RFC 6570.
+func escapePathParam(value string, allowReserved bool) string {
+	if allowReserved {
+		return escapeDotSegments(escapeReservedPathParam(value))
+	}
+	return escapeDotSegments(url.PathEscape(value))
+}
+
+// escapeDotSegments percent-encodes the segments of an escaped path parameter
+// which are `.` or `..`, so that they aren't removed from the path of a
+// request as dot-segments.
+func escapeDotSegments(escaped string) string {
+	segments := strings.Split(escaped, "/")
+	for i, segment := range segments {
+		if segment == "." || segment == ".." {
+			segments[i] = strings.ReplaceAll(segment, ".", "%2E")
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// escapeReservedPathParam percent-encodes the characters of value which can't
+// be in the path of a URL as they are, keeping its reserved characters and
+// percent-encoded octets, apart from `?` and `#`, which would end the path.
+func escapeReservedPathParam(value string) string {
+	const hexDigits = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', strings.IndexByte("-._~:/@!$&'()*+,;=[]", c) >= 0:
+			b.WriteByte(c)
+		case c == '%' && i+2 < len(value) && strings.IndexByte("0123456789ABCDEFabcdef", value[i+1]) >= 0 && strings.IndexByte("0123456789ABCDEFabcdef", value[i+2]) >= 0:
+			b.WriteString(value[i : i+3])
+			i += 2
+		default:
+			b.WriteByte('%')
+			b.WriteByte(hexDigits[c>>4])
+			b.WriteByte(hexDigits[c&15])
+		}
+	}
+	return b.String()
+}
+
 // ClientWithResponses builds on ClientInterface to offer response payloads
 type ClientWithResponses struct {
 	ClientInterface
@@ -183,6 +308,29 @@ func WithBaseURL(baseURL string) ClientOption {
 	}
 }
 
+// ParseAs reads and closes the body of an HTTP response, decoding it as JSON
+// into a T. This is useful for responses which the typed fields of a
+// response don't cover, such as for a status code which isn't documented.
+func ParseAs[T any](rsp *http.Response) (T, error) {
+	var dest T
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return dest, err
+	}
+	return ResponseAs[T](bodyBytes)
+}
+
+// ResponseAs decodes the Body of a response returned by ClientWithResponses
+// as JSON into a T, such as `ResponseAs[Error](resp.Body)`.
+func ResponseAs[T any](body []byte) (T, error) {
+	var dest T
+	if err := json.Unmarshal(body, &dest); err != nil {
+		return dest, err
+	}
+	return dest, nil
+}
+
 // ClientWithResponsesInterface is the interface specification for the client with responses above.
 type ClientWithResponsesInterface interface {
 	// GetSimplePrimitiveWithResponse request
@@ -298,12 +446,12 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/7RRPY/TQBD9K9aDcuX13XXbUaErkBC5DlEs9iQZ5P1gdhJxsvzf0a4TIBHtVfbMzrz3",
-	"5r0FYwo5RYpa4BYIlZxioVbsOOSZvlxatTOmqBS1/ir9Uptnz7FWZTxS8K3/mgkORYXjAeu6GkxURuGs",
-	"nCIcPnSl4XZXri59/0GjbrMc96nCzDzShTT6UBE/Pb9gNVDWuZYvVLTbkZxJYHAmKRv8Qz/0Qx1MmaLP",
-	"DIenfugfYJC9HtthdlPwWTiw8pnskr34sNa3A7XzUibxVfHzBIePpLvblQYnPpCSFLivC6oRjQLmKrlN",
-	"wEDo54mFJjiVE5l/3NonCV7hwFGfHmHu7TMo+tru3SRj/WZuQ3ochvp5L7SHwzv7N0/7Z87eJdmcfkv5",
-	"HJUOJP/VX7lLy20jPskMh6NqdtZeQlMq2k9EOfjce65bvwMAAP//QUrtxqoCAAA=",
+	"H4sIAAAAAAAC/7RSPU/DMBD9K9GD0YrTdvPGhDogIdoNMZjk2hjFH9jXiirKf0d2WlCROnJLcnfv3r13",
+	"8ggfyOlgoLCqm3oBAeN2HmrEkWIy3kFhUTd1AwE2PBAUtpS42lA8UoTAYFpyifKI0zb3n9ZbTJNAKpAE",
+	"9TriEAco9MxBSXleypS47oiC1aHWBtObQNDcp8wlk7FhoOdorGFzJDkGHbWdcq/8EV+4z2tLtRgoCfcQ",
+	"iPR5MJE6KI4HEkh8KhZmcgiktierMymfQu4Yx7QvxnY+Ws1zabXElOXtiTPWB4qajXfrDgqPxJtrsRD/",
+	"qjFxNG5/Q2KkFLxLVK64bJr8uY+0g8KdbL0N3pHjJH9wchb/cs4x5RD4hWaGK9Y/A2pER6mNJvD8YB6q",
+	"WXt1mar8+we1jMzqmFw5ItMXyzDofIvxpslpju8BAIEJ7AeqAgAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -11,9 +11,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
@@ -30,6 +32,12 @@ type Foo struct {
 	Field1 *string `json:"field1,omitempty"`
 }
 
+// BazApplicationBarPlusJSON defines model for baz.
+type BazApplicationBarPlusJSON = Bar
+
+// BazApplicationFooPlusJSON defines model for baz.
+type BazApplicationFooPlusJSON = Foo
+
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -55,6 +63,9 @@ type Client struct {
 	// A list of callbacks for modifying requests which are generated before sending over
 	// the network.
 	RequestEditors []RequestEditorFn
+
+	// A logger which logs each request, along with its response.
+	Logger *slog.Logger
 }
 
 // ClientOption allows setting custom parameters during construction
@@ -101,6 +112,41 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 	}
 }
 
+// WithLogger logs each request, with its operation, method, path template,
+// parameters, status code and latency. The values of parameters which are
+// sensitive, as they're marked with `x-sensitive` or have the `password`
+// format, are redacted.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) error {
+		c.Logger = logger
+		return nil
+	}
+}
+
+// WithHeaders sets the given headers on every request, such as an API key
+// which every operation requires. Headers set by a request editor passed to
+// an operation take precedence.
+func WithHeaders(headers http.Header) ClientOption {
+	headers = headers.Clone()
+	return WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		for key, values := range headers {
+			req.Header.Del(key)
+			for _, value := range values {
+				req.Header.Add(key, value)
+			}
+		}
+		return nil
+	})
+}
+
+// WithUserAgent sets the User-Agent header of every request.
+func WithUserAgent(userAgent string) ClientOption {
+	return WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("User-Agent", userAgent)
+		return nil
+	})
+}
+
 // The interface specification for the client above.
 type ClientInterface interface {
 	// Test request
@@ -116,7 +162,7 @@ func (c *Client) Test(ctx context.Context, reqEditors ...RequestEditorFn) (*http
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "Test", "/test", nil)
 }
 
 // NewTestRequest generates requests for Test
@@ -146,6 +192,31 @@ func NewTestRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
+// do sends the request, logging it with the Logger, if there is one. The
+// parameters of the request are only collected when they're logged.
+func (c *Client) do(req *http.Request, operationID, path string, params func() []slog.Attr) (*http.Response, error) {
+	if c.Logger == nil {
+		return c.Client.Do(req)
+	}
+	start := time.Now()
+	rsp, err := c.Client.Do(req)
+	attrs := []slog.Attr{
+		slog.String("operation", operationID),
+		slog.String("method", req.Method),
+		slog.String("path", path),
+		slog.Duration("latency", time.Since(start)),
+	}
+	if params != nil {
+		attrs = append(attrs, slog.Attr{Key: "params", Value: slog.GroupValue(params()...)})
+	}
+	if err != nil {
+		c.Logger.LogAttrs(req.Context(), slog.LevelError, "request failed", append(attrs, slog.Any("error", err))...)
+		return nil, err
+	}
+	c.Logger.LogAttrs(req.Context(), slog.LevelInfo, "request", append(attrs, slog.Int("status", rsp.StatusCode))...)
+	return rsp, nil
+}
+
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
 	for _, r := range c.RequestEditors {
 		if err := r(ctx, req); err != nil {
@@ -187,6 +258,29 @@ func WithBaseURL(baseURL string) ClientOption {
 	}
 }
 
+// ParseAs reads and closes the body of an HTTP response, decoding it as JSON
+// into a T. This is useful for responses which the typed fields of a
+// response don't cover, such as for a status code which isn't documented.
+func ParseAs[T any](rsp *http.Response) (T, error) {
+	var dest T
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return dest, err
+	}
+	return ResponseAs[T](bodyBytes)
+}
+
+// ResponseAs decodes the Body of a response returned by ClientWithResponses
+// as JSON into a T, such as `ResponseAs[Error](resp.Body)`.
+func ResponseAs[T any](body []byte) (T, error) {
+	var dest T
+	if err := json.Unmarshal(body, &dest); err != nil {
+		return dest, err
+	}
+	return dest, nil
+}
+
 // ClientWithResponsesInterface is the interface specification for the client with responses above.
 type ClientWithResponsesInterface interface {
 	// TestWithResponse request
@@ -248,19 +342,19 @@ func ParseTestResponse(rsp *http.Response) (*TestResponse, error) {
 		}
 		response.ApplicationbarJSON200 = &dest
 
-	case rsp.Header.Get("Content-Type") == "application/bar+json" && rsp.StatusCode == 201:
-		var dest Bar
+	case rsp.Header.Get("Content-Type") == "application/foo+json" && rsp.StatusCode == 200:
+		var dest Foo
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.ApplicationbarJSON201 = &dest
+		response.ApplicationfooJSON200 = &dest
 
-	case rsp.Header.Get("Content-Type") == "application/foo+json" && rsp.StatusCode == 200:
-		var dest Foo
+	case rsp.Header.Get("Content-Type") == "application/bar+json" && rsp.StatusCode == 201:
+		var dest Bar
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.ApplicationfooJSON200 = &dest
+		response.ApplicationbarJSON201 = &dest
 
 	case rsp.Header.Get("Content-Type") == "application/foo+json" && rsp.StatusCode == 201:
 		var dest Foo
@@ -333,6 +427,9 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 	router.GET(options.BaseURL+"/test", wrapper.Test)
 }
 
+type BazApplicationBarPlusJSONResponse = Bar
+type BazApplicationFooPlusJSONResponse = Foo
+
 type TestRequestObject struct {
 }
 
@@ -423,10 +520,10 @@ func (sh *strictHandler) Test(ctx *gin.Context) {
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9SSwU7DMAyG3+WHG9WalVvegDsvkKXuFrTFVuwLTH135BSBJo0DR05263yf4l+5IvNF",
-	"uFI1RbyikQpXpf5xSB9eMlejat4mkXPJyQrX8ZDa05ty9f+aT3RJ3j02WhDxMP54x22qTmBdhxvLwvxH",
-	"y8KM1TUzaW5FXIOIbv46s12+eZHGQs3KttFS6DxP3tm7ECLUWqnHzefi+8T+LuEMC9UkBRHPu7ALGCDJ",
-	"Tp0cjbSHdqRe3Np3fpkR8erD4TbvKYT/lPeAKex/o78XG/0V9bTWzwAAAP//ShuZKG0CAAA=",
+	"H4sIAAAAAAAC/9SQwU7DMBBE/2XgRtSYcPMfcOcHHGfTGrXeldcXiPzvaB2JClQOHHvalUdvdjwbWCgH",
+	"SfB4ObiDwwAJ9aTwG8ZKWm05Uh8sVEJNnF8XeLyZOKCQCmelTkzO2YicK+XOBJFzip0aV+and+Vs7xpP",
+	"dAm2PRZa4fEwRr4IZ8pVx11VI9Da8MNlDuWfLnMoaGazkMaSxGzggTZgcs9/0d8fG+fwabw5XPXr+b5a",
+	"Ur9BipVU017Hmui89AP1QwgeWkvKxz2MpbpNTDeJ9qtrS3VHXbfWvgYAu3qOLW0CAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
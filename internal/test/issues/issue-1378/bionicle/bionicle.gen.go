@@ -202,15 +202,13 @@ func (response GetBionicleName200JSONResponse) VisitGetBionicleNameResponse(w ht
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetBionicleName400JSONResponse struct {
-	union json.RawMessage
-}
+type GetBionicleName400JSONResponse = Bionicle
 
 func (response GetBionicleName400JSONResponse) VisitGetBionicleNameResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
-	return json.NewEncoder(w).Encode(response.union)
+	return json.NewEncoder(w).Encode(response)
 }
 
 // StrictServerInterface represents all server handlers.
@@ -278,12 +276,12 @@ func (sh *strictHandler) GetBionicleName(w http.ResponseWriter, r *http.Request,
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/5SRMU/DMBCF/0p1MFpJCkwZuyAWWNiqDq57aVw1Z3O+IqEo/x2d09CWloEs1vnO+e69",
-	"14MLXQyEJAnqHqJl26Eg52rtA3m3x1fbodYbTI59FB8IatDbWWhm0uLMtZatE2Qw4LUZrbRggPLL8TDA",
-	"+HHwjBuohQ9oILkWO6t/lq+oc0nY0xaGYZiaeY/FcY+8IYeILB5zh46b/X5/zlqOUyszTYX1Dp2MFE9N",
-	"uJb2jknAgHhR6FR+IqexPy+qooLBQIhINnqo4bGoijmYLDyvVk7ulb3yB73bouihCqyiXjZQwzPK4txo",
-	"c5HCsod7xgZquCtPWZWnkfIipWGl0lMMlEaHHqpKDxdIkDLdxrj3LvPLXVI9/VkSt2DHKMqfHLJ1l5ap",
-	"G0//ZAXCt+ZPidfU1U1u/r4DAAD//1M2C6bJAgAA",
+	"H4sIAAAAAAAC/5RRPU/DMBT8K9XBGCUpMHnsglhgYas6uO5L46qxjf2KhCL/d/SSqukXA9OTns937+56",
+	"+EBOBwuF57Iu5yhgXeOherDlPUHhkxKjwIaSiTaw9W5aflNM42Je1mWNXCBobpP8r9bWO2v2VPVOd5Rl",
+	"tyWWEXTUHTHFBLXs8RipgcJDZXwXvCPHqZogJ5533RHyqkCkFLxLNMg81bUM4x2TG9h1CHtrtFxa7ZJc",
+	"1yOZljoNdV9sfE3V4qiEnPO1Y/H28k8t7+ij+dPirerqrm4upKU4iLxtoPBKvLjIRCAT8XXCPS4SVD3c",
+	"MMchhUMNtd20LPiZb2bc0sy0OmrDFCEFfB1spA0UxwMVZ475Jwhz4mjddjzsaFNeT04npF/vyDAKhCgm",
+	"2Y6tOt2do05859rLESWh5d8BAG1u5uvJAgAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -181,9 +181,9 @@ type strictHandler struct {
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/0TMvU7HMAwE8He5OYpasWVnYGGhG2IoiSlG1A6OQUJV3h2lFfpP/rif7kDWvaqQeEM6",
-	"0PI77eu53psttmaW7aE8qj+R+HhX00rmTCfKWmhM/62EhObGsqH3AKOvbzYqSM+Xegn/Sl8/KDv6YCxv",
-	"OgoKtWxcnVWQsFBzBDj7J93OH7J25XOc4oQeoJVkrYyEuzjFGf0vAAD//4irytnRAAAA",
+	"H4sIAAAAAAAC/0yNMU/EMAxG/8s3R1ErtuwMLCx0QwwlMcWI2sExJ52q/PdT2+Fus56e37dBK8lcGQlP",
+	"cYgjAli+FGmDs/8SEiZqjoBCLRtXZ5U7vJC1E4xxiAN6QNa1qpB42yMtf9M6H+ez2WRzZlleyqv6G4kf",
+	"M9dKSNDPH8p7sppWMmc6nrIWerCaG8uC3gOM/v7ZqCC9n9ZH773fBgCssc7G0QAAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -198,15 +198,13 @@ func (response GetBionicleName200JSONResponse) VisitGetBionicleNameResponse(w ht
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetBionicleName400JSONResponse struct {
-	union json.RawMessage
-}
+type GetBionicleName400JSONResponse = Bionicle
 
 func (response GetBionicleName400JSONResponse) VisitGetBionicleNameResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
-	return json.NewEncoder(w).Encode(response.union)
+	return json.NewEncoder(w).Encode(response)
 }
 
 // StrictServerInterface represents all server handlers.
@@ -274,9 +272,9 @@ func (sh *strictHandler) GetBionicleName(w http.ResponseWriter, r *http.Request,
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/0SMWwrCMBBF93L1s+SBf1mHG4h1igNpEpJBkDBdu1Qtft5zOHeA81IQBu7U58ZVuGQE",
-	"XKkLJghLov98Uutf740zDjqhVMqxMgIuxhmPCTXKo++P9sYl85zIjhxX0p2dGy0IOIx5xTWd7Cexmz/w",
-	"5n+Fqr4DAAD//1rngHSiAAAA",
+	"H4sIAAAAAAAC/0TLTQrCMBBH8bv8dVnygbucwwvEOsWBNAnJIEiYnl2qFre/xxsolXKsjICLccZjAuel",
+	"IAwISyIEXKkLJtypz42rcMl/fFLrX/DGGQedUKM8+v7bG5fMcyI7clxJdzs3WhBwFPOKazrZz2I3f/Dm",
+	"f4eqvgcAYmAxZ6IAAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -11,9 +11,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
@@ -80,6 +82,9 @@ type Client struct {
 	// A list of callbacks for modifying requests which are generated before sending over
 	// the network.
 	RequestEditors []RequestEditorFn
+
+	// A logger which logs each request, along with its response.
+	Logger *slog.Logger
 }
 
 // ClientOption allows setting custom parameters during construction
@@ -126,6 +131,41 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 	}
 }
 
+// WithLogger logs each request, with its operation, method, path template,
+// parameters, status code and latency. The values of parameters which are
+// sensitive, as they're marked with `x-sensitive` or have the `password`
+// format, are redacted.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) error {
+		c.Logger = logger
+		return nil
+	}
+}
+
+// WithHeaders sets the given headers on every request, such as an API key
+// which every operation requires. Headers set by a request editor passed to
+// an operation take precedence.
+func WithHeaders(headers http.Header) ClientOption {
+	headers = headers.Clone()
+	return WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		for key, values := range headers {
+			req.Header.Del(key)
+			for _, value := range values {
+				req.Header.Add(key, value)
+			}
+		}
+		return nil
+	})
+}
+
+// WithUserAgent sets the User-Agent header of every request.
+func WithUserAgent(userAgent string) ClientOption {
+	return WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("User-Agent", userAgent)
+		return nil
+	})
+}
+
 // The interface specification for the client above.
 type ClientInterface interface {
 	// TestWithBody request with any body
@@ -143,7 +183,7 @@ func (c *Client) TestWithBody(ctx context.Context, contentType string, body io.R
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "Test", "/test", nil)
 }
 
 func (c *Client) TestWithApplicationTestPlusJSONBody(ctx context.Context, body TestApplicationTestPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -155,7 +195,7 @@ func (c *Client) TestWithApplicationTestPlusJSONBody(ctx context.Context, body T
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "Test", "/test", nil)
 }
 
 // NewTestRequestWithApplicationTestPlusJSONBody calls the generic Test builder with application/test+json body
@@ -198,6 +238,31 @@ func NewTestRequestWithBody(server string, contentType string, body io.Reader) (
 	return req, nil
 }
 
+// do sends the request, logging it with the Logger, if there is one. The
+// parameters of the request are only collected when they're logged.
+func (c *Client) do(req *http.Request, operationID, path string, params func() []slog.Attr) (*http.Response, error) {
+	if c.Logger == nil {
+		return c.Client.Do(req)
+	}
+	start := time.Now()
+	rsp, err := c.Client.Do(req)
+	attrs := []slog.Attr{
+		slog.String("operation", operationID),
+		slog.String("method", req.Method),
+		slog.String("path", path),
+		slog.Duration("latency", time.Since(start)),
+	}
+	if params != nil {
+		attrs = append(attrs, slog.Attr{Key: "params", Value: slog.GroupValue(params()...)})
+	}
+	if err != nil {
+		c.Logger.LogAttrs(req.Context(), slog.LevelError, "request failed", append(attrs, slog.Any("error", err))...)
+		return nil, err
+	}
+	c.Logger.LogAttrs(req.Context(), slog.LevelInfo, "request", append(attrs, slog.Int("status", rsp.StatusCode))...)
+	return rsp, nil
+}
+
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
 	for _, r := range c.RequestEditors {
 		if err := r(ctx, req); err != nil {
@@ -239,6 +304,29 @@ func WithBaseURL(baseURL string) ClientOption {
 	}
 }
 
+// ParseAs reads and closes the body of an HTTP response, decoding it as JSON
+// into a T. This is useful for responses which the typed fields of a
+// response don't cover, such as for a status code which isn't documented.
+func ParseAs[T any](rsp *http.Response) (T, error) {
+	var dest T
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return dest, err
+	}
+	return ResponseAs[T](bodyBytes)
+}
+
+// ResponseAs decodes the Body of a response returned by ClientWithResponses
+// as JSON into a T, such as `ResponseAs[Error](resp.Body)`.
+func ResponseAs[T any](body []byte) (T, error) {
+	var dest T
+	if err := json.Unmarshal(body, &dest); err != nil {
+		return dest, err
+	}
+	return dest, nil
+}
+
 // ClientWithResponsesInterface is the interface specification for the client with responses above.
 type ClientWithResponsesInterface interface {
 	// TestWithBodyWithResponse request with any body
@@ -357,13 +445,12 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/8xTO4/bMAz+KwbbrU6cR7toa4cCGdqhyFZ0UGzGUWCLikT3YgT+7wfSvgT3Au62m0xQ",
-	"/PQ9TF2gpDaQR88JzAVSecDWarnFxPINkQJGdqjdvcOmWkpVYSqjC+zIg4HvmY3R9hntM/Rdm/23TYcJ",
-	"cnCMrSKlDeYvkEKWkE/VCv7lwH1AMJA4Ol/DkENrz5sR+e16qgxyqCJWL4nwmBirjHZHLDm7c3zIbNNQ",
-	"aaXrbYuQv2poYtkRNWj9Y54n+oYcIp46F7ESS9MdV8DN0CgEcjjPappJc6YqDPzqJeA/eOow8W+V/VPQ",
-	"V9712/xRxx/CogDeZxoGwVBAb4MDA+v5Yi4MwfJBdRc8rWCN+hFPVqLYVGDG/RxFYuIfVPUyU5Jn9Dpu",
-	"Q2hcqQC96csxSYoPSy7V54h7MPCpuL2CYnoCxXaSqEGkQD6Naa4WX5//mZqo0uFhuA8AAP//SKFLc1MD",
-	"AAA=",
+	"H4sIAAAAAAAC/8ySPU/zQAzHv0rk59lIm77AchsMSB1gQN0QwzVx06uS8/XOgUZVvjs6J6TiRcDIdJbt",
+	"//n3t3wCcmi1M6BgOZ1N55BCTrUji5YDqBOEfIe1lnCNgePLrUNQQJs95gwpOE8OPRuUrq3BqpjHqMCQ",
+	"e+PYkAUF14n2XrcJbRO0TZ0866rBACkYxlqUMQ3qEUgkEaWPFvCUvg0N7I0toUuh1sdVr7waqzIhFgVi",
+	"8RWExcBYJD188mJ4l+iqolzHrNU1fmNomLIhqlDb93M+8HUpeDw0xmMRLQ1/jIKzoXGLx0lJk5icCIWC",
+	"uzYu/AEPDQa+F+zbqB7nLn/njxr+Exa77ieT0MUep3knVBkPB1eiPJFYR6OrAlR/jT0CBr6hoo09OVlG",
+	"K+3aucrkIpCfLvaB7PmkY/Tf4xYU/MvON5/11ZCtByCxGRzZ0O9qMbv8vPeSqJDmrnsdAHQxcQZTAwAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
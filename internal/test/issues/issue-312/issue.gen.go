@@ -11,9 +11,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
@@ -69,6 +71,9 @@ type Client struct {
 	// A list of callbacks for modifying requests which are generated before sending over
 	// the network.
 	RequestEditors []RequestEditorFn
+
+	// A logger which logs each request, along with its response.
+	Logger *slog.Logger
 }
 
 // ClientOption allows setting custom parameters during construction
@@ -115,6 +120,49 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 	}
 }
 
+// WithLogger logs each request, with its operation, method, path template,
+// parameters, status code and latency. The values of parameters which are
+// sensitive, as they're marked with `x-sensitive` or have the `password`
+// format, are redacted.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) error {
+		c.Logger = logger
+		return nil
+	}
+}
+
+// WithHeaders sets the given headers on every request, such as an API key
+// which every operation requires. Headers set by a request editor passed to
+// an operation take precedence.
+func WithHeaders(headers http.Header) ClientOption {
+	headers = headers.Clone()
+	return WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		for key, values := range headers {
+			req.Header.Del(key)
+			for _, value := range values {
+				req.Header.Add(key, value)
+			}
+		}
+		return nil
+	})
+}
+
+// WithUserAgent sets the User-Agent header of every request.
+func WithUserAgent(userAgent string) ClientOption {
+	return WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("User-Agent", userAgent)
+		return nil
+	})
+}
+
+// getPetLogAttrs returns the parameters of a GetPet
+// request, which are logged along with it.
+func getPetLogAttrs(petId string) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	logAttrs_ = append(logAttrs_, slog.Any("petId", petId))
+	return logAttrs_
+}
+
 // The interface specification for the client above.
 type ClientInterface interface {
 	// GetPet request
@@ -135,7 +183,7 @@ func (c *Client) GetPet(ctx context.Context, petId string, reqEditors ...Request
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "GetPet", "/pets/{petId}", func() []slog.Attr { return getPetLogAttrs(petId) })
 }
 
 func (c *Client) ValidatePetsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -147,7 +195,7 @@ func (c *Client) ValidatePetsWithBody(ctx context.Context, contentType string, b
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "ValidatePets", "/pets:validate", nil)
 }
 
 func (c *Client) ValidatePets(ctx context.Context, body ValidatePetsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -159,7 +207,7 @@ func (c *Client) ValidatePets(ctx context.Context, body ValidatePetsJSONRequestB
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "ValidatePets", "/pets:validate", nil)
 }
 
 // NewGetPetRequest generates requests for GetPet
@@ -172,6 +220,7 @@ func NewGetPetRequest(server string, petId string) (*http.Request, error) {
 	if err != nil {
 		return nil, err
 	}
+	pathParam0 = escapeDotSegments(pathParam0)
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
@@ -236,6 +285,31 @@ func NewValidatePetsRequestWithBody(server string, contentType string, body io.R
 	return req, nil
 }
 
+// do sends the request, logging it with the Logger, if there is one. The
+// parameters of the request are only collected when they're logged.
+func (c *Client) do(req *http.Request, operationID, path string, params func() []slog.Attr) (*http.Response, error) {
+	if c.Logger == nil {
+		return c.Client.Do(req)
+	}
+	start := time.Now()
+	rsp, err := c.Client.Do(req)
+	attrs := []slog.Attr{
+		slog.String("operation", operationID),
+		slog.String("method", req.Method),
+		slog.String("path", path),
+		slog.Duration("latency", time.Since(start)),
+	}
+	if params != nil {
+		attrs = append(attrs, slog.Attr{Key: "params", Value: slog.GroupValue(params()...)})
+	}
+	if err != nil {
+		c.Logger.LogAttrs(req.Context(), slog.LevelError, "request failed", append(attrs, slog.Any("error", err))...)
+		return nil, err
+	}
+	c.Logger.LogAttrs(req.Context(), slog.LevelInfo, "request", append(attrs, slog.Int("status", rsp.StatusCode))...)
+	return rsp, nil
+}
+
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
 	for _, r := range c.RequestEditors {
 		if err := r(ctx, req); err != nil {
@@ -250,6 +324,56 @@ func (c *Client) applyEditors(ctx context.Context, req *http.Request, additional
 	return nil
 }
 
+// escapePathParam escapes the value of a path parameter, so that it's sent as
+// the parameter in the path of a request. The value is escaped with
+// url.PathEscape, so that a `/`, `%`, `?` or unicode character in it is
+// percent-encoded, unless allowReserved is set, for a parameter with
+// `allowReserved: true`, when the reserved characters of RFC 3986, such as `/`,
+// and the percent-encoded octets in it are kept as they are, as they are by the
+// reserved expansion of RFC 6570.
+func escapePathParam(value string, allowReserved bool) string {
+	if allowReserved {
+		return escapeDotSegments(escapeReservedPathParam(value))
+	}
+	return escapeDotSegments(url.PathEscape(value))
+}
+
+// escapeDotSegments percent-encodes the segments of an escaped path parameter
+// which are `.` or `..`, so that they aren't removed from the path of a
+// request as dot-segments.
+func escapeDotSegments(escaped string) string {
+	segments := strings.Split(escaped, "/")
+	for i, segment := range segments {
+		if segment == "." || segment == ".." {
+			segments[i] = strings.ReplaceAll(segment, ".", "%2E")
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// escapeReservedPathParam percent-encodes the characters of value which can't
+// be in the path of a URL as they are, keeping its reserved characters and
+// percent-encoded octets, apart from `?` and `#`, which would end the path.
+func escapeReservedPathParam(value string) string {
+	const hexDigits = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', strings.IndexByte("-._~:/@!$&'()*+,;=[]", c) >= 0:
+			b.WriteByte(c)
+		case c == '%' && i+2 < len(value) && strings.IndexByte("0123456789ABCDEFabcdef", value[i+1]) >= 0 && strings.IndexByte("0123456789ABCDEFabcdef", value[i+2]) >= 0:
+			b.WriteString(value[i : i+3])
+			i += 2
+		default:
+			b.WriteByte('%')
+			b.WriteByte(hexDigits[c>>4])
+			b.WriteByte(hexDigits[c&15])
+		}
+	}
+	return b.String()
+}
+
 // ClientWithResponses builds on ClientInterface to offer response payloads
 type ClientWithResponses struct {
 	ClientInterface
@@ -277,6 +401,29 @@ func WithBaseURL(baseURL string) ClientOption {
 	}
 }
 
+// ParseAs reads and closes the body of an HTTP response, decoding it as JSON
+// into a T. This is useful for responses which the typed fields of a
+// response don't cover, such as for a status code which isn't documented.
+func ParseAs[T any](rsp *http.Response) (T, error) {
+	var dest T
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return dest, err
+	}
+	return ResponseAs[T](bodyBytes)
+}
+
+// ResponseAs decodes the Body of a response returned by ClientWithResponses
+// as JSON into a T, such as `ResponseAs[Error](resp.Body)`.
+func ResponseAs[T any](body []byte) (T, error) {
+	var dest T
+	if err := json.Unmarshal(body, &dest); err != nil {
+		return dest, err
+	}
+	return dest, nil
+}
+
 // ClientWithResponsesInterface is the interface specification for the client with responses above.
 type ClientWithResponsesInterface interface {
 	// GetPetWithResponse request
@@ -314,6 +461,7 @@ type ValidatePetsResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
 	JSON200      *[]Pet
+	JSONDefault  *Error
 }
 
 // Status returns HTTPResponse.Status
@@ -405,6 +553,13 @@ func ParseValidatePetsResponse(rsp *http.Response) (*ValidatePetsResponse, error
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
 	}
 
 	return response, nil
@@ -486,15 +641,15 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/6xUPW/bMBD9K8S1o2A58aaxRVF4KTIUXQIPrHSSmZof5Z2MGgb/e3Gk7NqxjAzNFIX3",
-	"eO/de0cfofU2eIeOCZojULtFq/Pnlxh9lI8QfcDIBvNx6zuUvx1SG01g4x00BaxyrYLeR6sZGjCOV49Q",
-	"AR8Cln9xwAipAotEerjb6FQ+XyWOxg2QUgURf48mYgfNM0yEJ/gmVfCEfCvaaTvD9X2LSirK94q3qALy",
-	"4k3K3GpzRvmfL9gyFOJv2ha+W3a6T08X/CQCDKPN+FdKzqQ6Rn2YVUYz0gRnXO9vFXzeYvuLVFGrkFod",
-	"jBtETtBRW2SMJIYY3knDNdGIavXwqBiJoYI9RiqdHhbLxVIU+oBOBwMNrPJRBUHzNk9Ty3z1MSCvuyQH",
-	"Q4lKyLUoWnfQwFdkiVDunSU0z0cwQiO9oJrihNwJLk3gOGI1LfGMgWkjYAreUQnkcbksO+0YXRajQ9iZ",
-	"NsupX0hmO170+xixhwY+1P9eTT09mVpUZ6+vPd7rnekk2pwXjdbqeChzyqkazB6dMh06Nr3BuMi47FWT",
-	"72rOqxs88W2CPyZE3h2oXnl5qj6VoviExJ98d3jPqcvWp+t1lCTSf7p9fgdv2n7zMu7HQJBrvR53/G4u",
-	"lN/KGdrR4Z+ALWOncMJcLsF1fCml9DcAAP//Z6kfG5EFAAA=",
+	"H4sIAAAAAAAC/6xTsW7bMBD9FeLaUbCcZNPYogi8FB6KLkYGVnqSmFokyzsZNQz9e0EyqRXLaD1kEsU7",
+	"3nv37t2JnIfV3lBFD6v1ak0FGds6qk50QGDjLFV09xIRI3tQRRvmEerh7l4JWKigBlwH4yWnf+5R/2Tl",
+	"g/MIClxrb2ynXKu8DnqAIDBNBXktPUek0kO4Oui9abQg3njHEr88DoMOR6ro+0tUxdwF5GU0IusY2zSz",
+	"6DYHA36NYPnkmmPEiL8moKFKwoiCamcFNsFr7/emToXKZ45IJ+K6x6Dj6WNASxV9KGs3eGdhhcsc5XIL",
+	"+aoHME3TNEVI9s4yUrsNWj3uJR/nXYwWvz1qQaMQggv0TmS+pGKZyf16vQRO0r9qdzOkHD2oIh2CPkbf",
+	"CAa+QZdEJJNJgy9PHrJppvi0w8XYHyGRl+rMAVaZBlZMaxBWiyE/QmLxgmYmq3YnsnoAVZQwkrvjj5ae",
+	"isXkF62xBGM7mqanixH+W0V6PxPNxDonnCuk4xYy4+x+PKOWN93tsghPBeWdFJO7SLeLbi9361sPFTPj",
+	"AkufVmxFidFfk9+Aztfh+YqRrsPzDJ9Xc79dDitSy5Z/s907ql0DKmgAs+6u6JHi54LGCjoEKqh1YdCS",
+	"rx7uFxQTmEqvp3P5/wqbn72m5zH/GQA3+KcukQUAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -38,12 +38,12 @@ type DocumentStatus struct {
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/7ySz0/rMAzH/5XK7x27tq/vljMIIYQ47AgIhdRbM9o4Styxaer/jpxuReOHxIlL4zq2",
-	"vx/HPoCh3pNDxxHUAaJpsdfJvCAz9OhYbB/IY2CL6cbpHuXkvUdQEDlYt4Yxh8iahxSCbuhB3QM5hBz4",
-	"leTbBpS/FQ0BHvMP6TnsFmtaiHMxCcwET8up7jjOSfS8QcOieQpaztrnsFvdDV/Rfq4lLutWJMENRhOs",
-	"Z0sOFNzqF8ziEDDjVnMW0Awh2i1mUiFmOmDWatd02GSTeLd/cNKx5U4UcKd730nvWwxxqlkVVfFPGiCP",
-	"TnsLCv4XVVFDDl5zm9jLU6I6wBrTJKS6FqzrBhRcTvdXyJBDwOjJxantuqrkMOT4OEPtfWdNyi03URhO",
-	"4xbrb8AVKPhTvu9DeVyGct6E9ETnT3N3I94xn1nrH8DWv0E7L813zOP4FgAA//8tucJ2/gIAAA==",
+	"H4sIAAAAAAAC/8SRQU/jMBCF/0r0do9uks3efN4VQghx4AgIGWfauCS2ZTulVdT/jiamLVRIcMOH2NF7",
+	"fvONZ4LzZJU3kPhb1mUDAWOXDnLChkI0zkKiLuvyDwSSST1BgrZq8D1BoKWog/Ep+67VMxVxDFSkTqUi",
+	"kB5DNBsq0s5TLFSgolO27aktfHCeQr+7t9gLeJW6yDWrQ7ScsKLEG/sUF7hsIfE/6xeUIBAoemcjzVeb",
+	"uubtI9HNFQS0s4nsHKa8742e46p1ZMuEqDsaFJ9+B1pC4lel3eCdJZtildVY/XN6HDhln5c4sjbfgG1+",
+	"iPY2qTTGA/Ne4OQ9hc3HY39yAo8LEu5pTZrfOQ8rmYxu1UDvXDEFY1c8xZiLyQnbxcotWF9k8zH98Q1I",
+	"nN0WIDsOkHdwllh9cfztAvHf0o0BDzP/WWNfwm5UP35GO6/XAQB8jIaD/gIAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
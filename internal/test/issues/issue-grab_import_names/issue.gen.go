@@ -11,9 +11,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
@@ -54,6 +56,9 @@ type Client struct {
 	// A list of callbacks for modifying requests which are generated before sending over
 	// the network.
 	RequestEditors []RequestEditorFn
+
+	// A logger which logs each request, along with its response.
+	Logger *slog.Logger
 }
 
 // ClientOption allows setting custom parameters during construction
@@ -100,6 +105,57 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 	}
 }
 
+// WithLogger logs each request, with its operation, method, path template,
+// parameters, status code and latency. The values of parameters which are
+// sensitive, as they're marked with `x-sensitive` or have the `password`
+// format, are redacted.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) error {
+		c.Logger = logger
+		return nil
+	}
+}
+
+// WithHeaders sets the given headers on every request, such as an API key
+// which every operation requires. Headers set by a request editor passed to
+// an operation take precedence.
+func WithHeaders(headers http.Header) ClientOption {
+	headers = headers.Clone()
+	return WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		for key, values := range headers {
+			req.Header.Del(key)
+			for _, value := range values {
+				req.Header.Add(key, value)
+			}
+		}
+		return nil
+	})
+}
+
+// WithUserAgent sets the User-Agent header of every request.
+func WithUserAgent(userAgent string) ClientOption {
+	return WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("User-Agent", userAgent)
+		return nil
+	})
+}
+
+// getFooLogAttrs returns the parameters of a GetFoo
+// request, which are logged along with it.
+func getFooLogAttrs(params *GetFooParams) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	if params == nil {
+		return logAttrs_
+	}
+	if params.Foo != nil {
+		logAttrs_ = append(logAttrs_, slog.Any("Foo", *params.Foo))
+	}
+	if params.Bar != nil {
+		logAttrs_ = append(logAttrs_, slog.Any("Bar", *params.Bar))
+	}
+	return logAttrs_
+}
+
 // The interface specification for the client above.
 type ClientInterface interface {
 	// GetFoo request
@@ -115,7 +171,7 @@ func (c *Client) GetFoo(ctx context.Context, params *GetFooParams, reqEditors ..
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "GetFoo", "/foo", func() []slog.Attr { return getFooLogAttrs(params) })
 }
 
 // NewGetFooRequest generates requests for GetFoo
@@ -171,6 +227,31 @@ func NewGetFooRequest(server string, params *GetFooParams) (*http.Request, error
 	return req, nil
 }
 
+// do sends the request, logging it with the Logger, if there is one. The
+// parameters of the request are only collected when they're logged.
+func (c *Client) do(req *http.Request, operationID, path string, params func() []slog.Attr) (*http.Response, error) {
+	if c.Logger == nil {
+		return c.Client.Do(req)
+	}
+	start := time.Now()
+	rsp, err := c.Client.Do(req)
+	attrs := []slog.Attr{
+		slog.String("operation", operationID),
+		slog.String("method", req.Method),
+		slog.String("path", path),
+		slog.Duration("latency", time.Since(start)),
+	}
+	if params != nil {
+		attrs = append(attrs, slog.Attr{Key: "params", Value: slog.GroupValue(params()...)})
+	}
+	if err != nil {
+		c.Logger.LogAttrs(req.Context(), slog.LevelError, "request failed", append(attrs, slog.Any("error", err))...)
+		return nil, err
+	}
+	c.Logger.LogAttrs(req.Context(), slog.LevelInfo, "request", append(attrs, slog.Int("status", rsp.StatusCode))...)
+	return rsp, nil
+}
+
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
 	for _, r := range c.RequestEditors {
 		if err := r(ctx, req); err != nil {
@@ -212,6 +293,29 @@ func WithBaseURL(baseURL string) ClientOption {
 	}
 }
 
+// ParseAs reads and closes the body of an HTTP response, decoding it as JSON
+// into a T. This is useful for responses which the typed fields of a
+// response don't cover, such as for a status code which isn't documented.
+func ParseAs[T any](rsp *http.Response) (T, error) {
+	var dest T
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return dest, err
+	}
+	return ResponseAs[T](bodyBytes)
+}
+
+// ResponseAs decodes the Body of a response returned by ClientWithResponses
+// as JSON into a T, such as `ResponseAs[Error](resp.Body)`.
+func ResponseAs[T any](body []byte) (T, error) {
+	var dest T
+	if err := json.Unmarshal(body, &dest); err != nil {
+		return dest, err
+	}
+	return dest, nil
+}
+
 // ClientWithResponsesInterface is the interface specification for the client with responses above.
 type ClientWithResponsesInterface interface {
 	// GetFooWithResponse request
@@ -366,12 +470,12 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/5SRT2vjQAzFv4rQ2SgmWfbg47Lssve9lVImthxP8fypJJekwd+9zDin0Bzqi5CQnn/z",
-	"3hV9HBN2VzRvM2OHRIQNvrOoTxE7bKmlFtcGU+bosscOD9TSHhvMziYtt7sxVY0TWykDay8+2yawCabM",
-	"4srk34Ad/mX7k1KVEBfYWBS7p/vLo1P++YPgeDFWgn7yBH2Kxmcj4H5KBCySRAnGYASnD58JJrNM4BPB",
-	"q6ZIcOM+FApfZCd2Aws2GF0oL95IhN8WLzxgN7pZuUHtJw6uWnPJZU9NfDzhujb3oLdfvJRFJSi+EMgS",
-	"zQcm2O6UoLa/l82HrfvvA8MiM8E5zAQXF+aHnL+cfI/zuWxrTlG5xrRv21KqhbEm5XKefV95dsWtMnus",
-	"tzZfRLvW7zMAAP//fFCj9EgCAAA=",
+	"H4sIAAAAAAAC/5SRT2vcQAzFv4rQ2WjNbulhjqW09N5bKWXWltdTPH+ikcNulv3uQeMlh5AQ4sswHunp",
+	"9/SumAsnXwI6PFBPe+wwpCmju6IGXRgdEhF2+MhSQ07osKeeerx1WLzO1Sp3U24dJ1Y7ihcfWVkquj9X",
+	"TD6azI+cmzg6nNmPLNjhyHWQUHQTPvrKX78QHC/KlWCYA8GQk/JZCXiYMwGLZKkEU1SC01MoBLNqIQiZ",
+	"4H/NieBu6GDQdZg5+mbmUgyiqoR0MnjhhzUIj+gmv1S+dS+g37x8AHof8c9EK4HtgUDWpCEywTajErTr",
+	"91W8tW233yEyrLIQnONCcPFx+STn39cwWzzCteRUueWx73s73qpr+0wtJl/KEoYGt7PV2b93QezrzPjm",
+	"5teIDn+yWqj29DwAYXg35UgCAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -46,14 +46,14 @@ func TestIllegalEnumNames(t *testing.T) {
 		}
 	}
 
-	require.Equal(t, `""`, constDefs["BarEmpty"])
-	require.Equal(t, `"Bar"`, constDefs["BarBar"])
-	require.Equal(t, `"Foo"`, constDefs["BarFoo"])
-	require.Equal(t, `"Foo Bar"`, constDefs["BarFooBar"])
-	require.Equal(t, `"Foo-Bar"`, constDefs["BarFooBar1"])
-	require.Equal(t, `"1Foo"`, constDefs["BarN1Foo"])
-	require.Equal(t, `" Foo"`, constDefs["BarFoo1"])
-	require.Equal(t, `" Foo "`, constDefs["BarFoo2"])
-	require.Equal(t, `"_Foo_"`, constDefs["BarUnderscoreFoo"])
-	require.Equal(t, `"1"`, constDefs["BarN1"])
+	require.Equal(t, `""`, constDefs["Empty"])
+	require.Equal(t, `"Bar"`, constDefs["BarParam"])
+	require.Equal(t, `"Foo"`, constDefs["Foo"])
+	require.Equal(t, `"Foo Bar"`, constDefs["FooSpaceBar"])
+	require.Equal(t, `"Foo-Bar"`, constDefs["FooMinusBar"])
+	require.Equal(t, `"1Foo"`, constDefs["N1Foo"])
+	require.Equal(t, `" Foo"`, constDefs["SpaceFoo"])
+	require.Equal(t, `" Foo "`, constDefs["SpaceFooSpace"])
+	require.Equal(t, `"_Foo_"`, constDefs["UnderscoreFoo"])
+	require.Equal(t, `"1"`, constDefs["N1"])
 }
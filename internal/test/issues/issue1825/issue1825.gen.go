@@ -24,9 +24,10 @@ type Container struct {
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/1SOQQrCMBBF7/J1WZKCu+yKB/AIMg1Tm2ozQ5KNlNxdUizirP7M8B5/g5dVJXIsGW5D",
-	"9jOvtMerxEIhcmqLJlFOJfD+knFhX+7U8jnxBAdjrJJ/0oMHm5W9edP6Otmf3X7V9rbDA2p3eMZ/z3E1",
-	"S5aI2qZDiJPAbQ1SjqQBDhfTmx4dlMrcetX6CQAA//80e7ipzwAAAA==",
+	"H4sIAAAAAAAC/1SOQWrEMAxF7/LbZcYe6M67oQfoEYrGozROE0vYTmkIuXuxSSij1f966KENohxJAxze",
+	"zNVc0SHEXuA2/F7kh9NE64VUp8APOOSSFl+WxI8TYu+gVIYMt+0dvMwqkWOpHdkPPFOL7xILhcipFk2i",
+	"nErghuQ+si+fVPNr4h4Oxlgl/01ffLNZ2ZuV5unF/tvtobYf7fhW3zg892fPuTVjloi9zd8ALRJkZPcA",
+	"AAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -21,9 +21,9 @@ type ObjectA struct {
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/xyNwQrCMBBEf6XMOZSKt9x69GR/IYa1jZrdJVkEKfl3SeYyj3mHORElqzCxVfgTNR6U",
-	"w8D740XR1o5aRKlYoiE4ZOptPyV4VCuJd7Qeh8RPGTLZp9stxHfYaVqndbvB4UulJmF4XOZlXtAcRImD",
-	"Jnhcx+SgwY5+1do/AAD//yAOcwugAAAA",
+	"H4sIAAAAAAAC/xyNwQrCMBBEf6XMOZSKt9x69GR/IYa1XbW7S7IIUvLv0szpDcPwDqiRJGNEXMdpnBDA",
+	"8lTEA87+IUQsKb/TSsM8zMsNAV8qlVUQcemPFmDJt4p4tICsu6mQ+NlR80Z76nh/vCj7fKIVNSrO1AdJ",
+	"O3XfzwgR1QvLitbzHwDZd9B4oAAAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -11,9 +11,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
@@ -141,6 +143,9 @@ type Client struct {
 	// A list of callbacks for modifying requests which are generated before sending over
 	// the network.
 	RequestEditors []RequestEditorFn
+
+	// A logger which logs each request, along with its response.
+	Logger *slog.Logger
 }
 
 // ClientOption allows setting custom parameters during construction
@@ -187,6 +192,49 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 	}
 }
 
+// WithLogger logs each request, with its operation, method, path template,
+// parameters, status code and latency. The values of parameters which are
+// sensitive, as they're marked with `x-sensitive` or have the `password`
+// format, are redacted.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) error {
+		c.Logger = logger
+		return nil
+	}
+}
+
+// WithHeaders sets the given headers on every request, such as an API key
+// which every operation requires. Headers set by a request editor passed to
+// an operation take precedence.
+func WithHeaders(headers http.Header) ClientOption {
+	headers = headers.Clone()
+	return WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		for key, values := range headers {
+			req.Header.Del(key)
+			for _, value := range values {
+				req.Header.Add(key, value)
+			}
+		}
+		return nil
+	})
+}
+
+// WithUserAgent sets the User-Agent header of every request.
+func WithUserAgent(userAgent string) ClientOption {
+	return WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("User-Agent", userAgent)
+		return nil
+	})
+}
+
+// getHTTPPetLogAttrs returns the parameters of a GetHTTPPet
+// request, which are logged along with it.
+func getHTTPPetLogAttrs(petID string) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	logAttrs_ = append(logAttrs_, slog.Any("petId", petID))
+	return logAttrs_
+}
+
 // The interface specification for the client above.
 type ClientInterface interface {
 	// GetHTTPPet request
@@ -202,7 +250,7 @@ func (c *Client) GetHTTPPet(ctx context.Context, petID string, reqEditors ...Req
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "GetHTTPPet", "/api/pets/{petId}", func() []slog.Attr { return getHTTPPetLogAttrs(petID) })
 }
 
 // NewGetHTTPPetRequest generates requests for GetHTTPPet
@@ -215,6 +263,7 @@ func NewGetHTTPPetRequest(server string, petID string) (*http.Request, error) {
 	if err != nil {
 		return nil, err
 	}
+	pathParam0 = escapeDotSegments(pathParam0)
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
@@ -239,6 +288,31 @@ func NewGetHTTPPetRequest(server string, petID string) (*http.Request, error) {
 	return req, nil
 }
 
+// do sends the request, logging it with the Logger, if there is one. The
+// parameters of the request are only collected when they're logged.
+func (c *Client) do(req *http.Request, operationID, path string, params func() []slog.Attr) (*http.Response, error) {
+	if c.Logger == nil {
+		return c.Client.Do(req)
+	}
+	start := time.Now()
+	rsp, err := c.Client.Do(req)
+	attrs := []slog.Attr{
+		slog.String("operation", operationID),
+		slog.String("method", req.Method),
+		slog.String("path", path),
+		slog.Duration("latency", time.Since(start)),
+	}
+	if params != nil {
+		attrs = append(attrs, slog.Attr{Key: "params", Value: slog.GroupValue(params()...)})
+	}
+	if err != nil {
+		c.Logger.LogAttrs(req.Context(), slog.LevelError, "request failed", append(attrs, slog.Any("error", err))...)
+		return nil, err
+	}
+	c.Logger.LogAttrs(req.Context(), slog.LevelInfo, "request", append(attrs, slog.Int("status", rsp.StatusCode))...)
+	return rsp, nil
+}
+
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
 	for _, r := range c.RequestEditors {
 		if err := r(ctx, req); err != nil {
@@ -253,6 +327,56 @@ func (c *Client) applyEditors(ctx context.Context, req *http.Request, additional
 	return nil
 }
 
+// escapePathParam escapes the value of a path parameter, so that it's sent as
+// the parameter in the path of a request. The value is escaped with
+// url.PathEscape, so that a `/`, `%`, `?` or unicode character in it is
+// percent-encoded, unless allowReserved is set, for a parameter with
+// `allowReserved: true`, when the reserved characters of RFC 3986, such as `/`,
+// and the percent-encoded octets in it are kept as they are, as they are by the
+// reserved expansion of RFC 6570.
+func escapePathParam(value string, allowReserved bool) string {
+	if allowReserved {
+		return escapeDotSegments(escapeReservedPathParam(value))
+	}
+	return escapeDotSegments(url.PathEscape(value))
+}
+
+// escapeDotSegments percent-encodes the segments of an escaped path parameter
+// which are `.` or `..`, so that they aren't removed from the path of a
+// request as dot-segments.
+func escapeDotSegments(escaped string) string {
+	segments := strings.Split(escaped, "/")
+	for i, segment := range segments {
+		if segment == "." || segment == ".." {
+			segments[i] = strings.ReplaceAll(segment, ".", "%2E")
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// escapeReservedPathParam percent-encodes the characters of value which can't
+// be in the path of a URL as they are, keeping its reserved characters and
+// percent-encoded octets, apart from `?` and `#`, which would end the path.
+func escapeReservedPathParam(value string) string {
+	const hexDigits = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', strings.IndexByte("-._~:/@!$&'()*+,;=[]", c) >= 0:
+			b.WriteByte(c)
+		case c == '%' && i+2 < len(value) && strings.IndexByte("0123456789ABCDEFabcdef", value[i+1]) >= 0 && strings.IndexByte("0123456789ABCDEFabcdef", value[i+2]) >= 0:
+			b.WriteString(value[i : i+3])
+			i += 2
+		default:
+			b.WriteByte('%')
+			b.WriteByte(hexDigits[c>>4])
+			b.WriteByte(hexDigits[c&15])
+		}
+	}
+	return b.String()
+}
+
 // ClientWithResponses builds on ClientInterface to offer response payloads
 type ClientWithResponses struct {
 	ClientInterface
@@ -280,6 +404,29 @@ func WithBaseURL(baseURL string) ClientOption {
 	}
 }
 
+// ParseAs reads and closes the body of an HTTP response, decoding it as JSON
+// into a T. This is useful for responses which the typed fields of a
+// response don't cover, such as for a status code which isn't documented.
+func ParseAs[T any](rsp *http.Response) (T, error) {
+	var dest T
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return dest, err
+	}
+	return ResponseAs[T](bodyBytes)
+}
+
+// ResponseAs decodes the Body of a response returned by ClientWithResponses
+// as JSON into a T, such as `ResponseAs[Error](resp.Body)`.
+func ResponseAs[T any](body []byte) (T, error) {
+	var dest T
+	if err := json.Unmarshal(body, &dest); err != nil {
+		return dest, err
+	}
+	return dest, nil
+}
+
 // ClientWithResponsesInterface is the interface specification for the client with responses above.
 type ClientWithResponsesInterface interface {
 	// GetHTTPPetWithResponse request
@@ -505,15 +652,15 @@ func HandlerWithOptions(si ServerInterface, options GorillaServerOptions) http.H
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/4RTTW/bPAz+KwLf96jZWXrzvdh6WXvIrQgQzaJtFrakSXSwLvB/HyglWZZk6CWRRerR",
-	"8yEeoPVT8A4dJ2gOkNoBJ5OXjzH6KIsQfcDIhHm79Rbl32JqIwUm76ApzSrXNHQ+ToahAXL8sAYN/B6w",
-	"fGKPERYNE6Zk+n8Cncrno4kjuR6WRUPEHzNFtNC8wvHCU/t20fDs8Llb80CuT7fw3zxTi4oHw4oHVLvS",
-	"uFOUlPOsWhOIzUgJLWjwggXN67UHZOX3WtUVN7KwPfP339+wZVj0faizY/NM9kPVd5FF+wvybWDOTHd8",
-	"3gyopKJ8l40IyNXtxboQuns6ICupVh/yPYrKRG6JSze5zmdLiUepPf40UxgxPyjV+ViyEoBPTqwa6RfG",
-	"nfIzh5mVL7Q07DGmQvBztapWwt8HdCYQNPCQtzQEw0M2pjaB6oCc6kNAfrKLbPbFQjHQCOqThQa+IH/d",
-	"bF7EXjkfzYSMMeWXQXKdYJ4UNpDR4NIDjjPq43BdPJ2zX1tpTsG7VDJbr1Zl1hyjy4RMCCO1mVL9lkTj",
-	"4QLv/4gdNPBf/Wea6+Mo18I6m/x3hHszkpUQc1xpniYT34vWHG1Pe3SKLDqmjjBWArL8DgAA//+VcR3v",
-	"MAQAAA==",
+	"H4sIAAAAAAAC/6RTQW/bPAz9KwK/76jFWXrzvdh6WXPIrQgQzaZtFrakUXSwLtB/HyhnTbYU24D5Ilt8",
+	"enyPej5BiOhdJKjhbrVercEC+S5AfYIjcqLgoYb354qQjAg13H91UxzRNKFF0wU2MqA5eDfhOx94ciN9",
+	"Qz6YMEucxYQoSpMtRCdDUurKRaoiSqpOEeWhzbrZo+iS5mly/AI1fEAxEcX0dERvqEUv1BHyCqzKZqe8",
+	"D+2C/LjbbbcooF3YTSjICeqnE6gsqKH0Ke70w8kAFhi/zMTYQi08o4XUDDg5FSEvUQ8lYfI95LxXcIrB",
+	"JywGNuu1Li2mhmnxV8PRjdSqYrDQBC/oiyEX40hNEVs9J0Werjr9z9hBDf9VTZhi8OglVUs1Veonnx8L",
+	"F8CFobxuUa40h8/P2MhP7p5gnknNl1nsLUTW+QktZkrxxrT9xd1uwHIbil5BPpP91TFFmtCVmESUFRQ/",
+	"98yBleBaqEYKLEyYkuvf0Frql6bkBXtksNBp8GTZutvcyCjNSmAhX+j/qH459gNeZD96fOw2MpDv020G",
+	"PgWhBo0MTpa/YgEeDCXjg5jGRRI3UkK9j6BcJaW/uzxqb+dA7RtTUH3/SPU6h9d5lnTknPeawu8DADro",
+	"BbYwBAAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
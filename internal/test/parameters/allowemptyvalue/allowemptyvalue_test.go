@@ -0,0 +1,97 @@
+package allowemptyvalue
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/oapi-codegen/nullable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type server struct {
+	query  string
+	params ListItemsParams
+}
+
+func (s *server) ListItems(w http.ResponseWriter, r *http.Request, params ListItemsParams) {
+	s.query = r.URL.RawQuery
+	s.params = params
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func TestAllowEmptyValue(t *testing.T) {
+	s := &server{}
+	ts := httptest.NewServer(Handler(s))
+	defer ts.Close()
+
+	client, err := NewClient(ts.URL)
+	require.NoError(t, err)
+
+	send := func(t *testing.T, params ListItemsParams) {
+		t.Helper()
+		*s = server{}
+		rsp, err := client.ListItems(context.Background(), &params)
+		require.NoError(t, err)
+		_ = rsp.Body.Close()
+		require.Equal(t, http.StatusNoContent, rsp.StatusCode)
+	}
+
+	t.Run("a value is sent as it is", func(t *testing.T) {
+		send(t, ListItemsParams{
+			Limit:   nullable.NewNullableWithValue(5),
+			Tags:    nullable.NewNullableWithValue([]string{"a", "b"}),
+			Verbose: nullable.NewNullableWithValue(true),
+		})
+		assert.Equal(t, "limit=5&tags=a&tags=b&verbose=true", s.query)
+		assert.Equal(t, nullable.NewNullableWithValue(5), s.params.Limit)
+		assert.Equal(t, nullable.NewNullableWithValue([]string{"a", "b"}), s.params.Tags)
+		assert.Equal(t, nullable.NewNullableWithValue(true), s.params.Verbose)
+	})
+
+	t.Run("null is sent as an empty value", func(t *testing.T) {
+		send(t, ListItemsParams{
+			Limit:   nullable.NewNullNullable[int](),
+			Tags:    nullable.NewNullNullable[[]string](),
+			Verbose: nullable.NewNullNullable[bool](),
+		})
+		assert.Equal(t, "limit=&tags=&verbose=", s.query)
+		assert.True(t, s.params.Limit.IsNull())
+		assert.True(t, s.params.Tags.IsNull())
+		assert.True(t, s.params.Verbose.IsNull())
+	})
+
+	t.Run("an unspecified parameter is left out", func(t *testing.T) {
+		send(t, ListItemsParams{
+			Verbose: nullable.NewNullNullable[bool](),
+		})
+		assert.Equal(t, "verbose=", s.query)
+		assert.False(t, s.params.Limit.IsSpecified())
+		assert.False(t, s.params.Tags.IsSpecified())
+	})
+
+	t.Run("a parameter without a value is empty", func(t *testing.T) {
+		rsp, err := http.Get(ts.URL + "/items?verbose&limit")
+		require.NoError(t, err)
+		_ = rsp.Body.Close()
+		require.Equal(t, http.StatusNoContent, rsp.StatusCode)
+		assert.True(t, s.params.Verbose.IsNull())
+		assert.True(t, s.params.Limit.IsNull())
+	})
+
+	t.Run("a required parameter must be present", func(t *testing.T) {
+		rsp, err := client.ListItems(context.Background(), &ListItemsParams{})
+		require.NoError(t, err)
+		_ = rsp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, rsp.StatusCode)
+	})
+
+	t.Run("an invalid value is rejected", func(t *testing.T) {
+		rsp, err := http.Get(ts.URL + "/items?verbose=true&limit=many")
+		require.NoError(t, err)
+		_ = rsp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, rsp.StatusCode)
+	})
+}
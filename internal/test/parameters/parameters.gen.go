@@ -11,9 +11,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
@@ -163,6 +165,9 @@ type Client struct {
 	// A list of callbacks for modifying requests which are generated before sending over
 	// the network.
 	RequestEditors []RequestEditorFn
+
+	// A logger which logs each request, along with its response.
+	Logger *slog.Logger
 }
 
 // ClientOption allows setting custom parameters during construction
@@ -209,6 +214,298 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 	}
 }
 
+// WithLogger logs each request, with its operation, method, path template,
+// parameters, status code and latency. The values of parameters which are
+// sensitive, as they're marked with `x-sensitive` or have the `password`
+// format, are redacted.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) error {
+		c.Logger = logger
+		return nil
+	}
+}
+
+// WithHeaders sets the given headers on every request, such as an API key
+// which every operation requires. Headers set by a request editor passed to
+// an operation take precedence.
+func WithHeaders(headers http.Header) ClientOption {
+	headers = headers.Clone()
+	return WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		for key, values := range headers {
+			req.Header.Del(key)
+			for _, value := range values {
+				req.Header.Add(key, value)
+			}
+		}
+		return nil
+	})
+}
+
+// WithUserAgent sets the User-Agent header of every request.
+func WithUserAgent(userAgent string) ClientOption {
+	return WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("User-Agent", userAgent)
+		return nil
+	})
+}
+
+// getContentObjectLogAttrs returns the parameters of a GetContentObject
+// request, which are logged along with it.
+func getContentObjectLogAttrs(param ComplexObject) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	logAttrs_ = append(logAttrs_, slog.Any("param", param))
+	return logAttrs_
+}
+
+// getCookieLogAttrs returns the parameters of a GetCookie
+// request, which are logged along with it.
+func getCookieLogAttrs(params *GetCookieParams) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	if params == nil {
+		return logAttrs_
+	}
+	if params.P != nil {
+		logAttrs_ = append(logAttrs_, slog.Any("p", *params.P))
+	}
+	if params.Ep != nil {
+		logAttrs_ = append(logAttrs_, slog.Any("ep", *params.Ep))
+	}
+	if params.Ea != nil {
+		logAttrs_ = append(logAttrs_, slog.Any("ea", *params.Ea))
+	}
+	if params.A != nil {
+		logAttrs_ = append(logAttrs_, slog.Any("a", *params.A))
+	}
+	if params.Eo != nil {
+		logAttrs_ = append(logAttrs_, slog.Any("eo", *params.Eo))
+	}
+	if params.O != nil {
+		logAttrs_ = append(logAttrs_, slog.Any("o", *params.O))
+	}
+	if params.Co != nil {
+		logAttrs_ = append(logAttrs_, slog.Any("co", *params.Co))
+	}
+	if params.N1s != nil {
+		logAttrs_ = append(logAttrs_, slog.Any("1s", *params.N1s))
+	}
+	return logAttrs_
+}
+
+// enumParamsLogAttrs returns the parameters of a EnumParams
+// request, which are logged along with it.
+func enumParamsLogAttrs(params *EnumParamsParams) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	if params == nil {
+		return logAttrs_
+	}
+	if params.EnumPathParam != nil {
+		logAttrs_ = append(logAttrs_, slog.Any("enumPathParam", *params.EnumPathParam))
+	}
+	return logAttrs_
+}
+
+// getHeaderLogAttrs returns the parameters of a GetHeader
+// request, which are logged along with it.
+func getHeaderLogAttrs(params *GetHeaderParams) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	if params == nil {
+		return logAttrs_
+	}
+	if params.XPrimitive != nil {
+		logAttrs_ = append(logAttrs_, slog.Any("X-Primitive", *params.XPrimitive))
+	}
+	if params.XPrimitiveExploded != nil {
+		logAttrs_ = append(logAttrs_, slog.Any("X-Primitive-Exploded", *params.XPrimitiveExploded))
+	}
+	if params.XArrayExploded != nil {
+		logAttrs_ = append(logAttrs_, slog.Any("X-Array-Exploded", *params.XArrayExploded))
+	}
+	if params.XArray != nil {
+		logAttrs_ = append(logAttrs_, slog.Any("X-Array", *params.XArray))
+	}
+	if params.XObjectExploded != nil {
+		logAttrs_ = append(logAttrs_, slog.Any("X-Object-Exploded", *params.XObjectExploded))
+	}
+	if params.XObject != nil {
+		logAttrs_ = append(logAttrs_, slog.Any("X-Object", *params.XObject))
+	}
+	if params.XComplexObject != nil {
+		logAttrs_ = append(logAttrs_, slog.Any("X-Complex-Object", *params.XComplexObject))
+	}
+	if params.N1StartingWithNumber != nil {
+		logAttrs_ = append(logAttrs_, slog.Any("1-Starting-With-Number", *params.N1StartingWithNumber))
+	}
+	return logAttrs_
+}
+
+// getLabelExplodeArrayLogAttrs returns the parameters of a GetLabelExplodeArray
+// request, which are logged along with it.
+func getLabelExplodeArrayLogAttrs(param []int32) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	logAttrs_ = append(logAttrs_, slog.Any("param", param))
+	return logAttrs_
+}
+
+// getLabelExplodeObjectLogAttrs returns the parameters of a GetLabelExplodeObject
+// request, which are logged along with it.
+func getLabelExplodeObjectLogAttrs(param Object) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	logAttrs_ = append(logAttrs_, slog.Any("param", param))
+	return logAttrs_
+}
+
+// getLabelNoExplodeArrayLogAttrs returns the parameters of a GetLabelNoExplodeArray
+// request, which are logged along with it.
+func getLabelNoExplodeArrayLogAttrs(param []int32) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	logAttrs_ = append(logAttrs_, slog.Any("param", param))
+	return logAttrs_
+}
+
+// getLabelNoExplodeObjectLogAttrs returns the parameters of a GetLabelNoExplodeObject
+// request, which are logged along with it.
+func getLabelNoExplodeObjectLogAttrs(param Object) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	logAttrs_ = append(logAttrs_, slog.Any("param", param))
+	return logAttrs_
+}
+
+// getMatrixExplodeArrayLogAttrs returns the parameters of a GetMatrixExplodeArray
+// request, which are logged along with it.
+func getMatrixExplodeArrayLogAttrs(id []int32) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	logAttrs_ = append(logAttrs_, slog.Any("id", id))
+	return logAttrs_
+}
+
+// getMatrixExplodeObjectLogAttrs returns the parameters of a GetMatrixExplodeObject
+// request, which are logged along with it.
+func getMatrixExplodeObjectLogAttrs(id Object) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	logAttrs_ = append(logAttrs_, slog.Any("id", id))
+	return logAttrs_
+}
+
+// getMatrixNoExplodeArrayLogAttrs returns the parameters of a GetMatrixNoExplodeArray
+// request, which are logged along with it.
+func getMatrixNoExplodeArrayLogAttrs(id []int32) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	logAttrs_ = append(logAttrs_, slog.Any("id", id))
+	return logAttrs_
+}
+
+// getMatrixNoExplodeObjectLogAttrs returns the parameters of a GetMatrixNoExplodeObject
+// request, which are logged along with it.
+func getMatrixNoExplodeObjectLogAttrs(id Object) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	logAttrs_ = append(logAttrs_, slog.Any("id", id))
+	return logAttrs_
+}
+
+// getPassThroughLogAttrs returns the parameters of a GetPassThrough
+// request, which are logged along with it.
+func getPassThroughLogAttrs(param string) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	logAttrs_ = append(logAttrs_, slog.Any("param", param))
+	return logAttrs_
+}
+
+// getDeepObjectLogAttrs returns the parameters of a GetDeepObject
+// request, which are logged along with it.
+func getDeepObjectLogAttrs(params *GetDeepObjectParams) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	if params == nil {
+		return logAttrs_
+	}
+	logAttrs_ = append(logAttrs_, slog.Any("deepObj", params.DeepObj))
+	return logAttrs_
+}
+
+// getQueryFormLogAttrs returns the parameters of a GetQueryForm
+// request, which are logged along with it.
+func getQueryFormLogAttrs(params *GetQueryFormParams) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	if params == nil {
+		return logAttrs_
+	}
+	if params.Ea != nil {
+		logAttrs_ = append(logAttrs_, slog.Any("ea", *params.Ea))
+	}
+	if params.A != nil {
+		logAttrs_ = append(logAttrs_, slog.Any("a", *params.A))
+	}
+	if params.Eo != nil {
+		logAttrs_ = append(logAttrs_, slog.Any("eo", *params.Eo))
+	}
+	if params.O != nil {
+		logAttrs_ = append(logAttrs_, slog.Any("o", *params.O))
+	}
+	if params.Ep != nil {
+		logAttrs_ = append(logAttrs_, slog.Any("ep", *params.Ep))
+	}
+	if params.P != nil {
+		logAttrs_ = append(logAttrs_, slog.Any("p", *params.P))
+	}
+	if params.Ps != nil {
+		logAttrs_ = append(logAttrs_, slog.Any("ps", *params.Ps))
+	}
+	if params.Co != nil {
+		logAttrs_ = append(logAttrs_, slog.Any("co", *params.Co))
+	}
+	if params.N1s != nil {
+		logAttrs_ = append(logAttrs_, slog.Any("1s", *params.N1s))
+	}
+	return logAttrs_
+}
+
+// getSimpleExplodeArrayLogAttrs returns the parameters of a GetSimpleExplodeArray
+// request, which are logged along with it.
+func getSimpleExplodeArrayLogAttrs(param []int32) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	logAttrs_ = append(logAttrs_, slog.Any("param", param))
+	return logAttrs_
+}
+
+// getSimpleExplodeObjectLogAttrs returns the parameters of a GetSimpleExplodeObject
+// request, which are logged along with it.
+func getSimpleExplodeObjectLogAttrs(param Object) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	logAttrs_ = append(logAttrs_, slog.Any("param", param))
+	return logAttrs_
+}
+
+// getSimpleNoExplodeArrayLogAttrs returns the parameters of a GetSimpleNoExplodeArray
+// request, which are logged along with it.
+func getSimpleNoExplodeArrayLogAttrs(param []int32) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	logAttrs_ = append(logAttrs_, slog.Any("param", param))
+	return logAttrs_
+}
+
+// getSimpleNoExplodeObjectLogAttrs returns the parameters of a GetSimpleNoExplodeObject
+// request, which are logged along with it.
+func getSimpleNoExplodeObjectLogAttrs(param Object) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	logAttrs_ = append(logAttrs_, slog.Any("param", param))
+	return logAttrs_
+}
+
+// getSimplePrimitiveLogAttrs returns the parameters of a GetSimplePrimitive
+// request, which are logged along with it.
+func getSimplePrimitiveLogAttrs(param int32) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	logAttrs_ = append(logAttrs_, slog.Any("param", param))
+	return logAttrs_
+}
+
+// getStartingWithNumberLogAttrs returns the parameters of a GetStartingWithNumber
+// request, which are logged along with it.
+func getStartingWithNumberLogAttrs(n1param string) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	logAttrs_ = append(logAttrs_, slog.Any("1param", n1param))
+	return logAttrs_
+}
+
 // The interface specification for the client above.
 type ClientInterface interface {
 	// GetContentObject request
@@ -284,7 +581,7 @@ func (c *Client) GetContentObject(ctx context.Context, param ComplexObject, reqE
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "GetContentObject", "/contentObject/{param}", func() []slog.Attr { return getContentObjectLogAttrs(param) })
 }
 
 func (c *Client) GetCookie(ctx context.Context, params *GetCookieParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -296,7 +593,7 @@ func (c *Client) GetCookie(ctx context.Context, params *GetCookieParams, reqEdit
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "GetCookie", "/cookie", func() []slog.Attr { return getCookieLogAttrs(params) })
 }
 
 func (c *Client) EnumParams(ctx context.Context, params *EnumParamsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -308,7 +605,7 @@ func (c *Client) EnumParams(ctx context.Context, params *EnumParamsParams, reqEd
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "EnumParams", "/enums", func() []slog.Attr { return enumParamsLogAttrs(params) })
 }
 
 func (c *Client) GetHeader(ctx context.Context, params *GetHeaderParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -320,7 +617,7 @@ func (c *Client) GetHeader(ctx context.Context, params *GetHeaderParams, reqEdit
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "GetHeader", "/header", func() []slog.Attr { return getHeaderLogAttrs(params) })
 }
 
 func (c *Client) GetLabelExplodeArray(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -332,7 +629,7 @@ func (c *Client) GetLabelExplodeArray(ctx context.Context, param []int32, reqEdi
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "GetLabelExplodeArray", "/labelExplodeArray/{.param*}", func() []slog.Attr { return getLabelExplodeArrayLogAttrs(param) })
 }
 
 func (c *Client) GetLabelExplodeObject(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -344,7 +641,7 @@ func (c *Client) GetLabelExplodeObject(ctx context.Context, param Object, reqEdi
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "GetLabelExplodeObject", "/labelExplodeObject/{.param*}", func() []slog.Attr { return getLabelExplodeObjectLogAttrs(param) })
 }
 
 func (c *Client) GetLabelNoExplodeArray(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -356,7 +653,7 @@ func (c *Client) GetLabelNoExplodeArray(ctx context.Context, param []int32, reqE
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "GetLabelNoExplodeArray", "/labelNoExplodeArray/{.param}", func() []slog.Attr { return getLabelNoExplodeArrayLogAttrs(param) })
 }
 
 func (c *Client) GetLabelNoExplodeObject(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -368,7 +665,7 @@ func (c *Client) GetLabelNoExplodeObject(ctx context.Context, param Object, reqE
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "GetLabelNoExplodeObject", "/labelNoExplodeObject/{.param}", func() []slog.Attr { return getLabelNoExplodeObjectLogAttrs(param) })
 }
 
 func (c *Client) GetMatrixExplodeArray(ctx context.Context, id []int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -380,7 +677,7 @@ func (c *Client) GetMatrixExplodeArray(ctx context.Context, id []int32, reqEdito
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "GetMatrixExplodeArray", "/matrixExplodeArray/{.id*}", func() []slog.Attr { return getMatrixExplodeArrayLogAttrs(id) })
 }
 
 func (c *Client) GetMatrixExplodeObject(ctx context.Context, id Object, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -392,7 +689,7 @@ func (c *Client) GetMatrixExplodeObject(ctx context.Context, id Object, reqEdito
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "GetMatrixExplodeObject", "/matrixExplodeObject/{.id*}", func() []slog.Attr { return getMatrixExplodeObjectLogAttrs(id) })
 }
 
 func (c *Client) GetMatrixNoExplodeArray(ctx context.Context, id []int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -404,7 +701,7 @@ func (c *Client) GetMatrixNoExplodeArray(ctx context.Context, id []int32, reqEdi
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "GetMatrixNoExplodeArray", "/matrixNoExplodeArray/{.id}", func() []slog.Attr { return getMatrixNoExplodeArrayLogAttrs(id) })
 }
 
 func (c *Client) GetMatrixNoExplodeObject(ctx context.Context, id Object, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -416,7 +713,7 @@ func (c *Client) GetMatrixNoExplodeObject(ctx context.Context, id Object, reqEdi
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "GetMatrixNoExplodeObject", "/matrixNoExplodeObject/{.id}", func() []slog.Attr { return getMatrixNoExplodeObjectLogAttrs(id) })
 }
 
 func (c *Client) GetPassThrough(ctx context.Context, param string, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -428,7 +725,7 @@ func (c *Client) GetPassThrough(ctx context.Context, param string, reqEditors ..
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "GetPassThrough", "/passThrough/{param}", func() []slog.Attr { return getPassThroughLogAttrs(param) })
 }
 
 func (c *Client) GetDeepObject(ctx context.Context, params *GetDeepObjectParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -440,7 +737,7 @@ func (c *Client) GetDeepObject(ctx context.Context, params *GetDeepObjectParams,
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "GetDeepObject", "/queryDeepObject", func() []slog.Attr { return getDeepObjectLogAttrs(params) })
 }
 
 func (c *Client) GetQueryForm(ctx context.Context, params *GetQueryFormParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -452,7 +749,7 @@ func (c *Client) GetQueryForm(ctx context.Context, params *GetQueryFormParams, r
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "GetQueryForm", "/queryForm", func() []slog.Attr { return getQueryFormLogAttrs(params) })
 }
 
 func (c *Client) GetSimpleExplodeArray(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -464,7 +761,7 @@ func (c *Client) GetSimpleExplodeArray(ctx context.Context, param []int32, reqEd
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "GetSimpleExplodeArray", "/simpleExplodeArray/{param*}", func() []slog.Attr { return getSimpleExplodeArrayLogAttrs(param) })
 }
 
 func (c *Client) GetSimpleExplodeObject(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -476,7 +773,7 @@ func (c *Client) GetSimpleExplodeObject(ctx context.Context, param Object, reqEd
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "GetSimpleExplodeObject", "/simpleExplodeObject/{param*}", func() []slog.Attr { return getSimpleExplodeObjectLogAttrs(param) })
 }
 
 func (c *Client) GetSimpleNoExplodeArray(ctx context.Context, param []int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -488,7 +785,7 @@ func (c *Client) GetSimpleNoExplodeArray(ctx context.Context, param []int32, req
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "GetSimpleNoExplodeArray", "/simpleNoExplodeArray/{param}", func() []slog.Attr { return getSimpleNoExplodeArrayLogAttrs(param) })
 }
 
 func (c *Client) GetSimpleNoExplodeObject(ctx context.Context, param Object, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -500,7 +797,7 @@ func (c *Client) GetSimpleNoExplodeObject(ctx context.Context, param Object, req
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "GetSimpleNoExplodeObject", "/simpleNoExplodeObject/{param}", func() []slog.Attr { return getSimpleNoExplodeObjectLogAttrs(param) })
 }
 
 func (c *Client) GetSimplePrimitive(ctx context.Context, param int32, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -512,7 +809,7 @@ func (c *Client) GetSimplePrimitive(ctx context.Context, param int32, reqEditors
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "GetSimplePrimitive", "/simplePrimitive/{param}", func() []slog.Attr { return getSimplePrimitiveLogAttrs(param) })
 }
 
 func (c *Client) GetStartingWithNumber(ctx context.Context, n1param string, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -524,7 +821,7 @@ func (c *Client) GetStartingWithNumber(ctx context.Context, n1param string, reqE
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "GetStartingWithNumber", "/startingWithNumber/{1param}", func() []slog.Attr { return getStartingWithNumberLogAttrs(n1param) })
 }
 
 // NewGetContentObjectRequest generates requests for GetContentObject
@@ -538,7 +835,7 @@ func NewGetContentObjectRequest(server string, param ComplexObject) (*http.Reque
 	if err != nil {
 		return nil, err
 	}
-	pathParam0 = string(pathParamBuf0)
+	pathParam0 = escapePathParam(string(pathParamBuf0), false)
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
@@ -894,6 +1191,7 @@ func NewGetLabelExplodeArrayRequest(server string, param []int32) (*http.Request
 	if err != nil {
 		return nil, err
 	}
+	pathParam0 = escapeDotSegments(pathParam0)
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
@@ -928,6 +1226,7 @@ func NewGetLabelExplodeObjectRequest(server string, param Object) (*http.Request
 	if err != nil {
 		return nil, err
 	}
+	pathParam0 = escapeDotSegments(pathParam0)
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
@@ -962,6 +1261,7 @@ func NewGetLabelNoExplodeArrayRequest(server string, param []int32) (*http.Reque
 	if err != nil {
 		return nil, err
 	}
+	pathParam0 = escapeDotSegments(pathParam0)
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
@@ -996,6 +1296,7 @@ func NewGetLabelNoExplodeObjectRequest(server string, param Object) (*http.Reque
 	if err != nil {
 		return nil, err
 	}
+	pathParam0 = escapeDotSegments(pathParam0)
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
@@ -1030,6 +1331,7 @@ func NewGetMatrixExplodeArrayRequest(server string, id []int32) (*http.Request,
 	if err != nil {
 		return nil, err
 	}
+	pathParam0 = escapeDotSegments(pathParam0)
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
@@ -1064,6 +1366,7 @@ func NewGetMatrixExplodeObjectRequest(server string, id Object) (*http.Request,
 	if err != nil {
 		return nil, err
 	}
+	pathParam0 = escapeDotSegments(pathParam0)
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
@@ -1098,6 +1401,7 @@ func NewGetMatrixNoExplodeArrayRequest(server string, id []int32) (*http.Request
 	if err != nil {
 		return nil, err
 	}
+	pathParam0 = escapeDotSegments(pathParam0)
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
@@ -1132,6 +1436,7 @@ func NewGetMatrixNoExplodeObjectRequest(server string, id Object) (*http.Request
 	if err != nil {
 		return nil, err
 	}
+	pathParam0 = escapeDotSegments(pathParam0)
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
@@ -1162,7 +1467,7 @@ func NewGetPassThroughRequest(server string, param string) (*http.Request, error
 
 	var pathParam0 string
 
-	pathParam0 = param
+	pathParam0 = escapePathParam(param, false)
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
@@ -1413,6 +1718,7 @@ func NewGetSimpleExplodeArrayRequest(server string, param []int32) (*http.Reques
 	if err != nil {
 		return nil, err
 	}
+	pathParam0 = escapeDotSegments(pathParam0)
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
@@ -1447,6 +1753,7 @@ func NewGetSimpleExplodeObjectRequest(server string, param Object) (*http.Reques
 	if err != nil {
 		return nil, err
 	}
+	pathParam0 = escapeDotSegments(pathParam0)
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
@@ -1481,6 +1788,7 @@ func NewGetSimpleNoExplodeArrayRequest(server string, param []int32) (*http.Requ
 	if err != nil {
 		return nil, err
 	}
+	pathParam0 = escapeDotSegments(pathParam0)
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
@@ -1515,6 +1823,7 @@ func NewGetSimpleNoExplodeObjectRequest(server string, param Object) (*http.Requ
 	if err != nil {
 		return nil, err
 	}
+	pathParam0 = escapeDotSegments(pathParam0)
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
@@ -1549,6 +1858,7 @@ func NewGetSimplePrimitiveRequest(server string, param int32) (*http.Request, er
 	if err != nil {
 		return nil, err
 	}
+	pathParam0 = escapeDotSegments(pathParam0)
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
@@ -1579,7 +1889,7 @@ func NewGetStartingWithNumberRequest(server string, n1param string) (*http.Reque
 
 	var pathParam0 string
 
-	pathParam0 = n1param
+	pathParam0 = escapePathParam(n1param, false)
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
@@ -1604,6 +1914,31 @@ func NewGetStartingWithNumberRequest(server string, n1param string) (*http.Reque
 	return req, nil
 }
 
+// do sends the request, logging it with the Logger, if there is one. The
+// parameters of the request are only collected when they're logged.
+func (c *Client) do(req *http.Request, operationID, path string, params func() []slog.Attr) (*http.Response, error) {
+	if c.Logger == nil {
+		return c.Client.Do(req)
+	}
+	start := time.Now()
+	rsp, err := c.Client.Do(req)
+	attrs := []slog.Attr{
+		slog.String("operation", operationID),
+		slog.String("method", req.Method),
+		slog.String("path", path),
+		slog.Duration("latency", time.Since(start)),
+	}
+	if params != nil {
+		attrs = append(attrs, slog.Attr{Key: "params", Value: slog.GroupValue(params()...)})
+	}
+	if err != nil {
+		c.Logger.LogAttrs(req.Context(), slog.LevelError, "request failed", append(attrs, slog.Any("error", err))...)
+		return nil, err
+	}
+	c.Logger.LogAttrs(req.Context(), slog.LevelInfo, "request", append(attrs, slog.Int("status", rsp.StatusCode))...)
+	return rsp, nil
+}
+
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
 	for _, r := range c.RequestEditors {
 		if err := r(ctx, req); err != nil {
@@ -1618,6 +1953,56 @@ func (c *Client) applyEditors(ctx context.Context, req *http.Request, additional
 	return nil
 }
 
+// escapePathParam escapes the value of a path parameter, so that it's sent as
+// the parameter in the path of a request. The value is escaped with
+// url.PathEscape, so that a `/`, `%`, `?` or unicode character in it is
+// percent-encoded, unless allowReserved is set, for a parameter with
+// `allowReserved: true`, when the reserved characters of RFC 3986, such as `/`,
+// and the percent-encoded octets in it are kept as they are, as they are by the
+// reserved expansion of RFC 6570.
+func escapePathParam(value string, allowReserved bool) string {
+	if allowReserved {
+		return escapeDotSegments(escapeReservedPathParam(value))
+	}
+	return escapeDotSegments(url.PathEscape(value))
+}
+
+// escapeDotSegments percent-encodes the segments of an escaped path parameter
+// which are `.` or `..`, so that they aren't removed from the path of a
+// request as dot-segments.
+func escapeDotSegments(escaped string) string {
+	segments := strings.Split(escaped, "/")
+	for i, segment := range segments {
+		if segment == "." || segment == ".." {
+			segments[i] = strings.ReplaceAll(segment, ".", "%2E")
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// escapeReservedPathParam percent-encodes the characters of value which can't
+// be in the path of a URL as they are, keeping its reserved characters and
+// percent-encoded octets, apart from `?` and `#`, which would end the path.
+func escapeReservedPathParam(value string) string {
+	const hexDigits = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', strings.IndexByte("-._~:/@!$&'()*+,;=[]", c) >= 0:
+			b.WriteByte(c)
+		case c == '%' && i+2 < len(value) && strings.IndexByte("0123456789ABCDEFabcdef", value[i+1]) >= 0 && strings.IndexByte("0123456789ABCDEFabcdef", value[i+2]) >= 0:
+			b.WriteString(value[i : i+3])
+			i += 2
+		default:
+			b.WriteByte('%')
+			b.WriteByte(hexDigits[c>>4])
+			b.WriteByte(hexDigits[c&15])
+		}
+	}
+	return b.String()
+}
+
 // ClientWithResponses builds on ClientInterface to offer response payloads
 type ClientWithResponses struct {
 	ClientInterface
@@ -1645,6 +2030,29 @@ func WithBaseURL(baseURL string) ClientOption {
 	}
 }
 
+// ParseAs reads and closes the body of an HTTP response, decoding it as JSON
+// into a T. This is useful for responses which the typed fields of a
+// response don't cover, such as for a status code which isn't documented.
+func ParseAs[T any](rsp *http.Response) (T, error) {
+	var dest T
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return dest, err
+	}
+	return ResponseAs[T](bodyBytes)
+}
+
+// ResponseAs decodes the Body of a response returned by ClientWithResponses
+// as JSON into a T, such as `ResponseAs[Error](resp.Body)`.
+func ResponseAs[T any](body []byte) (T, error) {
+	var dest T
+	if err := json.Unmarshal(body, &dest); err != nil {
+		return dest, err
+	}
+	return dest, nil
+}
+
 // ClientWithResponsesInterface is the interface specification for the client with responses above.
 type ClientWithResponsesInterface interface {
 	// GetContentObjectWithResponse request
@@ -2755,7 +3163,11 @@ func (w *ServerInterfaceWrapper) GetContentObject(ctx echo.Context) error {
 	// ------------- Path parameter "param" -------------
 	var param ComplexObject
 
-	err = json.Unmarshal([]byte(ctx.Param("param")), &param)
+	paramValue, err := url.PathUnescape(ctx.Param("param"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err))
+	}
+	err = json.Unmarshal([]byte(paramValue), &param)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "Error unmarshaling parameter 'param' as JSON")
 	}
@@ -3156,7 +3568,10 @@ func (w *ServerInterfaceWrapper) GetPassThrough(ctx echo.Context) error {
 	// ------------- Path parameter "param" -------------
 	var param string
 
-	param = ctx.Param("param")
+	param, err = url.PathUnescape(ctx.Param("param"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter param: %s", err))
+	}
 
 	// Invoke the callback with all the unmarshaled arguments
 	err = w.Handler.GetPassThrough(ctx, param)
@@ -3347,7 +3762,10 @@ func (w *ServerInterfaceWrapper) GetStartingWithNumber(ctx echo.Context) error {
 	// ------------- Path parameter "1param" -------------
 	var n1param string
 
-	n1param = ctx.Param("1param")
+	n1param, err = url.PathUnescape(ctx.Param("n1param"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter 1param: %s", err))
+	}
 
 	// Invoke the callback with all the unmarshaled arguments
 	err = w.Handler.GetStartingWithNumber(ctx, n1param)
@@ -3402,33 +3820,33 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	router.GET(baseURL+"/simpleNoExplodeArray/:param", wrapper.GetSimpleNoExplodeArray)
 	router.GET(baseURL+"/simpleNoExplodeObject/:param", wrapper.GetSimpleNoExplodeObject)
 	router.GET(baseURL+"/simplePrimitive/:param", wrapper.GetSimplePrimitive)
-	router.GET(baseURL+"/startingWithNumber/:1param", wrapper.GetStartingWithNumber)
+	router.GET(baseURL+"/startingWithNumber/:n1param", wrapper.GetStartingWithNumber)
 
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9xaX3OjNhD/Ksy2Tx1i7Ls+8Za5XtvM9HJpnZnrTCYPCqyDroB0kpwm4/F370gCAwJj",
-	"7JjY17cE7Z/f/rRaeRdWELGMsxxzJSFcgUDJWS7R/DOnGU/xr+KRfhKxXGGu9J8Kn1XAU0Jz/Z+MEsyI",
-	"ef7CEUKQStD8EdbrtQ8xykhQrijLIYRLTxq7XunLYw9fMVKgRa0d4/0D01LPn+1iuAIuGEehqAV3Fde8",
-	"0VzhIwpY+3AlL+PMgioWHxhLkeR6sTL2o8AFhPBDUMUfFM6DzxUegd+WVGAM4V2p7GvXlZ/7htkmxgUV",
-	"Ul2TDDuI8UGwtGvB8Wqk/Jqpe8MpzRdMK6c0wmJzcuMIPl3dauuKKm0eblEqb47iCQX48IRC2m2YTaaT",
-	"qRZkHHPCKYTwfjKdzMAHTlRi8AfFftv4ghUngmRrvfKIJlwdLNH7qncDfkP1oa5gTAmSoUIhIbxr5A/h",
-	"PKWRUQ6+SuZkUd/2NBOjYANCAxv8kgbjGepcKrHE9b3fzPF30+k2fxu5wDkIa+MziBj7h2I/G0aiRUPz",
-	"QHBBM6rokxbEZ56yGCFckFRiEVhUmilDa4RVSFbcLZjIiLKn4v078FuHZO0PgqD52oIAjw+hcBt7RAjy",
-	"MhQH6cdBFWZyEKDNE+u+A2ALV98WvSGuDXGsPHSDmGP9CIfVRxdLG0IfSUeCMFZRaYYWWYGK5c6Qoo6Y",
-	"2jRpYU8qIhTNH71/qUq8fJk9mArdaXYm+6lyrxC3ysW4IMtUHVrpMF/adO0sdB/zZXajC5zcVeluykUb",
-	"szbrPZF0ibIM/NsSxUstS41pldy0inmLAi0K4d1sOvXfTaf3/oAa1L4LfrZkNfaKeWWCFWwkSGIUfXX/",
-	"dyvx2rqflGYKNv6+uKmpvO0N0IPl4mNRgU5zJ7SRXWrpgaje7IbYAvPc7ok2TFsSB9I5xrWxDdL3f3u0",
-	"IysMbY3wgLvEdTK7mBfSF1+oSi6uS+nT3S8pecC0SDBzKoLVxJTOn3qbjT9ctXbF7UrxIX3CcQ6hD1K9",
-	"mC7MRAjH7D7qnJX92b6kbWvTjsHakPM3Oj/XrCurdvPT1OshqF6n/kd5tYm/mVl7ELcztV7D3KlzKyNK",
-	"0GcntWjcf/A+tZQOOXg0Hj2nbHTjEbbJqb0YO7xW7aBsv2QajZxWqaLxAHKOUKi+54xq16n9WHtFlTr3",
-	"rOJEyttEsOVjMmRwe1OJ945t9xj7n2QoawYIvyDyaia/LeSa1I5OPUbk/W2TM7eIremDM8TpJ6pEiSvM",
-	"x/4RbkL4lYmsj7M/N0I7KBvUurvTntGmphWBWhX2bN0dmKdDOayFd1k9waTXgTAKgg0Zu0ZXLh9v9Pqk",
-	"h48REXhFBd7ieM858onnIw76Iw3XHauvna2/4rKyL8Kbv/sGtPDzltr5Dj5siDAaa41X03vQdj6jj9EY",
-	"cjuK3T8C5x16Zzz8GJ+54R8+zLsUz2L8MRpLmxdAw/mpv79ymDmIiQHJMyYNxSXzharETtGD1WwAFS21",
-	"ETuu2cgtl2bYfFxkcS9FCiEkSvEwCIovixRKNdGNS0b4hFBY36//CwAA//8xMRQydyYAAA==",
+	"H4sIAAAAAAAC/9SaQW/bNhTHv4rxtlMgW3a6k25B120B1ixbA6xAkAMjPUfsJJIl6SxBoO9eUJQs2RJt",
+	"WZac5JRGJMU/f3z8Pz41L8AFMiIoBPBhNp8twAPKlhyCF3hEqShnEMBiNp/NwQNNdYIQwA0qPfmC8hEl",
+	"eJDQEJlCM4SR1LR/vryBLPNA5V0UBLcvsJIJBBBrLQLfLybVqPQsQhQpETNCIbvzQBAdK/MuX9FUJHgt",
+	"aUo1fUT/RRBJ0sy0PaA2P7hASTTl7DKCAH5H/WVzCJjXSZKiLlUUAvOn+VLzX3QMHkj8vqISIwi0XKEH",
+	"Sj/ni7UywAMVxpgSM69+FqaFMo0POYIllynR9tGHc8jMQiQqwZnCfDHn87n58bPEJQTwkx/yVHCGTCt/",
+	"3c+36v8pfocsMwwLDFf805NIeIQXUpLn7iw2xw0LBO2bIViSRGELIFLMSTWmqhu44cm1cDvrAO4E2Ir2",
+	"N0ltHTd/3X/DUPcIODvwVBHXtlLbqvxCyXixtcHogOAaFdF2dJ2aUELuMdm2rdn+MPqzOW4QQrmed+Ba",
+	"uc42amf7sY0P7W161maslcfx4GAb8Dzuj7ZXOY+thLoH1piAXtuvUqIlfWoYFo120vncMsrJh0Yd4Vgt",
+	"78CsrNAGsrMOzMYm9jadaivK1gfxsDDbcw6HjLPXOYZNPAeE1Hh0XtujQs40Mt39Tv6xPuAo3y6mNrMQ",
+	"IRIa5vP43xRn5lk3Jh+5WdXTGs2gcARR6iaWfPUQd0FzXXUfCozGJ+2LhNAtJIXFKC0pexh62UoTqSl7",
+	"+Jfq+GqV3qP0XxYdqrXGMCeFxXvA8H2F8vk3LtNdi/573cm1ViTgQYQqlFRo+/WrOP3RZJ06zNN8vg0O",
+	"pXUW7rG004yRj7y13Kba40QOec2oVCJ3Q+WlNe0WfJwFV1KaSg4V0C9D1mAINwxR+3LZk8f+jamkNJX0",
+	"EOAKmW4KlFvCpDCKvbHsNJhqnrC576HNRV0DcKT8V0lcNFGYlknp75P/qY4nrLTqvkyGttxfEUWxnh3G",
+	"W+vlct7IdmlAMM/3btLmxS2qz9bLObY3aptahEuySnRfcjGSCOUuYH/YHi5YX6f1/+TYc4bj8l0jHeKa",
+	"mGlxBY8GUXWMt32d5pWlW1B7Wu+v6rgUWcjdnc6P2Mhj1dmT0IHm5kntgfPAdFpK25PX+6A7WEnhGi5F",
+	"7RnHrWz8lDMtq4CpKQOm6zrgwDTkXkL3PHSko4ac/0dxdymc93CWevsdKyzfMJKP4jAajnHNzuVPfx2j",
+	"FjxHbNGpSpwe5AaucfpAOlBC5/u2W8ubvHC75Z7M6ZCtUuU0uk9slV4bg1PuTxx5Fx1fF191NhFcl2Ps",
+	"8k3nySNJVqgOLzqcR8fLRUBwu5jPvfP5/K6tLvnFwtrYHz4pwyKzOCpwVVDk/6xqEiENIk3teyVPsGWX",
+	"PFhSqfQVSdtas/pqb+076iPuMg82Q60xb/W8y1Hy4DKq6ShBmufqIkopqzXec54gYQ2V61vIZQTVuLss",
+	"2yK9FWYN6BcT+8cFk3JUdYR7ffTLsuzHAKkkq753JgAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
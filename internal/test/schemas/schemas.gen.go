@@ -12,9 +12,11 @@ import (
 	"encoding/xml"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v2"
 
@@ -134,6 +136,9 @@ type Client struct {
 	// A list of callbacks for modifying requests which are generated before sending over
 	// the network.
 	RequestEditors []RequestEditorFn
+
+	// A logger which logs each request, along with its response.
+	Logger *slog.Logger
 }
 
 // ClientOption allows setting custom parameters during construction
@@ -180,6 +185,76 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 	}
 }
 
+// WithLogger logs each request, with its operation, method, path template,
+// parameters, status code and latency. The values of parameters which are
+// sensitive, as they're marked with `x-sensitive` or have the `password`
+// format, are redacted.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) error {
+		c.Logger = logger
+		return nil
+	}
+}
+
+// WithHeaders sets the given headers on every request, such as an API key
+// which every operation requires. Headers set by a request editor passed to
+// an operation take precedence.
+func WithHeaders(headers http.Header) ClientOption {
+	headers = headers.Clone()
+	return WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		for key, values := range headers {
+			req.Header.Del(key)
+			for _, value := range values {
+				req.Header.Add(key, value)
+			}
+		}
+		return nil
+	})
+}
+
+// WithUserAgent sets the User-Agent header of every request.
+func WithUserAgent(userAgent string) ClientOption {
+	return WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("User-Agent", userAgent)
+		return nil
+	})
+}
+
+// issue209LogAttrs returns the parameters of a Issue209
+// request, which are logged along with it.
+func issue209LogAttrs(str string) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	logAttrs_ = append(logAttrs_, slog.Any("str", str))
+	return logAttrs_
+}
+
+// issue30LogAttrs returns the parameters of a Issue30
+// request, which are logged along with it.
+func issue30LogAttrs(pFallthrough string) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	logAttrs_ = append(logAttrs_, slog.Any("fallthrough", pFallthrough))
+	return logAttrs_
+}
+
+// issue41LogAttrs returns the parameters of a Issue41
+// request, which are logged along with it.
+func issue41LogAttrs(n1param N5StartsWithNumber) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	logAttrs_ = append(logAttrs_, slog.Any("1param", n1param))
+	return logAttrs_
+}
+
+// issue9LogAttrs returns the parameters of a Issue9
+// request, which are logged along with it.
+func issue9LogAttrs(params *Issue9Params) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	if params == nil {
+		return logAttrs_
+	}
+	logAttrs_ = append(logAttrs_, slog.Any("foo", params.Foo))
+	return logAttrs_
+}
+
 // The interface specification for the client above.
 type ClientInterface interface {
 	// EnsureEverythingIsReferenced request
@@ -226,7 +301,7 @@ func (c *Client) EnsureEverythingIsReferenced(ctx context.Context, reqEditors ..
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "EnsureEverythingIsReferenced", "/ensure-everything-is-referenced", nil)
 }
 
 func (c *Client) Issue1051(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -238,7 +313,7 @@ func (c *Client) Issue1051(ctx context.Context, reqEditors ...RequestEditorFn) (
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "Issue1051", "/issues/1051", nil)
 }
 
 func (c *Client) Issue127(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -250,7 +325,7 @@ func (c *Client) Issue127(ctx context.Context, reqEditors ...RequestEditorFn) (*
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "Issue127", "/issues/127", nil)
 }
 
 func (c *Client) Issue185WithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -262,7 +337,7 @@ func (c *Client) Issue185WithBody(ctx context.Context, contentType string, body
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "Issue185", "/issues/185", nil)
 }
 
 func (c *Client) Issue185(ctx context.Context, body Issue185JSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -274,7 +349,7 @@ func (c *Client) Issue185(ctx context.Context, body Issue185JSONRequestBody, req
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "Issue185", "/issues/185", nil)
 }
 
 func (c *Client) Issue209(ctx context.Context, str string, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -286,7 +361,7 @@ func (c *Client) Issue209(ctx context.Context, str string, reqEditors ...Request
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "Issue209", "/issues/209/${str}", func() []slog.Attr { return issue209LogAttrs(str) })
 }
 
 func (c *Client) Issue30(ctx context.Context, pFallthrough string, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -298,7 +373,7 @@ func (c *Client) Issue30(ctx context.Context, pFallthrough string, reqEditors ..
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "Issue30", "/issues/30/{fallthrough}", func() []slog.Attr { return issue30LogAttrs(pFallthrough) })
 }
 
 func (c *Client) GetIssues375(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -310,7 +385,7 @@ func (c *Client) GetIssues375(ctx context.Context, reqEditors ...RequestEditorFn
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "GetIssues375", "/issues/375", nil)
 }
 
 func (c *Client) Issue41(ctx context.Context, n1param N5StartsWithNumber, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -322,7 +397,7 @@ func (c *Client) Issue41(ctx context.Context, n1param N5StartsWithNumber, reqEdi
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "Issue41", "/issues/41/{1param}", func() []slog.Attr { return issue41LogAttrs(n1param) })
 }
 
 func (c *Client) Issue9WithBody(ctx context.Context, params *Issue9Params, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -334,7 +409,7 @@ func (c *Client) Issue9WithBody(ctx context.Context, params *Issue9Params, conte
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "Issue9", "/issues/9", func() []slog.Attr { return issue9LogAttrs(params) })
 }
 
 func (c *Client) Issue9(ctx context.Context, params *Issue9Params, body Issue9JSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -346,7 +421,7 @@ func (c *Client) Issue9(ctx context.Context, params *Issue9Params, body Issue9JS
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "Issue9", "/issues/9", func() []slog.Attr { return issue9LogAttrs(params) })
 }
 
 func (c *Client) Issue975(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -358,7 +433,7 @@ func (c *Client) Issue975(ctx context.Context, reqEditors ...RequestEditorFn) (*
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "Issue975", "/issues/975", nil)
 }
 
 // NewEnsureEverythingIsReferencedRequest generates requests for EnsureEverythingIsReferenced
@@ -492,6 +567,7 @@ func NewIssue209Request(server string, str string) (*http.Request, error) {
 	if err != nil {
 		return nil, err
 	}
+	pathParam0 = escapeDotSegments(pathParam0)
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
@@ -526,6 +602,7 @@ func NewIssue30Request(server string, pFallthrough string) (*http.Request, error
 	if err != nil {
 		return nil, err
 	}
+	pathParam0 = escapeDotSegments(pathParam0)
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
@@ -587,6 +664,7 @@ func NewIssue41Request(server string, n1param N5StartsWithNumber) (*http.Request
 	if err != nil {
 		return nil, err
 	}
+	pathParam0 = escapeDotSegments(pathParam0)
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
@@ -696,6 +774,31 @@ func NewIssue975Request(server string) (*http.Request, error) {
 	return req, nil
 }
 
+// do sends the request, logging it with the Logger, if there is one. The
+// parameters of the request are only collected when they're logged.
+func (c *Client) do(req *http.Request, operationID, path string, params func() []slog.Attr) (*http.Response, error) {
+	if c.Logger == nil {
+		return c.Client.Do(req)
+	}
+	start := time.Now()
+	rsp, err := c.Client.Do(req)
+	attrs := []slog.Attr{
+		slog.String("operation", operationID),
+		slog.String("method", req.Method),
+		slog.String("path", path),
+		slog.Duration("latency", time.Since(start)),
+	}
+	if params != nil {
+		attrs = append(attrs, slog.Attr{Key: "params", Value: slog.GroupValue(params()...)})
+	}
+	if err != nil {
+		c.Logger.LogAttrs(req.Context(), slog.LevelError, "request failed", append(attrs, slog.Any("error", err))...)
+		return nil, err
+	}
+	c.Logger.LogAttrs(req.Context(), slog.LevelInfo, "request", append(attrs, slog.Int("status", rsp.StatusCode))...)
+	return rsp, nil
+}
+
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
 	for _, r := range c.RequestEditors {
 		if err := r(ctx, req); err != nil {
@@ -710,6 +813,56 @@ func (c *Client) applyEditors(ctx context.Context, req *http.Request, additional
 	return nil
 }
 
+// escapePathParam escapes the value of a path parameter, so that it's sent as
+// the parameter in the path of a request. The value is escaped with
+// url.PathEscape, so that a `/`, `%`, `?` or unicode character in it is
+// percent-encoded, unless allowReserved is set, for a parameter with
+// `allowReserved: true`, when the reserved characters of RFC 3986, such as `/`,
+// and the percent-encoded octets in it are kept as they are, as they are by the
+// reserved expansion of RFC 6570.
+func escapePathParam(value string, allowReserved bool) string {
+	if allowReserved {
+		return escapeDotSegments(escapeReservedPathParam(value))
+	}
+	return escapeDotSegments(url.PathEscape(value))
+}
+
+// escapeDotSegments percent-encodes the segments of an escaped path parameter
+// which are `.` or `..`, so that they aren't removed from the path of a
+// request as dot-segments.
+func escapeDotSegments(escaped string) string {
+	segments := strings.Split(escaped, "/")
+	for i, segment := range segments {
+		if segment == "." || segment == ".." {
+			segments[i] = strings.ReplaceAll(segment, ".", "%2E")
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// escapeReservedPathParam percent-encodes the characters of value which can't
+// be in the path of a URL as they are, keeping its reserved characters and
+// percent-encoded octets, apart from `?` and `#`, which would end the path.
+func escapeReservedPathParam(value string) string {
+	const hexDigits = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', strings.IndexByte("-._~:/@!$&'()*+,;=[]", c) >= 0:
+			b.WriteByte(c)
+		case c == '%' && i+2 < len(value) && strings.IndexByte("0123456789ABCDEFabcdef", value[i+1]) >= 0 && strings.IndexByte("0123456789ABCDEFabcdef", value[i+2]) >= 0:
+			b.WriteString(value[i : i+3])
+			i += 2
+		default:
+			b.WriteByte('%')
+			b.WriteByte(hexDigits[c>>4])
+			b.WriteByte(hexDigits[c&15])
+		}
+	}
+	return b.String()
+}
+
 // ClientWithResponses builds on ClientInterface to offer response payloads
 type ClientWithResponses struct {
 	ClientInterface
@@ -737,6 +890,29 @@ func WithBaseURL(baseURL string) ClientOption {
 	}
 }
 
+// ParseAs reads and closes the body of an HTTP response, decoding it as JSON
+// into a T. This is useful for responses which the typed fields of a
+// response don't cover, such as for a status code which isn't documented.
+func ParseAs[T any](rsp *http.Response) (T, error) {
+	var dest T
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return dest, err
+	}
+	return ResponseAs[T](bodyBytes)
+}
+
+// ResponseAs decodes the Body of a response returned by ClientWithResponses
+// as JSON into a T, such as `ResponseAs[Error](resp.Body)`.
+func ResponseAs[T any](body []byte) (T, error) {
+	var dest T
+	if err := json.Unmarshal(body, &dest); err != nil {
+		return dest, err
+	}
+	return dest, nil
+}
+
 // ClientWithResponsesInterface is the interface specification for the client with responses above.
 type ClientWithResponsesInterface interface {
 	// EnsureEverythingIsReferencedWithResponse request
@@ -748,6 +924,9 @@ type ClientWithResponsesInterface interface {
 	// Issue127WithResponse request
 	Issue127WithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*Issue127Response, error)
 
+	// Issue127WithResponseStream request, streaming the body of the response
+	Issue127WithResponseStream(ctx context.Context, reqEditors ...RequestEditorFn) (*Issue127ResponseStream, error)
+
 	// Issue185WithBodyWithResponse request with any body
 	Issue185WithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*Issue185Response, error)
 
@@ -833,6 +1012,7 @@ type Issue127Response struct {
 	JSON200      *GenericObject
 	XML200       *GenericObject
 	YAML200      *GenericObject
+	JSONDefault  *GenericObject
 }
 
 // Status returns HTTPResponse.Status
@@ -851,6 +1031,48 @@ func (r Issue127Response) StatusCode() int {
 	return 0
 }
 
+// Issue127ResponseStream is the response to a Issue127 request, whose body
+// is streamed rather than read into memory, such as to download a large file.
+type Issue127ResponseStream struct {
+	// Body streams the body of the response, and must be closed.
+	Body         io.ReadCloser
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r Issue127ResponseStream) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r Issue127ResponseStream) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentLength returns the length of the body, from the Content-Length
+// header, or -1 if it's unknown.
+func (r Issue127ResponseStream) ContentLength() int64 {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.ContentLength
+	}
+	return -1
+}
+
+// ContentDisposition returns the Content-Disposition header of the response,
+// such as `attachment; filename="pet.png"`, which mime.ParseMediaType parses.
+func (r Issue127ResponseStream) ContentDisposition() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Disposition")
+	}
+	return ""
+}
+
 type Issue185Response struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -1027,6 +1249,15 @@ func (c *ClientWithResponses) Issue127WithResponse(ctx context.Context, reqEdito
 	return ParseIssue127Response(rsp)
 }
 
+// Issue127WithResponseStream request returning *Issue127ResponseStream, whose body must be closed
+func (c *ClientWithResponses) Issue127WithResponseStream(ctx context.Context, reqEditors ...RequestEditorFn) (*Issue127ResponseStream, error) {
+	rsp, err := c.Issue127(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseIssue127ResponseStream(rsp)
+}
+
 // Issue185WithBodyWithResponse request with arbitrary body returning *Issue185Response
 func (c *ClientWithResponses) Issue185WithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*Issue185Response, error) {
 	rsp, err := c.Issue185WithBody(ctx, contentType, body, reqEditors...)
@@ -1209,6 +1440,16 @@ func ParseIssue127Response(rsp *http.Response) (*Issue127Response, error) {
 		response.YAML200 = &dest
 
 	case rsp.StatusCode == 200:
+	// Content-type (text/markdown) unsupported
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest GenericObject
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	case true:
 		// Content-type (text/markdown) unsupported
 
 	}
@@ -1216,6 +1457,14 @@ func ParseIssue127Response(rsp *http.Response) (*Issue127Response, error) {
 	return response, nil
 }
 
+// ParseIssue127ResponseStream wraps an HTTP response from a Issue127WithResponseStream call, without reading its body
+func ParseIssue127ResponseStream(rsp *http.Response) (*Issue127ResponseStream, error) {
+	return &Issue127ResponseStream{
+		Body:         rsp.Body,
+		HTTPResponse: rsp,
+	}, nil
+}
+
 // ParseIssue185Response parses an HTTP response from a Issue185WithResponse call
 func ParseIssue185Response(rsp *http.Response) (*Issue185Response, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -1484,7 +1733,7 @@ func (w *ServerInterfaceWrapper) Issue41(ctx echo.Context) error {
 	// ------------- Path parameter "1param" -------------
 	var n1param N5StartsWithNumber
 
-	err = runtime.BindStyledParameterWithOptions("simple", "1param", ctx.Param("1param"), &n1param, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "1param", ctx.Param("n1param"), &n1param, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter 1param: %s", err))
 	}
@@ -1562,7 +1811,7 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	router.GET(baseURL+"/issues/209/$:str", wrapper.Issue209)
 	router.GET(baseURL+"/issues/30/:fallthrough", wrapper.Issue30)
 	router.GET(baseURL+"/issues/375", wrapper.GetIssues375)
-	router.GET(baseURL+"/issues/41/:1param", wrapper.Issue41)
+	router.GET(baseURL+"/issues/41/:n1param", wrapper.Issue41)
 	router.GET(baseURL+"/issues/9", wrapper.Issue9)
 	router.GET(baseURL+"/issues/975", wrapper.Issue975)
 
@@ -1571,32 +1820,33 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/7RYUW/bPg7/KppuwD1cEifpiq156+12QwZcW6w97GHZg2IxsVab8iQ5qRH4u/9ByYmT",
-	"2W7Xf7enxJJI/Uj+RIra8VhnuUZAZ/lsx3NhRAYOjP+6dUbheo43wiX0LcHGRuVOaeQzfsmsn2e5cAk7",
-	"SPIBVzRNo3zAUWTAZ9w6mjDwo1AGJJ85U8CA2ziBTJBqV+b1MoVrXlXVftIDOb91wjj7RbnkqsiWYNpo",
-	"7hJlWRBhtCezXoRtlUuYYBjEBvuN9PI7xI5XA36J5V2Zw4TPds3XtMPceoYZyA1Y8hgTWDJSOFrgAgOC",
-	"RBepZEtgAplCB2YlYthVC6S93hfW6Sy49c4D2fGVNplwfMZjP9lArH0x4A9DLXI1jLWENeAQHpwRQyfW",
-	"NohrPuNLYTj57D+ELRYO5I3RORhX+qiG/wq8BMKWJtsW/t8Cc2QE6u2rFo5qwHXq1U6C6H6nfTD7lk+7",
-	"l5/uPXessCCZ00zqgEKgZC4R7hEkZ7+ChBzYrBkaEPZg7lXwBVNoHQj56kj3m98N+3k4quPT8vUQtAM8",
-	"/q2Dyx+wyOZ4vfw+x0tjhA++cpDZNgs2IqUfwCIj/StlLEG2EGuUR8oPJ7Jju3pA+K2qAf8ICEbF12FB",
-	"c6obiasiTcUyhZsTLKfItHdugNcOfD15iXKvy3P68L+Hi40vd/2Tz1P6U4QO/7v1dYXrunBgKA9QYrtE",
-	"jWWmCztHDAnu1C2qZ/jYJEo4azAtbEq29yc6rvWQBod1kvY7fwb6kgc41we4YdXuCT/4VYMabtvqynOs",
-	"MMqVt5StgxUijsHaodP3gPS9BGHA/HefGj99uRuGPMnCSuZXjhbI6zpBWwSh5twlzuWhlChc6Y6SAdax",
-	"WFiwbKUN2wijdGGZsrbwQwVKpjdgmFMZjNhNCsICE1IywdxelkQXSIVgWazZSj2ADLCcckSdsMstmI2H",
-	"tgFjw+6T0Xg0DpQGFLniM342Go8mfOBLp3dLBGgLA0PYgCldonA9VHZoYAUGMA5sXoPrqYaAMtcKHYMH",
-	"ZZ1lVvvExJqSz2KBVKtiA5STmEKfwxZoc4h9JkPtaEFuCgTp7SLyCdpmLvmMf/AAPxzwze3nBh0Rw+Ya",
-	"bQjydDymn1ijA/SgRZ6nKvbaou8+G+6O7gSnRBdNneavDaz4jP8jakyJ6utCdKjn1WAvM/1FmSnJxB01",
-	"+jHZVk3vSJU0dBogGqsGPApkiybj80lvMP9XpE7lKbAMpBL+wmEZeVEoZJ9ur6864jInvV7rC4PQPr7H",
-	"6zcoR1Zn4GM/2kz+9bSCJ10xfdvvCXEPjAjHCrRFnmtDrPW2PLjaMVLjPx3LDUCWO9as8rOjXldN377U",
-	"U4+R5LQy/uzFhyx9iSoyPsqEuZd6iy9WVIqXoOmILo2sRJG6P+jN3+SCJ7n57rw/5ZY5sDUp9CaxbQLI",
-	"9teVaF8hWZPUmDDA9neMfmK+O69vFGDdv7Usf5sXO+5iVXVq73R8Eb3eWWeqXrPfJxDfW6ZWTQcYLJMQ",
-	"p6KxOC277ZuOL4gix43n127czZLopDGtvh0hPhtHu5VIU5cYXayTqg34M1gqxpLdQ7nVRh73bLkBX8Gp",
-	"ENJ1gNzju8k6cdQe6DDjbNxhRUcffITtWf3wiY1v+1lIHUDt+pqGwu5ZSUlwq2KgYLkEGN39/bxC6lZD",
-	"hl7gNlFxUo9bJYHpFU37W34XTT+C8y6whOsP5tBWc/P0eX0ziXYTH5R+At/sY3b0bKBwHR4ODs8GHSF/",
-	"E65qT0U87P9osB+zuv30QWQ4svGi/2imCtCFc2l9/WMKY20MxC4t6X9aSJD+8lsnmGD1UsuSbn8LbMzr",
-	"TVAXPV74UYApj4iv9fMI/7eTXgctrus07C07gbESqYUTzlw8crqa5xW2UpA2uWMNjok609H9OgN0vQ77",
-	"s8ek4wmowyP+8a6I64DLll1+eCNMSUchhQ2klAakjgsyzePidbHYt3M+9KeN3NdvFEefb2tqFCatO7NZ",
-	"FNWdD/VSIwmQZyIfCUUJ/a8AAAD//xMX9T6YFAAA",
+	"H4sIAAAAAAAC/8RYW4/buhH+K+z0AH2ofE2CnPhtm6YHPkCzQXaLPMT7QItji1lpqJCUbcHwfy+GkizL",
+	"1t6aBbovK4tz/Wb4cag9mBxJ5hpm8GY4Hk4gAk0rA7M9bNA6bQhmMBmOh2OIwGufIszgFp0XN2g3aCEC",
+	"hS62OveVbFiLpUMnVsaKjbTaFE5o54rwqiAlzAat8DrDofiSonQopFJCCt/osuqCJJViWazFSu9QDRcE",
+	"hwhc8Opg9n0PhU1hBon3+Ww0qvNgE0OFmGcyH0oNh7sIcukTxxmNkFxhcYAbtKVPNK0H2g0srtAixahY",
+	"Zo2e/5kcreSU5gpm8CnofTqqzd3XVukCgUQ7gaRyo8kL3GnnnXBG+ER6EZssN4TknYgliSWK2KL0qIQm",
+	"4RPtFuRyjIUkJch4FshtQagWBBFYdLkhhyGb6XjM/2JDHikELfM81XEIe/TDcTB7cHGCmeQnX+YIMzDL",
+	"Hxh7iCC3nKTXlTVJ5W2Z44Sff7O4ghn8ddRGO6rsuNFVI3eIGp3pM3WmrBMXzpvsxltN69sQ0eO6H8/l",
+	"D/x3jjlUL0dVm40+PFjJOQt8uKjZx1QjeZFLKzMnGCmhKTbWYuzTkp/TQqEKLW3xZ8GNutU+EUujSi7W",
+	"goIuerRuGGrV/g7NSjJDmMHKmLDFYAY/C7RlKOrPQlvuPm8LjC4r5kLycDjcVcLo/D+MKlmim8V1eJBp",
+	"iKpjeSVTh9Gze+Vw6OL5Zjzar2Sa+sSaYp0cHof3zRguCvQVw85V4h7LrbHKCZeYIlVVg2PY4rwFmC/k",
+	"MkXBeD2OZBtPgyhv9JcBepLj28loPwmunkjv7eSifb40EYawhfPSek3rqkWoyJaBKPvzqHw+O4XH9sq7",
+	"G3bsvmmffK6ccoonOU6m7x/PjQXOk/u3vEfB/CcKckWeG8t0FVpp58NecUIZ+pvnQmKWe9FKhdXhJXcp",
+	"XMki9S/ir8cy/wMJrY6vK27jlDm4USbtvTLbXzDUwzTRi5n3/xJ5baiUWforRk4z22XpqyLZ4ZjJ7++e",
+	"6M3f312etczUa6RaWGwTJGFqGhw1u0i0R52QFgUVacok0zRmh1Jfpaqfaw9fjo7hnFOn4w+j3/bO20A3",
+	"Z9zQZ7sVGVXH4Zy+MFvwsfAwatNxz2mXYHzvhF6J1mYARmGcyhawtITOKfD+tEJdk5+oyGr1uhLSNYVh",
+	"HtjqGNmhT1Agy/K6JiFJVAPJgraJjpP6vdMKhVnxsrRWlsNfH38eKxdHP6fr5Y85XbG7B9o1OgP4D/QB",
+	"Y8fIdOaP90/0MgucO/gn5hbjANdKY9oekWv0QtYl4QEyQ/IP4tG1eSWct0VcDyvqwkN4vZG25NMqxQ2m",
+	"jLoyccFOQtAQvQ7GbXr1pijrLXHKAeN3kydIgCUuTqgi9TpPUWSotKyPJA5aahJ/3lx/fmn3bEgNnckw",
+	"DPvDzeTvT03T50z5tHw/HfKAfISuNRAeu4R6aTKCq3aGb39Ne7qiXhGWa+LYl+DLFhscLmhB4QrTDmiS",
+	"hCaPdiVj3B+qi9jHnjm+O15FsDI2kx5m9dAPEewGRuZ6EBuFa6QB7ryVAy/XIUGejWewlDbA2UOhT15i",
+	"GubvmfWi4+IVqcZ2X9B0XPO2wMPpLNZjtFl8mdGO1e/tc789nlIvR7seMHruoVX/nIyl9Z6XzVh6iOCC",
+	"/VrTgXwhAu0xewb+mz7oI2DC5zxX2jpWchgbUnBXNXwPMfQ4OsWLcMuiEIFJgxLcnUfSiPRE00XpPw7D",
+	"vVuQ2f4FDkeTk37VJtRjd9Ti0+eIn/uee1E4Ph2NUKaKgu/9/KHgJJI3zzO9G7TvBhalO6b3ucKCj1WP",
+	"Up1m+fbVbb8oRy7/deHRMoNwb1+RoTIzhZsT9ff4WZnDHapnX+qH9HeDtRnwy0GlC8HTV+Rf6uj+ut+d",
+	"PiUBTR7XWJHVaXfq0Nndd8FXE1VYPR349tAZ6I55cT4Q9R3pfFzzVbEd4P7nO3D4qBYXVvvyhoWqTGUc",
+	"o3MDb+6RTrT4YxvU1vj3EqUNvquHfzWE/+e320HF/qKyJIKl8BHv1GMYdru+vt8d7g7/HQBRPGEHmBQA",
+	"AA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
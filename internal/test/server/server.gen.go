@@ -85,6 +85,12 @@ type SomeObject struct {
 	Name string `json:"name"`
 }
 
+// ResponseWithReference defines model for ResponseWithReference.
+type ResponseWithReference = SomeObject
+
+// SimpleResponse defines model for SimpleResponse.
+type SimpleResponse = SomeObject
+
 // GetWithArgsParams defines parameters for GetWithArgs.
 type GetWithArgsParams struct {
 	// OptionalArgument An optional query argument
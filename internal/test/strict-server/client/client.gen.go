@@ -9,9 +9,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/oapi-codegen/runtime"
 )
@@ -21,6 +23,9 @@ type Example struct {
 	Value *string `json:"value,omitempty"`
 }
 
+// Reusableresponse defines model for reusableresponse.
+type Reusableresponse = Example
+
 // MultipleRequestAndResponseTypesTextBody defines parameters for MultipleRequestAndResponseTypes.
 type MultipleRequestAndResponseTypesTextBody = string
 
@@ -94,6 +99,9 @@ type Client struct {
 	// A list of callbacks for modifying requests which are generated before sending over
 	// the network.
 	RequestEditors []RequestEditorFn
+
+	// A logger which logs each request, along with its response.
+	Logger *slog.Logger
 }
 
 // ClientOption allows setting custom parameters during construction
@@ -140,6 +148,63 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 	}
 }
 
+// WithLogger logs each request, with its operation, method, path template,
+// parameters, status code and latency. The values of parameters which are
+// sensitive, as they're marked with `x-sensitive` or have the `password`
+// format, are redacted.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) error {
+		c.Logger = logger
+		return nil
+	}
+}
+
+// WithHeaders sets the given headers on every request, such as an API key
+// which every operation requires. Headers set by a request editor passed to
+// an operation take precedence.
+func WithHeaders(headers http.Header) ClientOption {
+	headers = headers.Clone()
+	return WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		for key, values := range headers {
+			req.Header.Del(key)
+			for _, value := range values {
+				req.Header.Add(key, value)
+			}
+		}
+		return nil
+	})
+}
+
+// WithUserAgent sets the User-Agent header of every request.
+func WithUserAgent(userAgent string) ClientOption {
+	return WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("User-Agent", userAgent)
+		return nil
+	})
+}
+
+// reservedGoKeywordParametersLogAttrs returns the parameters of a ReservedGoKeywordParameters
+// request, which are logged along with it.
+func reservedGoKeywordParametersLogAttrs(pType string) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	logAttrs_ = append(logAttrs_, slog.Any("type", pType))
+	return logAttrs_
+}
+
+// headersExampleLogAttrs returns the parameters of a HeadersExample
+// request, which are logged along with it.
+func headersExampleLogAttrs(params *HeadersExampleParams) []slog.Attr {
+	var logAttrs_ []slog.Attr
+	if params == nil {
+		return logAttrs_
+	}
+	logAttrs_ = append(logAttrs_, slog.Any("header1", params.Header1))
+	if params.Header2 != nil {
+		logAttrs_ = append(logAttrs_, slog.Any("header2", *params.Header2))
+	}
+	return logAttrs_
+}
+
 // The interface specification for the client above.
 type ClientInterface interface {
 	// JSONExampleWithBody request with any body
@@ -206,7 +271,7 @@ func (c *Client) JSONExampleWithBody(ctx context.Context, contentType string, bo
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "JSONExample", "/json", nil)
 }
 
 func (c *Client) JSONExample(ctx context.Context, body JSONExampleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -218,7 +283,7 @@ func (c *Client) JSONExample(ctx context.Context, body JSONExampleJSONRequestBod
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "JSONExample", "/json", nil)
 }
 
 func (c *Client) MultipartExampleWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -230,7 +295,7 @@ func (c *Client) MultipartExampleWithBody(ctx context.Context, contentType strin
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "MultipartExample", "/multipart", nil)
 }
 
 func (c *Client) MultipartRelatedExampleWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -242,7 +307,7 @@ func (c *Client) MultipartRelatedExampleWithBody(ctx context.Context, contentTyp
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "MultipartRelatedExample", "/multipart-related", nil)
 }
 
 func (c *Client) MultipleRequestAndResponseTypesWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -254,7 +319,7 @@ func (c *Client) MultipleRequestAndResponseTypesWithBody(ctx context.Context, co
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "MultipleRequestAndResponseTypes", "/multiple", nil)
 }
 
 func (c *Client) MultipleRequestAndResponseTypes(ctx context.Context, body MultipleRequestAndResponseTypesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -266,7 +331,7 @@ func (c *Client) MultipleRequestAndResponseTypes(ctx context.Context, body Multi
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "MultipleRequestAndResponseTypes", "/multiple", nil)
 }
 
 func (c *Client) MultipleRequestAndResponseTypesWithFormdataBody(ctx context.Context, body MultipleRequestAndResponseTypesFormdataRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -278,7 +343,7 @@ func (c *Client) MultipleRequestAndResponseTypesWithFormdataBody(ctx context.Con
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "MultipleRequestAndResponseTypes", "/multiple", nil)
 }
 
 func (c *Client) MultipleRequestAndResponseTypesWithTextBody(ctx context.Context, body MultipleRequestAndResponseTypesTextRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -290,7 +355,7 @@ func (c *Client) MultipleRequestAndResponseTypesWithTextBody(ctx context.Context
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "MultipleRequestAndResponseTypes", "/multiple", nil)
 }
 
 func (c *Client) ReservedGoKeywordParameters(ctx context.Context, pType string, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -302,7 +367,7 @@ func (c *Client) ReservedGoKeywordParameters(ctx context.Context, pType string,
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "ReservedGoKeywordParameters", "/reserved-go-keyword-parameters/{type}", func() []slog.Attr { return reservedGoKeywordParametersLogAttrs(pType) })
 }
 
 func (c *Client) ReusableResponsesWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -314,7 +379,7 @@ func (c *Client) ReusableResponsesWithBody(ctx context.Context, contentType stri
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "ReusableResponses", "/reusable-responses", nil)
 }
 
 func (c *Client) ReusableResponses(ctx context.Context, body ReusableResponsesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -326,7 +391,7 @@ func (c *Client) ReusableResponses(ctx context.Context, body ReusableResponsesJS
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "ReusableResponses", "/reusable-responses", nil)
 }
 
 func (c *Client) TextExampleWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -338,7 +403,7 @@ func (c *Client) TextExampleWithBody(ctx context.Context, contentType string, bo
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "TextExample", "/text", nil)
 }
 
 func (c *Client) TextExampleWithTextBody(ctx context.Context, body TextExampleTextRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -350,7 +415,7 @@ func (c *Client) TextExampleWithTextBody(ctx context.Context, body TextExampleTe
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "TextExample", "/text", nil)
 }
 
 func (c *Client) UnknownExampleWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -362,7 +427,7 @@ func (c *Client) UnknownExampleWithBody(ctx context.Context, contentType string,
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "UnknownExample", "/unknown", nil)
 }
 
 func (c *Client) UnspecifiedContentTypeWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -374,7 +439,7 @@ func (c *Client) UnspecifiedContentTypeWithBody(ctx context.Context, contentType
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "UnspecifiedContentType", "/unspecified-content-type", nil)
 }
 
 func (c *Client) URLEncodedExampleWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -386,7 +451,7 @@ func (c *Client) URLEncodedExampleWithBody(ctx context.Context, contentType stri
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "URLEncodedExample", "/urlencoded", nil)
 }
 
 func (c *Client) URLEncodedExampleWithFormdataBody(ctx context.Context, body URLEncodedExampleFormdataRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -398,7 +463,7 @@ func (c *Client) URLEncodedExampleWithFormdataBody(ctx context.Context, body URL
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "URLEncodedExample", "/urlencoded", nil)
 }
 
 func (c *Client) HeadersExampleWithBody(ctx context.Context, params *HeadersExampleParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -410,7 +475,7 @@ func (c *Client) HeadersExampleWithBody(ctx context.Context, params *HeadersExam
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "HeadersExample", "/with-headers", func() []slog.Attr { return headersExampleLogAttrs(params) })
 }
 
 func (c *Client) HeadersExample(ctx context.Context, params *HeadersExampleParams, body HeadersExampleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -422,7 +487,7 @@ func (c *Client) HeadersExample(ctx context.Context, params *HeadersExampleParam
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "HeadersExample", "/with-headers", func() []slog.Attr { return headersExampleLogAttrs(params) })
 }
 
 func (c *Client) UnionExampleWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -434,7 +499,7 @@ func (c *Client) UnionExampleWithBody(ctx context.Context, contentType string, b
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "UnionExample", "/with-union", nil)
 }
 
 func (c *Client) UnionExample(ctx context.Context, body UnionExampleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -446,7 +511,7 @@ func (c *Client) UnionExample(ctx context.Context, body UnionExampleJSONRequestB
 	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-	return c.Client.Do(req)
+	return c.do(req, "UnionExample", "/with-union", nil)
 }
 
 // NewJSONExampleRequest calls the generic JSONExample builder with application/json body
@@ -615,6 +680,7 @@ func NewReservedGoKeywordParametersRequest(server string, pType string) (*http.R
 	if err != nil {
 		return nil, err
 	}
+	pathParam0 = escapeDotSegments(pathParam0)
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
@@ -917,6 +983,44 @@ func NewUnionExampleRequestWithBody(server string, contentType string, body io.R
 	return req, nil
 }
 
+// do sends the request, reporting the progress of the response's body to the
+// callback of WithDownloadProgress, if the request's context has one.
+func (c *Client) do(req *http.Request, operationID, path string, params func() []slog.Attr) (*http.Response, error) {
+	rsp, err := c.send(req, operationID, path, params)
+	if err != nil {
+		return nil, err
+	}
+	if progress, ok := req.Context().Value(downloadProgressKey{}).(ProgressFunc); ok {
+		rsp.Body = &progressReader{ReadCloser: rsp.Body, total: rsp.ContentLength, progress: progress}
+	}
+	return rsp, nil
+}
+
+// send sends the request, logging it with the Logger, if there is one. The
+// parameters of the request are only collected when they're logged.
+func (c *Client) send(req *http.Request, operationID, path string, params func() []slog.Attr) (*http.Response, error) {
+	if c.Logger == nil {
+		return c.Client.Do(req)
+	}
+	start := time.Now()
+	rsp, err := c.Client.Do(req)
+	attrs := []slog.Attr{
+		slog.String("operation", operationID),
+		slog.String("method", req.Method),
+		slog.String("path", path),
+		slog.Duration("latency", time.Since(start)),
+	}
+	if params != nil {
+		attrs = append(attrs, slog.Attr{Key: "params", Value: slog.GroupValue(params()...)})
+	}
+	if err != nil {
+		c.Logger.LogAttrs(req.Context(), slog.LevelError, "request failed", append(attrs, slog.Any("error", err))...)
+		return nil, err
+	}
+	c.Logger.LogAttrs(req.Context(), slog.LevelInfo, "request", append(attrs, slog.Int("status", rsp.StatusCode))...)
+	return rsp, nil
+}
+
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
 	for _, r := range c.RequestEditors {
 		if err := r(ctx, req); err != nil {
@@ -931,6 +1035,115 @@ func (c *Client) applyEditors(ctx context.Context, req *http.Request, additional
 	return nil
 }
 
+// ProgressFunc is called with the number of bytes of a body transferred so
+// far, and its total size, which is -1 when it's unknown, each time more of it
+// is transferred.
+type ProgressFunc func(transferred, total int64)
+
+// WithUploadProgress reports the progress of sending the body of the request,
+// such as a file being uploaded, to progress. When the request is retried, the
+// progress starts again.
+func WithUploadProgress(progress ProgressFunc) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		if req.Body == nil || req.Body == http.NoBody {
+			return nil
+		}
+		total := req.ContentLength
+		if total == 0 {
+			total = -1
+		}
+		req.Body = &progressReader{ReadCloser: req.Body, total: total, progress: progress}
+		if getBody := req.GetBody; getBody != nil {
+			req.GetBody = func() (io.ReadCloser, error) {
+				body, err := getBody()
+				if err != nil {
+					return nil, err
+				}
+				return &progressReader{ReadCloser: body, total: total, progress: progress}, nil
+			}
+		}
+		return nil
+	}
+}
+
+// downloadProgressKey is the key of the request's context which holds the
+// callback of WithDownloadProgress.
+type downloadProgressKey struct{}
+
+// WithDownloadProgress returns a copy of ctx, which reports the progress of
+// reading the body of the response to a request made with it, such as a file
+// being downloaded, to progress.
+func WithDownloadProgress(ctx context.Context, progress ProgressFunc) context.Context {
+	return context.WithValue(ctx, downloadProgressKey{}, progress)
+}
+
+// progressReader reports the progress of reading a body to a ProgressFunc.
+type progressReader struct {
+	io.ReadCloser
+	transferred int64
+	total       int64
+	progress    ProgressFunc
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 {
+		r.transferred += int64(n)
+		r.progress(r.transferred, r.total)
+	}
+	return n, err
+}
+
+// escapePathParam escapes the value of a path parameter, so that it's sent as
+// the parameter in the path of a request. The value is escaped with
+// url.PathEscape, so that a `/`, `%`, `?` or unicode character in it is
+// percent-encoded, unless allowReserved is set, for a parameter with
+// `allowReserved: true`, when the reserved characters of RFC 3986, such as `/`,
+// and the percent-encoded octets in it are kept as they are, as they are by the
+// reserved expansion of RFC 6570.
+func escapePathParam(value string, allowReserved bool) string {
+	if allowReserved {
+		return escapeDotSegments(escapeReservedPathParam(value))
+	}
+	return escapeDotSegments(url.PathEscape(value))
+}
+
+// escapeDotSegments percent-encodes the segments of an escaped path parameter
+// which are `.` or `..`, so that they aren't removed from the path of a
+// request as dot-segments.
+func escapeDotSegments(escaped string) string {
+	segments := strings.Split(escaped, "/")
+	for i, segment := range segments {
+		if segment == "." || segment == ".." {
+			segments[i] = strings.ReplaceAll(segment, ".", "%2E")
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// escapeReservedPathParam percent-encodes the characters of value which can't
+// be in the path of a URL as they are, keeping its reserved characters and
+// percent-encoded octets, apart from `?` and `#`, which would end the path.
+func escapeReservedPathParam(value string) string {
+	const hexDigits = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', strings.IndexByte("-._~:/@!$&'()*+,;=[]", c) >= 0:
+			b.WriteByte(c)
+		case c == '%' && i+2 < len(value) && strings.IndexByte("0123456789ABCDEFabcdef", value[i+1]) >= 0 && strings.IndexByte("0123456789ABCDEFabcdef", value[i+2]) >= 0:
+			b.WriteString(value[i : i+3])
+			i += 2
+		default:
+			b.WriteByte('%')
+			b.WriteByte(hexDigits[c>>4])
+			b.WriteByte(hexDigits[c&15])
+		}
+	}
+	return b.String()
+}
+
 // ClientWithResponses builds on ClientInterface to offer response payloads
 type ClientWithResponses struct {
 	ClientInterface
@@ -958,6 +1171,29 @@ func WithBaseURL(baseURL string) ClientOption {
 	}
 }
 
+// ParseAs reads and closes the body of an HTTP response, decoding it as JSON
+// into a T. This is useful for responses which the typed fields of a
+// response don't cover, such as for a status code which isn't documented.
+func ParseAs[T any](rsp *http.Response) (T, error) {
+	var dest T
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return dest, err
+	}
+	return ResponseAs[T](bodyBytes)
+}
+
+// ResponseAs decodes the Body of a response returned by ClientWithResponses
+// as JSON into a T, such as `ResponseAs[Error](resp.Body)`.
+func ResponseAs[T any](body []byte) (T, error) {
+	var dest T
+	if err := json.Unmarshal(body, &dest); err != nil {
+		return dest, err
+	}
+	return dest, nil
+}
+
 // ClientWithResponsesInterface is the interface specification for the client with responses above.
 type ClientWithResponsesInterface interface {
 	// JSONExampleWithBodyWithResponse request with any body
@@ -974,6 +1210,9 @@ type ClientWithResponsesInterface interface {
 	// MultipleRequestAndResponseTypesWithBodyWithResponse request with any body
 	MultipleRequestAndResponseTypesWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*MultipleRequestAndResponseTypesResponse, error)
 
+	// MultipleRequestAndResponseTypesWithBodyWithResponseStream request with any body, streaming the body of the response
+	MultipleRequestAndResponseTypesWithBodyWithResponseStream(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*MultipleRequestAndResponseTypesResponseStream, error)
+
 	MultipleRequestAndResponseTypesWithResponse(ctx context.Context, body MultipleRequestAndResponseTypesJSONRequestBody, reqEditors ...RequestEditorFn) (*MultipleRequestAndResponseTypesResponse, error)
 
 	MultipleRequestAndResponseTypesWithFormdataBodyWithResponse(ctx context.Context, body MultipleRequestAndResponseTypesFormdataRequestBody, reqEditors ...RequestEditorFn) (*MultipleRequestAndResponseTypesResponse, error)
@@ -996,9 +1235,15 @@ type ClientWithResponsesInterface interface {
 	// UnknownExampleWithBodyWithResponse request with any body
 	UnknownExampleWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UnknownExampleResponse, error)
 
+	// UnknownExampleWithBodyWithResponseStream request with any body, streaming the body of the response
+	UnknownExampleWithBodyWithResponseStream(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UnknownExampleResponseStream, error)
+
 	// UnspecifiedContentTypeWithBodyWithResponse request with any body
 	UnspecifiedContentTypeWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UnspecifiedContentTypeResponse, error)
 
+	// UnspecifiedContentTypeWithBodyWithResponseStream request with any body, streaming the body of the response
+	UnspecifiedContentTypeWithBodyWithResponseStream(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UnspecifiedContentTypeResponseStream, error)
+
 	// URLEncodedExampleWithBodyWithResponse request with any body
 	URLEncodedExampleWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*URLEncodedExampleResponse, error)
 
@@ -1101,6 +1346,48 @@ func (r MultipleRequestAndResponseTypesResponse) StatusCode() int {
 	return 0
 }
 
+// MultipleRequestAndResponseTypesResponseStream is the response to a MultipleRequestAndResponseTypes request, whose body
+// is streamed rather than read into memory, such as to download a large file.
+type MultipleRequestAndResponseTypesResponseStream struct {
+	// Body streams the body of the response, and must be closed.
+	Body         io.ReadCloser
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r MultipleRequestAndResponseTypesResponseStream) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r MultipleRequestAndResponseTypesResponseStream) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentLength returns the length of the body, from the Content-Length
+// header, or -1 if it's unknown.
+func (r MultipleRequestAndResponseTypesResponseStream) ContentLength() int64 {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.ContentLength
+	}
+	return -1
+}
+
+// ContentDisposition returns the Content-Disposition header of the response,
+// such as `attachment; filename="pet.png"`, which mime.ParseMediaType parses.
+func (r MultipleRequestAndResponseTypesResponseStream) ContentDisposition() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Disposition")
+	}
+	return ""
+}
+
 type ReservedGoKeywordParametersResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -1186,6 +1473,48 @@ func (r UnknownExampleResponse) StatusCode() int {
 	return 0
 }
 
+// UnknownExampleResponseStream is the response to a UnknownExample request, whose body
+// is streamed rather than read into memory, such as to download a large file.
+type UnknownExampleResponseStream struct {
+	// Body streams the body of the response, and must be closed.
+	Body         io.ReadCloser
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r UnknownExampleResponseStream) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UnknownExampleResponseStream) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentLength returns the length of the body, from the Content-Length
+// header, or -1 if it's unknown.
+func (r UnknownExampleResponseStream) ContentLength() int64 {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.ContentLength
+	}
+	return -1
+}
+
+// ContentDisposition returns the Content-Disposition header of the response,
+// such as `attachment; filename="pet.png"`, which mime.ParseMediaType parses.
+func (r UnknownExampleResponseStream) ContentDisposition() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Disposition")
+	}
+	return ""
+}
+
 type UnspecifiedContentTypeResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -1207,6 +1536,48 @@ func (r UnspecifiedContentTypeResponse) StatusCode() int {
 	return 0
 }
 
+// UnspecifiedContentTypeResponseStream is the response to a UnspecifiedContentType request, whose body
+// is streamed rather than read into memory, such as to download a large file.
+type UnspecifiedContentTypeResponseStream struct {
+	// Body streams the body of the response, and must be closed.
+	Body         io.ReadCloser
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r UnspecifiedContentTypeResponseStream) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UnspecifiedContentTypeResponseStream) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ContentLength returns the length of the body, from the Content-Length
+// header, or -1 if it's unknown.
+func (r UnspecifiedContentTypeResponseStream) ContentLength() int64 {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.ContentLength
+	}
+	return -1
+}
+
+// ContentDisposition returns the Content-Disposition header of the response,
+// such as `attachment; filename="pet.png"`, which mime.ParseMediaType parses.
+func (r UnspecifiedContentTypeResponseStream) ContentDisposition() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header.Get("Content-Disposition")
+	}
+	return ""
+}
+
 type URLEncodedExampleResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -1320,6 +1691,15 @@ func (c *ClientWithResponses) MultipleRequestAndResponseTypesWithBodyWithRespons
 	return ParseMultipleRequestAndResponseTypesResponse(rsp)
 }
 
+// MultipleRequestAndResponseTypesWithBodyWithResponseStream request with arbitrary body returning *MultipleRequestAndResponseTypesResponseStream, whose body must be closed
+func (c *ClientWithResponses) MultipleRequestAndResponseTypesWithBodyWithResponseStream(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*MultipleRequestAndResponseTypesResponseStream, error) {
+	rsp, err := c.MultipleRequestAndResponseTypesWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseMultipleRequestAndResponseTypesResponseStream(rsp)
+}
+
 func (c *ClientWithResponses) MultipleRequestAndResponseTypesWithResponse(ctx context.Context, body MultipleRequestAndResponseTypesJSONRequestBody, reqEditors ...RequestEditorFn) (*MultipleRequestAndResponseTypesResponse, error) {
 	rsp, err := c.MultipleRequestAndResponseTypes(ctx, body, reqEditors...)
 	if err != nil {
@@ -1396,6 +1776,15 @@ func (c *ClientWithResponses) UnknownExampleWithBodyWithResponse(ctx context.Con
 	return ParseUnknownExampleResponse(rsp)
 }
 
+// UnknownExampleWithBodyWithResponseStream request with arbitrary body returning *UnknownExampleResponseStream, whose body must be closed
+func (c *ClientWithResponses) UnknownExampleWithBodyWithResponseStream(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UnknownExampleResponseStream, error) {
+	rsp, err := c.UnknownExampleWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUnknownExampleResponseStream(rsp)
+}
+
 // UnspecifiedContentTypeWithBodyWithResponse request with arbitrary body returning *UnspecifiedContentTypeResponse
 func (c *ClientWithResponses) UnspecifiedContentTypeWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UnspecifiedContentTypeResponse, error) {
 	rsp, err := c.UnspecifiedContentTypeWithBody(ctx, contentType, body, reqEditors...)
@@ -1405,6 +1794,15 @@ func (c *ClientWithResponses) UnspecifiedContentTypeWithBodyWithResponse(ctx con
 	return ParseUnspecifiedContentTypeResponse(rsp)
 }
 
+// UnspecifiedContentTypeWithBodyWithResponseStream request with arbitrary body returning *UnspecifiedContentTypeResponseStream, whose body must be closed
+func (c *ClientWithResponses) UnspecifiedContentTypeWithBodyWithResponseStream(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UnspecifiedContentTypeResponseStream, error) {
+	rsp, err := c.UnspecifiedContentTypeWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUnspecifiedContentTypeResponseStream(rsp)
+}
+
 // URLEncodedExampleWithBodyWithResponse request with arbitrary body returning *URLEncodedExampleResponse
 func (c *ClientWithResponses) URLEncodedExampleWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*URLEncodedExampleResponse, error) {
 	rsp, err := c.URLEncodedExampleWithBody(ctx, contentType, body, reqEditors...)
@@ -1543,6 +1941,14 @@ func ParseMultipleRequestAndResponseTypesResponse(rsp *http.Response) (*Multiple
 	return response, nil
 }
 
+// ParseMultipleRequestAndResponseTypesResponseStream wraps an HTTP response from a MultipleRequestAndResponseTypesWithResponseStream call, without reading its body
+func ParseMultipleRequestAndResponseTypesResponseStream(rsp *http.Response) (*MultipleRequestAndResponseTypesResponseStream, error) {
+	return &MultipleRequestAndResponseTypesResponseStream{
+		Body:         rsp.Body,
+		HTTPResponse: rsp,
+	}, nil
+}
+
 // ParseReservedGoKeywordParametersResponse parses an HTTP response from a ReservedGoKeywordParametersWithResponse call
 func ParseReservedGoKeywordParametersResponse(rsp *http.Response) (*ReservedGoKeywordParametersResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -1617,6 +2023,14 @@ func ParseUnknownExampleResponse(rsp *http.Response) (*UnknownExampleResponse, e
 	return response, nil
 }
 
+// ParseUnknownExampleResponseStream wraps an HTTP response from a UnknownExampleWithResponseStream call, without reading its body
+func ParseUnknownExampleResponseStream(rsp *http.Response) (*UnknownExampleResponseStream, error) {
+	return &UnknownExampleResponseStream{
+		Body:         rsp.Body,
+		HTTPResponse: rsp,
+	}, nil
+}
+
 // ParseUnspecifiedContentTypeResponse parses an HTTP response from a UnspecifiedContentTypeWithResponse call
 func ParseUnspecifiedContentTypeResponse(rsp *http.Response) (*UnspecifiedContentTypeResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -1633,6 +2047,14 @@ func ParseUnspecifiedContentTypeResponse(rsp *http.Response) (*UnspecifiedConten
 	return response, nil
 }
 
+// ParseUnspecifiedContentTypeResponseStream wraps an HTTP response from a UnspecifiedContentTypeWithResponseStream call, without reading its body
+func ParseUnspecifiedContentTypeResponseStream(rsp *http.Response) (*UnspecifiedContentTypeResponseStream, error) {
+	return &UnspecifiedContentTypeResponseStream{
+		Body:         rsp.Body,
+		HTTPResponse: rsp,
+	}, nil
+}
+
 // ParseURLEncodedExampleResponse parses an HTTP response from a URLEncodedExampleWithResponse call
 func ParseURLEncodedExampleResponse(rsp *http.Response) (*URLEncodedExampleResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -439,6 +439,19 @@ func HandlerWithOptions(si ServerInterface, options GorillaServerOptions) http.H
 	return r
 }
 
+type BadrequestResponse struct {
+}
+
+type ReusableresponseResponseHeaders struct {
+	Header1 string
+	Header2 int
+}
+type ReusableresponseJSONResponse struct {
+	Body Example
+
+	Headers ReusableresponseResponseHeaders
+}
+
 type JSONExampleRequestObject struct {
 	Body *JSONExampleJSONRequestBody
 }
@@ -464,6 +477,15 @@ func (response JSONExample400Response) VisitJSONExampleResponse(w http.ResponseW
 	return nil
 }
 
+type JSONExampledefaultResponse struct {
+	StatusCode int
+}
+
+func (response JSONExampledefaultResponse) VisitJSONExampleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(response.StatusCode)
+	return nil
+}
+
 type MultipartExampleRequestObject struct {
 	Body *multipart.Reader
 }
@@ -491,6 +513,15 @@ func (response MultipartExample400Response) VisitMultipartExampleResponse(w http
 	return nil
 }
 
+type MultipartExampledefaultResponse struct {
+	StatusCode int
+}
+
+func (response MultipartExampledefaultResponse) VisitMultipartExampleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(response.StatusCode)
+	return nil
+}
+
 type MultipartRelatedExampleRequestObject struct {
 	Body *multipart.Reader
 }
@@ -518,6 +549,15 @@ func (response MultipartRelatedExample400Response) VisitMultipartRelatedExampleR
 	return nil
 }
 
+type MultipartRelatedExampledefaultResponse struct {
+	StatusCode int
+}
+
+func (response MultipartRelatedExampledefaultResponse) VisitMultipartRelatedExampleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(response.StatusCode)
+	return nil
+}
+
 type MultipleRequestAndResponseTypesRequestObject struct {
 	JSONBody      *MultipleRequestAndResponseTypesJSONRequestBody
 	FormdataBody  *MultipleRequestAndResponseTypesFormdataRequestBody
@@ -554,8 +594,9 @@ func (response MultipleRequestAndResponseTypes200FormdataResponse) VisitMultiple
 }
 
 type MultipleRequestAndResponseTypes200ImagepngResponse struct {
-	Body          io.Reader
-	ContentLength int64
+	Body               io.Reader
+	ContentLength      int64
+	ContentDisposition string
 }
 
 func (response MultipleRequestAndResponseTypes200ImagepngResponse) VisitMultipleRequestAndResponseTypesResponse(w http.ResponseWriter) error {
@@ -563,6 +604,9 @@ func (response MultipleRequestAndResponseTypes200ImagepngResponse) VisitMultiple
 	if response.ContentLength != 0 {
 		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
 	}
+	if response.ContentDisposition != "" {
+		w.Header().Set("Content-Disposition", response.ContentDisposition)
+	}
 	w.WriteHeader(200)
 
 	if closer, ok := response.Body.(io.ReadCloser); ok {
@@ -654,6 +698,15 @@ func (response ReusableResponses400Response) VisitReusableResponsesResponse(w ht
 	return nil
 }
 
+type ReusableResponsesdefaultResponse struct {
+	StatusCode int
+}
+
+func (response ReusableResponsesdefaultResponse) VisitReusableResponsesResponse(w http.ResponseWriter) error {
+	w.WriteHeader(response.StatusCode)
+	return nil
+}
+
 type TextExampleRequestObject struct {
 	Body *TextExampleTextRequestBody
 }
@@ -680,6 +733,15 @@ func (response TextExample400Response) VisitTextExampleResponse(w http.ResponseW
 	return nil
 }
 
+type TextExampledefaultResponse struct {
+	StatusCode int
+}
+
+func (response TextExampledefaultResponse) VisitTextExampleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(response.StatusCode)
+	return nil
+}
+
 type UnknownExampleRequestObject struct {
 	Body io.Reader
 }
@@ -689,8 +751,9 @@ type UnknownExampleResponseObject interface {
 }
 
 type UnknownExample200Videomp4Response struct {
-	Body          io.Reader
-	ContentLength int64
+	Body               io.Reader
+	ContentLength      int64
+	ContentDisposition string
 }
 
 func (response UnknownExample200Videomp4Response) VisitUnknownExampleResponse(w http.ResponseWriter) error {
@@ -698,6 +761,9 @@ func (response UnknownExample200Videomp4Response) VisitUnknownExampleResponse(w
 	if response.ContentLength != 0 {
 		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
 	}
+	if response.ContentDisposition != "" {
+		w.Header().Set("Content-Disposition", response.ContentDisposition)
+	}
 	w.WriteHeader(200)
 
 	if closer, ok := response.Body.(io.ReadCloser); ok {
@@ -715,6 +781,15 @@ func (response UnknownExample400Response) VisitUnknownExampleResponse(w http.Res
 	return nil
 }
 
+type UnknownExampledefaultResponse struct {
+	StatusCode int
+}
+
+func (response UnknownExampledefaultResponse) VisitUnknownExampleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(response.StatusCode)
+	return nil
+}
+
 type UnspecifiedContentTypeRequestObject struct {
 	ContentType string
 	Body        io.Reader
@@ -725,9 +800,10 @@ type UnspecifiedContentTypeResponseObject interface {
 }
 
 type UnspecifiedContentType200VideoResponse struct {
-	Body          io.Reader
-	ContentType   string
-	ContentLength int64
+	Body               io.Reader
+	ContentType        string
+	ContentLength      int64
+	ContentDisposition string
 }
 
 func (response UnspecifiedContentType200VideoResponse) VisitUnspecifiedContentTypeResponse(w http.ResponseWriter) error {
@@ -735,6 +811,9 @@ func (response UnspecifiedContentType200VideoResponse) VisitUnspecifiedContentTy
 	if response.ContentLength != 0 {
 		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
 	}
+	if response.ContentDisposition != "" {
+		w.Header().Set("Content-Disposition", response.ContentDisposition)
+	}
 	w.WriteHeader(200)
 
 	if closer, ok := response.Body.(io.ReadCloser); ok {
@@ -768,6 +847,15 @@ func (response UnspecifiedContentType403Response) VisitUnspecifiedContentTypeRes
 	return nil
 }
 
+type UnspecifiedContentTypedefaultResponse struct {
+	StatusCode int
+}
+
+func (response UnspecifiedContentTypedefaultResponse) VisitUnspecifiedContentTypeResponse(w http.ResponseWriter) error {
+	w.WriteHeader(response.StatusCode)
+	return nil
+}
+
 type URLEncodedExampleRequestObject struct {
 	Body *URLEncodedExampleFormdataRequestBody
 }
@@ -798,6 +886,15 @@ func (response URLEncodedExample400Response) VisitURLEncodedExampleResponse(w ht
 	return nil
 }
 
+type URLEncodedExampledefaultResponse struct {
+	StatusCode int
+}
+
+func (response URLEncodedExampledefaultResponse) VisitURLEncodedExampleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(response.StatusCode)
+	return nil
+}
+
 type HeadersExampleRequestObject struct {
 	Params HeadersExampleParams
 	Body   *HeadersExampleJSONRequestBody
@@ -834,6 +931,15 @@ func (response HeadersExample400Response) VisitHeadersExampleResponse(w http.Res
 	return nil
 }
 
+type HeadersExampledefaultResponse struct {
+	StatusCode int
+}
+
+func (response HeadersExampledefaultResponse) VisitHeadersExampleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(response.StatusCode)
+	return nil
+}
+
 type UnionExampleRequestObject struct {
 	Body *UnionExampleJSONRequestBody
 }
@@ -885,6 +991,15 @@ func (response UnionExample400Response) VisitUnionExampleResponse(w http.Respons
 	return nil
 }
 
+type UnionExampledefaultResponse struct {
+	StatusCode int
+}
+
+func (response UnionExampledefaultResponse) VisitUnionExampleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(response.StatusCode)
+	return nil
+}
+
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
 
@@ -1361,24 +1476,23 @@ func (sh *strictHandler) UnionExample(w http.ResponseWriter, r *http.Request) {
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xYTXPbNhD9Kxi0p5QUZccn3hpPJm3T1h3ZPnV8WBFLCQkJoMBStEaj/94BQX1ZtCol",
-	"+uhkcpPIxdvle7uLBWY806XRChU5ns64RWe0ctj8GYKw+E+Fjvw/gS6z0pDUiqf8HYhB+24ecYuVg2GB",
-	"i+XePtOKUDVLwZhCZuCXJp+cXz/jLhtjCf7XjxZznvIfklUoSXjrEnyG0hTI5/N59CKCu4884mMEgbaJ",
-	"Nvy82sSmqUGeckdWqhH3IMHsutNMKsIRWu/Nm7ZBeINFHOmMG6sNWpKBowkUFXZ7ap/o4SfMKHyBVLne",
-	"5vJWKwKpHBMyz9GiItaSxzyGY64yRltCwYZT5j1kxBzaCVoecZLkA+P3689ZG7DjEZ+gdcHRVa/f63u9",
-	"tEEFRvKUv20eRdwAjZsPWgpkdJfuv93f/cmkY1CRLoFkBkUxZSVYN4YCBZOKtA+xysj1eOPJNsL/KtrV",
-	"71sqfdY0CfROi+kpEqbJy7V0vu73z5SX84jfBGddGMugkrUCa2ByqIoOzh/VZ6VrxdBabdsvS8qqIGnA",
-	"0rpWm2z/sTDZh/IlXpJrW8YCCE7E+rE8XZr42GIBhGIPAQbB8jAd1uBPqsLX+LmoBm0/7uxT92NdOzbW",
-	"NSPNBELBakljtlj4osFKxYA5qUYFskVQUaeYBbbb3s9KDNpvefAYJ+9n0QbKc1zXddwUUGULVJkWXyZh",
-	"xGUJI0yMGm0u99hAPOXDKfmU3d7gjlTIESd8psQUINXu3ftMLf0700cr7FCuFpupRMQjHX/Gaa2tiA1Y",
-	"KJHQumTmvc898Ag7SvmvpSXLQLEhMgUlCgY5oWUfNGsh3VbJDlq/H/THYLKCakae5Z/07xn3lDRjEI+4",
-	"d8DTwEqoa2m96GQrjHbQ9vSf+flVAizYDMN2vOGquw0uWtSSOou58y2xS7kO/oKnwZrFZYa23Rm3dfw4",
-	"xx7klXx963/A573GriO2vnPX9qGEVeHh65y1q/ah7Qs76R4sTqRAnZTm5kDki5HqDGYylyji9iviENtr",
-	"LeFWq8wibY5A/kinNLElmD9p0hhZYCBiTrMaWVk5YgacY5KaLlLIcFoVuNU8HleR3QZPD6t2ukvVNyfS",
-	"9M2lFL3pXx2+5O2J82ZjlHmlHge/vw82h57ZjzYzHTjxHc/vhcrZH1LitUut7hL+JRis9vQM5cRPREow",
-	"i1RZhYJNJCwuYrZqswVYydo1C4UwVtPQ4oLtkIEo2ol1vYGVQ+G6wFa3ck/f8J3RKe8yz5W4lZK77g4f",
-	"/WvWDtUvNwup1f/0ZhAKQquA5AR/Os6RchtFK7zLm9J7oXK0p4enby+r5hEPl9mhJ1W28I2DyKRJEi7B",
-	"e66G0QhtT+oEjPQs/BsAAP//cdIkn9EYAAA=",
+	"H4sIAAAAAAAC/+xYX3PbNgz/KjxsT50UOW2f9Lb2et3Wbdk57dMuD7QI2WwpkiMhKz6fvvuOomQ7duw6",
+	"zp9L7vpkJQTAH38ACIBLMBY1txJyeHM2OhtBAlKXBvIlzNF5aTTkcN6vkCSFkMMlOVkQ8+jm6Bhe88oq",
+	"9JCAQF84aSmqvTeauNSeCVmW6FATK4ym8EsLi5752lrjCAWbLJjfNAptAvHLQ/7vEmqnIIcZkc2zLEqe",
+	"+YZPp+jOpMnCAdqrBCynmQ/Ys68+QFiCNZ7Cr7HoeMD1u4Ac/ri8+PtDhL2DOqwx6RmvyVScZMGVWrCK",
+	"Oz/jCgWTmkwAWxfkzyABh//V6OmdEYuwUX/C8MmtVbLodl3h8cUMKx6+fnZYQg4/ZYWprNGoyWdx1Wc9",
+	"pdC2bRt28NZoj93JBJa8VhQ/N3F/0d+0aTRD50zH3+vRaFfq4hMkj4Hx7Wi0T2EFP5tw0bPVa2W1U6gL",
+	"I1Dsd9aX8Z8foszaZceRfp02TZOWxlXpzY2esxceFPSJbqlqRdJyR/u98tcgcoxTVvay7lyCE3+ObnhA",
+	"mPclPnWoOKE4wgHjKHk3P2yYf75euCfIE31AeH0g7j/j9VEhH6xkVnG5daWGygc5hBqmp0/A5l1wnHqJ",
+	"R2AHbvAocAxtsuJTzKyeHkKbQMhPTpDDZEFPEZNzKdBklX17d1j3ugkUfu8CUDiOir9qMe7tfQ7d1U5j",
+	"czkzjWcz0zAyTCBXrJE0Y8NGW72Z1IwzL/VUIRugPXazkzxOIUwe6mJPjs6m5MEC+cm6uB/k3yddQyql",
+	"M+Sim1j2pexvUWDf6NEvs4JrNglpV6Cco2BcC+aQaqdRsLnkw/gBYeJxvEIa5iTNK4QcIpDzbphb/dmn",
+	"r3TBkeRqTA5xuGXr9QFbJVf+NmNSE07RQdteRfEXNiZtuHMg9DtxN3CVLw+x0T6nCcxh7flEYXqDvtvD",
+	"d9zLjlei2xG8WlnHcOlDvbkNzQsYng+zOXA3/Afu00N5i4UsJYq0ZyGNgbO/qVppvI8Koezf9ghTOKSb",
+	"5T28b2hDbGUhPMDQDFnkMGHesAZZVXtilnvPJHVOVDI+4ohjmrhXz7GFe/UEFSGonN9d5c1p6ds9k4l0",
+	"atJvuGiME+m6JmTLcMA2GJ7irQkdtT+aT1H3n5XqTiCtl4bcDgVCMF4SOvbRsH77/VWJYnx2ZSS81N2p",
+	"IF2d0hcdP/xsVPFaS3NwmJFG76vg3SLrD/qj5J1U8ozGi7KLm63dkiOpudpuZrkidJqTnOMvT11iu5MP",
+	"YkH7hs82hHcc8o6L8fp+2Kk1L79naYd87+AOK+vdzeQrFhSuExdSkGTkbM5Vjfuy+P8BAP+Kn7LRGAAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
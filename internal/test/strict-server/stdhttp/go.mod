@@ -25,6 +25,7 @@ require (
 	github.com/google/uuid v1.5.0 // indirect
 	github.com/mailru/easyjson v0.9.0 // indirect
 	github.com/pb33f/libopenapi v0.25.8 // indirect
+	github.com/pb33f/ordered-map/v2 v2.2.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/speakeasy-api/jsonpath v0.6.2 // indirect
 	github.com/speakeasy-api/openapi-overlay v0.10.3 // indirect
@@ -0,0 +1,213 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"sort"
+	"strings"
+)
+
+// APIChange is a change to the exported Go API of the generated code, between the code previously generated and the code generated now, such as from a change to the spec, or to the options which decide how types are named or typed
+type APIChange struct {
+	// Name is the exported identifier which changed, such as `Pet`, or `Pet.Name` for a field or method
+	Name string `json:"name"`
+	// Kind is one of `type`, `field`, `method`, `func`, `const` or `var`
+	Kind string `json:"kind"`
+	// Change is one of `added`, `removed` or `changed`
+	Change string `json:"change"`
+	// Previous is the declaration's type or signature in the code previously generated, unless it was added
+	Previous string `json:"previous,omitempty"`
+	// Current is the declaration's type or signature in the code generated now, unless it was removed
+	Current string `json:"current,omitempty"`
+	// Breaking is whether code using the previous API may no longer compile, such as when a type is removed, a field's type changes, or a method is added to an interface
+	Breaking bool `json:"breaking"`
+}
+
+// apiDecl is an exported declaration of the generated code
+type apiDecl struct {
+	kind string
+	// decl is the declaration's type or signature, which it's compared by
+	decl string
+	// interfaceMethod is whether the declaration is a method of an interface, which its implementations must have
+	interfaceMethod bool
+}
+
+// CompareGoAPI compares the exported Go API of the code previously generated with that of the code generated now, each given as the source of the files generated, and returns the changes between them, sorted by name. Declarations are compared by their types and signatures, rather than by what they do, so a change to a function's body, or to a constant's value, isn't a change to the API
+func CompareGoAPI(previous, current []string) ([]APIChange, error) {
+	previousAPI, err := goAPI(previous)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the previous code: %w", err)
+	}
+	currentAPI, err := goAPI(current)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the current code: %w", err)
+	}
+
+	changes := []APIChange{}
+	for name, was := range previousAPI {
+		now, ok := currentAPI[name]
+		switch {
+		case !ok:
+			changes = append(changes, APIChange{Name: name, Kind: was.kind, Change: "removed", Previous: was.decl, Breaking: true})
+		case was.kind != now.kind || was.decl != now.decl:
+			changes = append(changes, APIChange{Name: name, Kind: now.kind, Change: "changed", Previous: was.decl, Current: now.decl, Breaking: true})
+		}
+	}
+	for name, now := range currentAPI {
+		if _, ok := previousAPI[name]; !ok {
+			changes = append(changes, APIChange{Name: name, Kind: now.kind, Change: "added", Current: now.decl, Breaking: now.interfaceMethod})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+	return changes, nil
+}
+
+// goAPI returns the exported declarations of the files, by name
+func goAPI(files []string) (map[string]apiDecl, error) {
+	api := make(map[string]apiDecl)
+	fset := token.NewFileSet()
+	for _, src := range files {
+		file, err := parser.ParseFile(fset, "", src, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if !decl.Name.IsExported() {
+					continue
+				}
+				if decl.Recv == nil || len(decl.Recv.List) == 0 {
+					api[decl.Name.Name] = apiDecl{kind: "func", decl: signature(decl.Type)}
+					continue
+				}
+				if recv := receiverName(decl.Recv.List[0].Type); ast.IsExported(recv) {
+					api[recv+"."+decl.Name.Name] = apiDecl{kind: "method", decl: signature(decl.Type)}
+				}
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						if spec.Name.IsExported() {
+							addTypeAPI(api, spec)
+						}
+					case *ast.ValueSpec:
+						kind := "var"
+						if decl.Tok == token.CONST {
+							kind = "const"
+						}
+						for _, name := range spec.Names {
+							if name.IsExported() {
+								api[name.Name] = apiDecl{kind: kind, decl: exprString(spec.Type)}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	return api, nil
+}
+
+// addTypeAPI adds a type to the API, along with the exported fields of a struct, or the methods of an interface
+func addTypeAPI(api map[string]apiDecl, spec *ast.TypeSpec) {
+	name := spec.Name.Name
+	switch t := spec.Type.(type) {
+	case *ast.StructType:
+		api[name] = apiDecl{kind: "type", decl: "struct"}
+		for _, field := range t.Fields.List {
+			fieldType := exprString(field.Type)
+			if len(field.Names) == 0 {
+				// An embedded field is named after its type
+				if embedded := receiverName(field.Type); ast.IsExported(embedded) {
+					api[name+"."+embedded] = apiDecl{kind: "field", decl: fieldType}
+				}
+				continue
+			}
+			for _, fieldName := range field.Names {
+				if fieldName.IsExported() {
+					api[name+"."+fieldName.Name] = apiDecl{kind: "field", decl: fieldType}
+				}
+			}
+		}
+	case *ast.InterfaceType:
+		api[name] = apiDecl{kind: "type", decl: "interface"}
+		for _, method := range t.Methods.List {
+			for _, methodName := range method.Names {
+				if funcType, ok := method.Type.(*ast.FuncType); ok && methodName.IsExported() {
+					api[name+"."+methodName.Name] = apiDecl{kind: "method", decl: signature(funcType), interfaceMethod: true}
+				}
+			}
+		}
+	default:
+		decl := exprString(spec.Type)
+		if spec.Assign.IsValid() {
+			decl = "= " + decl
+		}
+		api[name] = apiDecl{kind: "type", decl: decl}
+	}
+}
+
+// receiverName returns the name of the type of a receiver, or of an embedded field, such as `Client` for `*Client`
+func receiverName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.StarExpr:
+		return receiverName(expr.X)
+	case *ast.Ident:
+		return expr.Name
+	case *ast.SelectorExpr:
+		return expr.Sel.Name
+	case *ast.IndexExpr:
+		return receiverName(expr.X)
+	case *ast.IndexListExpr:
+		return receiverName(expr.X)
+	}
+	return ""
+}
+
+// signature returns the signature of a function, with the types of its parameters and results, but not their names, which callers don't depend on
+func signature(funcType *ast.FuncType) string {
+	fieldTypes := func(fields *ast.FieldList) []string {
+		var types []string
+		if fields == nil {
+			return types
+		}
+		for _, field := range fields.List {
+			n := max(len(field.Names), 1)
+			for range n {
+				types = append(types, exprString(field.Type))
+			}
+		}
+		return types
+	}
+
+	sig := "func(" + strings.Join(fieldTypes(funcType.Params), ", ") + ")"
+	results := fieldTypes(funcType.Results)
+	switch len(results) {
+	case 0:
+	case 1:
+		sig += " " + results[0]
+	default:
+		sig += " (" + strings.Join(results, ", ") + ")"
+	}
+	return sig
+}
+
+// exprString returns the source of an expression, such as a type, or an empty string if there isn't one
+func exprString(expr ast.Expr) string {
+	if expr == nil {
+		return ""
+	}
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, token.NewFileSet(), expr); err != nil {
+		return ""
+	}
+	return buf.String()
+}
@@ -0,0 +1,115 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+const apiCompatSpec = `openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        tag:
+          type: string
+    Owner:
+      type: object
+      properties:
+        name:
+          type: string
+`
+
+func TestCompareGoAPI(t *testing.T) {
+	generate := func(spec string, opts Configuration) string {
+		swagger, err := openapi.NewLoader().LoadFromData([]byte(spec))
+		require.NoError(t, err)
+		code, err := Generate(swagger, opts)
+		require.NoError(t, err)
+		return code
+	}
+	opts := Configuration{
+		PackageName: "api",
+		Generate:    GenerateOptions{Models: true, Client: true, ChiServer: true},
+	}
+	previous := generate(apiCompatSpec, opts)
+
+	t.Run("unchanged", func(t *testing.T) {
+		changes, err := CompareGoAPI([]string{previous}, []string{generate(apiCompatSpec, opts)})
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("changes to the spec", func(t *testing.T) {
+		spec := apiCompatSpec
+		// tag becomes required, so is no longer a pointer, Owner is removed, and an operation is added
+		spec = replaceOnce(t, spec, "required: [name]", "required: [name, tag]")
+		spec = replaceOnce(t, spec, "    Owner:\n      type: object\n      properties:\n        name:\n          type: string\n", "")
+		spec = replaceOnce(t, spec, "components:\n", "  /pets/{id}:\n    delete:\n      operationId: deletePet\n      parameters:\n        - name: id\n          in: path\n          required: true\n          schema:\n            type: string\n      responses:\n        '204':\n          description: Deleted\ncomponents:\n")
+
+		changes, err := CompareGoAPI([]string{previous}, []string{generate(spec, opts)})
+		require.NoError(t, err)
+
+		byName := make(map[string]APIChange)
+		for _, change := range changes {
+			byName[change.Name] = change
+		}
+		assert.Equal(t, APIChange{Name: "Pet.Tag", Kind: "field", Change: "changed", Previous: "*string", Current: "string", Breaking: true}, byName["Pet.Tag"])
+		assert.Equal(t, APIChange{Name: "Owner", Kind: "type", Change: "removed", Previous: "struct", Breaking: true}, byName["Owner"])
+		assert.Equal(t, APIChange{Name: "Client.DeletePet", Kind: "method", Change: "added", Current: "func(context.Context, string, ...RequestEditorFn) (*http.Response, error)"}, byName["Client.DeletePet"])
+		assert.True(t, byName["ServerInterface.DeletePet"].Breaking, "adding a method to an interface breaks its implementations")
+		assert.False(t, byName["DeletePetResponse"].Breaking)
+	})
+
+	t.Run("changes to the options", func(t *testing.T) {
+		// the suffix is kept between runs, so is restored for the tests which follow
+		defer func(suffix string) { responseTypeSuffix = suffix }(responseTypeSuffix)
+		withSuffix := opts
+		withSuffix.OutputOptions.ResponseTypeSuffix = "Result"
+
+		changes, err := CompareGoAPI([]string{previous}, []string{generate(apiCompatSpec, withSuffix)})
+		require.NoError(t, err)
+		assert.Contains(t, changes, APIChange{Name: "ListPetsResponse", Kind: "type", Change: "removed", Previous: "struct", Breaking: true})
+		assert.Contains(t, changes, APIChange{Name: "ListPetsResult", Kind: "type", Change: "added", Current: "struct"})
+	})
+
+	t.Run("invalid code", func(t *testing.T) {
+		_, err := CompareGoAPI([]string{"package api\n\nfunc {"}, []string{previous})
+		require.ErrorContains(t, err, "previous code")
+	})
+}
+
+func replaceOnce(t *testing.T, s, old, new string) string {
+	t.Helper()
+	require.Contains(t, s, old)
+	return strings.Replace(s, old, new, 1)
+}
@@ -0,0 +1,63 @@
+package codegen
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+// BasePathStrategy defines how the path of the spec's server URL, such as `/v1` for `https://api.example.com/v1`, is handled when registering a server's routes, as an operation's path is relative to it
+type BasePathStrategy string
+
+const (
+	// BasePathStrategyUnset is the default case, where the `base-path-strategy` option hasn't been set. This behaves as `BasePathStrategyStrip`.
+	BasePathStrategyUnset BasePathStrategy = ""
+	// BasePathStrategyStrip registers each route with the operation's path alone, such as `/pets`, so that the server URL's path is left to the `BaseURL` that the handlers are registered with.
+	BasePathStrategyStrip BasePathStrategy = "strip"
+	// BasePathStrategyKeep registers each route with the server URL's path prepended to the operation's path, such as `/v1/pets`, after any `BaseURL` that the handlers are registered with.
+	BasePathStrategyKeep BasePathStrategy = "keep"
+	// BasePathStrategyOption registers each route with the operation's path alone, as with `BasePathStrategyStrip`, but generates a `BasePath` constant holding the server URL's path, which is the `BaseURL` that the handlers are registered with by default, unless another is provided.
+	BasePathStrategyOption BasePathStrategy = "option"
+)
+
+// specBasePath returns the path of the first of the spec's server URLs, with any variables set to their defaults, and without a trailing slash, or an empty string if the spec has no servers
+func specBasePath(spec *openapi.T) (string, error) {
+	if spec == nil || len(spec.Servers) == 0 || spec.Servers[0] == nil || spec.Servers[0].Server == nil {
+		return "", nil
+	}
+	server := spec.Servers[0]
+
+	serverURL := server.URL
+	for name, variable := range server.Variables {
+		if variable == nil || variable.ServerVariable == nil {
+			continue
+		}
+		serverURL = strings.ReplaceAll(serverURL, "{"+name+"}", variable.Default)
+	}
+
+	parsed, err := url.Parse(serverURL)
+	if err != nil {
+		return "", fmt.Errorf("the server URL %q isn't valid: %w", server.URL, err)
+	}
+
+	basePath := strings.TrimRight(parsed.Path, "/")
+	if basePath != "" && !strings.HasPrefix(basePath, "/") {
+		basePath = "/" + basePath
+	}
+	return basePath, nil
+}
+
+// routeBasePath returns the path which is prepended to each of the routes registered with a server, which is only the server URL's path with `BasePathStrategyKeep`
+func routeBasePath() string {
+	if BasePathStrategy(globalState.options.OutputOptions.BasePathStrategy) != BasePathStrategyKeep {
+		return ""
+	}
+	return globalState.basePath
+}
+
+// registerWithBasePath reports whether the `BasePath` constant is generated, as the default `BaseURL` that a server's handlers are registered with
+func registerWithBasePath() bool {
+	return BasePathStrategy(globalState.options.OutputOptions.BasePathStrategy) == BasePathStrategyOption
+}
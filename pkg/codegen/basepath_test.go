@@ -0,0 +1,133 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+const basePathSpec = `openapi: 3.0.0
+info:
+  title: base path
+  version: 1.0.0
+servers:
+  - url: https://{host}/api/{version}/
+    variables:
+      host:
+        default: api.example.com
+      version:
+        default: v1
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '204':
+          description: OK
+`
+
+func TestSpecBasePath(t *testing.T) {
+	tests := map[string]struct {
+		servers  string
+		expected string
+	}{
+		"no servers": {
+			expected: "",
+		},
+		"absolute URL without a path": {
+			servers:  "servers:\n  - url: https://api.example.com\n",
+			expected: "",
+		},
+		"absolute URL with a path": {
+			servers:  "servers:\n  - url: https://api.example.com/v1/\n",
+			expected: "/v1",
+		},
+		"relative URL": {
+			servers:  "servers:\n  - url: /api\n",
+			expected: "/api",
+		},
+		"only the first server is used": {
+			servers:  "servers:\n  - url: /v2\n  - url: /v1\n",
+			expected: "/v2",
+		},
+		"variables are set to their defaults": {
+			servers:  "servers:\n  - url: https://{host}/{version}\n    variables:\n      host:\n        default: api.example.com\n      version:\n        default: v3\n",
+			expected: "/v3",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			spec := "openapi: 3.0.0\ninfo:\n  title: base path\n  version: 1.0.0\n" + tc.servers + "paths: {}\n"
+			swagger, err := openapi.NewLoader().LoadFromData([]byte(spec))
+			require.NoError(t, err)
+
+			basePath, err := specBasePath(swagger)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, basePath)
+		})
+	}
+}
+
+func TestBasePathStrategy(t *testing.T) {
+	config := func(strategy BasePathStrategy) Configuration {
+		return Configuration{
+			PackageName: "api",
+			Generate: GenerateOptions{
+				Models:     true,
+				ChiServer:  true,
+				EchoServer: true,
+			},
+			OutputOptions: OutputOptions{
+				BasePathStrategy: string(strategy),
+			},
+		}
+	}
+
+	t.Run("by default, routes are registered without the server URL's path", func(t *testing.T) {
+		for _, strategy := range []BasePathStrategy{BasePathStrategyUnset, BasePathStrategyStrip} {
+			code, err := generateFromSpec(t, basePathSpec, config(strategy))
+			require.NoError(t, err)
+			assert.Contains(t, code, `r.Get(options.BaseURL+"/pets", wrapper.ListPets)`)
+			assert.Contains(t, code, `return HandlerWithOptions(si, ChiServerOptions{})`)
+			assert.NotContains(t, code, "BasePath")
+		}
+	})
+
+	t.Run("keep registers routes with the server URL's path", func(t *testing.T) {
+		code, err := generateFromSpec(t, basePathSpec, config(BasePathStrategyKeep))
+		require.NoError(t, err)
+		assert.Contains(t, code, `r.Get(options.BaseURL+"/api/v1/pets", wrapper.ListPets)`)
+		assert.Contains(t, code, `router.GET(baseURL+"/api/v1/pets", wrapper.ListPets)`)
+		assert.NotContains(t, code, "BasePath")
+	})
+
+	t.Run("option registers routes under the server URL's path by default", func(t *testing.T) {
+		code, err := generateFromSpec(t, basePathSpec, config(BasePathStrategyOption))
+		require.NoError(t, err)
+		assert.Contains(t, code, `const BasePath = "/api/v1"`)
+		assert.Equal(t, 1, strings.Count(code, "const BasePath"), "the constant is only generated once for all servers")
+		assert.Contains(t, code, `r.Get(options.BaseURL+"/pets", wrapper.ListPets)`)
+		assert.Contains(t, code, `return HandlerWithOptions(si, ChiServerOptions{BaseURL: BasePath})`)
+		assert.Contains(t, code, "BaseURL:    BasePath,\n\t\tBaseRouter: r,")
+		assert.Contains(t, code, `RegisterHandlersWithBaseURL(router, si, BasePath)`)
+	})
+
+	t.Run("option quotes the server URL's path", func(t *testing.T) {
+		code, err := generateFromSpec(t, strings.Replace(basePathSpec, "/api/{version}/", `/api/%22{version}%5C/`, 1), config(BasePathStrategyOption))
+		require.NoError(t, err)
+		assert.Contains(t, code, `const BasePath = "/api/\"v1\\"`)
+	})
+}
+
+func TestBasePathStrategyValidation(t *testing.T) {
+	opts := OutputOptions{BasePathStrategy: "prefix"}
+	assert.Contains(t, opts.Validate(), "base-path-strategy")
+
+	opts.BasePathStrategy = string(BasePathStrategyOption)
+	assert.Nil(t, opts.Validate())
+}
@@ -0,0 +1,45 @@
+package codegen
+
+import (
+	"fmt"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+// batchBody returns the request body which the client's `<Operation>InBatches` method splits into batches, when the operation sets `x-batch`, which must be a JSON body whose schema is an array
+func batchBody(op *openapi.Operation, bodies []RequestBodyDefinition) (*RequestBodyDefinition, error) {
+	value, ok := op.Extensions[extBatch]
+	if !ok {
+		return nil, nil
+	}
+	batch, err := extParseBatch(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for %q: %w", extBatch, err)
+	}
+	if !batch {
+		return nil, nil
+	}
+
+	for i, body := range bodies {
+		if !body.IsJSON() || !body.IsSupportedByClient() {
+			continue
+		}
+		if !isSliceSchema(body.Schema) {
+			return nil, fmt.Errorf("%q requires the JSON request body to be an array, to split into batches", extBatch)
+		}
+		return &bodies[i], nil
+	}
+	return nil, fmt.Errorf("%q requires a JSON request body, to split into batches", extBatch)
+}
+
+// isSliceSchema returns whether the Go type of a schema is a slice, either inline or by referencing an array schema, which isn't overridden with `x-go-type`
+func isSliceSchema(s Schema) bool {
+	if s.ArrayType != nil {
+		return true
+	}
+	if s.RefType == "" || s.OAPISchema == nil || !s.OAPISchema.TypeIs("array") {
+		return false
+	}
+	_, overridden := s.OAPISchema.Extensions[extPropGoType]
+	return !overridden
+}
@@ -0,0 +1,119 @@
+package codegen
+
+import (
+	"go/importer"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func batchSpec(createPetsBody string) string {
+	return `openapi: 3.0.0
+info:
+  title: Batches
+  version: 1.0.0
+paths:
+  /stores/{store}/pets:
+    post:
+      operationId: createPets
+      x-batch: true
+      parameters:
+        - name: store
+          in: path
+          required: true
+          schema:
+            type: string
+      requestBody:
+        content:
+          ` + createPetsBody + `
+      responses:
+        '204':
+          description: OK
+  /tags:
+    put:
+      operationId: putTags
+      x-batch: true
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Tags'
+      responses:
+        '204':
+          description: OK
+  /pets:
+    post:
+      operationId: createPet
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '204':
+          description: OK
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+    Tags:
+      type: array
+      items:
+        type: string
+`
+}
+
+func TestGenerateBatches(t *testing.T) {
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client: true,
+			Models: true,
+		},
+	}
+
+	code, err := generateFromSpec(t, batchSpec(`application/json: {schema: {type: array, items: {$ref: '#/components/schemas/Pet'}}}`), opts)
+	require.NoError(t, err)
+
+	t.Run("array bodies are sent in batches", func(t *testing.T) {
+		assert.Contains(t, code, "func (c *ClientWithResponses) CreatePetsInBatches(ctx context.Context, store string, items CreatePetsJSONRequestBody, batchSize int, reqEditors ...RequestEditorFn) ([]*CreatePetsResponse, error) {")
+		assert.Contains(t, code, "rsp, err := c.CreatePetsWithResponse(ctx, store, items[start:end], reqEditors...)")
+	})
+
+	t.Run("referenced array bodies are sent in batches", func(t *testing.T) {
+		assert.Contains(t, code, "func (c *ClientWithResponses) PutTagsInBatches(ctx context.Context, items PutTagsJSONRequestBody, batchSize int, reqEditors ...RequestEditorFn) ([]*PutTagsResponse, error) {")
+	})
+
+	t.Run("operations without x-batch aren't", func(t *testing.T) {
+		assert.NotContains(t, code, "CreatePetInBatches")
+	})
+
+	t.Run("the code type checks", func(t *testing.T) {
+		fset := token.NewFileSet()
+		typeCheck(t, fset, importer.ForCompiler(fset, "source", nil), code, `package api
+
+import "context"
+
+func send(client *ClientWithResponses) ([]*PutTagsResponse, error) {
+	return client.PutTagsInBatches(context.Background(), Tags{"a", "b", "c"}, 2)
+}
+`)
+	})
+
+	t.Run("the body must be an array", func(t *testing.T) {
+		_, err := generateFromSpec(t, batchSpec(`application/json: {schema: {$ref: '#/components/schemas/Pet'}}`), opts)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `"x-batch" requires the JSON request body to be an array, to split into batches`)
+	})
+
+	t.Run("the body must be JSON", func(t *testing.T) {
+		_, err := generateFromSpec(t, batchSpec(`text/plain: {schema: {type: string}}`), opts)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `"x-batch" requires a JSON request body, to split into batches`)
+	})
+}
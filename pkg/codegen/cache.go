@@ -0,0 +1,229 @@
+package codegen
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sort"
+	"time"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+	"gopkg.in/yaml.v3"
+)
+
+// maxCachedFiles is how many files of generated code are kept in a cache directory, the least recently used of which are removed when there are more, as a file is kept for every version of the spec which is generated from, and is only reused if the spec is changed back
+var maxCachedFiles = 16
+
+// readBuildInfo reads the build of oapi-codegen which is running, which tests replace, as test binaries aren't built with a version
+var readBuildInfo = debug.ReadBuildInfo
+
+// GenerateCached generates the code for a spec, as Generate does, but reuses the code generated previously for the same spec, configuration, user templates and build of oapi-codegen, which is kept in cacheDir, rather than generating it again, such as when a build system regenerates the code on every build.
+//
+// The code generated for each of the spec's components can depend on the others, such as when the names of their types collide, so the whole of the code is cached, keyed by the whole spec, and any change to it regenerates all of the code. Only the most recently used files of code are kept in cacheDir, so that it doesn't grow as the spec is edited. Code is always generated, without being cached, when oapi-codegen is built from source with uncommitted changes, as its version doesn't identify the code it generates
+func GenerateCached(spec *openapi.T, opts Configuration, cacheDir string) (string, error) {
+	log := opts.Logger
+	if log == nil {
+		log = slog.New(slog.DiscardHandler)
+	}
+
+	key, err := generationCacheKey(spec, opts)
+	if err != nil {
+		return "", fmt.Errorf("error hashing what the code is generated from: %w", err)
+	}
+	if key == "" {
+		log.Debug("not caching the generated code, as the build of oapi-codegen has no version")
+		return Generate(spec, opts)
+	}
+
+	path := filepath.Join(cacheDir, key+".cache")
+	cached, err := os.ReadFile(path)
+	if err == nil {
+		log.Debug("reusing the cached code", "path", path)
+		// The file's modification time records when it was last used, for evicting the least recently used files
+		now := time.Now()
+		if err := os.Chtimes(path, now, now); err != nil {
+			log.Warn("failed to mark the cached code as used", "path", path, "error", err)
+		}
+		return string(cached), nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("error reading the cached code: %w", err)
+	}
+
+	code, err := Generate(spec, opts)
+	if err != nil {
+		return "", err
+	}
+	if err := writeCacheFile(path, code); err != nil {
+		return "", fmt.Errorf("error caching the generated code: %w", err)
+	}
+	log.Debug("cached the generated code", "path", path)
+	// The code has already been generated, so failing to evict the older files doesn't fail generating it
+	if err := evictCacheFiles(cacheDir, maxCachedFiles); err != nil {
+		log.Warn("failed to remove the least recently used cached code", "dir", cacheDir, "error", err)
+	}
+	return code, nil
+}
+
+// evictCacheFiles removes the least recently used files of code from cacheDir, by their modification time, so that at most keep of them are left
+func evictCacheFiles(cacheDir string, keep int) error {
+	paths, err := filepath.Glob(filepath.Join(cacheDir, "*.cache"))
+	if err != nil {
+		return err
+	}
+	if len(paths) <= keep {
+		return nil
+	}
+
+	used := make(map[string]time.Time, len(paths))
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			// It may have been removed by another run of oapi-codegen in the meantime
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return err
+		}
+		used[path] = info.ModTime()
+	}
+	sort.SliceStable(paths, func(i, j int) bool {
+		return used[paths[i]].After(used[paths[j]])
+	})
+
+	var errs []error
+	for _, path := range paths[keep:] {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// generationCacheKey hashes everything the code generated for a spec depends on, or returns an empty key if the build of oapi-codegen can't be identified
+func generationCacheKey(spec *openapi.T, opts Configuration) (string, error) {
+	version, ok := generatorVersion()
+	if !ok {
+		return "", nil
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "generator %s\n", version)
+
+	config, err := yaml.Marshal(opts)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the configuration: %w", err)
+	}
+	writeCacheKeyPart(h, "configuration", config)
+	if opts.NoVCSVersionOverride != nil {
+		writeCacheKeyPart(h, "version override", []byte(*opts.NoVCSVersionOverride))
+	}
+
+	// User templates may be files or URLs, so it's their text which the code depends on
+	names := make([]string, 0, len(opts.OutputOptions.UserTemplates))
+	for name := range opts.OutputOptions.UserTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		txt, err := GetUserTemplateText(opts.OutputOptions.UserTemplates[name])
+		if err != nil {
+			return "", fmt.Errorf("error loading user-provided template %q: %w", name, err)
+		}
+		writeCacheKeyPart(h, "template "+name, []byte(txt))
+	}
+
+	// The positions in the generated code are relative to the working directory
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	writeCacheKeyPart(h, "working directory", []byte(wd))
+
+	// The spec is hashed both as it was loaded, for the positions of its schemas, and as it is now, as it may have been changed since
+	source, err := spec.SourceHash()
+	if err != nil {
+		return "", err
+	}
+	writeCacheKeyPart(h, "source", []byte(source))
+	rendered, err := spec.Render()
+	if err != nil {
+		return "", fmt.Errorf("failed to render spec: %w", err)
+	}
+	writeCacheKeyPart(h, "spec", rendered)
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// writeCacheKeyPart writes a named part of a cache key to w, prefixed with its length, so that parts can't run into each other
+func writeCacheKeyPart(w io.Writer, name string, data []byte) {
+	fmt.Fprintf(w, "%s %d\n", name, len(data))
+	_, _ = w.Write(data)
+}
+
+// generatorVersion identifies the build of oapi-codegen which is running, by the version of each of the modules it's built from, such as the formatter, or returns false if it can't, such as when it's built from source with uncommitted changes, or with a module replaced by a local directory
+func generatorVersion() (string, bool) {
+	bi, ok := readBuildInfo()
+	if !ok {
+		return "", false
+	}
+
+	var revision, modified string
+	for _, setting := range bi.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			revision = setting.Value
+		case "vcs.modified":
+			modified = setting.Value
+		}
+	}
+
+	version := bi.GoVersion + "\n"
+	for i, m := range append([]*debug.Module{&bi.Main}, bi.Deps...) {
+		if m.Replace != nil {
+			m = m.Replace
+		}
+		v := m.Version
+		if v == "" || v == "(devel)" {
+			// Only the main module can be identified without a version, by the commit it's built from
+			if i > 0 || revision == "" || modified != "false" {
+				return "", false
+			}
+			v = revision
+		}
+		version += m.Path + "@" + v + " " + m.Sum + "\n"
+	}
+	return version, true
+}
+
+// writeCacheFile writes code to path, through a temporary file, so that a cached file is never seen partially written
+func writeCacheFile(path, code string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(code); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(f.Name())
+		return err
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		_ = os.Remove(f.Name())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package codegen
+
+import (
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+const cacheSpec = `openapi: 3.0.0
+info:
+  title: Cache
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+`
+
+func TestGenerateCached(t *testing.T) {
+	buildInfo := func(bi *debug.BuildInfo) {
+		t.Helper()
+		previous := readBuildInfo
+		readBuildInfo = func() (*debug.BuildInfo, bool) { return bi, true }
+		t.Cleanup(func() { readBuildInfo = previous })
+	}
+	release := &debug.BuildInfo{
+		GoVersion: "go1.24.0",
+		Main:      debug.Module{Path: "github.com/oapi-codegen/oapi-codegen/v2", Version: "v2.9.0"},
+		Deps:      []*debug.Module{{Path: "golang.org/x/tools", Version: "v0.30.0", Sum: "h1:abc="}},
+	}
+	opts := Configuration{
+		PackageName: "api",
+		Generate:    GenerateOptions{Models: true},
+	}
+	load := func(t *testing.T, spec string) *openapi.T {
+		t.Helper()
+		swagger, err := openapi.NewLoader().LoadFromData([]byte(spec))
+		require.NoError(t, err)
+		return swagger
+	}
+	cached := func(t *testing.T, dir string) []string {
+		t.Helper()
+		files, err := filepath.Glob(filepath.Join(dir, "*.cache"))
+		require.NoError(t, err)
+		return files
+	}
+
+	t.Run("reuses the code for the same spec and configuration", func(t *testing.T) {
+		buildInfo(release)
+		dir := t.TempDir()
+
+		code, err := GenerateCached(load(t, cacheSpec), opts, dir)
+		require.NoError(t, err)
+		expected, err := Generate(load(t, cacheSpec), opts)
+		require.NoError(t, err)
+		assert.Equal(t, expected, code)
+
+		files := cached(t, dir)
+		require.Len(t, files, 1)
+
+		// The cached code is returned, rather than generated again
+		require.NoError(t, os.WriteFile(files[0], []byte("cached"), 0o644))
+		code, err = GenerateCached(load(t, cacheSpec), opts, dir)
+		require.NoError(t, err)
+		assert.Equal(t, "cached", code)
+	})
+
+	t.Run("regenerates when what the code depends on changes", func(t *testing.T) {
+		buildInfo(release)
+		dir := t.TempDir()
+
+		_, err := GenerateCached(load(t, cacheSpec), opts, dir)
+		require.NoError(t, err)
+
+		code, err := GenerateCached(load(t, cacheSpec+"        age:\n          type: integer\n"), opts, dir)
+		require.NoError(t, err)
+		assert.Contains(t, code, "Age  *int")
+		assert.Len(t, cached(t, dir), 2)
+
+		renamed := opts
+		renamed.PackageName = "pets"
+		code, err = GenerateCached(load(t, cacheSpec), renamed, dir)
+		require.NoError(t, err)
+		assert.Contains(t, code, "package pets")
+		assert.Len(t, cached(t, dir), 3)
+
+		// The spec can be changed after it's loaded
+		swagger := load(t, cacheSpec)
+		require.True(t, swagger.RemoveSchema("Pet"))
+		code, err = GenerateCached(swagger, opts, dir)
+		require.NoError(t, err)
+		assert.NotContains(t, code, "type Pet ")
+		assert.Len(t, cached(t, dir), 4)
+
+		upgraded := *release
+		upgraded.Main.Version = "v2.10.0"
+		buildInfo(&upgraded)
+		_, err = GenerateCached(load(t, cacheSpec), opts, dir)
+		require.NoError(t, err)
+		assert.Len(t, cached(t, dir), 5)
+	})
+
+	t.Run("removes the least recently used code", func(t *testing.T) {
+		buildInfo(release)
+		previous := maxCachedFiles
+		maxCachedFiles = 2
+		t.Cleanup(func() { maxCachedFiles = previous })
+		dir := t.TempDir()
+
+		_, err := GenerateCached(load(t, cacheSpec), opts, dir)
+		require.NoError(t, err)
+		files := cached(t, dir)
+		require.Len(t, files, 1)
+		first := files[0]
+
+		_, err = GenerateCached(load(t, cacheSpec+"        age:\n          type: integer\n"), opts, dir)
+		require.NoError(t, err)
+		files = cached(t, dir)
+		require.Len(t, files, 2)
+		second := files[0]
+		if second == first {
+			second = files[1]
+		}
+
+		// The first file was used longer ago, until it's reused
+		now := time.Now()
+		require.NoError(t, os.Chtimes(first, now.Add(-2*time.Hour), now.Add(-2*time.Hour)))
+		require.NoError(t, os.Chtimes(second, now.Add(-time.Hour), now.Add(-time.Hour)))
+		_, err = GenerateCached(load(t, cacheSpec), opts, dir)
+		require.NoError(t, err)
+
+		_, err = GenerateCached(load(t, cacheSpec+"        tag:\n          type: string\n"), opts, dir)
+		require.NoError(t, err)
+		files = cached(t, dir)
+		assert.Len(t, files, 2)
+		assert.Contains(t, files, first)
+		assert.NotContains(t, files, second)
+	})
+
+	t.Run("doesn't cache a build with uncommitted changes", func(t *testing.T) {
+		buildInfo(&debug.BuildInfo{
+			GoVersion: "go1.24.0",
+			Main:      debug.Module{Path: "github.com/oapi-codegen/oapi-codegen/v2", Version: "(devel)"},
+			Settings: []debug.BuildSetting{
+				{Key: "vcs.revision", Value: "0123456789abcdef"},
+				{Key: "vcs.modified", Value: "true"},
+			},
+		})
+		dir := t.TempDir()
+
+		code, err := GenerateCached(load(t, cacheSpec), opts, dir)
+		require.NoError(t, err)
+		assert.Contains(t, code, "type Pet struct")
+		assert.Empty(t, cached(t, dir))
+	})
+}
@@ -0,0 +1,262 @@
+package codegen
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+// clientUsage uses the generated client, so that type checking it alongside the generated code ensures that the concrete clients implement their interfaces, and that the constructor options exist
+const clientUsage = `package api
+
+import (
+	"context"
+	"net/http"
+)
+
+var (
+	_ ClientInterface              = (*Client)(nil)
+	_ ClientWithResponsesInterface = (*ClientWithResponses)(nil)
+)
+
+func newClients() (*Client, *ClientWithResponses, error) {
+	opts := []ClientOption{
+		WithHTTPClient(&http.Client{}),
+		WithHeaders(http.Header{"X-Api-Key": {"secret"}}),
+		WithUserAgent("petstore/1.0"),
+		WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+			return nil
+		}),
+	}
+
+	client, err := NewClient("https://petstore.example.com", opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	clientWithResponses, err := NewClientWithResponses("https://petstore.example.com", append(opts, WithBaseURL("https://petstore.example.com/v2"))...)
+	return client, clientWithResponses, err
+}
+
+func getPetName(ctx context.Context, client ClientWithResponsesInterface) (*string, error) {
+	resp, err := client.GetPetWithResponse(ctx, "1", nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.JSON200 != nil {
+		return resp.JSON200.Name, nil
+	}
+	pet, err := ResponseAs[Pet](resp.Body)
+	return pet.Name, err
+}
+
+func parsePet(rsp *http.Response) (Pet, error) {
+	return ParseAs[Pet](rsp)
+}
+`
+
+const unionResponseSpec = `openapi: 3.1.0
+info:
+  title: union
+  version: 1.0.0
+paths:
+  /animal:
+    get:
+      operationId: getAnimal
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                oneOf:
+                  - $ref: '#/components/schemas/Cat'
+                  - $ref: '#/components/schemas/Dog'
+components:
+  schemas:
+    Cat:
+      type: object
+      properties:
+        meow:
+          type: string
+    Dog:
+      type: object
+      properties:
+        bark:
+          type: string
+`
+
+const unionResponseUsage = `package api
+
+import "context"
+
+func getCat(ctx context.Context, client ClientWithResponsesInterface) (Cat, error) {
+	resp, err := client.GetAnimalWithResponse(ctx)
+	if err != nil {
+		return Cat{}, err
+	}
+	if resp.JSON200 != nil {
+		return resp.JSON200.AsCat()
+	}
+	animal, err := ResponseAs[GetAnimal_JSON200](resp.Body)
+	if err != nil {
+		return Cat{}, err
+	}
+	return animal.AsCat()
+}
+`
+
+// typeCheckClient generates the models and client for spec, and type checks them along with usage. The importer is shared between checks, as importing the packages the client uses from source is slow
+func typeCheckClient(t *testing.T, fset *token.FileSet, imp types.Importer, spec, usage string) {
+	t.Helper()
+
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+			Client: true,
+		},
+	})
+	require.NoError(t, err)
+
+	typeCheck(t, fset, imp, code, usage)
+}
+
+// generateFromSpec loads spec and generates code for it with the configuration
+func generateFromSpec(t *testing.T, spec string, opts Configuration) (string, error) {
+	t.Helper()
+
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err)
+
+	return Generate(swagger, opts)
+}
+
+// typeCheck type checks the generated code alongside the usage of it
+func typeCheck(t *testing.T, fset *token.FileSet, imp types.Importer, code, usage string) {
+	t.Helper()
+
+	var files []*ast.File
+	for name, src := range map[string]string{"api.gen.go": code, "usage.go": usage} {
+		file, err := parser.ParseFile(fset, name, src, 0)
+		require.NoError(t, err)
+		files = append(files, file)
+	}
+
+	conf := types.Config{Importer: imp}
+	_, err := conf.Check("api", fset, files, nil)
+	require.NoError(t, err)
+}
+
+func TestClientCompiles(t *testing.T) {
+	fset := token.NewFileSet()
+	imp := importer.ForCompiler(fset, "source", nil)
+
+	t.Run("client", func(t *testing.T) {
+		typeCheckClient(t, fset, imp, manifestSpec, clientUsage)
+	})
+
+	t.Run("union response", func(t *testing.T) {
+		typeCheckClient(t, fset, imp, unionResponseSpec, unionResponseUsage)
+	})
+}
+
+const rangeResponseSpec = `openapi: 3.0.0
+info:
+  title: ranges
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  type: string
+        '206':
+          description: Partial
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  type: string
+        '2XX':
+          description: Other success
+          content:
+            application/json:
+              schema:
+                type: object
+        '404':
+          description: Not found
+        '4XX':
+          description: Client error
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Error'
+        default:
+          description: Error
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Error'
+components:
+  schemas:
+    Error:
+      type: object
+      properties:
+        message:
+          type: string
+`
+
+func TestParseResponseOrder(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(rangeResponseSpec))
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+			Client: true,
+		},
+	})
+	require.NoError(t, err)
+
+	for _, field := range []string{"JSON200 ", "JSON206 ", "JSON2XX ", "JSON4XX ", "JSONDefault "} {
+		assert.Contains(t, code, field)
+	}
+
+	// Explicit status codes are matched before ranges, and ranges before the default response
+	parse := code[strings.Index(code, "func ParseListPetsResponse"):]
+	order := []string{
+		"rsp.StatusCode == 200",
+		"rsp.StatusCode == 206",
+		"case rsp.StatusCode == 404:",
+		"rsp.StatusCode/100 == 2",
+		"rsp.StatusCode/100 == 4",
+		"&& true",
+	}
+	last := -1
+	for _, clause := range order {
+		i := strings.Index(parse, clause)
+		require.Greater(t, i, last, "%s is out of order", clause)
+		last = i
+	}
+}
@@ -0,0 +1,82 @@
+package codegen
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+// ClientAuthDefinition is a security scheme of type `apiKey`, or of type `http` with the `basic` scheme, which is referenced by the spec's security requirements, for which the client has an option to authenticate its requests
+type ClientAuthDefinition struct {
+	// Name is the name of the security scheme in the spec
+	Name string
+	// OptionName is the name of the client option, such as `WithAPIKey`, or, when the spec has more than one scheme of the same type, such as `WithPetstoreKeyAPIKey`
+	OptionName string
+	// BasicAuth is whether the scheme is HTTP basic authentication, rather than an API key
+	BasicAuth bool
+	// In is where an API key is sent, which is one of `header`, `query` or `cookie`
+	In string
+	// ParamName is the name of the header, query parameter or cookie an API key is sent as
+	ParamName   string
+	Description string
+}
+
+// GenerateClientAuth generates a client option for each API key and HTTP basic security scheme which the spec's security requirements reference
+func GenerateClientAuth(t *template.Template, spec *openapi.T, ops []OperationDefinition) (string, error) {
+	return GenerateTemplates([]string{"client-auth.tmpl"}, t, clientAuthDefinitions(spec, ops))
+}
+
+// clientAuthDefinitions returns the API key and HTTP basic security schemes which the spec's or the operations' security requirements reference, in the order of their names. A requirement which references a scheme the spec doesn't declare in its components has no option
+func clientAuthDefinitions(spec *openapi.T, ops []OperationDefinition) []ClientAuthDefinition {
+	if spec.Components == nil {
+		return nil
+	}
+	referenced := make(map[string]bool)
+	for _, def := range DescribeSecurityDefinition(spec.SecurityRequirements()) {
+		referenced[def.ProviderName] = true
+	}
+	for _, op := range ops {
+		for _, def := range op.SecurityDefinitions {
+			referenced[def.ProviderName] = true
+		}
+	}
+
+	var definitions []ClientAuthDefinition
+	counts := make(map[bool]int)
+	for _, name := range SortedMapKeys(referenced) {
+		ref := spec.Components.SecuritySchemes[name]
+		if ref == nil || ref.Value == nil || ref.Value.SecurityScheme == nil {
+			continue
+		}
+		scheme := ref.Value
+		definition := ClientAuthDefinition{
+			Name:        name,
+			Description: scheme.Description,
+		}
+		switch {
+		case scheme.Type == "apiKey" && (scheme.In == "header" || scheme.In == "query" || scheme.In == "cookie"):
+			definition.In = scheme.In
+			definition.ParamName = scheme.Name
+		case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "basic"):
+			definition.BasicAuth = true
+		default:
+			continue
+		}
+		counts[definition.BasicAuth]++
+		definitions = append(definitions, definition)
+	}
+
+	// The options are only named after their schemes when they'd otherwise have the same name
+	for i, definition := range definitions {
+		option := "APIKey"
+		if definition.BasicAuth {
+			option = "BasicAuth"
+		}
+		if counts[definition.BasicAuth] > 1 {
+			option = SchemaNameToTypeName(definition.Name) + option
+		}
+		definitions[i].OptionName = "With" + option
+	}
+	return definitions
+}
@@ -0,0 +1,166 @@
+package codegen
+
+import (
+	"go/importer"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+const clientAuthSpec = `openapi: 3.0.0
+info:
+  title: Auth
+  version: 1.0.0
+security:
+  - api_key: []
+paths:
+  /pets:
+    get:
+      operationId: findPets
+      security:
+        - query_key: []
+          cookie_key: []
+        - basicAuth: []
+      responses:
+        '200':
+          description: OK
+  /health:
+    get:
+      operationId: health
+      responses:
+        '200':
+          description: OK
+components:
+  securitySchemes:
+    api_key:
+      type: apiKey
+      name: X-API-Key
+      in: header
+    query_key:
+      type: apiKey
+      name: key
+      in: query
+    cookie_key:
+      type: apiKey
+      name: session
+      in: cookie
+    basicAuth:
+      type: http
+      scheme: basic
+    unused_key:
+      type: apiKey
+      name: X-Unused
+      in: header
+    bearerAuth:
+      type: http
+      scheme: bearer
+`
+
+// clientAuthUsage configures the client with each of the options, so that type checking it alongside the generated code ensures the options compile
+const clientAuthUsage = `package api
+
+func useAuth() (*Client, error) {
+	return NewClient("https://example.com",
+		WithApiKeyAPIKey("key"),
+		WithQueryKeyAPIKey("key"),
+		WithCookieKeyAPIKey("key"),
+		WithBasicAuth("user", "pass"),
+	)
+}
+`
+
+func TestGenerateClientAuth(t *testing.T) {
+	t.Run("an option is generated for each referenced scheme", func(t *testing.T) {
+		swagger, err := openapi.NewLoader().LoadFromData([]byte(clientAuthSpec))
+		require.NoError(t, err)
+
+		code, err := Generate(swagger, Configuration{
+			PackageName: "api",
+			Generate: GenerateOptions{
+				Models: true,
+				Client: true,
+			},
+		})
+		require.NoError(t, err)
+
+		assert.Contains(t, code, "\t\t\treq.Header.Set(\"X-API-Key\", key)\n")
+		assert.Contains(t, code, "\t\t\tquery.Set(\"key\", key)\n")
+		assert.Contains(t, code, "\t\t\treq.AddCookie(&http.Cookie{Name: \"session\", Value: key})\n")
+		assert.Contains(t, code, "\t\t\treq.SetBasicAuth(username, password)\n")
+		// Schemes which aren't referenced, or which aren't API keys or basic authentication, don't get an option
+		assert.NotContains(t, code, "X-Unused")
+		assert.NotContains(t, code, "Bearer")
+
+		fset := token.NewFileSet()
+		typeCheck(t, fset, importer.ForCompiler(fset, "source", nil), code, clientAuthUsage)
+	})
+
+	t.Run("a single scheme of a type has a short option", func(t *testing.T) {
+		swagger, err := openapi.NewLoader().LoadFromData([]byte(`openapi: 3.0.0
+info:
+  title: Auth
+  version: 1.0.0
+security:
+  - api_key: []
+paths: {}
+components:
+  securitySchemes:
+    api_key:
+      type: apiKey
+      name: X-API-Key
+      in: header
+      description: The key from your account settings
+`))
+		require.NoError(t, err)
+
+		code, err := Generate(swagger, Configuration{
+			PackageName: "api",
+			Generate: GenerateOptions{
+				Client: true,
+			},
+		})
+		require.NoError(t, err)
+
+		assert.Contains(t, code, "// WithAPIKey authenticates each of the client's requests with the API\n// key, which is sent as the `X-API-Key` header, for the `api_key` security scheme.\n//\n// The key from your account settings\nfunc WithAPIKey(key string) ClientOption {")
+		assert.NotContains(t, code, "WithBasicAuth")
+	})
+	t.Run("nothing is generated for schemes which the spec doesn't declare", func(t *testing.T) {
+		swagger, err := openapi.NewLoader().LoadFromData([]byte(`openapi: 3.0.0
+info:
+  title: Auth
+  version: 1.0.0
+security:
+  - api_key: []
+paths:
+  /pets:
+    get:
+      operationId: findPets
+      security:
+        - basicAuth: []
+      responses:
+        '200':
+          description: OK
+components:
+  securitySchemes:
+    bearerAuth:
+      type: http
+      scheme: bearer
+`))
+		require.NoError(t, err)
+
+		code, err := Generate(swagger, Configuration{
+			PackageName: "api",
+			Generate: GenerateOptions{
+				Client: true,
+			},
+		})
+		require.NoError(t, err)
+
+		assert.NotContains(t, code, "WithAPIKey")
+		assert.NotContains(t, code, "WithBasicAuth")
+	})
+}
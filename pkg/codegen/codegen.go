@@ -15,13 +15,13 @@
 package codegen
 
 import (
-	"bufio"
 	"bytes"
 	"context"
 	"embed"
 	"fmt"
 	"io"
 	"io/fs"
+	"log/slog"
 	"net/http"
 	"os"
 	"runtime/debug"
@@ -30,11 +30,10 @@ import (
 	"text/template"
 	"time"
 
-	"golang.org/x/tools/imports"
-
 	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
 	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
 )
 
 // Embed the templates directory
@@ -51,6 +50,45 @@ var globalState struct {
 	// initialismsMap stores initialisms as "lower(initialism) -> initialism" map.
 	// List of initialisms was taken from https://staticcheck.io/docs/configuration/options/#initialisms.
 	initialismsMap map[string]string
+	// typeNames keeps track of the type names given out, when a `TypeNameCollisionStrategy` is configured
+	typeNames *typeNameRegistry
+	// titleTypeNames maps the type names given to inline schemas from their titles, with `use-schema-titles`, and to component schemas, to the part of the spec they're given to
+	titleTypeNames map[string]string
+	// report collects what's generated, when generating a Report rather than code
+	report *Report
+	// typeManifest collects the generated types, when generating a TypeManifest rather than code
+	typeManifest *TypeManifest
+	// debugModel collects the model the code is generated from, when generating a DebugModel rather than code
+	debugModel *DebugModel
+	// docs is set to the Markdown reference, when generating docs rather than code
+	docs *string
+	// serverScaffold is set when generating the scaffold of a server implementation rather than code
+	serverScaffold *serverScaffold
+	// sources maps the generated types and operations to a JSON Pointer to the part of the spec they were generated from, for the Manifest
+	sources map[string]specSource
+	// modelTypes are the names of the types generated for the models, for `one-file-per-type`
+	modelTypes map[string]bool
+	// basePath is the path of the spec's server URL, when a `BasePathStrategy` which uses it is configured
+	basePath string
+	// provenance identifies the spec, for the header of the generated code, with `spec-provenance`
+	provenance *SpecProvenance
+	// embeddedOperationIds are the operationIds the operations are generated with, which they're given in the embedded spec, without changing the spec itself
+	embeddedOperationIds map[*v3.Operation]string
+}
+
+// timePhase passes how long a phase of generating code has taken, since start, to the configured Timings, if there are any
+func timePhase(phase string, start time.Time) {
+	if globalState.options.Timings != nil {
+		globalState.options.Timings(phase, time.Since(start))
+	}
+}
+
+// logger returns the configured Logger, or a logger which discards everything if none was provided
+func logger() *slog.Logger {
+	if globalState.options.Logger == nil {
+		return slog.New(slog.DiscardHandler)
+	}
+	return globalState.options.Logger
 }
 
 // goImport represents a go package to be imported in the generated code
@@ -119,14 +157,78 @@ func constructImportMapping(importMapping map[string]string) importMap {
 // Generate uses the Go templating engine to generate all of our server wrappers from
 // the descriptions we've built up above from the schema objects.
 // opts defines
+//
+// Other than removing the operations and components which opts filters out
+// or prunes, along with the paths left without operations, Generate doesn't
+// change the spec, so that the same spec can be generated from more than
+// once, such as for each of several targets.
 func Generate(spec *openapi.T, opts Configuration) (string, error) {
+	code, err := generate(spec, opts)
+	if err != nil {
+		return "", err
+	}
+	return string(code), nil
+}
+
+// GenerateTo generates the code for a spec, as Generate does, but writes it to w, rather than returning it as a string.
+// The code is still buffered in full before it's written, as it's formatted, and its imports fixed up, as a whole file, so GenerateTo only saves the copy of the code into a string, not the memory for the code itself. Nothing is written to w if generating the code fails
+func GenerateTo(w io.Writer, spec *openapi.T, opts Configuration) error {
+	code, err := generate(spec, opts)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(code)
+	return err
+}
+
+// generate generates the code for a spec, for Generate and GenerateTo
+func generate(spec *openapi.T, opts Configuration) ([]byte, error) {
 	// This is global state
 	globalState.options = opts
 	globalState.spec = spec
 	globalState.importMapping = constructImportMapping(opts.ImportMapping)
+	globalState.sources = make(map[string]specSource)
+	globalState.modelTypes = make(map[string]bool)
+	globalState.basePath = ""
+	globalState.provenance = nil
+	globalState.embeddedOperationIds = nil
+
+	// The spec is hashed before it's filtered, so that the hash is the same for each of the files generated from it
+	if opts.Generate.SpecProvenance {
+		provenance, err := newSpecProvenance(spec)
+		if err != nil {
+			return nil, fmt.Errorf("error hashing the spec: %w", err)
+		}
+		globalState.provenance = provenance
+	}
+
+	switch BasePathStrategy(opts.OutputOptions.BasePathStrategy) {
+	case BasePathStrategyKeep, BasePathStrategyOption:
+		basePath, err := specBasePath(spec)
+		if err != nil {
+			return nil, fmt.Errorf("error finding the base path of the server URL: %w", err)
+		}
+		globalState.basePath = basePath
+	}
 
+	// The embedded spec is exactly what was generated, unless the spec is to be embedded as it was before it was filtered and pruned
+	embeddedSpec := spec
+	if opts.Generate.EmbeddedSpec && opts.OutputOptions.EmbedUnfilteredSpec {
+		snapshot, err := spec.Snapshot()
+		if err != nil {
+			return nil, fmt.Errorf("error copying the unfiltered spec: %w", err)
+		}
+		embeddedSpec = snapshot
+	}
+
+	start := time.Now()
+	hadOperations := pathsWithOperations(spec)
 	filterOperationsByTag(spec, opts)
 	filterOperationsByOperationID(spec, opts)
+	if err := filterSkippedOperations(spec); err != nil {
+		return nil, fmt.Errorf("error filtering skipped operations: %w", err)
+	}
+	removeFilteredPaths(spec, hadOperations)
 	// Note: Pruning logic has been simplified to work with libopenapi's reference resolution
 	// The original logic relied on finding $ref strings, but libopenapi auto-resolves them
 	// For now we use a more conservative approach
@@ -135,6 +237,7 @@ func Generate(spec *openapi.T, opts Configuration) (string, error) {
 		// Most tests and examples should work without pruning
 		pruneUnusedComponents(spec)
 	}
+	timePhase("filter", start)
 
 	// if we are provided an override for the response type suffix update it
 	if opts.OutputOptions.ResponseTypeSuffix != "" {
@@ -148,15 +251,17 @@ func Generate(spec *openapi.T, opts Configuration) (string, error) {
 	nameNormalizerFunction := NameNormalizerFunction(opts.OutputOptions.NameNormalizer)
 	nameNormalizer = NameNormalizers[nameNormalizerFunction]
 	if nameNormalizer == nil {
-		return "", fmt.Errorf(`the name-normalizer option %v could not be found among options %q`,
+		return nil, fmt.Errorf(`the name-normalizer option %v could not be found among options %q`,
 			opts.OutputOptions.NameNormalizer, NameNormalizers.Options())
 	}
 
 	if nameNormalizerFunction != NameNormalizerFunctionToCamelCaseWithInitialisms && len(opts.OutputOptions.AdditionalInitialisms) > 0 {
-		return "", fmt.Errorf("you have specified `additional-initialisms`, but the `name-normalizer` is not set to `ToCamelCaseWithInitialisms`. Please specify `name-normalizer: ToCamelCaseWithInitialisms` or remove the `additional-initialisms` configuration")
+		return nil, fmt.Errorf("you have specified `additional-initialisms`, but the `name-normalizer` is not set to `ToCamelCaseWithInitialisms`. Please specify `name-normalizer: ToCamelCaseWithInitialisms` or remove the `additional-initialisms` configuration")
 	}
 
 	globalState.initialismsMap = makeInitialismsMap(opts.OutputOptions.AdditionalInitialisms)
+	globalState.typeNames = newTypeNameRegistry(TypeNameCollisionStrategy(opts.OutputOptions.TypeNameCollisions))
+	globalState.titleTypeNames = nil
 
 	// This creates the golang templates text package
 	TemplateFunctions["opts"] = func() Configuration { return globalState.options }
@@ -165,7 +270,7 @@ func Generate(spec *openapi.T, opts Configuration) (string, error) {
 	// above
 	err := LoadTemplates(templates, t)
 	if err != nil {
-		return "", fmt.Errorf("error parsing oapi-codegen templates: %w", err)
+		return nil, fmt.Errorf("error parsing oapi-codegen templates: %w", err)
 	}
 
 	// load user-provided templates. Will Override built-in versions.
@@ -174,49 +279,124 @@ func Generate(spec *openapi.T, opts Configuration) (string, error) {
 
 		txt, err := GetUserTemplateText(template)
 		if err != nil {
-			return "", fmt.Errorf("error loading user-provided template %q: %w", name, err)
+			return nil, fmt.Errorf("error loading user-provided template %q: %w", name, err)
 		}
 
 		_, err = utpl.Parse(txt)
 		if err != nil {
-			return "", fmt.Errorf("error parsing user-provided template %q: %w", name, err)
+			return nil, fmt.Errorf("error parsing user-provided template %q: %w", name, err)
+		}
+	}
+
+	// Failures to generate code for individual schemas and operations are collected, rather than
+	// stopping at the first one, so that they can all be reported together
+	var genErr Error
+
+	if opts.OutputOptions.StrictKeywords {
+		if err := checkStrictKeywords(spec); err != nil && !genErr.merge(err) {
+			return nil, fmt.Errorf("error checking for unsupported keywords: %w", err)
 		}
 	}
 
+	if err := registerComponentTypeNames(spec); err != nil && !genErr.merge(err) {
+		return nil, fmt.Errorf("error naming component types: %w", err)
+	}
+
+	start = time.Now()
 	ops, err := OperationDefinitions(spec, opts.OutputOptions.InitialismOverrides)
-	if err != nil {
-		return "", fmt.Errorf("error creating operation definitions: %w", err)
+	if err != nil && !genErr.merge(err) {
+		return nil, fmt.Errorf("error creating operation definitions: %w", err)
+	}
+	if err := checkRouteConflicts(ops, opts.Generate); err != nil && !genErr.merge(err) {
+		return nil, fmt.Errorf("error checking for conflicting routes: %w", err)
+	}
+	if opts.Generate.Client {
+		if err := resolveLongRunningOperations(ops); err != nil && !genErr.merge(err) {
+			return nil, fmt.Errorf("error resolving long-running operations: %w", err)
+		}
+		if err := resolveURLLengthGuards(ops); err != nil && !genErr.merge(err) {
+			return nil, fmt.Errorf("error resolving URL length limits: %w", err)
+		}
+	}
+	reportOperations(ops)
+	recordOperationSources(ops)
+	recordDebugModelOperations(ops)
+	timePhase("operations", start)
+
+	if globalState.docs != nil {
+		*globalState.docs, err = generateDocs(t, spec, ops)
+		if err != nil {
+			return nil, fmt.Errorf("error generating docs: %w", err)
+		}
 	}
 
 	xGoTypeImports, err := OperationImports(ops)
 	if err != nil {
-		return "", fmt.Errorf("error getting operation imports: %w", err)
+		return nil, fmt.Errorf("error getting operation imports: %w", err)
+	}
+
+	if globalState.serverScaffold != nil {
+		externalImports := append(globalState.importMapping.GoImports(), importMap(xGoTypeImports).GoImports()...)
+		globalState.serverScaffold.code, err = generateServerScaffold(t, ops, externalImports, opts, globalState.serverScaffold.generate)
+		if err != nil {
+			return nil, fmt.Errorf("error generating server scaffold: %w", err)
+		}
+	}
+
+	excludeSchemas, err := skippedSchemas(spec)
+	if err != nil {
+		return nil, fmt.Errorf("error finding skipped schemas: %w", err)
 	}
+	excludeSchemas = append(excludeSchemas, opts.OutputOptions.ExcludeSchemas...)
 
 	var typeDefinitions, constantDefinitions string
 	if opts.Generate.Models {
-		typeDefinitions, err = GenerateTypeDefinitions(t, spec, ops, opts.OutputOptions.ExcludeSchemas)
-		if err != nil {
-			return "", fmt.Errorf("error generating type definitions: %w", err)
+		start = time.Now()
+		typeDefinitions, err = GenerateTypeDefinitions(t, spec, ops, excludeSchemas)
+		if err != nil && !genErr.merge(err) {
+			return nil, fmt.Errorf("error generating type definitions: %w", err)
 		}
 
 		constantDefinitions, err = GenerateConstants(t, ops)
 		if err != nil {
-			return "", fmt.Errorf("error generating constants: %w", err)
+			return nil, fmt.Errorf("error generating constants: %w", err)
 		}
 
-		imprts, err := GetTypeDefinitionsImports(spec, opts.OutputOptions.ExcludeSchemas)
+		imprts, err := GetTypeDefinitionsImports(spec, excludeSchemas)
 		if err != nil {
-			return "", fmt.Errorf("error getting type definition imports: %w", err)
+			return nil, fmt.Errorf("error getting type definition imports: %w", err)
 		}
 		MergeImports(xGoTypeImports, imprts)
+		timePhase("models", start)
+	}
+
+	if err := genErr.errOrNil(); err != nil {
+		return nil, err
 	}
 
 	var serverURLsDefinitions string
 	if opts.Generate.ServerURLs {
 		serverURLsDefinitions, err = GenerateServerURLs(t, spec)
 		if err != nil {
-			return "", fmt.Errorf("error generating Server URLs: %w", err)
+			return nil, fmt.Errorf("error generating Server URLs: %w", err)
+		}
+	}
+
+	start = time.Now()
+	var basePathDefinition string
+	if registerWithBasePath() && opts.Generate.generatesServer() {
+		basePathDefinition, err = GenerateTemplates([]string{"base-path.tmpl"}, t, globalState.basePath)
+		if err != nil {
+			return nil, fmt.Errorf("error generating base path: %w", err)
+		}
+	}
+
+	// The servers share the helper which binds the query parameters which allow an empty value
+	var emptyQueryParamsDefinition string
+	if opts.Generate.generatesServer() && hasEmptyQueryParams(ops) {
+		emptyQueryParamsDefinition, err = GenerateTemplates([]string{"server-empty-query-params.tmpl"}, t, ops)
+		if err != nil {
+			return nil, fmt.Errorf("error generating the binding of query parameters: %w", err)
 		}
 	}
 
@@ -224,7 +404,7 @@ func Generate(spec *openapi.T, opts Configuration) (string, error) {
 	if opts.Generate.IrisServer {
 		irisServerOut, err = GenerateIrisServer(t, ops)
 		if err != nil {
-			return "", fmt.Errorf("error generating Go handlers for Paths: %w", err)
+			return nil, fmt.Errorf("error generating Go handlers for Paths: %w", err)
 		}
 	}
 
@@ -232,7 +412,7 @@ func Generate(spec *openapi.T, opts Configuration) (string, error) {
 	if opts.Generate.EchoServer {
 		echoServerOut, err = GenerateEchoServer(t, ops)
 		if err != nil {
-			return "", fmt.Errorf("error generating Go handlers for Paths: %w", err)
+			return nil, fmt.Errorf("error generating Go handlers for Paths: %w", err)
 		}
 	}
 
@@ -240,7 +420,7 @@ func Generate(spec *openapi.T, opts Configuration) (string, error) {
 	if opts.Generate.ChiServer {
 		chiServerOut, err = GenerateChiServer(t, ops)
 		if err != nil {
-			return "", fmt.Errorf("error generating Go handlers for Paths: %w", err)
+			return nil, fmt.Errorf("error generating Go handlers for Paths: %w", err)
 		}
 	}
 
@@ -248,7 +428,7 @@ func Generate(spec *openapi.T, opts Configuration) (string, error) {
 	if opts.Generate.FiberServer {
 		fiberServerOut, err = GenerateFiberServer(t, ops)
 		if err != nil {
-			return "", fmt.Errorf("error generating Go handlers for Paths: %w", err)
+			return nil, fmt.Errorf("error generating Go handlers for Paths: %w", err)
 		}
 	}
 
@@ -256,7 +436,7 @@ func Generate(spec *openapi.T, opts Configuration) (string, error) {
 	if opts.Generate.GinServer {
 		ginServerOut, err = GenerateGinServer(t, ops)
 		if err != nil {
-			return "", fmt.Errorf("error generating Go handlers for Paths: %w", err)
+			return nil, fmt.Errorf("error generating Go handlers for Paths: %w", err)
 		}
 	}
 
@@ -264,7 +444,7 @@ func Generate(spec *openapi.T, opts Configuration) (string, error) {
 	if opts.Generate.GorillaServer {
 		gorillaServerOut, err = GenerateGorillaServer(t, ops)
 		if err != nil {
-			return "", fmt.Errorf("error generating Go handlers for Paths: %w", err)
+			return nil, fmt.Errorf("error generating Go handlers for Paths: %w", err)
 		}
 	}
 
@@ -272,7 +452,7 @@ func Generate(spec *openapi.T, opts Configuration) (string, error) {
 	if opts.Generate.StdHTTPServer {
 		stdHTTPServerOut, err = GenerateStdHTTPServer(t, ops)
 		if err != nil {
-			return "", fmt.Errorf("error generating Go handlers for Paths: %w", err)
+			return nil, fmt.Errorf("error generating Go handlers for Paths: %w", err)
 		}
 	}
 
@@ -282,25 +462,29 @@ func Generate(spec *openapi.T, opts Configuration) (string, error) {
 		if spec.Components != nil {
 			responses, err = GenerateResponseDefinitions("", spec.Components.Responses)
 			if err != nil {
-				return "", fmt.Errorf("error generation response definitions for schema: %w", err)
+				return nil, fmt.Errorf("error generation response definitions for schema: %w", err)
 			}
 		}
 		strictServerResponses, err := GenerateStrictResponses(t, responses)
 		if err != nil {
-			return "", fmt.Errorf("error generation response definitions for schema: %w", err)
+			return nil, fmt.Errorf("error generation response definitions for schema: %w", err)
 		}
 		strictServerOut, err = GenerateStrictServer(t, ops, opts)
 		if err != nil {
-			return "", fmt.Errorf("error generating Go handlers for Paths: %w", err)
+			return nil, fmt.Errorf("error generating Go handlers for Paths: %w", err)
 		}
 		strictServerOut = strictServerResponses + strictServerOut
 	}
+	if opts.Generate.generatesServer() || opts.Generate.Strict {
+		timePhase("server", start)
+	}
 
+	start = time.Now()
 	var clientOut string
 	if opts.Generate.Client {
 		clientOut, err = GenerateClient(t, ops)
 		if err != nil {
-			return "", fmt.Errorf("error generating client: %w", err)
+			return nil, fmt.Errorf("error generating client: %w", err)
 		}
 	}
 
@@ -308,183 +492,198 @@ func Generate(spec *openapi.T, opts Configuration) (string, error) {
 	if opts.Generate.Client {
 		clientWithResponsesOut, err = GenerateClientWithResponses(t, ops)
 		if err != nil {
-			return "", fmt.Errorf("error generating client with responses: %w", err)
+			return nil, fmt.Errorf("error generating client with responses: %w", err)
 		}
 	}
 
-	var inlinedSpec string
-	if opts.Generate.EmbeddedSpec {
-		inlinedSpec, err = GenerateInlinedSpec(t, globalState.importMapping, spec)
-		if err != nil {
-			return "", fmt.Errorf("error generating Go handlers for Paths: %w", err)
-		}
-	}
-
-	var buf bytes.Buffer
-	w := bufio.NewWriter(&buf)
-
-	externalImports := append(globalState.importMapping.GoImports(), importMap(xGoTypeImports).GoImports()...)
-	importsOut, err := GenerateImports(
-		t,
-		externalImports,
-		opts.PackageName,
-		opts.NoVCSVersionOverride,
-	)
-	if err != nil {
-		return "", fmt.Errorf("error generating imports: %w", err)
-	}
-
-	_, err = w.WriteString(importsOut)
-	if err != nil {
-		return "", fmt.Errorf("error writing imports: %w", err)
-	}
-
-	_, err = w.WriteString(constantDefinitions)
-	if err != nil {
-		return "", fmt.Errorf("error writing constants: %w", err)
-	}
-
-	_, err = w.WriteString(serverURLsDefinitions)
-	if err != nil {
-		return "", fmt.Errorf("error writing Server URLs: %w", err)
-	}
-
-	_, err = w.WriteString(typeDefinitions)
-	if err != nil {
-		return "", fmt.Errorf("error writing type definitions: %w", err)
-	}
-
+	var clientAuthOut string
 	if opts.Generate.Client {
-		_, err = w.WriteString(clientOut)
-		if err != nil {
-			return "", fmt.Errorf("error writing client: %w", err)
-		}
-		_, err = w.WriteString(clientWithResponsesOut)
-		if err != nil {
-			return "", fmt.Errorf("error writing client: %w", err)
-		}
-	}
-
-	if opts.Generate.IrisServer {
-		_, err = w.WriteString(irisServerOut)
-		if err != nil {
-			return "", fmt.Errorf("error writing server path handlers: %w", err)
-		}
-
-	}
-
-	if opts.Generate.EchoServer {
-		_, err = w.WriteString(echoServerOut)
+		clientAuthOut, err = GenerateClientAuth(t, spec, ops)
 		if err != nil {
-			return "", fmt.Errorf("error writing server path handlers: %w", err)
+			return nil, fmt.Errorf("error generating client authentication options: %w", err)
 		}
 	}
 
-	if opts.Generate.ChiServer {
-		_, err = w.WriteString(chiServerOut)
+	var oauth2Out string
+	if opts.Generate.Client && opts.OutputOptions.ClientOAuth2 {
+		oauth2Out, err = GenerateOAuth2(t, spec)
 		if err != nil {
-			return "", fmt.Errorf("error writing server path handlers: %w", err)
+			return nil, fmt.Errorf("error generating OAuth2 client options: %w", err)
 		}
 	}
-
-	if opts.Generate.FiberServer {
-		_, err = w.WriteString(fiberServerOut)
-		if err != nil {
-			return "", fmt.Errorf("error writing server path handlers: %w", err)
-		}
+	if opts.Generate.Client {
+		timePhase("client", start)
 	}
 
-	if opts.Generate.GinServer {
-		_, err = w.WriteString(ginServerOut)
+	var conformanceTestsOut string
+	if opts.Generate.ConformanceTests {
+		conformanceTestsOut, err = GenerateConformanceTests(t, ops)
 		if err != nil {
-			return "", fmt.Errorf("error writing server path handlers: %w", err)
+			return nil, fmt.Errorf("error generating conformance tests: %w", err)
 		}
 	}
 
-	if opts.Generate.GorillaServer {
-		_, err = w.WriteString(gorillaServerOut)
+	var urlHelpersOut string
+	if opts.Generate.URLHelpers {
+		urlHelpersOut, err = GenerateURLHelpers(t, ops)
 		if err != nil {
-			return "", fmt.Errorf("error writing server path handlers: %w", err)
+			return nil, fmt.Errorf("error generating URL helpers: %w", err)
 		}
 	}
 
-	if opts.Generate.StdHTTPServer {
-		_, err = w.WriteString(stdHTTPServerOut)
+	var jsonSchemasOut string
+	if opts.Generate.JSONSchemas {
+		jsonSchemasOut, err = GenerateJSONSchemas(t, spec)
 		if err != nil {
-			return "", fmt.Errorf("error writing server path handlers: %w", err)
+			return nil, fmt.Errorf("error generating JSON Schemas: %w", err)
 		}
 	}
 
-	if opts.Generate.Strict {
-		_, err = w.WriteString(strictServerOut)
+	var provenanceOut string
+	if opts.Generate.SpecProvenance {
+		provenanceOut, err = GenerateSpecProvenance(t, globalState.provenance)
 		if err != nil {
-			return "", fmt.Errorf("error writing server path handlers: %w", err)
+			return nil, fmt.Errorf("error generating spec provenance: %w", err)
 		}
 	}
 
+	var inlinedSpec string
 	if opts.Generate.EmbeddedSpec {
-		_, err = w.WriteString(inlinedSpec)
+		if embeddedSpec != spec {
+			globalState.embeddedOperationIds = unfilteredOperationIds(spec, embeddedSpec, globalState.embeddedOperationIds)
+		}
+		inlinedSpec, err = GenerateInlinedSpec(t, globalState.importMapping, embeddedSpec)
 		if err != nil {
-			return "", fmt.Errorf("error writing inlined spec: %w", err)
+			return nil, fmt.Errorf("error generating Go handlers for Paths: %w", err)
 		}
 	}
 
-	err = w.Flush()
+	externalImports := append(globalState.importMapping.GoImports(), importMap(xGoTypeImports).GoImports()...)
+	importsOut, err := GenerateImports(
+		t,
+		externalImports,
+		opts.PackageName,
+		opts.NoVCSVersionOverride,
+	)
 	if err != nil {
-		return "", fmt.Errorf("error flushing output buffer: %w", err)
+		return nil, fmt.Errorf("error generating imports: %w", err)
+	}
+
+	// The sections are copied into the code once, in the order they're output, into a buffer of the size of the code, rather than one which grows as they're written, as the code of a large spec is sizeable. The sections of what isn't generated are empty
+	sections := []string{
+		importsOut,
+		constantDefinitions,
+		serverURLsDefinitions,
+		typeDefinitions,
+		clientOut,
+		clientWithResponsesOut,
+		clientAuthOut,
+		oauth2Out,
+		basePathDefinition,
+		emptyQueryParamsDefinition,
+		irisServerOut,
+		echoServerOut,
+		chiServerOut,
+		fiberServerOut,
+		ginServerOut,
+		gorillaServerOut,
+		stdHTTPServerOut,
+		strictServerOut,
+		conformanceTestsOut,
+		urlHelpersOut,
+		jsonSchemasOut,
+		provenanceOut,
+		inlinedSpec,
+	}
+	size := 0
+	for _, section := range sections {
+		size += len(section)
+	}
+	code := make([]byte, 0, size)
+	for _, section := range sections {
+		code = append(code, section...)
 	}
 
 	// remove any byte-order-marks which break Go-Code
-	goCode := SanitizeCode(buf.String())
+	code = sanitizeCode(code)
+
+	if opts.OutputOptions.SourceComments {
+		commented, err := addSourceComments(string(code), globalState.sources)
+		if err != nil {
+			return nil, fmt.Errorf("error adding source comments: %w", err)
+		}
+		code = []byte(commented)
+	}
 
 	// The generation code produces unindented horrors. Use the Go Imports
 	// to make it all pretty.
 	if opts.OutputOptions.SkipFmt {
-		return goCode, nil
+		return code, nil
 	}
 
-	outBytes, err := imports.Process(opts.PackageName+".go", []byte(goCode), nil)
+	start = time.Now()
+	formatted, err := formatSource(code, opts.PackageName, Formatter(opts.OutputOptions.Formatter))
 	if err != nil {
-		return "", fmt.Errorf("error formatting Go code %s: %w", goCode, err)
+		return nil, fmt.Errorf("error formatting Go code %s: %w", code, err)
 	}
-	return string(outBytes), nil
+	timePhase("format", start)
+	return formatted, nil
 }
 
 func GenerateTypeDefinitions(t *template.Template, swagger *openapi.T, ops []OperationDefinition, excludeSchemas []string) (string, error) {
 	var allTypes []TypeDefinition
 	if swagger.Components != nil {
+		// Failures for each kind of component are collected, so that they can all be reported at once
+		var genErr Error
+
 		schemaTypes, err := GenerateTypesForSchemas(t, swagger.Components.Schemas, excludeSchemas)
-		if err != nil {
+		if err != nil && !genErr.merge(err) {
 			return "", fmt.Errorf("error generating Go types for component schemas: %w", err)
 		}
 
 		paramTypes, err := GenerateTypesForParameters(t, swagger.Components.Parameters)
-		if err != nil {
+		if err != nil && !genErr.merge(err) {
 			return "", fmt.Errorf("error generating Go types for component parameters: %w", err)
 		}
 		allTypes = append(schemaTypes, paramTypes...)
 
 		responseTypes, err := GenerateTypesForResponses(t, swagger.Components.Responses)
-		if err != nil {
+		if err != nil && !genErr.merge(err) {
 			return "", fmt.Errorf("error generating Go types for component responses: %w", err)
 		}
 		allTypes = append(allTypes, responseTypes...)
 
 		bodyTypes, err := GenerateTypesForRequestBodies(t, swagger.Components.RequestBodies)
-		if err != nil {
+		if err != nil && !genErr.merge(err) {
 			return "", fmt.Errorf("error generating Go types for component request bodies: %w", err)
 		}
 		allTypes = append(allTypes, bodyTypes...)
+
+		if err := genErr.errOrNil(); err != nil {
+			return "", err
+		}
 	}
 
 	// Go through all operations, and add their types to allTypes, so that we can
 	// scan all of them for enums. Operation definitions are handled differently
 	// from the rest, so let's keep track of enumTypes separately, which will contain
 	// all types needed to be scanned for enums, which includes those within operations.
+	typeDefLists := [][]TypeDefinition{allTypes}
+	for _, op := range ops {
+		typeDefLists = append(typeDefLists, op.TypeDefinitions)
+	}
+	if err := BreakRecursiveTypes(typeDefLists...); err != nil {
+		return "", fmt.Errorf("error breaking recursive types: %w", err)
+	}
+
 	enumTypes := allTypes
 	for _, op := range ops {
 		enumTypes = append(enumTypes, op.TypeDefinitions...)
 	}
+	reportTypes(enumTypes)
+	recordTypeManifestTypes(enumTypes)
+	recordDebugModelTypes(enumTypes)
+	recordModelTypes(enumTypes)
 
 	operationsOut, err := GenerateTypesForOperations(t, ops)
 	if err != nil {
@@ -516,7 +715,37 @@ func GenerateTypeDefinitions(t *template.Template, swagger *openapi.T, ops []Ope
 		return "", fmt.Errorf("error generating boilerplate for union types with additionalProperties: %w", err)
 	}
 
-	typeDefinitions := strings.Join([]string{enumsOut, typesOut, operationsOut, allOfBoilerplate, unionBoilerplate, unionAndAdditionalBoilerplate}, "")
+	yamlBoilerplate, err := GenerateYAMLBoilerplate(t, allTypes)
+	if err != nil {
+		return "", fmt.Errorf("error generating YAML boilerplate: %w", err)
+	}
+
+	copyAndEqualBoilerplate, err := GenerateCopyAndEqualBoilerplate(t, enumTypes)
+	if err != nil {
+		return "", fmt.Errorf("error generating DeepCopy and Equal boilerplate: %w", err)
+	}
+
+	validateBoilerplate, err := GenerateValidateBoilerplate(t, enumTypes)
+	if err != nil {
+		return "", fmt.Errorf("error generating Validate boilerplate: %w", err)
+	}
+
+	stringerBoilerplate, err := GenerateStringerBoilerplate(t, enumTypes)
+	if err != nil {
+		return "", fmt.Errorf("error generating String and LogValue boilerplate: %w", err)
+	}
+
+	redactedBoilerplate, err := GenerateRedactedBoilerplate(t, enumTypes)
+	if err != nil {
+		return "", fmt.Errorf("error generating String and GoString boilerplate for sensitive fields: %w", err)
+	}
+
+	fuzzBoilerplate, err := GenerateFuzzBoilerplate(t, enumTypes)
+	if err != nil {
+		return "", fmt.Errorf("error generating Random and Fuzz boilerplate: %w", err)
+	}
+
+	typeDefinitions := strings.Join([]string{enumsOut, typesOut, operationsOut, allOfBoilerplate, unionBoilerplate, unionAndAdditionalBoilerplate, yamlBoilerplate, copyAndEqualBoilerplate, validateBoilerplate, stringerBoilerplate, redactedBoilerplate, fuzzBoilerplate}, "")
 	return typeDefinitions, nil
 }
 
@@ -554,6 +783,7 @@ func GenerateTypesForSchemas(t *template.Template, schemas map[string]*openapi.S
 		excludeSchemasMap[schema] = true
 	}
 	types := make([]TypeDefinition, 0)
+	var genErr Error
 	// We're going to define Go types for every object under components/schemas
 	for _, schemaName := range SortedSchemaKeys(schemas) {
 		if _, ok := excludeSchemasMap[schemaName]; ok {
@@ -561,24 +791,36 @@ func GenerateTypesForSchemas(t *template.Template, schemas map[string]*openapi.S
 		}
 		schemaRef := schemas[schemaName]
 
+		artifact := "components/schemas/" + schemaName
+
 		goSchema, err := GenerateGoSchema(schemaRef, []string{schemaName})
 		if err != nil {
-			return nil, fmt.Errorf("error converting Schema %s to Go type: %w", schemaName, err)
+			genErr.add(artifact, schemaRef.Position(), fmt.Errorf("error converting Schema to Go type: %w", err))
+			continue
 		}
 
 		goTypeName, err := renameSchema(schemaName, schemaRef)
 		if err != nil {
-			return nil, fmt.Errorf("error making name for components/schemas/%s: %w", schemaName, err)
+			genErr.add(artifact, schemaRef.Position(), fmt.Errorf("error making name: %w", err))
+			continue
 		}
 
-		types = append(types, TypeDefinition{
+		typeDef := TypeDefinition{
 			JsonName: schemaName,
 			TypeName: goTypeName,
 			Schema:   goSchema,
+		}
+		recordTypeSources([]TypeDefinition{typeDef}, specSource{
+			Pointer:  jsonPointer("components", "schemas", schemaName),
+			Position: schemaRef.Position(),
 		})
 
+		types = append(types, typeDef)
 		types = append(types, goSchema.AdditionalTypes...)
 	}
+	if err := genErr.errOrNil(); err != nil {
+		return nil, err
+	}
 	return types, nil
 }
 
@@ -586,17 +828,21 @@ func GenerateTypesForSchemas(t *template.Template, schemas map[string]*openapi.S
 // components/parameters section of the Swagger spec.
 func GenerateTypesForParameters(t *template.Template, params map[string]*openapi.ParameterRef) ([]TypeDefinition, error) {
 	var types []TypeDefinition
+	var genErr Error
 	for _, paramName := range SortedMapKeys(params) {
 		paramOrRef := params[paramName]
+		artifact := "components/parameters/" + paramName
 
 		goType, err := paramToGoType(paramOrRef.Value, nil)
 		if err != nil {
-			return nil, fmt.Errorf("error generating Go type for schema in parameter %s: %w", paramName, err)
+			genErr.add(artifact, paramOrRef.Value.Position(), fmt.Errorf("error generating Go type for schema: %w", err))
+			continue
 		}
 
 		goTypeName, err := renameParameter(paramName, paramOrRef)
 		if err != nil {
-			return nil, fmt.Errorf("error making name for components/parameters/%s: %w", paramName, err)
+			genErr.add(artifact, paramOrRef.Value.Position(), fmt.Errorf("error making name: %w", err))
+			continue
 		}
 
 		typeDef := TypeDefinition{
@@ -609,13 +855,21 @@ func GenerateTypesForParameters(t *template.Template, params map[string]*openapi
 			// Generate a reference type for referenced parameters
 			refType, err := RefPathToGoType(paramOrRef.Ref)
 			if err != nil {
-				return nil, fmt.Errorf("error generating Go type for (%s) in parameter %s: %w", paramOrRef.Ref, paramName, err)
+				genErr.add(artifact, paramOrRef.Value.Position(), fmt.Errorf("error generating Go type for (%s): %w", paramOrRef.Ref, err))
+				continue
 			}
 			typeDef.TypeName = SchemaNameToTypeName(refType)
 		}
+		recordTypeSources([]TypeDefinition{typeDef}, specSource{
+			Pointer:  jsonPointer("components", "parameters", paramName),
+			Position: paramOrRef.Value.Position(),
+		})
 
 		types = append(types, typeDef)
 	}
+	if err := genErr.errOrNil(); err != nil {
+		return nil, err
+	}
 	return types, nil
 }
 
@@ -623,9 +877,11 @@ func GenerateTypesForParameters(t *template.Template, params map[string]*openapi
 // components/responses section of the Swagger spec.
 func GenerateTypesForResponses(t *template.Template, responses openapi.ResponseBodies) ([]TypeDefinition, error) {
 	var types []TypeDefinition
+	var genErr Error
 
 	for _, responseName := range SortedMapKeys(responses) {
 		responseOrRef := responses[responseName]
+		artifact := "components/responses/" + responseName
 
 		// We have to generate the response object. We're only going to
 		// handle media types that conform to JSON. Other responses should
@@ -648,12 +904,14 @@ func GenerateTypesForResponses(t *template.Template, responses openapi.ResponseB
 
 			goType, err := GenerateGoSchema(mediaTypeObj.Schema, []string{responseName})
 			if err != nil {
-				return nil, fmt.Errorf("error generating Go type for schema in response %s: %w", responseName, err)
+				genErr.add(artifact, mediaTypeObj.Schema.Position(), fmt.Errorf("error generating Go type for schema: %w", err))
+				continue
 			}
 
 			goTypeName, err := renameResponse(responseName, responseOrRef)
 			if err != nil {
-				return nil, fmt.Errorf("error making name for components/responses/%s: %w", responseName, err)
+				genErr.add(artifact, mediaTypeObj.Schema.Position(), fmt.Errorf("error making name: %w", err))
+				continue
 			}
 
 			typeDef := TypeDefinition{
@@ -666,7 +924,8 @@ func GenerateTypesForResponses(t *template.Template, responses openapi.ResponseB
 				// Generate a reference type for referenced parameters
 				refType, err := RefPathToGoType(responseOrRef.Ref)
 				if err != nil {
-					return nil, fmt.Errorf("error generating Go type for (%s) in parameter %s: %w", responseOrRef.Ref, responseName, err)
+					genErr.add(artifact, mediaTypeObj.Schema.Position(), fmt.Errorf("error generating Go type for (%s): %w", responseOrRef.Ref, err))
+					continue
 				}
 				typeDef.TypeName = SchemaNameToTypeName(refType)
 			}
@@ -674,10 +933,17 @@ func GenerateTypesForResponses(t *template.Template, responses openapi.ResponseB
 			if jsonCount > 1 {
 				typeDef.TypeName = typeDef.TypeName + mediaTypeToCamelCase(mediaType)
 			}
+			recordTypeSources([]TypeDefinition{typeDef}, specSource{
+				Pointer:  jsonPointer("components", "responses", responseName),
+				Position: mediaTypeObj.Schema.Position(),
+			})
 
 			types = append(types, typeDef)
 		}
 	}
+	if err := genErr.errOrNil(); err != nil {
+		return nil, err
+	}
 	return types, nil
 }
 
@@ -685,9 +951,11 @@ func GenerateTypesForResponses(t *template.Template, responses openapi.ResponseB
 // components/requestBodies section of the Swagger spec.
 func GenerateTypesForRequestBodies(t *template.Template, bodies map[string]*openapi.RequestBodyRef) ([]TypeDefinition, error) {
 	var types []TypeDefinition
+	var genErr Error
 
 	for _, requestBodyName := range SortedMapKeys(bodies) {
 		requestBodyRef := bodies[requestBodyName]
+		artifact := "components/requestBodies/" + requestBodyName
 
 		// As for responses, we will only generate Go code for JSON bodies,
 		// the other body formats are up to the user.
@@ -699,12 +967,14 @@ func GenerateTypesForRequestBodies(t *template.Template, bodies map[string]*open
 
 			goType, err := GenerateGoSchema(body.Schema, []string{requestBodyName})
 			if err != nil {
-				return nil, fmt.Errorf("error generating Go type for schema in body %s: %w", requestBodyName, err)
+				genErr.add(artifact, body.Schema.Position(), fmt.Errorf("error generating Go type for schema: %w", err))
+				continue
 			}
 
 			goTypeName, err := renameRequestBody(requestBodyName, requestBodyRef)
 			if err != nil {
-				return nil, fmt.Errorf("error making name for components/schemas/%s: %w", requestBodyName, err)
+				genErr.add(artifact, body.Schema.Position(), fmt.Errorf("error making name: %w", err))
+				continue
 			}
 
 			typeDef := TypeDefinition{
@@ -717,13 +987,21 @@ func GenerateTypesForRequestBodies(t *template.Template, bodies map[string]*open
 				// Generate a reference type for referenced bodies
 				refType, err := RefPathToGoType(requestBodyRef.Ref)
 				if err != nil {
-					return nil, fmt.Errorf("error generating Go type for (%s) in body %s: %w", requestBodyRef.Ref, requestBodyName, err)
+					genErr.add(artifact, body.Schema.Position(), fmt.Errorf("error generating Go type for (%s): %w", requestBodyRef.Ref, err))
+					continue
 				}
 				typeDef.TypeName = SchemaNameToTypeName(refType)
 			}
+			recordTypeSources([]TypeDefinition{typeDef}, specSource{
+				Pointer:  jsonPointer("components", "requestBodies", requestBodyName),
+				Position: body.Schema.Position(),
+			})
 			types = append(types, typeDef)
 		}
 	}
+	if err := genErr.errOrNil(); err != nil {
+		return nil, err
+	}
 	return types, nil
 }
 
@@ -740,12 +1018,24 @@ func GenerateTypes(t *template.Template, types []TypeDefinition) (string, error)
 			// exact type definition, in which case, we can de-dupe. If they don't
 			// match, we try auto-renaming.
 			if TypeDefinitionsEquivalent(prevType, typ) {
+				logger().Debug("de-duplicated equivalent type", "type", typ.TypeName, "first", prevType.JsonName, "duplicate", typ.JsonName)
 				continue
 			}
 			
+			// When a collision strategy is configured, names have already been made unique as the
+			// types were generated, so the only collisions left are between explicitly chosen names
+			if globalState.typeNames != nil {
+				return "", fmt.Errorf("duplicate typename '%s' detected: "+
+					"first defined for '%s', conflicts with '%s'. "+
+					"Please use x-go-type-name to specify unique names",
+					originalTypeName, prevType.JsonName, typ.JsonName)
+			}
+
 			// Try auto-renaming by appending a descriptive suffix
 			renamedTypeName := autoRenameTypeWithDescriptiveSuffix(typ, m)
 			if renamedTypeName != "" {
+				logger().Debug("renamed colliding type", "type", originalTypeName, "renamed", renamedTypeName, "first", prevType.JsonName, "conflict", typ.JsonName)
+				reportRename("type", typ.JsonName, originalTypeName, renamedTypeName, fmt.Sprintf("collides with the type generated for %s", prevType.JsonName))
 				typ.TypeName = renamedTypeName
 			} else {
 				// If auto-renaming fails, return a more informative error
@@ -863,6 +1153,8 @@ func GenerateEnums(t *template.Template, types []TypeDefinition) (string, error)
 			for e1key := range e1.GetValues() {
 				_, found := e2.GetValues()[e1key]
 				if found {
+					reportEnumPrefix(e1, fmt.Sprintf("the enum's constants collide with those of the enum %s", e2.TypeName))
+					reportEnumPrefix(e2, fmt.Sprintf("the enum's constants collide with those of the enum %s", e1.TypeName))
 					e1.PrefixTypeName = true
 					e2.PrefixTypeName = true
 					enums[i] = e1
@@ -891,6 +1183,7 @@ func GenerateEnums(t *template.Template, types []TypeDefinition) (string, error)
 					newName = tp.TypeName + suffix + fmt.Sprintf("%d", conflictCount)
 				}
 				
+				reportRename("enum-value", e1.TypeName, tp.TypeName, newName, fmt.Sprintf("collides with the type %s", tp.TypeName))
 				// Update the enum values map
 				delete(e1.Schema.EnumValues, tp.TypeName)
 				e1.Schema.EnumValues[newName] = enumValueName
@@ -902,6 +1195,7 @@ func GenerateEnums(t *template.Template, types []TypeDefinition) (string, error)
 		// type name.
 		_, found := e1.GetValues()[e1.TypeName]
 		if found {
+			reportEnumPrefix(e1, "the enum's constants collide with its type name")
 			e1.PrefixTypeName = true
 			enums[i] = e1
 		}
@@ -909,6 +1203,8 @@ func GenerateEnums(t *template.Template, types []TypeDefinition) (string, error)
 
 	// Now see if enums conflict with any non-enum typenames
 
+	recordTypeManifestEnums(enums)
+	recordDebugModelEnums(enums)
 	return GenerateTemplates([]string{"constants.tmpl"}, t, Constants{EnumDefinitions: enums})
 }
 
@@ -932,18 +1228,40 @@ func GenerateImports(t *template.Template, externalImports []string, packageName
 		}
 	}
 
+	buildConstraint, err := buildConstraint(globalState.options)
+	if err != nil {
+		return "", err
+	}
+
+	fileHeader, err := renderFileHeader(globalState.options.OutputOptions.FileHeader, fileHeaderContext{
+		PackageName: packageName,
+		ModuleName:  modulePath,
+		Version:     moduleVersion,
+	})
+	if err != nil {
+		return "", err
+	}
+
 	context := struct {
 		ExternalImports   []string
 		PackageName       string
 		ModuleName        string
 		Version           string
 		AdditionalImports []AdditionalImport
+		FileHeader        string
+		BuildConstraint   string
+		GenerateCommand   string
+		SpecProvenance    *SpecProvenance
 	}{
 		ExternalImports:   externalImports,
 		PackageName:       packageName,
 		ModuleName:        modulePath,
 		Version:           moduleVersion,
 		AdditionalImports: globalState.options.AdditionalImports,
+		FileHeader:        fileHeader,
+		BuildConstraint:   buildConstraint,
+		GenerateCommand:   globalState.options.OutputOptions.GenerateCommand,
+		SpecProvenance:    globalState.provenance,
 	}
 
 	return GenerateTemplates([]string{"imports.tmpl"}, t, context)
@@ -965,6 +1283,9 @@ func GenerateAdditionalPropertyBoilerplate(t *template.Template, typeDefs []Type
 
 		if t.Schema.HasAdditionalProperties {
 			filteredTypes = append(filteredTypes, t)
+		} else if t.Schema.PropertyNames != nil && strings.HasPrefix(t.Schema.GoType, "map[") && !t.IsAlias() {
+			// A dictionary's property names are validated when it's unmarshaled
+			filteredTypes = append(filteredTypes, t)
 		}
 	}
 
@@ -1023,6 +1344,40 @@ func GenerateUnionAndAdditionalProopertiesBoilerplate(t *template.Template, type
 	return GenerateTemplates([]string{"union-and-additional-properties.tmpl"}, t, context)
 }
 
+// GenerateYAMLBoilerplate generates the YAML marshalling for the types which
+// have custom JSON marshalling, such as unions and objects with
+// additionalProperties, when YAML is one of the `extra-serializers`, so that
+// they're marshaled to and from YAML the same way as JSON
+func GenerateYAMLBoilerplate(t *template.Template, typeDefs []TypeDefinition) (string, error) {
+	if !sliceContains(globalState.options.OutputOptions.ExtraSerializers, string(ExtraSerializerYAML)) {
+		return "", nil
+	}
+
+	var filteredTypes []TypeDefinition
+	seen := make(map[string]bool)
+	for _, td := range typeDefs {
+		if seen[td.TypeName] || td.IsAlias() || !strings.HasPrefix(td.Schema.GoType, "struct") {
+			continue
+		}
+		if td.Schema.HasAdditionalProperties || len(td.Schema.UnionElements) != 0 {
+			seen[td.TypeName] = true
+			filteredTypes = append(filteredTypes, td)
+		}
+	}
+
+	if len(filteredTypes) == 0 {
+		return "", nil
+	}
+
+	context := struct {
+		Types []TypeDefinition
+	}{
+		Types: filteredTypes,
+	}
+
+	return GenerateTemplates([]string{"yaml.tmpl"}, t, context)
+}
+
 // SanitizeCode runs sanitizers across the generated Go code to ensure the
 // generated code will be able to compile.
 func SanitizeCode(goCode string) string {
@@ -1031,6 +1386,15 @@ func SanitizeCode(goCode string) string {
 	return strings.ReplaceAll(goCode, "\uFEFF", "")
 }
 
+// sanitizeCode removes any byte-order-marks from code, as SanitizeCode does, only copying the code if it has any
+func sanitizeCode(code []byte) []byte {
+	bom := []byte("\uFEFF")
+	if !bytes.Contains(code, bom) {
+		return code
+	}
+	return bytes.ReplaceAll(code, bom, nil)
+}
+
 // GetUserTemplateText attempts to retrieve the template text from a passed in URL or file
 // path when inputData is more than one line.
 // This function will attempt to load a file first, and if it fails, will try to get the
@@ -1131,13 +1495,19 @@ func OperationSchemaImports(s *Schema) (map[string]goImport, error) {
 func OperationImports(ops []OperationDefinition) (map[string]goImport, error) {
 	res := map[string]goImport{}
 	for _, op := range ops {
-		for _, pd := range [][]ParameterDefinition{op.PathParams, op.QueryParams} {
+		for _, pd := range [][]ParameterDefinition{op.PathParams, op.QueryParams, op.HeaderParams, op.CookieParams} {
 			for _, p := range pd {
 				imprts, err := OperationSchemaImports(&p.Schema)
 				if err != nil {
 					return nil, err
 				}
 				MergeImports(res, imprts)
+
+				imprts, err = ParameterImports(p.Spec)
+				if err != nil {
+					return nil, err
+				}
+				MergeImports(res, imprts)
 			}
 		}
 
@@ -1318,6 +1688,28 @@ func GetParametersImports(params map[string]*openapi.ParameterRef) (map[string]g
 			return nil, err
 		}
 		MergeImports(res, imprts)
+
+		imprts, err = ParameterImports(param.Value)
+		if err != nil {
+			return nil, err
+		}
+		MergeImports(res, imprts)
+	}
+	return res, nil
+}
+
+// ParameterImports returns the import of the type a parameter overrides its schema's type with, through x-go-type and x-go-type-import on the parameter itself
+func ParameterImports(param *openapi.Parameter) (map[string]goImport, error) {
+	res := map[string]goImport{}
+	if param == nil {
+		return res, nil
+	}
+	gi, err := ParseGoImportExtension(&openapi.SchemaRef{Value: &openapi.Schema{Extensions: param.Extensions}})
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s of parameter %s: %w", extPropGoImport, param.Name, err)
+	}
+	if gi != nil {
+		res[gi.String()] = *gi
 	}
 	return res, nil
 }
@@ -2,11 +2,18 @@ package codegen
 
 import (
 	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"go/format"
+	"io"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 
 	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
 	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
@@ -64,6 +71,7 @@ type GetTestByNameResponse struct {
 	XML200       *[]Test
 	JSON422      *[]interface{}
 	XML422       *[]interface{}
+	JSONDefault  *Error
 }`)
 
 	// Check that the helper methods are generated correctly:
@@ -228,3 +236,891 @@ func (t *ExampleSchema_Item) FromExternalRef0NewPet(v externalRef0.NewPet) error
 
 //go:embed test_spec.yaml
 var testOpenAPIDefinition string
+
+func TestEmbeddedSpecRoundTrip(t *testing.T) {
+	spec := `
+openapi: 3.0.3
+info:
+  title: Embedded round trip
+  version: "1.0"
+paths:
+  /zebras:
+    get:
+      operationId: listZebras
+      responses:
+        "200":
+          description: Zebras
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Zebra'
+components:
+  schemas:
+    Zebra:
+      type: object
+      properties:
+        stripes:
+          type: integer
+          format: int64
+          example: 9007199254740993
+        wild:
+          type: boolean
+          default: true
+`
+
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:       true,
+			EmbeddedSpec: true,
+		},
+		Compatibility: CompatibilityOptions{
+			PreserveOriginalOperationIdCasingInEmbeddedSpec: true,
+		},
+	})
+	require.NoError(t, err)
+
+	var expected any
+	require.NoError(t, yaml.Unmarshal([]byte(spec), &expected))
+	expectedJSON, err := json.Marshal(expected)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, string(expectedJSON), string(decodeEmbeddedSpec(t, code)))
+}
+
+func TestGenerateIsIdempotent(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: Idempotent
+  version: 1.0.0
+paths:
+  /pets:
+    servers:
+      - url: https://pets.example.com
+    get:
+      operationId: list-pets
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        nickname:
+          type: [string, "null"]
+        owner:
+          allOf:
+            - $ref: '#/components/schemas/Person'
+            - type: object
+              required: [since]
+              properties:
+                since:
+                  type: string
+        walker:
+          allOf:
+            - $ref: '#/components/schemas/Person'
+            - type: object
+              properties:
+                rate:
+                  type: number
+    Person:
+      type: object
+      properties:
+        name:
+          type: string
+`
+
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	rendered, err := swagger.Render()
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:       true,
+			Client:       true,
+			EmbeddedSpec: true,
+		},
+		OutputOptions: OutputOptions{
+			IncludeOperationIDs: []string{"list-pets"},
+		},
+	}
+	first, err := Generate(swagger, opts)
+	require.NoError(t, err)
+	second, err := Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.Equal(t, first, second, "generating code from the same spec twice generates the same code")
+
+	// Merging an allOf doesn't add the properties of one member to another
+	assert.NotContains(t, first, "Rate  *float32 `json:\"rate,omitempty\"`\n\t\tSince string")
+	assert.Contains(t, first, "func (c *Client) ListPets(")
+
+	after, err := swagger.Render()
+	require.NoError(t, err)
+	assert.Equal(t, string(rendered), string(after), "the spec isn't changed by generating code from it")
+	person := swagger.Components.Schemas["Person"].Value
+	assert.Equal(t, 1, person.Properties.Len())
+	assert.Empty(t, person.Required)
+
+	// The embedded spec still has the operationIds the operations are generated with
+	assert.Contains(t, string(decodeEmbeddedSpec(t, second)), `"operationId":"ListPets"`)
+}
+
+func TestEmbeddedSpecIsWhatIsGenerated(t *testing.T) {
+	spec := `openapi: 3.0.3
+info:
+  title: Embedded
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      tags: [pets]
+      operationId: list-pets
+      parameters:
+        - $ref: '#/components/parameters/Limit'
+      responses:
+        '200':
+          $ref: '#/components/responses/Pets'
+  /owners:
+    get:
+      tags: [owners]
+      operationId: list-owners
+      parameters:
+        - $ref: '#/components/parameters/Offset'
+      responses:
+        '200':
+          description: OK
+components:
+  parameters:
+    Limit:
+      name: limit
+      in: query
+      schema:
+        type: integer
+    Offset:
+      name: offset
+      in: query
+      schema:
+        type: integer
+  responses:
+    Pets:
+      description: OK
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/Pet'
+  schemas:
+    Pet:
+      type: object
+`
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:       true,
+			Client:       true,
+			EmbeddedSpec: true,
+		},
+		OutputOptions: OutputOptions{
+			IncludeTags: []string{"pets"},
+		},
+	}
+
+	t.Run("filtered and pruned", func(t *testing.T) {
+		swagger, err := openapi.NewLoader().LoadFromData([]byte(spec))
+		require.NoError(t, err)
+		code, err := Generate(swagger, opts)
+		require.NoError(t, err)
+		assert.Contains(t, code, "func (c *Client) ListPets(")
+		assert.NotContains(t, code, "ListOwners")
+
+		var embedded struct {
+			Paths      map[string]map[string]any
+			Components struct {
+				Parameters map[string]any
+				Responses  map[string]any
+				Schemas    map[string]any
+			}
+		}
+		require.NoError(t, json.Unmarshal(decodeEmbeddedSpec(t, code), &embedded))
+		assert.Len(t, embedded.Paths, 1, "the path without operations left is removed")
+		assert.Equal(t, "ListPets", embedded.Paths["/pets"]["get"].(map[string]any)["operationId"])
+		assert.Len(t, embedded.Components.Parameters, 1, "only the parameter which is still used is kept")
+		assert.Contains(t, embedded.Components.Parameters, "Limit")
+		assert.Contains(t, embedded.Components.Responses, "Pets")
+		assert.Contains(t, embedded.Components.Schemas, "Pet")
+
+		reloaded, err := openapi.NewLoader().LoadFromData(decodeEmbeddedSpec(t, code))
+		require.NoError(t, err)
+		assert.Empty(t, reloaded.LoadResult().Warnings, "the embedded spec has no dangling references")
+	})
+
+	t.Run("unfiltered", func(t *testing.T) {
+		swagger, err := openapi.NewLoader().LoadFromData([]byte(spec))
+		require.NoError(t, err)
+		unfiltered := opts
+		unfiltered.OutputOptions.EmbedUnfilteredSpec = true
+		code, err := Generate(swagger, unfiltered)
+		require.NoError(t, err)
+		assert.NotContains(t, code, "ListOwners", "the code is still only generated for what isn't filtered out")
+
+		embedded := string(decodeEmbeddedSpec(t, code))
+		assert.Contains(t, embedded, `"/owners"`)
+		assert.Contains(t, embedded, `"operationId":"list-owners"`, "operations which aren't generated keep their operationIds")
+		assert.Contains(t, embedded, `"operationId":"ListPets"`)
+		assert.Contains(t, embedded, `"Offset"`)
+	})
+}
+
+func TestGenerateErrorIncludesLocation(t *testing.T) {
+	spec := `openapi: 3.0.3
+info:
+  title: Locations
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        weight:
+          type: number
+          format: kilograms
+`
+
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err)
+
+	_, err = Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+		},
+		OutputOptions: OutputOptions{
+			SkipPrune: true,
+		},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "components/schemas/Pet (9:7): error converting Schema to Go type")
+	assert.Contains(t, err.Error(), "error generating Go schema for property 'weight' (12:11)")
+}
+
+func TestGenerateAggregatesErrors(t *testing.T) {
+	spec := `openapi: 3.0.3
+info:
+  title: Errors
+  version: 1.0.0
+paths:
+  /pets/{petId}:
+    get:
+      operationId: getPet
+      responses:
+        '200':
+          description: OK
+components:
+  schemas:
+    Cat:
+      type: object
+      properties:
+        weight:
+          type: number
+          format: kilograms
+    Dog:
+      type: number
+      format: huge
+`
+
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err)
+
+	_, err = Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+		},
+		OutputOptions: OutputOptions{
+			SkipPrune: true,
+		},
+	})
+	require.Error(t, err)
+
+	var genErr *Error
+	require.True(t, errors.As(err, &genErr))
+	require.Len(t, genErr.Failures, 3)
+
+	assert.Equal(t, "GET /pets/{petId}", genErr.Failures[0].Artifact)
+	assert.Equal(t, 7, genErr.Failures[0].Position.Line)
+	assert.Equal(t, "components/schemas/Cat", genErr.Failures[1].Artifact)
+	assert.Equal(t, 15, genErr.Failures[1].Position.Line)
+	assert.Equal(t, "components/schemas/Dog", genErr.Failures[2].Artifact)
+	assert.Equal(t, 21, genErr.Failures[2].Position.Line)
+
+	assert.True(t, strings.HasPrefix(err.Error(), "3 errors occurred generating code:"))
+}
+
+func TestInlineTypeNaming(t *testing.T) {
+	spec := `openapi: 3.0.3
+info:
+  title: Inline types
+  version: 1.0.0
+paths:
+  /users/{id}:
+    post:
+      operationId: postUsersId
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      requestBody:
+        x-go-type-name: CreateUser
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  status:
+                    type: string
+                    enum: [active, inactive]
+        '201':
+          description: Created
+          x-go-type-name: CreatedUser
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  id:
+                    type: string
+`
+
+	generate := func(t *testing.T, outputOptions OutputOptions) string {
+		t.Helper()
+
+		swagger, err := openapi.NewLoader().LoadFromData([]byte(spec))
+		require.NoError(t, err)
+
+		code, err := Generate(swagger, Configuration{
+			PackageName: "api",
+			Generate: GenerateOptions{
+				Models:        true,
+				Client:        true,
+				StdHTTPServer: true,
+				Strict:        true,
+			},
+			OutputOptions: outputOptions,
+		})
+		require.NoError(t, err)
+
+		_, err = format.Source([]byte(code))
+		require.NoError(t, err)
+		return code
+	}
+
+	t.Run("request body and response names come from x-go-type-name", func(t *testing.T) {
+		code := generate(t, OutputOptions{})
+
+		assert.Contains(t, code, "type CreateUser struct {")
+		assert.Contains(t, code, "type PostUsersIdJSONRequestBody CreateUser")
+		assert.NotContains(t, code, "PostUsersIdJSONBody")
+
+		assert.Contains(t, code, "type CreatedUser struct {")
+		assert.Contains(t, code, "JSON201 *CreatedUser")
+		assert.Contains(t, code, "type PostUsersId201JSONResponse = CreatedUser")
+
+		// Without `promote-inline-schemas`, other responses are still anonymous structs
+		assert.Contains(t, code, "JSON200      *struct {")
+	})
+
+	t.Run("promote-inline-schemas names all inline responses", func(t *testing.T) {
+		code := generate(t, OutputOptions{
+			PromoteInlineSchemas: true,
+		})
+
+		assert.Contains(t, code, "type PostUsersId200JSONResponseBody struct {")
+		assert.Contains(t, code, "Status *PostUsersId200JSONResponseBodyStatus `json:\"status,omitempty\"`")
+		assert.Contains(t, code, "type PostUsersId200JSONResponseBodyStatus string")
+		assert.Contains(t, code, "JSON200      *PostUsersId200JSONResponseBody")
+		assert.Contains(t, code, "type PostUsersId200JSONResponse = PostUsersId200JSONResponseBody")
+	})
+
+	t.Run("inline-type-names renames promoted types", func(t *testing.T) {
+		code := generate(t, OutputOptions{
+			PromoteInlineSchemas: true,
+			InlineTypeNames: map[string]string{
+				"PostUsersId200JSONResponseBody": "UserStatus",
+			},
+		})
+
+		assert.Contains(t, code, "type UserStatus struct {")
+		assert.Contains(t, code, "Status *UserStatusStatus `json:\"status,omitempty\"`")
+		assert.Contains(t, code, "JSON200      *UserStatus")
+		assert.NotContains(t, code, "PostUsersId200JSONResponseBody")
+	})
+}
+
+func TestSharedInlineResponseTypes(t *testing.T) {
+	spec := `openapi: 3.0.3
+info:
+  title: Shared inline types
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  count:
+                    type: integer
+        '400':
+          description: Bad request
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  code:
+                    type: integer
+                  message:
+                    type: string
+  /users:
+    get:
+      operationId: listUsers
+      responses:
+        '400':
+          description: Bad request
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  code:
+                    type: integer
+                  message:
+                    type: string
+        '404':
+          description: Not found
+          x-go-type-name: UserNotFound
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  code:
+                    type: integer
+                  message:
+                    type: string
+`
+
+	generate := func(t *testing.T, outputOptions OutputOptions) string {
+		t.Helper()
+
+		swagger, err := openapi.NewLoader().LoadFromData([]byte(spec))
+		require.NoError(t, err)
+
+		code, err := Generate(swagger, Configuration{
+			PackageName: "api",
+			Generate: GenerateOptions{
+				Models:        true,
+				Client:        true,
+				StdHTTPServer: true,
+				Strict:        true,
+			},
+			OutputOptions: outputOptions,
+		})
+		require.NoError(t, err)
+
+		_, err = format.Source([]byte(code))
+		require.NoError(t, err)
+		return code
+	}
+
+	t.Run("identical schemas share the first response's type", func(t *testing.T) {
+		code := generate(t, OutputOptions{
+			ShareInlineResponseTypes: true,
+		})
+
+		assert.Contains(t, code, "type ListPets200JSONResponseBody struct {")
+		assert.Contains(t, code, "type ListPets400JSONResponseBody struct {")
+		assert.Equal(t, 1, strings.Count(code, "type ListPets400JSONResponseBody struct {"))
+		assert.NotContains(t, code, "type ListUsers400JSONResponseBody struct {")
+		assert.Contains(t, code, "type ListUsers400JSONResponse = ListPets400JSONResponseBody")
+
+		// An explicitly named type is never shared
+		assert.Contains(t, code, "type UserNotFound struct {")
+	})
+
+	t.Run("inline-type-names renames the shared type", func(t *testing.T) {
+		code := generate(t, OutputOptions{
+			ShareInlineResponseTypes: true,
+			InlineTypeNames: map[string]string{
+				"ListPets400JSONResponseBody": "Error",
+			},
+		})
+
+		assert.Contains(t, code, "type Error struct {")
+		assert.Contains(t, code, "type ListPets400JSONResponse = Error")
+		assert.Contains(t, code, "type ListUsers400JSONResponse = Error")
+		assert.NotContains(t, code, "ListPets400JSONResponseBody")
+	})
+}
+
+func TestPropertyNames(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: propertyNames
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Region:
+      type: string
+      enum: [eu, us]
+    Labels:
+      type: object
+      propertyNames:
+        pattern: "^[a-z][a-z0-9-]*$"
+      additionalProperties:
+        type: string
+    Quotas:
+      type: object
+      propertyNames:
+        $ref: '#/components/schemas/Region'
+      additionalProperties:
+        type: integer
+    Settings:
+      type: object
+      properties:
+        version:
+          type: string
+        limits:
+          type: object
+          propertyNames:
+            enum: [cpu, memory]
+          additionalProperties:
+            type: integer
+      propertyNames:
+        type: string
+        enum: [version, theme, locale]
+      additionalProperties:
+        type: string
+`
+
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = format.Source([]byte(code))
+	require.NoError(t, err)
+
+	// A pattern is checked when a dictionary is unmarshaled
+	assert.Contains(t, code, "type Labels map[string]string")
+	assert.Contains(t, code, `var propertyNamesPatternLabels = regexp.MustCompile("^[a-z][a-z0-9-]*$")`)
+	assert.Contains(t, code, "if !propertyNamesPatternLabels.MatchString(fieldName) {")
+
+	// Keys which reference a string enum use its type
+	assert.Contains(t, code, "type Quotas map[Region]int")
+	assert.Contains(t, code, `case "eu", "us":`)
+
+	// Keys which are an inline string enum are given a type of their own
+	assert.Contains(t, code, "type SettingsKey string")
+	assert.Contains(t, code, `Locale  SettingsKey = "locale"`)
+	assert.Contains(t, code, "AdditionalProperties map[SettingsKey]string `json:\"-\"`")
+	assert.Contains(t, code, "func (a Settings) Get(fieldName SettingsKey) (value string, found bool) {")
+	assert.Contains(t, code, `case "version", "theme", "locale":`)
+	assert.Contains(t, code, "a.AdditionalProperties[SettingsKey(fieldName)] = fieldVal")
+	assert.Contains(t, code, "object[string(fieldName)], err = json.Marshal(field)")
+
+	// An inline dictionary with constrained keys is given a named type, so that they can be validated
+	assert.Contains(t, code, "Limits               *Settings_Limits       `json:\"limits,omitempty\"`")
+	assert.Contains(t, code, "type Settings_Limits map[string]int")
+	assert.Contains(t, code, "func (a *Settings_Limits) UnmarshalJSON(b []byte) error {")
+	assert.Contains(t, code, `case "cpu", "memory":`)
+}
+
+func TestPropertyNamesInvalidPattern(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: propertyNames
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Labels:
+      type: object
+      propertyNames:
+        pattern: "^(?!internal-)"
+      additionalProperties:
+        type: string
+`
+
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err)
+
+	_, err = Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+		},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "isn't supported by Go's regexp package")
+}
+
+func TestAdditionalPropertiesRepresentation(t *testing.T) {
+	spec := `openapi: 3.0.3
+info:
+  title: additionalProperties
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        labels:
+          type: object
+          additionalProperties:
+            type: string
+        counts:
+          type: object
+          x-oapi-codegen-additional-properties: struct
+          additionalProperties:
+            type: integer
+    Metadata:
+      type: object
+      properties:
+        version:
+          type: string
+      additionalProperties:
+        type: string
+    Settings:
+      type: object
+      x-oapi-codegen-additional-properties: map
+      properties:
+        theme:
+          type: string
+      additionalProperties:
+        type: string
+`
+
+	generate := func(t *testing.T, outputOptions OutputOptions) string {
+		t.Helper()
+
+		swagger, err := openapi.NewLoader().LoadFromData([]byte(spec))
+		require.NoError(t, err)
+
+		code, err := Generate(swagger, Configuration{
+			PackageName: "api",
+			Generate: GenerateOptions{
+				Models: true,
+			},
+			OutputOptions: outputOptions,
+		})
+		require.NoError(t, err)
+
+		_, err = format.Source([]byte(code))
+		require.NoError(t, err)
+		return code
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		code := generate(t, OutputOptions{})
+
+		assert.Contains(t, code, "Labels *map[string]string `json:\"labels,omitempty\"`")
+		assert.Contains(t, code, "type Metadata struct {")
+		assert.Contains(t, code, "AdditionalProperties map[string]string `json:\"-\"`")
+
+		// The extension overrides the defaults
+		assert.Contains(t, code, "Counts *Pet_Counts        `json:\"counts,omitempty\"`")
+		assert.Contains(t, code, "AdditionalProperties map[string]int `json:\"-\"`")
+		assert.Contains(t, code, "type Settings map[string]string")
+	})
+
+	t.Run("named-map-types", func(t *testing.T) {
+		code := generate(t, OutputOptions{
+			NamedMapTypes: true,
+		})
+
+		assert.Contains(t, code, "Labels *Pet_Labels `json:\"labels,omitempty\"`")
+		assert.Contains(t, code, "type Pet_Labels map[string]string")
+	})
+
+	t.Run("prefer-map-for-additional-properties", func(t *testing.T) {
+		code := generate(t, OutputOptions{
+			PreferMapForAdditionalProperties: true,
+		})
+
+		assert.Contains(t, code, "type Metadata map[string]string")
+		assert.NotContains(t, code, "func (a Metadata) Get(")
+	})
+}
+
+// largeSpec returns a spec with n component schemas, each with a dozen properties, and n paths, each with an operation to get, and one to create, one of them
+func largeSpec(n int) string {
+	var sb strings.Builder
+	sb.WriteString("openapi: 3.0.0\ninfo:\n  title: Large\n  version: 1.0.0\npaths:\n")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&sb, `  /things%[1]d/{id}:
+    parameters:
+      - {name: id, in: path, required: true, schema: {type: string}}
+    get:
+      operationId: getThing%[1]d
+      parameters:
+        - {name: limit, in: query, schema: {type: integer}}
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Thing%[1]d'}
+    post:
+      operationId: createThing%[1]d
+      requestBody:
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/Thing%[1]d'}
+      responses:
+        "201":
+          description: Created
+`, i)
+	}
+	sb.WriteString("components:\n  schemas:\n")
+	types := []string{"string", "integer", "boolean", "number"}
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&sb, "    Thing%d:\n      type: object\n      required: [field0]\n      properties:\n", i)
+		for j := 0; j < 12; j++ {
+			fmt.Fprintf(&sb, "        field%d:\n          type: %s\n          description: Field %d of thing %d\n", j, types[j%len(types)], j, i)
+		}
+		sb.WriteString("        status:\n          type: string\n          enum: [a, b, c]\n")
+	}
+	return sb.String()
+}
+
+func TestGenerateTo(t *testing.T) {
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:    true,
+			Client:    true,
+			ChiServer: true,
+			Strict:    true,
+		},
+	}
+	load := func() *openapi.T {
+		swagger, err := openapi.NewLoader().LoadFromData([]byte(largeSpec(3)))
+		require.NoError(t, err)
+		return swagger
+	}
+
+	code, err := Generate(load(), opts)
+	require.NoError(t, err)
+
+	var sb strings.Builder
+	require.NoError(t, GenerateTo(&sb, load(), opts))
+	assert.Equal(t, code, sb.String())
+
+	// The code is buffered, so nothing is written when generating it fails
+	sb.Reset()
+	opts.OutputOptions.NameNormalizer = "unknown"
+	require.Error(t, GenerateTo(&sb, load(), opts))
+	assert.Empty(t, sb.String())
+}
+
+func TestTimings(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(largeSpec(3)))
+	require.NoError(t, err)
+
+	var phases []string
+	_, err = Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:    true,
+			Client:    true,
+			ChiServer: true,
+		},
+		Timings: func(phase string, elapsed time.Duration) {
+			assert.GreaterOrEqual(t, elapsed, time.Duration(0))
+			phases = append(phases, phase)
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"filter", "operations", "models", "server", "client", "format"}, phases)
+
+	// Phases which aren't run aren't timed
+	phases = nil
+	swagger, err = openapi.NewLoader().LoadFromData([]byte(largeSpec(3)))
+	require.NoError(t, err)
+	_, err = Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate:    GenerateOptions{Models: true},
+		OutputOptions: OutputOptions{
+			SkipFmt: true,
+		},
+		Timings: func(phase string, _ time.Duration) {
+			phases = append(phases, phase)
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"filter", "operations", "models"}, phases)
+}
+
+// BenchmarkGenerateLargeSpec measures generating the code for a spec with hundreds of schemas and operations, whose code is several megabytes
+func BenchmarkGenerateLargeSpec(b *testing.B) {
+	data := []byte(largeSpec(200))
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:    true,
+			Client:    true,
+			ChiServer: true,
+			Strict:    true,
+		},
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		swagger, err := openapi.NewLoader().LoadFromData(data)
+		require.NoError(b, err)
+		b.StartTimer()
+
+		if err := GenerateTo(io.Discard, swagger, opts); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
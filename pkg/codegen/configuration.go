@@ -3,7 +3,12 @@ package codegen
 import (
 	"errors"
 	"fmt"
+	"go/build/constraint"
+	"log/slog"
 	"reflect"
+	"strings"
+	"text/template"
+	"time"
 )
 
 type AdditionalImport struct {
@@ -28,6 +33,12 @@ type Configuration struct {
 	// NoVCSVersionOverride allows overriding the version of the application for cases where no Version Control System (VCS) is available when building, for instance when using a Nix derivation.
 	// See documentation for how to use it in examples/no-vcs-version-override/README.md
 	NoVCSVersionOverride *string `yaml:"-"`
+	// Logger receives debug logs about the decisions made while generating code, such as which operations are filtered out, which components are pruned, and how colliding type names are resolved.
+	// If nil, nothing is logged
+	Logger *slog.Logger `yaml:"-"`
+	// Timings receives how long each phase of generating code takes, as it finishes, such as generating the models, or formatting the code, to guide optimising the generation of large specs. The phases are `filter`, `operations`, `models`, `server`, `client` and `format`, each of which is only timed when it's run.
+	// If nil, nothing is timed
+	Timings func(phase string, elapsed time.Duration) `yaml:"-"`
 }
 
 // Validate checks whether Configuration represent a valid configuration
@@ -128,10 +139,66 @@ type GenerateOptions struct {
 	EmbeddedSpec bool `yaml:"embedded-spec,omitempty"`
 	// ServerURLs generates types for the `Server` definitions' URLs, instead of needing to provide your own values
 	ServerURLs bool `yaml:"server-urls,omitempty"`
+	// DeepCopy generates a `DeepCopy()` method for each model, which returns a copy that doesn't share any pointers, slices or maps with the original
+	DeepCopy bool `yaml:"deepcopy,omitempty"`
+	// Equal generates an `Equal(other T) bool` method for each model, which compares the contents of the model, rather than the pointers, slices and maps within it
+	Equal bool `yaml:"equal,omitempty"`
+	// Validation generates a `Validate() error` method for each model, which checks it against the constraints of its schema, such as `enum`, `minLength` or `maximum`, along with a `WithRequestValidation` option for the client, which validates each request before it's sent
+	Validation bool `yaml:"validation,omitempty"`
+	// Stringer generates `String()` and `LogValue()` methods for each model, which summarize it by its key fields, as listed in its schema's `x-log-fields`, or otherwise all of its properties, with the values of sensitive fields redacted
+	Stringer bool `yaml:"stringer,omitempty"`
+	// ConformanceTests generates a `RunServerConformance(t, handler)` function, which sends a minimal valid request for each operation to a server's `http.Handler`, and checks that the status code and content type of each response are documented in the spec. As it uses `testing`, it's split out into a `_test.go` file by GenerateFiles
+	ConformanceTests bool `yaml:"conformance-tests,omitempty"`
+	// URLHelpers generates a `URLFor<Operation>(...) string` function for each operation, which builds the path of a request to it from its path parameters, styled and escaped as the client does, such as for servers generating links, and for tests
+	URLHelpers bool `yaml:"url-helpers,omitempty"`
+	// Fuzz generates a `Random<Type>(r, size)` function for each model, which returns a random value that conforms to its schema, along with a `Generate` method, so that the model can be used with `testing/quick`, and a `RunFuzz<Type>(f, target)` function, which fuzzes a target with random values of the model. As the `RunFuzz<Type>` functions use `testing`, they're split out into a `_test.go` file by GenerateFiles
+	Fuzz bool `yaml:"fuzz,omitempty"`
+	// JSONSchemas generates a `SchemaFor(name string) []byte` function, which returns each of the component schemas, by name, as a standalone JSON Schema 2020-12 document, for runtime validation in other services and languages
+	JSONSchemas bool `yaml:"json-schemas,omitempty"`
+	// SpecProvenance generates `SpecVersion()` and `SpecHash()` functions, which return the `info.version` of the spec and a canonical SHA-256 hash of it, along with the documents it references, so that services can report exactly which contract they were generated from. Both are also added to the header of the generated code
+	SpecProvenance bool `yaml:"spec-provenance,omitempty"`
+	// Docs generates a Markdown reference of the API, rather than code, with a section for each operation, with its parameters, bodies and responses, and examples of calling it with curl and with the generated client, followed by a section for each of the component schemas. It's output to the file given by `output-files.docs`
+	Docs bool `yaml:"docs,omitempty"`
+	// ServerScaffold generates a `Server` type which implements the server's interface, with each of its methods responding with `501 Not Implemented`, as a starting point for implementing the API. As it's meant to be edited, it's output to the file given by `output-files.server-scaffold`, and only if that file doesn't already exist
+	ServerScaffold bool `yaml:"server-scaffold,omitempty"`
 }
 
 func (oo GenerateOptions) Validate() map[string]string {
-	return nil
+	problems := make(map[string]string)
+
+	// The methods must be generated in the same package as the types they're defined on
+	if oo.DeepCopy && !oo.Models {
+		problems["deepcopy"] = "You have specified `deepcopy`, but not `models`. Please specify `models: true`, as the methods are generated alongside the models"
+	}
+	if oo.Equal && !oo.Models {
+		problems["equal"] = "You have specified `equal`, but not `models`. Please specify `models: true`, as the methods are generated alongside the models"
+	}
+	if oo.Stringer && !oo.Models {
+		problems["stringer"] = "You have specified `stringer`, but not `models`. Please specify `models: true`, as the methods are generated alongside the models"
+	}
+	if oo.JSONSchemas && !oo.Models {
+		problems["json-schemas"] = "You have specified `json-schemas`, but not `models`. Please specify `models: true`, as the schemas are generated alongside the models"
+	}
+	if oo.Fuzz && !oo.Models {
+		problems["fuzz"] = "You have specified `fuzz`, but not `models`. Please specify `models: true`, as the functions are generated alongside the models"
+	}
+	if oo.Validation && !oo.Models && !oo.Client {
+		problems["validation"] = "You have specified `validation`, but neither `models` nor `client`. Please specify `models: true` or `client: true`, as the validation is generated alongside them"
+	}
+
+	if oo.ServerScaffold && !oo.generatesServer() {
+		problems["server-scaffold"] = "You have specified `server-scaffold`, but none of the servers. Please specify one, such as `chi-server: true`, as the scaffold implements its interface"
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return problems
+}
+
+// generatesServer reports whether any of the servers are generated
+func (oo GenerateOptions) generatesServer() bool {
+	return oo.ChiServer || oo.EchoServer || oo.GinServer || oo.GorillaServer || oo.FiberServer || oo.IrisServer || oo.StdHTTPServer
 }
 
 func (oo GenerateOptions) Warnings() map[string]string {
@@ -234,6 +301,10 @@ type CompatibilityOptions struct {
 	// NOTE that this will not impact generated code.
 	// NOTE that if you're using `include-operation-ids` or `exclude-operation-ids` you may want to ensure that the `operationId`s used are correct.
 	PreserveOriginalOperationIdCasingInEmbeddedSpec bool `yaml:"preserve-original-operation-id-casing-in-embedded-spec"`
+
+	// KeepSingleMemberCompositions keeps a schema which is only an `allOf` of a single reference, or a `oneOf` of a single schema, as it's written, rather than unwrapping it into the schema it wraps when the spec is loaded. A single-member `oneOf` is then generated as a union with one member, and a component which is only an `allOf` of a single reference as a struct which embeds it, rather than as an alias.
+	// NOTE that this only applies to specs loaded by the `oapi-codegen` command. When loading a spec with `openapi.NewLoader`, set the Loader's `SimplifyCompositions` to false instead.
+	KeepSingleMemberCompositions bool `yaml:"keep-single-member-compositions,omitempty"`
 }
 
 func (co CompatibilityOptions) Validate() map[string]string {
@@ -244,6 +315,8 @@ func (co CompatibilityOptions) Validate() map[string]string {
 type OutputOptions struct {
 	// Whether to skip go imports on the generated code
 	SkipFmt bool `yaml:"skip-fmt,omitempty"`
+	// Formatter is the tool used to format the generated code, which is `goimports` by default. Corresponds with the constants defined for `codegen.Formatter`
+	Formatter string `yaml:"formatter,omitempty"`
 	// Whether to skip pruning unused components on the generated code
 	SkipPrune bool `yaml:"skip-prune,omitempty"`
 	// Only include operations that have one of these tags. Ignored when empty.
@@ -300,16 +373,211 @@ type OutputOptions struct {
 
 	// PreferSkipOptionalPointerOnContainerTypes allows disabling the generation of an "optional pointer" for an optional field that is a container type (such as a slice or a map), which ends up requiring an additional, unnecessary, `... != nil` check
 	PreferSkipOptionalPointerOnContainerTypes bool `yaml:"prefer-skip-optional-pointer-on-container-types,omitempty"`
+
+	// DownconvertEmbeddedSpec converts an OpenAPI 3.1 specification to OpenAPI 3.0 before it is embedded, for consumers of the embedded spec which only understand OpenAPI 3.0
+	DownconvertEmbeddedSpec bool `yaml:"downconvert-embedded-spec,omitempty"`
+
+	// EmbedUnfilteredSpec embeds the spec as it was before the operations and components which aren't generated were filtered out and pruned, rather than exactly what was generated
+	EmbedUnfilteredSpec bool `yaml:"embed-unfiltered-spec,omitempty"`
+
+	// DuplicateOperationIds defines how operations which share an `operationId` are handled. By default, generation fails, reporting where each of the operations is defined. Corresponds with the constants defined for `codegen.DuplicateOperationIdStrategy`
+	DuplicateOperationIds string `yaml:"duplicate-operation-ids,omitempty"`
+
+	// BasePathStrategy defines how the path of the spec's server URL, such as `/v1`, is handled when registering a server's routes. By default, routes are registered with the operations' paths alone. Corresponds with the constants defined for `codegen.BasePathStrategy`
+	BasePathStrategy string `yaml:"base-path-strategy,omitempty"`
+
+	// Routes normalizes the operations' paths, for servers which match the paths of requests case-insensitively, or regardless of a trailing slash, in the same way wherever they're used: the routes registered with the server, the paths of the client's requests, and the URL helpers. Operations whose paths are then the same, for the same method, fail generation, as their routes would conflict
+	Routes RouteOptions `yaml:"routes,omitempty"`
+
+	// TypeNameCollisions defines how two different types which would be generated with the same Go type name are handled, which applies to component types as well as those generated from inline schemas, such as enums, unions and nested objects. Corresponds with the constants defined for `codegen.TypeNameCollisionStrategy`
+	TypeNameCollisions string `yaml:"type-name-collisions,omitempty"`
+
+	// UseSchemaTitles names the type generated for an inline object, map or enum with a `title` from the title, such as `PetOwner` for `title: Pet owner`, rather than from where it's defined in the spec, such as `Pet_Owner`. An inline object is then a named type, rather than an anonymous struct. When the title's name is already given to a component schema, or to an inline schema elsewhere, the type is named as it otherwise would be
+	UseSchemaTitles bool `yaml:"use-schema-titles,omitempty"`
+
+	// InlineTypeNames renames the types generated from inline schemas, such as `PostUsersIdJSONBody` for an inline request body. Each key is the name the type would otherwise be given, and its value is the name to use instead
+	InlineTypeNames map[string]string `yaml:"inline-type-names,omitempty"`
+
+	// PromoteInlineSchemas generates a named type for each inline object schema in a response, such as `PostUsersId200JSONResponseBody`, as is already done for request bodies, rather than an anonymous struct
+	PromoteInlineSchemas bool `yaml:"promote-inline-schemas,omitempty"`
+
+	// ShareInlineResponseTypes promotes inline object schemas in responses to named types, as with `promote-inline-schemas`, but generates a single type for any structurally identical schemas, rather than one per response. The shared type is named after the first response (in path and method order) which uses it, and can be renamed with `inline-type-names`
+	ShareInlineResponseTypes bool `yaml:"share-inline-response-types,omitempty"`
+
+	// StructTags adds further struct tags, such as `bson` or `db`, to every field of the generated structs, derived from each field's JSON name. A field's `x-oapi-codegen-extra-tags` take precedence
+	StructTags []StructTagProfile `yaml:"struct-tags,omitempty"`
+
+	// NamedMapTypes generates a named type for each inline dictionary schema, which only has `additionalProperties`, such as `Pet_Labels`, rather than using a `map[string]T` inline. This can be overridden for a schema with `x-oapi-codegen-additional-properties`
+	NamedMapTypes bool `yaml:"named-map-types,omitempty"`
+
+	// PreferMapForAdditionalProperties generates a `map[string]T` for objects which have both `properties` and `additionalProperties`, rather than a struct with an `AdditionalProperties` field. This can be overridden for a schema with `x-oapi-codegen-additional-properties`
+	PreferMapForAdditionalProperties bool `yaml:"prefer-map-for-additional-properties,omitempty"`
+
+	// StrictKeywords fails generation if the spec uses any JSON Schema keywords which the generated code doesn't represent, and would otherwise be silently ignored, such as `if`/`then`/`else`, `not`, `patternProperties` and `dependentSchemas`. Every use is reported, along with where it is in the spec
+	StrictKeywords bool `yaml:"strict-keywords,omitempty"`
+
+	// SourceComments adds a comment to each generated type and operation, such as `// source: api.yaml#/components/schemas/Pet (line 12)`, linking it to the part of the spec it was generated from, with a JSON Pointer, and the file and line when they're known
+	SourceComments bool `yaml:"source-comments,omitempty"`
+
+	// BuildTags is a build constraint expression, such as `!codeanalysis`, which is added to the generated code's `//go:build` line
+	BuildTags string `yaml:"build-tags,omitempty"`
+
+	// FailOnDeprecatedUsage splits the client's functions for deprecated operations out into a file alongside the output file, such as `api_deprecated.gen.go` for `api.gen.go`, which is excluded when building with the `no_deprecated` build tag, so that building with it fails wherever deprecated operations are used
+	FailOnDeprecatedUsage bool `yaml:"fail-on-deprecated-usage,omitempty"`
+
+	// GenerateCommand is the command to regenerate the code with, such as `go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen -config cfg.yaml api.yaml`, which is added to the generated code as a `//go:generate` directive
+	GenerateCommand string `yaml:"generate-command,omitempty"`
+
+	// OneFilePerType splits each of the types generated for the models, along with its methods and constants, out into a file of its own alongside the output file, such as `pet.gen.go` for the `Pet` type, so that changes to the spec only change the files of the affected types. Files for types which are no longer generated are removed
+	OneFilePerType bool `yaml:"one-file-per-type,omitempty"`
+
+	// ClientTagGroups groups the methods of the `ClientWithResponses` by the tags of their operations, so that an operation tagged `pets`, such as `FindPets`, can also be called as `client.Pets().Find(ctx, params)`
+	ClientTagGroups bool `yaml:"client-tag-groups,omitempty"`
+
+	// ClientOAuth2 generates a `WithOAuth2(ts oauth2.TokenSource)` client option, which authenticates the client's requests with tokens from golang.org/x/oauth2, along with the endpoint and a constant for each scope of each flow of the spec's `oauth2` security schemes, and a `clientcredentials.Config` and client option for each `clientCredentials` flow
+	ClientOAuth2 bool `yaml:"client-oauth2,omitempty"`
+
+	// ClientRetryPolicy generates a `WithRetryPolicy(policy RetryPolicy)` client option, which retries requests to the operations which can safely be repeated when they fail transiently, along with the `RetryPolicy` type and a `DefaultRetryableStatus` function
+	ClientRetryPolicy bool `yaml:"client-retry-policy,omitempty"`
+
+	// FileHeader is a Go template, such as a license banner, which is rendered at the top of the generated code as a comment. The template can use `{{.PackageName}}`, `{{.ModuleName}}` and `{{.Version}}`. Each line is commented out, unless the header is already written as a comment
+	FileHeader string `yaml:"file-header,omitempty"`
+
+	// JSONStringIntegers generates a property whose schema is a string with an integer format, such as `type: string, format: int64`, as the Go integer type, which is encoded as a JSON string with the `,string` option of its `json` tag, as if it was marked with `x-go-json-string`. A referenced schema, or one which is an enum, is still generated as a string
+	JSONStringIntegers bool `yaml:"json-string-integers,omitempty"`
+
+	// InferMapValueTypesFromExamples generates the values of an object's free-form `additionalProperties`, such as `additionalProperties: true`, as a concrete Go type, such as `map[string]string` rather than `map[string]interface{}`, when every additional property in the schema's examples has a value of the same type. The decision, either way, is logged and included in the `report`
+	InferMapValueTypesFromExamples bool `yaml:"infer-map-value-types-from-examples,omitempty"`
+
+	// EnumValueNames names the constants of some of the values of enums, as `x-enum-varnames` does, for specs which can't be changed. It's keyed by the path to the enum's schema, which is the name of the component schema followed by the names of any properties, separated by dots, such as `Pet.status`, and then by the enum value, such as `">=10"`, with the name of its constant
+	EnumValueNames map[string]map[string]string `yaml:"enum-value-names,omitempty"`
+
+	// ExtraSerializers adds a struct tag for each of the given serialisation formats to every field of the generated structs, naming the field the same as its JSON tag does, as `yaml-tags` does for YAML. Corresponds with the constants defined for `codegen.ExtraSerializer`. With `yaml`, the types which have custom JSON marshalling, such as unions and objects with `additionalProperties`, are also marshaled to and from YAML through their JSON representation
+	ExtraSerializers []string `yaml:"extra-serializers,omitempty"`
 }
 
 func (oo OutputOptions) Validate() map[string]string {
+	problems := make(map[string]string)
+
 	if NameNormalizerFunction(oo.NameNormalizer) != NameNormalizerFunctionToCamelCaseWithInitialisms && len(oo.AdditionalInitialisms) > 0 {
-		return map[string]string{
-			"additional-initialisms": "You have specified `additional-initialisms`, but the `name-normalizer` is not set to `ToCamelCaseWithInitialisms`. Please specify `name-normalizer: ToCamelCaseWithInitialisms` or remove the `additional-initialisms` configuration",
+		problems["additional-initialisms"] = "You have specified `additional-initialisms`, but the `name-normalizer` is not set to `ToCamelCaseWithInitialisms`. Please specify `name-normalizer: ToCamelCaseWithInitialisms` or remove the `additional-initialisms` configuration"
+	}
+
+	switch DuplicateOperationIdStrategy(oo.DuplicateOperationIds) {
+	case DuplicateOperationIdStrategyUnset, DuplicateOperationIdStrategyError, DuplicateOperationIdStrategyNumberedSuffix:
+	default:
+		problems["duplicate-operation-ids"] = fmt.Sprintf("Unknown strategy %q. Please specify one of `error` or `numbered-suffix`", oo.DuplicateOperationIds)
+	}
+
+	switch BasePathStrategy(oo.BasePathStrategy) {
+	case BasePathStrategyUnset, BasePathStrategyStrip, BasePathStrategyKeep, BasePathStrategyOption:
+	default:
+		problems["base-path-strategy"] = fmt.Sprintf("Unknown strategy %q. Please specify one of `strip`, `keep` or `option`", oo.BasePathStrategy)
+	}
+
+	if oo.BuildTags != "" {
+		if _, err := constraint.Parse("//go:build " + oo.BuildTags); err != nil {
+			problems["build-tags"] = fmt.Sprintf("The build constraint %q isn't valid: %v", oo.BuildTags, err)
+		}
+	}
+	if strings.ContainsAny(oo.GenerateCommand, "\r\n") {
+		problems["generate-command"] = "The `generate-command` must be a single line"
+	}
+	if oo.FileHeader != "" {
+		if _, err := template.New("file-header").Parse(oo.FileHeader); err != nil {
+			problems["file-header"] = fmt.Sprintf("The `file-header` isn't a valid template: %v", err)
 		}
 	}
 
-	return nil
+	if oo.FailOnDeprecatedUsage && (oo.SkipFmt || Formatter(oo.Formatter) == FormatterNone) {
+		problems["fail-on-deprecated-usage"] = "You have specified `fail-on-deprecated-usage`, which needs to format the code to fix the imports of each file, but have disabled formatting. Please remove `skip-fmt`, or set the `formatter`"
+	}
+
+	if oo.OneFilePerType && (oo.SkipFmt || Formatter(oo.Formatter) == FormatterNone) {
+		problems["one-file-per-type"] = "You have specified `one-file-per-type`, which needs to format the code to fix the imports of each file, but have disabled formatting. Please remove `skip-fmt`, or set the `formatter`"
+	}
+
+	switch Formatter(oo.Formatter) {
+	case FormatterUnset, FormatterGoimports, FormatterGofmt, FormatterGofumpt, FormatterNone:
+	default:
+		problems["formatter"] = fmt.Sprintf("Unknown formatter %q. Please specify one of `goimports`, `gofmt`, `gofumpt` or `none`", oo.Formatter)
+	}
+	if oo.SkipFmt && oo.Formatter != "" && Formatter(oo.Formatter) != FormatterNone {
+		problems["skip-fmt"] = fmt.Sprintf("You have specified `skip-fmt`, which skips formatting the code, along with the %q `formatter`. Please remove one of them", oo.Formatter)
+	}
+
+	switch TypeNameCollisionStrategy(oo.TypeNameCollisions) {
+	case TypeNameCollisionStrategyUnset, TypeNameCollisionStrategyError, TypeNameCollisionStrategyNumberedSuffix, TypeNameCollisionStrategyPathPrefixed:
+	default:
+		problems["type-name-collisions"] = fmt.Sprintf("Unknown strategy %q. Please specify one of `error`, `numbered-suffix` or `path-prefixed`", oo.TypeNameCollisions)
+	}
+
+	seenStructTags := make(map[string]bool)
+	for i, profile := range oo.StructTags {
+		key := fmt.Sprintf("struct-tags[%d]", i)
+		switch {
+		case profile.Name == "":
+			problems[key] = "The `name` of the struct tag must be specified"
+		case profile.Name == "json":
+			problems[key] = "The `json` struct tag is always generated, and can't be configured with `struct-tags`"
+		case seenStructTags[profile.Name]:
+			problems[key] = fmt.Sprintf("The struct tag %q has already been configured", profile.Name)
+		}
+		seenStructTags[profile.Name] = true
+
+		switch StructTagCasing(profile.Casing) {
+		case StructTagCasingUnset, StructTagCasingSnake, StructTagCasingCamel, StructTagCasingPascal, StructTagCasingKebab, StructTagCasingLower:
+		default:
+			problems[key+".casing"] = fmt.Sprintf("Unknown casing %q. Please specify one of `snake`, `camel`, `pascal`, `kebab` or `lower`", profile.Casing)
+		}
+	}
+
+	seenSerializers := make(map[string]bool)
+	for i, serializer := range oo.ExtraSerializers {
+		key := fmt.Sprintf("extra-serializers[%d]", i)
+		switch ExtraSerializer(serializer) {
+		case ExtraSerializerYAML, ExtraSerializerXML, ExtraSerializerMsgpack:
+			if seenSerializers[serializer] {
+				problems[key] = fmt.Sprintf("The serializer %q has already been configured", serializer)
+			}
+		default:
+			problems[key] = fmt.Sprintf("Unknown serializer %q. Please specify one of `yaml`, `xml` or `msgpack`", serializer)
+		}
+		seenSerializers[serializer] = true
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return problems
+}
+
+// StructTagProfile describes a struct tag which is added to every field of the generated structs
+type StructTagProfile struct {
+	// Name is the name of the struct tag, such as `bson` or `db`
+	Name string `yaml:"name"`
+	// Casing defines how the tag's value is derived from the field's JSON name. By default, the JSON name is used as-is. Corresponds with the constants defined for `codegen.StructTagCasing`
+	Casing string `yaml:"casing,omitempty"`
+	// OmitEmpty adds `,omitempty` to the tag whenever the field's JSON tag has it
+	OmitEmpty bool `yaml:"omitempty,omitempty"`
+}
+
+// RouteOptions configures how the operations' paths are normalized, with `routes`
+type RouteOptions struct {
+	// StripTrailingSlash removes the trailing slash of each path other than `/`, so that `/pets/` is registered, and requested, as `/pets`
+	StripTrailingSlash bool `yaml:"strip-trailing-slash,omitempty"`
+	// CaseSensitive is whether the server matches the paths of requests case-sensitively, which it does by default. If false, the literal parts of each path are lowercased, so that `/Pets/{petId}` is registered, and requested, as `/pets/{petId}`
+	CaseSensitive *bool `yaml:"case-sensitive,omitempty"`
+}
+
+// caseInsensitive reports whether the paths are lowercased, with `case-sensitive: false`
+func (ro RouteOptions) caseInsensitive() bool {
+	return ro.CaseSensitive != nil && !*ro.CaseSensitive
+}
+
+// normalizes reports whether any of the options change the operations' paths
+func (ro RouteOptions) normalizes() bool {
+	return ro.StripTrailingSlash || ro.caseInsensitive()
 }
 
 type OutputOptionsOverlay struct {
@@ -0,0 +1,250 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+// ConformanceTest is the request the conformance tests send for an operation, along with the responses the spec documents for it
+type ConformanceTest struct {
+	OperationID string
+	Method      string
+	// URL is the path of the request, with its path parameters filled in, along with its query
+	URL     string
+	Headers []ConformanceHeader
+	Cookies []ConformanceHeader
+	// ContentType is the content type of the Body, if there is one
+	ContentType string
+	Body        string
+	Responses   []ConformanceResponse
+	// Skip is the reason the operation can't be tested, if it can't
+	Skip string
+}
+
+// ConformanceHeader is a header, or cookie, sent with a request by the conformance tests
+type ConformanceHeader struct {
+	Name  string
+	Value string
+}
+
+// ConformanceResponse is a response the spec documents for an operation, which is a status code, a range such as `2XX`, or `default`, along with its content types
+type ConformanceResponse struct {
+	Status       string
+	ContentTypes []string
+}
+
+// GenerateConformanceTests generates `RunServerConformance`, as configured with the `conformance-tests` option, which sends a minimal valid request for each operation to a server's handler, and checks that the status code and content type of its response are documented in the spec
+func GenerateConformanceTests(t *template.Template, ops []OperationDefinition) (string, error) {
+	var tests []ConformanceTest
+	for _, op := range ops {
+		test, err := conformanceTest(op)
+		if err != nil {
+			return "", fmt.Errorf("error generating conformance test for %s: %w", op.OperationId, err)
+		}
+		tests = append(tests, test)
+	}
+	return GenerateTemplates([]string{"conformance.tmpl"}, t, tests)
+}
+
+// conformanceTest builds the request for an operation, with an example of each of its required parameters, and its body
+func conformanceTest(op OperationDefinition) (ConformanceTest, error) {
+	test := ConformanceTest{
+		OperationID: op.OperationId,
+		Method:      op.Method,
+	}
+
+	path := op.Path
+	for _, param := range op.PathParams {
+		value, err := conformanceParamValue(param)
+		if err != nil {
+			return test, err
+		}
+		path = strings.ReplaceAll(path, "{"+param.ParamName+"}", url.PathEscape(strings.Join(value, ",")))
+	}
+	query := url.Values{}
+	for _, param := range op.QueryParams {
+		if !param.Required {
+			continue
+		}
+		value, err := conformanceParamValue(param)
+		if err != nil {
+			return test, err
+		}
+		if param.Explode() {
+			query[param.ParamName] = value
+		} else {
+			query.Set(param.ParamName, strings.Join(value, ","))
+		}
+	}
+	test.URL = path
+	if len(query) > 0 {
+		test.URL += "?" + query.Encode()
+	}
+
+	for _, params := range []struct {
+		params []ParameterDefinition
+		to     *[]ConformanceHeader
+	}{{op.HeaderParams, &test.Headers}, {op.CookieParams, &test.Cookies}} {
+		for _, param := range params.params {
+			if !param.Required {
+				continue
+			}
+			value, err := conformanceParamValue(param)
+			if err != nil {
+				return test, err
+			}
+			*params.to = append(*params.to, ConformanceHeader{Name: param.ParamName, Value: strings.Join(value, ",")})
+		}
+	}
+
+	if err := conformanceBody(op, &test); err != nil {
+		return test, err
+	}
+
+	for _, response := range op.Responses {
+		r := ConformanceResponse{Status: response.StatusCode}
+		for _, content := range response.Contents {
+			r.ContentTypes = append(r.ContentTypes, content.ContentType)
+		}
+		test.Responses = append(test.Responses, r)
+	}
+	return test, nil
+}
+
+// conformanceBody sets the body of the request to an example of the operation's body, in the first content type which an example can be encoded as, or skips the operation when its body is required, but can't be encoded
+func conformanceBody(op OperationDefinition, test *ConformanceTest) error {
+	if op.Spec == nil || op.Spec.RequestBody == nil || op.Spec.RequestBody.Value == nil {
+		return nil
+	}
+	content := op.Spec.RequestBody.Value.Content
+	contentTypes := SortedMapKeys(content)
+	for _, contentType := range contentTypes {
+		mediaType := content[contentType]
+		value := conformanceMediaTypeExample(mediaType)
+		switch {
+		case contentType == "application/json" || strings.HasSuffix(contentType, "+json"):
+			body, err := json.Marshal(value)
+			if err != nil {
+				return fmt.Errorf("error encoding example body: %w", err)
+			}
+			test.ContentType, test.Body = contentType, string(body)
+			return nil
+		case contentType == "application/x-www-form-urlencoded":
+			object, ok := value.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			form := url.Values{}
+			for name, v := range object {
+				form[name] = conformanceValueStrings(v)
+			}
+			test.ContentType, test.Body = contentType, form.Encode()
+			return nil
+		case strings.HasPrefix(contentType, "text/"):
+			test.ContentType, test.Body = contentType, fmt.Sprint(value)
+			return nil
+		}
+	}
+	if op.BodyRequired {
+		test.Skip = fmt.Sprintf("an example body can't be encoded as any of %s", strings.Join(contentTypes, ", "))
+	}
+	return nil
+}
+
+// conformanceMediaTypeExample returns the media type's example, or its first named example, or otherwise an example of its schema
+func conformanceMediaTypeExample(mediaType *openapi.MediaType) interface{} {
+	if mediaType == nil || mediaType.MediaType == nil {
+		return nil
+	}
+	var value interface{}
+	if mediaType.Example != nil && mediaType.Example.Decode(&value) == nil {
+		return value
+	}
+	if value, ok := conformanceNamedExample(mediaType.Examples); ok {
+		return value
+	}
+	var schema *base.Schema
+	if mediaType.Schema != nil && mediaType.Schema.Value != nil {
+		schema = mediaType.Schema.Value.Schema
+	}
+	return exampleValue(schema, 0)
+}
+
+// conformanceNamedExample returns the value of the first of the named examples, by name, which has one
+func conformanceNamedExample(examples map[string]*openapi.ExampleRef) (interface{}, bool) {
+	for _, name := range SortedMapKeys(examples) {
+		example := examples[name]
+		if example == nil || example.Value == nil || example.Value.Example == nil || example.Value.Value == nil {
+			continue
+		}
+		var value interface{}
+		if example.Value.Value.Decode(&value) == nil {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// conformanceParamValue returns an example of the parameter, as the strings it's sent as, which are more than one for an array
+func conformanceParamValue(param ParameterDefinition) ([]string, error) {
+	if param.Spec == nil {
+		return []string{""}, nil
+	}
+	var value interface{}
+	if param.Spec.Example != nil && param.Spec.Example.Decode(&value) == nil {
+		return conformanceValueStrings(value), nil
+	}
+	if value, ok := conformanceNamedExample(param.Spec.Examples); ok {
+		return conformanceValueStrings(value), nil
+	}
+
+	if len(param.Spec.Content) > 0 {
+		// A parameter with a content type is sent encoded as JSON
+		value := conformanceMediaTypeExample(param.Spec.Content[SortedMapKeys(param.Spec.Content)[0]])
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding example of parameter %s: %w", param.ParamName, err)
+		}
+		return []string{string(encoded)}, nil
+	}
+
+	var schema *base.Schema
+	if param.Spec.Schema != nil && param.Spec.Schema.Value != nil {
+		schema = param.Spec.Schema.Value.Schema
+	}
+	return conformanceValueStrings(exampleValue(schema, 0)), nil
+}
+
+// conformanceValueStrings formats a value as a parameter, where an array is each of its items, and an object is each of its properties and their values, in order
+func conformanceValueStrings(value interface{}) []string {
+	switch value := value.(type) {
+	case []interface{}:
+		var values []string
+		for _, v := range value {
+			values = append(values, fmt.Sprint(v))
+		}
+		return values
+	case map[string]interface{}:
+		names := make([]string, 0, len(value))
+		for name := range value {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		var values []string
+		for _, name := range names {
+			values = append(values, name, fmt.Sprint(value[name]))
+		}
+		return values
+	case nil:
+		return []string{""}
+	}
+	return []string{fmt.Sprint(value)}
+}
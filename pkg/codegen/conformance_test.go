@@ -0,0 +1,167 @@
+package codegen
+
+import (
+	"go/importer"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+const conformanceSpec = `openapi: 3.0.0
+info:
+  title: conformance
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    get:
+      operationId: getPet
+      parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: integer
+          minimum: 10
+      - name: tags
+        in: query
+        required: true
+        schema:
+          type: array
+          items:
+            type: string
+            enum:
+            - a
+            - b
+      - name: X-Request-Id
+        in: header
+        required: true
+        schema:
+          type: string
+          format: uuid
+      - name: session
+        in: cookie
+        required: true
+        example: abc
+        schema:
+          type: string
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+        4XX:
+          description: error
+  /pets:
+    post:
+      operationId: addPet
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '201':
+          description: created
+        default:
+          description: err
+          content:
+            application/problem+json:
+              schema:
+                type: object
+  /upload:
+    post:
+      operationId: upload
+      requestBody:
+        required: true
+        content:
+          multipart/form-data:
+            schema:
+              type: object
+              properties:
+                f:
+                  type: string
+                  format: binary
+      responses:
+        '204':
+          description: ok
+components:
+  schemas:
+    Pet:
+      type: object
+      required:
+      - name
+      - kind
+      - age
+      properties:
+        name:
+          type: string
+          minLength: 3
+        kind:
+          type: string
+          enum:
+          - cat
+          - dog
+        age:
+          type: number
+          exclusiveMinimum: true
+          minimum: 2
+          multipleOf: 0.5
+        tag:
+          type: string
+`
+
+// conformanceUsage runs the conformance tests against a handler, so that type checking it alongside the generated code ensures the tests compile
+const conformanceUsage = `package api
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestPetstore(t *testing.T) {
+	RunServerConformance(t, http.NotFoundHandler(), func(req *http.Request) {
+		req.Header.Set("Authorization", "Bearer token")
+	})
+}
+`
+
+func TestConformanceTests(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(conformanceSpec))
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:           true,
+			ConformanceTests: true,
+		},
+	})
+	require.NoError(t, err)
+
+	t.Run("requests", func(t *testing.T) {
+		// The required parameters are sent with examples which meet their constraints
+		assert.Contains(t, code, "url:         \"/pets/10?tags=a\",")
+		assert.Contains(t, code, "\"X-Request-Id\": \"00000000-0000-0000-0000-000000000000\",")
+		assert.Contains(t, code, "\"session\": \"abc\",")
+		// Only the required properties of the body
+		assert.Contains(t, code, "body:        \"{\\\"age\\\":2.5,\\\"kind\\\":\\\"cat\\\",\\\"name\\\":\\\"aaa\\\"}\",")
+		assert.Contains(t, code, "skip: \"an example body can't be encoded as any of multipart/form-data\",")
+	})
+
+	t.Run("responses", func(t *testing.T) {
+		assert.Contains(t, code, "{status: \"200\", contentTypes: []string{\"application/json\"}},\n\t\t\t\t{status: \"4XX\"},")
+		assert.Contains(t, code, "{status: \"default\", contentTypes: []string{\"application/problem+json\"}},")
+	})
+
+	t.Run("compiles", func(t *testing.T) {
+		fset := token.NewFileSet()
+		typeCheck(t, fset, importer.ForCompiler(fset, "source", nil), code, conformanceUsage)
+	})
+}
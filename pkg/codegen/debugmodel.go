@@ -0,0 +1,298 @@
+package codegen
+
+import (
+	"sort"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+// DebugModel is the intermediate model which the code for a spec is generated from: the Go types chosen for its schemas and operations, along with the decisions made while choosing them, such as renaming a type to resolve a collision. It's intended to explain why a particular Go construct is generated, without reading the generator's source, so is serialised as JSON, and its structure may change between versions
+type DebugModel struct {
+	// Types are the named Go types generated for the spec, by name
+	Types []DebugModelType `json:"types"`
+	// Operations are the operations which code is generated for
+	Operations []DebugModelOperation `json:"operations"`
+	// Renamed are the types, operations, enum constants and fields which are given a different name to the one they'd otherwise have
+	Renamed []ReportRename `json:"renamed"`
+	// Downgraded are the schemas which are generated as a less specific Go type than they describe
+	Downgraded []ReportIssue `json:"downgraded"`
+	// Inferred are the schemas whose Go type is inferred from their examples, with `infer-map-value-types-from-examples`
+	Inferred []ReportIssue `json:"inferred"`
+}
+
+// DebugModelType is a named Go type which is generated
+type DebugModelType struct {
+	Name string `json:"name"`
+	// JSONName is the name of the part of the spec the type is generated for, such as the name of a component schema, or the path to an inline schema
+	JSONName string `json:"jsonName"`
+	// Source is a JSON Pointer to the part of the spec the type was generated from, such as `#/components/schemas/Pet`, if known
+	Source string `json:"source,omitempty"`
+	// Alias is whether the type is declared as an alias, such as `type Pets = []Pet`, rather than as a type of its own
+	Alias bool `json:"alias"`
+	// EnumConstants are the constants generated for an enum, mapped to their values
+	EnumConstants map[string]string `json:"enumConstants,omitempty"`
+	Schema        DebugModelSchema  `json:"schema"`
+}
+
+// DebugModelSchema is the Go type chosen for a schema
+type DebugModelSchema struct {
+	// GoType is the Go type the schema is generated as, on a single line, which is the name of the type it refers to for a reference
+	GoType string `json:"goType"`
+	// Ref is the name of the type the schema refers to, such as for a `$ref`, or for an inline schema which is given a named type
+	Ref string `json:"ref,omitempty"`
+	// Position is where the schema is in the spec, as `file:line:column`, if known
+	Position    string `json:"position,omitempty"`
+	Description string `json:"description,omitempty"`
+	// SkipOptionalPointer is whether the type is used without a pointer when it's optional
+	SkipOptionalPointer bool `json:"skipOptionalPointer,omitempty"`
+	// Items is the Go type of the elements of an array
+	Items *DebugModelSchema `json:"items,omitempty"`
+	// Properties are the fields of a struct
+	Properties []DebugModelProperty `json:"properties,omitempty"`
+	// Embeds are the types embedded in a struct, for an `allOf`
+	Embeds []string `json:"embeds,omitempty"`
+	// AdditionalProperties is the Go type of the values of the object's additional properties, if it allows them
+	AdditionalProperties *DebugModelSchema `json:"additionalProperties,omitempty"`
+	// Enum maps the names the values of an enum are given, before they're prefixed with the name of its type, to the values
+	Enum map[string]string `json:"enum,omitempty"`
+	// Union are the Go types a union may hold
+	Union []string `json:"union,omitempty"`
+	// Discriminator is the JSON property which determines which of the variants a union holds, if it has one
+	Discriminator *TypeManifestDiscriminator `json:"discriminator,omitempty"`
+	// AdditionalTypes are the names of the types generated for the schema's inline schemas
+	AdditionalTypes []string `json:"additionalTypes,omitempty"`
+}
+
+// DebugModelProperty is a field of a struct, and the property of the JSON object it maps to
+type DebugModelProperty struct {
+	// Name is the name of the Go field
+	Name string `json:"name"`
+	// JSONName is the name of the JSON property
+	JSONName string `json:"jsonName"`
+	// GoType is the Go type of the field, including a pointer for an optional field
+	GoType    string `json:"goType"`
+	Required  bool   `json:"required"`
+	Nullable  bool   `json:"nullable"`
+	ReadOnly  bool   `json:"readOnly,omitempty"`
+	WriteOnly bool   `json:"writeOnly,omitempty"`
+	// Recursive is whether the field refers back to a type which contains it, so is always a pointer
+	Recursive bool `json:"recursive,omitempty"`
+	// Embedded is whether the field is the type it refers to, embedded anonymously, with x-go-embed
+	Embedded bool `json:"embedded,omitempty"`
+	// JSONString is whether the field's number or boolean is encoded as a JSON string, with x-go-json-string
+	JSONString bool             `json:"jsonString,omitempty"`
+	Schema     DebugModelSchema `json:"schema"`
+}
+
+// DebugModelOperation is an operation which code is generated for
+type DebugModelOperation struct {
+	OperationID string `json:"operationId"`
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+	// Parameters are the operation's parameters, in the path, query, headers and cookies, in that order
+	Parameters []DebugModelParameter `json:"parameters,omitempty"`
+	// Bodies are the request bodies the operation accepts, by content type
+	Bodies    []DebugModelBody     `json:"bodies,omitempty"`
+	Responses []DebugModelResponse `json:"responses,omitempty"`
+	// Types are the names of the types generated for the operation, such as for its parameters and inline bodies
+	Types []string `json:"types,omitempty"`
+}
+
+// DebugModelParameter is a parameter of an operation
+type DebugModelParameter struct {
+	Name     string           `json:"name"`
+	In       string           `json:"in"`
+	Required bool             `json:"required"`
+	Schema   DebugModelSchema `json:"schema"`
+}
+
+// DebugModelBody is a request body, or the content of a response, of a particular content type
+type DebugModelBody struct {
+	ContentType string `json:"contentType"`
+	// NameTag is what the names of the types generated for the body are tagged with, such as `JSON`, which is empty when code isn't generated for the content type
+	NameTag  string           `json:"nameTag,omitempty"`
+	Required bool             `json:"required,omitempty"`
+	Schema   DebugModelSchema `json:"schema"`
+}
+
+// DebugModelResponse is a response of an operation
+type DebugModelResponse struct {
+	StatusCode string `json:"statusCode"`
+	// Ref is the name of the component response the response refers to, if it does
+	Ref      string           `json:"ref,omitempty"`
+	Contents []DebugModelBody `json:"contents,omitempty"`
+}
+
+// GenerateDebugModel generates the code for a spec, as Generate does, but rather than returning the code, returns the DebugModel the code is generated from
+func GenerateDebugModel(spec *openapi.T, opts Configuration) (*DebugModel, error) {
+	model := &DebugModel{
+		Types:      []DebugModelType{},
+		Operations: []DebugModelOperation{},
+	}
+	report := &Report{
+		Renamed:    []ReportRename{},
+		Downgraded: []ReportIssue{},
+		Inferred:   []ReportIssue{},
+	}
+
+	globalState.debugModel = model
+	globalState.report = report
+	defer func() {
+		globalState.debugModel = nil
+		globalState.report = nil
+	}()
+
+	if _, err := Generate(spec, opts); err != nil {
+		return nil, err
+	}
+
+	model.Renamed = report.Renamed
+	model.Downgraded = report.Downgraded
+	model.Inferred = report.Inferred
+	sort.SliceStable(model.Types, func(i, j int) bool {
+		return model.Types[i].Name < model.Types[j].Name
+	})
+	return model, nil
+}
+
+// recordDebugModelOperations records the operations which code is generated for, when generating a DebugModel
+func recordDebugModelOperations(ops []OperationDefinition) {
+	if globalState.debugModel == nil {
+		return
+	}
+	for _, op := range ops {
+		operation := DebugModelOperation{
+			OperationID: op.OperationId,
+			Method:      op.Method,
+			Path:        op.Path,
+		}
+		for _, params := range [][]ParameterDefinition{op.PathParams, op.QueryParams, op.HeaderParams, op.CookieParams} {
+			for _, param := range params {
+				operation.Parameters = append(operation.Parameters, DebugModelParameter{
+					Name:     param.ParamName,
+					In:       param.In,
+					Required: param.Required,
+					Schema:   newDebugModelSchema(param.Schema),
+				})
+			}
+		}
+		for _, body := range op.Bodies {
+			operation.Bodies = append(operation.Bodies, DebugModelBody{
+				ContentType: body.ContentType,
+				NameTag:     body.NameTag,
+				Required:    body.Required,
+				Schema:      newDebugModelSchema(body.Schema),
+			})
+		}
+		for _, response := range op.Responses {
+			r := DebugModelResponse{
+				StatusCode: response.StatusCode,
+				Ref:        response.Ref,
+			}
+			for _, content := range response.Contents {
+				r.Contents = append(r.Contents, DebugModelBody{
+					ContentType: content.ContentType,
+					NameTag:     content.NameTag,
+					Schema:      newDebugModelSchema(content.Schema),
+				})
+			}
+			operation.Responses = append(operation.Responses, r)
+		}
+		for _, td := range op.TypeDefinitions {
+			operation.Types = append(operation.Types, td.TypeName)
+		}
+		globalState.debugModel.Operations = append(globalState.debugModel.Operations, operation)
+	}
+}
+
+// recordDebugModelTypes records the types which are generated, including those generated for their inline schemas, when generating a DebugModel
+func recordDebugModelTypes(typeDefs []TypeDefinition) {
+	if globalState.debugModel == nil {
+		return
+	}
+	seen := make(map[string]bool, len(globalState.debugModel.Types))
+	for _, t := range globalState.debugModel.Types {
+		seen[t.Name] = true
+	}
+	var record func(typeDefs []TypeDefinition)
+	record = func(typeDefs []TypeDefinition) {
+		for _, td := range typeDefs {
+			if seen[td.TypeName] {
+				continue
+			}
+			seen[td.TypeName] = true
+			globalState.debugModel.Types = append(globalState.debugModel.Types, DebugModelType{
+				Name:     td.TypeName,
+				JSONName: td.JsonName,
+				Source:   globalState.sources[td.TypeName].Pointer,
+				Alias:    td.IsAlias(),
+				Schema:   newDebugModelSchema(td.Schema),
+			})
+			record(td.Schema.AdditionalTypes)
+		}
+	}
+	record(typeDefs)
+}
+
+// recordDebugModelEnums records the constants generated for each enum, when generating a DebugModel
+func recordDebugModelEnums(enums []EnumDefinition) {
+	if globalState.debugModel == nil {
+		return
+	}
+	for _, enum := range enums {
+		for i := range globalState.debugModel.Types {
+			if globalState.debugModel.Types[i].Name == enum.TypeName {
+				globalState.debugModel.Types[i].EnumConstants = enum.GetValues()
+			}
+		}
+	}
+}
+
+// newDebugModelSchema describes the Go type chosen for a schema
+func newDebugModelSchema(s Schema) DebugModelSchema {
+	d := DebugModelSchema{
+		GoType:              typeManifestGoType(s.TypeDecl()),
+		Ref:                 s.RefType,
+		Position:            s.OAPISchema.Position().String(),
+		Description:         s.Description,
+		SkipOptionalPointer: s.SkipOptionalPointer,
+		Embeds:              s.EmbeddedTypes,
+		Enum:                s.EnumValues,
+	}
+	if s.ArrayType != nil {
+		items := newDebugModelSchema(*s.ArrayType)
+		d.Items = &items
+	}
+	for _, p := range s.Properties {
+		d.Properties = append(d.Properties, DebugModelProperty{
+			Name:       p.GoFieldName(),
+			JSONName:   p.JsonFieldName,
+			GoType:     typeManifestGoType(p.GoTypeDef()),
+			Required:   p.Required,
+			Nullable:   p.Nullable,
+			ReadOnly:   p.ReadOnly,
+			WriteOnly:  p.WriteOnly,
+			Recursive:  p.Recursive,
+			Embedded:   p.Embedded,
+			JSONString: p.JSONString,
+			Schema:     newDebugModelSchema(p.Schema),
+		})
+	}
+	if s.HasAdditionalProperties {
+		additional := newDebugModelSchema(mapValueSchema(s))
+		d.AdditionalProperties = &additional
+	}
+	for _, element := range s.UnionElements {
+		d.Union = append(d.Union, element.String())
+	}
+	if s.Discriminator != nil {
+		d.Discriminator = &TypeManifestDiscriminator{
+			Property: s.Discriminator.Property,
+			Mapping:  s.Discriminator.Mapping,
+		}
+	}
+	for _, td := range s.AdditionalTypes {
+		d.AdditionalTypes = append(d.AdditionalTypes, td.TypeName)
+	}
+	return d
+}
@@ -0,0 +1,100 @@
+package codegen
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+func TestGenerateDebugModel(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(`openapi: 3.0.0
+info:
+  title: debug-model
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    get:
+      operationId: getPet
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: integer
+          format: int64
+        tags:
+          type: array
+          items:
+            type: string
+        status:
+          type: string
+          enum: [available, sold]
+    Animal:
+      oneOf:
+        - $ref: '#/components/schemas/Pet'
+        - type: string
+`))
+	require.NoError(t, err)
+
+	model, err := GenerateDebugModel(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+			Client: true,
+		},
+	})
+	require.NoError(t, err)
+
+	types := make(map[string]DebugModelType)
+	for _, typ := range model.Types {
+		types[typ.Name] = typ
+	}
+	require.Contains(t, types, "Animal")
+	assert.Equal(t, []string{"Pet", "Animal1"}, types["Animal"].Schema.Union)
+	assert.Equal(t, []string{"Animal1"}, types["Animal"].Schema.AdditionalTypes)
+
+	require.Contains(t, types, "Animal1")
+	assert.True(t, types["Animal1"].Alias)
+	assert.Equal(t, "string", types["Animal1"].Schema.GoType)
+
+	require.Contains(t, types, "PetStatus")
+	assert.Equal(t, "Pet.Status", types["PetStatus"].JSONName)
+	assert.Equal(t, map[string]string{"Available": "available", "Sold": "sold"}, types["PetStatus"].EnumConstants)
+
+	encoded, err := json.Marshal(types["Pet"].Schema.Properties)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"name": "Id", "jsonName": "id", "goType": "int64", "required": true, "nullable": false, "schema": {"goType": "int64", "position": "29:11"}},
+		{"name": "Status", "jsonName": "status", "goType": "*PetStatus", "required": false, "nullable": false, "schema": {"goType": "PetStatus", "ref": "PetStatus", "position": "36:11", "enum": {"Available": "available", "Sold": "sold"}, "additionalTypes": ["PetStatus"]}},
+		{"name": "Tags", "jsonName": "tags", "goType": "*[]string", "required": false, "nullable": false, "schema": {"goType": "[]string", "position": "32:11", "items": {"goType": "string", "position": "34:13"}}}
+	]`, string(encoded))
+
+	require.Len(t, model.Operations, 1)
+	op := model.Operations[0]
+	assert.Equal(t, "GetPet", op.OperationID)
+	require.Len(t, op.Parameters, 1)
+	assert.Equal(t, DebugModelParameter{Name: "id", In: "path", Required: true, Schema: DebugModelSchema{GoType: "int", Position: "14:13"}}, op.Parameters[0])
+	require.Len(t, op.Responses, 1)
+	require.Len(t, op.Responses[0].Contents, 1)
+	assert.Equal(t, "Pet", op.Responses[0].Contents[0].Schema.Ref)
+	assert.Empty(t, model.Renamed)
+}
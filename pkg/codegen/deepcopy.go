@@ -0,0 +1,437 @@
+package codegen
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+// copyKind describes how a value is deep copied and compared by the generated `DeepCopy` and `Equal` methods
+type copyKind int
+
+const (
+	// copyKindValue is a value which is copied by assignment, and compared with `==`
+	copyKindValue copyKind = iota
+	// copyKindTime is a `time.Time`, which is copied by assignment, and compared with its `Equal` method
+	copyKindTime
+	// copyKindOpaque is a type we know nothing about, such as `interface{}` or a type from another package, which is copied by assignment, and compared with `reflect.DeepEqual`
+	copyKindOpaque
+	// copyKindMethods is a generated type, which has its own `DeepCopy` and `Equal` methods
+	copyKindMethods
+	// copyKindBytes is a `[]byte`, or `json.RawMessage`, such as the data inside a union
+	copyKindBytes
+	copyKindSlice
+	copyKindMap
+	copyKindStruct
+)
+
+// comparableGoTypes are the Go types generated for schemas which can be compared with `==`
+var comparableGoTypes = map[string]bool{
+	"string": true, "bool": true,
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+	"float32": true, "float64": true,
+	"openapi_types.UUID": true, "openapi_types.Email": true,
+}
+
+// copyField is a field of a struct, which may be wrapped in a pointer, or a `nullable.Nullable`
+type copyField struct {
+	Name     string
+	Schema   Schema
+	Pointer  bool
+	Nullable string
+}
+
+// CopyAndEqualTypeDefinition is a type which has `DeepCopy` and `Equal` methods generated for it
+type CopyAndEqualTypeDefinition struct {
+	TypeName string
+	// DeepCopy is the body of the `DeepCopy` method, if it is to be generated
+	DeepCopy string
+	// Equal is the body of the `Equal` method, if it is to be generated
+	Equal string
+}
+
+// copyAndEqualGenerator generates the bodies of the `DeepCopy` and `Equal` methods, by walking the schemas of each type
+type copyAndEqualGenerator struct {
+	// methods are the types which get `DeepCopy` and `Equal` methods
+	methods map[string]bool
+	// aliases are the schemas of the types which are defined as an alias, which don't get methods of their own
+	aliases map[string]Schema
+}
+
+// GenerateCopyAndEqualBoilerplate generates `DeepCopy` and `Equal` methods, as configured with the `deepcopy` and `equal` options, for each of the types which isn't defined as an alias.
+func GenerateCopyAndEqualBoilerplate(t *template.Template, typeDefs []TypeDefinition) (string, error) {
+	opts := globalState.options.Generate
+	if !opts.DeepCopy && !opts.Equal {
+		return "", nil
+	}
+
+	g := copyAndEqualGenerator{
+		methods: make(map[string]bool),
+		aliases: make(map[string]Schema),
+	}
+	var filteredTypes []TypeDefinition
+	for _, td := range typeDefs {
+		if g.methods[td.TypeName] {
+			continue
+		}
+		if _, found := g.aliases[td.TypeName]; found {
+			continue
+		}
+		if td.IsAlias() {
+			g.aliases[td.TypeName] = td.Schema
+			continue
+		}
+		g.methods[td.TypeName] = true
+		filteredTypes = append(filteredTypes, td)
+	}
+
+	var types []CopyAndEqualTypeDefinition
+	for _, td := range filteredTypes {
+		fields := structFields(td.Schema)
+		if opts.DeepCopy && slices.ContainsFunc(fields, func(f copyField) bool { return f.Name == "DeepCopy" }) {
+			return "", fmt.Errorf("%s has a field named DeepCopy, which is the name of the generated method. Please rename the field with `x-go-name`, or disable `deepcopy`", td.TypeName)
+		}
+		if opts.Equal && slices.ContainsFunc(fields, func(f copyField) bool { return f.Name == "Equal" }) {
+			return "", fmt.Errorf("%s has a field named Equal, which is the name of the generated method. Please rename the field with `x-go-name`, or disable `equal`", td.TypeName)
+		}
+
+		def := CopyAndEqualTypeDefinition{TypeName: td.TypeName}
+		if opts.DeepCopy {
+			def.DeepCopy = strings.Join(g.deepCopyMethod(td), "\n")
+		}
+		if opts.Equal {
+			def.Equal = strings.Join(g.equalMethod(td), "\n")
+		}
+		types = append(types, def)
+	}
+
+	if len(types) == 0 {
+		return "", nil
+	}
+
+	context := struct {
+		Types []CopyAndEqualTypeDefinition
+	}{
+		Types: types,
+	}
+
+	return GenerateTemplates([]string{"deepcopy.tmpl"}, t, context)
+}
+
+// classify returns how a value of the schema's type is copied and compared, along with the schema that describes it, following any aliases
+func (g *copyAndEqualGenerator) classify(s Schema) (copyKind, Schema) {
+	seen := make(map[string]bool)
+	for {
+		name := s.TypeDecl()
+		if g.methods[name] {
+			return copyKindMethods, s
+		}
+		alias, found := g.aliases[name]
+		if !found || seen[name] {
+			break
+		}
+		seen[name] = true
+		s = alias
+	}
+
+	switch {
+	case s.RefType != "":
+		// A type which isn't generated here
+		return copyKindOpaque, s
+	case s.GoType == "[]byte" || s.GoType == "json.RawMessage":
+		return copyKindBytes, s
+	case s.ArrayType != nil && strings.HasPrefix(s.GoType, "[]"):
+		return copyKindSlice, s
+	case strings.HasPrefix(s.GoType, "map["):
+		return copyKindMap, s
+	case strings.HasPrefix(s.GoType, "struct"):
+		return copyKindStruct, s
+	case comparableGoTypes[s.GoType]:
+		return copyKindValue, s
+	case s.GoType == "time.Time":
+		return copyKindTime, s
+	default:
+		return copyKindOpaque, s
+	}
+}
+
+// structFields returns the fields of the struct generated for the schema, as laid out by GenStructFromSchema
+func structFields(s Schema) []copyField {
+	var fields []copyField
+	for _, embeddedType := range s.EmbeddedTypes {
+		name := strings.TrimPrefix(embeddedType, "*")
+		if i := strings.LastIndex(name, "."); i != -1 {
+			name = name[i+1:]
+		}
+		fields = append(fields, copyField{
+			Name:    name,
+			Schema:  Schema{GoType: embeddedType, RefType: embeddedType},
+			Pointer: strings.HasPrefix(embeddedType, "*"),
+		})
+	}
+
+	for _, p := range s.Properties {
+		// The same as GenFieldsFromProperties, which may skip the optional pointer
+		if extension, ok := p.Extensions[extPropGoTypeSkipOptionalPointer]; ok {
+			if skipOptionalPointer, err := extParsePropGoTypeSkipOptionalPointer(extension); err == nil {
+				p.Schema.SkipOptionalPointer = skipOptionalPointer
+			}
+		}
+
+		field := copyField{
+			Name:   p.GoFieldName(),
+			Schema: p.Schema,
+		}
+		typeDef := p.GoTypeDef()
+		if strings.HasPrefix(typeDef, "nullable.Nullable[") {
+			field.Nullable = typeDef
+		} else if typeDef != p.Schema.TypeDecl() && strings.HasPrefix(typeDef, "*") {
+			field.Pointer = true
+		}
+		fields = append(fields, field)
+	}
+
+	if s.HasAdditionalProperties && s.AdditionalPropertiesType != nil {
+		fields = append(fields, copyField{
+			Name: "AdditionalProperties",
+			Schema: Schema{
+				GoType:                   "map[" + s.AdditionalPropertiesKeyType() + "]" + additionalPropertiesType(s),
+				AdditionalPropertiesType: s.AdditionalPropertiesType,
+			},
+		})
+	}
+
+	if len(s.UnionElements) != 0 {
+		fields = append(fields, copyField{
+			Name:   "union",
+			Schema: Schema{GoType: "json.RawMessage"},
+		})
+	}
+	return fields
+}
+
+// mapValueSchema returns the schema of the values of a map, which is `interface{}` for a free-form object
+func mapValueSchema(s Schema) Schema {
+	if s.AdditionalPropertiesType != nil {
+		return *s.AdditionalPropertiesType
+	}
+	return Schema{GoType: "interface{}"}
+}
+
+// operand wraps a dereferenced pointer in parentheses, so that it can be indexed, or have a field or method selected
+func operand(x string) string {
+	if strings.HasPrefix(x, "*") {
+		return "(" + x + ")"
+	}
+	return x
+}
+
+func (g *copyAndEqualGenerator) deepCopyMethod(td TypeDefinition) []string {
+	kind, s := g.classify(td.Schema)
+	switch kind {
+	case copyKindMethods:
+		underlying := s.TypeDecl()
+		if underlying == td.TypeName {
+			return []string{"return t"}
+		}
+		return []string{fmt.Sprintf("return %s(%s(t).DeepCopy())", td.TypeName, underlying)}
+	case copyKindBytes, copyKindSlice, copyKindMap, copyKindStruct:
+		stmts := g.deepCopyValue("out", td.Schema, 0)
+		if len(stmts) == 0 {
+			// Nothing is shared with the original
+			return []string{"return t"}
+		}
+		stmts = append([]string{"out := t"}, stmts...)
+		return append(stmts, "return out")
+	default:
+		return []string{"return t"}
+	}
+}
+
+// deepCopyField returns the statements which replace anything the field x shares with the value it was copied from with a copy of its own
+func (g *copyAndEqualGenerator) deepCopyField(x string, f copyField, depth int) []string {
+	switch {
+	case f.Nullable != "":
+		return g.deepCopyMap(x, f.Nullable, f.Schema, depth)
+	case f.Pointer:
+		v := fmt.Sprintf("v%d", depth)
+		stmts := []string{
+			fmt.Sprintf("if %s != nil {", x),
+			fmt.Sprintf("%s := *%s", v, x),
+		}
+		stmts = append(stmts, g.deepCopyValue(v, f.Schema, depth+1)...)
+		return append(stmts, fmt.Sprintf("%s = &%s", x, v), "}")
+	default:
+		return g.deepCopyValue(x, f.Schema, depth)
+	}
+}
+
+// deepCopyValue returns the statements which replace anything x shares with the value it was copied from with a copy of its own
+func (g *copyAndEqualGenerator) deepCopyValue(x string, s Schema, depth int) []string {
+	kind, s := g.classify(s)
+	switch kind {
+	case copyKindMethods:
+		return []string{fmt.Sprintf("%s = %s.DeepCopy()", x, x)}
+	case copyKindBytes:
+		return []string{
+			fmt.Sprintf("if %s != nil {", x),
+			fmt.Sprintf("%s = append([]byte(nil), %s...)", x, x),
+			"}",
+		}
+	case copyKindSlice:
+		c := fmt.Sprintf("s%d", depth)
+		i := fmt.Sprintf("i%d", depth)
+		elemStmts := g.deepCopyValue(c+"["+i+"]", *s.ArrayType, depth+1)
+		stmts := []string{
+			fmt.Sprintf("if %s != nil {", x),
+			fmt.Sprintf("%s := make(%s, len(%s))", c, s.GoType, x),
+			fmt.Sprintf("copy(%s, %s)", c, x),
+		}
+		if len(elemStmts) != 0 {
+			stmts = append(stmts, fmt.Sprintf("for %s := range %s {", i, c))
+			stmts = append(stmts, elemStmts...)
+			stmts = append(stmts, "}")
+		}
+		return append(stmts, fmt.Sprintf("%s = %s", x, c), "}")
+	case copyKindMap:
+		return g.deepCopyMap(x, s.GoType, mapValueSchema(s), depth)
+	case copyKindStruct:
+		var stmts []string
+		for _, f := range structFields(s) {
+			stmts = append(stmts, g.deepCopyField(x+"."+f.Name, f, depth)...)
+		}
+		return stmts
+	default:
+		return nil
+	}
+}
+
+// deepCopyMap returns the statements which replace the map x, of type mapType, with a copy of its own
+func (g *copyAndEqualGenerator) deepCopyMap(x, mapType string, value Schema, depth int) []string {
+	m := fmt.Sprintf("m%d", depth)
+	k := fmt.Sprintf("k%d", depth)
+	v := fmt.Sprintf("v%d", depth)
+	stmts := []string{
+		fmt.Sprintf("if %s != nil {", x),
+		fmt.Sprintf("%s := make(%s, len(%s))", m, mapType, x),
+		fmt.Sprintf("for %s, %s := range %s {", k, v, x),
+	}
+	stmts = append(stmts, g.deepCopyValue(v, value, depth+1)...)
+	return append(stmts,
+		fmt.Sprintf("%s[%s] = %s", m, k, v),
+		"}",
+		fmt.Sprintf("%s = %s", x, m),
+		"}",
+	)
+}
+
+func (g *copyAndEqualGenerator) equalMethod(td TypeDefinition) []string {
+	kind, s := g.classify(td.Schema)
+	switch kind {
+	case copyKindMethods:
+		underlying := s.TypeDecl()
+		if underlying == td.TypeName {
+			return []string{"return reflect.DeepEqual(t, other)"}
+		}
+		return []string{fmt.Sprintf("return %s(t).Equal(%s(other))", underlying, underlying)}
+	case copyKindValue:
+		return []string{"return t == other"}
+	case copyKindTime:
+		return []string{"return time.Time(t).Equal(time.Time(other))"}
+	case copyKindBytes, copyKindSlice, copyKindMap, copyKindStruct:
+		stmts := g.equalValue("t", "other", td.Schema, 0)
+		return append(stmts, "return true")
+	default:
+		return []string{"return reflect.DeepEqual(t, other)"}
+	}
+}
+
+// equalField returns the statements which return false when the fields a and b differ
+func (g *copyAndEqualGenerator) equalField(a, b string, f copyField, depth int) []string {
+	switch {
+	case f.Nullable != "":
+		return g.equalMap(a, b, f.Schema, depth)
+	case f.Pointer:
+		stmts := []string{
+			fmt.Sprintf("if (%s == nil) != (%s == nil) {", a, b),
+			"return false",
+			"}",
+			fmt.Sprintf("if %s != nil {", a),
+		}
+		switch kind, _ := g.classify(f.Schema); kind {
+		case copyKindSlice, copyKindMap, copyKindStruct:
+			// Compare the values pointed to, rather than dereferencing the pointers over and over again
+			va, vb := fmt.Sprintf("a%d", depth), fmt.Sprintf("b%d", depth)
+			stmts = append(stmts, fmt.Sprintf("%s, %s := *%s, *%s", va, vb, a, b))
+			stmts = append(stmts, g.equalValue(va, vb, f.Schema, depth+1)...)
+		default:
+			stmts = append(stmts, g.equalValue("*"+a, "*"+b, f.Schema, depth)...)
+		}
+		return append(stmts, "}")
+	default:
+		return g.equalValue(a, b, f.Schema, depth)
+	}
+}
+
+// equalValue returns the statements which return false when a and b differ
+func (g *copyAndEqualGenerator) equalValue(a, b string, s Schema, depth int) []string {
+	var cond string
+	kind, s := g.classify(s)
+	switch kind {
+	case copyKindValue:
+		cond = fmt.Sprintf("%s != %s", a, b)
+	case copyKindTime:
+		cond = fmt.Sprintf("!%s.Equal(%s)", operand(a), b)
+	case copyKindMethods:
+		cond = fmt.Sprintf("!%s.Equal(%s)", operand(a), b)
+	case copyKindBytes:
+		cond = fmt.Sprintf("!bytes.Equal(%s, %s)", a, b)
+	case copyKindSlice:
+		i := fmt.Sprintf("i%d", depth)
+		stmts := []string{
+			fmt.Sprintf("if len(%s) != len(%s) || (%s == nil) != (%s == nil) {", a, b, a, b),
+			"return false",
+			"}",
+			fmt.Sprintf("for %s := range %s {", i, a),
+		}
+		stmts = append(stmts, g.equalValue(operand(a)+"["+i+"]", operand(b)+"["+i+"]", *s.ArrayType, depth+1)...)
+		return append(stmts, "}")
+	case copyKindMap:
+		return g.equalMap(a, b, mapValueSchema(s), depth)
+	case copyKindStruct:
+		var stmts []string
+		for _, f := range structFields(s) {
+			stmts = append(stmts, g.equalField(operand(a)+"."+f.Name, operand(b)+"."+f.Name, f, depth)...)
+		}
+		return stmts
+	default:
+		cond = fmt.Sprintf("!reflect.DeepEqual(%s, %s)", a, b)
+	}
+	return []string{
+		fmt.Sprintf("if %s {", cond),
+		"return false",
+		"}",
+	}
+}
+
+// equalMap returns the statements which return false when the maps a and b differ
+func (g *copyAndEqualGenerator) equalMap(a, b string, value Schema, depth int) []string {
+	k := fmt.Sprintf("k%d", depth)
+	v := fmt.Sprintf("v%d", depth)
+	w := fmt.Sprintf("w%d", depth)
+	ok := fmt.Sprintf("ok%d", depth)
+	stmts := []string{
+		fmt.Sprintf("if len(%s) != len(%s) || (%s == nil) != (%s == nil) {", a, b, a, b),
+		"return false",
+		"}",
+		fmt.Sprintf("for %s, %s := range %s {", k, v, a),
+		fmt.Sprintf("%s, %s := %s[%s]", w, ok, operand(b), k),
+		fmt.Sprintf("if !%s {", ok),
+		"return false",
+		"}",
+	}
+	stmts = append(stmts, g.equalValue(v, w, value, depth+1)...)
+	return append(stmts, "}")
+}
@@ -0,0 +1,147 @@
+package codegen
+
+import (
+	"go/format"
+	"testing"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const deepCopySpec = `openapi: 3.0.3
+info:
+  title: DeepCopy and Equal
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Status:
+      type: string
+      enum: [available, sold]
+    Tags:
+      type: array
+      items:
+        type: string
+    Owner:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        born:
+          type: string
+          format: date-time
+    Pet:
+      type: object
+      required: [id, owners]
+      properties:
+        id:
+          type: integer
+        status:
+          $ref: '#/components/schemas/Status'
+        tags:
+          $ref: '#/components/schemas/Tags'
+        owners:
+          type: array
+          items:
+            $ref: '#/components/schemas/Owner'
+        labels:
+          type: object
+          additionalProperties:
+            type: string
+        extra: {}
+    Animal:
+      oneOf:
+        - $ref: '#/components/schemas/Pet'
+        - $ref: '#/components/schemas/Owner'
+`
+
+func TestGenerateDeepCopyAndEqual(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(deepCopySpec))
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:   true,
+			DeepCopy: true,
+			Equal:    true,
+		},
+		OutputOptions: OutputOptions{
+			SkipPrune: true,
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = format.Source([]byte(code))
+	require.NoError(t, err)
+
+	// Every type which isn't an alias gets both methods
+	for _, typeName := range []string{"Status", "Owner", "Pet", "Animal"} {
+		assert.Contains(t, code, "func (t "+typeName+") DeepCopy() "+typeName+" {")
+		assert.Contains(t, code, "func (t "+typeName+") Equal(other "+typeName+") bool {")
+	}
+	assert.NotContains(t, code, "func (t Tags) DeepCopy()")
+
+	// Pointers, slices and maps get copies of their own, and referenced types use their own methods
+	assert.Contains(t, code, `	if out.Born != nil {
+		v0 := *out.Born
+		out.Born = &v0
+	}`)
+	assert.Contains(t, code, `	if out.Owners != nil {
+		s0 := make([]Owner, len(out.Owners))
+		copy(s0, out.Owners)
+		for i0 := range s0 {
+			s0[i0] = s0[i0].DeepCopy()
+		}
+		out.Owners = s0
+	}`)
+	assert.Contains(t, code, `		m1 := make(map[string]string, len(v0))`)
+
+	// Values are compared by their contents
+	assert.Contains(t, code, "if !(*t.Born).Equal(*other.Born) {")
+	assert.Contains(t, code, "if !t.Owners[i0].Equal(other.Owners[i0]) {")
+	assert.Contains(t, code, "if !reflect.DeepEqual(t.Extra, other.Extra) {")
+	assert.Contains(t, code, `	if !bytes.Equal(t.union, other.union) {`)
+	assert.Contains(t, code, "func (t Status) Equal(other Status) bool {\n\treturn t == other\n}")
+}
+
+func TestGenerateDeepCopyRequiresModels(t *testing.T) {
+	opts := GenerateOptions{Client: true, DeepCopy: true, Equal: true}
+	problems := opts.Validate()
+	assert.Contains(t, problems, "deepcopy")
+	assert.Contains(t, problems, "equal")
+
+	opts.Models = true
+	assert.Nil(t, opts.Validate())
+}
+
+func TestGenerateDeepCopyAndEqualMethodFields(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(`openapi: 3.0.0
+info:
+  title: deepcopy
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Snapshot:
+      type: object
+      properties:
+        deepCopy:
+          type: boolean
+    Comparison:
+      type: object
+      properties:
+        equal:
+          type: boolean
+`))
+	require.NoError(t, err)
+
+	generate := func(opts GenerateOptions) error {
+		_, err := Generate(swagger, Configuration{PackageName: "api", Generate: opts})
+		return err
+	}
+	assert.ErrorContains(t, generate(GenerateOptions{Models: true, DeepCopy: true}), "Snapshot has a field named DeepCopy")
+	assert.ErrorContains(t, generate(GenerateOptions{Models: true, Equal: true}), "Comparison has a field named Equal")
+}
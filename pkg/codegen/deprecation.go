@@ -0,0 +1,190 @@
+package codegen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strings"
+)
+
+// deprecatedBuildTag is the build tag which excludes the client's functions for deprecated operations, with `fail-on-deprecated-usage`, so that building with it fails wherever they're used
+const deprecatedBuildTag = "no_deprecated"
+
+// deprecatedInterfaces are the interfaces which the client's interfaces embed with `fail-on-deprecated-usage`, holding the methods for deprecated operations, which are empty when building with the deprecatedBuildTag
+var deprecatedInterfaces = []string{"deprecatedClientInterface", "deprecatedClientWithResponsesInterface"}
+
+// IsDeprecated returns whether the operation is marked as `deprecated` in the spec
+func (o *OperationDefinition) IsDeprecated() bool {
+	return o.Spec != nil && o.Spec.Deprecated != nil && *o.Spec.Deprecated
+}
+
+// DeprecationComment returns the `Deprecated:` paragraph of the doc comments of the operation's generated methods, with the reason given by its `x-deprecated-reason`, or an empty string if the operation isn't deprecated
+func (o *OperationDefinition) DeprecationComment() string {
+	if !o.IsDeprecated() {
+		return ""
+	}
+	reason := "this operation has been marked as deprecated upstream, but no `x-deprecated-reason` was set"
+	if extension, ok := o.Spec.Extensions[extDeprecationReason]; ok {
+		if extReason, err := extParseDeprecationReason(extension); err == nil && extReason != "" {
+			reason = extReason
+		}
+	}
+	return stringToGoCommentWithPrefix("Deprecated: "+reason, "")
+}
+
+// IsBehindDeprecatedBuildTag returns whether the client's functions for the operation are excluded when building with the deprecatedBuildTag, as it's deprecated and `fail-on-deprecated-usage` is set
+func (o *OperationDefinition) IsBehindDeprecatedBuildTag() bool {
+	return o.IsDeprecated() && globalState.options.OutputOptions.FailOnDeprecatedUsage
+}
+
+// deprecatedFileNames returns the names of the files which the client's functions for deprecated operations are split out into, alongside mainFile, such as `api_deprecated.gen.go` for `api.gen.go`, along with the file holding the empty interfaces which replace them when building with the deprecatedBuildTag
+func deprecatedFileNames(mainFile string) (string, string) {
+	base, ext := strings.TrimSuffix(mainFile, ".go"), ".go"
+	if strings.HasSuffix(base, ".gen") {
+		base, ext = strings.TrimSuffix(base, ".gen"), ".gen.go"
+	}
+	return base + "_deprecated" + ext, base + "_" + deprecatedBuildTag + ext
+}
+
+// isDeprecatedDecl returns whether a declaration is one of the client's functions for a deprecated operation, which have a `Deprecated:` paragraph in their doc comments, or one of the deprecatedInterfaces, along with the name of the interface
+func isDeprecatedDecl(decl ast.Decl) (bool, string) {
+	switch decl := decl.(type) {
+	case *ast.FuncDecl:
+		if decl.Doc == nil {
+			return false, ""
+		}
+		for _, line := range strings.Split(decl.Doc.Text(), "\n") {
+			if strings.HasPrefix(line, "Deprecated: ") {
+				return true, ""
+			}
+		}
+	case *ast.GenDecl:
+		if decl.Tok != token.TYPE || len(decl.Specs) != 1 {
+			return false, ""
+		}
+		name := decl.Specs[0].(*ast.TypeSpec).Name.Name
+		for _, iface := range deprecatedInterfaces {
+			if name == iface {
+				return true, name
+			}
+		}
+	}
+	return false, ""
+}
+
+// splitDeprecatedFiles splits the client's functions for deprecated operations out of code, which is generated with `fail-on-deprecated-usage`, into a file which is excluded when building with the deprecatedBuildTag, along with a file which replaces the interfaces holding their methods with empty ones in that case. The rest of the code is kept in mainFile
+func splitDeprecatedFiles(code, mainFile string, formatter Formatter) (map[string]string, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, mainFile, code, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing generated code: %w", err)
+	}
+	offset := func(pos token.Pos) int {
+		return fset.Position(pos).Offset
+	}
+
+	// The file header, which comes before the package's documentation, is kept in each file, with the deprecatedBuildTag added to its build constraint
+	preambleEnd := offset(file.Package)
+	if file.Doc != nil {
+		preambleEnd = offset(file.Doc.Pos())
+	}
+	var header, constraint []string
+	for _, line := range strings.Split(code[:preambleEnd], "\n") {
+		if strings.HasPrefix(line, "//go:build ") {
+			constraint = append(constraint, "("+strings.TrimPrefix(line, "//go:build ")+")")
+			continue
+		}
+		header = append(header, line)
+	}
+	for _, line := range strings.Split(code[:offset(file.Package)], "\n") {
+		if strings.HasPrefix(line, "// Code generated ") {
+			header = append(header, line)
+		}
+	}
+	preamble := func(tag string) string {
+		return "//go:build " + strings.Join(append(constraint, tag), " && ") + "\n\n" + strings.TrimLeft(strings.Join(header, "\n"), "\n") + "\n\n"
+	}
+
+	type span struct {
+		start, end int
+	}
+	var imports, deprecated, interfaces []string
+	var removed []span
+	for _, decl := range file.Decls {
+		start := decl.Pos()
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			if decl.Doc != nil {
+				start = decl.Doc.Pos()
+			}
+			if decl.Tok == token.IMPORT {
+				imports = append(imports, code[offset(start):offset(decl.End())])
+			}
+		case *ast.FuncDecl:
+			if decl.Doc != nil {
+				start = decl.Doc.Pos()
+			}
+		}
+		ok, iface := isDeprecatedDecl(decl)
+		if !ok {
+			continue
+		}
+		if iface != "" {
+			interfaces = append(interfaces, iface)
+		}
+		s := span{start: strings.LastIndex(code[:offset(start)], "\n") + 1, end: offset(decl.End())}
+		deprecated = append(deprecated, code[s.start:s.end])
+		removed = append(removed, s)
+	}
+
+	// Nothing needs splitting out when the client isn't generated
+	if len(removed) == 0 {
+		return map[string]string{mainFile: code}, nil
+	}
+
+	// Formatting fixes the imports of each file, so that each only imports what it uses
+	if formatter != FormatterGofumpt {
+		formatter = FormatterGoimports
+	}
+
+	deprecatedFile, stubFile := deprecatedFileNames(mainFile)
+	files := make(map[string]string, 3)
+
+	var sb strings.Builder
+	sb.WriteString(preamble("!" + deprecatedBuildTag))
+	sb.WriteString("package " + file.Name.Name + "\n\n")
+	sb.WriteString(strings.Join(imports, "\n") + "\n\n")
+	sb.WriteString(strings.Join(deprecated, "\n\n") + "\n")
+	formatted, err := formatCode(sb.String(), file.Name.Name, formatter)
+	if err != nil {
+		return nil, fmt.Errorf("error formatting %s: %w", deprecatedFile, err)
+	}
+	files[deprecatedFile] = formatted
+
+	sb.Reset()
+	sb.WriteString(preamble(deprecatedBuildTag))
+	sb.WriteString("package " + file.Name.Name + "\n")
+	for _, iface := range interfaces {
+		fmt.Fprintf(&sb, "\n// %s is empty when building with the `%s` tag, so that using deprecated operations fails to build.\ntype %s interface{}\n", iface, deprecatedBuildTag, iface)
+	}
+	formatted, err = formatCode(sb.String(), file.Name.Name, formatter)
+	if err != nil {
+		return nil, fmt.Errorf("error formatting %s: %w", stubFile, err)
+	}
+	files[stubFile] = formatted
+
+	sort.Slice(removed, func(i, j int) bool {
+		return removed[i].start > removed[j].start
+	})
+	for _, s := range removed {
+		code = code[:s.start] + code[s.end:]
+	}
+	formatted, err = formatCode(code, file.Name.Name, formatter)
+	if err != nil {
+		return nil, fmt.Errorf("error formatting %s: %w", mainFile, err)
+	}
+	files[mainFile] = formatted
+	return files, nil
+}
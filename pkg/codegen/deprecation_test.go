@@ -0,0 +1,163 @@
+package codegen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+const deprecationSpec = `openapi: 3.0.0
+info:
+  title: Deprecations
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '200':
+          description: OK
+    post:
+      operationId: addPet
+      deprecated: true
+      x-deprecated-reason: Pets can no longer be added.
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+      responses:
+        '204':
+          description: OK
+  /pets/{id}:
+    get:
+      operationId: getPet
+      deprecated: true
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: OK
+`
+
+func TestDeprecatedOperations(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(deprecationSpec))
+	require.NoError(t, err)
+
+	t.Run("markers", func(t *testing.T) {
+		code, err := Generate(swagger, Configuration{
+			PackageName: "api",
+			Generate: GenerateOptions{
+				Models:        true,
+				Client:        true,
+				StdHTTPServer: true,
+				Strict:        true,
+			},
+		})
+		require.NoError(t, err)
+
+		assert.Contains(t, code, "// AddPetWithBody sends a AddPet request with any body.\n//\n// Deprecated: Pets can no longer be added.\nfunc (c *Client) AddPetWithBody(")
+		assert.Contains(t, code, "// Deprecated: Pets can no longer be added.\nfunc (c *ClientWithResponses) AddPetWithResponse(")
+		assert.Contains(t, code, "// Deprecated: this operation has been marked as deprecated upstream, but no `x-deprecated-reason` was set\nfunc NewGetPetRequest(")
+		assert.Contains(t, code, "// (GET /pets/{id})\n\t//\n\t// Deprecated: this operation has been marked as deprecated upstream", "the server interfaces are marked")
+		assert.NotContains(t, code, "deprecatedClientInterface")
+		assert.NotContains(t, code, "Deprecated: Pets can no longer be added.\nfunc (c *Client) ListPets(")
+	})
+
+	t.Run("build tag", func(t *testing.T) {
+		files, err := GenerateFiles(swagger, Configuration{
+			PackageName: "api",
+			Generate: GenerateOptions{
+				Models: true,
+				Client: true,
+			},
+			OutputOptions: OutputOptions{
+				FailOnDeprecatedUsage: true,
+			},
+		}, "gen/api.gen.go")
+		require.NoError(t, err)
+
+		var names []string
+		for name := range files {
+			names = append(names, name)
+		}
+		require.ElementsMatch(t, []string{"api.gen.go", "api_deprecated.gen.go", "api_no_deprecated.gen.go"}, names)
+
+		main := files["api.gen.go"]
+		assert.Contains(t, main, "\tdeprecatedClientInterface\n")
+		assert.Contains(t, main, "func (c *Client) ListPets(")
+		assert.Contains(t, main, "func ParseGetPetResponse(", "the response types are kept, as they don't depend on the deprecated methods")
+		assert.NotContains(t, main, "func (c *Client) GetPet(")
+		assert.NotContains(t, main, "Deprecated:")
+
+		deprecated := files["api_deprecated.gen.go"]
+		assert.Contains(t, deprecated, "//go:build !no_deprecated\n")
+		assert.Contains(t, deprecated, "type deprecatedClientWithResponsesInterface interface {")
+		assert.Contains(t, deprecated, "func (c *Client) GetPet(")
+		assert.Contains(t, deprecated, "func NewAddPetRequestWithBody(")
+
+		stub := files["api_no_deprecated.gen.go"]
+		assert.Contains(t, stub, "//go:build no_deprecated\n")
+		assert.Contains(t, stub, "type deprecatedClientInterface interface{}")
+		assert.NotContains(t, stub, "import")
+
+		fset := token.NewFileSet()
+		imp := importer.ForCompiler(fset, "source", nil)
+		check := func(usage string, files ...string) error {
+			var parsed []*ast.File
+			for i, src := range append(files, usage) {
+				file, err := parser.ParseFile(fset, fmt.Sprintf("file%d.go", i), src, 0)
+				require.NoError(t, err)
+				parsed = append(parsed, file)
+			}
+			conf := types.Config{Importer: imp}
+			_, err := conf.Check("api", fset, parsed, nil)
+			return err
+		}
+
+		usage := `package api
+
+import "context"
+
+func usage(c ClientWithResponsesInterface) {
+	_, _ = c.ListPetsWithResponse(context.Background())
+	_, _ = c.GetPetWithResponse(context.Background(), "id")
+}
+`
+		require.NoError(t, check(usage, main, deprecated))
+		err = check(usage, main, stub)
+		require.Error(t, err, "using a deprecated operation fails to build with the `no_deprecated` tag")
+		assert.Contains(t, err.Error(), "GetPetWithResponse")
+		require.NoError(t, check("package api\n", main, stub), "the client builds without the deprecated operations")
+	})
+
+	t.Run("requires formatting", func(t *testing.T) {
+		cfg := Configuration{
+			PackageName: "api",
+			Generate: GenerateOptions{
+				Client: true,
+			},
+			OutputOptions: OutputOptions{
+				FailOnDeprecatedUsage: true,
+				SkipFmt:               true,
+			},
+		}
+		assert.ErrorContains(t, cfg.Validate(), "fail-on-deprecated-usage")
+	})
+}
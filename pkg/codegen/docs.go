@@ -0,0 +1,567 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+// DocsOperation is the section of the Markdown reference for an operation
+type DocsOperation struct {
+	OperationID string
+	Method      string
+	Path        string
+	Summary     string
+	Description string
+	Deprecated  bool
+	// Tags are the tags the operation is tagged with, which are described in the Tags section
+	Tags         []string
+	ExternalDocs *DocsLink
+	// Security describes the security requirements which apply to the operation, whether its own or the spec's, if there are any
+	Security   string
+	Parameters []DocsParameter
+	Bodies     []DocsContent
+	Responses  []DocsResponse
+	// Curl is an example of the request with curl
+	Curl string
+	// Go is an example of the request with the generated client
+	Go string
+}
+
+// DocsTag is a tag, either declared in the spec's top-level `tags` or used by an operation, in the Markdown reference
+type DocsTag struct {
+	Name         string
+	Description  string
+	ExternalDocs *DocsLink
+	// Operations are the IDs of the operations tagged with the tag
+	Operations []string
+}
+
+// DocsLink is a link to external docs, from the spec's `externalDocs`
+type DocsLink struct {
+	Description string
+	URL         string
+}
+
+// DocsParameter is a parameter of an operation, in the Markdown reference
+type DocsParameter struct {
+	Name        string
+	In          string
+	Type        string
+	Required    bool
+	Description string
+}
+
+// DocsContent is a content type of a request or response body, and its schema, in the Markdown reference
+type DocsContent struct {
+	ContentType string
+	Type        string
+}
+
+// DocsResponse is a response of an operation, in the Markdown reference
+type DocsResponse struct {
+	Status      string
+	Description string
+	Contents    []DocsContent
+}
+
+// DocsSchema is a component schema, in the Markdown reference
+type DocsSchema struct {
+	Name        string
+	Description string
+	// Type describes a schema which isn't an object, such as `array of Pet`
+	Type       string
+	Properties []DocsProperty
+}
+
+// DocsProperty is a property of a component schema, in the Markdown reference
+type DocsProperty struct {
+	Name        string
+	Type        string
+	Required    bool
+	Description string
+}
+
+// GenerateDocs generates a Markdown reference for a spec, rather than code, as configured with the `docs` target. It has a section for each operation, with its parameters, bodies and responses, along with examples of calling it with curl and with the generated client, followed by a section for each of the component schemas
+func GenerateDocs(spec *openapi.T, opts Configuration) (string, error) {
+	var docs string
+	globalState.docs = &docs
+	defer func() {
+		globalState.docs = nil
+	}()
+
+	// Only the operations are needed, rather than any of the code
+	opts.Generate = GenerateOptions{}
+	if _, err := Generate(spec, opts); err != nil {
+		return "", err
+	}
+	return docs, nil
+}
+
+// generateDocs renders the Markdown reference for the operations, when generating docs rather than code
+func generateDocs(t *template.Template, spec *openapi.T, ops []OperationDefinition) (string, error) {
+	var operations []DocsOperation
+	for _, op := range ops {
+		operation, err := docsOperation(spec, op)
+		if err != nil {
+			return "", fmt.Errorf("error generating docs for %s: %w", op.OperationId, err)
+		}
+		operations = append(operations, operation)
+	}
+
+	var schemas []DocsSchema
+	if spec.Components != nil {
+		for _, name := range SortedMapKeys(spec.Components.Schemas) {
+			ref := spec.Components.Schemas[name]
+			if ref == nil || ref.Value == nil || ref.Value.Schema == nil {
+				continue
+			}
+			schemas = append(schemas, docsSchema(name, ref.Value.Schema))
+		}
+	}
+
+	title := ""
+	if spec.Info != nil {
+		title = spec.Info.Title
+	}
+	var externalDocs *DocsLink
+	if spec.Document != nil {
+		externalDocs = docsLink(spec.ExternalDocs)
+	}
+	context := struct {
+		Title        string
+		ExternalDocs *DocsLink
+		Operations   []DocsOperation
+		Tags         []DocsTag
+		Schemas      []DocsSchema
+	}{
+		Title:        title,
+		ExternalDocs: externalDocs,
+		Operations:   operations,
+		Tags:         docsTags(spec, operations),
+		Schemas:      schemas,
+	}
+	return GenerateTemplates([]string{"docs.tmpl"}, t, context)
+}
+
+// docsOperation describes an operation, with examples of calling it which send the same request as the conformance tests
+func docsOperation(spec *openapi.T, op OperationDefinition) (DocsOperation, error) {
+	operation := DocsOperation{
+		OperationID: op.OperationId,
+		Method:      op.Method,
+		Path:        op.Path,
+		Summary:     op.Summary,
+	}
+	if op.Spec != nil && op.Spec.Operation != nil {
+		operation.Description = op.Spec.Description
+		operation.Deprecated = op.Spec.Deprecated != nil && *op.Spec.Deprecated
+		operation.ExternalDocs = docsLink(op.Spec.ExternalDocs)
+	}
+	operation.Tags = op.Tags
+	operation.Security = docsSecurity(spec.OperationSecurityRequirements(op.Spec))
+
+	for _, param := range op.AllParams() {
+		p := DocsParameter{
+			Name:     param.ParamName,
+			In:       param.In,
+			Required: param.Required,
+		}
+		if param.Spec != nil {
+			p.Type = docsSchemaRefType(param.Spec.Schema)
+			if param.Spec.Parameter != nil {
+				p.Description = param.Spec.Description
+			}
+		}
+		operation.Parameters = append(operation.Parameters, p)
+	}
+
+	if op.Spec != nil && op.Spec.RequestBody != nil && op.Spec.RequestBody.Value != nil {
+		content := op.Spec.RequestBody.Value.Content
+		for _, contentType := range SortedMapKeys(content) {
+			operation.Bodies = append(operation.Bodies, DocsContent{ContentType: contentType, Type: docsMediaTypeType(content[contentType])})
+		}
+	}
+
+	if op.Spec != nil {
+		responses := op.Spec.Responses.Map()
+		for _, status := range SortedMapKeys(responses) {
+			ref := responses[status]
+			if ref == nil || ref.Value == nil {
+				continue
+			}
+			r := DocsResponse{Status: status}
+			if ref.Value.Response != nil {
+				r.Description = ref.Value.Description
+			}
+			for _, contentType := range SortedMapKeys(ref.Value.Content) {
+				r.Contents = append(r.Contents, DocsContent{ContentType: contentType, Type: docsMediaTypeType(ref.Value.Content[contentType])})
+			}
+			operation.Responses = append(operation.Responses, r)
+		}
+	}
+
+	test, err := conformanceTest(op)
+	if err != nil {
+		return operation, err
+	}
+	operation.Curl = docsCurl(spec, test)
+	operation.Go = docsGo(op, test)
+	return operation, nil
+}
+
+// docsTags describes the tags declared in the spec's top-level `tags`, in the order they're declared, followed by any others the operations are tagged with, in the order they're first used, along with the operations tagged with each
+func docsTags(spec *openapi.T, operations []DocsOperation) []DocsTag {
+	var tags []DocsTag
+	index := make(map[string]int)
+	for _, tag := range spec.Tags {
+		if _, ok := index[tag.Name]; ok {
+			continue
+		}
+		index[tag.Name] = len(tags)
+		tags = append(tags, DocsTag{
+			Name:         tag.Name,
+			Description:  tag.Description,
+			ExternalDocs: docsLink(tag.ExternalDocs),
+		})
+	}
+	for _, operation := range operations {
+		for _, name := range operation.Tags {
+			i, ok := index[name]
+			if !ok {
+				i = len(tags)
+				index[name] = i
+				tags = append(tags, DocsTag{Name: name})
+			}
+			tags[i].Operations = append(tags[i].Operations, operation.OperationID)
+		}
+	}
+	return tags
+}
+
+// docsSecurity describes security requirements, such as `api_key` or `oauth (read:pets)`, any one of which is sufficient
+func docsSecurity(requirements openapi.SecurityRequirements) string {
+	var alternatives []string
+	for _, requirement := range requirements {
+		if len(requirement) == 0 {
+			alternatives = append(alternatives, "none")
+			continue
+		}
+		var schemes []string
+		for _, name := range SortedMapKeys(requirement) {
+			scheme := "`" + name + "`"
+			if scopes := requirement[name]; len(scopes) > 0 {
+				scheme += " (" + strings.Join(scopes, ", ") + ")"
+			}
+			schemes = append(schemes, scheme)
+		}
+		alternatives = append(alternatives, strings.Join(schemes, " and "))
+	}
+	return strings.Join(alternatives, ", or ")
+}
+
+// docsLink returns a link to external docs, if there are any
+func docsLink(docs *base.ExternalDoc) *DocsLink {
+	if docs == nil || docs.URL == "" {
+		return nil
+	}
+	return &DocsLink{Description: docs.Description, URL: docs.URL}
+}
+
+// docsCurl returns a curl command which sends the example request to the first of the spec's servers
+func docsCurl(spec *openapi.T, test ConformanceTest) string {
+	lines := []string{fmt.Sprintf("curl -X %s %s", test.Method, docsShellQuote(docsServerURL(spec)+test.URL))}
+	for _, header := range test.Headers {
+		lines = append(lines, "-H "+docsShellQuote(header.Name+": "+header.Value))
+	}
+	for _, cookie := range test.Cookies {
+		lines = append(lines, "-b "+docsShellQuote(cookie.Name+"="+cookie.Value))
+	}
+	if test.ContentType != "" {
+		lines = append(lines, "-H "+docsShellQuote("Content-Type: "+test.ContentType), "-d "+docsShellQuote(test.Body))
+	}
+	return strings.Join(lines, " \\\n  ")
+}
+
+// docsServerURL returns the URL of the first of the spec's servers, with the default value of each of its variables, or otherwise a local server
+func docsServerURL(spec *openapi.T) string {
+	if len(spec.Servers) == 0 || spec.Servers[0] == nil || spec.Servers[0].Server == nil {
+		return "http://localhost"
+	}
+	server := spec.Servers[0]
+	url := server.URL
+	for name, variable := range server.Variables {
+		if variable != nil && variable.ServerVariable != nil {
+			url = strings.ReplaceAll(url, "{"+name+"}", variable.Default)
+		}
+	}
+	return strings.TrimSuffix(url, "/")
+}
+
+// docsShellQuote quotes a value for a POSIX shell
+func docsShellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// docsGo returns an example of sending the example request with the generated client, with the example's path parameters as literals, and its required parameters and body set
+func docsGo(op OperationDefinition, test ConformanceTest) string {
+	pkg := globalState.options.PackageName
+	var lines []string
+	args := []string{"ctx"}
+
+	for _, param := range op.PathParams {
+		value, _ := conformanceParamValue(param)
+		literal, ok := docsGoLiteral(param.Schema.GoType, strings.Join(value, ","))
+		if !ok {
+			lines = append(lines, fmt.Sprintf("var %s %s // set the %s path parameter", param.GoVariableName(), docsQualify(pkg, param.TypeDef()), param.ParamName))
+			literal = param.GoVariableName()
+		}
+		args = append(args, literal)
+	}
+
+	if op.RequiresParamObject() {
+		lines = append(lines, fmt.Sprintf("params := &%s.%sParams{}", pkg, op.OperationId))
+		for _, param := range op.Params() {
+			if !param.Required || param.HasOptionalPointer() || param.AllowsEmptyValue() {
+				continue
+			}
+			value, _ := conformanceParamValue(param)
+			if literal, ok := docsGoLiteral(param.Schema.GoType, strings.Join(value, ",")); ok {
+				lines = append(lines, fmt.Sprintf("params.%s = %s", param.GoName(), literal))
+			}
+		}
+		args = append(args, "params")
+	}
+
+	method := op.OperationId + "WithResponse"
+	if body := docsJSONBody(op); body != nil && test.ContentType == body.ContentType {
+		lines = append(lines,
+			fmt.Sprintf("var body %s.%s%sRequestBody", pkg, op.OperationId, body.NameTag),
+			fmt.Sprintf("if err := json.Unmarshal([]byte(%s), &body); err != nil {", docsGoString(test.Body)),
+			"\treturn err",
+			"}",
+		)
+		method = op.OperationId + body.Suffix() + "WithResponse"
+		args = append(args, "body")
+	} else if op.HasBody() {
+		contentType, body := test.ContentType, test.Body
+		if contentType == "" && len(op.Bodies) > 0 {
+			contentType = op.Bodies[0].ContentType
+		}
+		method = op.OperationId + "WithBodyWithResponse"
+		args = append(args, strconv.Quote(contentType), fmt.Sprintf("strings.NewReader(%s)", strconv.Quote(body)))
+	}
+
+	lines = append(lines,
+		fmt.Sprintf("resp, err := client.%s(%s)", method, strings.Join(args, ", ")),
+		"if err != nil {",
+		"\treturn err",
+		"}",
+		"fmt.Println(resp.StatusCode())",
+	)
+	return strings.Join(lines, "\n")
+}
+
+// docsGoString returns a string as a Go literal, which is a raw string, unless it contains a backtick
+func docsGoString(value string) string {
+	if strings.Contains(value, "`") {
+		return strconv.Quote(value)
+	}
+	return "`" + value + "`"
+}
+
+// docsJSONBody returns the JSON body of the operation which the generated client has a typed method for, if it has one
+func docsJSONBody(op OperationDefinition) *RequestBodyDefinition {
+	for _, body := range op.Bodies {
+		if body.IsJSON() && body.IsSupportedByClient() {
+			return &body
+		}
+	}
+	return nil
+}
+
+// docsGoLiteral returns a value as a Go literal, for a string, number or boolean
+func docsGoLiteral(goType, value string) (string, bool) {
+	switch {
+	case goType == "string":
+		return strconv.Quote(value), true
+	case goType == "bool":
+		_, err := strconv.ParseBool(value)
+		return value, err == nil
+	case numberGoTypes[goType]:
+		_, err := strconv.ParseFloat(value, 64)
+		return value, err == nil
+	}
+	return "", false
+}
+
+// docsQualify qualifies a generated type with its package, other than a builtin type, or a type from another package
+func docsQualify(pkg, goType string) string {
+	name := strings.TrimLeft(goType, "*[]")
+	if name == "" || strings.Contains(name, ".") || name[0] < 'A' || name[0] > 'Z' {
+		return goType
+	}
+	return goType[:len(goType)-len(name)] + pkg + "." + name
+}
+
+// docsSchema describes a component schema, with a row for each of its properties
+func docsSchema(name string, schema *base.Schema) DocsSchema {
+	s := DocsSchema{
+		Name:        name,
+		Description: schema.Description,
+		Type:        docsBaseSchemaType(schema),
+	}
+	if schema.Properties == nil {
+		return s
+	}
+	for pair := schema.Properties.First(); pair != nil; pair = pair.Next() {
+		property := DocsProperty{
+			Name:     pair.Key(),
+			Type:     docsProxyType(pair.Value()),
+			Required: slices.Contains(schema.Required, pair.Key()),
+		}
+		if value := pair.Value().Schema(); value != nil {
+			property.Description = value.Description
+		}
+		s.Properties = append(s.Properties, property)
+	}
+	return s
+}
+
+// docsMediaTypeType describes the schema of a content type
+func docsMediaTypeType(mediaType *openapi.MediaType) string {
+	if mediaType == nil {
+		return ""
+	}
+	return docsSchemaRefType(mediaType.Schema)
+}
+
+// docsSchemaRefType describes a schema, as a link to the component schema it references, if it's a reference
+func docsSchemaRefType(ref *openapi.SchemaRef) string {
+	if ref == nil {
+		return ""
+	}
+	if ref.Ref != "" {
+		return docsRefLink(ref.Ref)
+	}
+	if ref.Value == nil {
+		return ""
+	}
+	return docsBaseSchemaType(ref.Value.Schema)
+}
+
+// docsProxyType describes a schema, which may be a reference to a component schema
+func docsProxyType(proxy *base.SchemaProxy) string {
+	if proxy == nil {
+		return ""
+	}
+	if proxy.IsReference() {
+		return docsRefLink(proxy.GetReference())
+	}
+	return docsBaseSchemaType(proxy.Schema())
+}
+
+// docsBaseSchemaType describes a schema by its type and format, such as `string (date-time)` or `array of Pet`, along with its enum values, if it has any
+func docsBaseSchemaType(schema *base.Schema) string {
+	if schema == nil {
+		return ""
+	}
+	for _, variants := range []struct {
+		keyword string
+		schemas []*base.SchemaProxy
+	}{{"all of", schema.AllOf}, {"one of", schema.OneOf}, {"any of", schema.AnyOf}} {
+		if len(variants.schemas) == 0 {
+			continue
+		}
+		var types []string
+		for _, proxy := range variants.schemas {
+			types = append(types, docsProxyType(proxy))
+		}
+		return variants.keyword + " " + strings.Join(types, ", ")
+	}
+
+	typ := exampleType(schema)
+	switch typ {
+	case "array":
+		if schema.Items != nil && schema.Items.IsA() {
+			return "array of " + docsProxyType(schema.Items.A)
+		}
+	case "":
+		return "any"
+	}
+	if schema.Format != "" {
+		typ += " (" + schema.Format + ")"
+	}
+	if bounds := docsBounds(schema); bounds != "" {
+		typ += ", " + bounds
+	}
+	if len(schema.Enum) > 0 {
+		var values []string
+		for _, node := range schema.Enum {
+			var value interface{}
+			if node.Decode(&value) != nil {
+				continue
+			}
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				continue
+			}
+			values = append(values, "`"+string(encoded)+"`")
+		}
+		typ += ", one of " + strings.Join(values, ", ")
+	}
+	return typ
+}
+
+// docsBounds describes the bounds of a number, such as `at least 1, less than 10`, which are the same for either form of `exclusiveMinimum` and `exclusiveMaximum`
+func docsBounds(schema *base.Schema) string {
+	bounds := (&openapi.Schema{Schema: schema}).Bounds()
+	literal := func(v float64) string {
+		return "`" + strconv.FormatFloat(v, 'g', -1, 64) + "`"
+	}
+	var descriptions []string
+	if bounds.Minimum != nil {
+		if bounds.ExclusiveMinimum {
+			descriptions = append(descriptions, "greater than "+literal(*bounds.Minimum))
+		} else {
+			descriptions = append(descriptions, "at least "+literal(*bounds.Minimum))
+		}
+	}
+	if bounds.Maximum != nil {
+		if bounds.ExclusiveMaximum {
+			descriptions = append(descriptions, "less than "+literal(*bounds.Maximum))
+		} else {
+			descriptions = append(descriptions, "at most "+literal(*bounds.Maximum))
+		}
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// docsRefLink links to the component schema a reference is to, or returns the reference as it is, if it's to another document
+func docsRefLink(ref string) string {
+	name, found := strings.CutPrefix(ref, "#/components/schemas/")
+	if !found {
+		return "`" + ref + "`"
+	}
+	return fmt.Sprintf("[%s](#%s)", name, docsAnchor(name))
+}
+
+// docsAnchorPattern matches the characters which are dropped from a heading's anchor
+var docsAnchorPattern = regexp.MustCompile(`[^\p{L}\p{N}_\- ]`)
+
+// docsAnchor returns the anchor of a Markdown heading, as GitHub generates it
+func docsAnchor(heading string) string {
+	return strings.ReplaceAll(strings.ToLower(docsAnchorPattern.ReplaceAllString(heading, "")), " ", "-")
+}
+
+// docsCell escapes text for a cell of a Markdown table, which can't span lines
+func docsCell(text string) string {
+	return strings.ReplaceAll(strings.Join(strings.Fields(text), " "), "|", `\|`)
+}
@@ -0,0 +1,125 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+const docsSpec = `openapi: 3.0.0
+info:
+  title: Petstore
+  version: 1.0.0
+servers:
+  - url: https://api.example.com/v1
+externalDocs:
+  url: https://example.com/docs
+security:
+  - api_key: []
+tags:
+  - name: pets
+    description: Everything about your pets
+    externalDocs:
+      description: Find out more
+      url: https://example.com/pets
+paths:
+  /pets:
+    post:
+      operationId: addPet
+      summary: Adds a pet
+      tags: [pets]
+      parameters:
+        - name: dry_run
+          in: query
+          required: true
+          description: Whether to only validate the pet
+          schema:
+            type: boolean
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '201':
+          description: The added pet
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+  /pets/{id}:
+    delete:
+      operationId: deletePet
+      security:
+        - oauth: [write:pets]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+            example: 7
+      responses:
+        '204':
+          description: Deleted
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+          description: The pet's | name
+          example: Rex
+        tags:
+          type: array
+          items:
+            type: string
+        age:
+          type: integer
+          minimum: 0
+          exclusiveMinimum: true
+          maximum: 30
+`
+
+func TestGenerateDocs(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(docsSpec))
+	require.NoError(t, err)
+
+	docs, err := GenerateDocs(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client: true,
+			Docs:   true,
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, docs, "# Petstore reference\n")
+	assert.Contains(t, docs, "| [AddPet](#addpet) | `POST` | `/pets` | Adds a pet |")
+	assert.Contains(t, docs, "# Petstore reference\n\nSee [https://example.com/docs](https://example.com/docs)\n")
+	assert.Contains(t, docs, "### AddPet\n\n`POST /pets`\n\nTags: [`pets`](#tags)\n\nSecurity: `api_key`\n\nAdds a pet\n")
+	// An operation's own security overrides the spec's
+	assert.Contains(t, docs, "`DELETE /pets/{id}`\n\nSecurity: `oauth` (write:pets)\n")
+	assert.Contains(t, docs, "| `dry_run` | query | boolean | yes | Whether to only validate the pet |")
+	assert.Contains(t, docs, "| 201 | `application/json` | [Pet](#pet) | The added pet |")
+
+	// The tags are described, along with the operations tagged with them
+	assert.Contains(t, docs, "| `pets` | Everything about your pets [Find out more](https://example.com/pets) | [AddPet](#addpet) |")
+
+	// The examples send the same request, built from the examples in the spec
+	assert.Contains(t, docs, "```sh\ncurl -X POST 'https://api.example.com/v1/pets?dry_run=true' \\\n  -H 'Content-Type: application/json' \\\n  -d '{\"name\":\"Rex\"}'\n```")
+	assert.Contains(t, docs, "```go\nparams := &api.AddPetParams{}\nparams.DryRun = true\nvar body api.AddPetJSONRequestBody\nif err := json.Unmarshal([]byte(`{\"name\":\"Rex\"}`), &body); err != nil {\n\treturn err\n}\nresp, err := client.AddPetWithResponse(ctx, params, body)\n")
+	assert.Contains(t, docs, "resp, err := client.DeletePetWithResponse(ctx, 7)\n")
+
+	// Each of the component schemas is described, with its properties, which are escaped for the table
+	assert.Contains(t, docs, "## Schemas\n\n### Pet\n")
+	assert.Contains(t, docs, "| `name` | string | yes | The pet's \\| name |")
+	assert.Contains(t, docs, "| `tags` | array of string | no |  |")
+	assert.Contains(t, docs, "| `age` | integer, greater than `0`, at most `30` | no |  |")
+}
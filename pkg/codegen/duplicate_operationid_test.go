@@ -0,0 +1,96 @@
+package codegen
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const duplicateOperationIdSpec = `openapi: 3.0.3
+info:
+  title: Duplicate operationId Test
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    get:
+      operationId: getPet
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: Success
+  /v2/pets/{id}:
+    get:
+      operationId: get_pet
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: Success
+`
+
+// TestDuplicateOperationIdError tests that operations whose operationIds collide fail generation, reporting both locations
+func TestDuplicateOperationIdError(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(duplicateOperationIdSpec))
+	require.NoError(t, err)
+
+	_, err = Generate(swagger, Configuration{
+		PackageName: "testapi",
+		Generate: GenerateOptions{
+			Models:    true,
+			Client:    true,
+			ChiServer: true,
+		},
+	})
+	require.Error(t, err)
+
+	var genErr *Error
+	require.True(t, errors.As(err, &genErr))
+	require.Len(t, genErr.Failures, 1)
+	assert.Equal(t, "GET /v2/pets/{id}", genErr.Failures[0].Artifact)
+	assert.Equal(t, 19, genErr.Failures[0].Position.Line)
+	assert.Contains(t, err.Error(), "duplicate operationId GetPet, which is also used by GET /pets/{id} (7:5)")
+}
+
+// TestDuplicateOperationIdNumberedSuffix tests that operations whose operationIds collide can instead be renamed
+func TestDuplicateOperationIdNumberedSuffix(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(duplicateOperationIdSpec))
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "testapi",
+		Generate: GenerateOptions{
+			Models:    true,
+			Client:    true,
+			ChiServer: true,
+		},
+		OutputOptions: OutputOptions{
+			DuplicateOperationIds: string(DuplicateOperationIdStrategyNumberedSuffix),
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, code, "GetPet(ctx context.Context, id string, reqEditors ...RequestEditorFn)")
+	assert.Contains(t, code, "GetPet2(ctx context.Context, id string, reqEditors ...RequestEditorFn)")
+	assert.Contains(t, code, "GetPet2(w http.ResponseWriter, r *http.Request, id string)")
+}
+
+func TestDuplicateOperationIdsValidation(t *testing.T) {
+	opts := OutputOptions{DuplicateOperationIds: "rename"}
+	problems := opts.Validate()
+	assert.Contains(t, problems, "duplicate-operation-ids")
+
+	opts.DuplicateOperationIds = string(DuplicateOperationIdStrategyNumberedSuffix)
+	assert.Nil(t, opts.Validate())
+}
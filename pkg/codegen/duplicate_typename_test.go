@@ -1,6 +1,7 @@
 package codegen
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
@@ -123,4 +124,155 @@ func TestAutoRenameExhaustion(t *testing.T) {
 	// This should fail to find a unique name since 2-10 are all taken
 	result := autoRenameType("OverloadedType", existingTypes)
 	assert.Empty(t, result, "Should return empty string when unable to find unique name")
-}
\ No newline at end of file
+}
+const typeNameCollisionSpec = `
+openapi: 3.0.0
+info:
+  title: Type name collision Test
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '200':
+          $ref: '#/components/responses/Pet'
+components:
+  schemas:
+    account_type:
+      type: string
+      enum: ["user", "admin"]
+    AccountType:
+      type: object
+      properties:
+        id:
+          type: integer
+    Account:
+      type: object
+      properties:
+        type:
+          $ref: '#/components/schemas/account_type'
+        details:
+          $ref: '#/components/schemas/AccountType'
+    Pet:
+      type: object
+      properties:
+        status:
+          type: string
+          enum: ["available", "sold"]
+    Pet_Status:
+      type: string
+  responses:
+    Pet:
+      description: A pet
+      content:
+        application/json:
+          schema:
+            type: object
+            properties:
+              name:
+                type: string
+`
+
+func generateWithTypeNameCollisions(t *testing.T, strategy TypeNameCollisionStrategy) (string, error) {
+	t.Helper()
+
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(typeNameCollisionSpec))
+	require.NoError(t, err)
+
+	return Generate(swagger, Configuration{
+		PackageName: "testapi",
+		Generate: GenerateOptions{
+			Models: true,
+		},
+		OutputOptions: OutputOptions{
+			SkipPrune:          true,
+			TypeNameCollisions: string(strategy),
+		},
+	})
+}
+
+// TestTypeNameCollisionsNumberedSuffix tests that colliding types are renamed with a number, and that references use the new name
+func TestTypeNameCollisionsNumberedSuffix(t *testing.T) {
+	code, err := generateWithTypeNameCollisions(t, TypeNameCollisionStrategyNumberedSuffix)
+	require.NoError(t, err)
+
+	// Schemas are named in sorted order, so `AccountType` keeps its name
+	assert.Contains(t, code, "type AccountType struct {")
+	assert.Contains(t, code, "type AccountType2 string")
+	assert.Contains(t, code, "User  AccountType2 = \"user\"")
+	assert.Contains(t, code, "Details *AccountType  `json:\"details,omitempty\"`")
+	assert.Contains(t, code, "Type    *AccountType2 `json:\"type,omitempty\"`")
+
+	// Component types take precedence over types generated from inline schemas
+	assert.Contains(t, code, "type PetStatus = string")
+	assert.Contains(t, code, "type PetStatus2 string")
+	assert.Contains(t, code, "Status *PetStatus2 `json:\"status,omitempty\"`")
+
+	assert.Contains(t, code, "type Pet struct {")
+	assert.Contains(t, code, "type Pet2 struct {")
+}
+
+// TestTypeNameCollisionsPathPrefixed tests that colliding types are prefixed with where they are defined
+func TestTypeNameCollisionsPathPrefixed(t *testing.T) {
+	code, err := generateWithTypeNameCollisions(t, TypeNameCollisionStrategyPathPrefixed)
+	require.NoError(t, err)
+
+	assert.Contains(t, code, "type AccountType struct {")
+	assert.Contains(t, code, "type SchemaAccountType string")
+	assert.Contains(t, code, "type ResponsePet struct {")
+	assert.Contains(t, code, "Status *InlinePetStatus `json:\"status,omitempty\"`")
+}
+
+// TestTypeNameCollisionsError tests that colliding types fail generation, reporting each collision
+func TestTypeNameCollisionsError(t *testing.T) {
+	_, err := generateWithTypeNameCollisions(t, TypeNameCollisionStrategyError)
+	require.Error(t, err)
+
+	var genErr *Error
+	require.True(t, errors.As(err, &genErr))
+
+	var messages []string
+	for _, failure := range genErr.Failures {
+		messages = append(messages, failure.Error())
+	}
+	assert.Contains(t, messages, "components/schemas/account_type (16:7): type name AccountType for components/schemas/account_type collides with the type generated for components/schemas/AccountType, use `x-go-type-name` to give one of them a different name")
+	assert.Contains(t, messages, "components/responses/Pet: type name Pet for components/responses/Pet collides with the type generated for components/schemas/Pet, use `x-go-type-name` to give one of them a different name")
+	assert.Contains(t, messages, "components/schemas/Pet (31:7): error converting Schema to Go type: error generating Go schema for property 'status' (34:11): type name PetStatus for Pet.Status collides with the type generated for components/schemas/Pet_Status, use `x-go-type-name` to give one of them a different name")
+}
+
+func TestTypeNameCollisionsExplicitName(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info:
+  title: Type name collision Test
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Colour:
+      type: string
+    Car:
+      type: object
+      properties:
+        colour:
+          type: string
+          enum: ["red", "blue"]
+          x-go-type-name: Colour
+`
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err)
+
+	_, err = Generate(swagger, Configuration{
+		PackageName: "testapi",
+		Generate: GenerateOptions{
+			Models: true,
+		},
+		OutputOptions: OutputOptions{
+			SkipPrune:          true,
+			TypeNameCollisions: string(TypeNameCollisionStrategyNumberedSuffix),
+		},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "duplicate typename 'Colour' detected")
+}
@@ -0,0 +1,13 @@
+package codegen
+
+// hasEmptyQueryParams reports whether any of the operations has a query parameter with `allowEmptyValue: true`, for which the client gets the `styleQueryParameterAllowingEmpty` helper, and the server the `bindQueryParameterAllowingEmpty` helper
+func hasEmptyQueryParams(ops []OperationDefinition) bool {
+	for i := range ops {
+		for j := range ops[i].QueryParams {
+			if ops[i].QueryParams[j].AllowsEmptyValue() {
+				return true
+			}
+		}
+	}
+	return false
+}
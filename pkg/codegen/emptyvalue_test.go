@@ -0,0 +1,83 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const emptyValueSpec = `openapi: 3.0.0
+info:
+  title: Empty values
+  version: 1.0.0
+paths:
+  /items:
+    get:
+      operationId: listItems
+      parameters:
+        - name: limit
+          in: query
+          allowEmptyValue: true
+          schema:
+            type: integer
+        - name: cursor
+          in: query
+          schema:
+            type: string
+        - name: X-Trace
+          in: header
+          allowEmptyValue: true
+          schema:
+            type: string
+      responses:
+        '204':
+          description: No content
+`
+
+func TestAllowEmptyValue(t *testing.T) {
+	code, err := generateFromSpec(t, emptyValueSpec, Configuration{
+		PackageName: "api",
+		Generate:    GenerateOptions{Models: true, Client: true, ChiServer: true},
+	})
+	require.NoError(t, err)
+
+	t.Run("a query parameter which allows an empty value is nullable", func(t *testing.T) {
+		assert.Contains(t, code, "Limit  nullable.Nullable[int]")
+		assert.Contains(t, code, "Cursor *string")
+	})
+
+	t.Run("allowEmptyValue is ignored for other parameters", func(t *testing.T) {
+		assert.Contains(t, code, "XTrace *string")
+	})
+
+	t.Run("the client styles it as an empty value when it's null", func(t *testing.T) {
+		assert.Contains(t, code, `styleQueryParameterAllowingEmpty("form", true, "limit", runtime.ParamLocationQuery, params.Limit)`)
+		assert.Contains(t, code, "func styleQueryParameterAllowingEmpty[T any](")
+		assert.Contains(t, code, `runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor)`)
+	})
+
+	t.Run("the server binds an empty value as null", func(t *testing.T) {
+		assert.Contains(t, code, `bindQueryParameterAllowingEmpty("form", true, false, "limit", r.URL.Query(), &params.Limit)`)
+		assert.Contains(t, code, "func bindQueryParameterAllowingEmpty[T any](")
+		assert.Contains(t, code, `runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)`)
+	})
+
+	t.Run("the helpers are only generated for the client or server", func(t *testing.T) {
+		code, err := generateFromSpec(t, emptyValueSpec, Configuration{
+			PackageName: "api",
+			Generate:    GenerateOptions{Models: true, Client: true},
+		})
+		require.NoError(t, err)
+		assert.Contains(t, code, "func styleQueryParameterAllowingEmpty[T any](")
+		assert.NotContains(t, code, "func bindQueryParameterAllowingEmpty[T any](")
+
+		code, err = generateFromSpec(t, emptyValueSpec, Configuration{
+			PackageName: "api",
+			Generate:    GenerateOptions{Models: true},
+		})
+		require.NoError(t, err)
+		assert.NotContains(t, code, "func styleQueryParameterAllowingEmpty[T any](")
+		assert.NotContains(t, code, "func bindQueryParameterAllowingEmpty[T any](")
+	})
+}
@@ -0,0 +1,86 @@
+package codegen
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+// Error is returned by Generate when code can't be generated for parts of the
+// spec. Rather than stopping at the first problem, generation carries on so
+// that every failure can be fixed in one go.
+type Error struct {
+	Failures []*Failure
+}
+
+// Failure describes why code couldn't be generated for a single part of the
+// spec.
+type Failure struct {
+	// Artifact identifies what was being generated, such as
+	// `components/schemas/Pet` or `GET /pets`
+	Artifact string
+	// Position is where the artifact is defined in the spec, if known
+	Position openapi.Position
+	Err      error
+}
+
+func (f *Failure) Error() string {
+	return fmt.Sprintf("%s%s: %s", f.Artifact, locationSuffix(f.Position), f.Err)
+}
+
+func (f *Failure) Unwrap() error {
+	return f.Err
+}
+
+func (e *Error) Error() string {
+	if len(e.Failures) == 1 {
+		return e.Failures[0].Error()
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d errors occurred generating code:", len(e.Failures))
+	for _, failure := range e.Failures {
+		sb.WriteString("\n- ")
+		sb.WriteString(failure.Error())
+	}
+	return sb.String()
+}
+
+func (e *Error) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, failure := range e.Failures {
+		errs[i] = failure
+	}
+	return errs
+}
+
+// add records a failure to generate code for artifact
+func (e *Error) add(artifact string, pos openapi.Position, err error) {
+	e.Failures = append(e.Failures, &Failure{
+		Artifact: artifact,
+		Position: pos,
+		Err:      err,
+	})
+}
+
+// merge records the failures from err, returning false if err doesn't hold
+// any, in which case it should be handled as a fatal error instead
+func (e *Error) merge(err error) bool {
+	var genErr *Error
+	if !errors.As(err, &genErr) {
+		return false
+	}
+	e.Failures = append(e.Failures, genErr.Failures...)
+	return true
+}
+
+// errOrNil returns e if any failures have been recorded, and nil otherwise,
+// so that callers don't return a non-nil error interface holding no failures
+func (e *Error) errOrNil() error {
+	if len(e.Failures) == 0 {
+		return nil
+	}
+	return e
+}
@@ -0,0 +1,176 @@
+package codegen
+
+import (
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"gopkg.in/yaml.v3"
+)
+
+// maxExampleDepth limits how deeply nested schemas are followed when generating an example, so that recursive schemas end
+const maxExampleDepth = 8
+
+// exampleValue returns a minimal value which is valid for the schema, such as to send in a request. It's the schema's `const`, `example` or `default`, if it has one, or otherwise the first of its `enum` values, or the smallest value of its type which meets its constraints. Only the required properties of an object are set, and arrays have a single item, unless they need more
+func exampleValue(schema *base.Schema, depth int) interface{} {
+	if schema == nil || depth > maxExampleDepth {
+		return nil
+	}
+
+	nodes := []*yaml.Node{schema.Const, schema.Example}
+	if len(schema.Examples) > 0 {
+		nodes = append(nodes, schema.Examples[0])
+	}
+	nodes = append(nodes, schema.Default)
+	if len(schema.Enum) > 0 {
+		nodes = append(nodes, schema.Enum[0])
+	}
+	for _, node := range nodes {
+		if node == nil {
+			continue
+		}
+		var value interface{}
+		if err := node.Decode(&value); err == nil {
+			return value
+		}
+	}
+
+	if len(schema.AllOf) > 0 {
+		merged := make(map[string]interface{})
+		for _, proxy := range schema.AllOf {
+			if object, ok := exampleValue(proxy.Schema(), depth+1).(map[string]interface{}); ok {
+				for name, value := range object {
+					merged[name] = value
+				}
+			}
+		}
+		if object, ok := exampleObject(schema, depth).(map[string]interface{}); ok {
+			for name, value := range object {
+				merged[name] = value
+			}
+		}
+		return merged
+	}
+	for _, variants := range [][]*base.SchemaProxy{schema.OneOf, schema.AnyOf} {
+		if len(variants) > 0 {
+			return exampleValue(variants[0].Schema(), depth+1)
+		}
+	}
+
+	switch exampleType(schema) {
+	case "object":
+		return exampleObject(schema, depth)
+	case "array":
+		var items *base.Schema
+		if schema.Items != nil && schema.Items.IsA() {
+			items = schema.Items.A.Schema()
+		}
+		n := int64(1)
+		if schema.MinItems != nil && *schema.MinItems > n {
+			n = *schema.MinItems
+		}
+		values := make([]interface{}, n)
+		for i := range values {
+			values[i] = exampleValue(items, depth+1)
+		}
+		return values
+	case "string":
+		return exampleString(schema)
+	case "integer":
+		return int64(exampleNumber(schema, true))
+	case "number":
+		return exampleNumber(schema, false)
+	case "boolean":
+		return true
+	}
+	return nil
+}
+
+// exampleType returns the type of the schema, other than `null`, inferring it from its keywords if it isn't given
+func exampleType(schema *base.Schema) string {
+	if types := (&openapi.Schema{Schema: schema}).NonNullTypes(); len(types) > 0 {
+		return types[0]
+	}
+	switch {
+	case schema.Properties != nil:
+		return "object"
+	case schema.Items != nil:
+		return "array"
+	}
+	return ""
+}
+
+// exampleObject returns an object with the schema's required properties set
+func exampleObject(schema *base.Schema, depth int) interface{} {
+	object := make(map[string]interface{})
+	if schema.Properties == nil {
+		return object
+	}
+	for pair := schema.Properties.First(); pair != nil; pair = pair.Next() {
+		if !slices.Contains(schema.Required, pair.Key()) {
+			continue
+		}
+		object[pair.Key()] = exampleValue(pair.Value().Schema(), depth+1)
+	}
+	return object
+}
+
+// exampleFormats are examples of the string formats whose values are checked
+var exampleFormats = map[string]string{
+	"date":      "2006-01-02",
+	"date-time": "2006-01-02T15:04:05Z",
+	"time":      "15:04:05Z",
+	"email":     "user@example.com",
+	"uuid":      "00000000-0000-0000-0000-000000000000",
+	"uri":       "https://example.com",
+	"url":       "https://example.com",
+	"hostname":  "example.com",
+	"ipv4":      "192.0.2.1",
+	"ipv6":      "2001:db8::1",
+	"byte":      "ZXhhbXBsZQ==",
+}
+
+// exampleString returns a string of the schema's format, or which is as long as its `minLength` requires
+func exampleString(schema *base.Schema) string {
+	if example, ok := exampleFormats[schema.Format]; ok {
+		return example
+	}
+	n := int64(1)
+	if schema.MinLength != nil && *schema.MinLength > n {
+		n = *schema.MinLength
+	}
+	if schema.MaxLength != nil && *schema.MaxLength < n {
+		n = *schema.MaxLength
+	}
+	return strings.Repeat("a", int(n))
+}
+
+// exampleNumber returns 1, or the smallest number which meets the schema's `minimum`, if it's more than 1, or its `maximum`, if it's less, rounded up to its `multipleOf`
+func exampleNumber(schema *base.Schema, integer bool) float64 {
+	step := 1.0
+	if !integer {
+		step = 0.5
+	}
+	value := 1.0
+	bounds := (&openapi.Schema{Schema: schema}).Bounds()
+	if bounds.Minimum != nil && *bounds.Minimum >= value {
+		value = *bounds.Minimum
+		if bounds.ExclusiveMinimum {
+			value += step
+		}
+	} else if bounds.Maximum != nil && *bounds.Maximum <= value {
+		value = *bounds.Maximum
+		if bounds.ExclusiveMaximum {
+			value -= step
+		}
+	}
+	if schema.MultipleOf != nil && *schema.MultipleOf > 0 {
+		value = math.Ceil(value / *schema.MultipleOf) * *schema.MultipleOf
+	}
+	if integer {
+		value = math.Ceil(value)
+	}
+	return value
+}
@@ -2,7 +2,10 @@ package codegen
 
 import (
 	"fmt"
+
 	"gopkg.in/yaml.v3"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
 )
 
 const (
@@ -25,9 +28,31 @@ const (
 	extEnumNames         = "x-enumNames"
 	extDeprecationReason = "x-deprecated-reason"
 	extOrder             = "x-order"
+	// extEnumDescriptions documents each of the values of an enum, in the same order, as x-enum-varnames names them
+	extEnumDescriptions = "x-enum-descriptions"
+	// extEnumDescriptionsCamelCase is the alternative spelling of x-enum-descriptions, as x-enumNames is of x-enum-varnames
+	extEnumDescriptionsCamelCase = "x-enumDescriptions"
 	// extOapiCodegenOnlyHonourGoName is to be used to explicitly enforce the generation of a field as the `x-go-name` extension has describe it.
 	// This is intended to be used alongside the `allow-unexported-struct-field-names` Compatibility option
 	extOapiCodegenOnlyHonourGoName = "x-oapi-codegen-only-honour-go-name"
+	// extSensitive marks a parameter or property as holding a secret, so that its value is redacted wherever the generated code would otherwise reveal it, such as in logs
+	extSensitive = "x-sensitive"
+	// extAdditionalProperties overrides how an object with `additionalProperties` is represented, with one of the `AdditionalPropertiesRepresentation` constants
+	extAdditionalProperties = "x-oapi-codegen-additional-properties"
+	// extOapiCodegenSkip excludes an operation, schema or property from generation
+	extOapiCodegenSkip = "x-oapi-codegen-skip"
+	// extGoEmbed embeds the type of a referenced schema, as a member of an allOf or as a property, anonymously in the generated struct
+	extGoEmbed = "x-go-embed"
+	// extGoJSONString encodes the value of a numeric or boolean property as a JSON string, with the `,string` option of its `json` tag
+	extGoJSONString = "x-go-json-string"
+	// extLRO marks an operation as starting a long-running operation, naming the operation which returns its status, for which the client gets a `WaitFor<Operation>` function to poll it until it finishes
+	extLRO = "x-lro"
+	// extBatch marks an operation whose request body is a JSON array, for which the client gets an `<Operation>InBatches` method to send a large number of items in several requests
+	extBatch = "x-batch"
+	// extRetryable overrides whether the client's retry policy retries an operation, which by default are those whose method is safe or idempotent
+	extRetryable = "x-retryable"
+	// extMaxURLLength limits the length of the URL of a request to an operation, beyond which the client sends the query to a fallback operation, or returns an error, rather than have the server reject it
+	extMaxURLLength = "x-max-url-length"
 )
 
 // Helper function to decode YAML nodes to Go values
@@ -145,6 +170,66 @@ func extParseDeprecationReason(extPropValue interface{}) (string, error) {
 	return extString(extPropValue)
 }
 
+func extParseOapiCodegenSkip(extPropValue interface{}) (bool, error) {
+	var result bool
+	if err := decodeYamlNode(extPropValue, &result); err != nil {
+		return false, err
+	}
+	return result, nil
+}
+
+// isSkipped returns whether the extensions mark what they belong to as excluded from generation, with x-oapi-codegen-skip
+func isSkipped(extensions map[string]interface{}) (bool, error) {
+	extension, ok := extensions[extOapiCodegenSkip]
+	if !ok {
+		return false, nil
+	}
+	skip, err := extParseOapiCodegenSkip(extension)
+	if err != nil {
+		return false, fmt.Errorf("invalid value for %q: %w", extOapiCodegenSkip, err)
+	}
+	return skip, nil
+}
+
+func extParseGoEmbed(extPropValue interface{}) (bool, error) {
+	var result bool
+	if err := decodeYamlNode(extPropValue, &result); err != nil {
+		return false, err
+	}
+	return result, nil
+}
+
+// isEmbedded returns whether a reference to another schema is marked with x-go-embed, either alongside the reference, or on the referenced schema itself, for every reference to it
+func isEmbedded(sref *openapi.SchemaRef) (bool, error) {
+	if sref == nil || sref.Ref == "" {
+		return false, nil
+	}
+	var extension interface{}
+	var ok bool
+	if sref.Siblings != nil {
+		extension, ok = sref.Siblings.Extensions[extGoEmbed]
+	}
+	if !ok && sref.Value != nil {
+		extension, ok = sref.Value.Extensions[extGoEmbed]
+	}
+	if !ok {
+		return false, nil
+	}
+	embed, err := extParseGoEmbed(extension)
+	if err != nil {
+		return false, fmt.Errorf("invalid value for %q: %w", extGoEmbed, err)
+	}
+	return embed, nil
+}
+
+func extParseGoJSONString(extPropValue interface{}) (bool, error) {
+	var result bool
+	if err := decodeYamlNode(extPropValue, &result); err != nil {
+		return false, err
+	}
+	return result, nil
+}
+
 func extParseOapiCodegenOnlyHonourGoName(extPropValue interface{}) (bool, error) {
 	var result bool
 	if err := decodeYamlNode(extPropValue, &result); err != nil {
@@ -152,3 +237,83 @@ func extParseOapiCodegenOnlyHonourGoName(extPropValue interface{}) (bool, error)
 	}
 	return result, nil
 }
+
+func extParseAdditionalProperties(extPropValue interface{}) (AdditionalPropertiesRepresentation, error) {
+	value, err := extString(extPropValue)
+	if err != nil {
+		return "", err
+	}
+	switch representation := AdditionalPropertiesRepresentation(value); representation {
+	case AdditionalPropertiesRepresentationMap, AdditionalPropertiesRepresentationNamedMap, AdditionalPropertiesRepresentationStruct:
+		return representation, nil
+	default:
+		return "", fmt.Errorf("unknown representation %q, expected one of `map`, `named-map` or `struct`", value)
+	}
+}
+
+func extParseSensitive(extPropValue interface{}) (bool, error) {
+	var result bool
+	if err := decodeYamlNode(extPropValue, &result); err != nil {
+		return false, err
+	}
+	return result, nil
+}
+
+func extParseBatch(extPropValue interface{}) (bool, error) {
+	var result bool
+	if err := decodeYamlNode(extPropValue, &result); err != nil {
+		return false, err
+	}
+	return result, nil
+}
+
+func extParseRetryable(extPropValue interface{}) (bool, error) {
+	var result bool
+	if err := decodeYamlNode(extPropValue, &result); err != nil {
+		return false, err
+	}
+	return result, nil
+}
+
+// lroExtension is the value of `x-lro`, which is either the operationId of the status operation, or an object naming it along with how to tell when the long-running operation has finished
+type lroExtension struct {
+	Operation      string   `yaml:"operation"`
+	StatusProperty string   `yaml:"status-property"`
+	TerminalStates []string `yaml:"terminal-states"`
+}
+
+func extParseLRO(extPropValue interface{}) (lroExtension, error) {
+	var result lroExtension
+	if node, ok := extPropValue.(*yaml.Node); ok && node.Kind == yaml.MappingNode {
+		if err := node.Decode(&result); err != nil {
+			return result, err
+		}
+	} else if err := decodeYamlNode(extPropValue, &result.Operation); err != nil {
+		return result, err
+	}
+	if result.Operation == "" {
+		return result, fmt.Errorf("the status operation's operationId is required")
+	}
+	return result, nil
+}
+
+// maxURLLengthExtension is the value of `x-max-url-length`, which is either the longest URL which is sent, or an object with it along with the operationId of the `POST` operation the query is sent to instead
+type maxURLLengthExtension struct {
+	MaxLength int    `yaml:"max-length"`
+	Fallback  string `yaml:"fallback"`
+}
+
+func extParseMaxURLLength(extPropValue interface{}) (maxURLLengthExtension, error) {
+	var result maxURLLengthExtension
+	if node, ok := extPropValue.(*yaml.Node); ok && node.Kind == yaml.MappingNode {
+		if err := node.Decode(&result); err != nil {
+			return result, err
+		}
+	} else if err := decodeYamlNode(extPropValue, &result.MaxLength); err != nil {
+		return result, err
+	}
+	if result.MaxLength <= 0 {
+		return result, fmt.Errorf("the maximum length must be positive")
+	}
+	return result, nil
+}
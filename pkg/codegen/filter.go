@@ -1,6 +1,10 @@
 package codegen
 
-import "github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+import (
+	"fmt"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
 
 func sliceToMap(items []string) map[string]bool {
 	m := make(map[string]bool, len(items))
@@ -10,25 +14,118 @@ func sliceToMap(items []string) map[string]bool {
 	return m
 }
 
-func filterOperationsByTag(swagger *openapi.T, opts Configuration) {
-	if len(opts.OutputOptions.ExcludeTags) > 0 {
-		operationsWithTags(swagger.Paths, sliceToMap(opts.OutputOptions.ExcludeTags), true)
+// filterablePathItems returns the path items whose operations are filtered, by
+// their location, which are those of the paths, the webhooks and the
+// components, along with those of the callbacks of their operations.
+func filterablePathItems(swagger *openapi.T) map[string]*openapi.PathItem {
+	pathItems := make(map[string]*openapi.PathItem)
+	if swagger == nil {
+		return pathItems
 	}
-	if len(opts.OutputOptions.IncludeTags) > 0 {
-		operationsWithTags(swagger.Paths, sliceToMap(opts.OutputOptions.IncludeTags), false)
+
+	var add func(location string, pathItem *openapi.PathItem)
+	addCallback := func(location string, callback *openapi.CallbackRef) {
+		if callback == nil || callback.Value == nil {
+			return
+		}
+		for expression, pathItem := range callback.Value.Map() {
+			add(fmt.Sprintf("%s %s", location, expression), pathItem)
+		}
+	}
+	add = func(location string, pathItem *openapi.PathItem) {
+		if pathItem == nil {
+			return
+		}
+		pathItems[location] = pathItem
+		for method, op := range pathItem.Operations() {
+			for name, callback := range op.Callbacks {
+				addCallback(fmt.Sprintf("%s %s callback %s", location, method, name), callback)
+			}
+		}
+	}
+
+	if swagger.Paths != nil {
+		for path, pathItem := range swagger.Paths.Map() {
+			add(path, pathItem)
+		}
+	}
+	for name, pathItem := range swagger.Webhooks {
+		add("webhook "+name, pathItem)
+	}
+	if swagger.Components != nil {
+		for name, pathItem := range swagger.Components.PathItems {
+			if pathItem != nil {
+				add("#/components/pathItems/"+name, pathItem.Value)
+			}
+		}
+		for name, callback := range swagger.Components.Callbacks {
+			addCallback("#/components/callbacks/"+name, callback)
+		}
+	}
+	return pathItems
+}
+
+// pathsWithOperations returns the paths and webhooks which have any operations, by their location as filterablePathItems has them
+func pathsWithOperations(swagger *openapi.T) map[string]bool {
+	locations := make(map[string]bool)
+	if swagger == nil {
+		return locations
+	}
+	if swagger.Paths != nil {
+		for path, pathItem := range swagger.Paths.Map() {
+			if len(pathItem.Operations()) > 0 {
+				locations[path] = true
+			}
+		}
+	}
+	for name, pathItem := range swagger.Webhooks {
+		if pathItem != nil && len(pathItem.Operations()) > 0 {
+			locations["webhook "+name] = true
+		}
 	}
+	return locations
 }
 
-func operationsWithTags(paths *openapi.Paths, tags map[string]bool, exclude bool) {
-	if paths == nil {
+// removeFilteredPaths removes the paths and webhooks which had operations, as pathsWithOperations returned before filtering, but have none left, so that neither the generated code nor the embedded spec has them
+func removeFilteredPaths(swagger *openapi.T, hadOperations map[string]bool) {
+	if swagger == nil {
 		return
 	}
+	if swagger.Paths != nil {
+		for path, pathItem := range swagger.Paths.Map() {
+			if hadOperations[path] && len(pathItem.Operations()) == 0 {
+				logger().Debug("removed path without operations", "path", path)
+				swagger.Paths.Delete(path)
+			}
+		}
+	}
+	for name, pathItem := range swagger.Webhooks {
+		if hadOperations["webhook "+name] && pathItem != nil && len(pathItem.Operations()) == 0 {
+			logger().Debug("removed webhook without operations", "webhook", name)
+			delete(swagger.Webhooks, name)
+			if swagger.Document != nil && swagger.Document.Webhooks != nil {
+				swagger.Document.Webhooks.Delete(name)
+			}
+		}
+	}
+}
+
+func filterOperationsByTag(swagger *openapi.T, opts Configuration) {
+	if len(opts.OutputOptions.ExcludeTags) > 0 {
+		operationsWithTags(filterablePathItems(swagger), sliceToMap(opts.OutputOptions.ExcludeTags), true)
+	}
+	if len(opts.OutputOptions.IncludeTags) > 0 {
+		operationsWithTags(filterablePathItems(swagger), sliceToMap(opts.OutputOptions.IncludeTags), false)
+	}
+}
 
-	for _, pathItem := range paths.Map() {
+func operationsWithTags(pathItems map[string]*openapi.PathItem, tags map[string]bool, exclude bool) {
+	for path, pathItem := range pathItems {
 		ops := pathItem.Operations()
 		names := make([]string, 0, len(ops))
 		for name, op := range ops {
 			if operationHasTag(op, tags) == exclude {
+				logger().Debug("filtered operation by tag", "method", name, "path", path, "tags", op.Tags)
 				names = append(names, name)
 			}
 		}
@@ -51,25 +148,66 @@ func operationHasTag(op *openapi.Operation, tags map[string]bool) bool {
 	return false
 }
 
+// filterSkippedOperations removes the operations marked with x-oapi-codegen-skip
+func filterSkippedOperations(swagger *openapi.T) error {
+	for path, pathItem := range filterablePathItems(swagger) {
+		ops := pathItem.Operations()
+		names := make([]string, 0, len(ops))
+		for name, op := range ops {
+			skip, err := isSkipped(op.Extensions)
+			if err != nil {
+				return fmt.Errorf("%s %s: %w", name, path, err)
+			}
+			if skip {
+				logger().Debug("skipped operation", "method", name, "path", path, "operationId", op.OperationID)
+				names = append(names, name)
+			}
+		}
+		for _, name := range names {
+			pathItem.SetOperation(name, nil)
+		}
+	}
+	return nil
+}
+
+// skippedSchemas returns the names of the component schemas marked with x-oapi-codegen-skip, which are excluded from generation as those in `exclude-schemas` are
+func skippedSchemas(swagger *openapi.T) ([]string, error) {
+	if swagger == nil || swagger.Components == nil {
+		return nil, nil
+	}
+	var names []string
+	for _, name := range SortedSchemaKeys(swagger.Components.Schemas) {
+		schemaRef := swagger.Components.Schemas[name]
+		if schemaRef == nil || schemaRef.Ref != "" || schemaRef.Value == nil {
+			continue
+		}
+		skip, err := isSkipped(schemaRef.Value.Extensions)
+		if err != nil {
+			return nil, fmt.Errorf("components/schemas/%s: %w", name, err)
+		}
+		if skip {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
 func filterOperationsByOperationID(swagger *openapi.T, opts Configuration) {
 	if len(opts.OutputOptions.ExcludeOperationIDs) > 0 {
-		operationsWithOperationIDs(swagger.Paths, sliceToMap(opts.OutputOptions.ExcludeOperationIDs), true)
+		operationsWithOperationIDs(filterablePathItems(swagger), sliceToMap(opts.OutputOptions.ExcludeOperationIDs), true)
 	}
 	if len(opts.OutputOptions.IncludeOperationIDs) > 0 {
-		operationsWithOperationIDs(swagger.Paths, sliceToMap(opts.OutputOptions.IncludeOperationIDs), false)
+		operationsWithOperationIDs(filterablePathItems(swagger), sliceToMap(opts.OutputOptions.IncludeOperationIDs), false)
 	}
 }
 
-func operationsWithOperationIDs(paths *openapi.Paths, operationIDs map[string]bool, exclude bool) {
-	if paths == nil {
-		return
-	}
-
-	for _, pathItem := range paths.Map() {
+func operationsWithOperationIDs(pathItems map[string]*openapi.PathItem, operationIDs map[string]bool, exclude bool) {
+	for path, pathItem := range pathItems {
 		ops := pathItem.Operations()
 		names := make([]string, 0, len(ops))
 		for name, op := range ops {
 			if operationHasOperationID(op, operationIDs) == exclude {
+				logger().Debug("filtered operation by operationId", "method", name, "path", path, "operationId", op.OperationID)
 				names = append(names, name)
 			}
 		}
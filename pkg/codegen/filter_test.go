@@ -1,10 +1,14 @@
 package codegen
 
 import (
+	"bytes"
+	"log/slog"
+	"strings"
 	"testing"
 
 	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFilterOperationsByTag(t *testing.T) {
@@ -68,6 +72,35 @@ func TestFilterOperationsByTag(t *testing.T) {
 	})
 }
 
+func TestFilterClonedSpec(t *testing.T) {
+	loader := openapi.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	swagger, err := loader.LoadFromData([]byte(testOpenAPIDefinition))
+	require.NoError(t, err)
+	schemas := len(swagger.Components.Schemas)
+
+	clone, err := swagger.Clone()
+	require.NoError(t, err)
+	code, err := Generate(clone, Configuration{
+		PackageName: "testswagger",
+		Generate: GenerateOptions{
+			Client: true,
+			Models: true,
+		},
+		OutputOptions: OutputOptions{
+			IncludeTags: []string{"cat"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, code, `"/cat"`)
+	assert.NotContains(t, code, `"/test/%s"`)
+
+	// Filtering and pruning the clone leaves the original whole
+	assert.Nil(t, clone.Paths.Find("/test/{name}"), "the path is removed, as it has no operations left")
+	assert.NotNil(t, swagger.Paths.Find("/test/{name}").GetOperation("GET"))
+	assert.Len(t, swagger.Components.Schemas, schemas)
+}
+
 func TestFilterOperationsByOperationID(t *testing.T) {
 	packageName := "testswagger"
 	t.Run("include operation ids", func(t *testing.T) {
@@ -128,3 +161,221 @@ func TestFilterOperationsByOperationID(t *testing.T) {
 		assert.NotContains(t, code, `"/cat"`)
 	})
 }
+
+func TestFilterOperationsLogging(t *testing.T) {
+	var buf bytes.Buffer
+	opts := Configuration{
+		PackageName: "testswagger",
+		Generate: GenerateOptions{
+			Models: true,
+		},
+		OutputOptions: OutputOptions{
+			IncludeTags: []string{"hippo", "giraffe", "cat"},
+		},
+		Logger: slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+
+	loader := openapi.NewLoader()
+	swagger, err := loader.LoadFromData([]byte(testOpenAPIDefinition))
+	assert.NoError(t, err)
+
+	_, err = Generate(swagger, opts)
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), `msg="filtered operation by tag"`)
+	assert.Contains(t, buf.String(), `path=/test/{name}`)
+}
+
+const webhooksAndCallbacksSpec = `openapi: 3.1.0
+info:
+  title: webhooks and callbacks
+  version: 1.0.0
+paths:
+  /subscriptions:
+    post:
+      operationId: subscribe
+      tags: [subscriptions]
+      responses:
+        '201':
+          description: Subscribed
+      callbacks:
+        onEvent:
+          '{$request.body#/callbackUrl}':
+            post:
+              operationId: eventCallback
+              tags: [events]
+              requestBody:
+                content:
+                  application/json:
+                    schema:
+                      $ref: '#/components/schemas/Event'
+              responses:
+                '200':
+                  description: Received
+webhooks:
+  newPet:
+    post:
+      operationId: newPetWebhook
+      tags: [pets]
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '200':
+          description: Received
+components:
+  schemas:
+    Event:
+      type: object
+      properties:
+        name:
+          type: string
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+  pathItems:
+    petEvents:
+      post:
+        operationId: petEvents
+        tags: [pets]
+        responses:
+          '200':
+            description: Received
+`
+
+func TestFilterWebhooksAndCallbacks(t *testing.T) {
+	load := func(t *testing.T) *openapi.T {
+		t.Helper()
+		swagger, err := openapi.NewLoader().LoadFromData([]byte(webhooksAndCallbacksSpec))
+		require.NoError(t, err)
+		return swagger
+	}
+
+	t.Run("exclude tags", func(t *testing.T) {
+		swagger := load(t)
+		filterOperationsByTag(swagger, Configuration{
+			OutputOptions: OutputOptions{
+				ExcludeTags: []string{"pets", "events"},
+			},
+		})
+
+		assert.NotNil(t, swagger.Paths.Value("/subscriptions").Post)
+		assert.Nil(t, swagger.Webhooks["newPet"].Post)
+		assert.Nil(t, swagger.Components.PathItems["petEvents"].Value.Post)
+
+		callback := swagger.Paths.Value("/subscriptions").Operations()["POST"].Callbacks["onEvent"]
+		require.NotNil(t, callback)
+		assert.Nil(t, callback.Value.Map()["{$request.body#/callbackUrl}"].Post)
+	})
+
+	t.Run("include operation ids", func(t *testing.T) {
+		swagger := load(t)
+		filterOperationsByOperationID(swagger, Configuration{
+			OutputOptions: OutputOptions{
+				IncludeOperationIDs: []string{"subscribe", "newPetWebhook"},
+			},
+		})
+
+		assert.NotNil(t, swagger.Paths.Value("/subscriptions").Post)
+		assert.NotNil(t, swagger.Webhooks["newPet"].Post)
+		assert.Nil(t, swagger.Components.PathItems["petEvents"].Value.Post)
+	})
+}
+
+const skipExtensionSpec = `openapi: 3.0.0
+info:
+  title: skip
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+  /internal/debug:
+    get:
+      operationId: debugState
+      x-oapi-codegen-skip: true
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/DebugState'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+        internalNotes:
+          type: string
+          x-oapi-codegen-skip: true
+        owner:
+          $ref: '#/components/schemas/Owner'
+          x-oapi-codegen-skip: true
+        tag:
+          $ref: '#/components/schemas/Tag'
+    Owner:
+      type: object
+      properties:
+        name:
+          type: string
+    Tag:
+      type: string
+      x-oapi-codegen-skip: true
+    DebugState:
+      type: object
+      properties:
+        goroutines:
+          type: integer
+`
+
+func TestSkipExtension(t *testing.T) {
+	generate := func(spec string) (string, error) {
+		swagger, err := openapi.NewLoader().LoadFromData([]byte(spec))
+		require.NoError(t, err)
+
+		return Generate(swagger, Configuration{
+			PackageName: "api",
+			Generate: GenerateOptions{
+				Models: true,
+				Client: true,
+			},
+		})
+	}
+
+	code, err := generate(skipExtensionSpec)
+	require.NoError(t, err)
+
+	t.Run("operations are skipped", func(t *testing.T) {
+		assert.Contains(t, code, "func (c *Client) ListPets(")
+		assert.NotContains(t, code, "DebugState(")
+	})
+
+	t.Run("properties are skipped", func(t *testing.T) {
+		assert.Contains(t, code, "Name *string")
+		assert.NotContains(t, code, "InternalNotes")
+		assert.NotContains(t, code, "*Owner")
+	})
+
+	t.Run("schemas are skipped, but can still be referenced", func(t *testing.T) {
+		assert.Contains(t, code, "Tag  *Tag")
+		assert.NotContains(t, code, "type Tag ")
+	})
+
+	t.Run("invalid values are errors", func(t *testing.T) {
+		_, err := generate(strings.Replace(skipExtensionSpec, "x-oapi-codegen-skip: true\n      responses", "x-oapi-codegen-skip: maybe\n      responses", 1))
+		assert.ErrorContains(t, err, `GET /internal/debug: invalid value for "x-oapi-codegen-skip"`)
+	})
+}
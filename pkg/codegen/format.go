@@ -0,0 +1,125 @@
+package codegen
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/imports"
+	gofumpt "mvdan.cc/gofumpt/format"
+)
+
+// Formatter is the tool used to format the generated code. Corresponds with the `formatter` output option
+type Formatter string
+
+const (
+	// FormatterUnset is the default case, where the `formatter` option hasn't been set. This behaves as `FormatterGoimports`.
+	FormatterUnset Formatter = ""
+	// FormatterGoimports formats the code as `goimports` does, which also adds any missing imports, and removes any unused imports, such as those from user templates or `import-mapping`.
+	FormatterGoimports Formatter = "goimports"
+	// FormatterGofmt removes any unused imports, then formats the code as `gofmt` does. This is quicker than `goimports`, as it doesn't look for missing imports, so requires any imports needed by user templates to be added with `additional-imports`.
+	FormatterGofmt Formatter = "gofmt"
+	// FormatterGofumpt fixes the imports as `goimports` does, then formats the code with the stricter rules of `gofumpt`.
+	FormatterGofumpt Formatter = "gofumpt"
+	// FormatterNone doesn't format the code at all, which can be useful when debugging templates, as the code is output even if it isn't valid Go. This is the same as setting `skip-fmt`.
+	FormatterNone Formatter = "none"
+)
+
+// formatCode formats the generated code with the given formatter
+func formatCode(code, packageName string, formatter Formatter) (string, error) {
+	out, err := formatSource([]byte(code), packageName, formatter)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// formatSource formats code as formatCode does, without copying it to and from a string, for the code of a whole spec
+func formatSource(code []byte, packageName string, formatter Formatter) ([]byte, error) {
+	switch formatter {
+	case FormatterNone:
+		return code, nil
+	case FormatterGofmt:
+		return gofmtSource(code)
+	case FormatterGofumpt:
+		out, err := imports.Process(packageName+".go", code, nil)
+		if err != nil {
+			return nil, err
+		}
+		return gofumpt.Source(out, gofumpt.Options{})
+	default:
+		return imports.Process(packageName+".go", code, nil)
+	}
+}
+
+// gofmtSource removes the unused imports from code, as the imports template imports every package the generated code could need, then formats it as `gofmt` does
+func gofmtSource(code []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", code, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	// The packages referred to, as the `X` in `X.Sel`, which aren't declared in the file
+	used := make(map[string]bool)
+	ast.Inspect(file, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if x, ok := sel.X.(*ast.Ident); ok && x.Obj == nil {
+				used[x.Name] = true
+			}
+		}
+		return true
+	})
+
+	// Deleting an import removes it from file.Imports, so loop over a copy of them
+	for _, spec := range append([]*ast.ImportSpec(nil), file.Imports...) {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+		name := importPathToAssumedName(importPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		if name == "_" || name == "." || used[name] {
+			continue
+		}
+		if spec.Name != nil {
+			astutil.DeleteNamedImport(fset, file, spec.Name.Name, importPath)
+		} else {
+			astutil.DeleteImport(fset, file, importPath)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// importPathToAssumedName returns the name of the package imported by importPath, when it isn't given a name, as `goimports` assumes it to be, without loading the package, such as `echo` for `github.com/labstack/echo/v4`, and `yaml` for `gopkg.in/yaml.v2`
+func importPathToAssumedName(importPath string) string {
+	base := path.Base(importPath)
+	if strings.HasPrefix(base, "v") {
+		if _, err := strconv.Atoi(base[1:]); err == nil {
+			if dir := path.Dir(importPath); dir != "." {
+				base = path.Base(dir)
+			}
+		}
+	}
+	base = strings.TrimPrefix(base, "go-")
+	if i := strings.IndexFunc(base, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	}); i >= 0 {
+		base = base[:i]
+	}
+	return base
+}
@@ -0,0 +1,90 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+const unformattedCode = `package api
+
+import (
+	"fmt"
+	"strings"
+)
+
+func Hello(name string) string {
+
+	return fmt.Sprintf("Hello, %s", name)
+}
+`
+
+func TestFormatCode(t *testing.T) {
+	t.Run("goimports removes unused imports", func(t *testing.T) {
+		for _, formatter := range []Formatter{FormatterUnset, FormatterGoimports} {
+			code, err := formatCode(unformattedCode, "api", formatter)
+			require.NoError(t, err)
+			assert.NotContains(t, code, `"strings"`)
+			assert.Contains(t, code, "{\n\n\treturn")
+		}
+	})
+
+	t.Run("gofmt removes unused imports", func(t *testing.T) {
+		code, err := formatCode(unformattedCode, "api", FormatterGofmt)
+		require.NoError(t, err)
+		assert.NotContains(t, code, `"strings"`)
+		assert.Contains(t, code, `"fmt"`)
+		assert.Contains(t, code, "{\n\n\treturn")
+	})
+
+	t.Run("gofumpt fixes the imports, and applies its stricter rules", func(t *testing.T) {
+		code, err := formatCode(unformattedCode, "api", FormatterGofumpt)
+		require.NoError(t, err)
+		assert.NotContains(t, code, `"strings"`)
+		assert.Contains(t, code, "{\n\treturn", "the empty line at the start of the function is removed")
+	})
+
+	t.Run("none leaves the code as it is", func(t *testing.T) {
+		code, err := formatCode(unformattedCode, "api", FormatterNone)
+		require.NoError(t, err)
+		assert.Equal(t, unformattedCode, code)
+	})
+}
+
+func TestFormatterValidation(t *testing.T) {
+	problems := OutputOptions{Formatter: "prettier"}.Validate()
+	assert.Contains(t, problems, "formatter")
+
+	problems = OutputOptions{Formatter: "gofumpt", SkipFmt: true}.Validate()
+	assert.Contains(t, problems, "skip-fmt")
+
+	problems = OutputOptions{Formatter: "gofumpt"}.Validate()
+	assert.Empty(t, problems)
+}
+
+func TestGenerateWithGofmt(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(testOpenAPIDefinition))
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			EchoServer: true,
+			Client:     true,
+			Models:     true,
+		},
+		OutputOptions: OutputOptions{Formatter: string(FormatterGofmt)},
+	})
+	require.NoError(t, err)
+
+	// The imports template imports every package the code could need, so those which aren't used must be removed
+	assert.Contains(t, code, `"github.com/labstack/echo/v4"`)
+	assert.Contains(t, code, `"gopkg.in/yaml.v2"`)
+	assert.NotContains(t, code, `"golang.org/x/oauth2"`)
+	assert.NotContains(t, code, `"net/http/httptest"`)
+	assert.NotContains(t, code, `"github.com/gin-gonic/gin"`)
+	assert.NotContains(t, code, `"testing"`)
+}
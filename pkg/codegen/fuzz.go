@@ -0,0 +1,398 @@
+package codegen
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// FuzzTypeDefinition is a type which has a random value generator, and a fuzz helper, generated for it
+type FuzzTypeDefinition struct {
+	TypeName string
+	// Random is the body of the `Random<TypeName>` function
+	Random string
+}
+
+// fuzzGenerator generates the statements which set values to random values which conform to their schemas, by walking the schemas of each type
+type fuzzGenerator struct {
+	// methods are the types which get `Random` functions
+	methods map[string]bool
+	// aliases are the schemas of the types which are defined as an alias, which don't get functions of their own
+	aliases map[string]Schema
+}
+
+// GenerateFuzzBoilerplate generates, as configured with the `fuzz` option, a `Random<Type>` function for each of the types which isn't defined as an alias, which returns a random value which conforms to its schema, along with a `Generate` method, so that the type implements `testing/quick`'s `Generator`, and a `RunFuzz<Type>` function, which fuzzes a target with random values of the type
+func GenerateFuzzBoilerplate(t *template.Template, typeDefs []TypeDefinition) (string, error) {
+	if !globalState.options.Generate.Fuzz {
+		return "", nil
+	}
+
+	g := fuzzGenerator{
+		methods: make(map[string]bool),
+		aliases: make(map[string]Schema),
+	}
+	var filteredTypes []TypeDefinition
+	for _, td := range typeDefs {
+		if g.methods[td.TypeName] {
+			continue
+		}
+		if _, found := g.aliases[td.TypeName]; found {
+			continue
+		}
+		if td.IsAlias() {
+			g.aliases[td.TypeName] = td.Schema
+			continue
+		}
+		g.methods[td.TypeName] = true
+		filteredTypes = append(filteredTypes, td)
+	}
+
+	var types []FuzzTypeDefinition
+	for _, td := range filteredTypes {
+		for _, name := range []string{"Random" + td.TypeName, "RunFuzz" + td.TypeName} {
+			if globalState.modelTypes[name] {
+				return "", fmt.Errorf("the function which generates random %s values would be named %s, which is the name of a model. Please rename the model, or disable `fuzz`", td.TypeName, name)
+			}
+		}
+		if slices.ContainsFunc(structFields(td.Schema), func(f copyField) bool { return f.Name == "Generate" }) {
+			return "", fmt.Errorf("%s has a field named Generate, which is the name of the method that implements testing/quick's Generator. Please rename the field with `x-go-name`, or disable `fuzz`", td.TypeName)
+		}
+
+		types = append(types, FuzzTypeDefinition{
+			TypeName: td.TypeName,
+			Random:   strings.Join(g.randomFunction(td), "\n"),
+		})
+	}
+
+	if len(types) == 0 {
+		return "", nil
+	}
+
+	context := struct {
+		Types []FuzzTypeDefinition
+	}{
+		Types: types,
+	}
+
+	return GenerateTemplates([]string{"fuzz.tmpl"}, t, context)
+}
+
+// classify returns how a random value of the schema's type is generated, along with the schema that describes it, following any aliases. The kinds are the same as for validation
+func (g *fuzzGenerator) classify(s Schema) (validateKind, Schema) {
+	v := validationGenerator{methods: g.methods, aliases: g.aliases}
+	return v.classify(s)
+}
+
+func (g *fuzzGenerator) randomFunction(td TypeDefinition) []string {
+	kind, s := g.classify(td.Schema)
+	if kind == validateKindMethods {
+		underlying := s.TypeDecl()
+		if underlying == td.TypeName {
+			return []string{fmt.Sprintf("return %s{}", td.TypeName)}
+		}
+		return []string{fmt.Sprintf("return %s(Random%s(r, size))", td.TypeName, underlying)}
+	}
+	stmts := []string{"var v " + td.TypeName}
+	stmts = append(stmts, g.randomValue("v", td.TypeName, td.Schema, 0)...)
+	return append(stmts, "return v")
+}
+
+// randomValue returns the statements which set x, of the Go type typ, to a random value which conforms to its schema, whose size is bounded by the `size` variable
+func (g *fuzzGenerator) randomValue(x, typ string, s Schema, depth int) []string {
+	kind, s := g.classify(s)
+	switch kind {
+	case validateKindMethods:
+		value := fmt.Sprintf("Random%s(r, max(size-1, 0))", s.TypeDecl())
+		if typ != s.TypeDecl() {
+			value = typ + "(" + value + ")"
+		}
+		return []string{fmt.Sprintf("%s = %s", x, value)}
+	case validateKindString:
+		return g.randomString(x, typ, s)
+	case validateKindNumber:
+		return g.randomNumber(x, typ, s)
+	case validateKindNull:
+		// A `const: null` can only ever be nil
+		return []string{x + " = nil"}
+	case validateKindSlice:
+		i := fmt.Sprintf("i%d", depth)
+		stmts := []string{"{"}
+		stmts = append(stmts, randomLength("n", s, depth)...)
+		stmts = append(stmts,
+			fmt.Sprintf("%s = make(%s, n)", x, typ),
+			fmt.Sprintf("for %s := range %s {", i, x),
+		)
+		stmts = append(stmts, g.randomValue(operand(x)+"["+i+"]", s.ArrayType.TypeDecl(), *s.ArrayType, depth+1)...)
+		return append(stmts, "}", "}")
+	case validateKindMap:
+		i := fmt.Sprintf("i%d", depth)
+		v := fmt.Sprintf("v%d", depth)
+		valueSchema := mapValueSchema(s)
+		keyType := strings.TrimPrefix(s.GoType, "map[")
+		keyType = keyType[:strings.Index(keyType, "]")]
+		stmts := []string{"{"}
+		stmts = append(stmts, randomLength("n", s, depth)...)
+		stmts = append(stmts,
+			fmt.Sprintf("%s = make(%s, n)", x, typ),
+			fmt.Sprintf("for %s := 0; %s < n; %s++ {", i, i, i),
+			fmt.Sprintf("var %s %s", v, valueSchema.TypeDecl()),
+		)
+		stmts = append(stmts, g.randomValue(v, valueSchema.TypeDecl(), valueSchema, depth+1)...)
+		stmts = append(stmts, fmt.Sprintf("%s[%s] = %s", operand(x), convert(keyType, "string", fmt.Sprintf("fmt.Sprintf(\"key%%d\", %s)", i)), v))
+		return append(stmts, "}", "}")
+	case validateKindStruct:
+		var stmts []string
+		for i, f := range structFields(s) {
+			if i < len(s.EmbeddedTypes) && !f.Pointer {
+				// An embedded type is part of the struct, rather than nested within it
+				if kind, _ := g.classify(f.Schema); kind == validateKindMethods {
+					stmts = append(stmts, fmt.Sprintf("%s.%s = Random%s(r, size)", operand(x), f.Name, f.Schema.TypeDecl()))
+					continue
+				}
+			}
+			required := true
+			if p := i - len(s.EmbeddedTypes); p >= 0 && p < len(s.Properties) {
+				required = s.Properties[p].Required
+			}
+			stmts = append(stmts, g.randomField(operand(x)+"."+f.Name, f, required, depth)...)
+		}
+		return append(stmts, g.randomUnion(x, s)...)
+	default:
+		return g.randomOther(x, typ, s)
+	}
+}
+
+// randomField returns the statements which set the field x to a random value. An optional field is only set some of the time, and a nullable field is sometimes null
+func (g *fuzzGenerator) randomField(x string, f copyField, required bool, depth int) []string {
+	typ := f.Schema.TypeDecl()
+	if f.Nullable == "" && !f.Pointer {
+		return g.randomValue(x, typ, f.Schema, depth)
+	}
+
+	v := fmt.Sprintf("v%d", depth)
+	cond := "size > 0 && r.Intn(2) == 0"
+	if required {
+		cond = "size > 0"
+	}
+	stmts := []string{fmt.Sprintf("if %s {", cond), fmt.Sprintf("var %s %s", v, typ)}
+	stmts = append(stmts, g.randomValue(v, typ, f.Schema, depth+1)...)
+	if f.Nullable == "" {
+		return append(stmts, fmt.Sprintf("%s = &%s", x, v), "}")
+	}
+	return append(stmts,
+		fmt.Sprintf("%s = nullable.NewNullableWithValue(%s)", x, v),
+		"} else if r.Intn(2) == 0 {",
+		fmt.Sprintf("%s = nullable.NewNullNullable[%s]()", x, typ),
+		"}",
+	)
+}
+
+// randomUnion returns the statements which set the union inside x to a random one of its variants, of those which are generated here
+func (g *fuzzGenerator) randomUnion(x string, s Schema) []string {
+	var variants []UnionElement
+	for _, element := range s.UnionElements {
+		if kind, _ := g.classify(Schema{GoType: element.String(), RefType: element.String()}); kind == validateKindMethods {
+			variants = append(variants, element)
+		}
+	}
+	if len(variants) == 0 {
+		return nil
+	}
+	stmts := []string{fmt.Sprintf("switch r.Intn(%d) {", len(variants))}
+	for i, variant := range variants {
+		stmts = append(stmts,
+			fmt.Sprintf("case %d:", i),
+			fmt.Sprintf("_ = %s.From%s(Random%s(r, max(size-1, 0)))", operand(x), variant.Method(), variant),
+		)
+	}
+	return append(stmts, "}")
+}
+
+// randomLength returns the statements which declare n, the number of items of an array or map, which is between its minimum and maximum number of items, and no more than size above its minimum
+func randomLength(n string, s Schema, depth int) []string {
+	var minimum, maximum *int64
+	if o := s.OAPISchema; o != nil && o.Schema != nil {
+		minimum, maximum = o.MinItems, o.MaxItems
+		if strings.HasPrefix(s.GoType, "map[") {
+			minimum, maximum = o.MinProperties, o.MaxProperties
+		}
+	}
+	var lo int64
+	if minimum != nil {
+		lo = *minimum
+	}
+	stmts := []string{fmt.Sprintf("%s := %d + r.Intn(size+1)", n, lo)}
+	if maximum != nil {
+		stmts = append(stmts, fmt.Sprintf("%s = min(%s, %d)", n, n, *maximum))
+	}
+	return stmts
+}
+
+// randomString returns the statements which set x to the schema's `const`, or one of its enum values, or an example of its format, or its example, if it has a pattern, or otherwise a random string of letters, as long as its minimum and maximum lengths allow
+func (g *fuzzGenerator) randomString(x, typ string, s Schema) []string {
+	o := s.OAPISchema
+	if o == nil || o.Schema == nil {
+		o = nil
+	}
+
+	if o != nil {
+		if literal, ok := constLiteral(o, "string"); ok {
+			return []string{fmt.Sprintf("%s = %s", x, convert(typ, "string", literal))}
+		}
+		var values []string
+		for _, value := range o.EnumStrings() {
+			literal := strconv.Quote(value)
+			if !slices.Contains(values, literal) {
+				values = append(values, literal)
+			}
+		}
+		if len(values) > 0 {
+			return []string{fmt.Sprintf("%s = []%s{%s}[r.Intn(%d)]", x, typ, strings.Join(values, ", "), len(values))}
+		}
+		if example, ok := exampleFormats[o.Format]; ok {
+			return []string{fmt.Sprintf("%s = %s", x, convert(typ, "string", strconv.Quote(example)))}
+		}
+		if o.Pattern != "" {
+			// A string which matches the pattern can't be generated, other than the schema's own example
+			if example, ok := exampleValue(o.Schema, 0).(string); ok && o.Example != nil {
+				return []string{fmt.Sprintf("%s = %s", x, convert(typ, "string", strconv.Quote(example)))}
+			}
+		}
+	}
+
+	var lo int64
+	stmts := []string{"{"}
+	if o != nil && o.MinLength != nil {
+		lo = *o.MinLength
+	}
+	stmts = append(stmts, fmt.Sprintf("n := %d + r.Intn(size+1)", lo))
+	if o != nil && o.MaxLength != nil {
+		stmts = append(stmts, fmt.Sprintf("n = min(n, %d)", *o.MaxLength))
+	}
+	return append(stmts,
+		"b := make([]rune, n)",
+		"for i := range b {",
+		"b[i] = rune('a' + r.Intn(26))",
+		"}",
+		fmt.Sprintf("%s = %s(b)", x, typ),
+		"}",
+	)
+}
+
+// randomNumber returns the statements which set x to the schema's `const`, or one of its enum values, or otherwise a random number between its bounds, which is a multiple of its `multipleOf`
+func (g *fuzzGenerator) randomNumber(x, typ string, s Schema) []string {
+	literal := func(v float64) string {
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	integer := !strings.HasPrefix(s.GoType, "float")
+
+	o := s.OAPISchema
+	if o == nil || o.Schema == nil {
+		if integer {
+			return []string{fmt.Sprintf("%s = %s(r.Intn(1000))", x, typ)}
+		}
+		return []string{fmt.Sprintf("%s = %s(r.Float64() * 1000)", x, typ)}
+	}
+
+	if constant, ok := constLiteral(o, s.GoType); ok {
+		return []string{fmt.Sprintf("%s = %s", x, convert(typ, s.GoType, constant))}
+	}
+
+	var values []string
+	for _, value := range o.Enum() {
+		switch value := value.(type) {
+		case int:
+			values = append(values, literal(float64(value)))
+		case float64:
+			values = append(values, literal(value))
+		}
+	}
+	if len(values) > 0 {
+		return []string{fmt.Sprintf("%s = []%s{%s}[r.Intn(%d)]", x, typ, strings.Join(values, ", "), len(values))}
+	}
+
+	// The bounds default to a range of 2000 around 0, or either of the bounds
+	lo, hi := -1000.0, 1000.0
+	bounds := o.Bounds()
+	minimum, exclusiveMinimum := bounds.Minimum, bounds.ExclusiveMinimum
+	maximum, exclusiveMaximum := bounds.Maximum, bounds.ExclusiveMaximum
+	switch {
+	case minimum != nil && maximum != nil:
+		lo, hi = *minimum, *maximum
+	case minimum != nil:
+		lo, hi = *minimum, *minimum+2000
+	case maximum != nil:
+		lo, hi = *maximum-2000, *maximum
+	}
+	if strings.HasPrefix(s.GoType, "uint") && lo < 0 {
+		lo = 0
+	}
+
+	step := 1.0
+	if o.MultipleOf != nil && *o.MultipleOf > 0 {
+		step = *o.MultipleOf
+	} else if !integer {
+		step = 0
+	}
+	if step == 0 {
+		// Any number within the bounds, other than an exclusive bound
+		span := hi - lo
+		if exclusiveMinimum || exclusiveMaximum {
+			lo, span = lo+span*0.001, span*0.998
+		}
+		return []string{fmt.Sprintf("%s = %s", x, convert(typ, "float64", fmt.Sprintf("%s + r.Float64()*%s", literal(lo), literal(span))))}
+	}
+
+	// A multiple of the step, within the bounds
+	first, last := math.Ceil(lo/step), math.Floor(hi/step)
+	if exclusiveMinimum && first*step == lo {
+		first++
+	}
+	if exclusiveMaximum && last*step == hi {
+		last--
+	}
+	if last < first {
+		last = first
+	}
+	multiple := fmt.Sprintf("(%s + r.Int63n(%s))", literal(first), literal(last-first+1))
+	if step == 1 {
+		return []string{fmt.Sprintf("%s = %s", x, convert(typ, "int64", multiple))}
+	}
+	return []string{fmt.Sprintf("%s = %s", x, convert(typ, "float64", fmt.Sprintf("float64%s * %s", multiple, literal(step))))}
+}
+
+// randomOther returns the statements which set x to a random value of the other types which are generated for schemas, such as `bool` or `time.Time`, or leaves a type we know nothing about as its zero value
+func (g *fuzzGenerator) randomOther(x, typ string, s Schema) []string {
+	switch s.GoType {
+	case "bool":
+		if constant, ok := constLiteral(s.OAPISchema, "bool"); ok {
+			return []string{fmt.Sprintf("%s = %s", x, convert(typ, "bool", constant))}
+		}
+		return []string{fmt.Sprintf("%s = %s", x, convert(typ, "bool", "r.Intn(2) == 0"))}
+	case "time.Time":
+		return []string{fmt.Sprintf("%s = %s", x, convert(typ, "time.Time", "time.Unix(r.Int63n(4102444800), 0).UTC()"))}
+	case "openapi_types.Date":
+		return []string{fmt.Sprintf("%s = %s{Time: time.Unix(r.Int63n(4102444800), 0).UTC().Truncate(24 * time.Hour)}", x, typ)}
+	case "openapi_types.UUID":
+		return []string{fmt.Sprintf("_, _ = r.Read(%s[:])", operand(x))}
+	case "openapi_types.Email":
+		return []string{fmt.Sprintf("%s = %s(fmt.Sprintf(\"user%%d@example.com\", r.Intn(1000)))", x, typ)}
+	case "[]byte":
+		return []string{
+			fmt.Sprintf("%s = make(%s, r.Intn(size+1))", x, typ),
+			fmt.Sprintf("_, _ = r.Read(%s)", x),
+		}
+	}
+	return nil
+}
+
+// convert returns the expression, of the Go type from, converted to typ, unless it's already of that type
+func convert(typ, from, expr string) string {
+	if typ == from {
+		return expr
+	}
+	return typ + "(" + expr + ")"
+}
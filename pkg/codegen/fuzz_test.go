@@ -0,0 +1,129 @@
+package codegen
+
+import (
+	"go/importer"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+const fuzzSpec = `openapi: 3.0.0
+info:
+  title: fuzz
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Status:
+      type: string
+      enum: [available, sold]
+    Tag:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+          minLength: 2
+          maxLength: 5
+    Pet:
+      type: object
+      required: [id, tags]
+      properties:
+        id:
+          type: integer
+          minimum: 1
+          maximum: 100
+          multipleOf: 5
+        status:
+          $ref: '#/components/schemas/Status'
+        tags:
+          type: array
+          maxItems: 3
+          items:
+            $ref: '#/components/schemas/Tag'
+        parent:
+          $ref: '#/components/schemas/Pet'
+    Animal:
+      oneOf:
+        - $ref: '#/components/schemas/Pet'
+        - $ref: '#/components/schemas/Tag'
+`
+
+// fuzzUsage uses the generated functions, so that type checking it alongside the generated code ensures the models can be used with testing/quick and fuzzed
+const fuzzUsage = `package api
+
+import (
+	"testing"
+	"testing/quick"
+)
+
+var _ quick.Generator = Pet{}
+
+func FuzzTags(f *testing.F) {
+	RunFuzzPet(f, func(t *testing.T, v Pet) {
+		if len(v.Tags) > 3 {
+			t.Fatal("too many tags")
+		}
+	})
+}
+`
+
+func TestFuzzBoilerplate(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(fuzzSpec))
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+			Fuzz:   true,
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, code, "func RandomPet(r *rand.Rand, size int) Pet {")
+	assert.Contains(t, code, "func (Pet) Generate(r *rand.Rand, size int) reflect.Value {")
+	assert.Contains(t, code, "func RunFuzzPet(f *testing.F, target func(t *testing.T, v Pet)) {")
+	// Values are within the bounds of the schema
+	assert.Contains(t, code, "v.Id = int(float64(1+r.Int63n(20)) * 5)")
+	assert.Contains(t, code, "v = []Status{\"available\", \"sold\"}[r.Intn(2)]")
+	assert.Contains(t, code, "n := 2 + r.Intn(size+1)\n\t\tn = min(n, 5)")
+	assert.Contains(t, code, "n = min(n, 3)\n\t\tv.Tags = make([]Tag, n)")
+	// Nested values are smaller, so that recursive schemas end
+	assert.Contains(t, code, "v0 = RandomPet(r, max(size-1, 0))")
+	// A union is set to a random one of its variants
+	assert.Contains(t, code, "_ = v.FromTag(RandomTag(r, max(size-1, 0)))")
+
+	fset := token.NewFileSet()
+	typeCheck(t, fset, importer.ForCompiler(fset, "source", nil), code, fuzzUsage)
+}
+
+func TestFuzzGenerateField(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(`openapi: 3.0.0
+info:
+  title: fuzz
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Report:
+      type: object
+      properties:
+        generate:
+          type: boolean
+`))
+	require.NoError(t, err)
+
+	_, err = Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+			Fuzz:   true,
+		},
+	})
+	assert.ErrorContains(t, err, "Report has a field named Generate")
+}
@@ -0,0 +1,71 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/build/constraint"
+	"strings"
+	"text/template"
+)
+
+// buildConstraint returns the expression for the generated code's `//go:build` line, combining the `build-tags` option with any constraint the generated code needs, or an empty string if there's no constraint
+func buildConstraint(opts Configuration) (string, error) {
+	var exprs []constraint.Expr
+	if opts.Generate.StdHTTPServer {
+		// The routing enhancements to `net/http` were added in Go 1.22
+		exprs = append(exprs, &constraint.TagExpr{Tag: "go1.22"})
+	}
+	if opts.OutputOptions.BuildTags != "" {
+		expr, err := constraint.Parse("//go:build " + opts.OutputOptions.BuildTags)
+		if err != nil {
+			return "", fmt.Errorf("error parsing `build-tags` %q: %w", opts.OutputOptions.BuildTags, err)
+		}
+		exprs = append(exprs, expr)
+	}
+
+	if len(exprs) == 0 {
+		return "", nil
+	}
+	expr := exprs[0]
+	for _, e := range exprs[1:] {
+		expr = &constraint.AndExpr{X: expr, Y: e}
+	}
+	return expr.String(), nil
+}
+
+// fileHeaderContext is the data available to the `file-header` template
+type fileHeaderContext struct {
+	PackageName string
+	ModuleName  string
+	Version     string
+}
+
+// renderFileHeader renders the `file-header` template, which is output as a comment at the top of the generated code. Each line is commented out, unless the header is already written as a comment
+func renderFileHeader(header string, context fileHeaderContext) (string, error) {
+	if header == "" {
+		return "", nil
+	}
+
+	tmpl, err := template.New("file-header").Parse(header)
+	if err != nil {
+		return "", fmt.Errorf("error parsing `file-header`: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, context); err != nil {
+		return "", fmt.Errorf("error rendering `file-header`: %w", err)
+	}
+
+	rendered := strings.TrimRight(buf.String(), "\n")
+	if strings.HasPrefix(rendered, "//") || strings.HasPrefix(rendered, "/*") {
+		return rendered, nil
+	}
+	lines := strings.Split(rendered, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = "//"
+		} else {
+			lines[i] = "// " + line
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
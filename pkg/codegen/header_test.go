@@ -0,0 +1,70 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+func TestFileHeaders(t *testing.T) {
+	generate := func(generate GenerateOptions, outputOptions OutputOptions) string {
+		swagger, err := openapi.NewLoader().LoadFromData([]byte(manifestSpec))
+		require.NoError(t, err)
+
+		code, err := Generate(swagger, Configuration{
+			PackageName:   "api",
+			Generate:      generate,
+			OutputOptions: outputOptions,
+		})
+		require.NoError(t, err)
+		return code
+	}
+
+	t.Run("by default", func(t *testing.T) {
+		code := generate(GenerateOptions{Models: true}, OutputOptions{})
+		assert.True(t, strings.HasPrefix(code, "// Package api provides primitives"))
+		assert.NotContains(t, code, "//go:")
+	})
+
+	t.Run("with a header, build tags and a generate command", func(t *testing.T) {
+		code := generate(GenerateOptions{Models: true, StdHTTPServer: true}, OutputOptions{
+			FileHeader:      "Copyright Acme, Inc.\n\nLicensed under the Apache License, Version 2.0\n",
+			BuildTags:       "!codeanalysis || integration",
+			GenerateCommand: "go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen -config cfg.yaml api.yaml",
+		})
+
+		assert.True(t, strings.HasPrefix(code, `// Copyright Acme, Inc.
+//
+// Licensed under the Apache License, Version 2.0
+
+//go:build go1.22 && (!codeanalysis || integration)
+
+// Package api provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by `), code)
+		// The directive is split, so that `go generate` doesn't run it from this file
+		assert.Contains(t, code, "package api\n\n//go:"+"generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen -config cfg.yaml api.yaml\n")
+	})
+
+	t.Run("with a header template which is already a comment", func(t *testing.T) {
+		code := generate(GenerateOptions{Models: true}, OutputOptions{
+			FileHeader: "/* Generated into package {{.PackageName}} */",
+		})
+		assert.True(t, strings.HasPrefix(code, "/* Generated into package api */\n\n// Package api"), code)
+	})
+}
+
+func TestFileHeaderValidation(t *testing.T) {
+	problems := OutputOptions{BuildTags: "linux &&"}.Validate()
+	assert.Contains(t, problems, "build-tags")
+
+	problems = OutputOptions{GenerateCommand: "go generate\nrm -rf ."}.Validate()
+	assert.Contains(t, problems, "generate-command")
+
+	problems = OutputOptions{FileHeader: "{{.PackageName"}.Validate()
+	assert.Contains(t, problems, "file-header")
+}
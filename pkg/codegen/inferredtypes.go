@@ -0,0 +1,89 @@
+package codegen
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+	"gopkg.in/yaml.v3"
+)
+
+// inferAdditionalPropertiesType infers the Go type of the values of a schema's free-form `additionalProperties` from the schema's examples, with `infer-map-value-types-from-examples`. The type is only inferred when each property of the examples which isn't one of the schema's `properties` has a value of the same JSON type, such as all strings, or all numbers, where integers are allowed too. The decision is logged, and recorded when generating a Report, whenever the examples have any additional properties
+func inferAdditionalPropertiesType(schema *openapi.Schema, path []string) (string, bool) {
+	if schema.Schema == nil {
+		return "", false
+	}
+
+	nodes := []*yaml.Node{schema.Schema.Example}
+	nodes = append(nodes, schema.Schema.Examples...)
+
+	properties := schema.PropertiesToMap()
+	var kinds []string
+	values := 0
+	for _, node := range nodes {
+		if node == nil {
+			continue
+		}
+		var example interface{}
+		if err := node.Decode(&example); err != nil {
+			continue
+		}
+		object, ok := example.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for name, value := range object {
+			if _, ok := properties[name]; ok {
+				continue
+			}
+			values++
+			if kind := exampleKind(value); !slices.Contains(kinds, kind) {
+				kinds = append(kinds, kind)
+			}
+		}
+	}
+	if values == 0 {
+		return "", false
+	}
+	slices.Sort(kinds)
+
+	var goType string
+	switch strings.Join(kinds, ",") {
+	case "string":
+		goType = "string"
+	case "boolean":
+		goType = "bool"
+	case "integer":
+		goType = "int"
+	case "number", "integer,number":
+		goType = "float32"
+	default:
+		logger().Debug("didn't infer the type of additional properties from examples", "path", strings.Join(path, "."), "kinds", kinds)
+		reportInference(path, schema.Position(), "the values of additionalProperties are generated as interface{}, as the examples have values of %v", kinds)
+		return "", false
+	}
+
+	logger().Debug("inferred the type of additional properties from examples", "path", strings.Join(path, "."), "type", goType, "values", values)
+	reportInference(path, schema.Position(), "the values of additionalProperties are generated as %s, as the %d values in the examples are all of the type %s", goType, values, strings.Join(kinds, " or "))
+	return goType, true
+}
+
+// exampleKind returns the JSON type of a value decoded from an example, as it's named by a schema's `type`
+func exampleKind(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case int, int64, uint64:
+		return "integer"
+	case float64:
+		return "number"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
@@ -0,0 +1,121 @@
+package codegen
+
+import (
+	"go/importer"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+const inferredTypesSpec = `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Labels:
+      type: object
+      additionalProperties: true
+      example:
+        team: pets
+        tier: gold
+    Measurements:
+      type: object
+      properties:
+        unit:
+          type: string
+      additionalProperties: {}
+      examples:
+        - unit: cm
+          height: 30
+        - unit: cm
+          height: 12.5
+          width: 20
+    Attributes:
+      type: object
+      additionalProperties: true
+      example:
+        name: Rex
+        age: 3
+    Tags:
+      type: object
+      additionalProperties: true
+`
+
+func TestInferMapValueTypesFromExamples(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(inferredTypesSpec))
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+		},
+		OutputOptions: OutputOptions{
+			InferMapValueTypesFromExamples: true,
+		},
+	}
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	t.Run("values of the same type are inferred", func(t *testing.T) {
+		assert.Contains(t, code, "type Labels map[string]string")
+		assert.Contains(t, code, "AdditionalProperties map[string]float32 `json:\"-\"`")
+		assert.Contains(t, code, "func (a Measurements) Get(fieldName string) (value float32, found bool) {")
+	})
+
+	t.Run("values of different types aren't inferred", func(t *testing.T) {
+		assert.Contains(t, code, "type Attributes map[string]interface{}")
+		assert.Contains(t, code, "type Tags map[string]interface{}")
+	})
+
+	t.Run("the code type checks", func(t *testing.T) {
+		fset := token.NewFileSet()
+		typeCheck(t, fset, importer.ForCompiler(fset, "source", nil), code, `package api
+
+func use(l Labels, m Measurements) (string, float32) {
+	height, _ := m.Get("height")
+	return l["team"], height
+}
+`)
+	})
+
+	t.Run("the decisions are reported", func(t *testing.T) {
+		report, err := GenerateReport(swagger, opts)
+		require.NoError(t, err)
+
+		assert.Equal(t, []ReportIssue{
+			{
+				Location: "Attributes",
+				Position: "27:7",
+				Message:  "the values of additionalProperties are generated as interface{}, as the examples have values of [integer string]",
+			},
+			{
+				Location: "Labels",
+				Position: "9:7",
+				Message:  "the values of additionalProperties are generated as string, as the 2 values in the examples are all of the type string",
+			},
+			{
+				Location: "Measurements",
+				Position: "15:7",
+				Message:  "the values of additionalProperties are generated as float32, as the 3 values in the examples are all of the type integer or number",
+			},
+		}, report.Inferred)
+	})
+
+	t.Run("nothing is inferred by default", func(t *testing.T) {
+		opts := opts
+		opts.OutputOptions.InferMapValueTypesFromExamples = false
+		code, err := Generate(swagger, opts)
+		require.NoError(t, err)
+
+		assert.Contains(t, code, "type Labels map[string]interface{}")
+		assert.Contains(t, code, "AdditionalProperties map[string]interface{} `json:\"-\"`")
+	})
+}
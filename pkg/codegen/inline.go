@@ -22,11 +22,56 @@ import (
 	"text/template"
 
 	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
 )
 
+// unfilteredOperationIds returns the operationIds which the operations of generated are generated with, by the same operations of unfiltered, the copy of the spec from before generated was filtered, so that they're embedded with them
+func unfilteredOperationIds(generated, unfiltered *openapi.T, operationIds map[*v3.Operation]string) map[*v3.Operation]string {
+	result := make(map[*v3.Operation]string)
+	if generated.Paths == nil || unfiltered.Paths == nil {
+		return result
+	}
+	unfilteredPaths := unfiltered.Paths.Map()
+	for path, pathItem := range generated.Paths.Map() {
+		unfilteredItem := unfilteredPaths[path]
+		if unfilteredItem == nil {
+			continue
+		}
+		for method, op := range pathItem.Operations() {
+			operationId, ok := operationIds[op.Operation]
+			if !ok {
+				continue
+			}
+			if unfilteredOp := unfilteredItem.GetOperation(method); unfilteredOp != nil {
+				result[unfilteredOp.Operation] = operationId
+			}
+		}
+	}
+	return result
+}
+
 // GenerateInlinedSpec generates a gzipped, base64 encoded JSON representation of the
 // swagger definition, which we embed inside the generated code.
 func GenerateInlinedSpec(t *template.Template, importMapping importMap, swagger *openapi.T) (string, error) {
+	// The operations are embedded with the operationIds they're generated
+	// with, which they only have until the spec is marshaled, so that the spec
+	// itself isn't changed
+	for operation, operationId := range globalState.embeddedOperationIds {
+		original := operation.OperationId
+		operation.OperationId = operationId
+		defer func() {
+			operation.OperationId = original
+		}()
+	}
+
+	if globalState.options.OutputOptions.DownconvertEmbeddedSpec {
+		downconverted, err := swagger.To30()
+		if err != nil {
+			return "", fmt.Errorf("error downconverting swagger to OpenAPI 3.0: %w", err)
+		}
+		swagger = downconverted
+	}
+
 	// ensure that any external file references are embedded into the embedded spec
 	swagger.InternalizeRefs(context.Background(), nil)
 	// Marshal to json
@@ -0,0 +1,40 @@
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"text/template"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+// JSONSchemaDefinition is a component schema which is embedded in the generated code as a JSON Schema 2020-12 document
+type JSONSchemaDefinition struct {
+	Name string
+	// Document is the compacted JSON of the JSON Schema document
+	Document string
+}
+
+// GenerateJSONSchemas generates, as configured with the `json-schemas` option, a `SchemaFor(name string) []byte` function, which returns each of the component schemas, by name, as a standalone JSON Schema 2020-12 document, such that other services can validate values from the same source of truth
+func GenerateJSONSchemas(t *template.Template, spec *openapi.T) (string, error) {
+	if globalState.modelTypes["SchemaFor"] {
+		return "", fmt.Errorf("the function which returns the JSON Schema of a component schema would be named SchemaFor, which is the name of a model. Please rename the model, or disable `json-schemas`")
+	}
+
+	documents, err := spec.JSONSchemas()
+	if err != nil {
+		return "", fmt.Errorf("error converting component schemas to JSON Schema: %w", err)
+	}
+
+	var schemas []JSONSchemaDefinition
+	for _, name := range SortedMapKeys(documents) {
+		var compacted bytes.Buffer
+		if err := json.Compact(&compacted, documents[name]); err != nil {
+			return "", fmt.Errorf("error compacting JSON Schema of %s: %w", name, err)
+		}
+		schemas = append(schemas, JSONSchemaDefinition{Name: name, Document: compacted.String()})
+	}
+
+	return GenerateTemplates([]string{"jsonschema.tmpl"}, t, schemas)
+}
@@ -0,0 +1,51 @@
+package codegen
+
+import (
+	"go/importer"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+func TestJSONSchemas(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(`openapi: 3.0.0
+info:
+  title: json-schemas
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: integer
+        tag:
+          $ref: '#/components/schemas/Tag'
+    Tag:
+      type: string
+      nullable: true
+`))
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:      true,
+			JSONSchemas: true,
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, code, "func SchemaFor(name string) []byte {")
+	assert.Contains(t, code, `"Pet": "{\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"type\":\"object\",\"required\":[\"id\"],\"properties\":{\"id\":{\"type\":\"integer\"},\"tag\":{\"$ref\":\"#/$defs/Tag\"}},\"$defs\":{\"Tag\":{\"type\":[\"string\",\"null\"]}}}",`)
+	assert.Contains(t, code, `"Tag": "{\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"type\":[\"string\",\"null\"]}",`)
+
+	fset := token.NewFileSet()
+	typeCheck(t, fset, importer.ForCompiler(fset, "source", nil), code, "package api\n\nvar _ []byte = SchemaFor(\"Pet\")\n")
+}
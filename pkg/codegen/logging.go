@@ -0,0 +1,67 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// redactedValue replaces the value of a sensitive parameter, when the client logs a request
+const redactedValue = "[REDACTED]"
+
+// HasLogAttrs returns whether the operation has any parameters, which the client logs along with each request
+func (o *OperationDefinition) HasLogAttrs() bool {
+	return len(o.PathParams) > 0 || o.RequiresParamObject()
+}
+
+// LogAttrs returns the statements which collect the parameters of a request, as `slog.Attr`s, for the client's `WithLogger`. The values of sensitive parameters are redacted, and optional parameters are only logged when they're set, or present with an empty value
+func (o *OperationDefinition) LogAttrs() string {
+	// The trailing underscore keeps the slice from clashing with a path parameter, whose name never ends with one
+	stmts := []string{"var logAttrs_ []slog.Attr"}
+	for _, param := range o.PathParams {
+		stmts = append(stmts, fmt.Sprintf("logAttrs_ = append(logAttrs_, %s)", logAttr(param, param.GoVariableName())))
+	}
+
+	if o.RequiresParamObject() {
+		stmts = append(stmts, "if params == nil {", "return logAttrs_", "}")
+		for _, param := range o.Params() {
+			// The field of the parameter is laid out as GenerateParamsTypes does
+			prop := Property{
+				JsonFieldName: param.ParamName,
+				Required:      param.Required,
+				Schema:        param.Schema,
+				Extensions:    param.Spec.Extensions,
+				AllowEmpty:    param.AllowsEmptyValue(),
+			}
+			field := "params." + prop.GoFieldName()
+			if prop.AllowEmpty {
+				// A parameter which is present with an empty value is logged as an empty string
+				stmts = append(stmts,
+					fmt.Sprintf("if value, err := %s.Get(); err == nil {", field),
+					fmt.Sprintf("logAttrs_ = append(logAttrs_, %s)", logAttr(param, "value")),
+					fmt.Sprintf("} else if %s.IsNull() {", field),
+					fmt.Sprintf("logAttrs_ = append(logAttrs_, %s)", logAttr(param, `""`)),
+					"}",
+				)
+				continue
+			}
+			if strings.HasPrefix(prop.GoTypeDef(), "*") {
+				stmts = append(stmts,
+					fmt.Sprintf("if %s != nil {", field),
+					fmt.Sprintf("logAttrs_ = append(logAttrs_, %s)", logAttr(param, "*"+field)),
+					"}",
+				)
+				continue
+			}
+			stmts = append(stmts, fmt.Sprintf("logAttrs_ = append(logAttrs_, %s)", logAttr(param, field)))
+		}
+	}
+	return strings.Join(append(stmts, "return logAttrs_"), "\n")
+}
+
+// logAttr returns the `slog.Attr` which logs the value of the parameter, unless it's sensitive
+func logAttr(param ParameterDefinition, value string) string {
+	if param.IsSensitive() {
+		return fmt.Sprintf("slog.String(%q, %q)", param.ParamName, redactedValue)
+	}
+	return fmt.Sprintf("slog.Any(%q, %s)", param.ParamName, value)
+}
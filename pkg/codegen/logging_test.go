@@ -0,0 +1,125 @@
+package codegen
+
+import (
+	"go/importer"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+const loggingSpec = `openapi: 3.0.0
+info:
+  title: logging
+  version: 1.0.0
+paths:
+  /users/{id}:
+    get:
+      operationId: getUser
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+        - name: X-Api-Key
+          in: header
+          required: true
+          x-sensitive: true
+          schema:
+            type: string
+        - name: token
+          in: query
+          schema:
+            type: string
+            format: password
+        - name: secret
+          in: query
+          schema:
+            $ref: '#/components/schemas/Secret'
+        - name: limit
+          in: query
+          schema:
+            type: integer
+      responses:
+        '200':
+          description: OK
+  /attrs/{attrs}:
+    get:
+      operationId: getAttrs
+      parameters:
+        - name: attrs
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: OK
+  /ping:
+    post:
+      operationId: ping
+      responses:
+        '200':
+          description: OK
+components:
+  schemas:
+    Secret:
+      type: string
+      x-sensitive: true
+`
+
+// loggingUsage sends a request with a client which logs it, so that type checking it alongside the generated code ensures the logging compiles
+const loggingUsage = `package api
+
+import (
+	"context"
+	"log/slog"
+)
+
+func getUser(ctx context.Context, id string) error {
+	client, err := NewClient("https://example.com", WithLogger(slog.Default()))
+	if err != nil {
+		return err
+	}
+	limit := 10
+	_, err = client.GetUser(ctx, id, &GetUserParams{XApiKey: "key", Limit: &limit})
+	return err
+}
+`
+
+func TestClientLogging(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(loggingSpec))
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+			Client: true,
+		},
+	})
+	require.NoError(t, err)
+
+	t.Run("redacts sensitive parameters", func(t *testing.T) {
+		assert.Contains(t, code, "logAttrs_ = append(logAttrs_, slog.Any(\"id\", id))")
+		assert.Contains(t, code, "logAttrs_ = append(logAttrs_, slog.String(\"X-Api-Key\", \"[REDACTED]\"))")
+		assert.Contains(t, code, "if params.Token != nil {\n\t\tlogAttrs_ = append(logAttrs_, slog.String(\"token\", \"[REDACTED]\"))")
+		assert.Contains(t, code, "if params.Secret != nil {\n\t\tlogAttrs_ = append(logAttrs_, slog.String(\"secret\", \"[REDACTED]\"))")
+		assert.Contains(t, code, "if params.Limit != nil {\n\t\tlogAttrs_ = append(logAttrs_, slog.Any(\"limit\", *params.Limit))")
+	})
+
+	t.Run("logs requests", func(t *testing.T) {
+		assert.Contains(t, code, "return c.do(req, \"GetUser\", \"/users/{id}\", func() []slog.Attr { return getUserLogAttrs(id, params) })")
+		assert.Contains(t, code, "return c.do(req, \"Ping\", \"/ping\", nil)")
+		assert.Contains(t, code, "logAttrs_ = append(logAttrs_, slog.Any(\"attrs\", attrs))", "a parameter named attrs doesn't clash with the attributes being collected")
+	})
+
+	t.Run("compiles", func(t *testing.T) {
+		fset := token.NewFileSet()
+		typeCheck(t, fset, importer.ForCompiler(fset, "source", nil), code, loggingUsage)
+	})
+}
@@ -0,0 +1,109 @@
+package codegen
+
+import (
+	"fmt"
+)
+
+// defaultLROStatusProperty is the property of the status operation's response which holds the state of a long-running operation, unless `x-lro` names another
+const defaultLROStatusProperty = "status"
+
+// defaultLROTerminalStates are the states in which a long-running operation has finished, unless `x-lro` lists others
+var defaultLROTerminalStates = []string{"succeeded", "failed", "canceled"}
+
+// LongRunningOperation describes how the long-running operation which an operation annotated with `x-lro` starts is polled until it finishes
+type LongRunningOperation struct {
+	// StatusOperation is the operation which returns the state of the long-running operation, given its ID as its only path parameter
+	StatusOperation *OperationDefinition
+	// StatusProperty is the property of the status operation's response which holds the state
+	StatusProperty string
+	// TerminalStates are the states in which the long-running operation has finished, which are compared case-insensitively
+	TerminalStates []string
+}
+
+// IDParam returns the status operation's path parameter, which identifies the long-running operation
+func (l LongRunningOperation) IDParam() ParameterDefinition {
+	return l.StatusOperation.PathParams[0]
+}
+
+// resolveLongRunningOperations sets the LRO of each operation annotated with `x-lro` to the status operation it names, which must take the long-running operation's ID as its only path parameter, and no other required parameters
+func resolveLongRunningOperations(ops []OperationDefinition) error {
+	var failures Error
+
+	for i := range ops {
+		op := &ops[i]
+		value, ok := op.Spec.Extensions[extLRO]
+		if !ok {
+			continue
+		}
+		artifact := op.Method + " " + op.Path
+
+		extension, err := extParseLRO(value)
+		if err != nil {
+			failures.add(artifact, op.Spec.Position(), fmt.Errorf("invalid value for %q: %w", extLRO, err))
+			continue
+		}
+
+		status := findOperation(ops, extension.Operation)
+		if status == nil {
+			failures.add(artifact, op.Spec.Position(), fmt.Errorf("%q names the status operation %q, which doesn't exist", extLRO, extension.Operation))
+			continue
+		}
+		if len(status.PathParams) != 1 {
+			failures.add(artifact, op.Spec.Position(), fmt.Errorf("%q names the status operation %q, which must have exactly one path parameter, for the long-running operation's ID, but has %d", extLRO, extension.Operation, len(status.PathParams)))
+			continue
+		}
+		if required := requiredParams(status.Params()); len(required) != 0 {
+			failures.add(artifact, op.Spec.Position(), fmt.Errorf("%q names the status operation %q, which can't be polled as it requires the parameter %q", extLRO, extension.Operation, required[0].ParamName))
+			continue
+		}
+		if status.HasBody() && status.BodyRequired {
+			failures.add(artifact, op.Spec.Position(), fmt.Errorf("%q names the status operation %q, which can't be polled as it requires a request body", extLRO, extension.Operation))
+			continue
+		}
+
+		lro := &LongRunningOperation{
+			StatusOperation: status,
+			StatusProperty:  extension.StatusProperty,
+			TerminalStates:  extension.TerminalStates,
+		}
+		if lro.StatusProperty == "" {
+			lro.StatusProperty = defaultLROStatusProperty
+		}
+		if len(lro.TerminalStates) == 0 {
+			lro.TerminalStates = defaultLROTerminalStates
+		}
+		op.LRO = lro
+	}
+
+	return failures.errOrNil()
+}
+
+// hasLongRunningOperations reports whether any of the operations is annotated with `x-lro`, for which the client gets the polling helpers
+func hasLongRunningOperations(ops []OperationDefinition) bool {
+	for _, op := range ops {
+		if op.LRO != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// findOperation returns the operation with the operationId, as it's written in the spec, or after it's normalized into the operation's Go name
+func findOperation(ops []OperationDefinition, operationID string) *OperationDefinition {
+	for i := range ops {
+		if ops[i].Spec.OperationId == operationID || ops[i].OperationId == nameNormalizer(operationID) {
+			return &ops[i]
+		}
+	}
+	return nil
+}
+
+func requiredParams(params []ParameterDefinition) []ParameterDefinition {
+	var required []ParameterDefinition
+	for _, param := range params {
+		if param.Required {
+			required = append(required, param)
+		}
+	}
+	return required
+}
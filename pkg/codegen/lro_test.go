@@ -0,0 +1,140 @@
+package codegen
+
+import (
+	"go/importer"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func lroSpec(createLRO, deleteLRO string) string {
+	return `openapi: 3.0.0
+info:
+  title: Long-running operations
+  version: 1.0.0
+paths:
+  /clusters:
+    post:
+      operationId: createCluster
+      x-lro: ` + createLRO + `
+      responses:
+        '202':
+          description: Accepted
+  /clusters/{name}:
+    delete:
+      operationId: deleteCluster
+      x-lro: ` + deleteLRO + `
+      parameters:
+        - name: name
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '202':
+          description: Accepted
+  /operations/{operation-id}:
+    get:
+      operationId: getOperation
+      parameters:
+        - name: operation-id
+          in: path
+          required: true
+          schema:
+            type: string
+        - name: verbose
+          in: query
+          schema:
+            type: boolean
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  status:
+                    type: string
+                  done:
+                    type: boolean
+`
+}
+
+func TestGenerateLongRunningOperations(t *testing.T) {
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client: true,
+			Models: true,
+		},
+	}
+
+	code, err := generateFromSpec(t, lroSpec("getOperation", `{operation: getOperation, status-property: done, terminal-states: ["true"]}`), opts)
+	require.NoError(t, err)
+
+	t.Run("the status operation is polled with the ID", func(t *testing.T) {
+		assert.Contains(t, code, "func WaitForCreateCluster(ctx context.Context, client ClientWithResponsesInterface, id string, opts PollOptions) (*GetOperationResponse, error) {")
+		assert.Contains(t, code, "rsp, err := client.GetOperationWithResponse(ctx, id, nil)")
+		assert.NotContains(t, code, "func WaitForGetOperation(")
+	})
+
+	t.Run("the status property and terminal states default", func(t *testing.T) {
+		assert.Contains(t, code, `pollFinished(rsp.StatusCode(), rsp.Body, "status", []string{"succeeded", "failed", "canceled"})`)
+	})
+
+	t.Run("the status property and terminal states can be set", func(t *testing.T) {
+		assert.Contains(t, code, "func WaitForDeleteCluster(")
+		assert.Contains(t, code, `pollFinished(rsp.StatusCode(), rsp.Body, "done", []string{"true"})`)
+	})
+
+	t.Run("the code type checks", func(t *testing.T) {
+		fset := token.NewFileSet()
+		typeCheck(t, fset, importer.ForCompiler(fset, "source", nil), code, `package api
+
+import "context"
+
+func wait(client ClientWithResponsesInterface) (*GetOperationResponse, error) {
+	return WaitForCreateCluster(context.Background(), client, "op", PollOptions{})
+}
+`)
+	})
+
+	t.Run("nothing is generated without x-lro", func(t *testing.T) {
+		code, err := generateFromSpec(t, `openapi: 3.0.0
+info:
+  title: Short-running operations
+  version: 1.0.0
+paths:
+  /clusters:
+    post:
+      operationId: createCluster
+      responses:
+        '202':
+          description: Accepted
+`, opts)
+		require.NoError(t, err)
+		assert.NotContains(t, code, "PollOptions")
+		assert.NotContains(t, code, "WaitFor")
+	})
+
+	t.Run("the status operation must exist", func(t *testing.T) {
+		_, err := generateFromSpec(t, lroSpec("getOperation", "getStatus"), opts)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `"x-lro" names the status operation "getStatus", which doesn't exist`)
+	})
+
+	t.Run("the status operation must take the ID as its only path parameter", func(t *testing.T) {
+		_, err := generateFromSpec(t, lroSpec("getOperation", "createCluster"), opts)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `"x-lro" names the status operation "createCluster", which must have exactly one path parameter, for the long-running operation's ID, but has 0`)
+	})
+
+	t.Run("the status operation must be named", func(t *testing.T) {
+		_, err := generateFromSpec(t, lroSpec("getOperation", "{status-property: done}"), opts)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid value for "x-lro": the status operation's operationId is required`)
+	})
+}
@@ -0,0 +1,207 @@
+package codegen
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"unicode"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+// Manifest lists the types and functions in generated code, along with the part of the spec each was generated from, so that other tools can map the code back to the spec, and detect when it has been edited by hand
+type Manifest struct {
+	// Hash is the SHA-256 hash of all the generated code
+	Hash string `json:"hash"`
+	// Declarations are the types, functions and methods in the generated code, in the order they're declared
+	Declarations []ManifestDeclaration `json:"declarations"`
+}
+
+// ManifestDeclaration is a type, function or method in generated code
+type ManifestDeclaration struct {
+	// Name is the name of the type or function, or `Type.Method` for a method
+	Name string `json:"name"`
+	// Kind is one of `type`, `func` or `method`
+	Kind string `json:"kind"`
+	// Source is a JSON Pointer to the part of the spec the declaration was generated from, such as `#/components/schemas/Pet` or `#/paths/~1pets/get`. It's empty for declarations which aren't generated from a specific part of the spec, such as the `Client`
+	Source string `json:"source,omitempty"`
+	// Hash is the SHA-256 hash of the declaration's code
+	Hash string `json:"hash"`
+}
+
+// GenerateWithManifest generates code for a spec, as Generate does, along with a Manifest of the generated code
+func GenerateWithManifest(spec *openapi.T, opts Configuration) (string, *Manifest, error) {
+	code, err := Generate(spec, opts)
+	if err != nil {
+		return "", nil, err
+	}
+
+	manifest, err := newManifest(code, globalState.sources)
+	if err != nil {
+		return "", nil, fmt.Errorf("error generating manifest: %w", err)
+	}
+	return code, manifest, nil
+}
+
+// specSource is the part of the spec a type or operation was generated from
+type specSource struct {
+	// Pointer is a JSON Pointer to the part of the spec
+	Pointer string
+	// Position is where the part of the spec is, if known
+	Position openapi.Position
+}
+
+// jsonPointer returns a JSON Pointer to the part of the spec at the given path
+func jsonPointer(tokens ...string) string {
+	escaper := strings.NewReplacer("~", "~0", "/", "~1")
+	var sb strings.Builder
+	sb.WriteString("#")
+	for _, t := range tokens {
+		sb.WriteString("/")
+		sb.WriteString(escaper.Replace(t))
+	}
+	return sb.String()
+}
+
+// recordSource records that the Go type or function name was generated from source, unless another part of the spec has already been recorded for it
+func recordSource(name string, source specSource) {
+	if _, ok := globalState.sources[name]; ok {
+		return
+	}
+	globalState.sources[name] = source
+}
+
+// recordTypeSources records the part of the spec that a type, and any types generated for its inline schemas, were generated from
+func recordTypeSources(typeDefs []TypeDefinition, source specSource) {
+	for _, td := range typeDefs {
+		recordSource(td.TypeName, source)
+		recordTypeSources(td.Schema.AdditionalTypes, source)
+	}
+}
+
+// recordOperationSources records the part of the spec each operation, and the types generated for it, were generated from
+func recordOperationSources(ops []OperationDefinition) {
+	for _, op := range ops {
+		source := specSource{
+			Pointer:  jsonPointer("paths", op.Path, strings.ToLower(op.Method)),
+			Position: op.Spec.Position(),
+		}
+		recordSource(op.OperationId, source)
+		recordTypeSources(op.TypeDefinitions, source)
+	}
+}
+
+// newManifest lists the declarations in code, looking up where each was generated from in sources
+func newManifest(code string, sources map[string]specSource) (*Manifest, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", code, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing generated code: %w", err)
+	}
+
+	manifest := &Manifest{
+		Hash:         hashCode(code),
+		Declarations: []ManifestDeclaration{},
+	}
+	text := func(node ast.Node) string {
+		return code[fset.Position(node.Pos()).Offset:fset.Position(node.End()).Offset]
+	}
+
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			if decl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				name := typeSpec.Name.Name
+				// A type on its own is hashed along with the `type` keyword
+				var node ast.Node = typeSpec
+				if len(decl.Specs) == 1 {
+					node = decl
+				}
+				manifest.Declarations = append(manifest.Declarations, ManifestDeclaration{
+					Name:   name,
+					Kind:   "type",
+					Source: declarationSource(sources, name, "").Pointer,
+					Hash:   hashCode(text(node)),
+				})
+			}
+		case *ast.FuncDecl:
+			name := decl.Name.Name
+			kind := "func"
+			receiver := ""
+			if decl.Recv != nil && len(decl.Recv.List) > 0 {
+				kind = "method"
+				receiver = receiverTypeName(decl.Recv.List[0].Type)
+				name = receiver + "." + name
+			}
+			manifest.Declarations = append(manifest.Declarations, ManifestDeclaration{
+				Name:   name,
+				Kind:   kind,
+				Source: declarationSource(sources, decl.Name.Name, receiver).Pointer,
+				Hash:   hashCode(text(decl)),
+			})
+		}
+	}
+	return manifest, nil
+}
+
+// declarationSource finds where a declaration was generated from, by its own name, or that of its receiver, or otherwise the operation it was generated for, such as `NewListPetsRequest` or `ListPetsWithResponse` for the `ListPets` operation
+func declarationSource(sources map[string]specSource, name, receiver string) specSource {
+	if receiver != "" {
+		if source := declarationSource(sources, receiver, ""); source.Pointer != "" {
+			return source
+		}
+	}
+	if source, ok := sources[name]; ok {
+		return source
+	}
+
+	for _, candidate := range []string{name, strings.TrimPrefix(name, "New"), strings.TrimPrefix(name, "Parse")} {
+		var longest string
+		var source specSource
+		for identifier, s := range sources {
+			if len(identifier) > len(longest) && hasNamePrefix(candidate, identifier) {
+				longest, source = identifier, s
+			}
+		}
+		if source.Pointer != "" {
+			return source
+		}
+	}
+	return specSource{}
+}
+
+// hasNamePrefix returns true if name is prefix, or starts with prefix followed by another word, such that `ListPetsParams` and `Pet_Name` have the prefixes `ListPets` and `Pet`, but `ListPetsByOwner` doesn't have the prefix `ListPet`
+func hasNamePrefix(name, prefix string) bool {
+	if !strings.HasPrefix(name, prefix) {
+		return false
+	}
+	if len(name) == len(prefix) {
+		return true
+	}
+	next := rune(name[len(prefix)])
+	return unicode.IsUpper(next) || unicode.IsDigit(next) || next == '_'
+}
+
+// receiverTypeName returns the name of a method's receiver type, without any pointer
+func receiverTypeName(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+func hashCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
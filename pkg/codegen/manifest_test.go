@@ -0,0 +1,97 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+const manifestSpec = `openapi: 3.0.0
+info:
+  title: manifest
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    get:
+      operationId: getPet
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+        - name: verbose
+          in: query
+          schema:
+            type: boolean
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+        kind:
+          type: string
+          enum: [cat, dog]
+`
+
+func TestGenerateWithManifest(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(manifestSpec))
+	require.NoError(t, err)
+
+	code, manifest, err := GenerateWithManifest(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+			Client: true,
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, hashCode(code), manifest.Hash)
+
+	declarations := make(map[string]ManifestDeclaration)
+	for _, declaration := range manifest.Declarations {
+		declarations[declaration.Name] = declaration
+	}
+
+	tests := []struct {
+		name   string
+		kind   string
+		source string
+	}{
+		{name: "Pet", kind: "type", source: "#/components/schemas/Pet"},
+		{name: "PetKind", kind: "type", source: "#/components/schemas/Pet"},
+		{name: "GetPetParams", kind: "type", source: "#/paths/~1pets~1{id}/get"},
+		{name: "NewGetPetRequest", kind: "func", source: "#/paths/~1pets~1{id}/get"},
+		{name: "Client.GetPet", kind: "method", source: "#/paths/~1pets~1{id}/get"},
+		{name: "GetPetResponse.StatusCode", kind: "method", source: "#/paths/~1pets~1{id}/get"},
+		{name: "ParseGetPetResponse", kind: "func", source: "#/paths/~1pets~1{id}/get"},
+		{name: "NewClient", kind: "func", source: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			declaration, ok := declarations[tt.name]
+			require.True(t, ok, "%s should be in the manifest", tt.name)
+			assert.Equal(t, tt.kind, declaration.Kind)
+			assert.Equal(t, tt.source, declaration.Source)
+			assert.Regexp(t, `^sha256:[0-9a-f]{64}$`, declaration.Hash)
+		})
+	}
+}
+
+func TestJSONPointer(t *testing.T) {
+	assert.Equal(t, "#/paths/~1pets~1{id}/get", jsonPointer("paths", "/pets/{id}", "get"))
+	assert.Equal(t, "#/components/schemas/a~0b", jsonPointer("components", "schemas", "a~b"))
+}
@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/orderedmap"
+
 	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
 )
 
@@ -95,6 +98,19 @@ func mergeOpenapiSchemas(s1, s2 openapi.Schema, allOf bool) (openapi.Schema, err
 	// This is a simplified version that focuses on the essential properties
 	var result openapi.Schema = s1
 
+	// The schemas are part of the document, which is shared with any later
+	// generation, so the result is merged into copies of them, rather than
+	// into s1 itself
+	if s1.Schema != nil {
+		copied := *s1.Schema
+		result.Schema = &copied
+	} else {
+		result.Schema = &base.Schema{}
+	}
+	if result.Properties != nil {
+		result.Properties = orderedmap.From(result.Properties.FromOldest())
+	}
+
 	// Merge type information - for OpenAPI 3.1 we handle union types
 	if len(s1.TypeSlice()) > 0 && len(s2.TypeSlice()) > 0 {
 		// Combine types for union support
@@ -122,8 +138,8 @@ func mergeOpenapiSchemas(s1, s2 openapi.Schema, allOf bool) (openapi.Schema, err
 		// Merge properties from both schemas
 		if s2Props != nil {
 			if s1Props == nil {
-				// If s1 has no properties, use s2's properties
-				result.Properties = s2.Properties
+				// If s1 has no properties, use a copy of s2's properties
+				result.Properties = orderedmap.From(s2.Properties.FromOldest())
 			} else {
 				// Merge properties: create a new orderedmap with all properties
 				// Keep existing properties from s1
@@ -187,7 +203,7 @@ func mergeOpenapiSchemas(s1, s2 openapi.Schema, allOf bool) (openapi.Schema, err
 					}
 				} else if s2.Properties != nil {
 					// If result has no Properties but s2 does, initialize result.Properties
-					result.Properties = s2.Properties
+					result.Properties = orderedmap.From(s2.Properties.FromOldest())
 				}
 			}
 		}
@@ -99,7 +99,7 @@ func GenStructFromAllOf(allOf []*openapi.SchemaRef, path []string) (string, erro
 					addPropsType = goSchema.AdditionalPropertiesType.RefType
 				}
 
-				additionalPropertiesPart := fmt.Sprintf("AdditionalProperties map[string]%s `json:\"-\"`", addPropsType)
+				additionalPropertiesPart := fmt.Sprintf("AdditionalProperties map[%s]%s `json:\"-\"`", goSchema.AdditionalPropertiesKeyType(), addPropsType)
 				if !StringInArray(additionalPropertiesPart, objectParts) {
 					objectParts = append(objectParts, additionalPropertiesPart)
 				}
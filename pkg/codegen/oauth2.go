@@ -0,0 +1,111 @@
+package codegen
+
+import (
+	"strconv"
+	"strings"
+	"text/template"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+// OAuth2SchemeDefinition is a security scheme of type `oauth2`, for which the client has the endpoint and the scopes of each of its flows, with `client-oauth2`
+type OAuth2SchemeDefinition struct {
+	// Name is the name of the security scheme in the spec
+	Name string
+	// GoName is the Go name of the security scheme, such as `PetstoreAuth`, which prefixes the names generated for its flows
+	GoName string
+	Flows  []OAuth2FlowDefinition
+}
+
+// OAuth2FlowDefinition is a flow of an OAuth2SchemeDefinition
+type OAuth2FlowDefinition struct {
+	// Name is the name of the flow in the spec, such as `clientCredentials`
+	Name string
+	// GoName is the Go name of the flow, prefixed with the name of its security scheme, such as `PetstoreAuthClientCredentials`
+	GoName     string
+	AuthURL    string
+	TokenURL   string
+	RefreshURL string
+	Scopes     []OAuth2ScopeDefinition
+}
+
+// OAuth2ScopeDefinition is a scope of an OAuth2FlowDefinition, for which a constant is generated
+type OAuth2ScopeDefinition struct {
+	// GoName is the name of the constant, prefixed with the name of its flow, such as `PetstoreAuthClientCredentialsScopeReadPets`
+	GoName      string
+	Value       string
+	Description string
+}
+
+// GenerateOAuth2 generates the `WithOAuth2` client option, along with the endpoint and scope constants of each flow of the spec's `oauth2` security schemes, and a configuration for each `clientCredentials` flow
+func GenerateOAuth2(t *template.Template, spec *openapi.T) (string, error) {
+	return GenerateTemplates([]string{"oauth2.tmpl"}, t, oauth2SchemeDefinitions(spec))
+}
+
+// oauth2SchemeDefinitions returns the spec's `oauth2` security schemes, in the order of their names
+func oauth2SchemeDefinitions(spec *openapi.T) []OAuth2SchemeDefinition {
+	if spec.Components == nil {
+		return nil
+	}
+
+	var schemes []OAuth2SchemeDefinition
+	for _, name := range SortedMapKeys(spec.Components.SecuritySchemes) {
+		ref := spec.Components.SecuritySchemes[name]
+		if ref == nil || ref.Value == nil || ref.Value.SecurityScheme == nil || ref.Value.Type != "oauth2" || ref.Value.Flows == nil {
+			continue
+		}
+		scheme := OAuth2SchemeDefinition{
+			Name:   name,
+			GoName: SchemaNameToTypeName(name),
+		}
+		flows := ref.Value.Flows
+		for _, flow := range []struct {
+			name string
+			flow *v3.OAuthFlow
+		}{
+			{"implicit", flows.Implicit},
+			{"password", flows.Password},
+			{"clientCredentials", flows.ClientCredentials},
+			{"authorizationCode", flows.AuthorizationCode},
+		} {
+			if flow.flow == nil {
+				continue
+			}
+			scheme.Flows = append(scheme.Flows, oauth2FlowDefinition(scheme.GoName, flow.name, flow.flow))
+		}
+		schemes = append(schemes, scheme)
+	}
+	return schemes
+}
+
+// oauth2FlowDefinition describes a flow of a security scheme, naming the constant for each of its scopes, in the order they're declared. Scopes whose names would be the same, such as `read:pets` and `read.pets`, are numbered
+func oauth2FlowDefinition(schemeGoName, name string, flow *v3.OAuthFlow) OAuth2FlowDefinition {
+	definition := OAuth2FlowDefinition{
+		Name:       name,
+		GoName:     schemeGoName + UppercaseFirstCharacter(name),
+		AuthURL:    flow.AuthorizationUrl,
+		TokenURL:   flow.TokenUrl,
+		RefreshURL: flow.RefreshUrl,
+	}
+	if flow.Scopes == nil {
+		return definition
+	}
+
+	seen := make(map[string]bool)
+	for pair := flow.Scopes.First(); pair != nil; pair = pair.Next() {
+		base := definition.GoName + "Scope" + SchemaNameToTypeName(pair.Key())
+		goName := base
+		for i := 1; seen[goName]; i++ {
+			goName = base + strconv.Itoa(i)
+		}
+		seen[goName] = true
+		definition.Scopes = append(definition.Scopes, OAuth2ScopeDefinition{
+			GoName:      goName,
+			Value:       pair.Key(),
+			Description: strings.Join(strings.Fields(pair.Value()), " "),
+		})
+	}
+	return definition
+}
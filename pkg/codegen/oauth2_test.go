@@ -0,0 +1,85 @@
+package codegen
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+const oauth2Spec = `openapi: 3.0.0
+info:
+  title: OAuth2
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: findPets
+      security:
+        - petstore_auth: [read:pets]
+      responses:
+        '200':
+          description: OK
+components:
+  securitySchemes:
+    petstore_auth:
+      type: oauth2
+      flows:
+        clientCredentials:
+          tokenUrl: https://auth.example.com/token
+          scopes:
+            read:pets: Read your pets
+            read.pets: Also read your pets
+        authorizationCode:
+          authorizationUrl: https://auth.example.com/authorize
+          tokenUrl: https://auth.example.com/token
+          scopes: {}
+    api_key:
+      type: apiKey
+      name: X-API-Key
+      in: header
+`
+
+func TestGenerateOAuth2(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(oauth2Spec))
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+			Client: true,
+		},
+		OutputOptions: OutputOptions{
+			ClientOAuth2: true,
+		},
+	}
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	_, err = parser.ParseFile(token.NewFileSet(), "api.gen.go", code, parser.AllErrors)
+	require.NoError(t, err)
+
+	assert.Contains(t, code, `"golang.org/x/oauth2"`)
+	assert.Contains(t, code, "func WithOAuth2(ts oauth2.TokenSource) ClientOption {")
+	assert.Contains(t, code, "var PetstoreAuthClientCredentialsEndpoint = oauth2.Endpoint{\n\tTokenURL: \"https://auth.example.com/token\",\n}")
+	assert.Contains(t, code, "var PetstoreAuthAuthorizationCodeEndpoint = oauth2.Endpoint{\n\tAuthURL:  \"https://auth.example.com/authorize\",\n\tTokenURL: \"https://auth.example.com/token\",\n}")
+	assert.Contains(t, code, "\t// PetstoreAuthClientCredentialsScopeReadPets is the `read:pets` scope: Read your pets\n\tPetstoreAuthClientCredentialsScopeReadPets = \"read:pets\"\n")
+	// Scopes whose names would be the same are numbered
+	assert.Contains(t, code, "\tPetstoreAuthClientCredentialsScopeReadPets1 = \"read.pets\"\n")
+	assert.Contains(t, code, "func PetstoreAuthClientCredentials(clientID, clientSecret string, scopes ...string) *clientcredentials.Config {")
+	assert.Contains(t, code, "func WithPetstoreAuthClientCredentials(ctx context.Context, clientID, clientSecret string, scopes ...string) ClientOption {")
+	// Only the clientCredentials flow can be configured with just the client's ID and secret
+	assert.NotContains(t, code, "func PetstoreAuthAuthorizationCode(")
+	assert.NotContains(t, code, "ApiKey")
+
+	// Without `client-oauth2`, the client doesn't need golang.org/x/oauth2
+	opts.OutputOptions.ClientOAuth2 = false
+	code, err = Generate(swagger, opts)
+	require.NoError(t, err)
+	assert.NotContains(t, code, "oauth2")
+}
@@ -155,6 +155,10 @@ components:
           const: true
         nullConst:
           const: null
+        nothing:
+          $ref: '#/components/schemas/Nothing'
+    Nothing:
+      const: null
 `
 
 	loader := openapi.NewLoader()
@@ -165,7 +169,8 @@ components:
 	opts := Configuration{
 		PackageName: "testapi",
 		Generate: GenerateOptions{
-			Models: true,
+			Models:     true,
+			Validation: true,
 		},
 	}
 
@@ -178,6 +183,11 @@ components:
 
 	// Const values should be handled properly
 	assert.Contains(t, code, "type ConstTest struct")
+	assert.Regexp(t, `NullConst +\*struct\{\} +`+"`json:\"nullConst,omitempty\"`", code)
+	assert.Contains(t, code, "type Nothing = *struct{}")
+	assert.Regexp(t, `Nothing +Nothing +`+"`json:\"nothing,omitempty\"`", code, "a reference to a const null isn't a pointer to a pointer")
+	assert.Contains(t, code, `return errors.New("nullConst: must be null")`)
+	assert.Contains(t, code, `return errors.New("nothing: must be null")`)
 }
 
 // TestOpenAPI31RefWithSiblings tests $ref with sibling properties
@@ -408,8 +418,6 @@ webhooks:
 
 // TestOpenAPI31MigrationEdgeCases tests the edge cases specification
 func TestOpenAPI31MigrationEdgeCases(t *testing.T) {
-	t.Skip("Temporarily skipping due to complex circular reference issue - will be fixed separately")
-
 	spec, err := util.LoadSwagger("test_specs/openapi31_migration_edge_cases.yaml")
 	require.NoError(t, err)
 
@@ -430,6 +438,17 @@ func TestOpenAPI31MigrationEdgeCases(t *testing.T) {
 	assert.Contains(t, code, "CircularRefTest")
 	assert.Contains(t, code, "DeeplyNestedTest")
 	assert.Contains(t, code, "ComplexEnumTest")
+
+	// Enum values are escaped, and those which can't be constants are skipped
+	assert.Contains(t, code, `ComplexEnumTestStringEnum = "quote\"test"`)
+	assert.Contains(t, code, `ComplexEnumTestStringEnum = "slash\\test"`)
+	assert.NotContains(t, code, "<nil>")
+	assert.NotContains(t, code, "ComplexEnumTestFloatEnum = NaN")
+
+	// Circular references through optional properties, arrays and maps
+	assert.Regexp(t, `Parent\s+\*CircularRefTest`, code)
+	assert.Regexp(t, `Children\s+\*\[\]CircularRefTest`, code)
+	assert.Regexp(t, `Owner\s+\*CircularRefTest`, code)
 }
 
 // TestOpenAPI31BackwardCompatibility tests that 3.0 features still work
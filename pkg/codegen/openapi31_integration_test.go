@@ -1,7 +1,12 @@
 package codegen
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
 	"go/format"
+	"io"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -563,40 +568,39 @@ info:
 			shouldError: false, // Should be valid in 3.1
 			description: "Should handle empty paths and webhooks",
 		},
-		// TODO: Fix circular reference handling in GoSchemaImports
-		// {
-		// 	name: "Complex circular references",
-		// 	spec: `
-		// openapi: 3.1.0
-		// info:
-		//   title: Circular Test
-		//   version: 1.0.0
-		// paths:
-		//   /test:
-		//     get:
-		//       responses:
-		//         '200':
-		//           description: Success
-		//           content:
-		//             application/json:
-		//               schema:
-		//                 $ref: '#/components/schemas/A'
-		// components:
-		//   schemas:
-		//     A:
-		//       type: object
-		//       properties:
-		//         b:
-		//           $ref: '#/components/schemas/B'
-		//     B:
-		//       type: object
-		//       properties:
-		//         a:
-		//           $ref: '#/components/schemas/A'
-		// `,
-		// 	shouldError: false,
-		// 	description: "Should handle circular references",
-		// },
+		{
+			name: "Complex circular references",
+			spec: `
+openapi: 3.1.0
+info:
+  title: Circular Test
+  version: 1.0.0
+paths:
+  /test:
+    get:
+      responses:
+        '200':
+          description: Success
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/A'
+components:
+  schemas:
+    A:
+      type: object
+      properties:
+        b:
+          $ref: '#/components/schemas/B'
+    B:
+      type: object
+      properties:
+        a:
+          $ref: '#/components/schemas/A'
+`,
+			shouldError: false,
+			description: "Should handle circular references",
+		},
 	}
 
 	for _, tt := range tests {
@@ -694,6 +698,79 @@ components:
 	assert.Contains(t, code, "github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi")
 }
 
+// TestOpenAPI31DownconvertEmbeddedSpec tests that the embedded spec can be downconverted to OpenAPI 3.0
+func TestOpenAPI31DownconvertEmbeddedSpec(t *testing.T) {
+	spec := `
+openapi: 3.1.0
+info:
+  title: Downconverted Embedded Spec Test
+  version: 1.0.0
+paths:
+  /test:
+    get:
+      responses:
+        '200':
+          description: Success
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/TestModel'
+components:
+  schemas:
+    TestModel:
+      type: object
+      properties:
+        name:
+          type: ["string", "null"]
+`
+
+	loader := openapi.NewLoader()
+	swagger, err := loader.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "testapi",
+		Generate: GenerateOptions{
+			Models:       true,
+			EmbeddedSpec: true,
+		},
+		OutputOptions: OutputOptions{
+			DownconvertEmbeddedSpec: true,
+		},
+	}
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	// The types are still generated from the original document
+	assert.Contains(t, code, "Name *string `json:\"name\"`")
+
+	embedded := decodeEmbeddedSpec(t, code)
+	assert.Contains(t, string(embedded), `"openapi":"3.0.3"`)
+	assert.Contains(t, string(embedded), `"nullable":true`)
+}
+
+// decodeEmbeddedSpec extracts the JSON spec embedded in generated code
+func decodeEmbeddedSpec(t *testing.T, code string) []byte {
+	t.Helper()
+
+	start := strings.Index(code, "var swaggerSpec = []string{")
+	require.NotEqual(t, -1, start, "generated code does not embed the spec")
+	end := strings.Index(code[start:], "}")
+	var encoded strings.Builder
+	for _, line := range strings.Split(code[start:start+end], "\n")[1:] {
+		encoded.WriteString(strings.Trim(strings.TrimSpace(line), `",`))
+	}
+
+	zipped, err := base64.StdEncoding.DecodeString(encoded.String())
+	require.NoError(t, err)
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	require.NoError(t, err)
+	embedded, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	return embedded
+}
+
 // BenchmarkOpenAPI31Generation benchmarks code generation performance
 func BenchmarkOpenAPI31Generation(b *testing.B) {
 	spec := `
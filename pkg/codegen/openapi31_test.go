@@ -506,6 +506,55 @@ paths:
 	require.NoError(t, err)
 }
 
+// TestNullableForms tests that OpenAPI 3.0's `nullable: true` and a 3.1 union
+// with `null` generate the same code
+func TestNullableForms(t *testing.T) {
+	generate := func(version, nullableString, nullableInteger string, nullableType bool) string {
+		swagger, err := openapi.NewLoader().LoadFromData([]byte(`openapi: ` + version + `
+info:
+  title: Nullable forms
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+` + nullableString + `
+        age:
+` + nullableInteger + `
+        tags:
+          type: array
+          items:
+` + nullableString))
+		require.NoError(t, err)
+
+		code, err := Generate(swagger, Configuration{
+			PackageName: "testapi",
+			Generate: GenerateOptions{
+				Models: true,
+			},
+			OutputOptions: OutputOptions{
+				NullableType: nullableType,
+			},
+		})
+		require.NoError(t, err)
+		return code
+	}
+
+	for _, nullableType := range []bool{false, true} {
+		v30 := generate("3.0.3", "          type: string\n          nullable: true", "          type: integer\n          nullable: true", nullableType)
+		v31 := generate("3.1.0", "          type: [string, \"null\"]", "          type: [\"null\", integer]", nullableType)
+		assert.Equal(t, v30, v31, "nullable-type: %v", nullableType)
+		if nullableType {
+			assert.Contains(t, v31, "Name nullable.Nullable[string] `json:\"name\"`")
+			assert.Contains(t, v31, "Age  nullable.Nullable[int]    `json:\"age,omitempty\"`")
+		}
+	}
+}
+
 // TestGeneratedCodeNoKinOpenAPI ensures generated code doesn't import kin-openapi
 func TestGeneratedCodeNoKinOpenAPI(t *testing.T) {
 	spec := `
@@ -17,6 +17,8 @@ import (
 	"bufio"
 	"bytes"
 	"fmt"
+	"io"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -25,6 +27,7 @@ import (
 
 	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
 	"github.com/oapi-codegen/oapi-codegen/v2/pkg/util"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
 )
 
 type ParameterDefinition struct {
@@ -113,6 +116,16 @@ func (pd *ParameterDefinition) Explode() bool {
 	return *pd.Spec.Explode
 }
 
+// AllowsEmptyValue reports whether the parameter is a `form` query parameter declared with `allowEmptyValue: true`, whose field in the Params struct is a nullable.Nullable, rather than a pointer, so that it can be absent, present with an empty value, such as `?param=`, which is null, or present with a value
+func (pd *ParameterDefinition) AllowsEmptyValue() bool {
+	return pd.In == "query" && pd.Spec.AllowEmptyValue && pd.IsStyled() && pd.Style() == "form"
+}
+
+// AllowReserved reports whether the parameter is declared with `allowReserved: true`, so that the reserved characters of RFC 3986 in its value, such as `/`, are sent as they are, rather than percent-encoded
+func (pd *ParameterDefinition) AllowReserved() bool {
+	return pd.Spec.AllowReserved
+}
+
 func (pd ParameterDefinition) GoVariableName() string {
 	name := LowercaseFirstCharacters(pd.GoName())
 	if IsGoKeyword(name) {
@@ -133,18 +146,44 @@ func (pd ParameterDefinition) GoName() string {
 			}
 		}
 	}
+	// A name made up only of symbols, such as `!!`, would otherwise have no name in Go at all
+	if nameNormalizer(goName) == "" {
+		return TransliterateEnumValue(goName)
+	}
 	return SchemaNameToTypeName(goName)
 }
 
+// RouteParamName returns the name of a path parameter in the routes which are registered with a server, which is its name in the spec, unless that can't be used as the name of a wildcard by every router, such as `pet-id`, in which case it's named after its Go variable instead
+func (pd ParameterDefinition) RouteParamName() string {
+	if isRouteParamName(pd.ParamName) {
+		return pd.ParamName
+	}
+	return pd.GoVariableName()
+}
+
+// isRouteParamName reports whether name can be used as the name of a wildcard in a route by every router, which `net/http` restricts to letters, digits and underscores, not starting with a digit, and some other routers treat other characters, such as `-` and `.`, as ending the wildcard
+func isRouteParamName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		if !unicode.IsLetter(r) && r != '_' && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return true
+}
+
 // Deprecated: Use HasOptionalPointer, as it is clearer what the intent is.
 func (pd ParameterDefinition) IndirectOptional() bool {
-	return !pd.Required && !pd.Schema.SkipOptionalPointer
+	return !pd.Required && !pd.Schema.SkipOptionalPointer && !pd.AllowsEmptyValue()
 }
 
 // HasOptionalPointer indicates whether the generated property has an optional pointer associated with it.
 // This takes into account the `x-go-type-skip-optional-pointer` extension, allowing a parameter definition to control whether the pointer should be skipped.
+// A parameter which allows an empty value is a nullable.Nullable instead, so never has a pointer.
 func (pd ParameterDefinition) HasOptionalPointer() bool {
-	return pd.Required == false && pd.Schema.SkipOptionalPointer == false //nolint:staticcheck
+	return pd.Required == false && pd.Schema.SkipOptionalPointer == false && !pd.AllowsEmptyValue() //nolint:staticcheck
 }
 
 type ParameterDefinitions []ParameterDefinition
@@ -225,13 +264,46 @@ type OperationDefinition struct {
 	CookieParams        []ParameterDefinition // Parameters in cookies
 	TypeDefinitions     []TypeDefinition      // These are all the types we need to define for this operation
 	SecurityDefinitions []SecurityDefinition  // These are the security providers
-	BodyRequired        bool
-	Bodies              []RequestBodyDefinition // The list of bodies for which to generate handlers.
-	Responses           []ResponseDefinition    // The list of responses that can be accepted by handlers.
-	Summary             string                  // Summary string from Swagger, used to generate a comment
-	Method              string                  // GET, POST, DELETE, etc.
-	Path                string                  // The Swagger path for the operation, like /resource/{id}
-	Spec                *openapi.Operation
+	// SecurityRequirements are the security requirements which apply to the operation, whether its own or the spec's, any one of which is sufficient
+	SecurityRequirements openapi.SecurityRequirements
+	BodyRequired         bool
+	Bodies               []RequestBodyDefinition // The list of bodies for which to generate handlers.
+	Responses            []ResponseDefinition    // The list of responses that can be accepted by handlers.
+	Summary              string                  // Summary string from Swagger, used to generate a comment
+	Method               string                  // GET, POST, DELETE, etc.
+	Path                 string                  // The Swagger path for the operation, like /resource/{id}
+	Tags                 []string                // The tags of the operation, which group its client methods, with `client-tag-groups`
+	Spec                 *openapi.Operation
+	// LRO describes how to poll the long-running operation which the operation starts, when it's annotated with `x-lro`
+	LRO *LongRunningOperation
+	// BatchBody is the JSON array request body which the client can send in batches, when the operation sets `x-batch`
+	BatchBody *RequestBodyDefinition
+	// Retryable is whether the client's retry policy may retry the operation, as its method is safe or idempotent, or it sets `x-retryable`
+	Retryable bool
+	// URLLengthGuard describes what the client does when the URL of a request is too long, when the operation sets `x-max-url-length`
+	URLLengthGuard *URLLengthGuard
+}
+
+// RoutePath returns the path of the operation, as it's registered with a server, where each path parameter is named with its RouteParamName, and which is normalized as configured with `routes`, and prefixed with the path of the spec's server URL with `BasePathStrategyKeep`
+func (o *OperationDefinition) RoutePath() string {
+	path := o.RequestPath()
+	var sb strings.Builder
+	last := 0
+	for _, match := range pathParamRE.FindAllStringSubmatchIndex(path, -1) {
+		name := path[match[2]:match[3]]
+		if param := ParameterDefinitions(o.PathParams).FindByName(name); param != nil {
+			sb.WriteString(path[last:match[2]])
+			sb.WriteString(param.RouteParamName())
+			last = match[3]
+		}
+	}
+	sb.WriteString(path[last:])
+	return routeBasePath() + sb.String()
+}
+
+// RequestPath returns the path of the operation, as requests are made to it by the client and the URL helpers, which is normalized as configured with `routes`, so that it matches the route registered with a server
+func (o *OperationDefinition) RequestPath() string {
+	return normalizeRoute(o.Path, globalState.options.OutputOptions.Routes)
 }
 
 // Params returns the list of all parameters except Path parameters. Path parameters
@@ -257,6 +329,24 @@ func (o *OperationDefinition) RequiresParamObject() bool {
 	return len(o.Params()) > 0
 }
 
+// RequestParams returns the path parameters, and the object of the other parameters, if there is one, as the parameters of a function, such as `id int64, params *GetPetParams`. This is used by the client's helpers, which take the parameters of a request
+func (o *OperationDefinition) RequestParams() string {
+	params := genParamArgs(o.PathParams)
+	if o.RequiresParamObject() {
+		params += fmt.Sprintf(", params *%sParams", o.OperationId)
+	}
+	return strings.TrimPrefix(params, ", ")
+}
+
+// RequestArgs returns the arguments which pass the parameters of a request to a function which takes the RequestParams
+func (o *OperationDefinition) RequestArgs() string {
+	args := genParamNames(o.PathParams)
+	if o.RequiresParamObject() {
+		args += ", params"
+	}
+	return strings.TrimPrefix(args, ", ")
+}
+
 // HasBody is called by the template engine to determine whether to generate body
 // marshaling code on the client. This is true for all body types, whether
 // we generate types for them.
@@ -277,6 +367,41 @@ func (o *OperationDefinition) SummaryAsComment() string {
 	return strings.Join(parts, "\n")
 }
 
+// SecurityAlternatives returns the operation's security requirements, any one of which is sufficient, as the security schemes each of them requires, all of which must authenticate a request. An empty requirement allows requests without authentication
+func (o *OperationDefinition) SecurityAlternatives() [][]SecurityDefinition {
+	var alternatives [][]SecurityDefinition
+	for _, requirement := range o.SecurityRequirements {
+		alternatives = append(alternatives, DescribeSecurityDefinition(openapi.SecurityRequirements{requirement}))
+	}
+	return alternatives
+}
+
+// TagsAsComment returns a comment with the description and external docs of each of the operation's tags which are declared in the spec's top-level `tags`, to follow the operation's summary on the methods of the server interfaces
+func (o *OperationDefinition) TagsAsComment() string {
+	var comments []string
+	for _, tag := range o.Tags {
+		if comment := tagComment(tag, fmt.Sprintf("Tagged `%s`", tag)); comment != "" {
+			comments = append(comments, comment)
+		}
+	}
+	return strings.Join(comments, "\n")
+}
+
+// promotedResponseTypeName returns the name of the type which the inline schema for a response's content type has been promoted to, if any
+func (o *OperationDefinition) promotedResponseTypeName(statusCode, contentType string) string {
+	for _, response := range o.Responses {
+		if response.StatusCode != statusCode {
+			continue
+		}
+		for _, content := range response.Contents {
+			if content.ContentType == contentType {
+				return content.promotedTypeName
+			}
+		}
+	}
+	return ""
+}
+
 // GetResponseTypeDefinitions produces a list of type definitions for a given Operation for the response
 // types which we know how to parse. These will be turned into fields on a
 // response object for automatic deserialization of responses in the generated
@@ -310,7 +435,16 @@ func (o *OperationDefinition) GetResponseTypeDefinitions() ([]ResponseTypeDefini
 					if err != nil {
 						return nil, fmt.Errorf("unable to determine Go type for %s.%s: %w", o.OperationId, contentTypeName, err)
 					}
-					
+
+					// If the inline schema has been promoted to a named type, use that type instead
+					if promoted := o.promotedResponseTypeName(responseName, contentTypeName); promoted != "" {
+						responseSchema = Schema{
+							Description: responseSchema.Description,
+							GoType:      promoted,
+							RefType:     promoted,
+						}
+					}
+
 					// For OpenAPI 3.1 compatibility: if the content schema has a reference,
 					// ensure we use the reference type consistently
 					if contentType.Schema.Ref != "" && contentType.Schema.Value != nil {
@@ -350,7 +484,10 @@ func (o *OperationDefinition) GetResponseTypeDefinitions() ([]ResponseTypeDefini
 					// If the response schema has union elements (oneOf/anyOf) and is not a reference,
 					// create a named type for it so that union helper methods can be generated.
 					if len(responseSchema.UnionElements) != 0 && responseSchema.RefType == "" {
-						unionTypeName := SchemaNameToTypeName(o.OperationId) + "_" + typeName
+						unionTypeName, err := inlineTypeName([]string{o.OperationId, typeName}, SchemaNameToTypeName(o.OperationId)+"_"+typeName, false)
+						if err != nil {
+							return nil, err
+						}
 						unionTypeDef := TypeDefinition{
 							TypeName: unionTypeName,
 							Schema:   responseSchema,
@@ -536,6 +673,9 @@ type ResponseContentDefinition struct {
 	// When we generate type names, we need a Tag for it, such as JSON, in
 	// which case we will produce "Response200JSONContent".
 	NameTag string
+
+	// promotedTypeName is the name of the type generated for an inline schema, when it has been promoted to a named type
+	promotedTypeName string
 }
 
 // TypeDef returns the Go type definition for a request body
@@ -550,6 +690,27 @@ func (r ResponseContentDefinition) IsSupported() bool {
 	return r.NameTag != ""
 }
 
+// HasBinaryResponse reports whether any of the operation's responses has content which isn't decoded, such as `application/octet-stream` or a string of `format: binary`, for which the client gets a `<Operation>WithResponseStream` method which streams the body of the response
+func (o *OperationDefinition) HasBinaryResponse() bool {
+	for _, response := range o.Responses {
+		for _, content := range response.Contents {
+			if !content.IsSupported() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isBinaryContent returns whether the schema of a response's content is a string of `format: binary`, which is streamed from an io.Reader rather than held in memory, as content of a type which can't be decoded, such as `application/octet-stream`, is
+func isBinaryContent(content *openapi.MediaType) bool {
+	if content == nil || content.Schema == nil || content.Schema.Value == nil {
+		return false
+	}
+	schema := content.Schema.Value
+	return schema.TypeIs("string") && schema.Format == "binary"
+}
+
 // HasFixedContentType returns true if content type has fixed content type, i.e. contains no "*" symbol
 func (r ResponseContentDefinition) HasFixedContentType() bool {
 	return !strings.Contains(r.ContentType, "*")
@@ -588,9 +749,35 @@ func FilterParameterDefinitionByType(params []ParameterDefinition, in string) []
 	return out
 }
 
+// DuplicateOperationIdStrategy defines how operations which share an `operationId` are handled, as they would otherwise generate colliding method and type names
+type DuplicateOperationIdStrategy string
+
+const (
+	// DuplicateOperationIdStrategyUnset is the default case, where the `duplicate-operation-ids` option hasn't been set. This behaves as `DuplicateOperationIdStrategyError`.
+	DuplicateOperationIdStrategyUnset DuplicateOperationIdStrategy = ""
+	// DuplicateOperationIdStrategyError fails generation, reporting the location of each operation which shares the `operationId`.
+	DuplicateOperationIdStrategyError DuplicateOperationIdStrategy = "error"
+	// DuplicateOperationIdStrategyNumberedSuffix keeps the `operationId` of the first operation, when sorted by path and then method, and appends an increasing number to each subsequent operation.
+	//
+	// For instance, three operations with the `operationId` `getPet` become:
+	//
+	// - `GetPet`
+	// - `GetPet2`
+	// - `GetPet3`
+	DuplicateOperationIdStrategyNumberedSuffix DuplicateOperationIdStrategy = "numbered-suffix"
+)
+
 // OperationDefinitions returns all operations for a swagger definition.
+//
+// Operations which can't be described are skipped, and reported together in an *Error, which is
+// returned alongside the operations which could be described.
 func OperationDefinitions(swagger *openapi.T, initialismOverrides bool) ([]OperationDefinition, error) {
 	var operations []OperationDefinition
+	var genErr Error
+	// seenOperationIds tracks which operation first used each operationId, so duplicates can be detected
+	seenOperationIds := make(map[string]*Failure)
+	// sharedResponseTypes tracks the type generated for each inline response schema, so identical schemas can share a type
+	sharedResponseTypes := make(map[[32]byte]string)
 
 	var toCamelCaseFunc func(string) string
 	if initialismOverrides {
@@ -609,50 +796,75 @@ func OperationDefinitions(swagger *openapi.T, initialismOverrides bool) ([]Opera
 		// are shared by all methods.
 		globalParams, err := DescribeParameters(pathItem.Parameters, nil)
 		if err != nil {
-			return nil, fmt.Errorf("error describing global parameters for %s: %s",
-				requestPath, err)
+			genErr.add(requestPath, openapi.Position{}, fmt.Errorf("error describing global parameters: %w", err))
+			continue
 		}
 
 		// Each path can have a number of operations, POST, GET, OPTIONS, etc.
 		pathOps := pathItem.Operations()
 		for _, opName := range SortedMapKeys(pathOps) {
-			// NOTE that this is a reference to the existing copy of the Operation, so any modifications will modify our shared copy of the spec
+			// NOTE that this is a reference to the existing copy of the Operation, so it mustn't be modified, as the spec is shared with any later generation
 			op := pathOps[opName]
+			artifact := opName + " " + requestPath
 
-			if pathItem.Servers != nil {
-				op.Servers = pathItem.Servers
-			}
 			// take a copy of operationId, so we don't modify the underlying spec
 			operationId := op.OperationId
-			// We rely on OperationID to generate function names, it's required
-			if operationId == "" {
+			// We rely on OperationID to generate function names, it's required,
+			// unless x-go-name names the operation's functions and types
+			// directly
+			if extension, ok := op.Extensions[extGoName]; ok {
+				operationId, err = extParseGoFieldName(extension)
+				if err != nil {
+					genErr.add(artifact, op.Position(), fmt.Errorf("invalid value for %q: %w", extGoName, err))
+					continue
+				}
+			} else if operationId == "" {
 				operationId, err = generateDefaultOperationID(opName, requestPath, toCamelCaseFunc)
 				if err != nil {
-					return nil, fmt.Errorf("error generating default OperationID for %s/%s: %s",
-						opName, requestPath, err)
+					genErr.add(artifact, op.Position(), fmt.Errorf("error generating default OperationID: %w", err))
+					continue
 				}
 			} else {
 				operationId = nameNormalizer(operationId)
 			}
 			operationId = typeNamePrefix(operationId) + operationId
 
+			if first, ok := seenOperationIds[operationId]; ok {
+				if DuplicateOperationIdStrategy(globalState.options.OutputOptions.DuplicateOperationIds) != DuplicateOperationIdStrategyNumberedSuffix {
+					genErr.add(artifact, op.Position(), fmt.Errorf("duplicate operationId %s, which is also used by %s%s", operationId, first.Artifact, locationSuffix(first.Position)))
+					continue
+				}
+				renamed := operationId
+				for i := 2; seenOperationIds[renamed] != nil; i++ {
+					renamed = operationId + strconv.Itoa(i)
+				}
+				logger().Debug("renamed duplicate operationId", "operationId", operationId, "renamed", renamed, "first", first.Artifact, "duplicate", artifact)
+				reportRename("operation", artifact, operationId, renamed, fmt.Sprintf("duplicate operationId, which is also used by %s", first.Artifact))
+				operationId = renamed
+			}
+			seenOperationIds[operationId] = &Failure{Artifact: artifact, Position: op.Position()}
+
 			if !globalState.options.Compatibility.PreserveOriginalOperationIdCasingInEmbeddedSpec {
-				// update the existing, shared, copy of the spec if we're not wanting to preserve it
-				op.OperationId = operationId
+				// The embedded spec has the operationId as it's generated, rather than as it's written
+				if globalState.embeddedOperationIds == nil {
+					globalState.embeddedOperationIds = make(map[*v3.Operation]string)
+				}
+				globalState.embeddedOperationIds[op.Operation] = operationId
 			}
 
 			// These are parameters defined for the specific path method that
 			// we're iterating over.
 			localParams, err := DescribeParameters(openapi.ParametersToRefSlice(op.Parameters), []string{operationId + "Params"})
 			if err != nil {
-				return nil, fmt.Errorf("error describing global parameters for %s/%s: %s",
-					opName, requestPath, err)
+				genErr.add(artifact, op.Position(), fmt.Errorf("error describing parameters: %w", err))
+				continue
 			}
 			// All the parameters required by a handler are the union of the
 			// global parameters and the local parameters.
 			allParams, err := CombineOperationParameters(globalParams, localParams)
 			if err != nil {
-				return nil, err
+				genErr.add(artifact, op.Position(), err)
+				continue
 			}
 
 			ensureExternalRefsInParameterDefinitions(&allParams, pathItem.Ref)
@@ -663,21 +875,52 @@ func OperationDefinitions(swagger *openapi.T, initialismOverrides bool) ([]Opera
 			pathParams := FilterParameterDefinitionByType(allParams, "path")
 			pathParams, err = SortParamsByPath(requestPath, pathParams)
 			if err != nil {
-				return nil, err
+				genErr.add(artifact, op.Position(), err)
+				continue
+			}
+			if err := checkParamGoNames(pathParams, ParameterDefinition.GoVariableName); err != nil {
+				genErr.add(artifact, op.Position(), err)
+				continue
+			}
+			objectParams := slices.Concat(FilterParameterDefinitionByType(allParams, "query"), FilterParameterDefinitionByType(allParams, "header"), FilterParameterDefinitionByType(allParams, "cookie"))
+			if err := checkParamGoNames(objectParams, ParameterDefinition.GoName); err != nil {
+				genErr.add(artifact, op.Position(), err)
+				continue
 			}
 
 			bodyDefinitions, typeDefinitions, err := GenerateBodyDefinitions(operationId, op.RequestBody)
 			if err != nil {
-				return nil, fmt.Errorf("error generating body definitions: %w", err)
+				genErr.add(artifact, op.Position(), fmt.Errorf("error generating body definitions: %w", err))
+				continue
 			}
 
 			ensureExternalRefsInRequestBodyDefinitions(&bodyDefinitions, pathItem.Ref)
 
+			batch, err := batchBody(op, bodyDefinitions)
+			if err != nil {
+				genErr.add(artifact, op.Position(), err)
+				continue
+			}
+
+			retryable, err := operationRetryable(opName, op)
+			if err != nil {
+				genErr.add(artifact, op.Position(), err)
+				continue
+			}
+
 			responseDefinitions, err := GenerateResponseDefinitions(operationId, op.Responses.Map())
 			if err != nil {
-				return nil, fmt.Errorf("error generating response definitions: %w", err)
+				genErr.add(artifact, op.Position(), fmt.Errorf("error generating response definitions: %w", err))
+				continue
 			}
 
+			promotedTypes, err := promoteInlineResponses(operationId, op.Responses.Map(), responseDefinitions, sharedResponseTypes)
+			if err != nil {
+				genErr.add(artifact, op.Position(), fmt.Errorf("error promoting inline response schemas: %w", err))
+				continue
+			}
+			typeDefinitions = append(typeDefinitions, promotedTypes...)
+
 			ensureExternalRefsInResponseDefinitions(&responseDefinitions, pathItem.Ref)
 
 			opDef := OperationDefinition{
@@ -690,25 +933,20 @@ func OperationDefinitions(swagger *openapi.T, initialismOverrides bool) ([]Opera
 				Summary:         op.Summary,
 				Method:          opName,
 				Path:            requestPath,
+				Tags:            op.Tags,
 				Spec:            op,
 				Bodies:          bodyDefinitions,
 				Responses:       responseDefinitions,
 				TypeDefinitions: typeDefinitions,
+				BatchBody:       batch,
+				Retryable:       retryable,
 			}
 
-			// check for overrides of SecurityDefinitions.
+			// The operation's own security requirements override the top-level ones.
 			// See: "Step 2. Applying security:" from the spec:
 			// https://swagger.io/docs/specification/authentication/
-			if op.Security != nil {
-				opDef.SecurityDefinitions = DescribeSecurityDefinition(openapi.ConvertSecurityRequirements(op.Security))
-			} else {
-				// use global securityDefinitions
-				// globalSecurityDefinitions contains the top-level securityDefinitions.
-				// They are the default securityPermissions which are injected into each
-				// path, except for the case where a path explicitly overrides them.
-				opDef.SecurityDefinitions = DescribeSecurityDefinition(openapi.ConvertSecurityRequirements(swagger.Security))
-
-			}
+			opDef.SecurityRequirements = swagger.OperationSecurityRequirements(op)
+			opDef.SecurityDefinitions = DescribeSecurityDefinition(opDef.SecurityRequirements)
 
 			if op.RequestBody != nil {
 				opDef.BodyRequired = op.RequestBody.Value.IsRequired()
@@ -720,7 +958,7 @@ func OperationDefinitions(swagger *openapi.T, initialismOverrides bool) ([]Opera
 			operations = append(operations, opDef)
 		}
 	}
-	return operations, nil
+	return operations, genErr.errOrNil()
 }
 
 func generateDefaultOperationID(opName string, requestPath string, toCamelCaseFunc func(string) string) (string, error) {
@@ -743,6 +981,110 @@ func generateDefaultOperationID(opName string, requestPath string, toCamelCaseFu
 	return nameNormalizer(operationId), nil
 }
 
+// promotedTypeName returns the name of the type generated for the inline schema of a request body or response, which would be named typeName.
+// This can be overridden with `x-go-type-name` on the request body or response, or with the schema's `title`, with `use-schema-titles`, to which the content type's tag is appended for anything but the default, JSON, content type.
+func promotedTypeName(path []string, typeName string, extensions map[string]interface{}, schema *openapi.SchemaRef, tag string, defaultContent bool) (string, error) {
+	suffix := ""
+	if !defaultContent {
+		suffix = tag
+	}
+	explicit := false
+	if extension, ok := extensions[extGoTypeName]; ok {
+		name, err := extTypeName(extension)
+		if err != nil {
+			return "", fmt.Errorf("invalid value for %q: %w", extGoTypeName, err)
+		}
+		typeName, explicit = name+suffix, true
+	} else if schema != nil && schema.Ref == "" && schema.Value != nil {
+		if name, ok := titleTypeName(schema.Value, path, suffix); ok {
+			typeName = name
+		}
+	}
+	return inlineTypeName(path, typeName, explicit)
+}
+
+// isPromotableSchema returns whether a schema is an inline object, which would otherwise be generated as an anonymous struct
+func isPromotableSchema(s Schema) bool {
+	return s.RefType == "" && len(s.UnionElements) == 0 && (len(s.Properties) != 0 || s.HasAdditionalProperties)
+}
+
+// schemaFingerprint returns a hash of an inline schema's definition, which is the same for any two structurally identical schemas, regardless of where they are defined in the spec
+func schemaFingerprint(sref *openapi.SchemaRef) ([32]byte, bool) {
+	if sref == nil || sref.Value == nil || sref.Value.Schema == nil || sref.Value.Schema.GoLow() == nil {
+		return [32]byte{}, false
+	}
+	return sref.Value.Schema.GoLow().Hash(), true
+}
+
+// promoteInlineResponses generates named types for the inline object schemas of responses, rather than anonymous structs, when the `promote-inline-schemas` or `share-inline-response-types` options are set, or the response sets `x-go-type-name`.
+// The contents of responseDefinitions are updated to refer to the named types, which are returned so they can be generated alongside the operation.
+//
+// When the `share-inline-response-types` option is set, shared records the type generated for each inline schema, so that a structurally identical schema in a later response refers to the existing type rather than generating a duplicate.
+func promoteInlineResponses(operationID string, responses map[string]*openapi.ResponseRef, responseDefinitions []ResponseDefinition, shared map[[32]byte]string) ([]TypeDefinition, error) {
+	opts := globalState.options.OutputOptions
+	var typeDefinitions []TypeDefinition
+	for i, rd := range responseDefinitions {
+		responseRef := responses[rd.StatusCode]
+		if responseRef == nil || responseRef.Value == nil || IsGoTypeReference(responseRef.Ref) {
+			continue
+		}
+		_, explicit := responseRef.Value.Extensions[extGoTypeName]
+		if !explicit && !opts.PromoteInlineSchemas && !opts.ShareInlineResponseTypes {
+			continue
+		}
+
+		for j, rcd := range rd.Contents {
+			content := responseRef.Value.Content[rcd.ContentType]
+			if content == nil || content.Schema == nil || content.Schema.Ref != "" || !isPromotableSchema(rcd.Schema) {
+				continue
+			}
+
+			// A response which explicitly names its type always gets a type of its own
+			fingerprint, hashed := schemaFingerprint(content.Schema)
+			sharing := opts.ShareInlineResponseTypes && hashed && !explicit
+			if existing, ok := shared[fingerprint]; sharing && ok {
+				logger().Debug("reusing identical inline response type", "operationId", operationID, "status", rd.StatusCode, "contentType", rcd.ContentType, "type", existing)
+				responseDefinitions[i].Contents[j].Schema = Schema{
+					Description: rcd.Schema.Description,
+					GoType:      existing,
+					RefType:     existing,
+				}
+				responseDefinitions[i].Contents[j].promotedTypeName = existing
+				continue
+			}
+
+			defaultName := operationID + rd.StatusCode + rcd.NameTag + "ResponseBody"
+			typeName, err := promotedTypeName([]string{operationID, rd.StatusCode, rcd.NameTag + "ResponseBody"}, defaultName, responseRef.Value.Extensions, content.Schema, rcd.NameTag, rcd.NameTag == "JSON")
+			if err != nil {
+				return nil, fmt.Errorf("error naming response type for %s: %w", rd.StatusCode, err)
+			}
+
+			// Regenerate the schema, so that the names of any types nested within it are based on the new name
+			schema, err := GenerateGoSchema(content.Schema, []string{typeName})
+			if err != nil {
+				return nil, fmt.Errorf("error generating response type for %s: %w", rd.StatusCode, err)
+			}
+			typeDefinitions = append(typeDefinitions, TypeDefinition{
+				TypeName: typeName,
+				JsonName: typeName,
+				Schema:   schema,
+			})
+			typeDefinitions = append(typeDefinitions, schema.AdditionalTypes...)
+
+			responseDefinitions[i].Contents[j].Schema = Schema{
+				Description: schema.Description,
+				GoType:      typeName,
+				RefType:     typeName,
+			}
+			responseDefinitions[i].Contents[j].promotedTypeName = typeName
+			if sharing {
+				shared[fingerprint] = typeName
+			}
+		}
+	}
+	return typeDefinitions, nil
+}
+
 // GenerateBodyDefinitions turns the Swagger body definitions into a list of our body
 // definitions which will be used for code generation.
 func GenerateBodyDefinitions(operationID string, bodyOrRef *openapi.RequestBodyRef) ([]RequestBodyDefinition, []TypeDefinition, error) {
@@ -780,7 +1122,10 @@ func GenerateBodyDefinitions(operationID string, bodyOrRef *openapi.RequestBodyR
 			continue
 		}
 
-		bodyTypeName := operationID + tag + "Body"
+		bodyTypeName, err := promotedTypeName([]string{operationID, tag + "Body"}, operationID+tag+"Body", body.Extensions, content.Schema, tag, defaultBody)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error naming request body type: %w", err)
+		}
 		bodySchema, err := GenerateGoSchema(content.Schema, []string{bodyTypeName})
 		if err != nil {
 			return nil, nil, fmt.Errorf("error generating request body definition: %w", err)
@@ -866,13 +1211,16 @@ func GenerateResponseDefinitions(operationID string, responses map[string]*opena
 				tag = "JSON"
 			case util.IsMediaTypeJson(contentType):
 				tag = mediaTypeToCamelCase(contentType)
+			case isBinaryContent(content):
+				// Binary content is streamed, whatever its content type
 			case contentType == "application/x-www-form-urlencoded":
 				tag = "Formdata"
 			case strings.HasPrefix(contentType, "multipart/"):
 				tag = "Multipart"
 			case contentType == "text/plain":
 				tag = "Text"
-			default:
+			}
+			if tag == "" {
 				rcd := ResponseContentDefinition{
 					ContentType: contentType,
 				}
@@ -982,6 +1330,7 @@ func GenerateParamsTypes(op OperationDefinition) []TypeDefinition {
 			Schema:        pSchema,
 			NeedsFormTag:  param.Style() == "form",
 			Extensions:    param.Spec.Extensions,
+			AllowEmpty:    param.AllowsEmptyValue(),
 		}
 		s.Properties = append(s.Properties, prop)
 	}
@@ -1001,13 +1350,9 @@ func GenerateTypesForOperations(t *template.Template, ops []OperationDefinition)
 	var buf bytes.Buffer
 	w := bufio.NewWriter(&buf)
 
-	addTypes, err := GenerateTemplates([]string{"param-types.tmpl", "request-bodies.tmpl"}, t, ops)
-	if err != nil {
+	if err := ExecuteTemplates(w, []string{"param-types.tmpl", "request-bodies.tmpl"}, t, ops); err != nil {
 		return "", fmt.Errorf("error generating type boilerplate for operations: %w", err)
 	}
-	if _, err := w.WriteString(addTypes); err != nil {
-		return "", fmt.Errorf("error writing boilerplate to buffer: %w", err)
-	}
 
 	// Generate boiler plate for all additional types.
 	var td []TypeDefinition
@@ -1127,6 +1472,24 @@ func GenerateStrictServer(t *template.Template, operations []OperationDefinition
 		templates = append(templates, "strict/strict-iris-interface.tmpl", "strict/strict-iris.tmpl")
 	}
 
+	// The security middleware is only needed when the spec has security requirements
+	for _, op := range operations {
+		if len(op.SecurityRequirements) > 0 {
+			templates = append(templates, "strict/strict-security.tmpl")
+			break
+		}
+	}
+
+	// The precondition helpers are only needed when operations take If-Match or If-None-Match headers
+	if hasPreconditions(operations) {
+		templates = append(templates, "strict/strict-preconditions.tmpl")
+	}
+
+	// The range helpers are only needed when operations serve partial content
+	if hasPartialContent(operations) {
+		templates = append(templates, "strict/strict-ranges.tmpl")
+	}
+
 	return GenerateTemplates(templates, t, operations)
 }
 
@@ -1137,32 +1500,72 @@ func GenerateStrictResponses(t *template.Template, responses []ResponseDefinitio
 // GenerateClient uses the template engine to generate the function which registers our wrappers
 // as Echo path handlers.
 func GenerateClient(t *template.Template, ops []OperationDefinition) (string, error) {
-	return GenerateTemplates([]string{"client.tmpl"}, t, ops)
+	if globalState.options.OutputOptions.ClientRetryPolicy {
+		if err := checkRetryPolicyNames(); err != nil {
+			return "", err
+		}
+	}
+
+	templates := []string{"client.tmpl"}
+	if hasConditionalRequestParams(ops) {
+		templates = append(templates, "client-preconditions.tmpl")
+	}
+	if hasURLTooLongErrors(ops) {
+		templates = append(templates, "client-url-length.tmpl")
+	}
+	if hasPartialContent(ops) {
+		templates = append(templates, "client-ranges.tmpl")
+	}
+	if hasBinaryContent(ops) {
+		templates = append(templates, "client-progress.tmpl")
+	}
+	if hasPathParams(ops) {
+		templates = append(templates, "path-params.tmpl")
+	}
+	if hasEmptyQueryParams(ops) {
+		templates = append(templates, "client-empty-query-params.tmpl")
+	}
+	return GenerateTemplates(templates, t, ops)
 }
 
 // GenerateClientWithResponses generates a client which extends the basic client which does response
 // unmarshaling.
 func GenerateClientWithResponses(t *template.Template, ops []OperationDefinition) (string, error) {
-	return GenerateTemplates([]string{"client-with-responses.tmpl"}, t, ops)
+	templates := []string{"client-with-responses.tmpl"}
+	if hasLongRunningOperations(ops) {
+		templates = append(templates, "client-lro.tmpl")
+	}
+	for _, op := range ops {
+		if op.BatchBody != nil {
+			templates = append(templates, "client-batch.tmpl")
+			break
+		}
+	}
+	return GenerateTemplates(templates, t, ops)
 }
 
 // GenerateTemplates used to generate templates
 func GenerateTemplates(templates []string, t *template.Template, ops interface{}) (string, error) {
-	var generatedTemplates []string
-	for _, tmpl := range templates {
-		var buf bytes.Buffer
-		w := bufio.NewWriter(&buf)
+	var sb strings.Builder
+	if err := ExecuteTemplates(&sb, templates, t, ops); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
 
-		if err := t.ExecuteTemplate(w, tmpl, ops); err != nil {
-			return "", fmt.Errorf("error generating %s: %s", tmpl, err)
+// ExecuteTemplates executes each of the templates with the same data, writing their output to w as it's generated, separated by newlines, rather than building it up as a string, as GenerateTemplates does
+func ExecuteTemplates(w io.Writer, templates []string, t *template.Template, data interface{}) error {
+	for i, tmpl := range templates {
+		if i > 0 {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return fmt.Errorf("error writing %s: %w", tmpl, err)
+			}
 		}
-		if err := w.Flush(); err != nil {
-			return "", fmt.Errorf("error flushing output buffer for %s: %s", tmpl, err)
+		if err := t.ExecuteTemplate(w, tmpl, data); err != nil {
+			return fmt.Errorf("error generating %s: %s", tmpl, err)
 		}
-		generatedTemplates = append(generatedTemplates, buf.String())
 	}
-
-	return strings.Join(generatedTemplates, "\n"), nil
+	return nil
 }
 
 // CombineOperationParameters combines the Parameters defined at a global level (Parameters defined for all methods on a given path) with the Parameters defined at a local level (Parameters defined for a specific path), preferring the locally defined parameter over the global one
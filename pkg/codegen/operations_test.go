@@ -17,6 +17,9 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
 )
 
@@ -148,3 +151,266 @@ func TestGenerateDefaultOperationID(t *testing.T) {
 		}
 	}
 }
+
+const operationExtensionsSpec = `openapi: 3.0.0
+info:
+  title: extensions
+  version: 1.0.0
+components:
+  parameters:
+    Trace:
+      name: X-Trace
+      in: header
+      x-go-type: uuid.UUID
+      x-go-type-import:
+        path: github.com/google/uuid
+      schema:
+        type: string
+paths:
+  /pets/{pet_id}:
+    get:
+      operationId: get-pet
+      x-go-name: FetchPet
+      parameters:
+        - name: pet_id
+          in: path
+          required: true
+          x-go-name: ID
+          schema:
+            type: string
+        - name: since
+          in: query
+          x-go-name: Since
+          x-go-type: uuid.UUID
+          x-go-type-import:
+            path: github.com/google/uuid
+          x-go-type-skip-optional-pointer: true
+          schema:
+            type: string
+            enum: [a, b]
+        - $ref: '#/components/parameters/Trace'
+      responses:
+        '200':
+          description: OK
+`
+
+func TestOperationExtensions(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(operationExtensionsSpec))
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:        true,
+			Client:        true,
+			StdHTTPServer: true,
+		},
+	})
+	require.NoError(t, err)
+
+	t.Run("x-go-name on an operation names its functions and types", func(t *testing.T) {
+		assert.Contains(t, code, "FetchPet(w http.ResponseWriter, r *http.Request, id string, params FetchPetParams)")
+		assert.Contains(t, code, "func NewFetchPetRequest(")
+		assert.NotContains(t, code, "GetPet")
+	})
+
+	t.Run("x-go-type on a parameter overrides its schema's type", func(t *testing.T) {
+		assert.Contains(t, code, "Since  uuid.UUID  `form:\"since,omitempty\" json:\"since,omitempty\"`")
+		assert.Contains(t, code, "XTrace *uuid.UUID `json:\"X-Trace,omitempty\"`")
+		assert.Contains(t, code, `"github.com/google/uuid"`)
+		assert.NotContains(t, code, "FetchPetParamsSince", "the schema's enum shouldn't generate a type")
+	})
+}
+
+const allMethodsSpec = `openapi: 3.0.0
+info:
+  title: all methods
+  version: 1.0.0
+paths:
+  /pets:
+    get: {operationId: getPets, responses: {"200": {description: OK}}}
+    put: {operationId: putPets, responses: {"204": {description: OK}}}
+    post: {operationId: postPets, responses: {"204": {description: OK}}}
+    delete: {operationId: deletePets, responses: {"204": {description: OK}}}
+    options: {operationId: optionsPets, responses: {"204": {description: OK}}}
+    head: {operationId: headPets, responses: {"200": {description: OK}}}
+    patch: {operationId: patchPets, responses: {"204": {description: OK}}}
+    trace: {operationId: tracePets, responses: {"200": {description: OK}}}
+`
+
+func TestAllHTTPMethods(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(allMethodsSpec))
+	require.NoError(t, err)
+
+	operations := []string{"GetPets", "PutPets", "PostPets", "DeletePets", "OptionsPets", "HeadPets", "PatchPets", "TracePets"}
+
+	servers := map[string]struct {
+		generate      GenerateOptions
+		registrations []string
+	}{
+		"chi": {
+			generate: GenerateOptions{ChiServer: true},
+			registrations: []string{
+				"r.Get(options.BaseURL+\"/pets\", wrapper.GetPets)",
+				"r.Options(options.BaseURL+\"/pets\", wrapper.OptionsPets)",
+				"r.Head(options.BaseURL+\"/pets\", wrapper.HeadPets)",
+				"r.Trace(options.BaseURL+\"/pets\", wrapper.TracePets)",
+			},
+		},
+		"echo": {
+			generate: GenerateOptions{EchoServer: true},
+			registrations: []string{
+				"router.GET(baseURL+\"/pets\", wrapper.GetPets)",
+				"router.OPTIONS(baseURL+\"/pets\", wrapper.OptionsPets)",
+				"router.HEAD(baseURL+\"/pets\", wrapper.HeadPets)",
+				"router.TRACE(baseURL+\"/pets\", wrapper.TracePets)",
+			},
+		},
+		"gin": {
+			generate: GenerateOptions{GinServer: true},
+			registrations: []string{
+				"router.GET(options.BaseURL+\"/pets\", wrapper.GetPets)",
+				"router.OPTIONS(options.BaseURL+\"/pets\", wrapper.OptionsPets)",
+				"router.HEAD(options.BaseURL+\"/pets\", wrapper.HeadPets)",
+				"router.Handle(http.MethodTrace, options.BaseURL+\"/pets\", wrapper.TracePets)",
+			},
+		},
+		"std-http": {
+			generate: GenerateOptions{StdHTTPServer: true},
+			registrations: []string{
+				"m.HandleFunc(\"GET \"+options.BaseURL+\"/pets\", wrapper.GetPets)",
+				"m.HandleFunc(\"OPTIONS \"+options.BaseURL+\"/pets\", wrapper.OptionsPets)",
+				"m.HandleFunc(\"HEAD \"+options.BaseURL+\"/pets\", wrapper.HeadPets)",
+				"m.HandleFunc(\"TRACE \"+options.BaseURL+\"/pets\", wrapper.TracePets)",
+			},
+		},
+	}
+
+	for name, server := range servers {
+		t.Run(name, func(t *testing.T) {
+			generate := server.generate
+			generate.Models = true
+			generate.Client = true
+			generate.Strict = true
+
+			code, err := Generate(swagger, Configuration{
+				PackageName: "api",
+				Generate:    generate,
+			})
+			require.NoError(t, err)
+
+			for _, operation := range operations {
+				assert.Contains(t, code, "func (c *Client) "+operation+"(ctx context.Context")
+				assert.Contains(t, code, "func (c *ClientWithResponses) "+operation+"WithResponse(ctx context.Context")
+				assert.Contains(t, code, operation+"(ctx context.Context, request "+operation+"RequestObject) ("+operation+"ResponseObject, error)")
+			}
+			for _, registration := range server.registrations {
+				assert.Contains(t, code, registration)
+			}
+		})
+	}
+}
+
+const pathParamNamesSpec = `openapi: 3.0.0
+info:
+  title: path parameter names
+  version: 1.0.0
+paths:
+  /pets/{pet-id}/toys/{toyId!}/{type}/{!!}/{owner.name}:
+    get:
+      operationId: getToy
+      parameters:
+        - {name: pet-id, in: path, required: true, schema: {type: string}}
+        - {name: "toyId!", in: path, required: true, schema: {type: integer}}
+        - {name: type, in: path, required: true, schema: {type: string}}
+        - {name: "!!", in: path, required: true, schema: {type: string}}
+        - {name: owner.name, in: path, required: true, x-go-name: OwnerFullName, schema: {type: string}}
+      responses:
+        '204':
+          description: OK
+`
+
+func TestPathParamNames(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(pathParamNamesSpec))
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:        true,
+			Client:        true,
+			StdHTTPServer: true,
+			Strict:        true,
+		},
+	})
+	require.NoError(t, err)
+
+	t.Run("parameters are named in Go after their names in the spec, or x-go-name", func(t *testing.T) {
+		assert.Contains(t, code, "GetToy(ctx context.Context, petId string, toyId int, pType string, notNot string, ownerFullName string, reqEditors ...RequestEditorFn)")
+		assert.Contains(t, code, "PetId         string `json:\"pet-id\"`")
+		assert.Contains(t, code, "NotNot        string `json:\"!!\"`")
+		assert.Contains(t, code, "OwnerFullName string `json:\"owner.name\"`")
+	})
+
+	t.Run("the URL is built with the parameters' names in the spec", func(t *testing.T) {
+		assert.Contains(t, code, `runtime.StyleParamWithLocation("simple", false, "pet-id", runtime.ParamLocationPath, petId)`)
+		assert.Contains(t, code, `runtime.StyleParamWithLocation("simple", false, "!!", runtime.ParamLocationPath, notNot)`)
+	})
+
+	t.Run("routes name parameters which aren't valid wildcards after their Go variables", func(t *testing.T) {
+		assert.Contains(t, code, `m.HandleFunc("GET "+options.BaseURL+"/pets/{petId}/toys/{toyId}/{type}/{notNot}/{ownerFullName}", wrapper.GetToy)`)
+		assert.Contains(t, code, `runtime.BindStyledParameterWithOptions("simple", "pet-id", r.PathValue("petId"), &petId`)
+		assert.Contains(t, code, `runtime.BindStyledParameterWithOptions("simple", "type", r.PathValue("type"), &pType`)
+	})
+}
+
+func TestParamGoNameCollisions(t *testing.T) {
+	tests := map[string]struct {
+		parameters string
+		expected   string
+	}{
+		"path parameters": {
+			parameters: `
+        - {name: pet-id, in: path, required: true, schema: {type: string}}
+        - {name: petId, in: path, required: true, schema: {type: string}}`,
+			expected: `parameters 'pet-id' and 'petId' would both be named petId in Go, so one of them needs renaming with "x-go-name"`,
+		},
+		"query parameters": {
+			parameters: `
+        - {name: pet-id, in: path, required: true, schema: {type: string}}
+        - {name: petId, in: path, required: true, x-go-name: OtherPetId, schema: {type: string}}
+        - {name: sort-by, in: query, schema: {type: string}}
+        - {name: sort_by, in: query, schema: {type: string}}`,
+			expected: `parameters 'sort-by' and 'sort_by' would both be named SortBy in Go, so one of them needs renaming with "x-go-name"`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			spec := `openapi: 3.0.0
+info:
+  title: parameter names
+  version: 1.0.0
+paths:
+  /pets/{pet-id}/{petId}:
+    get:
+      parameters:` + tc.parameters + `
+      responses:
+        '204':
+          description: OK
+`
+			swagger, err := openapi.NewLoader().LoadFromData([]byte(spec))
+			require.NoError(t, err)
+
+			_, err = Generate(swagger, Configuration{
+				PackageName: "api",
+				Generate: GenerateOptions{
+					Models: true,
+					Client: true,
+				},
+			})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expected)
+		})
+	}
+}
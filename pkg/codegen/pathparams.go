@@ -0,0 +1,11 @@
+package codegen
+
+// hasPathParams reports whether any of the operations has path parameters, for which the `escapePathParam` helper is generated, to escape them as they're placed in the path of a request
+func hasPathParams(ops []OperationDefinition) bool {
+	for i := range ops {
+		if len(ops[i].PathParams) > 0 {
+			return true
+		}
+	}
+	return false
+}
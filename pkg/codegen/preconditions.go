@@ -0,0 +1,66 @@
+package codegen
+
+import "strings"
+
+// HasETagResponse reports whether any of the operation's responses declares an `ETag` header, for which the client's response type gets an `ETag` method, to pass to the conditional requests which follow
+func (o *OperationDefinition) HasETagResponse() bool {
+	for _, response := range o.Responses {
+		for _, header := range response.Headers {
+			if strings.EqualFold(header.Name, "ETag") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IfMatchParam returns the operation's `If-Match` header parameter, if it has one whose Go type is a string, which the strict server's precondition helper evaluates
+func (o *OperationDefinition) IfMatchParam() *ParameterDefinition {
+	return o.preconditionParam("If-Match")
+}
+
+// IfNoneMatchParam returns the operation's `If-None-Match` header parameter, if it has one whose Go type is a string, which the strict server's precondition helper evaluates
+func (o *OperationDefinition) IfNoneMatchParam() *ParameterDefinition {
+	return o.preconditionParam("If-None-Match")
+}
+
+// HasPreconditions reports whether the operation has an `If-Match` or `If-None-Match` header parameter which the strict server's precondition helper evaluates
+func (o *OperationDefinition) HasPreconditions() bool {
+	return o.IfMatchParam() != nil || o.IfNoneMatchParam() != nil
+}
+
+// IsSafeMethod reports whether the operation's method is GET or HEAD, for which a failed `If-None-Match` precondition is answered with 304 Not Modified, rather than 412 Precondition Failed
+func (o *OperationDefinition) IsSafeMethod() bool {
+	return o.Method == "GET" || o.Method == "HEAD"
+}
+
+func (o *OperationDefinition) preconditionParam(name string) *ParameterDefinition {
+	for i, param := range o.HeaderParams {
+		if strings.EqualFold(param.ParamName, name) && param.TypeDef() == "string" {
+			return &o.HeaderParams[i]
+		}
+	}
+	return nil
+}
+
+// hasConditionalRequestParams reports whether any of the operations has an `If-Match` or `If-None-Match` header parameter, for which the client gets request editors to set them with an ETag
+func hasConditionalRequestParams(ops []OperationDefinition) bool {
+	for _, op := range ops {
+		for _, param := range op.HeaderParams {
+			if strings.EqualFold(param.ParamName, "If-Match") || strings.EqualFold(param.ParamName, "If-None-Match") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasPreconditions reports whether any of the operations has preconditions for the strict server to evaluate
+func hasPreconditions(ops []OperationDefinition) bool {
+	for i := range ops {
+		if ops[i].HasPreconditions() {
+			return true
+		}
+	}
+	return false
+}
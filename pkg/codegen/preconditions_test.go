@@ -0,0 +1,126 @@
+package codegen
+
+import (
+	"go/importer"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const preconditionsSpec = `openapi: 3.0.0
+info:
+  title: Conditional requests
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: string
+    get:
+      operationId: getPet
+      parameters:
+        - name: If-None-Match
+          in: header
+          schema:
+            type: string
+      responses:
+        '200':
+          description: OK
+          headers:
+            ETag:
+              schema:
+                type: string
+        '304':
+          description: Not Modified
+    put:
+      operationId: putPet
+      parameters:
+        - name: if-match
+          in: header
+          required: true
+          schema:
+            type: string
+        - name: If-None-Match
+          in: header
+          schema:
+            type: string
+      responses:
+        '204':
+          description: OK
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '204':
+          description: OK
+`
+
+func TestGeneratePreconditions(t *testing.T) {
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			StdHTTPServer: true,
+			Strict:        true,
+			Client:        true,
+			Models:        true,
+		},
+	}
+
+	code, err := generateFromSpec(t, preconditionsSpec, opts)
+	require.NoError(t, err)
+
+	t.Run("responses which declare an ETag header return it", func(t *testing.T) {
+		assert.Contains(t, code, "func (r GetPetResponse) ETag() string {")
+		assert.NotContains(t, code, "func (r PutPetResponse) ETag() string {")
+		assert.NotContains(t, code, "func (r ListPetsResponse) ETag() string {")
+	})
+
+	t.Run("the client can pass an ETag with request editors", func(t *testing.T) {
+		assert.Contains(t, code, "func WithIfMatch(etag string) RequestEditorFn {")
+		assert.Contains(t, code, "func WithIfNoneMatch(etag string) RequestEditorFn {")
+	})
+
+	t.Run("safe methods are not modified when If-None-Match matches", func(t *testing.T) {
+		assert.Contains(t, code, "func (request GetPetRequestObject) CheckPreconditions(etag string) int {\n\tif ifNoneMatch := request.Params.IfNoneMatch; ifNoneMatch != nil && etagListMatches(*ifNoneMatch, etag, true) {\n\t\treturn http.StatusNotModified\n\t}\n\treturn 0\n}")
+	})
+
+	t.Run("other methods fail when either precondition does", func(t *testing.T) {
+		assert.Contains(t, code, "func (request PutPetRequestObject) CheckPreconditions(etag string) int {\n\tif ifMatch := request.Params.IfMatch; ifMatch != \"\" && !etagListMatches(ifMatch, etag, false) {\n\t\treturn http.StatusPreconditionFailed\n\t}\n\tif ifNoneMatch := request.Params.IfNoneMatch; ifNoneMatch != nil && etagListMatches(*ifNoneMatch, etag, true) {\n\t\treturn http.StatusPreconditionFailed\n\t}\n\treturn 0\n}")
+		assert.NotContains(t, code, "func (request ListPetsRequestObject) CheckPreconditions(")
+	})
+
+	t.Run("the code type checks", func(t *testing.T) {
+		fset := token.NewFileSet()
+		typeCheck(t, fset, importer.ForCompiler(fset, "source", nil), code, `package api
+
+var _ int = GetPetRequestObject{}.CheckPreconditions("\"v1\"")
+var _ string = GetPetResponse{}.ETag()
+var _ RequestEditorFn = WithIfMatch("\"v1\"")
+`)
+	})
+
+	t.Run("nothing is generated without conditional headers", func(t *testing.T) {
+		code, err := generateFromSpec(t, `openapi: 3.0.0
+info:
+  title: Unconditional requests
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '204':
+          description: OK
+`, opts)
+		require.NoError(t, err)
+		assert.NotContains(t, code, "ETag")
+		assert.NotContains(t, code, "WithIfMatch")
+		assert.NotContains(t, code, "CheckPreconditions")
+		assert.NotContains(t, code, "etagListMatches")
+	})
+}
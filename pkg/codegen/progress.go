@@ -0,0 +1,62 @@
+package codegen
+
+import (
+	"strings"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+// HasBinaryContent reports whether any of the operation's request bodies is multipart or binary, such as `application/octet-stream`, or any of its responses is binary, whose transfer the client can report the progress of
+func (o *OperationDefinition) HasBinaryContent() bool {
+	if o.Spec == nil {
+		return false
+	}
+	if o.Spec.RequestBody != nil && o.Spec.RequestBody.Value != nil {
+		for contentType, content := range o.Spec.RequestBody.Value.Content {
+			if strings.HasPrefix(contentType, "multipart/") || isBinaryMediaType(contentType, content) {
+				return true
+			}
+		}
+	}
+	if o.Spec.Responses != nil {
+		for _, response := range o.Spec.Responses.Map() {
+			if response == nil || response.Value == nil {
+				continue
+			}
+			for contentType, content := range response.Value.Content {
+				if isBinaryMediaType(contentType, content) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// isBinaryMediaType returns whether content is binary, being a string of `format: binary`, or of a content type which is always binary, such as `application/octet-stream` or `image/png`, unlike text, such as `text/csv`, or a structured format, such as `application/xml`
+func isBinaryMediaType(contentType string, content *openapi.MediaType) bool {
+	if isBinaryContent(content) {
+		return true
+	}
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.TrimSpace(strings.ToLower(mediaType))
+	if mediaType == "application/octet-stream" {
+		return true
+	}
+	for _, prefix := range []string{"image/", "audio/", "video/", "font/"} {
+		if strings.HasPrefix(mediaType, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasBinaryContent reports whether any of the operations has binary content, for which the client gets the `WithUploadProgress` request editor and the `WithDownloadProgress` function
+func hasBinaryContent(ops []OperationDefinition) bool {
+	for i := range ops {
+		if ops[i].HasBinaryContent() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+package codegen
+
+import (
+	"go/importer"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const progressSpec = `openapi: 3.0.0
+info:
+  title: Files
+  version: 1.0.0
+paths:
+  /files/{name}:
+    parameters:
+      - name: name
+        in: path
+        required: true
+        schema:
+          type: string
+    get:
+      operationId: getFile
+      responses:
+        '200':
+          description: OK
+          content:
+            application/octet-stream:
+              schema:
+                type: string
+                format: binary
+    put:
+      operationId: putFile
+      requestBody:
+        content:
+          application/octet-stream:
+            schema:
+              type: string
+              format: binary
+      responses:
+        '204':
+          description: Stored
+`
+
+func TestGenerateTransferProgress(t *testing.T) {
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client: true,
+			Models: true,
+		},
+	}
+
+	code, err := generateFromSpec(t, progressSpec, opts)
+	require.NoError(t, err)
+
+	t.Run("the client can report the progress of transfers", func(t *testing.T) {
+		assert.Contains(t, code, "func WithUploadProgress(progress ProgressFunc) RequestEditorFn {")
+		assert.Contains(t, code, "func WithDownloadProgress(ctx context.Context, progress ProgressFunc) context.Context {")
+		assert.Contains(t, code, "if progress, ok := req.Context().Value(downloadProgressKey{}).(ProgressFunc); ok {")
+	})
+
+	t.Run("the code type checks", func(t *testing.T) {
+		fset := token.NewFileSet()
+		typeCheck(t, fset, importer.ForCompiler(fset, "source", nil), code, `package api
+
+import (
+	"context"
+	"os"
+)
+
+func upload(ctx context.Context, client ClientInterface, f *os.File, report func(transferred, total int64)) error {
+	rsp, err := client.PutFileWithBody(ctx, "pet.png", "application/octet-stream", f, WithUploadProgress(report))
+	if err != nil {
+		return err
+	}
+	return rsp.Body.Close()
+}
+
+func download(ctx context.Context, client ClientInterface, report ProgressFunc) error {
+	rsp, err := client.GetFile(WithDownloadProgress(ctx, report), "pet.png")
+	if err != nil {
+		return err
+	}
+	return rsp.Body.Close()
+}
+`)
+	})
+
+	t.Run("nothing is generated without binary content", func(t *testing.T) {
+		code, err := generateFromSpec(t, `openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  type: string
+`, opts)
+		require.NoError(t, err)
+		assert.NotContains(t, code, "Progress")
+		assert.NotContains(t, code, "sendWithRetries")
+	})
+
+	t.Run("nothing is generated for text or structured content", func(t *testing.T) {
+		code, err := generateFromSpec(t, `openapi: 3.0.0
+info:
+  title: Reports
+  version: 1.0.0
+paths:
+  /reports:
+    post:
+      operationId: createReport
+      requestBody:
+        content:
+          text/csv:
+            schema:
+              type: string
+      responses:
+        '200':
+          description: OK
+          content:
+            application/xml:
+              schema:
+                type: object
+`, opts)
+		require.NoError(t, err)
+		assert.NotContains(t, code, "Progress")
+	})
+}
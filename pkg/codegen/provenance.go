@@ -0,0 +1,39 @@
+package codegen
+
+import (
+	"fmt"
+	"text/template"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+// SpecProvenance identifies the spec which the code is generated from, as configured with the `spec-provenance` option
+type SpecProvenance struct {
+	// Version is the spec's `info.version`
+	Version string
+	// Hash is the canonical SHA-256 hash of the spec, along with the documents it references, as returned by `openapi.T.ContentHash`
+	Hash string
+}
+
+// newSpecProvenance hashes the spec, for the header of the generated code, and its `SpecVersion` and `SpecHash` functions
+func newSpecProvenance(spec *openapi.T) (*SpecProvenance, error) {
+	hash, err := spec.ContentHash()
+	if err != nil {
+		return nil, err
+	}
+	provenance := &SpecProvenance{Hash: hash}
+	if spec.Info != nil && spec.Info.Info != nil {
+		provenance.Version = spec.Info.Version
+	}
+	return provenance, nil
+}
+
+// GenerateSpecProvenance generates, as configured with the `spec-provenance` option, the `SpecVersion()` and `SpecHash()` functions, which return the version and hash of the spec which the code was generated from, so that services can report exactly which contract they were built against
+func GenerateSpecProvenance(t *template.Template, provenance *SpecProvenance) (string, error) {
+	for _, name := range []string{"SpecVersion", "SpecHash"} {
+		if globalState.modelTypes[name] {
+			return "", fmt.Errorf("the function which returns the spec's provenance would be named %s, which is the name of a model. Please rename the model, or disable `spec-provenance`", name)
+		}
+	}
+	return GenerateTemplates([]string{"spec-provenance.tmpl"}, t, provenance)
+}
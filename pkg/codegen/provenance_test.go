@@ -0,0 +1,65 @@
+package codegen
+
+import (
+	"go/importer"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+const provenanceSpec = `openapi: 3.0.0
+info:
+  title: Provenance
+  version: 2.1.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+`
+
+func TestSpecProvenance(t *testing.T) {
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:         true,
+			SpecProvenance: true,
+		},
+	}
+
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(provenanceSpec))
+	require.NoError(t, err)
+	hash, err := swagger.ContentHash()
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	assert.Contains(t, code, "DO NOT EDIT.\n//\n// Generated from version 2.1.0 of the spec, whose SHA-256 hash is\n// "+hash+".\npackage api\n")
+	assert.Contains(t, code, "func SpecVersion() string {\n\treturn \"2.1.0\"\n}")
+	assert.Contains(t, code, "func SpecHash() string {\n\treturn \""+hash+"\"\n}")
+
+	fset := token.NewFileSet()
+	typeCheck(t, fset, importer.ForCompiler(fset, "source", nil), code, "package api\n\nvar _, _ string = SpecVersion(), SpecHash()\n")
+
+	t.Run("disabled", func(t *testing.T) {
+		code, err := Generate(swagger, Configuration{
+			PackageName: "api",
+			Generate:    GenerateOptions{Models: true},
+		})
+		require.NoError(t, err)
+		assert.NotContains(t, code, "Generated from version")
+		assert.NotContains(t, code, "SpecHash")
+	})
+
+	t.Run("collides with a model", func(t *testing.T) {
+		_, err := generateFromSpec(t, provenanceSpec+`    SpecHash:
+      type: string
+`, opts)
+		require.ErrorContains(t, err, "would be named SpecHash, which is the name of a model")
+	})
+}
@@ -2,9 +2,13 @@ package codegen
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"gopkg.in/yaml.v3"
 )
 
 func stringInSlice(a string, list []string) bool {
@@ -23,24 +27,41 @@ type RefWrapper struct {
 }
 
 func walkSwagger(swagger *openapi.T, doFn func(RefWrapper) (bool, error)) error {
-	if swagger == nil || swagger.Paths == nil {
+	if swagger == nil {
 		return nil
 	}
 
-	for _, p := range swagger.Paths.Map() {
-		for _, param := range p.Parameters {
-			_ = walkParameterRef(param, doFn)
-		}
-		for _, op := range p.Operations() {
-			_ = walkOperation(op, doFn)
+	if swagger.Paths != nil {
+		for _, p := range swagger.Paths.Map() {
+			_ = walkPathItem(p, doFn)
 		}
 	}
 
+	for _, p := range swagger.Webhooks {
+		_ = walkPathItem(p, doFn)
+	}
+
 	_ = walkComponents(swagger.Components, doFn)
 
 	return nil
 }
 
+func walkPathItem(pathItem *openapi.PathItem, doFn func(RefWrapper) (bool, error)) error {
+	// Not a valid ref, ignore it and continue
+	if pathItem == nil {
+		return nil
+	}
+
+	for _, param := range pathItem.Parameters {
+		_ = walkParameterRef(param, doFn)
+	}
+	for _, op := range pathItem.Operations() {
+		_ = walkOperation(op, doFn)
+	}
+
+	return nil
+}
+
 func walkOperation(op *openapi.Operation, doFn func(RefWrapper) (bool, error)) error {
 	// Not a valid ref, ignore it and continue
 	if op == nil {
@@ -108,6 +129,10 @@ func walkComponents(components *openapi.Components, doFn func(RefWrapper) (bool,
 		_ = walkCallbackRef(callback, doFn)
 	}
 
+	for _, pathItem := range components.PathItems {
+		_ = walkPathItemRef(pathItem, doFn)
+	}
+
 	return nil
 }
 
@@ -299,18 +324,29 @@ func walkCallbackRef(ref *openapi.CallbackRef, doFn func(RefWrapper) (bool, erro
 	}
 
 	for _, pathItem := range ref.Value.Map() {
-		for _, parameter := range pathItem.Parameters {
-			_ = walkParameterRef(parameter, doFn)
-		}
-		// Use the Operations() method which returns wrapped operations
-		for _, op := range pathItem.Operations() {
-			_ = walkOperation(op, doFn)
-		}
+		_ = walkPathItem(pathItem, doFn)
 	}
 
 	return nil
 }
 
+func walkPathItemRef(ref *openapi.PathItemRef, doFn func(RefWrapper) (bool, error)) error {
+	// Not a valid ref, ignore it and continue
+	if ref == nil {
+		return nil
+	}
+	refWrapper := RefWrapper{Ref: ref.Ref, HasValue: ref.Value != nil, SourceRef: ref}
+	shouldContinue, err := doFn(refWrapper)
+	if err != nil {
+		return err
+	}
+	if !shouldContinue {
+		return nil
+	}
+
+	return walkPathItem(ref.Value, doFn)
+}
+
 func walkHeaderRef(ref *openapi.HeaderRef, doFn func(RefWrapper) (bool, error)) error {
 	// Not a valid ref, ignore it and continue
 	if ref == nil {
@@ -431,88 +467,97 @@ func findComponentRefs(swagger *openapi.T) []string {
 	return refs
 }
 
-func removeOrphanedComponents(swagger *openapi.T, refs []string) int {
-	if swagger == nil || swagger.Components == nil {
-		return 0
-	}
-
-	countRemoved := 0
-
-	for key := range swagger.Components.Schemas {
-		ref := fmt.Sprintf("#/components/schemas/%s", key)
-		if !stringInSlice(ref, refs) {
-			countRemoved++
-			delete(swagger.Components.Schemas, key)
+// documentComponentRefs returns the references to the components which the rendered spec has, as those of the model are resolved by libopenapi, so that the components which are pruned are exactly those which nothing left in the spec, including what's embedded, refers to
+func documentComponentRefs(swagger *openapi.T) ([]string, error) {
+	rendered, err := swagger.Render()
+	if err != nil {
+		return nil, fmt.Errorf("failed to render spec: %w", err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(rendered, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse rendered spec: %w", err)
+	}
+
+	var refs []string
+	var walk func(n *yaml.Node)
+	walk = func(n *yaml.Node) {
+		if n.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				if n.Content[i].Value == "$ref" && n.Content[i+1].Kind == yaml.ScalarNode {
+					if ref := componentRef(n.Content[i+1].Value); ref != "" {
+						refs = append(refs, ref)
+					}
+				}
+			}
 		}
-	}
-
-	for key := range swagger.Components.Parameters {
-		ref := fmt.Sprintf("#/components/parameters/%s", key)
-		if !stringInSlice(ref, refs) {
-			countRemoved++
-			delete(swagger.Components.Parameters, key)
+		for _, child := range n.Content {
+			walk(child)
 		}
 	}
+	walk(&doc)
+	return refs, nil
+}
 
-	// securitySchemes are an exception. definitions in securitySchemes
-	// are referenced directly by name. and not by $ref
-
-	// for key, _ := range swagger.Components.SecuritySchemes {
-	// 	ref := fmt.Sprintf("#/components/securitySchemes/%s", key)
-	// 	if !stringInSlice(ref, refs) {
-	// 		countRemoved++
-	// 		delete(swagger.Components.SecuritySchemes, key)
-	// 	}
-	// }
-
-	for key := range swagger.Components.RequestBodies {
-		ref := fmt.Sprintf("#/components/requestBodies/%s", key)
-		if !stringInSlice(ref, refs) {
-			countRemoved++
-			delete(swagger.Components.RequestBodies, key)
-		}
+// componentRef returns the reference to the component which ref refers to, or to part of, such as `#/components/schemas/Pet` for `#/components/schemas/Pet/properties/name`, or an empty string if ref isn't to a component of the spec
+func componentRef(ref string) string {
+	parts := strings.SplitN(ref, "/", 5)
+	if len(parts) < 4 || parts[0] != "#" || parts[1] != "components" {
+		return ""
 	}
+	name := strings.NewReplacer("~1", "/", "~0", "~").Replace(parts[3])
+	return fmt.Sprintf("#/components/%s/%s", parts[2], name)
+}
 
-	for key := range swagger.Components.Responses {
-		ref := fmt.Sprintf("#/components/responses/%s", key)
-		if !stringInSlice(ref, refs) {
-			countRemoved++
-			delete(swagger.Components.Responses, key)
-		}
+// pruneComponents removes the components of a kind, such as `schemas`, which refs doesn't have, from the spec's model as well as from its wrapper, which doesn't have each kind of component, so that the embedded spec doesn't have them either. It returns how many were removed
+func pruneComponents[V, W any](kind string, document *orderedmap.Map[string, V], wrapped map[string]W, refs []string) int {
+	names := make(map[string]bool)
+	for name := range wrapped {
+		names[name] = true
 	}
-
-	for key := range swagger.Components.Headers {
-		ref := fmt.Sprintf("#/components/headers/%s", key)
-		if !stringInSlice(ref, refs) {
-			countRemoved++
-			delete(swagger.Components.Headers, key)
+	if document != nil {
+		for name := range document.KeysFromOldest() {
+			names[name] = true
 		}
 	}
 
-	for key := range swagger.Components.Examples {
-		ref := fmt.Sprintf("#/components/examples/%s", key)
+	countRemoved := 0
+	for name := range names {
+		ref := fmt.Sprintf("#/components/%s/%s", kind, name)
 		if !stringInSlice(ref, refs) {
+			logger().Debug("pruned unused component", "ref", ref)
 			countRemoved++
-			delete(swagger.Components.Examples, key)
+			delete(wrapped, name)
+			if document != nil {
+				document.Delete(name)
+			}
 		}
 	}
+	return countRemoved
+}
 
-	for key := range swagger.Components.Links {
-		ref := fmt.Sprintf("#/components/links/%s", key)
-		if !stringInSlice(ref, refs) {
-			countRemoved++
-			delete(swagger.Components.Links, key)
-		}
+func removeOrphanedComponents(swagger *openapi.T, refs []string) int {
+	if swagger == nil || swagger.Components == nil {
+		return 0
 	}
 
-	for key := range swagger.Components.Callbacks {
-		ref := fmt.Sprintf("#/components/callbacks/%s", key)
-		if !stringInSlice(ref, refs) {
-			countRemoved++
-			delete(swagger.Components.Callbacks, key)
-		}
+	document := &v3.Components{}
+	if swagger.Document != nil && swagger.Document.Components != nil {
+		document = swagger.Document.Components
 	}
 
+	countRemoved := pruneComponents("schemas", document.Schemas, swagger.Components.Schemas, refs)
+	countRemoved += pruneComponents("parameters", document.Parameters, swagger.Components.Parameters, refs)
+
+	// securitySchemes are an exception. definitions in securitySchemes
+	// are referenced directly by name. and not by $ref
+
+	countRemoved += pruneComponents("requestBodies", document.RequestBodies, swagger.Components.RequestBodies, refs)
+	countRemoved += pruneComponents("responses", document.Responses, swagger.Components.Responses, refs)
+	countRemoved += pruneComponents("headers", document.Headers, swagger.Components.Headers, refs)
+	countRemoved += pruneComponents("examples", document.Examples, swagger.Components.Examples, refs)
+	countRemoved += pruneComponents("links", document.Links, swagger.Components.Links, refs)
+	countRemoved += pruneComponents("callbacks", document.Callbacks, swagger.Components.Callbacks, refs)
+
 	return countRemoved
 }
 
@@ -521,7 +566,13 @@ func pruneUnusedComponents(swagger *openapi.T) {
 		return
 	}
 	for {
-		refs := findComponentRefs(swagger)
+		documentRefs, err := documentComponentRefs(swagger)
+		if err != nil {
+			// Without the references of the rendered spec, components which are used would be pruned
+			logger().Debug("not pruning unused components", "error", err)
+			return
+		}
+		refs := append(findComponentRefs(swagger), documentRefs...)
 		countRemoved := removeOrphanedComponents(swagger, refs)
 		if countRemoved < 1 {
 			break
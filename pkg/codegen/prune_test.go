@@ -5,6 +5,7 @@ import (
 
 	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFindReferences(t *testing.T) {
@@ -434,3 +435,21 @@ components:
           enum: [car, cat, oldage]
 
 `
+
+func TestWalkWebhooksAndPathItems(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(webhooksAndCallbacksSpec))
+	require.NoError(t, err)
+
+	var refs []string
+	err = walkSwagger(swagger, func(ref RefWrapper) (bool, error) {
+		if ref.Ref != "" {
+			refs = append(refs, ref.Ref)
+		}
+		return true, nil
+	})
+	require.NoError(t, err)
+
+	// Pet is only referenced by the webhook, and Event only by the callback
+	assert.Contains(t, refs, "#/components/schemas/Pet")
+	assert.Contains(t, refs, "#/components/schemas/Event")
+}
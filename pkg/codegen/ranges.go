@@ -0,0 +1,36 @@
+package codegen
+
+import "strings"
+
+// RangeParam returns the operation's `Range` header parameter, if it has one whose Go type is a string, which the strict server's range helper parses
+func (o *OperationDefinition) RangeParam() *ParameterDefinition {
+	for i, param := range o.HeaderParams {
+		if strings.EqualFold(param.ParamName, "Range") && param.TypeDef() == "string" {
+			return &o.HeaderParams[i]
+		}
+	}
+	return nil
+}
+
+// HasPartialContent reports whether the operation takes a `Range` header parameter and declares a `206 Partial Content` response, for which the client gets a `Download<Operation>` function which resumes interrupted downloads, and the strict server's request object gets a `RequestedRange` method
+func (o *OperationDefinition) HasPartialContent() bool {
+	if o.RangeParam() == nil {
+		return false
+	}
+	for _, response := range o.Responses {
+		if response.StatusCode == "206" {
+			return true
+		}
+	}
+	return false
+}
+
+// hasPartialContent reports whether any of the operations serves partial content, for which the client and the strict server get the range helpers
+func hasPartialContent(ops []OperationDefinition) bool {
+	for i := range ops {
+		if ops[i].HasPartialContent() {
+			return true
+		}
+	}
+	return false
+}
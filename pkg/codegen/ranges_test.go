@@ -0,0 +1,152 @@
+package codegen
+
+import (
+	"go/importer"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const rangesSpec = `openapi: 3.0.0
+info:
+  title: Ranged downloads
+  version: 1.0.0
+paths:
+  /files/{name}:
+    get:
+      operationId: getFile
+      parameters:
+        - name: name
+          in: path
+          required: true
+          schema:
+            type: string
+        - name: Range
+          in: header
+          schema:
+            type: string
+      responses:
+        '200':
+          description: OK
+          content:
+            application/octet-stream:
+              schema:
+                type: string
+                format: binary
+        '206':
+          description: Partial Content
+          headers:
+            Content-Range:
+              schema:
+                type: string
+          content:
+            application/octet-stream:
+              schema:
+                type: string
+                format: binary
+        '416':
+          description: Range Not Satisfiable
+          headers:
+            Content-Range:
+              schema:
+                type: string
+  /files:
+    get:
+      operationId: listFiles
+      parameters:
+        - name: Range
+          in: header
+          schema:
+            type: string
+      responses:
+        '200':
+          description: OK
+`
+
+func TestGenerateRanges(t *testing.T) {
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			StdHTTPServer: true,
+			Strict:        true,
+			Client:        true,
+			Models:        true,
+		},
+	}
+
+	code, err := generateFromSpec(t, rangesSpec, opts)
+	require.NoError(t, err)
+
+	t.Run("the client can download with ranges", func(t *testing.T) {
+		assert.Contains(t, code, "func WithRange(start, end int64) RequestEditorFn {")
+		assert.Contains(t, code, "func DownloadGetFile(ctx context.Context, client ClientInterface, name string, params *GetFileParams, w io.Writer, opts DownloadOptions) (int64, error) {")
+		assert.NotContains(t, code, "func DownloadListFiles(", "an operation without a 206 response doesn't serve ranges")
+	})
+
+	t.Run("the strict server parses the requested range", func(t *testing.T) {
+		assert.Contains(t, code, "func (request GetFileRequestObject) RequestedRange(size int64) (*ByteRange, error) {\n\tif request.Params.Range == nil {\n\t\treturn nil, nil\n\t}\n\treturn parseByteRange(*request.Params.Range, size)\n}")
+		assert.NotContains(t, code, "func (request ListFilesRequestObject) RequestedRange(")
+	})
+
+	t.Run("the code type checks", func(t *testing.T) {
+		fset := token.NewFileSet()
+		typeCheck(t, fset, importer.ForCompiler(fset, "source", nil), code, `package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+)
+
+type server struct{}
+
+func (server) GetFile(ctx context.Context, request GetFileRequestObject) (GetFileResponseObject, error) {
+	const content = "0123456789"
+	size := int64(len(content))
+	rng, err := request.RequestedRange(size)
+	if errors.Is(err, ErrRangeNotSatisfiable) {
+		return GetFile416Response{Headers: GetFile416ResponseHeaders{ContentRange: UnsatisfiedContentRange(size)}}, nil
+	}
+	if rng == nil {
+		return GetFile200ApplicationoctetStreamResponse{Body: strings.NewReader(content), ContentLength: size}, nil
+	}
+	return GetFile206ApplicationoctetStreamResponse{
+		Body:          io.NewSectionReader(strings.NewReader(content), rng.Start, rng.Length()),
+		ContentLength: rng.Length(),
+		Headers:       GetFile206ResponseHeaders{ContentRange: rng.ContentRange(size)},
+	}, nil
+}
+
+func (server) ListFiles(ctx context.Context, request ListFilesRequestObject) (ListFilesResponseObject, error) {
+	return ListFiles200Response{}, nil
+}
+
+var _ StrictServerInterface = server{}
+
+func resume(ctx context.Context, client ClientInterface, w io.Writer, offset int64) (int64, error) {
+	return DownloadGetFile(ctx, client, "pet.png", &GetFileParams{}, w, DownloadOptions{Offset: offset, Resumes: 3})
+}
+`)
+	})
+
+	t.Run("nothing is generated without partial content", func(t *testing.T) {
+		code, err := generateFromSpec(t, `openapi: 3.0.0
+info:
+  title: Whole downloads
+  version: 1.0.0
+paths:
+  /files:
+    get:
+      operationId: listFiles
+      responses:
+        '200':
+          description: OK
+`, opts)
+		require.NoError(t, err)
+		assert.NotContains(t, code, "WithRange")
+		assert.NotContains(t, code, "ByteRange")
+	})
+}
@@ -0,0 +1,137 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// valueReference is a reference from a type to another type, which it contains by value, rather than via a pointer, slice or map
+type valueReference struct {
+	target string
+	// property holds the reference, and is nil when it can't be replaced with a pointer, such as for an embedded type
+	property *Property
+}
+
+// valueReferences returns the references to other types which a schema contains by value, including those within inline structs
+func valueReferences(s *Schema) []valueReference {
+	var refs []valueReference
+	for _, embeddedType := range s.EmbeddedTypes {
+		if !strings.HasPrefix(embeddedType, "*") {
+			refs = append(refs, valueReference{target: embeddedType})
+		}
+	}
+
+	for i := range s.Properties {
+		p := &s.Properties[i]
+
+		// The same as GenFieldsFromProperties, which may skip the optional pointer
+		resolved := *p
+		if extension, ok := p.Extensions[extPropGoTypeSkipOptionalPointer]; ok {
+			if skipOptionalPointer, err := extParsePropGoTypeSkipOptionalPointer(extension); err == nil {
+				resolved.Schema.SkipOptionalPointer = skipOptionalPointer
+			}
+		}
+		if resolved.GoTypeDef() != p.Schema.TypeDecl() {
+			// Already a pointer, or a nullable.Nullable
+			continue
+		}
+
+		switch {
+		case p.Schema.RefType != "":
+			refs = append(refs, valueReference{target: p.Schema.RefType, property: p})
+		case strings.HasPrefix(p.Schema.GoType, "struct"):
+			refs = append(refs, valueReferences(&p.Schema)...)
+		}
+	}
+	return refs
+}
+
+// BreakRecursiveTypes finds the types which would contain themselves by
+// value, either directly or via other types, and makes the property which
+// completes each cycle a pointer, as such a recursive type doesn't compile.
+// Slices and maps already break a cycle, so are left as they are.
+//
+// Every copy of a type definition, across all the given lists, is updated.
+func BreakRecursiveTypes(typeDefLists ...[]TypeDefinition) error {
+	copies := make(map[string][]*TypeDefinition)
+	for _, typeDefs := range typeDefLists {
+		for i := range typeDefs {
+			td := &typeDefs[i]
+			copies[td.TypeName] = append(copies[td.TypeName], td)
+		}
+	}
+
+	graph := make(map[string][]valueReference, len(copies))
+	for name, tds := range copies {
+		s := &tds[0].Schema
+		if s.RefType != "" {
+			// A type defined as another type, such as `type Foo Bar`, which can't be made a pointer
+			graph[name] = []valueReference{{target: s.RefType}}
+			continue
+		}
+		if strings.HasPrefix(s.GoType, "struct") {
+			graph[name] = valueReferences(s)
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(graph))
+	changed := make(map[string]bool)
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		state[name] = visiting
+		for _, ref := range graph[name] {
+			if _, ok := graph[ref.target]; !ok {
+				continue
+			}
+			switch state[ref.target] {
+			case visiting:
+				if ref.property == nil {
+					return fmt.Errorf("type '%s' contains itself by value through '%s', which can't be made a pointer", ref.target, name)
+				}
+				logger().Debug("using a pointer for recursive property", "type", name, "property", ref.property.JsonFieldName, "references", ref.target)
+				ref.property.Recursive = true
+				changed[name] = true
+			case unvisited:
+				if err := visit(ref.target); err != nil {
+					return err
+				}
+			}
+		}
+		state[name] = visited
+		return nil
+	}
+
+	for _, name := range SortedMapKeys(graph) {
+		if state[name] == unvisited {
+			if err := visit(name); err != nil {
+				return err
+			}
+		}
+	}
+
+	for name := range changed {
+		for _, td := range copies[name] {
+			regenerateStruct(&td.Schema)
+		}
+	}
+	return nil
+}
+
+// regenerateStruct regenerates the Go type of a struct, and of the inline structs of its properties, after their properties have changed
+func regenerateStruct(s *Schema) {
+	if !strings.HasPrefix(s.GoType, "struct") {
+		return
+	}
+	for i := range s.Properties {
+		if s.Properties[i].Schema.RefType == "" {
+			regenerateStruct(&s.Properties[i].Schema)
+		}
+	}
+	s.GoType = GenStructFromSchema(*s)
+}
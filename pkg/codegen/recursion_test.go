@@ -0,0 +1,129 @@
+package codegen
+
+import (
+	"go/format"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+const recursiveTypesSpec = `openapi: 3.0.3
+info:
+  title: recursive types
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Direct:
+      type: object
+      required: [name, self]
+      properties:
+        name:
+          type: string
+        self:
+          $ref: '#/components/schemas/Direct'
+    IndirectA:
+      type: object
+      required: [b]
+      properties:
+        b:
+          $ref: '#/components/schemas/IndirectB'
+    IndirectB:
+      type: object
+      required: [c]
+      properties:
+        c:
+          $ref: '#/components/schemas/IndirectC'
+    IndirectC:
+      type: object
+      required: [a]
+      properties:
+        a:
+          $ref: '#/components/schemas/IndirectA'
+    Tree:
+      type: object
+      required: [children, siblings]
+      properties:
+        children:
+          type: array
+          items:
+            $ref: '#/components/schemas/Tree'
+        siblings:
+          type: object
+          additionalProperties:
+            $ref: '#/components/schemas/Tree'
+    Inline:
+      type: object
+      required: [metadata]
+      properties:
+        metadata:
+          type: object
+          required: [owner]
+          properties:
+            owner:
+              $ref: '#/components/schemas/Inline'
+    Optional:
+      type: object
+      properties:
+        next:
+          $ref: '#/components/schemas/Optional'
+        previous:
+          $ref: '#/components/schemas/Optional'
+          x-go-type-skip-optional-pointer: true
+`
+
+func generateRecursiveTypes(t *testing.T, outputOptions OutputOptions) string {
+	t.Helper()
+
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(recursiveTypesSpec))
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+		},
+		OutputOptions: outputOptions,
+	})
+	require.NoError(t, err)
+
+	_, err = format.Source([]byte(code))
+	require.NoError(t, err)
+	return code
+}
+
+func TestBreakRecursiveTypes(t *testing.T) {
+	code := generateRecursiveTypes(t, OutputOptions{})
+
+	t.Run("direct", func(t *testing.T) {
+		assert.Contains(t, code, "Name string  `json:\"name\"`")
+		assert.Contains(t, code, "Self *Direct `json:\"self\"`")
+	})
+
+	t.Run("indirect", func(t *testing.T) {
+		// Only the property which completes the cycle is made a pointer
+		assert.Contains(t, code, "B IndirectB `json:\"b\"`")
+		assert.Contains(t, code, "C IndirectC `json:\"c\"`")
+		assert.Contains(t, code, "A *IndirectA `json:\"a\"`")
+	})
+
+	t.Run("array and map", func(t *testing.T) {
+		assert.Contains(t, code, "Children []Tree          `json:\"children\"`")
+		assert.Contains(t, code, "Siblings map[string]Tree `json:\"siblings\"`")
+	})
+
+	t.Run("inline struct", func(t *testing.T) {
+		assert.Contains(t, code, "Owner *Inline `json:\"owner\"`")
+	})
+
+	t.Run("skipped optional pointer", func(t *testing.T) {
+		assert.Contains(t, code, "Next     *Optional `json:\"next,omitempty\"`")
+		assert.Contains(t, code, "Previous *Optional `json:\"previous,omitempty\"`")
+
+		code := generateRecursiveTypes(t, OutputOptions{PreferSkipOptionalPointer: true})
+		assert.Contains(t, code, "Next     *Optional `json:\"next,omitempty\"`")
+	})
+}
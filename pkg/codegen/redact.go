@@ -0,0 +1,137 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// RedactedTypeDefinition is a struct which has sensitive fields, whose `String` and `GoString` methods redact their values
+type RedactedTypeDefinition struct {
+	TypeName string
+	// String is the body of the `String` method
+	String string
+	// GoString is the body of the `GoString` method
+	GoString string
+}
+
+// redactedField is a field of a struct, as it's formatted by the `String` and `GoString` methods
+type redactedField struct {
+	copyField
+	// JsonName is the name of the property the field was generated for, which is empty for the types embedded in the struct, and its additional properties
+	JsonName  string
+	Sensitive bool
+	// Embedded is set for a type embedded in the struct, whose field is named after the type
+	Embedded bool
+}
+
+// GenerateRedactedBoilerplate generates `String` and `GoString` methods for each struct which has a sensitive field, which is one marked with `x-sensitive`, or with the `password` format, or which is `writeOnly`, so that the values of those fields aren't leaked when the struct is formatted with `%v`, such as in logs. A struct which embeds one of these structs gets the methods too, as otherwise it would be formatted with the embedded struct's `String` method alone
+func GenerateRedactedBoilerplate(t *template.Template, typeDefs []TypeDefinition) (string, error) {
+	structs := make(map[string][]redactedField)
+	var names []string
+	for _, td := range typeDefs {
+		if _, found := structs[td.TypeName]; found {
+			continue
+		}
+		if !isRedactableStruct(td) {
+			continue
+		}
+		structs[td.TypeName] = redactedFields(td.Schema)
+		names = append(names, td.TypeName)
+	}
+
+	redacted := make(map[string]bool)
+	for changed := true; changed; {
+		changed = false
+		for _, name := range names {
+			if redacted[name] {
+				continue
+			}
+			for _, field := range structs[name] {
+				if field.Sensitive || (field.Embedded && redacted[field.Name]) {
+					redacted[name] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	var types []RedactedTypeDefinition
+	for _, name := range names {
+		if !redacted[name] {
+			continue
+		}
+		def, err := redactedTypeDefinition(name, structs[name])
+		if err != nil {
+			return "", err
+		}
+		types = append(types, def)
+	}
+
+	if len(types) == 0 {
+		return "", nil
+	}
+
+	context := struct {
+		Types []RedactedTypeDefinition
+	}{
+		Types: types,
+	}
+
+	return GenerateTemplates([]string{"redact.tmpl"}, t, context)
+}
+
+// isRedactableStruct returns whether the type is defined as a struct of its own, rather than an alias, or a union
+func isRedactableStruct(td TypeDefinition) bool {
+	return !td.IsAlias() && len(td.Schema.UnionElements) == 0 && strings.HasPrefix(td.Schema.GoType, "struct")
+}
+
+// redactedFields returns the fields of a struct, in the order they're declared, with the embedded types named as the fields Go gives them
+func redactedFields(s Schema) []redactedField {
+	var fields []redactedField
+	for i, field := range structFields(s) {
+		f := redactedField{copyField: field}
+		// The embedded types come first, followed by the properties, in order
+		if i < len(s.EmbeddedTypes) {
+			f.Embedded = true
+		} else if p := i - len(s.EmbeddedTypes); p < len(s.Properties) {
+			prop := s.Properties[p]
+			f.JsonName = prop.JsonFieldName
+			f.Sensitive = prop.WriteOnly || isSensitive(prop.Extensions, prop.Schema.OAPISchema)
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// redactedTypeDefinition returns the bodies of the `String` and `GoString` methods of a struct, which format it as `%+v` and `%#v` would, other than the values of its sensitive fields
+func redactedTypeDefinition(typeName string, fields []redactedField) (RedactedTypeDefinition, error) {
+	var stringFormat, goStringFormat, args []string
+	for _, field := range fields {
+		if field.Name == "String" || field.Name == "GoString" {
+			return RedactedTypeDefinition{}, fmt.Errorf("%s has sensitive fields, so has String and GoString methods generated to redact them, but it has a field named %s. Please rename the field with `x-go-name`", typeName, field.Name)
+		}
+		if field.Sensitive {
+			stringFormat = append(stringFormat, field.Name+":"+redactedValue)
+			goStringFormat = append(goStringFormat, fmt.Sprintf("%s:%q", field.Name, redactedValue))
+			continue
+		}
+		stringFormat = append(stringFormat, field.Name+":%+v")
+		goStringFormat = append(goStringFormat, field.Name+":%#v")
+		args = append(args, "t."+field.Name)
+	}
+
+	sprintf := func(format string) string {
+		return fmt.Sprintf("return fmt.Sprintf(%s)", strings.Join(append([]string{fmt.Sprintf("%q", format)}, args...), ", "))
+	}
+	def := RedactedTypeDefinition{
+		TypeName: typeName,
+		GoString: sprintf(globalState.options.PackageName + "." + typeName + "{" + strings.Join(goStringFormat, ", ") + "}"),
+	}
+	// With `stringer`, the struct's String method already redacts its sensitive fields
+	if !globalState.options.Generate.Stringer {
+		def.String = sprintf("{" + strings.Join(stringFormat, " ") + "}")
+	}
+	return def, nil
+}
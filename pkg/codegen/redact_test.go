@@ -0,0 +1,119 @@
+package codegen
+
+import (
+	"go/importer"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+const redactSpec = `openapi: 3.0.0
+info:
+  title: redact
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Secret:
+      type: string
+      x-sensitive: true
+    Credentials:
+      type: object
+      required: [username, password]
+      properties:
+        username:
+          type: string
+        password:
+          type: string
+          format: password
+    User:
+      allOf:
+        - $ref: '#/components/schemas/Credentials'
+        - type: object
+          properties:
+            name:
+              type: string
+    Account:
+      type: object
+      properties:
+        key:
+          $ref: '#/components/schemas/Secret'
+        pin:
+          type: integer
+          writeOnly: true
+        owner:
+          $ref: '#/components/schemas/User'
+    Profile:
+      type: object
+      properties:
+        credentials:
+          $ref: '#/components/schemas/Credentials'
+`
+
+// redactUsage formats models with sensitive fields, so that type checking it alongside the generated code ensures they implement fmt.Stringer and fmt.GoStringer
+const redactUsage = `package api
+
+import "fmt"
+
+var (
+	_ fmt.Stringer   = Credentials{}
+	_ fmt.GoStringer = Account{}
+)
+`
+
+func TestRedactedBoilerplate(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(redactSpec))
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, code, "func (t Credentials) String() string {\n\treturn fmt.Sprintf(\"{Password:[REDACTED] Username:%+v}\", t.Username)")
+	assert.Contains(t, code, "func (t Credentials) GoString() string {\n\treturn fmt.Sprintf(\"api.Credentials{Password:\\\"[REDACTED]\\\", Username:%#v}\", t.Username)")
+	// A `$ref` to a sensitive schema, and a `writeOnly` field
+	assert.Contains(t, code, "return fmt.Sprintf(\"{Key:[REDACTED] Owner:%+v Pin:[REDACTED]}\", t.Owner)")
+	// A model which embeds a model with sensitive fields
+	assert.Contains(t, code, "return fmt.Sprintf(\"{Credentials:%+v Name:%+v}\", t.Credentials, t.Name)")
+	// A model which only refers to a model with sensitive fields is formatted with that model's String method already
+	assert.NotContains(t, code, "func (t Profile) String() string")
+
+	fset := token.NewFileSet()
+	typeCheck(t, fset, importer.ForCompiler(fset, "source", nil), code, redactUsage)
+}
+
+func TestRedactedFieldNameCollision(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(`openapi: 3.0.0
+info:
+  title: redact
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Credentials:
+      type: object
+      properties:
+        string:
+          type: string
+        password:
+          type: string
+          format: password
+`))
+	require.NoError(t, err)
+
+	_, err = Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+		},
+	})
+	assert.ErrorContains(t, err, "has a field named String")
+}
@@ -0,0 +1,100 @@
+package codegen
+
+import (
+	"go/format"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+const refSiblingsSpec = `openapi: 3.1.0
+info:
+  title: $ref siblings
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Name:
+      type: string
+      description: A name
+    Owner:
+      type: object
+      properties:
+        id:
+          type: string
+    Pet:
+      type: object
+      required: [name, owner]
+      properties:
+        name:
+          $ref: '#/components/schemas/Name'
+          description: The name of the pet
+          maxLength: 20
+          pattern: "^[a-z]+$"
+        nickname:
+          $ref: '#/components/schemas/Name'
+          description: What the pet is called at home
+        owner:
+          $ref: '#/components/schemas/Owner'
+          type: [object, "null"]
+        previousOwner:
+          $ref: '#/components/schemas/Owner'
+          deprecated: true
+`
+
+func generateRefSiblings(t *testing.T, spec string) string {
+	t.Helper()
+
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = format.Source([]byte(code))
+	require.NoError(t, err)
+	return code
+}
+
+func TestRefSiblings(t *testing.T) {
+	code := generateRefSiblings(t, refSiblingsSpec)
+
+	t.Run("constraints generate an alias", func(t *testing.T) {
+		assert.Contains(t, code, "Name Pet_Name `json:\"name\"`")
+		assert.Contains(t, code, `// Pet_Name The name of the pet
+//
+// It refers to Name, with the constraints maxLength: 20, pattern: ^[a-z]+$.
+type Pet_Name = Name`)
+	})
+
+	t.Run("description overrides the referenced schema's", func(t *testing.T) {
+		assert.Contains(t, code, "// Nickname What the pet is called at home\n\tNickname *Name")
+		assert.Contains(t, code, "// Name A name\ntype Name = string")
+	})
+
+	t.Run("nullable", func(t *testing.T) {
+		assert.Contains(t, code, "Owner    *Owner `json:\"owner\"`")
+	})
+
+	t.Run("deprecated", func(t *testing.T) {
+		assert.Contains(t, code, "// Deprecated: this property has been marked as deprecated upstream, but no `x-deprecated-reason` was set\n\tPreviousOwner *Owner")
+	})
+}
+
+func TestRefSiblingsIgnoredForOpenAPI30(t *testing.T) {
+	code := generateRefSiblings(t, strings.Replace(refSiblingsSpec, "openapi: 3.1.0", "openapi: 3.0.3", 1))
+
+	assert.NotContains(t, code, "Pet_Name")
+	assert.Contains(t, code, "Name Name `json:\"name\"`")
+	assert.Contains(t, code, "// Nickname A name\n")
+	assert.Contains(t, code, "Owner         Owner  `json:\"owner\"`")
+}
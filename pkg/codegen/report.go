@@ -0,0 +1,301 @@
+package codegen
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+// Report summarises what would be generated for a spec, and which parts of the spec the generated code doesn't fully represent. It's intended to be read by other tools, so is serialised as JSON
+type Report struct {
+	// OpenAPIVersion is the version of the OpenAPI Specification the spec uses
+	OpenAPIVersion string `json:"openapiVersion"`
+	// Operations are the operations which code is generated for
+	Operations []ReportOperation `json:"operations"`
+	// Types are the Go types generated for the spec's schemas
+	Types []ReportType `json:"types"`
+	// Unsupported are the uses of keywords which the generated code ignores
+	Unsupported []ReportIssue `json:"unsupported"`
+	// Downgraded are the schemas which are generated as a less specific Go type than they describe, such as a union of types as an `interface{}`
+	Downgraded []ReportIssue `json:"downgraded"`
+	// Inferred are the schemas whose Go type is inferred from their examples, or is left as described as their examples disagree, with `infer-map-value-types-from-examples`
+	Inferred []ReportIssue `json:"inferred"`
+	// Renamed are the types, operations, enum constants and fields which are given a different name to the one they'd otherwise have
+	Renamed []ReportRename `json:"renamed"`
+	// Failures are the parts of the spec which code couldn't be generated for
+	Failures []ReportIssue `json:"failures"`
+}
+
+// ReportOperation is an operation which code is generated for
+type ReportOperation struct {
+	OperationID string `json:"operationId"`
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+}
+
+// ReportType is a Go type which is generated for part of the spec
+type ReportType struct {
+	Name string `json:"name"`
+	// Source is the name of the part of the spec the type is generated for, such as the name of a component schema, or the path to an inline schema
+	Source string `json:"source"`
+}
+
+// ReportIssue describes a part of the spec which isn't fully represented by the generated code
+type ReportIssue struct {
+	Location string `json:"location"`
+	// Position is where the issue is in the spec, as `file:line:column`, if known
+	Position string `json:"position,omitempty"`
+	Message  string `json:"message"`
+}
+
+// ReportRename describes a type, operation, enum constant or field which is given a different name
+type ReportRename struct {
+	// Kind is one of `type`, `operation`, `enum-value` or `field`
+	Kind string `json:"kind"`
+	// Source is the part of the spec the name is generated for, such as the name of a component schema, the path to an inline schema, or an operation
+	Source string `json:"source"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+	// Reason is why the name was changed, such as which other name it collides with
+	Reason string `json:"reason"`
+}
+
+// GenerateReport generates the code for a spec, as Generate does, but rather than returning the code, returns a Report of what's generated. Parts of the spec which code can't be generated for are listed as failures in the Report, rather than returned as an error
+func GenerateReport(spec *openapi.T, opts Configuration) (*Report, error) {
+	report := &Report{
+		OpenAPIVersion: spec.OpenAPI,
+		Operations:     []ReportOperation{},
+		Types:          []ReportType{},
+		Unsupported:    []ReportIssue{},
+		Downgraded:     []ReportIssue{},
+		Inferred:       []ReportIssue{},
+		Renamed:        []ReportRename{},
+		Failures:       []ReportIssue{},
+	}
+
+	// Unsupported keywords are reported, rather than failing generation
+	opts.OutputOptions.StrictKeywords = false
+
+	globalState.report = report
+	defer func() {
+		globalState.report = nil
+	}()
+
+	_, err := Generate(spec, opts)
+	var genErr *Error
+	if errors.As(err, &genErr) {
+		for _, failure := range genErr.Failures {
+			report.Failures = append(report.Failures, newReportIssue(failure))
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	// The spec has been filtered by Generate, so only the keywords of what's generated are reported
+	if err := checkStrictKeywords(spec); errors.As(err, &genErr) {
+		for _, failure := range genErr.Failures {
+			report.Unsupported = append(report.Unsupported, newReportIssue(failure))
+		}
+	}
+
+	sort.SliceStable(report.Types, func(i, j int) bool {
+		return report.Types[i].Name < report.Types[j].Name
+	})
+	return report, nil
+}
+
+// GenerateNamingReport generates the code for a spec, as Generate does, but rather than returning the code, returns the names which are changed from those they'd otherwise have, such as to resolve a collision, so that naming changes between versions of a spec can be reviewed
+func GenerateNamingReport(spec *openapi.T, opts Configuration) ([]ReportRename, error) {
+	report := &Report{
+		Renamed: []ReportRename{},
+	}
+
+	globalState.report = report
+	defer func() {
+		globalState.report = nil
+	}()
+
+	if _, err := Generate(spec, opts); err != nil {
+		return nil, err
+	}
+	return report.Renamed, nil
+}
+
+func newReportIssue(failure *Failure) ReportIssue {
+	return ReportIssue{
+		Location: failure.Artifact,
+		Position: failure.Position.String(),
+		Message:  failure.Err.Error(),
+	}
+}
+
+// reportOperations records the operations which code is generated for, when generating a Report
+func reportOperations(ops []OperationDefinition) {
+	if globalState.report == nil {
+		return
+	}
+	for _, op := range ops {
+		globalState.report.Operations = append(globalState.report.Operations, ReportOperation{
+			OperationID: op.OperationId,
+			Method:      op.Method,
+			Path:        op.Path,
+		})
+	}
+}
+
+// reportTypes records the types which are generated, when generating a Report
+func reportTypes(typeDefs []TypeDefinition) {
+	if globalState.report == nil {
+		return
+	}
+	seen := make(map[string]bool, len(globalState.report.Types))
+	for _, t := range globalState.report.Types {
+		seen[t.Name] = true
+	}
+	for _, td := range typeDefs {
+		if seen[td.TypeName] {
+			continue
+		}
+		seen[td.TypeName] = true
+		globalState.report.Types = append(globalState.report.Types, ReportType{
+			Name:   td.TypeName,
+			Source: td.JsonName,
+		})
+	}
+}
+
+// reportDowngrade records that the schema at path is generated as a less specific Go type than it describes, when generating a Report
+func reportDowngrade(path []string, pos openapi.Position, format string, args ...interface{}) {
+	if globalState.report == nil {
+		return
+	}
+	issue := ReportIssue{
+		Location: strings.Join(path, "."),
+		Position: pos.String(),
+		Message:  fmt.Sprintf(format, args...),
+	}
+	// A schema may be generated more than once, such as when a type is regenerated
+	for _, downgraded := range globalState.report.Downgraded {
+		if downgraded == issue {
+			return
+		}
+	}
+	globalState.report.Downgraded = append(globalState.report.Downgraded, issue)
+}
+
+// reportInference records the decision of whether the type of the schema at path is inferred from its examples, when generating a Report
+func reportInference(path []string, pos openapi.Position, format string, args ...interface{}) {
+	if globalState.report == nil {
+		return
+	}
+	issue := ReportIssue{
+		Location: strings.Join(path, "."),
+		Position: pos.String(),
+		Message:  fmt.Sprintf(format, args...),
+	}
+	for _, inferred := range globalState.report.Inferred {
+		if inferred == issue {
+			return
+		}
+	}
+	globalState.report.Inferred = append(globalState.report.Inferred, issue)
+}
+
+// reportRename records that a type, operation, enum constant or field is given a different name, and why, when generating a Report
+func reportRename(kind, source, from, to, reason string) {
+	if globalState.report == nil {
+		return
+	}
+	rename := ReportRename{
+		Kind:   kind,
+		Source: source,
+		From:   from,
+		To:     to,
+		Reason: reason,
+	}
+	for _, renamed := range globalState.report.Renamed {
+		if renamed == rename {
+			return
+		}
+	}
+	globalState.report.Renamed = append(globalState.report.Renamed, rename)
+}
+
+// reportEnumPrefix records that the constants of an enum are prefixed with its type name, as they'd otherwise collide, when generating a Report
+func reportEnumPrefix(enum EnumDefinition, reason string) {
+	if enum.PrefixTypeName {
+		return
+	}
+	for _, name := range SortedMapKeys(enum.Schema.EnumValues) {
+		reportRename("enum-value", enum.TypeName, name, enum.TypeName+UppercaseFirstCharacter(name), reason)
+	}
+}
+
+// reportEnumValueRenames records the constants of an enum which aren't named after their values, or their names from x-enum-varnames, as SanitizeEnumNames transliterated or numbered them, when generating a Report
+func reportEnumValueRenames(path []string, enumNames, enumValues []string, sanitized map[string]string) {
+	if globalState.report == nil {
+		return
+	}
+	names := make(map[string]string, len(enumValues))
+	for i, v := range enumValues {
+		if _, ok := names[v]; ok {
+			continue
+		}
+		names[v] = v
+		if i < len(enumNames) {
+			names[v] = enumNames[i]
+		}
+	}
+	for _, to := range SortedMapKeys(sanitized) {
+		v := sanitized[to]
+		n := names[v]
+		from := SanitizeGoIdentity(SchemaNameToTypeName(n))
+		if from == to {
+			continue
+		}
+		reason := "collides with the name of another value"
+		if n == v && nameNormalizer(v) == "" {
+			from = v
+			reason = "the value is made up of symbols, so has no name of its own"
+		}
+		reportRename("enum-value", strings.Join(path, "."), from, to, reason)
+	}
+}
+
+// reportFieldRename records a field whose name isn't just its property's name, converted to a Go identifier, as a prefix is added to it, or characters which aren't allowed in Go identifiers are dropped from it, when generating a Report
+func reportFieldRename(path []string, prop Property) {
+	if globalState.report == nil || prop.Embedded {
+		return
+	}
+	if _, ok := prop.Extensions[extGoName]; ok {
+		return
+	}
+
+	var dropped []rune
+	for _, r := range prop.JsonFieldName {
+		if _, separator := separatorSet[r]; separator || unicode.IsUpper(r) || unicode.IsLower(r) || unicode.IsDigit(r) {
+			continue
+		}
+		if !slices.Contains(dropped, r) {
+			dropped = append(dropped, r)
+		}
+	}
+	var reasons []string
+	if prefix := typeNamePrefix(prop.JsonFieldName); prefix != "" {
+		first, _ := utf8.DecodeRuneInString(prop.JsonFieldName)
+		reasons = append(reasons, fmt.Sprintf("the property name starts with %q, so is prefixed with %s", first, prefix))
+	}
+	if len(dropped) > 0 {
+		reasons = append(reasons, fmt.Sprintf("the characters %q aren't allowed in Go identifiers, so are dropped", string(dropped)))
+	}
+	if len(reasons) == 0 {
+		return
+	}
+	reportRename("field", strings.Join(path, "."), prop.JsonFieldName, prop.GoFieldName(), strings.Join(reasons, ", and "))
+}
@@ -0,0 +1,158 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+const reportSpec = `openapi: 3.1.0
+info:
+  title: report
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+  /pets/{id}:
+    get:
+      operationId: listPets
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: OK
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        id:
+          type: [string, integer, boolean]
+        name:
+          type: string
+          not:
+            const: ""
+`
+
+func TestGenerateReport(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(reportSpec))
+	require.NoError(t, err)
+
+	report, err := GenerateReport(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+			Client: true,
+		},
+		OutputOptions: OutputOptions{
+			DuplicateOperationIds: string(DuplicateOperationIdStrategyNumberedSuffix),
+		},
+	})
+	require.NoError(t, err)
+	assert.Nil(t, globalState.report)
+
+	assert.Equal(t, "3.1.0", report.OpenAPIVersion)
+	assert.Equal(t, []ReportOperation{
+		{OperationID: "ListPets", Method: "GET", Path: "/pets"},
+		{OperationID: "ListPets2", Method: "GET", Path: "/pets/{id}"},
+	}, report.Operations)
+	assert.Contains(t, report.Types, ReportType{Name: "Pet", Source: "Pet"})
+
+	require.Len(t, report.Unsupported, 1)
+	assert.Equal(t, "components/schemas/Pet/properties/name", report.Unsupported[0].Location)
+	assert.Equal(t, "39:11", report.Unsupported[0].Position)
+
+	require.Len(t, report.Downgraded, 1)
+	assert.Equal(t, "Pet.id", report.Downgraded[0].Location)
+	assert.Equal(t, "the union of types [string integer boolean] is generated as interface{}", report.Downgraded[0].Message)
+
+	assert.Equal(t, []ReportRename{
+		{Kind: "operation", Source: "GET /pets/{id}", From: "ListPets", To: "ListPets2", Reason: "duplicate operationId, which is also used by GET /pets"},
+	}, report.Renamed)
+	assert.Empty(t, report.Failures)
+}
+
+func TestGenerateReportFailures(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(reportSpec))
+	require.NoError(t, err)
+
+	report, err := GenerateReport(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+		},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, report.Failures, 1)
+	assert.Equal(t, "GET /pets/{id}", report.Failures[0].Location)
+	assert.Contains(t, report.Failures[0].Message, "duplicate operationId ListPets")
+}
+
+const namingReportSpec = `openapi: 3.0.0
+info:
+  title: naming report
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Operator:
+      type: string
+      enum: ["a b", "a-b", "!="]
+    Color:
+      type: string
+      enum: [red, blue]
+    Mood:
+      type: string
+      enum: [blue]
+    Pet:
+      type: object
+      properties:
+        _id:
+          type: string
+        size/cm:
+          type: integer
+        name:
+          type: string
+`
+
+func TestGenerateNamingReport(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(namingReportSpec))
+	require.NoError(t, err)
+
+	renamed, err := GenerateNamingReport(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+		},
+	})
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []ReportRename{
+		{Kind: "enum-value", Source: "Operator", From: "AB", To: "ASpaceB", Reason: "collides with the name of another value"},
+		{Kind: "enum-value", Source: "Operator", From: "AB", To: "AMinusB", Reason: "collides with the name of another value"},
+		{Kind: "enum-value", Source: "Operator", From: "!=", To: "NotEqual", Reason: "the value is made up of symbols, so has no name of its own"},
+		{Kind: "enum-value", Source: "Color", From: "Blue", To: "ColorBlue", Reason: "the enum's constants collide with those of the enum Mood"},
+		{Kind: "enum-value", Source: "Color", From: "Red", To: "ColorRed", Reason: "the enum's constants collide with those of the enum Mood"},
+		{Kind: "enum-value", Source: "Mood", From: "Blue", To: "MoodBlue", Reason: "the enum's constants collide with those of the enum Color"},
+		{Kind: "field", Source: "Pet", From: "_id", To: "UnderscoreId", Reason: `the property name starts with '_', so is prefixed with Underscore`},
+		{Kind: "field", Source: "Pet", From: "size/cm", To: "Sizecm", Reason: `the characters "/" aren't allowed in Go identifiers, so are dropped`},
+	}, renamed)
+}
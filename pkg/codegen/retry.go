@@ -0,0 +1,39 @@
+package codegen
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+// retryPolicyNames are the names of the types and functions generated for the client's retry policy, with `client-retry-policy`
+var retryPolicyNames = []string{"RetryPolicy", "DefaultRetryableStatus", "WithRetryPolicy"}
+
+// checkRetryPolicyNames returns an error if any of the names generated for the client's retry policy is already the name of a model
+func checkRetryPolicyNames() error {
+	for _, name := range retryPolicyNames {
+		if globalState.modelTypes[name] {
+			return fmt.Errorf("the client's retry policy would generate %s, which is the name of a model. Please rename the model, or disable `client-retry-policy`", name)
+		}
+	}
+	return nil
+}
+
+// operationRetryable returns whether the client's retry policy may retry requests to an operation, which by default are those whose method is safe or idempotent, as RFC 9110 defines, so that retrying can't repeat an operation's effects, unless the operation sets `x-retryable`
+func operationRetryable(method string, op *openapi.Operation) (bool, error) {
+	if value, ok := op.Extensions[extRetryable]; ok {
+		retryable, err := extParseRetryable(value)
+		if err != nil {
+			return false, fmt.Errorf("invalid value for %q: %w", extRetryable, err)
+		}
+		return retryable, nil
+	}
+
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true, nil
+	default:
+		return false, nil
+	}
+}
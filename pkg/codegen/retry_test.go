@@ -0,0 +1,139 @@
+package codegen
+
+import (
+	"go/importer"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func retrySpec(feedPetRetryable string) string {
+	return `openapi: 3.0.0
+info:
+  title: Retries
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '204':
+          description: OK
+    post:
+      operationId: createPet
+      responses:
+        '204':
+          description: OK
+  /pets/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: string
+    put:
+      operationId: replacePet
+      responses:
+        '204':
+          description: OK
+    delete:
+      operationId: deletePet
+      x-retryable: false
+      responses:
+        '204':
+          description: OK
+  /pets/{id}/feed:
+    post:
+      operationId: feedPet
+      x-retryable: ` + feedPetRetryable + `
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '204':
+          description: OK
+`
+}
+
+func TestGenerateRetryableOperations(t *testing.T) {
+	config := func(retries bool) Configuration {
+		return Configuration{
+			PackageName: "api",
+			Generate: GenerateOptions{
+				Client: true,
+				Models: true,
+			},
+			OutputOptions: OutputOptions{
+				ClientRetryPolicy: retries,
+			},
+		}
+	}
+
+	code, err := generateFromSpec(t, retrySpec("true"), config(true))
+	require.NoError(t, err)
+
+	t.Run("safe and idempotent methods are retryable", func(t *testing.T) {
+		assert.Contains(t, code, "\t\"ListPets\":   true,\n")
+		assert.Contains(t, code, "\t\"ReplacePet\": true,\n")
+	})
+
+	t.Run("other methods aren't", func(t *testing.T) {
+		assert.Contains(t, code, "\t\"CreatePet\":  false,\n")
+	})
+
+	t.Run("x-retryable overrides the method", func(t *testing.T) {
+		assert.Contains(t, code, "\t\"DeletePet\":  false,\n")
+		assert.Contains(t, code, "\t\"FeedPet\":    true,\n")
+	})
+
+	t.Run("the code type checks", func(t *testing.T) {
+		fset := token.NewFileSet()
+		typeCheck(t, fset, importer.ForCompiler(fset, "source", nil), code, `package api
+
+import "time"
+
+var _ ClientOption = WithRetryPolicy(RetryPolicy{
+	MaxAttempts:     5,
+	Backoff:         func(retry int) time.Duration { return time.Second },
+	RetryableStatus: DefaultRetryableStatus,
+})
+`)
+	})
+
+	t.Run("x-retryable must be a boolean", func(t *testing.T) {
+		_, err := generateFromSpec(t, retrySpec("sometimes"), config(true))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid value for "x-retryable"`)
+	})
+
+	t.Run("the retry policy is only generated with client-retry-policy", func(t *testing.T) {
+		code, err := generateFromSpec(t, retrySpec("true"), config(false))
+		require.NoError(t, err)
+		assert.NotContains(t, code, "RetryPolicy")
+		assert.NotContains(t, code, "retryableOperations")
+
+		fset := token.NewFileSet()
+		typeCheck(t, fset, importer.ForCompiler(fset, "source", nil), code, "package api\n")
+	})
+
+	t.Run("the retry policy's names can't clash with a model", func(t *testing.T) {
+		spec := retrySpec("true") + `components:
+  schemas:
+    RetryPolicy:
+      type: object
+      properties:
+        attempts:
+          type: integer
+`
+		_, err := generateFromSpec(t, spec, config(true))
+		assert.ErrorContains(t, err, "the client's retry policy would generate RetryPolicy, which is the name of a model")
+
+		_, err = generateFromSpec(t, spec, config(false))
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,171 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// routeSegment is one `/`-separated segment of an operation's path
+type routeSegment struct {
+	// literal is the segment's text, when it doesn't contain any parameters
+	literal string
+	// param is the name of the parameter, when the segment is exactly one parameter
+	param string
+	// mixed is set when the segment contains parameters along with other text, such as `{name}.json`, which we can't reason about
+	mixed bool
+}
+
+func (s routeSegment) isParam() bool {
+	return s.param != ""
+}
+
+func splitRoute(path string) []routeSegment {
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	segments := make([]routeSegment, len(parts))
+	for i, part := range parts {
+		switch {
+		case !strings.Contains(part, "{"):
+			segments[i].literal = part
+		default:
+			match := pathParamRE.FindStringSubmatchIndex(part)
+			if match != nil && match[0] == 0 && match[1] == len(part) {
+				segments[i].param = part[match[2]:match[3]]
+			} else {
+				segments[i].mixed = true
+			}
+		}
+	}
+	return segments
+}
+
+// routeTemplate returns path with the names of its parameters removed, so paths which only differ by them are equal
+func routeTemplate(path string) string {
+	return pathParamRE.ReplaceAllString(path, "{}")
+}
+
+// normalizeRoute normalizes an operation's path as configured with `routes`, removing its trailing slash with `strip-trailing-slash`, and lowercasing its literal parts, but not the names of its parameters, with `case-sensitive: false`
+func normalizeRoute(path string, opts RouteOptions) string {
+	if opts.StripTrailingSlash && len(path) > 1 {
+		if path = strings.TrimRight(path, "/"); path == "" {
+			path = "/"
+		}
+	}
+	if !opts.caseInsensitive() {
+		return path
+	}
+
+	var sb strings.Builder
+	last := 0
+	for _, match := range pathParamRE.FindAllStringIndex(path, -1) {
+		sb.WriteString(strings.ToLower(path[last:match[0]]))
+		sb.WriteString(path[match[0]:match[1]])
+		last = match[1]
+	}
+	sb.WriteString(strings.ToLower(path[last:]))
+	return sb.String()
+}
+
+// routeSpecificity compares the paths of two operations whose segments are all either literals or parameters, and reports whether any request could match both of them, and if so, whether each matches every request that the other one does
+func routeSpecificity(a, b []routeSegment) (overlap, aCoversB, bCoversA bool) {
+	if len(a) != len(b) {
+		return false, false, false
+	}
+	aCoversB, bCoversA = true, true
+	for i := range a {
+		switch {
+		case a[i].mixed || b[i].mixed:
+			return false, false, false
+		case a[i].isParam() && b[i].isParam():
+		case a[i].isParam():
+			bCoversA = false
+		case b[i].isParam():
+			aCoversB = false
+		case a[i].literal != b[i].literal:
+			return false, false, false
+		}
+	}
+	return true, aCoversB, bCoversA
+}
+
+// ginWildcardConflict returns the names of the parameters which are at the same position in both paths, after the same prefix, but are named differently, which Gin refuses to register for the same method
+func ginWildcardConflict(a, b []routeSegment) (string, string, bool) {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		switch {
+		case a[i].isParam() && b[i].isParam():
+			if a[i].param != b[i].param {
+				return a[i].param, b[i].param, true
+			}
+		case a[i].mixed || b[i].mixed || a[i] != b[i]:
+			return "", "", false
+		}
+	}
+	return "", "", false
+}
+
+// checkRouteConflicts reports operations whose paths can't be told apart by the routers which are being generated, for the same method, so that generation fails, rather than the server panicking when registering its routes, or silently sending requests to the wrong handler
+func checkRouteConflicts(ops []OperationDefinition, generate GenerateOptions) error {
+	var failures Error
+
+	if !generate.generatesServer() {
+		return nil
+	}
+
+	routes := globalState.options.OutputOptions.Routes
+	// Paths which are the same once they're lowercased, and their trailing slashes are removed, conflict on servers which match paths that way, such as fiber-server by default
+	insensitive := RouteOptions{StripTrailingSlash: true, CaseSensitive: new(bool)}
+
+	segments := make([][]routeSegment, len(ops))
+	templates := make([]string, len(ops))
+	normalized := make([]string, len(ops))
+	folded := make([]string, len(ops))
+	for i, op := range ops {
+		segments[i] = splitRoute(op.RoutePath())
+		templates[i] = routeTemplate(op.Path)
+		normalized[i] = routeTemplate(normalizeRoute(op.Path, routes))
+		folded[i] = routeTemplate(normalizeRoute(op.Path, insensitive))
+	}
+
+	for j := range ops {
+		second := &ops[j]
+		for i := 0; i < j; i++ {
+			first := &ops[i]
+			if first.Method != second.Method || first.Path == second.Path {
+				continue
+			}
+			artifact := second.Method + " " + second.Path
+			// Where an operation is in the spec is only looked up for a conflict, as it's costly to look up for each pair of operations of a large spec
+			other := func() string {
+				return first.Method + " " + first.Path + locationSuffix(first.Spec.Position())
+			}
+
+			if templates[i] == templates[j] {
+				failures.add(artifact, second.Spec.Position(), fmt.Errorf("route conflicts with %s, as their paths differ only by the names of their parameters", other()))
+				break
+			}
+
+			if normalized[i] == normalized[j] {
+				failures.add(artifact, second.Spec.Position(), fmt.Errorf("route conflicts with %s, as their paths are the same once they're normalized with `routes`", other()))
+				break
+			}
+			if folded[i] == folded[j] {
+				logger().Warn("paths differ only by case or a trailing slash, so their routes conflict on servers which match paths case-insensitively, or regardless of a trailing slash, which can be checked by configuring `routes`", "operation", artifact, "other", first.Method+" "+first.Path)
+			}
+
+			if generate.StdHTTPServer {
+				if overlap, firstCovers, secondCovers := routeSpecificity(segments[i], segments[j]); overlap && !firstCovers && !secondCovers {
+					failures.add(artifact, second.Spec.Position(), fmt.Errorf("route is ambiguous with %s, as some requests match both paths and neither is more specific than the other, which net/http panics on when registering them for std-http-server", other()))
+					break
+				}
+			}
+
+			if generate.GinServer {
+				if firstName, secondName, ok := ginWildcardConflict(segments[i], segments[j]); ok {
+					failures.add(artifact, second.Spec.Position(), fmt.Errorf("route conflicts with %s, as gin-server requires parameters at the same position in paths with the same prefix to have the same name, but they're named %q and %q", other(), secondName, firstName))
+					break
+				}
+			}
+		}
+	}
+
+	return failures.errOrNil()
+}
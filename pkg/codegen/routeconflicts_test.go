@@ -0,0 +1,152 @@
+package codegen
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+func routeConflictsSpec(paths ...string) string {
+	spec := "openapi: 3.0.3\ninfo:\n  title: routes\n  version: 1.0.0\npaths:\n"
+	for _, path := range paths {
+		spec += "  " + path + ":\n    get:\n"
+		params := OrderedParamsFromUri(path)
+		if len(params) > 0 {
+			spec += "      parameters:\n"
+		}
+		for _, param := range params {
+			spec += "        - {name: " + param + ", in: path, required: true, schema: {type: string}}\n"
+		}
+		spec += "      responses:\n        '204':\n          description: OK\n"
+	}
+	return spec
+}
+
+func TestRouteConflicts(t *testing.T) {
+	generateRoutes := func(t *testing.T, generate GenerateOptions, paths ...string) error {
+		t.Helper()
+		swagger, err := openapi.NewLoader().LoadFromData([]byte(routeConflictsSpec(paths...)))
+		require.NoError(t, err)
+
+		generate.Models = true
+		_, err = Generate(swagger, Configuration{
+			PackageName: "api",
+			Generate:    generate,
+		})
+		return err
+	}
+
+	t.Run("paths which differ only by parameter names conflict for every router", func(t *testing.T) {
+		err := generateRoutes(t, GenerateOptions{ChiServer: true}, "/pets/{id}", "/pets/{petId}")
+		require.Error(t, err)
+
+		var genErr *Error
+		require.True(t, errors.As(err, &genErr))
+		require.Len(t, genErr.Failures, 1)
+		assert.Equal(t, "GET /pets/{petId}", genErr.Failures[0].Artifact)
+		assert.Equal(t, 14, genErr.Failures[0].Position.Line)
+		assert.Contains(t, err.Error(), "route conflicts with GET /pets/{id} (7:5), as their paths differ only by the names of their parameters")
+	})
+
+	t.Run("a static segment is preferred over a parameter", func(t *testing.T) {
+		for _, generate := range []GenerateOptions{{ChiServer: true}, {GinServer: true}, {StdHTTPServer: true}, {GorillaServer: true}, {FiberServer: true}} {
+			assert.NoError(t, generateRoutes(t, generate, "/pets/{id}", "/pets/mine"))
+		}
+	})
+
+	t.Run("paths where neither is more specific are ambiguous for std-http-server", func(t *testing.T) {
+		err := generateRoutes(t, GenerateOptions{StdHTTPServer: true}, "/pets/{id}", "/{kind}/toys")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "GET /{kind}/toys (14:5): route is ambiguous with GET /pets/{id} (7:5)")
+
+		assert.NoError(t, generateRoutes(t, GenerateOptions{ChiServer: true}, "/pets/{id}", "/{kind}/toys"))
+	})
+
+	t.Run("parameters with different names at the same position conflict for gin-server", func(t *testing.T) {
+		err := generateRoutes(t, GenerateOptions{GinServer: true}, "/pets/{id}", "/pets/{petId}/toys")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `GET /pets/{petId}/toys (14:5): route conflicts with GET /pets/{id} (7:5), as gin-server requires parameters at the same position in paths with the same prefix to have the same name, but they're named "petId" and "id"`)
+
+		assert.NoError(t, generateRoutes(t, GenerateOptions{EchoServer: true}, "/pets/{id}", "/pets/{petId}/toys"))
+	})
+
+	t.Run("routes aren't checked when no server is generated", func(t *testing.T) {
+		assert.NoError(t, generateRoutes(t, GenerateOptions{Client: true}, "/pets/{id}", "/pets/{petId}"))
+	})
+}
+
+func TestNormalizeRoute(t *testing.T) {
+	insensitive := false
+	tests := []struct {
+		path     string
+		opts     RouteOptions
+		expected string
+	}{
+		{"/Pets/{petId}/", RouteOptions{}, "/Pets/{petId}/"},
+		{"/Pets/{petId}/", RouteOptions{StripTrailingSlash: true}, "/Pets/{petId}"},
+		{"/", RouteOptions{StripTrailingSlash: true}, "/"},
+		{"/Pets/{petId}/Toys.JSON", RouteOptions{CaseSensitive: &insensitive}, "/pets/{petId}/toys.json"},
+		{"/Pets/{petId}/", RouteOptions{StripTrailingSlash: true, CaseSensitive: &insensitive}, "/pets/{petId}"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, normalizeRoute(test.path, test.opts), test.path)
+	}
+}
+
+func TestRouteNormalization(t *testing.T) {
+	insensitive := false
+	generateRoutes := func(t *testing.T, routes RouteOptions, generate GenerateOptions, paths ...string) (string, error) {
+		t.Helper()
+		// The paths are given operationIds, as those generated from paths which differ only by case or a trailing slash are the same
+		spec := "openapi: 3.0.3\ninfo:\n  title: routes\n  version: 1.0.0\npaths:\n"
+		for i, path := range paths {
+			spec += "  " + path + ":\n    get:\n      operationId: op" + strconv.Itoa(i) + "\n"
+			if params := OrderedParamsFromUri(path); len(params) > 0 {
+				spec += "      parameters:\n"
+				for _, param := range params {
+					spec += "        - {name: " + param + ", in: path, required: true, schema: {type: string}}\n"
+				}
+			}
+			spec += "      responses:\n        '204':\n          description: OK\n"
+		}
+		swagger, err := openapi.NewLoader().LoadFromData([]byte(spec))
+		require.NoError(t, err)
+
+		generate.Models = true
+		return Generate(swagger, Configuration{
+			PackageName:   "api",
+			Generate:      generate,
+			OutputOptions: OutputOptions{Routes: routes},
+		})
+	}
+
+	t.Run("paths which are the same once normalized conflict", func(t *testing.T) {
+		_, err := generateRoutes(t, RouteOptions{CaseSensitive: &insensitive}, GenerateOptions{ChiServer: true}, "/pets/{id}", "/Pets/{id}")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "GET /pets/{id} (7:5): route conflicts with GET /Pets/{id} (15:5), as their paths are the same once they're normalized with `routes`")
+
+		_, err = generateRoutes(t, RouteOptions{StripTrailingSlash: true}, GenerateOptions{EchoServer: true}, "/pets", "/pets/")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "GET /pets/ (13:5): route conflicts with GET /pets (7:5), as their paths are the same once they're normalized with `routes`")
+
+		// Without normalization, the routes are told apart
+		_, err = generateRoutes(t, RouteOptions{}, GenerateOptions{ChiServer: true}, "/pets/{id}", "/Pets/{id}", "/pets", "/pets/")
+		assert.NoError(t, err)
+	})
+
+	t.Run("routes, client requests and URL helpers are normalized the same way", func(t *testing.T) {
+		code, err := generateRoutes(t, RouteOptions{StripTrailingSlash: true, CaseSensitive: &insensitive}, GenerateOptions{ChiServer: true, Client: true, URLHelpers: true}, "/Pets/{petId}/Toys/")
+		require.NoError(t, err)
+
+		assert.Contains(t, code, `r.Get(options.BaseURL+"/pets/{petId}/toys", wrapper.Op0)`)
+		assert.Contains(t, code, `operationPath := fmt.Sprintf("/pets/%s/toys", pathParam0)`)
+		assert.Contains(t, code, `return fmt.Sprintf("/pets/%s/toys", styleURLPathParam("simple", false, "petId", petId, false))`)
+		// The spec's own path is still documented
+		assert.Contains(t, code, "// (GET /Pets/{petId}/Toys/)")
+	})
+}
@@ -0,0 +1,309 @@
+package codegen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/imports"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+// serverScaffold is the scaffold of a server implementation, when generating it rather than code
+type serverScaffold struct {
+	// generate are the generate options the scaffold implements the server of
+	generate GenerateOptions
+	code     string
+}
+
+// GenerateServerScaffold generates a `Server` type which implements the `ServerInterface` of the configured server, or the `StrictServerInterface` with `strict-server`, with each of its methods responding with `501 Not Implemented`, as a starting point for implementing the API. Unlike the rest of the code, it's meant to be edited, so is only generated once, into a file of its own in the same package as the server, rather than being regenerated
+func GenerateServerScaffold(spec *openapi.T, opts Configuration) (string, error) {
+	if !opts.Generate.generatesServer() {
+		return "", errors.New("a server scaffold needs one of the servers to be generated, such as `chi-server`")
+	}
+	scaffold := serverScaffold{generate: opts.Generate}
+	globalState.serverScaffold = &scaffold
+	defer func() {
+		globalState.serverScaffold = nil
+	}()
+
+	// Only the operations are needed, rather than any of the code
+	opts.Generate = GenerateOptions{}
+	if _, err := Generate(spec, opts); err != nil {
+		return "", err
+	}
+	return scaffold.code, nil
+}
+
+// serverName returns the name the server is referred to by in the scaffold's template
+func (oo GenerateOptions) serverName() string {
+	switch {
+	case oo.EchoServer:
+		return "echo"
+	case oo.GinServer:
+		return "gin"
+	case oo.FiberServer:
+		return "fiber"
+	case oo.IrisServer:
+		return "iris"
+	case oo.ChiServer:
+		return "chi"
+	case oo.GorillaServer:
+		return "gorilla"
+	default:
+		return "std-http"
+	}
+}
+
+// generateServerScaffold renders the scaffold of a server implementing the operations, when generating it rather than code
+func generateServerScaffold(t *template.Template, ops []OperationDefinition, externalImports []string, opts Configuration, generate GenerateOptions) (string, error) {
+	context := struct {
+		PackageName     string
+		ExternalImports []string
+		Server          string
+		Strict          bool
+		Operations      []OperationDefinition
+	}{
+		PackageName:     opts.PackageName,
+		ExternalImports: externalImports,
+		Server:          generate.serverName(),
+		Strict:          generate.Strict,
+		Operations:      ops,
+	}
+	code, err := GenerateTemplates([]string{"server-scaffold.tmpl"}, t, context)
+	if err != nil {
+		return "", err
+	}
+
+	// The scaffold imports each of the packages it could need, so always has its unused imports removed, whichever formatter is configured
+	formatter := Formatter(opts.OutputOptions.Formatter)
+	if formatter == FormatterNone {
+		formatter = FormatterGoimports
+	}
+	code, err = formatCode(code, opts.PackageName, formatter)
+	if err != nil {
+		return "", fmt.Errorf("error formatting server scaffold: %w", err)
+	}
+	return code, nil
+}
+
+// UpdateServerScaffold adds a skeleton of each of the server's methods which its implementation doesn't have yet to src, a scaffold generated by GenerateServerScaffold, such as for the operations added to the spec since, so that their signatures don't have to be copied by hand. The implementation is the type asserted to implement the server's interface, such as with `var _ ServerInterface = (*Server)(nil)`, or otherwise `Server`, and the methods it already has are found in src, along with otherFiles, the source of the other files of its package. It returns the updated source, along with the names of the methods which were added, or src as it is if there are none
+func UpdateServerScaffold(spec *openapi.T, opts Configuration, src []byte, otherFiles ...[]byte) ([]byte, []string, error) {
+	scaffold, err := GenerateServerScaffold(spec, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	scaffoldFset := token.NewFileSet()
+	scaffoldFile, err := parser.ParseFile(scaffoldFset, "", scaffold, parser.ParseComments)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing server scaffold: %w", err)
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing implementation: %w", err)
+	}
+	files := []*ast.File{file}
+	for _, other := range otherFiles {
+		f, err := parser.ParseFile(fset, "", other, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error parsing the implementation's package: %w", err)
+		}
+		files = append(files, f)
+	}
+
+	interfaceName := "ServerInterface"
+	if opts.Generate.Strict {
+		interfaceName = "StrictServerInterface"
+	}
+	implementation := implementationType(files, interfaceName)
+	declared := declaredNames(files)
+	if !declared[implementation] {
+		return nil, nil, fmt.Errorf("the implementation, %s, isn't declared in its package", implementation)
+	}
+
+	var variables, methods []string
+	var added []string
+	used := make(map[string]bool)
+	for _, decl := range scaffoldFile.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Recv == nil || declared[implementation+"."+decl.Name.Name] {
+				continue
+			}
+			method := declSource(scaffoldFset, scaffold, decl, decl.Doc)
+			methods = append(methods, strings.Replace(method, "(s *Server)", "(s *"+implementation+")", 1))
+			added = append(added, decl.Name.Name)
+			usedPackages(decl, used)
+		case *ast.GenDecl:
+			// Any of the scaffold's variables which the package doesn't have, such as ErrNotImplemented with `strict-server`, are added along with the methods which use them
+			if decl.Tok != token.VAR || len(decl.Specs) != 1 {
+				continue
+			}
+			spec := decl.Specs[0].(*ast.ValueSpec)
+			if len(spec.Names) != 1 || spec.Names[0].Name == "_" || declared[spec.Names[0].Name] {
+				continue
+			}
+			variables = append(variables, declSource(scaffoldFset, scaffold, decl, decl.Doc))
+			usedPackages(decl, used)
+		}
+	}
+	if len(added) == 0 {
+		return src, nil, nil
+	}
+
+	updated := string(bytes.TrimRight(src, "\n")) + "\n\n" + strings.Join(append(variables, methods...), "\n\n") + "\n"
+	file, err = parser.ParseFile(fset, "", updated, parser.ParseComments)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing updated implementation: %w", err)
+	}
+	for _, imp := range scaffoldFile.Imports {
+		importPath, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error reading scaffold's imports: %w", err)
+		}
+		name := ""
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		if used[importName(name, importPath)] {
+			astutil.AddNamedImport(fset, file, name, importPath)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, nil, fmt.Errorf("error formatting updated implementation: %w", err)
+	}
+	// The added imports are grouped as goimports does, without otherwise changing the imports
+	formatted, err := imports.Process("", buf.Bytes(), &imports.Options{FormatOnly: true, Comments: true, TabIndent: true, TabWidth: 8})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error formatting updated implementation: %w", err)
+	}
+	return formatted, added, nil
+}
+
+// implementationType returns the name of the type which the files assert implements the interface, such as with `var _ ServerInterface = (*Server)(nil)`, or otherwise `Server`, the type GenerateServerScaffold generates
+func implementationType(files []*ast.File, interfaceName string) string {
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			decl, ok := decl.(*ast.GenDecl)
+			if !ok || decl.Tok != token.VAR {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				spec := spec.(*ast.ValueSpec)
+				if len(spec.Names) != 1 || spec.Names[0].Name != "_" || len(spec.Values) != 1 {
+					continue
+				}
+				if ident, ok := spec.Type.(*ast.Ident); !ok || ident.Name != interfaceName {
+					continue
+				}
+				if name := typeName(spec.Values[0]); name != "" {
+					return name
+				}
+			}
+		}
+	}
+	return "Server"
+}
+
+// typeName returns the name of the type of a value asserted to implement an interface, such as `(*Server)(nil)`, `&Server{}` or `Server{}`
+func typeName(value ast.Expr) string {
+	switch value := value.(type) {
+	case *ast.CallExpr:
+		if paren, ok := value.Fun.(*ast.ParenExpr); ok {
+			if star, ok := paren.X.(*ast.StarExpr); ok {
+				if ident, ok := star.X.(*ast.Ident); ok {
+					return ident.Name
+				}
+			}
+		}
+	case *ast.UnaryExpr:
+		return typeName(value.X)
+	case *ast.CompositeLit:
+		if ident, ok := value.Type.(*ast.Ident); ok {
+			return ident.Name
+		}
+	}
+	return ""
+}
+
+// declaredNames returns the names declared at the top level of the files, along with each of their methods, as `<Type>.<Method>`
+func declaredNames(files []*ast.File) map[string]bool {
+	declared := make(map[string]bool)
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if decl.Recv == nil || len(decl.Recv.List) == 0 {
+					declared[decl.Name.Name] = true
+					continue
+				}
+				recv := decl.Recv.List[0].Type
+				if star, ok := recv.(*ast.StarExpr); ok {
+					recv = star.X
+				}
+				if ident, ok := recv.(*ast.Ident); ok {
+					declared[ident.Name+"."+decl.Name.Name] = true
+				}
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						declared[spec.Name.Name] = true
+					case *ast.ValueSpec:
+						for _, name := range spec.Names {
+							declared[name.Name] = true
+						}
+					}
+				}
+			}
+		}
+	}
+	return declared
+}
+
+// declSource returns the source of a declaration, along with its doc comment
+func declSource(fset *token.FileSet, src string, decl ast.Decl, doc *ast.CommentGroup) string {
+	start := decl.Pos()
+	if doc != nil {
+		start = doc.Pos()
+	}
+	return src[fset.Position(start).Offset:fset.Position(decl.End()).Offset]
+}
+
+// usedPackages adds the names of the packages which the declaration refers to, such as `http` in `http.StatusNotImplemented`, to used
+func usedPackages(decl ast.Decl, used map[string]bool) {
+	ast.Inspect(decl, func(n ast.Node) bool {
+		if selector, ok := n.(*ast.SelectorExpr); ok {
+			if ident, ok := selector.X.(*ast.Ident); ok {
+				used[ident.Name] = true
+			}
+		}
+		return true
+	})
+}
+
+// majorVersionSuffix matches the major version at the end of an import path, such as `/v4` in `github.com/labstack/echo/v4`
+var majorVersionSuffix = regexp.MustCompile(`/v[0-9]+$`)
+
+// importName returns the name a package is referred to by, which is its import's name if it has one, or otherwise the last element of its path, without any major version suffix
+func importName(name, importPath string) string {
+	if name != "" {
+		return name
+	}
+	return path.Base(majorVersionSuffix.ReplaceAllString(importPath, ""))
+}
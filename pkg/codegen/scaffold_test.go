@@ -0,0 +1,154 @@
+package codegen
+
+import (
+	"go/format"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+const scaffoldSpec = `openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    get:
+      operationId: getPet
+      summary: Gets a pet
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+            format: uuid
+        - name: limit
+          in: query
+          schema:
+            type: integer
+      responses:
+        '200':
+          description: OK
+`
+
+func TestGenerateServerScaffold(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(scaffoldSpec))
+	require.NoError(t, err)
+
+	t.Run("chi", func(t *testing.T) {
+		scaffold, err := GenerateServerScaffold(swagger, Configuration{
+			PackageName: "api",
+			Generate:    GenerateOptions{ChiServer: true, ServerScaffold: true},
+			// The scaffold's unused imports are removed regardless
+			OutputOptions: OutputOptions{Formatter: string(FormatterGofmt)},
+		})
+		require.NoError(t, err)
+
+		_, err = format.Source([]byte(scaffold))
+		require.NoError(t, err)
+		assert.NotContains(t, scaffold, "DO NOT EDIT")
+		assert.Contains(t, scaffold, "package api\n")
+		assert.Contains(t, scaffold, "type Server struct{}")
+		assert.Contains(t, scaffold, "var _ ServerInterface = (*Server)(nil)")
+		assert.Contains(t, scaffold, "// Gets a pet\n// (GET /pets/{id})\nfunc (s *Server) GetPet(w http.ResponseWriter, r *http.Request, id openapi_types.UUID, params GetPetParams) {\n\tw.WriteHeader(http.StatusNotImplemented)\n}")
+		assert.NotContains(t, scaffold, "labstack/echo")
+	})
+
+	t.Run("echo", func(t *testing.T) {
+		scaffold, err := GenerateServerScaffold(swagger, Configuration{
+			PackageName: "api",
+			Generate:    GenerateOptions{EchoServer: true, ServerScaffold: true},
+		})
+		require.NoError(t, err)
+		assert.Contains(t, scaffold, "func (s *Server) GetPet(ctx echo.Context, id openapi_types.UUID, params GetPetParams) error {\n\treturn ctx.NoContent(http.StatusNotImplemented)\n}")
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		scaffold, err := GenerateServerScaffold(swagger, Configuration{
+			PackageName: "api",
+			Generate:    GenerateOptions{StdHTTPServer: true, Strict: true, ServerScaffold: true},
+		})
+		require.NoError(t, err)
+		assert.Contains(t, scaffold, "var _ StrictServerInterface = (*Server)(nil)")
+		assert.Contains(t, scaffold, "func (s *Server) GetPet(ctx context.Context, request GetPetRequestObject) (GetPetResponseObject, error) {\n\treturn nil, ErrNotImplemented\n}")
+	})
+
+	t.Run("without a server", func(t *testing.T) {
+		_, err := GenerateServerScaffold(swagger, Configuration{
+			PackageName: "api",
+			Generate:    GenerateOptions{Models: true, ServerScaffold: true},
+		})
+		require.Error(t, err)
+		assert.Contains(t, GenerateOptions{ServerScaffold: true}.Validate(), "server-scaffold")
+	})
+}
+
+func TestUpdateServerScaffold(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(scaffoldSpec + `  /pets:
+    post:
+      operationId: addPet
+      responses:
+        '201':
+          description: Created
+`))
+	require.NoError(t, err)
+
+	implementation := `package api
+
+import "net/http"
+
+// PetServer implements the API
+type PetServer struct{}
+
+var _ ServerInterface = (*PetServer)(nil)
+
+// GetPet returns the pet
+func (p *PetServer) GetPet(ctx echo.Context, id openapi_types.UUID, params GetPetParams) error {
+	return ctx.NoContent(http.StatusOK)
+}
+`
+	opts := Configuration{
+		PackageName: "api",
+		Generate:    GenerateOptions{EchoServer: true, ServerScaffold: true},
+	}
+
+	updated, added, err := UpdateServerScaffold(swagger, opts, []byte(implementation))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"AddPet"}, added)
+	assert.Contains(t, string(updated), "// GetPet returns the pet\nfunc (p *PetServer) GetPet(", "the existing implementation is kept")
+	assert.Contains(t, string(updated), "// (POST /pets)\nfunc (s *PetServer) AddPet(ctx echo.Context) error {\n\treturn ctx.NoContent(http.StatusNotImplemented)\n}\n")
+	assert.Contains(t, string(updated), "import (\n\t\"net/http\"\n\n\t\"github.com/labstack/echo/v4\"\n)", "the imports the added methods use are added")
+	_, err = format.Source(updated)
+	require.NoError(t, err)
+
+	t.Run("methods implemented in another file", func(t *testing.T) {
+		other := "package api\n\nfunc (s *PetServer) AddPet(ctx echo.Context) error {\n\treturn nil\n}\n"
+		unchanged, added, err := UpdateServerScaffold(swagger, opts, []byte(implementation), []byte(other))
+		require.NoError(t, err)
+		assert.Empty(t, added)
+		assert.Equal(t, implementation, string(unchanged))
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		strict := "package api\n\ntype Server struct{}\n"
+		opts := Configuration{
+			PackageName: "api",
+			Generate:    GenerateOptions{ChiServer: true, Strict: true, ServerScaffold: true},
+		}
+		updated, added, err := UpdateServerScaffold(swagger, opts, []byte(strict))
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"GetPet", "AddPet"}, added)
+		assert.Contains(t, string(updated), "var ErrNotImplemented = errors.New(\"not implemented\")", "the variables the methods use are added too")
+		assert.Contains(t, string(updated), "func (s *Server) AddPet(ctx context.Context, request AddPetRequestObject) (AddPetResponseObject, error) {")
+		assert.Contains(t, string(updated), "\"context\"")
+	})
+
+	t.Run("without an implementation", func(t *testing.T) {
+		_, _, err := UpdateServerScaffold(swagger, opts, []byte("package api\n"))
+		require.ErrorContains(t, err, "isn't declared")
+	})
+}
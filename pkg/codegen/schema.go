@@ -3,8 +3,14 @@ package codegen
 import (
 	"errors"
 	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
+	"gopkg.in/yaml.v3"
+
 	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
 )
 
@@ -16,11 +22,13 @@ type Schema struct {
 
 	ArrayType *Schema // The schema of array element
 
-	EnumValues map[string]string // Enum values
+	EnumValues       map[string]string // Enum values
+	EnumDescriptions map[string]string // The descriptions of the enum values, from x-enum-descriptions, by value
 
 	Properties               []Property       // For an object, the fields with names
 	HasAdditionalProperties  bool             // Whether we support additional properties
 	AdditionalPropertiesType *Schema          // And if we do, their type
+	PropertyNames            *PropertyNames   // And the constraints on their keys, from `propertyNames`
 	AdditionalTypes          []TypeDefinition // We may need to generate auxiliary helper types, stored here
 	EmbeddedTypes            []string         // For allOf, the types to embed as anonymous fields
 
@@ -40,6 +48,9 @@ type Schema struct {
 
 	// The original OpenAPIv3 Schema.
 	OAPISchema *openapi.Schema
+
+	// namedMap is set for a `map[string]T` which should be given a named type wherever it's used, rather than being used inline
+	namedMap bool
 }
 
 func (s Schema) IsRef() bool {
@@ -80,6 +91,39 @@ func (s Schema) GetAdditionalTypeDefs() []TypeDefinition {
 	return s.AdditionalTypes
 }
 
+// AdditionalPropertiesKeyType returns the Go type of the keys of the additional
+// properties, which is a `string` unless they're constrained to an enum by
+// `propertyNames`.
+func (s Schema) AdditionalPropertiesKeyType() string {
+	if s.PropertyNames != nil && s.PropertyNames.KeyType != "" {
+		return s.PropertyNames.KeyType
+	}
+	return "string"
+}
+
+// PropertyNames describes the constraints which a `propertyNames` schema
+// places on the keys of an object's additional properties.
+type PropertyNames struct {
+	KeyType string   // The Go type of the keys, which is a defined type when they're an enum
+	Pattern string   // The pattern which every key must match, if any
+	Enum    []string // The values which every key must be one of, if any
+}
+
+// PatternVar returns the name of the package-level variable which holds the
+// compiled Pattern for the given type.
+func (p PropertyNames) PatternVar(typeName string) string {
+	return "propertyNamesPattern" + typeName
+}
+
+// EnumCases returns the Enum as quoted Go strings, for use in a `case` clause.
+func (p PropertyNames) EnumCases() string {
+	cases := make([]string, len(p.Enum))
+	for i, v := range p.Enum {
+		cases[i] = strconv.Quote(v)
+	}
+	return strings.Join(cases, ", ")
+}
+
 type Property struct {
 	Description   string
 	JsonFieldName string
@@ -91,9 +135,33 @@ type Property struct {
 	NeedsFormTag  bool
 	Extensions    map[string]interface{}
 	Deprecated    bool
+	// Recursive is set when the property refers back to a type which contains
+	// it, so that it's always a pointer, as Go doesn't allow a type to contain
+	// itself by value.
+	Recursive bool
+	// Embedded is set when the property references another schema whose type
+	// is embedded anonymously, with x-go-embed, so that the field is named
+	// after the type.
+	Embedded bool
+	// JSONString is set when the property's number or boolean is encoded as
+	// a JSON string, with x-go-json-string, so that its json tag has the
+	// `,string` option.
+	JSONString bool
+	// AllowEmpty is set when the property is the field of a query parameter
+	// with `allowEmptyValue: true`, so that it's a nullable.Nullable, which is
+	// null when the parameter is present with an empty value.
+	AllowEmpty bool
 }
 
 func (p Property) GoFieldName() string {
+	if p.Embedded {
+		// An embedded field is named after its type, without any package
+		typeName := p.Schema.RefType
+		if i := strings.LastIndex(typeName, "."); i >= 0 {
+			typeName = typeName[i+1:]
+		}
+		return typeName
+	}
 	goFieldName := p.JsonFieldName
 	if extension, ok := p.Extensions[extGoName]; ok {
 		if extGoFieldName, err := extParseGoFieldName(extension); err == nil {
@@ -116,9 +184,12 @@ func (p Property) GoFieldName() string {
 
 func (p Property) GoTypeDef() string {
 	typeDef := p.Schema.TypeDecl()
-	if globalState.options.OutputOptions.NullableType && p.Nullable {
+	if p.AllowEmpty || (globalState.options.OutputOptions.NullableType && p.Nullable) {
 		return "nullable.Nullable[" + typeDef + "]"
 	}
+	if p.Recursive {
+		return "*" + typeDef
+	}
 	if !p.Schema.SkipOptionalPointer &&
 		(!p.Required || p.Nullable ||
 			(p.ReadOnly && (!p.Required || !globalState.options.Compatibility.DisableRequiredReadOnlyAsPointer)) ||
@@ -132,7 +203,7 @@ func (p Property) GoTypeDef() string {
 // HasOptionalPointer indicates whether the generated property has an optional pointer associated with it.
 // This takes into account the `x-go-type-skip-optional-pointer` extension, allowing a parameter definition to control whether the pointer should be skipped.
 func (p Property) HasOptionalPointer() bool {
-	return p.Required == false && p.Schema.SkipOptionalPointer == false //nolint:staticcheck
+	return p.Required == false && (p.Schema.SkipOptionalPointer == false || p.Recursive) //nolint:staticcheck
 }
 
 // EnumDefinition holds type information for enum
@@ -152,6 +223,19 @@ type EnumDefinition struct {
 	PrefixTypeName bool
 }
 
+// Literal returns the Go literal for one of the enum's values
+func (e *EnumDefinition) Literal(value string) string {
+	if e.ValueWrapper == `"` {
+		return strconv.Quote(value)
+	}
+	return e.ValueWrapper + value + e.ValueWrapper
+}
+
+// ValueDescription returns the description of one of the enum's values, from x-enum-descriptions, if it has one
+func (e *EnumDefinition) ValueDescription(value string) string {
+	return e.Schema.EnumDescriptions[value]
+}
+
 // GetValues generates enum names in a way to minimize global conflicts
 func (e *EnumDefinition) GetValues() map[string]string {
 	// in case there are no conflicts, it's safe to use the values as-is
@@ -268,6 +352,7 @@ func GenerateGoSchema(sref *openapi.SchemaRef, path []string) (Schema, error) {
 			refType, err := RefPathToGoType(sref.Ref)
 			if err != nil {
 				// If we can't resolve the reference, use interface{} as fallback
+				reportDowngrade(path, openapi.Position{}, "the reference %s couldn't be resolved, so is generated as interface{}", sref.Ref)
 				return Schema{
 					GoType:     "interface{}",
 					RefType:    "interface{}",
@@ -324,14 +409,40 @@ func GenerateGoSchema(sref *openapi.SchemaRef, path []string) (Schema, error) {
 			}
 		}
 
-		return Schema{
+		refSchema := Schema{
 			GoType:              refType,
 			RefType:             refType,
 			Description:         schema.Description,
 			DefineViaAlias:      !isDefiningComponentSchema, // Only prevent aliases when defining the schema itself
 			OAPISchema:          schema,
-			SkipOptionalPointer: skipOptionalPointer,
-		}, nil
+			SkipOptionalPointer: skipOptionalPointer || isConstNull(schema), // A reference to a `const: null` is already a pointer
+		}
+		if siblings := refSiblings(sref); siblings != nil {
+			if siblings.Description != "" {
+				refSchema.Description = siblings.Description
+			}
+			if len(siblings.Constraints) != 0 && len(path) > 1 {
+				// The constraints alongside the reference are documented by a
+				// type of their own, which is an alias of the referenced type.
+				typeName, err := inlineTypeName(path, PathToTypeName(path), false)
+				if err != nil {
+					return Schema{}, err
+				}
+				typeDef := TypeDefinition{
+					TypeName: typeName,
+					JsonName: strings.Join(path, "."),
+					Schema: Schema{
+						GoType:         refType,
+						RefType:        refType,
+						Description:    refSiblingsDescription(refSchema.Description, refType, siblings.Constraints),
+						DefineViaAlias: true,
+					},
+				}
+				refSchema.RefType = typeName
+				refSchema.AdditionalTypes = append(refSchema.AdditionalTypes, typeDef)
+			}
+		}
+		return refSchema, nil
 	}
 
 	outSchema := Schema{
@@ -353,9 +464,16 @@ func GenerateGoSchema(sref *openapi.SchemaRef, path []string) (Schema, error) {
 		// Check if this is a simple case that can use embedded structs
 		hasOnlyRefsAndSimpleInline := true
 		refCount := 0
+		// A member marked with x-go-embed forces embedding, wherever the allOf is
+		forceEmbed := false
 		for _, schemaRef := range allOfRefs {
 			if schemaRef.Ref != "" {
 				refCount++
+				embed, err := isEmbedded(schemaRef)
+				if err != nil {
+					return Schema{}, fmt.Errorf("error in allOf member %s: %w", schemaRef.Ref, err)
+				}
+				forceEmbed = forceEmbed || embed
 			} else {
 				// Check if the inline schema is simple (only properties, no complex structures)
 				if schemaRef.Value != nil &&
@@ -369,7 +487,10 @@ func GenerateGoSchema(sref *openapi.SchemaRef, path []string) (Schema, error) {
 		// Use embedded struct approach for simple cases with at least one reference
 		// Only apply to top-level schemas, not nested properties
 		isTopLevelSchema := len(path) <= 1
-		if hasOnlyRefsAndSimpleInline && refCount > 0 && isTopLevelSchema {
+		if forceEmbed && !hasOnlyRefsAndSimpleInline {
+			return Schema{}, fmt.Errorf("%q can't be used in an allOf with members which are themselves an allOf, anyOf or oneOf", extGoEmbed)
+		}
+		if hasOnlyRefsAndSimpleInline && refCount > 0 && (isTopLevelSchema || forceEmbed) {
 			resultSchema := Schema{
 				GoType:      "struct",
 				Description: schema.Description,
@@ -450,6 +571,15 @@ func GenerateGoSchema(sref *openapi.SchemaRef, path []string) (Schema, error) {
 				// expressed as a map.
 				outType = "map[string]interface{}"
 				setSkipOptionalPointerForContainerType(&outSchema)
+			} else if isConstNull(schema) {
+				// A schema which only allows `null` can't hold any other value,
+				// so is a pointer which is always nil, and is validated as such.
+				outType = "*struct{}"
+				outSchema.SkipOptionalPointer = true
+			} else if constType := constGoType(schema.Const); constType != "" {
+				// A schema without a type, which only allows its `const`, has the
+				// type of the value.
+				outType = constType
 			} else { // t == ""
 				// If we don't even have the object designator, we're a completely
 				// generic type.
@@ -481,12 +611,15 @@ func GenerateGoSchema(sref *openapi.SchemaRef, path []string) (Schema, error) {
 				if err != nil {
 					return Schema{}, fmt.Errorf("error generating type for additional properties: %w", err)
 				}
-				if additionalSchema.HasAdditionalProperties || len(additionalSchema.UnionElements) != 0 {
+				if additionalSchema.HasAdditionalProperties || len(additionalSchema.UnionElements) != 0 || additionalSchema.namedMap {
 					// If we have fields present which have additional properties or union values,
 					// but are not a pre-defined type, we need to define a type
 					// for them, which will be based on the field names we followed
 					// to get to the type.
-					typeName := PathToTypeName(append(path, "AdditionalProperties"))
+					typeName, err := inlineTypeName(append(path, "AdditionalProperties"), PathToTypeName(append(path, "AdditionalProperties")), false)
+					if err != nil {
+						return Schema{}, err
+					}
 
 					typeDef := TypeDefinition{
 						TypeName: typeName,
@@ -500,20 +633,49 @@ func GenerateGoSchema(sref *openapi.SchemaRef, path []string) (Schema, error) {
 				outSchema.AdditionalTypes = append(outSchema.AdditionalTypes, additionalSchema.AdditionalTypes...)
 			}
 
+			// Free-form additional properties may be given a concrete type from the schema's examples
+			if outSchema.HasAdditionalProperties && globalState.options.OutputOptions.InferMapValueTypesFromExamples &&
+				outSchema.AdditionalPropertiesType.GoType == "interface{}" && outSchema.AdditionalPropertiesType.RefType == "" {
+				if goType, ok := inferAdditionalPropertiesType(schema, path); ok {
+					outSchema.AdditionalPropertiesType = &Schema{
+						GoType: goType,
+					}
+				}
+			}
+
+			if outSchema.HasAdditionalProperties {
+				propertyNames, keyTypes, err := generatePropertyNames(schema.PropertyNames, path)
+				if err != nil {
+					return Schema{}, fmt.Errorf("error generating type for propertyNames: %w", err)
+				}
+				outSchema.PropertyNames = propertyNames
+				outSchema.AdditionalTypes = append(outSchema.AdditionalTypes, keyTypes...)
+			}
+
+			representation, err := additionalPropertiesRepresentation(schema)
+			if err != nil {
+				return Schema{}, err
+			}
+
 			// If the schema has no properties, and only additional properties, we will
 			// early-out here and generate a map[string]<schema> instead of an object
-			// that contains this map. We skip over anyOf/oneOf here because they can
-			// introduce properties. allOf was handled above.
-			if !globalState.options.Compatibility.DisableFlattenAdditionalProperties &&
-				len(schema.PropertiesToMap()) == 0 && schema.AnyOf == nil && schema.OneOf == nil {
+			// that contains this map, unless configured otherwise. We skip over anyOf/oneOf
+			// here because they can introduce properties. allOf was handled above.
+			if outSchema.HasAdditionalProperties && representation != AdditionalPropertiesRepresentationStruct &&
+				schema.AnyOf == nil && schema.OneOf == nil {
+				if len(schema.PropertiesToMap()) != 0 {
+					logger().Debug("representing object with properties as a map", "path", strings.Join(path, "."), "properties", SortedSchemaKeys(schema.PropertiesToMap()))
+				}
 				// We have a dictionary here. Returns the goType to be just a map from
 				// string to the property type. HasAdditionalProperties=false means
 				// that we won't generate custom json.Marshaler and json.Unmarshaler functions,
 				// since we don't need them for a simple map.
 				outSchema.HasAdditionalProperties = false
-				outSchema.GoType = fmt.Sprintf("map[string]%s", additionalPropertiesType(outSchema))
+				outSchema.GoType = fmt.Sprintf("map[%s]%s", outSchema.AdditionalPropertiesKeyType(), additionalPropertiesType(outSchema))
+				// The keys constrained by `propertyNames` are validated by a named type's UnmarshalJSON
+				outSchema.namedMap = representation == AdditionalPropertiesRepresentationNamedMap || outSchema.PropertyNames != nil
 				setSkipOptionalPointerForContainerType(&outSchema)
-				return outSchema, nil
+				return namedFromTitle(schema, path, outSchema)
 			}
 
 			// We've got an object with some properties.
@@ -521,19 +683,30 @@ func GenerateGoSchema(sref *openapi.SchemaRef, path []string) (Schema, error) {
 				p := schema.PropertiesToMap()[pName]
 				propertyPath := append(path, pName)
 
+				skip, err := propertyIsSkipped(p)
+				if err != nil {
+					return Schema{}, fmt.Errorf("property '%s'%s: %w", pName, locationSuffix(p.Position()), err)
+				}
+				if skip {
+					continue
+				}
+
 				pSchema, err := GenerateGoSchema(p, propertyPath)
 				if err != nil {
-					return Schema{}, fmt.Errorf("error generating Go schema for property '%s': %w", pName, err)
+					return Schema{}, fmt.Errorf("error generating Go schema for property '%s'%s: %w", pName, locationSuffix(p.Position()), err)
 				}
 
 				required := StringInArray(pName, schema.Required)
 
-				if (pSchema.HasAdditionalProperties || len(pSchema.UnionElements) != 0) && pSchema.RefType == "" {
+				if (pSchema.HasAdditionalProperties || len(pSchema.UnionElements) != 0 || pSchema.namedMap) && pSchema.RefType == "" {
 					// If we have fields present which have additional properties or union values,
 					// but are not a pre-defined type, we need to define a type
 					// for them, which will be based on the field names we followed
 					// to get to the type.
-					typeName := PathToTypeName(propertyPath)
+					typeName, err := inlineTypeName(propertyPath, PathToTypeName(propertyPath), false)
+					if err != nil {
+						return Schema{}, err
+					}
 
 					typeDef := TypeDefinition{
 						TypeName: typeName,
@@ -548,17 +721,45 @@ func GenerateGoSchema(sref *openapi.SchemaRef, path []string) (Schema, error) {
 				if p.Value != nil {
 					description = p.Value.Description
 				}
+				nullable := p.Value.IsNullable()
+				deprecated := p.Value.IsDeprecated()
+				if siblings := refSiblings(p); siblings != nil {
+					// The keywords alongside a reference override those of the referenced schema
+					if siblings.Description != "" {
+						description = siblings.Description
+					}
+					nullable = nullable || siblings.Nullable
+					deprecated = deprecated || siblings.Deprecated
+				}
 				prop := Property{
 					JsonFieldName: pName,
 					Schema:        pSchema,
 					Required:      required,
 					Description:   description,
-					Nullable:      p.Value.Nullable,
+					Nullable:      nullable,
 					ReadOnly:      p.Value.IsReadOnly(),
 					WriteOnly:     p.Value.IsWriteOnly(),
 					Extensions:    p.Value.Extensions,
-					Deprecated:    p.Value.IsDeprecated(),
+					Deprecated:    deprecated,
+				}
+
+				embedded, err := isEmbedded(p)
+				if err != nil {
+					return Schema{}, fmt.Errorf("property '%s'%s: %w", pName, locationSuffix(p.Position()), err)
 				}
+				if embedded {
+					if globalState.options.OutputOptions.NullableType && nullable {
+						return Schema{}, fmt.Errorf("property '%s'%s: a nullable property can't be embedded with %q, as its type is nullable.Nullable", pName, locationSuffix(p.Position()), extGoEmbed)
+					}
+					prop.Embedded = true
+				}
+
+				jsonString, err := propertyJSONString(p, &prop.Schema)
+				if err != nil {
+					return Schema{}, fmt.Errorf("property '%s'%s: %w", pName, locationSuffix(p.Position()), err)
+				}
+				prop.JSONString = jsonString
+				reportFieldRename(path, prop)
 				outSchema.Properties = append(outSchema.Properties, prop)
 				if len(pSchema.AdditionalTypes) > 0 {
 					outSchema.AdditionalTypes = append(outSchema.AdditionalTypes, pSchema.AdditionalTypes...)
@@ -587,6 +788,10 @@ func GenerateGoSchema(sref *openapi.SchemaRef, path []string) (Schema, error) {
 			if err != nil {
 				return outSchema, fmt.Errorf("invalid value for %q: %w", extGoTypeName, err)
 			}
+			typeName, err = inlineTypeName(path, typeName, true)
+			if err != nil {
+				return outSchema, err
+			}
 
 			newTypeDef := TypeDefinition{
 				TypeName: typeName,
@@ -598,6 +803,8 @@ func GenerateGoSchema(sref *openapi.SchemaRef, path []string) (Schema, error) {
 				DefineViaAlias:  true,
 				AdditionalTypes: append(outSchema.AdditionalTypes, newTypeDef),
 			}
+		} else if len(outSchema.Properties) != 0 || outSchema.HasAdditionalProperties || len(outSchema.UnionElements) != 0 {
+			return namedFromTitle(schema, path, outSchema)
 		}
 
 		return outSchema, nil
@@ -611,11 +818,10 @@ func GenerateGoSchema(sref *openapi.SchemaRef, path []string) (Schema, error) {
 		if err != nil {
 			return Schema{}, fmt.Errorf("error resolving primitive type: %w", err)
 		}
+		// The values are kept as they're written in the spec, rather than
+		// being decoded, so that `1.0` isn't mistaken for `1`.
 		enumItems := schema.Enum()
-		enumValues := make([]string, len(enumItems))
-		for i, enumValue := range enumItems {
-			enumValues[i] = fmt.Sprintf("%v", enumValue)
-		}
+		enumValues := schema.EnumRaw()
 
 		enumNames := enumValues
 		for _, key := range []string{extEnumVarNames, extEnumNames} {
@@ -626,8 +832,70 @@ func GenerateGoSchema(sref *openapi.SchemaRef, path []string) (Schema, error) {
 				}
 			}
 		}
+		for _, key := range []string{extEnumDescriptions, extEnumDescriptionsCamelCase} {
+			if extension, ok := schema.Extensions[key]; ok {
+				descriptions, err := extParseEnumVarNames(extension)
+				if err != nil {
+					return Schema{}, fmt.Errorf("invalid value for %q: %w", key, err)
+				}
+				outSchema.EnumDescriptions = make(map[string]string, len(descriptions))
+				for i, description := range descriptions {
+					if i < len(enumValues) && description != "" {
+						outSchema.EnumDescriptions[enumValues[i]] = description
+					}
+				}
+				break
+			}
+		}
+
+		// The `enum-value-names` option names some of the values, such as for a
+		// spec which can't be annotated with x-enum-varnames.
+		if valueNames, ok := globalState.options.OutputOptions.EnumValueNames[strings.Join(path, ".")]; ok {
+			names := make([]string, len(enumValues))
+			for i := range enumValues {
+				names[i] = enumValues[i]
+				if i < len(enumNames) {
+					names[i] = enumNames[i]
+				}
+			}
+			for _, value := range SortedMapKeys(valueNames) {
+				i := slices.Index(enumValues, value)
+				if i < 0 {
+					return Schema{}, fmt.Errorf("enum-value-names: %q isn't one of the values of the enum %s", value, strings.Join(path, "."))
+				}
+				names[i] = valueNames[value]
+			}
+			enumNames = names
+		}
+
+		// A `null` in the enum only allows the value to be null, and a value
+		// which can't be expressed as a constant of the Go type, such as a
+		// string in an enum of numbers, can't be given a constant.
+		var constNames, constValues []string
+		for i, enumValue := range enumItems {
+			if enumValue == nil {
+				continue
+			}
+			literal, ok := enumLiteral(outSchema.GoType, enumValues[i])
+			if !ok {
+				logger().Debug("skipping enum value which can't be a constant", "path", strings.Join(path, "."), "type", outSchema.GoType, "value", enumValues[i])
+				continue
+			}
+			name := enumValues[i]
+			if i < len(enumNames) {
+				name = enumNames[i]
+			}
+			if description, ok := outSchema.EnumDescriptions[enumValues[i]]; ok && literal != enumValues[i] {
+				delete(outSchema.EnumDescriptions, enumValues[i])
+				outSchema.EnumDescriptions[literal] = description
+			}
+			constNames = append(constNames, name)
+			constValues = append(constValues, literal)
+		}
+		enumNames, enumValues = constNames, constValues
 
 		sanitizedValues := SanitizeEnumNames(enumNames, enumValues)
+		reportEnumValueRenames(path, enumNames, enumValues, sanitizedValues)
 		outSchema.EnumValues = make(map[string]string, len(sanitizedValues))
 
 		for k, v := range sanitizedValues {
@@ -652,8 +920,14 @@ func GenerateGoSchema(sref *openapi.SchemaRef, path []string) (Schema, error) {
 				if err != nil {
 					return outSchema, fmt.Errorf("invalid value for %q: %w", extGoTypeName, err)
 				}
+				typeName, err = inlineTypeName(path, typeName, true)
+			} else if titleName, ok := titleTypeName(schema, path, ""); ok {
+				typeName, err = inlineTypeName(path, titleName, false)
 			} else {
-				typeName = SchemaNameToTypeName(PathToTypeName(path))
+				typeName, err = inlineTypeName(path, SchemaNameToTypeName(PathToTypeName(path)), false)
+			}
+			if err != nil {
+				return outSchema, err
 			}
 
 			typeDef := TypeDefinition{
@@ -673,41 +947,65 @@ func GenerateGoSchema(sref *openapi.SchemaRef, path []string) (Schema, error) {
 	return outSchema, nil
 }
 
+// namedFromTitle defines a type for the inline object or map outSchema, which is generated for the schema at path, named from the schema's `title`, with `use-schema-titles`, and returns a schema which refers to it. Otherwise, outSchema is returned as-is
+func namedFromTitle(schema *openapi.Schema, path []string, outSchema Schema) (Schema, error) {
+	titleName, ok := titleTypeName(schema, path, "")
+	if !ok {
+		return outSchema, nil
+	}
+	typeName, err := inlineTypeName(path, titleName, false)
+	if err != nil {
+		return outSchema, err
+	}
+
+	newTypeDef := TypeDefinition{
+		TypeName: typeName,
+		JsonName: strings.Join(path, "."),
+		Schema:   outSchema,
+	}
+	return Schema{
+		Description:         outSchema.Description,
+		GoType:              typeName,
+		DefineViaAlias:      true,
+		SkipOptionalPointer: outSchema.SkipOptionalPointer,
+		OAPISchema:          schema,
+		AdditionalTypes:     append(outSchema.AdditionalTypes, newTypeDef),
+	}, nil
+}
+
 // oapiSchemaToGoType converts an OpenApi schema into a Go type definition for
 // all non-object types.
 func oapiSchemaToGoType(schema *openapi.Schema, path []string, outSchema *Schema) error {
 	f := schema.Format
 	t := schema.Type
 
-	// Handle OpenAPI 3.1 union types (type arrays)
+	// Handle OpenAPI 3.1 union types (type arrays). Whether the schema is
+	// nullable, in either form, is already known by the adapter, so only the
+	// other types are considered here.
 	typeSlice := schema.TypeSlice()
 	if len(typeSlice) >= 1 {
-		// Check if this is a nullable type pattern (e.g., ["string", "null"])
-		hasNull := false
-		nonNullTypes := []string{}
-		for _, t := range typeSlice {
-			if t == "null" {
-				hasNull = true
-			} else {
-				nonNullTypes = append(nonNullTypes, t)
-			}
+		hasNull := schema.IsNullable()
+		nonNullTypes := schema.NonNullTypes()
+
+		// A `const: null` can only be null, as for a schema without a type
+		if hasNull && len(nonNullTypes) == 0 && isConstNull(schema) {
+			outSchema.GoType = "*struct{}"
+			outSchema.SkipOptionalPointer = true
+			outSchema.DefineViaAlias = true
+			return nil
 		}
 
 		// If we have only null, treat as interface{} (since Go can't represent pure null)
 		if hasNull && len(nonNullTypes) == 0 {
+			reportDowngrade(path, schema.Position(), "a schema which can only be null is generated as interface{}")
 			outSchema.GoType = "interface{}"
 			outSchema.DefineViaAlias = true
 			return nil
 		}
 
-		// If we have exactly one non-null type + null, treat as nullable
-		if hasNull && len(nonNullTypes) == 1 {
-			// Set the type to the single non-null type and mark as nullable
-			t = []string{nonNullTypes[0]}
-			schema.Nullable = true
-			// Fall through to normal type processing
-		} else if !hasNull && len(nonNullTypes) == 1 {
-			// If we have exactly one non-null type (no null), convert to normal type processing
+		// If we have exactly one non-null type, with or without null, convert
+		// to normal type processing
+		if len(nonNullTypes) == 1 {
 			t = []string{nonNullTypes[0]}
 			// Fall through to normal type processing
 		} else {
@@ -727,6 +1025,7 @@ func oapiSchemaToGoType(schema *openapi.Schema, path []string, outSchema *Schema
 
 				// For string + number union, use the most general numeric type (float32)
 				if hasString && hasNumber {
+					reportDowngrade(path, schema.Position(), "the union of types %v is generated as float32", nonNullTypes)
 					outSchema.GoType = "float32"
 					outSchema.DefineViaAlias = true
 					return nil
@@ -734,6 +1033,7 @@ func oapiSchemaToGoType(schema *openapi.Schema, path []string, outSchema *Schema
 			}
 
 			// Default to interface{} for complex unions
+			reportDowngrade(path, schema.Position(), "the union of types %v is generated as interface{}", typeSlice)
 			outSchema.GoType = "interface{}"
 			outSchema.DefineViaAlias = true
 			return nil
@@ -747,12 +1047,15 @@ func oapiSchemaToGoType(schema *openapi.Schema, path []string, outSchema *Schema
 		if err != nil {
 			return fmt.Errorf("error generating type for array: %w", err)
 		}
-		if (arrayType.HasAdditionalProperties || len(arrayType.UnionElements) != 0) && arrayType.RefType == "" {
+		if (arrayType.HasAdditionalProperties || len(arrayType.UnionElements) != 0 || arrayType.namedMap) && arrayType.RefType == "" {
 			// If we have items which have additional properties or union values,
 			// but are not a pre-defined type, we need to define a type
 			// for them, which will be based on the field names we followed
 			// to get to the type.
-			typeName := PathToTypeName(append(path, "Item"))
+			typeName, err := inlineTypeName(append(path, "Item"), PathToTypeName(append(path, "Item")), false)
+			if err != nil {
+				return err
+			}
 
 			typeDef := TypeDefinition{
 				TypeName: typeName,
@@ -838,6 +1141,51 @@ func oapiSchemaToGoType(schema *openapi.Schema, path []string, outSchema *Schema
 	return nil
 }
 
+// jsonStringIntegerFormats are the formats of a string schema which holds an integer, for which the Go integer type is generated when it's encoded as a JSON string
+var jsonStringIntegerFormats = []string{"int64", "int32", "int16", "int8", "int", "uint64", "uint32", "uint16", "uint8", "uint"}
+
+// propertyJSONString returns whether the property's value is encoded as a JSON string, with the `,string` option of its `json` tag, when it's marked with x-go-json-string, or is a string with an integer format and the `json-string-integers` option is set. An inline string with an integer format is generated as the Go integer type instead
+func propertyJSONString(p *openapi.SchemaRef, pSchema *Schema) (bool, error) {
+	schema := p.Value
+	if schema == nil {
+		return false, nil
+	}
+
+	marked := false
+	if extension, ok := schema.Extensions[extGoJSONString]; ok {
+		var err error
+		if marked, err = extParseGoJSONString(extension); err != nil {
+			return false, fmt.Errorf("invalid value for %q: %w", extGoJSONString, err)
+		}
+		if !marked {
+			return false, nil
+		}
+	}
+	integerString := p.Ref == "" && schema.TypeIs("string") && sliceContains(jsonStringIntegerFormats, schema.Format) &&
+		pSchema.GoType == "string" && len(pSchema.EnumValues) == 0
+	if !marked && !(integerString && globalState.options.OutputOptions.JSONStringIntegers) {
+		return false, nil
+	}
+
+	if globalState.options.OutputOptions.NullableType && (schema.IsNullable() || (p.Siblings != nil && p.Siblings.Nullable)) {
+		// nullable.Nullable encodes its value itself, so ignores the option
+		if marked {
+			return false, fmt.Errorf("a nullable property can't be encoded as a JSON string with %q, as its type is nullable.Nullable", extGoJSONString)
+		}
+		return false, nil
+	}
+
+	switch {
+	case integerString:
+		pSchema.GoType = schema.Format
+		return true, nil
+	case schema.TypeIs("integer"), schema.TypeIs("number"), schema.TypeIs("boolean"):
+		return true, nil
+	default:
+		return false, fmt.Errorf("%q only applies to integer, number and boolean schemas, and string schemas with an integer format which aren't referenced or enums", extGoJSONString)
+	}
+}
+
 // SchemaDescriptor describes a Schema, a type definition.
 type SchemaDescriptor struct {
 	Fields                   []FieldDescriptor
@@ -863,112 +1211,140 @@ func stringOrEmpty(b bool, s string) string {
 // GenFieldsFromProperties produce corresponding field names with JSON annotations,
 // given a list of schema descriptors
 func GenFieldsFromProperties(props []Property) []string {
-	var fields []string
+	fields := make([]string, 0, len(props))
+	var sb strings.Builder
 	for i, p := range props {
-		field := ""
+		sb.Reset()
+		writeField(&sb, i, p)
+		fields = append(fields, sb.String())
+	}
+	return fields
+}
 
-		goFieldName := p.GoFieldName()
+// writeField writes the field for the i'th of a struct's properties, along with its comments, as GenFieldsFromProperties produces it
+func writeField(sb *strings.Builder, i int, p Property) {
+	goFieldName := p.GoFieldName()
 
-		// Add a comment to a field in case we have one, otherwise skip.
-		if p.Description != "" {
-			// Separate the comment from a previous-defined, unrelated field.
-			// Make sure the actual field is separated by a newline.
-			if i != 0 {
-				field += "\n"
-			}
-			field += fmt.Sprintf("%s\n", StringWithTypeNameToGoComment(p.Description, p.GoFieldName()))
+	// Add a comment to a field in case we have one, otherwise skip.
+	if p.Description != "" {
+		// Separate the comment from a previous-defined, unrelated field.
+		// Make sure the actual field is separated by a newline.
+		if i != 0 {
+			sb.WriteString("\n")
 		}
+		sb.WriteString(StringWithTypeNameToGoComment(p.Description, p.GoFieldName()))
+		sb.WriteString("\n")
+	}
 
-		if p.Deprecated {
-			// This comment has to be on its own line for godoc & IDEs to pick up
-			var deprecationReason string
-			if extension, ok := p.Extensions[extDeprecationReason]; ok {
-				if extDeprecationReason, err := extParseDeprecationReason(extension); err == nil {
-					deprecationReason = extDeprecationReason
-				}
+	if p.Deprecated {
+		// This comment has to be on its own line for godoc & IDEs to pick up
+		var deprecationReason string
+		if extension, ok := p.Extensions[extDeprecationReason]; ok {
+			if extDeprecationReason, err := extParseDeprecationReason(extension); err == nil {
+				deprecationReason = extDeprecationReason
 			}
-
-			field += fmt.Sprintf("%s\n", DeprecationComment(deprecationReason))
 		}
 
-		// Check x-go-type-skip-optional-pointer, which will override if the type
-		// should be a pointer or not when the field is optional.
-		if extension, ok := p.Extensions[extPropGoTypeSkipOptionalPointer]; ok {
-			if skipOptionalPointer, err := extParsePropGoTypeSkipOptionalPointer(extension); err == nil {
-				p.Schema.SkipOptionalPointer = skipOptionalPointer
-			}
+		sb.WriteString(DeprecationComment(deprecationReason))
+		sb.WriteString("\n")
+	}
+
+	// Check x-go-type-skip-optional-pointer, which will override if the type
+	// should be a pointer or not when the field is optional.
+	if extension, ok := p.Extensions[extPropGoTypeSkipOptionalPointer]; ok {
+		if skipOptionalPointer, err := extParsePropGoTypeSkipOptionalPointer(extension); err == nil {
+			p.Schema.SkipOptionalPointer = skipOptionalPointer
 		}
+	}
 
-		field += fmt.Sprintf("    %s %s", goFieldName, p.GoTypeDef())
+	if p.Embedded {
+		// The JSON tag keeps the property nested in JSON, while the type's
+		// fields are promoted in Go
+		sb.WriteString("    " + p.GoTypeDef())
+	} else {
+		sb.WriteString("    " + goFieldName + " " + p.GoTypeDef())
+	}
 
-		shouldOmitEmpty := (!p.Required || p.ReadOnly || p.WriteOnly) &&
-			(!p.Required || !p.ReadOnly || !globalState.options.Compatibility.DisableRequiredReadOnlyAsPointer)
+	shouldOmitEmpty := (!p.Required || p.ReadOnly || p.WriteOnly) &&
+		(!p.Required || !p.ReadOnly || !globalState.options.Compatibility.DisableRequiredReadOnlyAsPointer)
 
-		omitEmpty := !p.Nullable && shouldOmitEmpty
+	omitEmpty := !p.Nullable && shouldOmitEmpty
 
-		if p.Nullable && globalState.options.OutputOptions.NullableType {
-			omitEmpty = shouldOmitEmpty
-		}
+	if p.Nullable && globalState.options.OutputOptions.NullableType {
+		omitEmpty = shouldOmitEmpty
+	}
 
-		omitZero := false
+	omitZero := false
 
-		// default, but allow turning of
-		if shouldOmitEmpty && p.Schema.SkipOptionalPointer && globalState.options.OutputOptions.PreferSkipOptionalPointerWithOmitzero {
-			omitZero = true
-		}
+	// default, but allow turning of
+	if shouldOmitEmpty && p.Schema.SkipOptionalPointer && globalState.options.OutputOptions.PreferSkipOptionalPointerWithOmitzero {
+		omitZero = true
+	}
 
-		// Support x-omitempty and x-omitzero
-		if extOmitEmptyValue, ok := p.Extensions[extPropOmitEmpty]; ok {
-			if xValue, err := extParseOmitEmpty(extOmitEmptyValue); err == nil {
-				omitEmpty = xValue
-			}
+	// Support x-omitempty and x-omitzero
+	if extOmitEmptyValue, ok := p.Extensions[extPropOmitEmpty]; ok {
+		if xValue, err := extParseOmitEmpty(extOmitEmptyValue); err == nil {
+			omitEmpty = xValue
 		}
+	}
 
-		if extOmitEmptyValue, ok := p.Extensions[extPropOmitZero]; ok {
-			if xValue, err := extParseOmitZero(extOmitEmptyValue); err == nil {
-				omitZero = xValue
-			}
+	if extOmitEmptyValue, ok := p.Extensions[extPropOmitZero]; ok {
+		if xValue, err := extParseOmitZero(extOmitEmptyValue); err == nil {
+			omitZero = xValue
 		}
+	}
 
-		fieldTags := make(map[string]string)
+	fieldTags := make(map[string]string)
 
-		fieldTags["json"] = p.JsonFieldName +
-			stringOrEmpty(omitEmpty, ",omitempty") +
-			stringOrEmpty(omitZero, ",omitzero")
+	fieldTags["json"] = p.JsonFieldName +
+		stringOrEmpty(p.JSONString, ",string") +
+		stringOrEmpty(omitEmpty, ",omitempty") +
+		stringOrEmpty(omitZero, ",omitzero")
 
-		if globalState.options.OutputOptions.EnableYamlTags {
-			fieldTags["yaml"] = p.JsonFieldName + stringOrEmpty(omitEmpty, ",omitempty")
-		}
-		if p.NeedsFormTag {
-			fieldTags["form"] = p.JsonFieldName + stringOrEmpty(omitEmpty, ",omitempty")
+	// Support yaml-tags and extra-serializers
+	jsonIgnored := false
+	if extension, ok := p.Extensions[extPropGoJsonIgnore]; ok {
+		if goJsonIgnore, err := extParseGoJsonIgnore(extension); err == nil {
+			jsonIgnored = goJsonIgnore
 		}
+	}
+	// x-go-json-ignore only ignores the field with extra-serializers, as
+	// yaml-tags has always tagged it
+	for k, v := range extraSerializerTags(p.JsonFieldName, omitEmpty, jsonIgnored && len(globalState.options.OutputOptions.ExtraSerializers) > 0) {
+		fieldTags[k] = v
+	}
+	if p.NeedsFormTag {
+		fieldTags["form"] = p.JsonFieldName + stringOrEmpty(omitEmpty, ",omitempty")
+	}
 
-		// Support x-go-json-ignore
-		if extension, ok := p.Extensions[extPropGoJsonIgnore]; ok {
-			if goJsonIgnore, err := extParseGoJsonIgnore(extension); err == nil && goJsonIgnore {
-				fieldTags["json"] = "-"
-			}
-		}
+	// Support struct-tags, which apply to every field
+	for k, v := range structTagProfileTags(p.JsonFieldName, omitEmpty) {
+		fieldTags[k] = v
+	}
 
-		// Support x-oapi-codegen-extra-tags
-		if extension, ok := p.Extensions[extPropExtraTags]; ok {
-			if tags, err := extExtraTags(extension); err == nil {
-				keys := SortedMapKeys(tags)
-				for _, k := range keys {
-					fieldTags[k] = tags[k]
-				}
+	// Support x-go-json-ignore
+	if jsonIgnored {
+		fieldTags["json"] = "-"
+	}
+
+	// Support x-oapi-codegen-extra-tags
+	if extension, ok := p.Extensions[extPropExtraTags]; ok {
+		if tags, err := extExtraTags(extension); err == nil {
+			keys := SortedMapKeys(tags)
+			for _, k := range keys {
+				fieldTags[k] = tags[k]
 			}
 		}
-		// Convert the fieldTags map into Go field annotations.
-		keys := SortedMapKeys(fieldTags)
-		tags := make([]string, len(keys))
-		for i, k := range keys {
-			tags[i] = fmt.Sprintf(`%s:"%s"`, k, fieldTags[k])
+	}
+	// Convert the fieldTags map into Go field annotations.
+	sb.WriteString("`")
+	for i, k := range SortedMapKeys(fieldTags) {
+		if i > 0 {
+			sb.WriteString(" ")
 		}
-		field += "`" + strings.Join(tags, " ") + "`"
-		fields = append(fields, field)
+		sb.WriteString(k + `:"` + fieldTags[k] + `"`)
 	}
-	return fields
+	sb.WriteString("`")
 }
 
 func additionalPropertiesType(schema Schema) string {
@@ -983,32 +1359,92 @@ func additionalPropertiesType(schema Schema) string {
 }
 
 func GenStructFromSchema(schema Schema) string {
-	// Start out with struct {
-	objectParts := []string{"struct {"}
+	// The struct is written as it's built, rather than joining its parts, as the structs of large schemas are sizeable
+	var sb strings.Builder
+	sb.WriteString("struct {")
 
 	// Add embedded types first (for allOf)
 	for _, embeddedType := range schema.EmbeddedTypes {
-		objectParts = append(objectParts, fmt.Sprintf("    %s", embeddedType))
+		sb.WriteString("\n    " + embeddedType)
 	}
 
 	// Append all the field definitions
-	objectParts = append(objectParts, GenFieldsFromProperties(schema.Properties)...)
+	for i, p := range schema.Properties {
+		sb.WriteString("\n")
+		writeField(&sb, i, p)
+	}
 	// Close the struct
 	if schema.HasAdditionalProperties {
-		objectParts = append(objectParts,
-			fmt.Sprintf("AdditionalProperties map[string]%s `json:\"-\"`",
-				additionalPropertiesType(schema)))
+		// The additional properties are only marshaled by the custom JSON
+		// marshalling, so are ignored by any other serializers
+		tags := `json:"-"`
+		for _, serializer := range extraSerializers {
+			if sliceContains(globalState.options.OutputOptions.ExtraSerializers, string(serializer)) {
+				tags += fmt.Sprintf(` %s:"-"`, serializer)
+			}
+		}
+		fmt.Fprintf(&sb, "\nAdditionalProperties map[%s]%s `%s`",
+			schema.AdditionalPropertiesKeyType(), additionalPropertiesType(schema), tags)
 	}
 	if len(schema.UnionElements) != 0 {
-		objectParts = append(objectParts, "union json.RawMessage")
+		sb.WriteString("\nunion json.RawMessage")
+	}
+	sb.WriteString("\n}")
+	return sb.String()
+}
+
+// propertyIsSkipped returns whether a property is marked with
+// x-oapi-codegen-skip, either in its schema, or alongside its reference, as
+// the referenced schema's own extensions apply to the schema rather than the
+// property
+func propertyIsSkipped(p *openapi.SchemaRef) (bool, error) {
+	if p.Ref != "" {
+		if p.Siblings == nil {
+			return false, nil
+		}
+		return isSkipped(p.Siblings.Extensions)
 	}
-	objectParts = append(objectParts, "}")
-	return strings.Join(objectParts, "\n")
+	if p.Value == nil {
+		return false, nil
+	}
+	return isSkipped(p.Value.Extensions)
 }
 
 // This constructs a Go type for a parameter, looking at either the schema or
-// the content, whichever is available
+// the content, whichever is available, unless the parameter itself overrides
+// it with x-go-type, as a property's schema can
 func paramToGoType(param *openapi.Parameter, path []string) (Schema, error) {
+	goType, err := paramSchemaToGoType(param, path)
+	if err != nil {
+		return Schema{}, err
+	}
+
+	if extension, ok := param.Extensions[extPropGoType]; ok {
+		typeName, err := extTypeName(extension)
+		if err != nil {
+			return Schema{}, fmt.Errorf("invalid value for %q: %w", extPropGoType, err)
+		}
+		goType = Schema{
+			GoType:         typeName,
+			DefineViaAlias: true,
+			Description:    goType.Description,
+			OAPISchema:     goType.OAPISchema,
+		}
+	}
+
+	if extension, ok := param.Extensions[extPropGoTypeSkipOptionalPointer]; ok {
+		skipOptionalPointer, err := extParsePropGoTypeSkipOptionalPointer(extension)
+		if err != nil {
+			return Schema{}, fmt.Errorf("invalid value for %q: %w", extPropGoTypeSkipOptionalPointer, err)
+		}
+		goType.SkipOptionalPointer = skipOptionalPointer
+	}
+	return goType, nil
+}
+
+// paramSchemaToGoType constructs a Go type for a parameter from its schema or
+// content
+func paramSchemaToGoType(param *openapi.Parameter, path []string) (Schema, error) {
 	if param.Content == nil && param.Schema == nil {
 		return Schema{}, fmt.Errorf("parameter '%s' has no schema or content", param.Name)
 	}
@@ -1167,6 +1603,10 @@ func generateUnion(outSchema *Schema, elements []*openapi.SchemaRef, discriminat
 				elementName = elementName + suffix
 			}
 			usedTypeNames[elementName] = true
+			elementName, err = inlineTypeName(elementPath, elementName, false)
+			if err != nil {
+				return err
+			}
 
 			// For all inline schemas in unions, create type definitions
 			if elementSchema.TypeDecl() == elementName {
@@ -1225,6 +1665,43 @@ func generateUnion(outSchema *Schema, elements []*openapi.SchemaRef, discriminat
 	return nil
 }
 
+// AdditionalPropertiesRepresentation defines how an object with `additionalProperties` is represented in Go, which can be set for a schema with the `x-oapi-codegen-additional-properties` extension
+type AdditionalPropertiesRepresentation string
+
+const (
+	// AdditionalPropertiesRepresentationMap generates a `map[string]T`, even when the object also has `properties`, which are then only kept as entries in the map.
+	// NOTE that this only makes sense when the type of each property can be stored as a T.
+	AdditionalPropertiesRepresentationMap AdditionalPropertiesRepresentation = "map"
+	// AdditionalPropertiesRepresentationNamedMap generates a `map[string]T`, as with `AdditionalPropertiesRepresentationMap`, which is always given a named type, rather than being used inline, such as for a property
+	AdditionalPropertiesRepresentationNamedMap AdditionalPropertiesRepresentation = "named-map"
+	// AdditionalPropertiesRepresentationStruct generates a struct with an `AdditionalProperties` field, even when the object has no `properties`
+	AdditionalPropertiesRepresentationStruct AdditionalPropertiesRepresentation = "struct"
+)
+
+// additionalPropertiesRepresentation returns how an object with `additionalProperties` is represented in Go, which is either set for the schema, or derived from the configuration
+func additionalPropertiesRepresentation(schema *openapi.Schema) (AdditionalPropertiesRepresentation, error) {
+	if extension, ok := schema.Extensions[extAdditionalProperties]; ok {
+		representation, err := extParseAdditionalProperties(extension)
+		if err != nil {
+			return "", fmt.Errorf("invalid value for %q: %w", extAdditionalProperties, err)
+		}
+		return representation, nil
+	}
+
+	opts := globalState.options
+	hasProperties := len(schema.PropertiesToMap()) != 0
+	switch {
+	case hasProperties && !opts.OutputOptions.PreferMapForAdditionalProperties:
+		return AdditionalPropertiesRepresentationStruct, nil
+	case !hasProperties && opts.Compatibility.DisableFlattenAdditionalProperties:
+		return AdditionalPropertiesRepresentationStruct, nil
+	case opts.OutputOptions.NamedMapTypes:
+		return AdditionalPropertiesRepresentationNamedMap, nil
+	default:
+		return AdditionalPropertiesRepresentationMap, nil
+	}
+}
+
 // setSkipOptionalPointerForContainerType ensures that the "optional pointer" is skipped on container types (such as a slice or a map).
 // This is controlled using the `prefer-skip-optional-pointer-on-container-types` Output Option
 // NOTE that it is still possible to override this on a per-field basis with `x-go-type-skip-optional-pointer`
@@ -1235,3 +1712,140 @@ func setSkipOptionalPointerForContainerType(outSchema *Schema) {
 
 	outSchema.SkipOptionalPointer = true
 }
+
+// generatePropertyNames returns the constraints which a `propertyNames` schema
+// places on the keys of an object's additional properties, along with the type
+// definitions needed for an enum of keys, or nil if the keys are unconstrained.
+func generatePropertyNames(sref *openapi.SchemaRef, path []string) (*PropertyNames, []TypeDefinition, error) {
+	if sref == nil || sref.Value == nil {
+		return nil, nil, nil
+	}
+	schema := sref.Value
+
+	propertyNames := &PropertyNames{
+		KeyType: "string",
+		Pattern: schema.Pattern,
+	}
+	if propertyNames.Pattern != "" {
+		if _, err := regexp.Compile(propertyNames.Pattern); err != nil {
+			return nil, nil, fmt.Errorf("pattern %q isn't supported by Go's regexp package: %w", propertyNames.Pattern, err)
+		}
+	}
+	propertyNames.Enum = append(propertyNames.Enum, schema.EnumStrings()...)
+	if propertyNames.Pattern == "" && len(propertyNames.Enum) == 0 {
+		return nil, nil, nil
+	}
+	if len(propertyNames.Enum) == 0 || !schema.TypeIs("string") {
+		return propertyNames, nil, nil
+	}
+
+	// Keys which are a string enum use its defined type, which is named as a
+	// property called `Key` would be, unless it's a reference.
+	keySchema, err := GenerateGoSchema(sref, append(path, "Key"))
+	if err != nil {
+		return nil, nil, err
+	}
+	if keySchema.RefType != "" {
+		propertyNames.KeyType = keySchema.RefType
+	}
+	return propertyNames, keySchema.AdditionalTypes, nil
+}
+
+// constGoType returns the Go type of a schema's `const`, as decoded by
+// openapi.DecodeValue, for a schema which doesn't have a type, or an empty
+// string if it isn't a number, boolean or string. Numbers which aren't
+// integers are a float64, so that the value isn't rounded to a float32.
+func constGoType(value interface{}) string {
+	switch value.(type) {
+	case int64:
+		return "int"
+	case uint64:
+		return "uint64"
+	case float64:
+		return "float64"
+	case bool:
+		return "bool"
+	case string:
+		return "string"
+	default:
+		return ""
+	}
+}
+
+// isConstNull returns whether a schema's `const` is `null`, so that the only
+// value it allows is JSON null.
+func isConstNull(schema *openapi.Schema) bool {
+	return schema != nil && schema.Schema != nil && schema.Schema.Const != nil &&
+		schema.Schema.Const.Kind == yaml.ScalarNode && schema.Schema.Const.ShortTag() == "!!null"
+}
+
+// constLiteral returns the Go literal for a schema's `const`, as it's written
+// in the spec, as a constant of the given Go type, or false if it doesn't have
+// one, or it can't be one, as enumLiteral does for enum values. Strings are
+// quoted.
+func constLiteral(o *openapi.Schema, goType string) (string, bool) {
+	if o == nil || o.Schema == nil || o.Schema.Const == nil || o.Schema.Const.Kind != yaml.ScalarNode || isConstNull(o) {
+		return "", false
+	}
+	if goType == "string" {
+		return strconv.Quote(o.Schema.Const.Value), true
+	}
+	return enumLiteral(goType, o.Schema.Const.Value)
+}
+
+// enumLiteral returns the Go literal for an enum value, as it's written in the
+// spec, as a constant of the given Go type, or false if it can't be one, such
+// as a string in an enum of numbers. Integers and booleans are normalized, so
+// that `1e3` is `1000` and `True` is `true`, while other numbers are kept as
+// they're written, so that `1.0` isn't rendered as `1`.
+func enumLiteral(goType, value string) (string, bool) {
+	switch goType {
+	case "int", "int8", "int16", "int32", "int64":
+		if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return strconv.FormatInt(i, 10), true
+		}
+		if f, err := strconv.ParseFloat(value, 64); err == nil && f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 {
+			return strconv.FormatInt(int64(f), 10), true
+		}
+		return "", false
+	case "uint", "uint8", "uint16", "uint32", "uint64":
+		if u, err := strconv.ParseUint(value, 10, 64); err == nil {
+			return strconv.FormatUint(u, 10), true
+		}
+		if f, err := strconv.ParseFloat(value, 64); err == nil && f == math.Trunc(f) && f >= 0 && f < math.MaxUint64 {
+			return strconv.FormatUint(uint64(f), 10), true
+		}
+		return "", false
+	case "float32", "float64":
+		f, err := strconv.ParseFloat(value, 64)
+		return value, err == nil && !math.IsNaN(f) && !math.IsInf(f, 0)
+	case "bool":
+		b, err := strconv.ParseBool(value)
+		return strconv.FormatBool(b), err == nil
+	default:
+		return value, true
+	}
+}
+
+// refSiblings returns the keywords alongside a reference, which are only
+// honored for OpenAPI 3.1, as OpenAPI 3.0 requires them to be ignored.
+func refSiblings(sref *openapi.SchemaRef) *openapi.RefSiblings {
+	if sref.Siblings == nil || globalState.spec == nil || !globalState.spec.IsOpenAPI31() {
+		return nil
+	}
+	return sref.Siblings
+}
+
+// refSiblingsDescription returns the description of a type for a reference
+// with constraints alongside it, which lists them after the description.
+func refSiblingsDescription(description, refType string, constraints []openapi.RefSiblingConstraint) string {
+	parts := make([]string, len(constraints))
+	for i, c := range constraints {
+		parts[i] = fmt.Sprintf("%s: %s", c.Keyword, c.Value)
+	}
+	overrides := fmt.Sprintf("refers to %s, with the constraints %s.", refType, strings.Join(parts, ", "))
+	if description == "" {
+		return overrides
+	}
+	return description + "\n\nIt " + overrides
+}
@@ -1,9 +1,15 @@
 package codegen
 
 import (
+	"go/importer"
+	"go/token"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
 )
 
 func TestProperty_GoTypeDef(t *testing.T) {
@@ -454,3 +460,327 @@ func TestProperty_GoTypeDef_nullable(t *testing.T) {
 		})
 	}
 }
+
+const goEmbedSpec = `openapi: 3.0.0
+info:
+  title: x-go-embed
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Base:
+      type: object
+      properties:
+        id:
+          type: string
+    Audit:
+      type: object
+      properties:
+        createdBy:
+          type: string
+    Owner:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+    Pet:
+      type: object
+      required: [owner]
+      properties:
+        name:
+          type: string
+        owner:
+          $ref: '#/components/schemas/Owner'
+          x-go-embed: true
+        audit:
+          $ref: '#/components/schemas/Audit'
+          x-go-embed: true
+        detail:
+          allOf:
+            - $ref: '#/components/schemas/Base'
+              x-go-embed: true
+            - type: object
+              properties:
+                extra:
+                  type: string
+`
+
+func TestGoEmbedExtension(t *testing.T) {
+	generate := func(spec string) (string, error) {
+		swagger, err := openapi.NewLoader().LoadFromData([]byte(spec))
+		require.NoError(t, err)
+
+		return Generate(swagger, Configuration{
+			PackageName: "api",
+			Generate: GenerateOptions{
+				Models: true,
+			},
+		})
+	}
+
+	code, err := generate(goEmbedSpec)
+	require.NoError(t, err)
+
+	t.Run("a property is embedded, but still nested in JSON", func(t *testing.T) {
+		assert.Contains(t, code, "\tOwner `json:\"owner\"`\n")
+		assert.Contains(t, code, "\t*Audit `json:\"audit,omitempty\"`\n")
+	})
+
+	t.Run("an allOf member is embedded, even when the allOf would be merged", func(t *testing.T) {
+		assert.Contains(t, code, `Detail *struct {
+		Base
+		Extra *string `)
+	})
+
+	t.Run("the embedded fields are named after their types", func(t *testing.T) {
+		fset := token.NewFileSet()
+		typeCheck(t, fset, importer.ForCompiler(fset, "source", nil), code, `package api
+
+func useEmbedded(p Pet) (string, *string, *string) {
+	return p.Owner.Name, p.CreatedBy, p.Detail.Id
+}
+`)
+	})
+
+	t.Run("invalid values are errors", func(t *testing.T) {
+		_, err := generate(strings.Replace(goEmbedSpec, "x-go-embed: true", "x-go-embed: sometimes", 1))
+		assert.ErrorContains(t, err, `invalid value for "x-go-embed"`)
+	})
+}
+
+const enumDescriptionsSpec = `openapi: 3.0.0
+info:
+  title: x-enum-descriptions
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Status:
+      type: string
+      enum: [available, pending, sold]
+      x-enum-varnames: [Available, Pending, Sold]
+      x-enum-descriptions:
+        - The pet can be adopted
+        - ""
+        - |-
+          The pet has been adopted.
+          It can't be adopted again.
+    Level:
+      type: integer
+      enum: [1, 2]
+      x-enumDescriptions: [Low, High]
+`
+
+func TestEnumDescriptions(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(enumDescriptionsSpec))
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, code, `const (
+	// Available The pet can be adopted
+	Available Status = "available"
+	Pending   Status = "pending"
+	// Sold The pet has been adopted.
+	// It can't be adopted again.
+	Sold Status = "sold"
+)`)
+	assert.Contains(t, code, `const (
+	// N1 Low
+	N1 Level = 1
+	// N2 High
+	N2 Level = 2
+)`)
+}
+
+const goJSONStringSpec = `openapi: 3.0.0
+info:
+  title: x-go-json-string
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Count:
+      type: integer
+      format: int64
+      x-go-json-string: true
+    Account:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: string
+          format: int64
+          x-go-json-string: true
+        balance:
+          type: number
+          format: double
+          x-go-json-string: true
+        count:
+          $ref: '#/components/schemas/Count'
+        version:
+          type: string
+          format: int32
+        name:
+          type: string
+`
+
+func TestGoJSONStringExtension(t *testing.T) {
+	generate := func(spec string, jsonStringIntegers bool) (string, error) {
+		swagger, err := openapi.NewLoader().LoadFromData([]byte(spec))
+		require.NoError(t, err)
+
+		return Generate(swagger, Configuration{
+			PackageName: "api",
+			Generate: GenerateOptions{
+				Models: true,
+			},
+			OutputOptions: OutputOptions{
+				JSONStringIntegers: jsonStringIntegers,
+			},
+		})
+	}
+
+	t.Run("marked properties are encoded as JSON strings", func(t *testing.T) {
+		code, err := generate(goJSONStringSpec, false)
+		require.NoError(t, err)
+
+		assert.Contains(t, code, "Id      int64    `json:\"id,string\"`")
+		assert.Contains(t, code, "Balance *float64 `json:\"balance,string,omitempty\"`")
+		assert.Contains(t, code, "Count   *Count   `json:\"count,string,omitempty\"`")
+		assert.Contains(t, code, "Version *string  `json:\"version,omitempty\"`")
+		assert.Contains(t, code, "Name    *string  `json:\"name,omitempty\"`")
+	})
+
+	t.Run("json-string-integers applies to strings with an integer format", func(t *testing.T) {
+		code, err := generate(goJSONStringSpec, true)
+		require.NoError(t, err)
+
+		assert.Contains(t, code, "Version *int32   `json:\"version,string,omitempty\"`")
+		assert.Contains(t, code, "Name    *string  `json:\"name,omitempty\"`")
+	})
+
+	t.Run("strings without an integer format can't be marked", func(t *testing.T) {
+		_, err := generate(strings.Replace(goJSONStringSpec, "format: int64\n          x-go-json-string", "x-go-json-string", 1), false)
+		assert.ErrorContains(t, err, `"x-go-json-string" only applies to`)
+	})
+
+	t.Run("invalid values are errors", func(t *testing.T) {
+		_, err := generate(strings.Replace(goJSONStringSpec, "x-go-json-string: true", "x-go-json-string: sometimes", 1), false)
+		assert.ErrorContains(t, err, `invalid value for "x-go-json-string"`)
+	})
+}
+
+func TestEnumValueNames(t *testing.T) {
+	const spec = `openapi: 3.0.0
+info:
+  title: enum-value-names
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        age:
+          type: string
+          enum: [">=10", "<10"]
+`
+	generate := func(valueNames map[string]map[string]string) (string, error) {
+		swagger, err := openapi.NewLoader().LoadFromData([]byte(spec))
+		require.NoError(t, err)
+
+		return Generate(swagger, Configuration{
+			PackageName: "api",
+			Generate: GenerateOptions{
+				Models: true,
+			},
+			OutputOptions: OutputOptions{
+				EnumValueNames: valueNames,
+			},
+		})
+	}
+
+	code, err := generate(map[string]map[string]string{
+		"Pet.age": {">=10": "Senior"},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, code, `LessThan10 PetAge = "<10"`)
+	assert.Contains(t, code, `Senior     PetAge = ">=10"`)
+
+	_, err = generate(map[string]map[string]string{
+		"Pet.age": {">=11": "Senior"},
+	})
+	assert.ErrorContains(t, err, `enum-value-names: ">=11" isn't one of the values of the enum Pet.age`)
+}
+
+func TestEnumLiterals(t *testing.T) {
+	const spec = `openapi: 3.0.0
+info:
+  title: enum-literals
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Ratio:
+      type: number
+      enum: [1.0, 2.5]
+    Count:
+      type: integer
+      enum: [1e3, 9007199254740993]
+    Flag:
+      type: boolean
+      enum: [True]
+    Version:
+      type: string
+      enum: [1.0, 010]
+`
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, code, "RatioN10 Ratio = 1.0\n", "numbers are kept as they're written")
+	assert.Contains(t, code, "N1e3              Count = 1000\n", "integers are normalized")
+	assert.Contains(t, code, "N9007199254740993 Count = 9007199254740993\n", "integers aren't rounded to a float64")
+	assert.Contains(t, code, "True Flag = true\n")
+	assert.Contains(t, code, "VersionN10  Version = \"1.0\"\n", "strings aren't decoded as numbers")
+	assert.Contains(t, code, "VersionN010 Version = \"010\"\n")
+}
+
+func TestEnumLiteral(t *testing.T) {
+	tests := []struct {
+		goType, value, literal string
+		ok                     bool
+	}{
+		{"int64", "1e3", "1000", true},
+		{"int", "1.5", "", false},
+		{"int32", "abc", "", false},
+		{"uint64", "18446744073709551615", "18446744073709551615", true},
+		{"uint", "-1", "", false},
+		{"float64", "1.0", "1.0", true},
+		{"float32", ".inf", ".inf", false},
+		{"bool", "TRUE", "true", true},
+		{"string", "1.0", "1.0", true},
+	}
+	for _, tt := range tests {
+		literal, ok := enumLiteral(tt.goType, tt.value)
+		assert.Equal(t, tt.ok, ok, "%s %s", tt.goType, tt.value)
+		if tt.ok {
+			assert.Equal(t, tt.literal, literal, "%s %s", tt.goType, tt.value)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package codegen
+
+import (
+	"go/importer"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+const schemaTitlesSpec = `
+openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    post:
+      operationId: createPet
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              title: New pet
+              properties:
+                name:
+                  type: string
+                  title: Name
+                owner:
+                  type: object
+                  title: Pet owner
+                  properties:
+                    email:
+                      type: string
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        owner:
+          type: object
+          title: Pet owner
+          properties:
+            name:
+              type: string
+        status:
+          type: string
+          title: Pet status
+          enum: [available, sold]
+        labels:
+          type: object
+          title: Labels
+          additionalProperties:
+            type: string
+        toys:
+          type: array
+          items:
+            type: object
+            title: Toy
+            properties:
+              name:
+                type: string
+        collar:
+          type: object
+          title: Toy
+          properties:
+            size:
+              type: integer
+        friend:
+          type: object
+          title: Pet
+          properties:
+            id:
+              type: integer
+`
+
+func TestUseSchemaTitles(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(schemaTitlesSpec))
+	require.NoError(t, err)
+
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+			Client: true,
+		},
+		OutputOptions: OutputOptions{
+			UseSchemaTitles: true,
+		},
+	}
+
+	code, err := Generate(swagger, opts)
+	require.NoError(t, err)
+
+	t.Run("inline schemas are named from their titles", func(t *testing.T) {
+		assert.Contains(t, code, "type NewPet struct {")
+		assert.Contains(t, code, "type CreatePetJSONRequestBody NewPet")
+		assert.Contains(t, code, "Owner *PetOwner `json:\"owner,omitempty\"`")
+		assert.Contains(t, code, "type Labels map[string]string")
+		assert.Contains(t, code, "Labels *Labels `json:\"labels,omitempty\"`")
+		assert.Contains(t, code, "Collar *Toy `json:\"collar,omitempty\"`")
+		assert.Contains(t, code, "type PetStatus string")
+	})
+
+	t.Run("titles which collide are ignored", func(t *testing.T) {
+		// The title of Pet.owner is already given to the request body's owner, of Pet.toys to Pet.collar, and of Pet.friend to the component
+		assert.Contains(t, code, "Owner  *struct {")
+		assert.Contains(t, code, "Toys   *[]struct {")
+		assert.Contains(t, code, "Friend *struct {")
+	})
+
+	t.Run("the code type checks", func(t *testing.T) {
+		fset := token.NewFileSet()
+		typeCheck(t, fset, importer.ForCompiler(fset, "source", nil), code, `package api
+
+func use(p Pet, body NewPet) (*Toy, Labels, *PetOwner) {
+	return p.Collar, *p.Labels, body.Owner
+}
+`)
+	})
+
+	t.Run("titles are ignored by default", func(t *testing.T) {
+		opts := opts
+		opts.OutputOptions.UseSchemaTitles = false
+		code, err := Generate(swagger, opts)
+		require.NoError(t, err)
+
+		assert.Contains(t, code, "type CreatePetJSONBody struct {")
+		assert.NotContains(t, code, "NewPet")
+		assert.NotContains(t, code, "type Toy ")
+		assert.NotContains(t, code, "type Labels ")
+	})
+}
@@ -0,0 +1,41 @@
+package codegen
+
+import (
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+// isSensitive returns whether a parameter or property holds a secret, as it's marked with `x-sensitive`, either itself or on its schema, or its schema has the `password` format
+func isSensitive(extensions map[string]interface{}, schema *openapi.Schema) bool {
+	if sensitive, ok := sensitiveExtension(extensions); ok {
+		return sensitive
+	}
+	if schema == nil || schema.Schema == nil {
+		return false
+	}
+	if sensitive, ok := sensitiveExtension(schema.Extensions); ok {
+		return sensitive
+	}
+	return schema.Format == "password"
+}
+
+// sensitiveExtension returns the value of the `x-sensitive` extension, if it's set
+func sensitiveExtension(extensions map[string]interface{}) (bool, bool) {
+	extension, ok := extensions[extSensitive]
+	if !ok {
+		return false, false
+	}
+	sensitive, err := extParseSensitive(extension)
+	if err != nil {
+		return false, false
+	}
+	return sensitive, true
+}
+
+// IsSensitive returns whether the parameter holds a secret, which is redacted when the client logs the parameters of a request
+func (pd ParameterDefinition) IsSensitive() bool {
+	var schema *openapi.Schema
+	if pd.Spec.Schema != nil {
+		schema = pd.Spec.Schema.Value
+	}
+	return isSensitive(pd.Spec.Extensions, schema)
+}
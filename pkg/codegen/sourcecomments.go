@@ -0,0 +1,79 @@
+package codegen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strings"
+)
+
+// sourceComment returns the comment which links a declaration to the part of the spec it was generated from, for `source-comments`
+func sourceComment(source specSource) string {
+	comment := "// source: " + source.Position.File + source.Pointer
+	if source.Position.IsValid() {
+		comment += fmt.Sprintf(" (line %d)", source.Position.Line)
+	}
+	return comment
+}
+
+// addSourceComments adds a comment to each type and function in code which was generated from a specific part of the spec, linking it to that part of the spec
+func addSourceComments(code string, sources map[string]specSource) (string, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", code, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return "", fmt.Errorf("error parsing generated code: %w", err)
+	}
+
+	type insertion struct {
+		offset  int
+		comment string
+	}
+	var insertions []insertion
+	add := func(pos token.Pos, doc *ast.CommentGroup, source specSource) {
+		if source.Pointer == "" {
+			return
+		}
+		// The comment goes on its own line, at the start of the line the declaration starts on
+		offset := strings.LastIndex(code[:fset.Position(pos).Offset], "\n") + 1
+		comment := sourceComment(source) + "\n"
+		if doc != nil {
+			// Separate the comment from the declaration's documentation, so it's a paragraph of its own
+			comment = "//\n" + comment
+		}
+		insertions = append(insertions, insertion{offset: offset, comment: comment})
+	}
+
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			if decl.Tok != token.TYPE {
+				continue
+			}
+			if len(decl.Specs) == 1 && !decl.Lparen.IsValid() {
+				typeSpec := decl.Specs[0].(*ast.TypeSpec)
+				add(decl.Pos(), decl.Doc, declarationSource(sources, typeSpec.Name.Name, ""))
+				continue
+			}
+			for _, spec := range decl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				add(typeSpec.Pos(), typeSpec.Doc, declarationSource(sources, typeSpec.Name.Name, ""))
+			}
+		case *ast.FuncDecl:
+			receiver := ""
+			if decl.Recv != nil && len(decl.Recv.List) > 0 {
+				receiver = receiverTypeName(decl.Recv.List[0].Type)
+			}
+			add(decl.Pos(), decl.Doc, declarationSource(sources, decl.Name.Name, receiver))
+		}
+	}
+
+	sort.SliceStable(insertions, func(i, j int) bool {
+		return insertions[i].offset > insertions[j].offset
+	})
+	for _, in := range insertions {
+		code = code[:in.offset] + in.comment + code[in.offset:]
+	}
+	return code, nil
+}
@@ -0,0 +1,65 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+func TestSourceComments(t *testing.T) {
+	generate := func(sourceComments bool) string {
+		swagger, err := openapi.NewLoader().LoadFromData([]byte(manifestSpec))
+		require.NoError(t, err)
+
+		code, err := Generate(swagger, Configuration{
+			PackageName: "api",
+			Generate: GenerateOptions{
+				Models: true,
+				Client: true,
+			},
+			OutputOptions: OutputOptions{
+				SourceComments: sourceComments,
+			},
+		})
+		require.NoError(t, err)
+		return code
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.NotContains(t, generate(false), "// source:")
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		code := generate(true)
+
+		assert.Contains(t, code, `// Pet defines model for Pet.
+//
+// source: #/components/schemas/Pet (line 29)
+type Pet struct {`)
+		assert.Contains(t, code, `// GetPetParams defines parameters for GetPet.
+//
+// source: #/paths/~1pets~1{id}/get (line 7)
+type GetPetParams struct {`)
+		assert.Contains(t, code, `// NewGetPetRequest generates requests for GetPet
+//
+// source: #/paths/~1pets~1{id}/get (line 7)
+func NewGetPetRequest(`)
+		// Declarations without documentation are only given the source comment
+		assert.Contains(t, code, `
+// source: #/paths/~1pets~1{id}/get (line 7)
+func (c *Client) GetPet(`)
+		assert.Contains(t, code, `// Creates a new Client, with reasonable defaults
+func NewClient(`)
+	})
+}
+
+func TestSourceComment(t *testing.T) {
+	assert.Equal(t, "// source: #/components/schemas/Pet", sourceComment(specSource{Pointer: "#/components/schemas/Pet"}))
+	assert.Equal(t, "// source: api.yaml#/components/schemas/Pet (line 12)", sourceComment(specSource{
+		Pointer:  "#/components/schemas/Pet",
+		Position: openapi.Position{File: "api.yaml", Line: 12, Column: 5},
+	}))
+}
@@ -0,0 +1,168 @@
+package codegen
+
+import (
+	"fmt"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+// unsupportedKeywords returns the JSON Schema keywords used by a schema which the generated code doesn't represent
+func unsupportedKeywords(s *openapi.Schema) []string {
+	var keywords []string
+	if s.Schema.If != nil {
+		keywords = append(keywords, "if")
+	}
+	if s.Schema.Then != nil {
+		keywords = append(keywords, "then")
+	}
+	if s.Schema.Else != nil {
+		keywords = append(keywords, "else")
+	}
+	if s.Schema.Not != nil {
+		keywords = append(keywords, "not")
+	}
+	if s.Schema.PatternProperties != nil && s.Schema.PatternProperties.Len() > 0 {
+		keywords = append(keywords, "patternProperties")
+	}
+	if s.Schema.DependentSchemas != nil && s.Schema.DependentSchemas.Len() > 0 {
+		keywords = append(keywords, "dependentSchemas")
+	}
+	return keywords
+}
+
+// keywordChecker walks every schema in a spec, recording a failure for each use of an unsupported keyword
+type keywordChecker struct {
+	failures Error
+	// seen holds the keywords which have already been reported, by their position, as a component referenced by an operation's parameters, request body or responses is resolved in place, so is reached more than once
+	seen map[string]bool
+}
+
+// checkStrictKeywords returns an *Error with a failure for each use of a keyword which would be ignored by the generated code, for `strict-keywords`
+func checkStrictKeywords(spec *openapi.T) error {
+	c := keywordChecker{seen: make(map[string]bool)}
+
+	// Components are checked first, so that their keywords are reported against the component, rather than an operation which uses it
+	if components := spec.Components; components != nil {
+		for _, name := range SortedMapKeys(components.Schemas) {
+			c.schema("components/schemas/"+name, components.Schemas[name])
+		}
+		for _, name := range SortedMapKeys(components.Parameters) {
+			c.parameter("components/parameters/"+name, components.Parameters[name])
+		}
+		for _, name := range SortedMapKeys(components.RequestBodies) {
+			if requestBody := components.RequestBodies[name]; requestBody != nil && requestBody.Value != nil {
+				c.content("components/requestBodies/"+name, requestBody.Value.Content)
+			}
+		}
+		for _, name := range SortedMapKeys(components.Responses) {
+			c.response("components/responses/"+name, components.Responses[name])
+		}
+		for _, name := range SortedMapKeys(components.Headers) {
+			c.header("components/headers/"+name, components.Headers[name])
+		}
+	}
+
+	pathItems := filterablePathItems(spec)
+	for _, location := range SortedMapKeys(pathItems) {
+		pathItem := pathItems[location]
+		for i, param := range pathItem.Parameters {
+			c.parameter(fmt.Sprintf("%s parameters/%d", location, i), param)
+		}
+		ops := pathItem.Operations()
+		for _, method := range SortedMapKeys(ops) {
+			c.operation(method+" "+location, ops[method])
+		}
+	}
+
+	return c.failures.errOrNil()
+}
+
+func (c *keywordChecker) operation(location string, op *openapi.Operation) {
+	if op == nil {
+		return
+	}
+	for i, param := range openapi.ParametersToRefSlice(op.Parameters) {
+		c.parameter(fmt.Sprintf("%s parameters/%d", location, i), param)
+	}
+	if op.RequestBody != nil && op.RequestBody.Value != nil {
+		c.content(location+" requestBody", op.RequestBody.Value.Content)
+	}
+	if op.Responses != nil {
+		responses := op.Responses.Map()
+		for _, code := range SortedMapKeys(responses) {
+			c.response(location+" responses/"+code, responses[code])
+		}
+	}
+}
+
+func (c *keywordChecker) parameter(location string, param *openapi.ParameterRef) {
+	if param == nil || param.Value == nil {
+		return
+	}
+	c.schema(location+"/schema", param.Value.Schema)
+	c.content(location, param.Value.Content)
+}
+
+func (c *keywordChecker) response(location string, response *openapi.ResponseRef) {
+	if response == nil || response.Value == nil {
+		return
+	}
+	for _, name := range SortedMapKeys(response.Value.Headers) {
+		c.header(location+"/headers/"+name, response.Value.Headers[name])
+	}
+	c.content(location, response.Value.Content)
+}
+
+func (c *keywordChecker) header(location string, header *openapi.HeaderRef) {
+	if header == nil || header.Value == nil {
+		return
+	}
+	c.schema(location+"/schema", header.Value.Schema)
+}
+
+func (c *keywordChecker) content(location string, content map[string]*openapi.MediaType) {
+	for _, contentType := range SortedMapKeys(content) {
+		if mediaType := content[contentType]; mediaType != nil {
+			c.schema(location+"/content/"+contentType+"/schema", mediaType.Schema)
+		}
+	}
+}
+
+func (c *keywordChecker) schema(location string, sref *openapi.SchemaRef) {
+	// A referenced schema is checked where it's defined
+	if sref == nil || sref.Ref != "" || sref.Value == nil || sref.Value.Schema == nil {
+		return
+	}
+	s := sref.Value
+
+	for _, keyword := range unsupportedKeywords(s) {
+		pos := s.KeywordPosition(keyword)
+		if pos.IsValid() {
+			key := keyword + "@" + pos.String()
+			if c.seen[key] {
+				continue
+			}
+			c.seen[key] = true
+		}
+		c.failures.add(location, pos, fmt.Errorf("the `%s` keyword isn't supported, so would be ignored by the generated code", keyword))
+	}
+
+	properties := s.PropertiesToMap()
+	for _, name := range SortedMapKeys(properties) {
+		c.schema(location+"/properties/"+name, properties[name])
+	}
+	c.schema(location+"/items", s.Items)
+	for i, item := range s.PrefixItems {
+		c.schema(fmt.Sprintf("%s/prefixItems/%d", location, i), item)
+	}
+	for i, item := range openapi.SchemaProxiesToRefs(s.Schema.AllOf) {
+		c.schema(fmt.Sprintf("%s/allOf/%d", location, i), item)
+	}
+	for i, item := range openapi.SchemaProxiesToRefs(s.Schema.AnyOf) {
+		c.schema(fmt.Sprintf("%s/anyOf/%d", location, i), item)
+	}
+	for i, item := range openapi.SchemaProxiesToRefs(s.Schema.OneOf) {
+		c.schema(fmt.Sprintf("%s/oneOf/%d", location, i), item)
+	}
+	c.schema(location+"/additionalProperties", s.AdditionalProperties.Schema)
+}
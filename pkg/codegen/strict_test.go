@@ -0,0 +1,450 @@
+package codegen
+
+import (
+	"go/importer"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+const strictKeywordsSpec = `openapi: 3.1.0
+info:
+  title: strict keywords
+  version: 1.0.0
+paths:
+  /pets:
+    post:
+      operationId: addPet
+      requestBody:
+        $ref: '#/components/requestBodies/NewPet'
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                patternProperties:
+                  "^x-":
+                    type: string
+components:
+  requestBodies:
+    NewPet:
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/Pet'
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+          not:
+            const: ""
+        kind:
+          type: string
+      if:
+        properties:
+          kind:
+            const: dog
+      then:
+        required: [name]
+      dependentSchemas:
+        name:
+          required: [kind]
+`
+
+func TestStrictKeywords(t *testing.T) {
+	generate := func(strict bool) error {
+		swagger, err := openapi.NewLoader().LoadFromData([]byte(strictKeywordsSpec))
+		require.NoError(t, err)
+
+		_, err = Generate(swagger, Configuration{
+			PackageName: "api",
+			Generate: GenerateOptions{
+				Models: true,
+			},
+			OutputOptions: OutputOptions{
+				StrictKeywords: strict,
+				SkipPrune:      true,
+			},
+		})
+		return err
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.NoError(t, generate(false))
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		err := generate(true)
+		require.Error(t, err)
+
+		var genErr *Error
+		require.ErrorAs(t, err, &genErr)
+
+		var reported []string
+		for _, failure := range genErr.Failures {
+			reported = append(reported, failure.Artifact)
+			assert.True(t, failure.Position.IsValid(), "failure for %s should have a position", failure.Artifact)
+		}
+		assert.Equal(t, []string{
+			"components/schemas/Pet",
+			"components/schemas/Pet",
+			"components/schemas/Pet",
+			"components/schemas/Pet/properties/name",
+			"POST /pets responses/200/content/application/json/schema",
+		}, reported)
+
+		assert.Contains(t, err.Error(), "components/schemas/Pet (38:7): the `if` keyword isn't supported, so would be ignored by the generated code")
+		assert.Contains(t, err.Error(), "components/schemas/Pet/properties/name (34:11): the `not` keyword isn't supported")
+		assert.Contains(t, err.Error(), "the `patternProperties` keyword isn't supported")
+		assert.Contains(t, err.Error(), "the `dependentSchemas` keyword isn't supported")
+	})
+}
+
+const strictSecuritySpec = `openapi: 3.0.0
+info:
+  title: strict security
+  version: 1.0.0
+security:
+  - api_key: []
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      security:
+        - oauth: [read, write]
+          api_key: []
+        - {}
+      responses:
+        '200':
+          description: OK
+  /me:
+    get:
+      operationId: me
+      responses:
+        '200':
+          description: OK
+  /health:
+    get:
+      operationId: health
+      security: []
+      responses:
+        '200':
+          description: OK
+components:
+  securitySchemes:
+    api_key:
+      type: apiKey
+      name: X-API-Key
+      in: header
+    oauth:
+      type: oauth2
+      flows:
+        clientCredentials:
+          tokenUrl: https://example.com/token
+          scopes:
+            read: Read
+            write: Write
+`
+
+// strictSecurityUsage authenticates the strict handler's requests with a SecurityHandler, so that type checking it alongside the generated code ensures the middleware compiles
+const strictSecurityUsage = `package api
+
+import (
+	"context"
+	"errors"
+)
+
+type apiKeySecurity struct{}
+
+func (apiKeySecurity) Authenticate(ctx context.Context, scheme string, scopes []string) (context.Context, error) {
+	if SecurityRequest(ctx).Header.Get("X-API-Key") == "" {
+		return nil, errors.New("unauthenticated")
+	}
+	return ctx, nil
+}
+
+func useSecurity(ssi StrictServerInterface) ServerInterface {
+	return NewStrictHandler(ssi, []StrictMiddlewareFunc{NewStrictSecurityMiddleware(apiKeySecurity{})})
+}
+`
+
+func TestStrictSecurity(t *testing.T) {
+	generate := func(spec string) string {
+		swagger, err := openapi.NewLoader().LoadFromData([]byte(spec))
+		require.NoError(t, err)
+
+		code, err := Generate(swagger, Configuration{
+			PackageName: "api",
+			Generate: GenerateOptions{
+				StdHTTPServer: true,
+				Strict:        true,
+				Models:        true,
+			},
+		})
+		require.NoError(t, err)
+		return code
+	}
+
+	t.Run("each operation's effective security is enforced", func(t *testing.T) {
+		code := generate(strictSecuritySpec)
+
+		assert.Contains(t, code, "type SecurityHandler interface")
+		assert.Contains(t, code, `"ListPets": {
+		{{scheme: "api_key"}, {scheme: "oauth", scopes: []string{"read", "write"}}},
+		{},
+	},`)
+		assert.Contains(t, code, `"Me": {
+		{{scheme: "api_key"}},
+	},`)
+		assert.NotContains(t, code, `"Health":`)
+
+		fset := token.NewFileSet()
+		typeCheck(t, fset, importer.ForCompiler(fset, "source", nil), code, strictSecurityUsage)
+	})
+
+	t.Run("without security nothing is generated", func(t *testing.T) {
+		code := generate(`openapi: 3.0.0
+info:
+  title: no security
+  version: 1.0.0
+paths:
+  /health:
+    get:
+      operationId: health
+      responses:
+        '200':
+          description: OK
+`)
+		assert.NotContains(t, code, "SecurityHandler")
+	})
+}
+
+const strictBinarySpec = `openapi: 3.0.0
+info:
+  title: binary responses
+  version: 1.0.0
+paths:
+  /pets/{id}/photo:
+    get:
+      operationId: getPhoto
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: OK
+          content:
+            image/png:
+              schema:
+                type: string
+                format: binary
+        '404':
+          description: Not found
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  message:
+                    type: string
+  /report:
+    get:
+      operationId: getReport
+      responses:
+        '200':
+          description: OK
+          content:
+            text/plain:
+              schema:
+                type: string
+                format: binary
+  /name:
+    get:
+      operationId: getName
+      responses:
+        '200':
+          description: OK
+          content:
+            text/plain:
+              schema:
+                type: string
+`
+
+const strictBinaryUsage = `package api
+
+import (
+	"context"
+	"io"
+	"strings"
+)
+
+type binaryServer struct{}
+
+func (binaryServer) GetPhoto(ctx context.Context, request GetPhotoRequestObject) (GetPhotoResponseObject, error) {
+	return GetPhoto200ImagepngResponse{
+		Body:               strings.NewReader("png"),
+		ContentLength:      3,
+		ContentDisposition: "attachment; filename=\"pet.png\"",
+	}, nil
+}
+
+func (binaryServer) GetReport(ctx context.Context, request GetReportRequestObject) (GetReportResponseObject, error) {
+	return GetReport200TextplainResponse{Body: strings.NewReader("report")}, nil
+}
+
+func (binaryServer) GetName(ctx context.Context, request GetNameRequestObject) (GetNameResponseObject, error) {
+	return GetName200TextResponse("name"), nil
+}
+
+var _ StrictServerInterface = binaryServer{}
+
+func download(ctx context.Context, client ClientWithResponsesInterface, w io.Writer) (int64, error) {
+	rsp, err := client.GetPhotoWithResponseStream(ctx, "1")
+	if err != nil {
+		return 0, err
+	}
+	defer rsp.Body.Close()
+	_ = rsp.ContentLength()
+	_ = rsp.ContentDisposition()
+	return io.Copy(w, rsp.Body)
+}
+`
+
+func TestStrictBinaryResponses(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(strictBinarySpec))
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			StdHTTPServer: true,
+			Strict:        true,
+			Client:        true,
+			Models:        true,
+		},
+	})
+	require.NoError(t, err)
+
+	t.Run("binary responses are streamed from an io.Reader", func(t *testing.T) {
+		assert.Contains(t, code, "type GetPhoto200ImagepngResponse struct {")
+		assert.Contains(t, code, "type GetReport200TextplainResponse struct {")
+		assert.Contains(t, code, `w.Header().Set("Content-Disposition", response.ContentDisposition)`)
+	})
+
+	t.Run("text which isn't binary is still a string", func(t *testing.T) {
+		assert.Contains(t, code, "type GetName200TextResponse string")
+		assert.NotContains(t, code, "GetNameWithResponseStream")
+	})
+
+	t.Run("the client streams binary responses", func(t *testing.T) {
+		assert.Contains(t, code, "GetPhotoWithResponseStream(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetPhotoResponseStream, error)")
+		assert.Contains(t, code, "func ParseGetPhotoResponseStream(rsp *http.Response) (*GetPhotoResponseStream, error) {")
+	})
+
+	t.Run("the code type checks", func(t *testing.T) {
+		fset := token.NewFileSet()
+		typeCheck(t, fset, importer.ForCompiler(fset, "source", nil), code, strictBinaryUsage)
+	})
+}
+
+const strictDefaultResponsesSpec = `openapi: 3.0.0
+info:
+  title: default responses
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    get:
+      operationId: getPet
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+        '4XX':
+          description: Client error
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Error'
+        default:
+          description: Unexpected error
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+    Error:
+      type: object
+      properties:
+        message:
+          type: string
+`
+
+const strictDefaultResponsesUsage = `package api
+
+import (
+	"context"
+	"net/http"
+)
+
+type defaultServer struct{}
+
+func (defaultServer) GetPet(ctx context.Context, request GetPetRequestObject) (GetPetResponseObject, error) {
+	switch request.Id {
+	case "":
+		return GetPet4XXJSONResponse{Body: Error{}, StatusCode: http.StatusBadRequest}, nil
+	case "teapot":
+		return GetPetdefaultResponse{StatusCode: http.StatusTeapot}, nil
+	}
+	return GetPet200JSONResponse{}, nil
+}
+
+var _ StrictServerInterface = defaultServer{}
+`
+
+func TestStrictDefaultResponses(t *testing.T) {
+	code, err := generateFromSpec(t, strictDefaultResponsesSpec, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			StdHTTPServer: true,
+			Strict:        true,
+			Models:        true,
+		},
+	})
+	require.NoError(t, err)
+
+	t.Run("the default response is responded with the given status code", func(t *testing.T) {
+		assert.Contains(t, code, "type GetPetdefaultResponse struct {\n\tStatusCode int\n}")
+		assert.Contains(t, code, "func (response GetPetdefaultResponse) VisitGetPetResponse(w http.ResponseWriter) error {")
+	})
+
+	t.Run("a range of status codes is responded with the given status code", func(t *testing.T) {
+		assert.Contains(t, code, "type GetPet4XXJSONResponse struct {\n\tBody       Error\n\tStatusCode int\n}")
+	})
+
+	t.Run("the code type checks", func(t *testing.T) {
+		fset := token.NewFileSet()
+		typeCheck(t, fset, importer.ForCompiler(fset, "source", nil), code, strictDefaultResponsesUsage)
+	})
+}
@@ -0,0 +1,135 @@
+package codegen
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+// extLogFields lists the properties of a schema which summarize it, for `stringer`
+const extLogFields = "x-log-fields"
+
+// StringerTypeDefinition is a model which has `String` and `LogValue` methods generated for it, with `stringer`
+type StringerTypeDefinition struct {
+	TypeName string
+	// String is the body of the `String` method
+	String string
+	// LogValue is the body of the `LogValue` method
+	LogValue string
+}
+
+// GenerateStringerBoilerplate generates `String` and `LogValue` methods for each struct, as configured with the `stringer` option, which summarize it by its key fields, such that it's concise in logs. The key fields are those listed in the schema's `x-log-fields`, or otherwise all of its properties, and the values of sensitive fields are redacted
+func GenerateStringerBoilerplate(t *template.Template, typeDefs []TypeDefinition) (string, error) {
+	if !globalState.options.Generate.Stringer {
+		return "", nil
+	}
+
+	seen := make(map[string]bool)
+	var types []StringerTypeDefinition
+	for _, td := range typeDefs {
+		if seen[td.TypeName] || !isRedactableStruct(td) {
+			continue
+		}
+		seen[td.TypeName] = true
+
+		def, err := stringerTypeDefinition(td)
+		if err != nil {
+			return "", fmt.Errorf("error generating String and LogValue methods for %s: %w", td.TypeName, err)
+		}
+		types = append(types, def)
+	}
+
+	if len(types) == 0 {
+		return "", nil
+	}
+
+	context := struct {
+		Types []StringerTypeDefinition
+	}{
+		Types: types,
+	}
+
+	return GenerateTemplates([]string{"stringer.tmpl"}, t, context)
+}
+
+// stringerTypeDefinition returns the bodies of the `String` and `LogValue` methods of a struct, which log each of its key fields, or only the value of an optional field which is set. A type embedded in the struct is logged inline, as its fields are part of the struct's schema
+func stringerTypeDefinition(td TypeDefinition) (StringerTypeDefinition, error) {
+	logFields, err := schemaLogFields(td.Schema)
+	if err != nil {
+		return StringerTypeDefinition{}, err
+	}
+
+	stringStmts := []string{"var fields []string"}
+	logValueStmts := []string{"var attrs []slog.Attr"}
+	for _, field := range redactedFields(td.Schema) {
+		if field.Name == "String" || field.Name == "LogValue" {
+			return StringerTypeDefinition{}, fmt.Errorf("it has a field named %s. Please rename the field with `x-go-name`", field.Name)
+		}
+		if field.Embedded {
+			stringStmts = append(stringStmts, fmt.Sprintf("fields = append(fields, fmt.Sprint(t.%s))", field.Name))
+			logValueStmts = append(logValueStmts, fmt.Sprintf("attrs = append(attrs, slog.Any(\"\", t.%s))", field.Name))
+			continue
+		}
+		if field.JsonName == "" || (logFields != nil && !slices.Contains(logFields, field.JsonName)) {
+			continue
+		}
+
+		value := "t." + field.Name
+		var open []string
+		switch {
+		case field.Nullable != "":
+			open = []string{fmt.Sprintf("if v, err := t.%s.Get(); err == nil {", field.Name)}
+			value = "v"
+		case field.Pointer:
+			open = []string{fmt.Sprintf("if t.%s != nil {", field.Name)}
+			value = "*t." + field.Name
+		}
+
+		str := fmt.Sprintf("fields = append(fields, fmt.Sprintf(\"%s: %%v\", %s))", field.JsonName, value)
+		attr := fmt.Sprintf("attrs = append(attrs, slog.Any(%q, %s))", field.JsonName, value)
+		if field.Sensitive {
+			str = fmt.Sprintf("fields = append(fields, %q)", field.JsonName+": "+redactedValue)
+			attr = fmt.Sprintf("attrs = append(attrs, slog.String(%q, %q))", field.JsonName, redactedValue)
+			if field.Nullable != "" {
+				// The value isn't used, so is only checked for
+				open = []string{fmt.Sprintf("if t.%s.IsSpecified() && !t.%s.IsNull() {", field.Name, field.Name)}
+			}
+		}
+
+		if len(open) == 0 {
+			stringStmts = append(stringStmts, str)
+			logValueStmts = append(logValueStmts, attr)
+			continue
+		}
+		stringStmts = append(stringStmts, open[0], str, "}")
+		logValueStmts = append(logValueStmts, open[0], attr, "}")
+	}
+
+	return StringerTypeDefinition{
+		TypeName: td.TypeName,
+		String:   strings.Join(append(stringStmts, fmt.Sprintf("return %q + strings.Join(fields, \", \") + \"}\"", td.TypeName+"{")), "\n"),
+		LogValue: strings.Join(append(logValueStmts, "return slog.GroupValue(attrs...)"), "\n"),
+	}, nil
+}
+
+// schemaLogFields returns the properties listed in the schema's `x-log-fields`, or nil if it isn't set
+func schemaLogFields(s Schema) ([]string, error) {
+	if s.OAPISchema == nil {
+		return nil, nil
+	}
+	extension, ok := s.OAPISchema.Extensions[extLogFields]
+	if !ok {
+		return nil, nil
+	}
+	var logFields []string
+	if err := decodeYamlNode(extension, &logFields); err != nil {
+		return nil, fmt.Errorf("invalid value for %q: %w", extLogFields, err)
+	}
+	for _, name := range logFields {
+		if !slices.ContainsFunc(s.Properties, func(p Property) bool { return p.JsonFieldName == name }) {
+			return nil, fmt.Errorf("%q lists %q, which isn't one of its properties", extLogFields, name)
+		}
+	}
+	return logFields, nil
+}
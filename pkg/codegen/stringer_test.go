@@ -0,0 +1,129 @@
+package codegen
+
+import (
+	"go/importer"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+const stringerSpec = `openapi: 3.0.0
+info:
+  title: stringer
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Credentials:
+      type: object
+      required: [username]
+      properties:
+        username:
+          type: string
+        password:
+          type: string
+          format: password
+    User:
+      allOf:
+        - $ref: '#/components/schemas/Credentials'
+        - type: object
+          properties:
+            name:
+              type: string
+    Pet:
+      type: object
+      x-log-fields: [id, name]
+      required: [id]
+      properties:
+        id:
+          type: integer
+        name:
+          type: string
+        tags:
+          type: array
+          items:
+            type: string
+`
+
+// stringerUsage logs models, so that type checking it alongside the generated code ensures they implement fmt.Stringer and slog.LogValuer
+const stringerUsage = `package api
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+var (
+	_ fmt.Stringer   = Pet{}
+	_ slog.LogValuer = User{}
+	_ fmt.GoStringer = Credentials{}
+)
+`
+
+func TestStringerBoilerplate(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(stringerSpec))
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:   true,
+			Stringer: true,
+		},
+	})
+	require.NoError(t, err)
+
+	// Only the fields listed in `x-log-fields`, and optional fields only when they're set
+	assert.Contains(t, code, "fields = append(fields, fmt.Sprintf(\"id: %v\", t.Id))\n\tif t.Name != nil {\n\t\tfields = append(fields, fmt.Sprintf(\"name: %v\", *t.Name))\n\t}\n\treturn \"Pet{\" + strings.Join(fields, \", \") + \"}\"")
+	assert.NotContains(t, code, "\"tags\"")
+	// Sensitive fields are redacted
+	assert.Contains(t, code, "attrs = append(attrs, slog.String(\"password\", \"[REDACTED]\"))")
+	assert.Contains(t, code, "fields = append(fields, \"password: [REDACTED]\")")
+	// An embedded type is logged inline
+	assert.Contains(t, code, "attrs = append(attrs, slog.Any(\"\", t.Credentials))")
+	// The String method which redacts the sensitive fields isn't generated as well, though GoString still is
+	assert.NotContains(t, code, "as `%+v` does")
+	assert.Contains(t, code, "func (t Credentials) GoString() string {")
+
+	fset := token.NewFileSet()
+	typeCheck(t, fset, importer.ForCompiler(fset, "source", nil), code, stringerUsage)
+}
+
+func TestStringerUnknownLogField(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(`openapi: 3.0.0
+info:
+  title: stringer
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      x-log-fields: [id, owner]
+      properties:
+        id:
+          type: integer
+`))
+	require.NoError(t, err)
+
+	_, err = Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:   true,
+			Stringer: true,
+		},
+	})
+	assert.ErrorContains(t, err, "\"x-log-fields\" lists \"owner\", which isn't one of its properties")
+}
+
+func TestStringerOption(t *testing.T) {
+	opts := GenerateOptions{Stringer: true}
+	assert.Contains(t, opts.Validate(), "stringer")
+
+	opts.Models = true
+	assert.Empty(t, opts.Validate())
+}
@@ -0,0 +1,133 @@
+package codegen
+
+import (
+	"strings"
+	"unicode"
+)
+
+// StructTagCasing defines how the value of a struct tag configured with `struct-tags` is derived from a field's JSON name
+type StructTagCasing string
+
+const (
+	// StructTagCasingUnset uses the JSON name as-is
+	StructTagCasingUnset StructTagCasing = ""
+	// StructTagCasingSnake converts the JSON name to snake case, i.e. `petName` becomes `pet_name`
+	StructTagCasingSnake StructTagCasing = "snake"
+	// StructTagCasingCamel converts the JSON name to camel case, i.e. `pet_name` becomes `petName`
+	StructTagCasingCamel StructTagCasing = "camel"
+	// StructTagCasingPascal converts the JSON name to Pascal case, i.e. `pet_name` becomes `PetName`
+	StructTagCasingPascal StructTagCasing = "pascal"
+	// StructTagCasingKebab converts the JSON name to kebab case, i.e. `petName` becomes `pet-name`
+	StructTagCasingKebab StructTagCasing = "kebab"
+	// StructTagCasingLower converts the JSON name to lower case, without separators, i.e. `pet_name` becomes `petname`
+	StructTagCasingLower StructTagCasing = "lower"
+)
+
+// ExtraSerializer is a serialisation format, other than JSON, which struct tags are generated for with `extra-serializers`
+type ExtraSerializer string
+
+const (
+	// ExtraSerializerYAML adds `yaml` tags, for gopkg.in/yaml.v3, along with YAML marshalling for the types which have custom JSON marshalling
+	ExtraSerializerYAML ExtraSerializer = "yaml"
+	// ExtraSerializerXML adds `xml` tags, for encoding/xml
+	ExtraSerializerXML ExtraSerializer = "xml"
+	// ExtraSerializerMsgpack adds `msgpack` tags, for github.com/vmihailenco/msgpack
+	ExtraSerializerMsgpack ExtraSerializer = "msgpack"
+)
+
+// Apply returns the value of a struct tag for the given JSON name
+func (c StructTagCasing) Apply(jsonName string) string {
+	if c == StructTagCasingUnset {
+		return jsonName
+	}
+
+	words := splitWords(jsonName)
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+
+	switch c {
+	case StructTagCasingSnake:
+		return strings.Join(words, "_")
+	case StructTagCasingKebab:
+		return strings.Join(words, "-")
+	case StructTagCasingLower:
+		return strings.Join(words, "")
+	case StructTagCasingCamel, StructTagCasingPascal:
+		for i, word := range words {
+			if i > 0 || c == StructTagCasingPascal {
+				words[i] = UppercaseFirstCharacter(word)
+			}
+		}
+		return strings.Join(words, "")
+	default:
+		return jsonName
+	}
+}
+
+// splitWords splits a name into its words, which are separated by anything other than a letter or digit, or by a change of case, such that `HTTPServer_id` is split into `HTTP`, `Server` and `id`
+func splitWords(name string) []string {
+	var words []string
+	var word []rune
+	runes := []rune(name)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if len(word) > 0 {
+				words = append(words, string(word))
+				word = nil
+			}
+			continue
+		}
+
+		if unicode.IsUpper(r) && len(word) > 0 {
+			prev := word[len(word)-1]
+			// A new word starts after a lower case letter or digit, or at the last upper case letter of an acronym
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if !unicode.IsUpper(prev) || nextIsLower {
+				words = append(words, string(word))
+				word = nil
+			}
+		}
+		word = append(word, r)
+	}
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+	return words
+}
+
+// structTagProfileTags returns the tags configured with `struct-tags` for a field with the given JSON name
+func structTagProfileTags(jsonName string, omitEmpty bool) map[string]string {
+	tags := make(map[string]string)
+	for _, profile := range globalState.options.OutputOptions.StructTags {
+		tags[profile.Name] = StructTagCasing(profile.Casing).Apply(jsonName) + stringOrEmpty(profile.OmitEmpty && omitEmpty, ",omitempty")
+	}
+	return tags
+}
+
+// extraSerializers are the serializers which can be configured with `extra-serializers`, in the order their tags are generated
+var extraSerializers = []ExtraSerializer{ExtraSerializerYAML, ExtraSerializerXML, ExtraSerializerMsgpack}
+
+// hasExtraSerializer returns whether struct tags are generated for the serializer, with `extra-serializers`, or with `yaml-tags` for YAML
+func hasExtraSerializer(serializer ExtraSerializer) bool {
+	if serializer == ExtraSerializerYAML && globalState.options.OutputOptions.EnableYamlTags {
+		return true
+	}
+	return sliceContains(globalState.options.OutputOptions.ExtraSerializers, string(serializer))
+}
+
+// extraSerializerTags returns the tags for each of the serializers configured with `extra-serializers`, or `yaml-tags`, for a field with the given JSON name, which are `-` for a field which isn't serialised
+func extraSerializerTags(jsonName string, omitEmpty, ignored bool) map[string]string {
+	tags := make(map[string]string)
+	for _, serializer := range extraSerializers {
+		if !hasExtraSerializer(serializer) {
+			continue
+		}
+		if ignored {
+			tags[string(serializer)] = "-"
+			continue
+		}
+		tags[string(serializer)] = jsonName + stringOrEmpty(omitEmpty, ",omitempty")
+	}
+	return tags
+}
@@ -0,0 +1,159 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStructTagCasing(t *testing.T) {
+	tests := []struct {
+		jsonName string
+		casing   StructTagCasing
+		expected string
+	}{
+		{"petName", StructTagCasingUnset, "petName"},
+		{"petName", StructTagCasingSnake, "pet_name"},
+		{"pet_name", StructTagCasingCamel, "petName"},
+		{"pet-name", StructTagCasingPascal, "PetName"},
+		{"petName", StructTagCasingKebab, "pet-name"},
+		{"pet_name", StructTagCasingLower, "petname"},
+		{"HTTPServer_id", StructTagCasingSnake, "http_server_id"},
+		{"address2Line", StructTagCasingSnake, "address2_line"},
+		{"id", StructTagCasingPascal, "Id"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.casing)+"/"+tt.jsonName, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.casing.Apply(tt.jsonName))
+		})
+	}
+}
+
+func TestGenerateStructTagProfiles(t *testing.T) {
+	spec := `openapi: 3.0.3
+info:
+  title: Struct tags
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [petName]
+      properties:
+        petName:
+          type: string
+        ownerId:
+          type: string
+          x-oapi-codegen-extra-tags:
+            db: owner
+`
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+		},
+		OutputOptions: OutputOptions{
+			StructTags: []StructTagProfile{
+				{Name: "bson", Casing: string(StructTagCasingSnake), OmitEmpty: true},
+				{Name: "db", Casing: string(StructTagCasingSnake)},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, code, "OwnerId *string `bson:\"owner_id,omitempty\" db:\"owner\" json:\"ownerId,omitempty\"`")
+	assert.Contains(t, code, "PetName string  `bson:\"pet_name\" db:\"pet_name\" json:\"petName\"`")
+}
+
+func TestStructTagProfilesValidation(t *testing.T) {
+	opts := OutputOptions{
+		StructTags: []StructTagProfile{
+			{Name: "bson", Casing: "screaming"},
+			{Name: "json"},
+			{Name: ""},
+			{Name: "bson"},
+		},
+	}
+	problems := opts.Validate()
+	assert.Contains(t, problems, "struct-tags[0].casing")
+	assert.Contains(t, problems, "struct-tags[1]")
+	assert.Contains(t, problems, "struct-tags[2]")
+	assert.Contains(t, problems, "struct-tags[3]")
+
+	opts.StructTags = []StructTagProfile{{Name: "bson", Casing: string(StructTagCasingSnake)}}
+	assert.Nil(t, opts.Validate())
+}
+
+func TestGenerateExtraSerializers(t *testing.T) {
+	spec := `openapi: 3.0.3
+info:
+  title: Extra serializers
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [petName]
+      properties:
+        petName:
+          type: string
+        secret:
+          type: string
+          x-go-json-ignore: true
+    Labels:
+      type: object
+      properties:
+        id:
+          type: integer
+      additionalProperties:
+        type: string
+`
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+		},
+		OutputOptions: OutputOptions{
+			ExtraSerializers: []string{string(ExtraSerializerMsgpack), string(ExtraSerializerXML), string(ExtraSerializerYAML)},
+		},
+	})
+	require.NoError(t, err)
+
+	t.Run("fields are tagged with their JSON names", func(t *testing.T) {
+		assert.Contains(t, code, "PetName string  `json:\"petName\" msgpack:\"petName\" xml:\"petName\" yaml:\"petName\"`")
+		assert.Contains(t, code, "Secret  *string `json:\"-\" msgpack:\"-\" xml:\"-\" yaml:\"-\"`")
+	})
+
+	t.Run("additional properties are only marshaled by the custom marshalling", func(t *testing.T) {
+		assert.Contains(t, code, "AdditionalProperties map[string]string `json:\"-\" yaml:\"-\" xml:\"-\" msgpack:\"-\"`")
+	})
+
+	t.Run("types with custom JSON marshalling are marshaled as YAML through JSON", func(t *testing.T) {
+		assert.Contains(t, code, "func (t Labels) MarshalYAML() (interface{}, error) {")
+		assert.Contains(t, code, "func (t *Labels) UnmarshalYAML(unmarshal func(interface{}) error) error {")
+		assert.NotContains(t, code, "func (t Pet) MarshalYAML()")
+	})
+}
+
+func TestExtraSerializersValidation(t *testing.T) {
+	opts := OutputOptions{
+		ExtraSerializers: []string{"yaml", "toml", "yaml"},
+	}
+	problems := opts.Validate()
+	assert.NotContains(t, problems, "extra-serializers[0]")
+	assert.Contains(t, problems, "extra-serializers[1]")
+	assert.Contains(t, problems, "extra-serializers[2]")
+
+	opts.ExtraSerializers = []string{"xml"}
+	assert.Nil(t, opts.Validate())
+}
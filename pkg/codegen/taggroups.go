@@ -0,0 +1,135 @@
+package codegen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ClientTagGroup is the operations which share a tag, whose methods are grouped together on the client with `client-tag-groups`
+type ClientTagGroup struct {
+	// Tag is the tag, as it's named in the spec
+	Tag string
+	// Name is the Go name of the tag, such as `Pets`, which names the client's method that returns the group
+	Name string
+	// TypeName is the name of the type which holds the group's methods, such as `PetsClient`
+	TypeName   string
+	Operations []ClientTagGroupOperation
+}
+
+// ClientTagGroupOperation is an operation in a ClientTagGroup
+type ClientTagGroupOperation struct {
+	OperationDefinition
+	// MethodName is the name of the operation's method within the group, which is the operation's name without the tag, such as `Find` for `FindPets` in the `Pets` group
+	MethodName string
+}
+
+// clientTagGroups groups the operations by their tags, in the order of the tags' names. Operations without a tag aren't grouped, and an operation with more than one tag is in each of their groups
+func clientTagGroups(ops []OperationDefinition) ([]ClientTagGroup, error) {
+	groups := make(map[string]*ClientTagGroup)
+	methods := make(map[string]bool)
+	for _, op := range ops {
+		methods[op.OperationId] = true
+		for _, tag := range op.Tags {
+			name := SchemaNameToTypeName(tag)
+			group, ok := groups[name]
+			if !ok {
+				group = &ClientTagGroup{Tag: tag, Name: name, TypeName: name + "Client"}
+				groups[name] = group
+			}
+			// Tags which only differ by case have the same name, so share a group
+			if len(group.Operations) > 0 && group.Operations[len(group.Operations)-1].OperationId == op.OperationId {
+				continue
+			}
+			group.Operations = append(group.Operations, ClientTagGroupOperation{OperationDefinition: op})
+		}
+	}
+
+	var result []ClientTagGroup
+	for _, name := range SortedMapKeys(groups) {
+		group := groups[name]
+		if methods[group.Name] {
+			return nil, fmt.Errorf("the method which returns the operations tagged %q would be named %s, which is the name of an operation's method. Please rename the tag, or disable `client-tag-groups`", group.Tag, group.Name)
+		}
+		if globalState.modelTypes[group.TypeName] {
+			return nil, fmt.Errorf("the type which holds the operations tagged %q would be named %s, which is the name of a model. Please rename the tag, or disable `client-tag-groups`", group.Tag, group.TypeName)
+		}
+		nameGroupMethods(group)
+		result = append(result, *group)
+	}
+	return result, nil
+}
+
+// tagComment returns a comment with the description and external docs of the tag, if it's declared in the spec's top-level `tags` with either, or otherwise an empty string. The comment starts with the prefix, if there is one
+func tagComment(name, prefix string) string {
+	if globalState.spec == nil {
+		return ""
+	}
+	tag := globalState.spec.Tag(name)
+	if tag == nil || (tag.Description == "" && (tag.ExternalDocs == nil || tag.ExternalDocs.URL == "")) {
+		return ""
+	}
+
+	text := strings.TrimSpace(tag.Description)
+	if prefix != "" {
+		text = strings.TrimSuffix(prefix+": "+text, ": ")
+	}
+	if docs := tag.ExternalDocs; docs != nil && docs.URL != "" {
+		link := "See " + docs.URL
+		if docs.Description != "" {
+			link = strings.TrimSpace(docs.Description) + ": " + docs.URL
+		}
+		text = strings.TrimPrefix(text+"\n"+link, "\n")
+	}
+	return StringToGoComment(text)
+}
+
+// nameGroupMethods names the methods of the operations in a group without the name of its tag, unless that would give two operations the same name, or not leave a name at all, in which case the operation's own name is used
+func nameGroupMethods(group *ClientTagGroup) {
+	for i, op := range group.Operations {
+		group.Operations[i].MethodName = withoutTagName(op.OperationId, group.Name)
+	}
+	for {
+		counts := make(map[string]int)
+		for _, op := range group.Operations {
+			counts[op.MethodName]++
+		}
+		collided := false
+		for i, op := range group.Operations {
+			if counts[op.MethodName] > 1 && op.MethodName != op.OperationId {
+				group.Operations[i].MethodName = op.OperationId
+				collided = true
+			}
+		}
+		if !collided {
+			break
+		}
+	}
+	sort.SliceStable(group.Operations, func(i, j int) bool {
+		return group.Operations[i].MethodName < group.Operations[j].MethodName
+	})
+}
+
+// withoutTagName removes the Go name of a tag, or its singular, from the name of an operation, such that `FindPets` and `FindPetByID` become `Find` and `FindByID` for the `Pets` tag. Only whole words are removed, so `Pet` isn't removed from `Petition`
+func withoutTagName(operationID, tagName string) string {
+	for _, name := range []string{tagName, strings.TrimSuffix(tagName, "s")} {
+		i := strings.Index(operationID, name)
+		if name == "" || i == -1 {
+			continue
+		}
+		end := i + len(name)
+		if end < len(operationID) {
+			next := rune(operationID[end])
+			if !unicode.IsUpper(next) && !unicode.IsDigit(next) && next != '_' {
+				continue
+			}
+		}
+		rest := operationID[:i] + operationID[end:]
+		if rest == "" || !unicode.IsLetter(rune(rest[0])) {
+			return operationID
+		}
+		return UppercaseFirstCharacter(rest)
+	}
+	return operationID
+}
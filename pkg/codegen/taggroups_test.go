@@ -0,0 +1,170 @@
+package codegen
+
+import (
+	"go/importer"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+const taggedSpec = `openapi: 3.0.0
+info:
+  title: Tagged
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: findPets
+      tags: [pets]
+      responses:
+        '200':
+          description: OK
+    post:
+      operationId: addPet
+      tags: [pets, admin]
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+      responses:
+        '201':
+          description: Created
+  /pets/{id}:
+    get:
+      operationId: findPetByID
+      tags: [pets]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+      responses:
+        '200':
+          description: OK
+  /health:
+    get:
+      operationId: health
+      responses:
+        '200':
+          description: OK
+`
+
+// taggedUsage calls the operations through their groups, so that type checking it alongside the generated code ensures the groups compile
+const taggedUsage = `package api
+
+import "context"
+
+func usePets(ctx context.Context, client *ClientWithResponses) error {
+	if _, err := client.Pets().Find(ctx); err != nil {
+		return err
+	}
+	if _, err := client.Pets().FindByID(ctx, 1); err != nil {
+		return err
+	}
+	_, err := client.Admin().AddPet(ctx, AddPetJSONRequestBody{})
+	return err
+}
+`
+
+func TestClientTagGroups(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(taggedSpec))
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+			Client: true,
+		},
+		OutputOptions: OutputOptions{
+			ClientTagGroups: true,
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, code, "func (c *ClientWithResponses) Pets() *PetsClient {")
+	assert.Contains(t, code, "func (g *PetsClient) Add(ctx context.Context, body AddPetJSONRequestBody, reqEditors ...RequestEditorFn) (*AddPetResponse, error) {")
+	// Operations without a tag aren't grouped
+	assert.NotContains(t, code, "HealthClient")
+
+	fset := token.NewFileSet()
+	typeCheck(t, fset, importer.ForCompiler(fset, "source", nil), code, taggedUsage)
+}
+
+func TestTagComments(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(taggedSpec + `tags:
+  - name: pets
+    description: Everything about your pets
+    externalDocs:
+      description: Find out more
+      url: https://example.com/pets
+  - name: admin
+    externalDocs:
+      url: https://example.com/admin
+`))
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:    true,
+			Client:    true,
+			ChiServer: true,
+		},
+		OutputOptions: OutputOptions{
+			ClientTagGroups: true,
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, code, "// PetsClient groups the operations tagged `pets`\n//\n// Everything about your pets\n// Find out more: https://example.com/pets\ntype PetsClient struct {")
+	assert.Contains(t, code, "// AdminClient groups the operations tagged `admin`\n//\n// See https://example.com/admin\ntype AdminClient struct {")
+	assert.Contains(t, code, "// (POST /pets)\n\t//\n\t// Tagged `pets`: Everything about your pets\n\t// Find out more: https://example.com/pets\n\t// Tagged `admin`\n\t// See https://example.com/admin\n\tAddPet(w http.ResponseWriter, r *http.Request)")
+	// Operations whose tags aren't described are commented as they were
+	assert.Contains(t, code, "// (GET /health)\n\tHealth(w http.ResponseWriter, r *http.Request)")
+}
+
+func TestWithoutTagName(t *testing.T) {
+	tests := []struct {
+		operationID string
+		tagName     string
+		expected    string
+	}{
+		{operationID: "FindPets", tagName: "Pets", expected: "Find"},
+		{operationID: "FindPetByID", tagName: "Pets", expected: "FindByID"},
+		{operationID: "PetsList", tagName: "Pets", expected: "List"},
+		{operationID: "CreatePetition", tagName: "Pets", expected: "CreatePetition"},
+		{operationID: "Pets", tagName: "Pets", expected: "Pets"},
+		{operationID: "AddPet", tagName: "Admin", expected: "AddPet"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.operationID, func(t *testing.T) {
+			assert.Equal(t, tt.expected, withoutTagName(tt.operationID, tt.tagName))
+		})
+	}
+}
+
+func TestNameGroupMethods(t *testing.T) {
+	group := &ClientTagGroup{
+		Name: "Pets",
+		Operations: []ClientTagGroupOperation{
+			{OperationDefinition: OperationDefinition{OperationId: "ListPets"}},
+			{OperationDefinition: OperationDefinition{OperationId: "List"}},
+			{OperationDefinition: OperationDefinition{OperationId: "DeletePet"}},
+		},
+	}
+	nameGroupMethods(group)
+
+	var names []string
+	for _, op := range group.Operations {
+		names = append(names, op.MethodName)
+	}
+	// `ListPets` would have the same name as `List`, so keeps its own
+	assert.Equal(t, []string{"Delete", "List", "ListPets"}, names)
+}
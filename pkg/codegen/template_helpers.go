@@ -139,7 +139,7 @@ func genResponseUnmarshal(op *OperationDefinition) string {
 		if len(responseRef.Value.Content) == 0 {
 			caseAction := "break // No content-type"
 			caseClauseKey := "case " + getConditionOfResponseName("rsp.StatusCode", typeDefinition.ResponseName) + ":"
-			unhandledCaseClauses[prefixLeastSpecific+caseClauseKey] = fmt.Sprintf("%s\n%s\n", caseClauseKey, caseAction)
+			unhandledCaseClauses[fmt.Sprintf("%s.%s.1.%s", prefixLeastSpecific, responseNameSortKey(typeDefinition.ResponseName), caseClauseKey)] = fmt.Sprintf("%s\n%s\n", caseClauseKey, caseAction)
 			continue
 		}
 
@@ -215,27 +215,53 @@ func genResponseUnmarshal(op *OperationDefinition) string {
 			default:
 				caseAction := fmt.Sprintf("// Content-type (%s) unsupported", contentTypeName)
 				caseClauseKey := "case " + getConditionOfResponseName("rsp.StatusCode", typeDefinition.ResponseName) + ":"
-				unhandledCaseClauses[prefixLeastSpecific+caseClauseKey] = fmt.Sprintf("%s\n%s\n", caseClauseKey, caseAction)
+				unhandledCaseClauses[fmt.Sprintf("%s.%s.1.%s", prefixLeastSpecific, responseNameSortKey(typeDefinition.ResponseName), caseClauseKey)] = fmt.Sprintf("%s\n%s\n", caseClauseKey, caseAction)
 			}
 		}
 	}
 
+	// A response without any content for a status code, such as a `404`,
+	// mustn't be captured by a range of status codes or `default` response
+	// which has content, so it's matched on its own
+	hasCatchAll := false
+	for _, typeDefinition := range typeDefinitions {
+		if responseNameSortKey(typeDefinition.ResponseName)[0] != '0' {
+			hasCatchAll = true
+		}
+	}
+	if hasCatchAll {
+		for responseName, responseRef := range responses.Map() {
+			if responseNameSortKey(responseName)[0] != '0' || responseRef.Value == nil || len(responseRef.Value.Content) != 0 {
+				continue
+			}
+			caseAction := "break // No content-type"
+			caseClauseKey := "case " + getConditionOfResponseName("rsp.StatusCode", responseName) + ":"
+			unhandledCaseClauses[fmt.Sprintf("%s.%s.1.%s", prefixLeastSpecific, responseNameSortKey(responseName), caseClauseKey)] = fmt.Sprintf("%s\n%s\n", caseClauseKey, caseAction)
+		}
+	}
+
 	if len(handledCaseClauses)+len(unhandledCaseClauses) == 0 {
 		// switch would be empty.
 		return ""
 	}
 
-	// Now build the switch statement in order of most-to-least specific:
+	// Now build the switch statement in order of most-to-least specific
+	// status code, so that a `2XX` or `default` response doesn't capture a
+	// response for a specific status code. For each status code, the handled
+	// content types come before the unhandled ones.
 	// See: https://github.com/oapi-codegen/oapi-codegen/issues/127 for why we handle this in two separate
 	// groups.
-	fmt.Fprintf(buffer, "switch {\n")
-	for _, caseClauseKey := range SortedMapKeys(handledCaseClauses) {
-
-		fmt.Fprintf(buffer, "%s\n", handledCaseClauses[caseClauseKey])
+	caseClauses := make(map[string]string, len(handledCaseClauses)+len(unhandledCaseClauses))
+	for caseClauseKey, caseClause := range handledCaseClauses {
+		caseClauses[caseClauseKey] = caseClause
+	}
+	for caseClauseKey, caseClause := range unhandledCaseClauses {
+		caseClauses[caseClauseKey] = caseClause
 	}
-	for _, caseClauseKey := range SortedMapKeys(unhandledCaseClauses) {
+	fmt.Fprintf(buffer, "switch {\n")
+	for _, caseClauseKey := range SortedMapKeys(caseClauses) {
 
-		fmt.Fprintf(buffer, "%s\n", unhandledCaseClauses[caseClauseKey])
+		fmt.Fprintf(buffer, "%s\n", caseClauses[caseClauseKey])
 	}
 	fmt.Fprintf(buffer, "}\n")
 
@@ -244,14 +270,14 @@ func genResponseUnmarshal(op *OperationDefinition) string {
 
 // buildUnmarshalCase builds an unmarshaling case clause for different content-types:
 func buildUnmarshalCase(typeDefinition ResponseTypeDefinition, caseAction string, contentType string) (caseKey string, caseClause string) {
-	caseKey = fmt.Sprintf("%s.%s.%s", prefixLeastSpecific, contentType, typeDefinition.ResponseName)
+	caseKey = fmt.Sprintf("%s.%s.0.%s", prefixLeastSpecific, responseNameSortKey(typeDefinition.ResponseName), contentType)
 	caseClauseKey := getConditionOfResponseName("rsp.StatusCode", typeDefinition.ResponseName)
 	caseClause = fmt.Sprintf("case strings.Contains(rsp.Header.Get(\"%s\"), \"%s\") && %s:\n%s\n", "Content-Type", contentType, caseClauseKey, caseAction)
 	return caseKey, caseClause
 }
 
 func buildUnmarshalCaseStrict(typeDefinition ResponseTypeDefinition, caseAction string, contentType string) (caseKey string, caseClause string) {
-	caseKey = fmt.Sprintf("%s.%s.%s", prefixLeastSpecific, contentType, typeDefinition.ResponseName)
+	caseKey = fmt.Sprintf("%s.%s.0.%s", prefixLeastSpecific, responseNameSortKey(typeDefinition.ResponseName), contentType)
 	caseClauseKey := getConditionOfResponseName("rsp.StatusCode", typeDefinition.ResponseName)
 	caseClause = fmt.Sprintf("case rsp.Header.Get(\"%s\") == \"%s\" && %s:\n%s\n", "Content-Type", contentType, caseClauseKey, caseAction)
 	return caseKey, caseClause
@@ -270,6 +296,21 @@ func getResponseTypeDefinitions(op *OperationDefinition) []ResponseTypeDefinitio
 	return td
 }
 
+// responseNameSortKey returns a key which sorts responses from the most to
+// the least specific, so that a response for a status code, such as `206`, is
+// matched before a range of status codes, such as `2XX`, which is matched
+// before the `default` response.
+func responseNameSortKey(responseName string) string {
+	switch responseName {
+	case "default":
+		return "2"
+	case "1XX", "2XX", "3XX", "4XX", "5XX":
+		return "1" + responseName
+	default:
+		return "0" + responseName
+	}
+}
+
 // Return the statusCode comparison clause from the response name.
 func getConditionOfResponseName(statusCodeVar, responseName string) string {
 	switch responseName {
@@ -330,6 +371,8 @@ var TemplateFunctions = template.FuncMap{
 	"swaggerUriToGinUri":         SwaggerUriToGinUri,
 	"swaggerUriToGorillaUri":     SwaggerUriToGorillaUri,
 	"swaggerUriToStdHttpUri":     SwaggerUriToStdHttpUri,
+	"registerWithBasePath":       registerWithBasePath,
+	"routeBasePath":              routeBasePath,
 	"lcFirst":                    LowercaseFirstCharacter,
 	"ucFirst":                    UppercaseFirstCharacter,
 	"ucFirstWithPkgName":         UppercaseFirstCharacterWithPkgName,
@@ -338,12 +381,17 @@ var TemplateFunctions = template.FuncMap{
 	"genResponseTypeName":        genResponseTypeName,
 	"genResponseUnmarshal":       genResponseUnmarshal,
 	"getResponseTypeDefinitions": getResponseTypeDefinitions,
+	"clientTagGroups":            clientTagGroups,
+	"hasBinaryContent":           hasBinaryContent,
+	"tagComment":                 tagComment,
 	"toStringArray":              toStringArray,
 	"lower":                      strings.ToLower,
 	"title":                      titleCaser.String,
 	"stripNewLines":              stripNewLines,
 	"sanitizeGoIdentity":         SanitizeGoIdentity,
 	"toGoComment":                StringWithTypeNameToGoComment,
+	"docsCell":                   docsCell,
+	"docsAnchor":                 docsAnchor,
 
 	"genServerURLWithVariablesFunctionParams": genServerURLWithVariablesFunctionParams,
 }
@@ -0,0 +1,160 @@
+package codegen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// testPackages are the packages which only the test helpers import, so that any declaration which refers to one of them is split out into the test file
+var testPackages = []string{"testing", "httptest"}
+
+// conformanceHelpers are the declarations which the conformance tests use, which don't refer to any of the testPackages themselves
+var conformanceHelpers = []string{"conformanceResponse", "conformanceContentTypes", "conformanceContentTypeMatches"}
+
+// generatesTestHelpers reports whether the generate targets include any test helpers, which import `testing`, and so are split out into a test file
+func (oo GenerateOptions) generatesTestHelpers() bool {
+	return oo.ConformanceTests || oo.Fuzz
+}
+
+// TestFileName returns the name of the `_test.go` file which the test helpers generated for outputFile are split out into, such as `api.gen_test.go` for `api.gen.go`
+func TestFileName(outputFile string) string {
+	return strings.TrimSuffix(outputFile, ".go") + "_test.go"
+}
+
+// IsTestFile returns true if code is a file which the test helpers were split out into from outputFile, so that it can be removed once they're no longer generated
+func IsTestFile(code, outputFile string) bool {
+	return strings.Contains(code, testFileMarker(filepath.Base(outputFile)))
+}
+
+func testFileMarker(mainFile string) string {
+	return fmt.Sprintf("// This file holds the test helpers split out of %s, as they import `testing`.", mainFile)
+}
+
+// isTestHelperDecl returns whether a declaration is one of the test helpers, which are the conformance tests and the `RunFuzz<Type>` functions
+func isTestHelperDecl(decl ast.Decl) bool {
+	var name string
+	switch decl := decl.(type) {
+	case *ast.FuncDecl:
+		name = decl.Name.Name
+	case *ast.GenDecl:
+		if decl.Tok == token.IMPORT {
+			return false
+		}
+		if decl.Tok == token.TYPE && len(decl.Specs) == 1 {
+			name = decl.Specs[0].(*ast.TypeSpec).Name.Name
+		}
+	}
+	if slices.Contains(conformanceHelpers, name) {
+		return true
+	}
+
+	found := false
+	ast.Inspect(decl, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if ident, ok := sel.X.(*ast.Ident); ok && slices.Contains(testPackages, ident.Name) {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// splitTestFile splits the test helpers out of code, which are generated with `conformance-tests` and `fuzz`, into a `_test.go` file, so that the package only imports `testing` and `net/http/httptest` in its tests. The rest of the code is kept in mainFile
+func splitTestFile(code, mainFile string, formatter Formatter) (map[string]string, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, mainFile, code, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing generated code: %w", err)
+	}
+	offset := func(pos token.Pos) int {
+		return fset.Position(pos).Offset
+	}
+
+	// The file header and build constraint, which come before the package's documentation, are kept in each file
+	preambleEnd := offset(file.Package)
+	if file.Doc != nil {
+		preambleEnd = offset(file.Doc.Pos())
+	}
+	preamble := code[:preambleEnd]
+	var generatedBy string
+	for _, line := range strings.Split(code[:offset(file.Package)], "\n") {
+		if strings.HasPrefix(line, "// Code generated ") {
+			generatedBy = line
+		}
+	}
+
+	type span struct {
+		start, end int
+	}
+	var imports, helpers []string
+	var removed []span
+	for _, decl := range file.Decls {
+		start := decl.Pos()
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			if decl.Doc != nil {
+				start = decl.Doc.Pos()
+			}
+			if decl.Tok == token.IMPORT {
+				imports = append(imports, code[offset(start):offset(decl.End())])
+			}
+		case *ast.FuncDecl:
+			if decl.Doc != nil {
+				start = decl.Doc.Pos()
+			}
+		}
+		if !isTestHelperDecl(decl) {
+			continue
+		}
+		s := span{start: strings.LastIndex(code[:offset(start)], "\n") + 1, end: offset(decl.End())}
+		helpers = append(helpers, code[s.start:s.end])
+		removed = append(removed, s)
+	}
+
+	if len(removed) == 0 {
+		return map[string]string{mainFile: code}, nil
+	}
+
+	// Formatting fixes the imports of each file, so that each only imports what it uses
+	if formatter != FormatterGofumpt {
+		formatter = FormatterGoimports
+	}
+
+	testFile := TestFileName(mainFile)
+	files := make(map[string]string, 2)
+
+	var sb strings.Builder
+	sb.WriteString(preamble)
+	if generatedBy != "" {
+		sb.WriteString(generatedBy + "\n")
+	}
+	sb.WriteString(testFileMarker(mainFile) + "\n\n")
+	sb.WriteString("package " + file.Name.Name + "\n\n")
+	sb.WriteString(strings.Join(imports, "\n") + "\n\n")
+	sb.WriteString(strings.Join(helpers, "\n\n") + "\n")
+	formatted, err := formatCode(sb.String(), file.Name.Name, formatter)
+	if err != nil {
+		return nil, fmt.Errorf("error formatting %s: %w", testFile, err)
+	}
+	files[testFile] = formatted
+
+	sort.Slice(removed, func(i, j int) bool {
+		return removed[i].start > removed[j].start
+	})
+	for _, s := range removed {
+		code = code[:s.start] + code[s.end:]
+	}
+	formatted, err = formatCode(code, file.Name.Name, formatter)
+	if err != nil {
+		return nil, fmt.Errorf("error formatting %s: %w", mainFile, err)
+	}
+	files[mainFile] = formatted
+	return files, nil
+}
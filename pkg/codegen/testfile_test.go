@@ -0,0 +1,155 @@
+package codegen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+const testFileSpec = `openapi: 3.0.0
+info:
+  title: Test helpers
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+`
+
+func TestSplitTestFile(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(testFileSpec))
+	require.NoError(t, err)
+
+	files, err := GenerateFiles(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:           true,
+			StdHTTPServer:    true,
+			ConformanceTests: true,
+			Fuzz:             true,
+		},
+	}, "gen/api.gen.go")
+	require.NoError(t, err)
+
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	require.ElementsMatch(t, []string{"api.gen.go", "api.gen_test.go"}, names)
+
+	main := files["api.gen.go"]
+	assert.Contains(t, main, "func RandomPet(r *rand.Rand, size int) Pet {")
+	assert.Contains(t, main, "func (Pet) Generate(r *rand.Rand, size int) reflect.Value {")
+	assert.NotContains(t, main, `"testing"`)
+	assert.NotContains(t, main, `"net/http/httptest"`)
+	assert.NotContains(t, main, "func RunFuzzPet(")
+	assert.NotContains(t, main, "func RunServerConformance(")
+	assert.NotContains(t, main, "conformanceResponse")
+
+	tests := files["api.gen_test.go"]
+	assert.Contains(t, tests, "// This file holds the test helpers split out of api.gen.go, as they import `testing`.\n")
+	assert.Contains(t, tests, "func RunFuzzPet(f *testing.F, target func(t *testing.T, v Pet)) {")
+	assert.Contains(t, tests, "func RunServerConformance(t *testing.T, handler http.Handler, editors ...func(req *http.Request)) {")
+	assert.Contains(t, tests, "func conformanceContentTypeMatches(")
+	assert.NotContains(t, tests, "func RandomPet(")
+	assert.True(t, IsTestFile(tests, "gen/api.gen.go"))
+	assert.False(t, IsTestFile(main, "gen/api.gen.go"))
+
+	fset := token.NewFileSet()
+	imp := importer.ForCompiler(fset, "source", nil)
+	check := func(files ...string) error {
+		var parsed []*ast.File
+		for i, src := range files {
+			file, err := parser.ParseFile(fset, fmt.Sprintf("file%d.go", i), src, 0)
+			require.NoError(t, err)
+			parsed = append(parsed, file)
+		}
+		conf := types.Config{Importer: imp}
+		_, err := conf.Check("api", fset, parsed, nil)
+		return err
+	}
+	require.NoError(t, check(main), "the package builds without its test helpers")
+	require.NoError(t, check(main, tests))
+}
+
+func TestTestFileName(t *testing.T) {
+	assert.Equal(t, "api.gen_test.go", TestFileName("api.gen.go"))
+	assert.Equal(t, "gen/server_test.go", TestFileName("gen/server.go"))
+}
+
+// TestSplitTestFileVets runs `go vet` on a package generated with its test helpers, which checks the signatures of the functions in its `_test.go` file, as `go test` does, as well as that it compiles
+func TestSplitTestFileVets(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping test that runs go vet")
+	}
+
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(testFileSpec))
+	require.NoError(t, err)
+
+	files, err := GenerateFiles(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:           true,
+			StdHTTPServer:    true,
+			ConformanceTests: true,
+			Fuzz:             true,
+		},
+	}, "api.gen.go")
+	require.NoError(t, err)
+
+	files["go.mod"] = "module example.com/api\n\ngo 1.24\n"
+	files["api_test.go"] = `package api
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestServer(t *testing.T) {
+	RunServerConformance(t, http.NotFoundHandler())
+}
+
+func FuzzPet(f *testing.F) {
+	RunFuzzPet(f, func(t *testing.T, v Pet) {})
+}
+`
+	dir := t.TempDir()
+	for name, code := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(code), 0o644))
+	}
+
+	cmd := exec.Command("go", "vet", "./...")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=-mod=mod")
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+}
@@ -0,0 +1,201 @@
+package codegen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+// GenerateFiles generates code for a spec, as Generate does, returning the generated files by their name. The code is output to a single file, with the base name of outputFile, unless `one-file-per-type` is set, in which case each of the models is split out into a file of its own, such as `pet.gen.go` for the `Pet` type, or `fail-on-deprecated-usage` is set, in which case the client's functions for deprecated operations are split out into a file which is excluded by the `no_deprecated` build tag. The test helpers generated with `conformance-tests` and `fuzz` are always split out into a `_test.go` file, such as `api.gen_test.go` for `api.gen.go`
+func GenerateFiles(spec *openapi.T, opts Configuration, outputFile string) (map[string]string, error) {
+	mainFile := filepath.Base(outputFile)
+
+	code, err := Generate(spec, opts)
+	if err != nil {
+		return nil, err
+	}
+	files := map[string]string{mainFile: code}
+
+	if opts.OutputOptions.FailOnDeprecatedUsage {
+		files, err = splitDeprecatedFiles(code, mainFile, Formatter(opts.OutputOptions.Formatter))
+		if err != nil {
+			return nil, fmt.Errorf("error splitting deprecated operations into files: %w", err)
+		}
+	}
+
+	if opts.Generate.generatesTestHelpers() {
+		testFiles, err := splitTestFile(files[mainFile], mainFile, Formatter(opts.OutputOptions.Formatter))
+		if err != nil {
+			return nil, fmt.Errorf("error splitting test helpers into a file: %w", err)
+		}
+		for name, code := range testFiles {
+			files[name] = code
+		}
+	}
+
+	if !opts.OutputOptions.OneFilePerType {
+		return files, nil
+	}
+
+	typeFiles, err := splitTypeFiles(files[mainFile], mainFile, globalState.modelTypes, Formatter(opts.OutputOptions.Formatter))
+	if err != nil {
+		return nil, fmt.Errorf("error splitting types into files: %w", err)
+	}
+	for name, code := range typeFiles {
+		files[name] = code
+	}
+	return files, nil
+}
+
+// IsTypeFile returns true if code is a file which a type was split out into from outputFile, with `one-file-per-type`, so that files for types which are no longer generated can be removed
+func IsTypeFile(code, outputFile string) bool {
+	return strings.Contains(code, typeFileMarker(filepath.Base(outputFile)))
+}
+
+func typeFileMarker(mainFile string) string {
+	return fmt.Sprintf("// This file holds types split out of %s, with `one-file-per-type`.", mainFile)
+}
+
+// recordModelTypes records the types generated for the models, including any types generated for their inline schemas, which are split into files of their own with `one-file-per-type`
+func recordModelTypes(typeDefs []TypeDefinition) {
+	for _, td := range typeDefs {
+		globalState.modelTypes[td.TypeName] = true
+		recordModelTypes(td.Schema.AdditionalTypes)
+	}
+}
+
+// typeFileName returns the name of the file a type is split out into, such as `pet_kind.gen.go` for `PetKind` or `Pet_Kind`
+func typeFileName(typeName string) string {
+	var sb strings.Builder
+	runes := []rune(typeName)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// A new word starts at an upper case letter, unless it's part of an initialism, such as the `ID` in `PetID`
+			if i > 0 && runes[i-1] != '_' && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				sb.WriteRune('_')
+			}
+			sb.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String() + ".gen.go"
+}
+
+// splitTypeFiles splits the declarations of each of the model types, along with their methods and constants, out of code into a file of their own. The rest of the code is kept in mainFile
+func splitTypeFiles(code, mainFile string, modelTypes map[string]bool, formatter Formatter) (map[string]string, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, mainFile, code, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing generated code: %w", err)
+	}
+	offset := func(pos token.Pos) int {
+		return fset.Position(pos).Offset
+	}
+
+	// The file header and build constraint, which come before the package's documentation, are kept in each file
+	preambleEnd := offset(file.Package)
+	if file.Doc != nil {
+		preambleEnd = offset(file.Doc.Pos())
+	}
+	preamble := code[:preambleEnd]
+	var generatedBy string
+	for _, line := range strings.Split(code[:offset(file.Package)], "\n") {
+		if strings.HasPrefix(line, "// Code generated ") {
+			generatedBy = line
+		}
+	}
+
+	type span struct {
+		start, end int
+	}
+	var imports []string
+	var removed []span
+	owned := make(map[string][]string)
+	for _, decl := range file.Decls {
+		start := decl.Pos()
+		owner := ""
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			if decl.Doc != nil {
+				start = decl.Doc.Pos()
+			}
+			switch decl.Tok {
+			case token.IMPORT:
+				imports = append(imports, code[offset(start):offset(decl.End())])
+			case token.TYPE:
+				owner = decl.Specs[0].(*ast.TypeSpec).Name.Name
+			case token.CONST:
+				// Enum values are declared with their type
+				if spec, ok := decl.Specs[0].(*ast.ValueSpec); ok {
+					if ident, ok := spec.Type.(*ast.Ident); ok {
+						owner = ident.Name
+					}
+				}
+			}
+		case *ast.FuncDecl:
+			if decl.Doc != nil {
+				start = decl.Doc.Pos()
+			}
+			if decl.Recv != nil && len(decl.Recv.List) > 0 {
+				owner = receiverTypeName(decl.Recv.List[0].Type)
+			}
+		}
+		if !modelTypes[owner] {
+			continue
+		}
+
+		name := typeFileName(owner)
+		if name == mainFile {
+			name = strings.TrimSuffix(name, ".gen.go") + "_type.gen.go"
+		}
+		// Start from the beginning of the line, to take any indentation along with the declaration
+		s := span{start: strings.LastIndex(code[:offset(start)], "\n") + 1, end: offset(decl.End())}
+		owned[name] = append(owned[name], code[s.start:s.end])
+		removed = append(removed, s)
+	}
+
+	// Formatting fixes the imports of each file, so that each only imports what it uses
+	if formatter != FormatterGofumpt {
+		formatter = FormatterGoimports
+	}
+
+	files := make(map[string]string, len(owned)+1)
+	for name, decls := range owned {
+		var sb strings.Builder
+		sb.WriteString(preamble)
+		if generatedBy != "" {
+			sb.WriteString(generatedBy + "\n")
+		}
+		sb.WriteString(typeFileMarker(mainFile) + "\n\n")
+		sb.WriteString("package " + file.Name.Name + "\n\n")
+		sb.WriteString(strings.Join(imports, "\n") + "\n\n")
+		sb.WriteString(strings.Join(decls, "\n\n") + "\n")
+
+		formatted, err := formatCode(sb.String(), file.Name.Name, formatter)
+		if err != nil {
+			return nil, fmt.Errorf("error formatting %s: %w", name, err)
+		}
+		files[name] = formatted
+	}
+
+	sort.Slice(removed, func(i, j int) bool {
+		return removed[i].start > removed[j].start
+	})
+	for _, s := range removed {
+		code = code[:s.start] + code[s.end:]
+	}
+	formatted, err := formatCode(code, file.Name.Name, formatter)
+	if err != nil {
+		return nil, fmt.Errorf("error formatting %s: %w", mainFile, err)
+	}
+	files[mainFile] = formatted
+	return files, nil
+}
@@ -0,0 +1,76 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+func TestGenerateFiles(t *testing.T) {
+	generate := func(oneFilePerType bool) map[string]string {
+		swagger, err := openapi.NewLoader().LoadFromData([]byte(manifestSpec))
+		require.NoError(t, err)
+
+		files, err := GenerateFiles(swagger, Configuration{
+			PackageName: "api",
+			Generate: GenerateOptions{
+				Models: true,
+				Client: true,
+			},
+			OutputOptions: OutputOptions{
+				OneFilePerType: oneFilePerType,
+			},
+		}, "gen/api.gen.go")
+		require.NoError(t, err)
+		return files
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		files := generate(false)
+		require.Len(t, files, 1)
+		assert.Contains(t, files["api.gen.go"], "type Pet struct")
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		files := generate(true)
+
+		var names []string
+		for name := range files {
+			names = append(names, name)
+		}
+		assert.ElementsMatch(t, []string{"api.gen.go", "pet.gen.go", "pet_kind.gen.go", "get_pet_params.gen.go"}, names)
+
+		pet := files["pet.gen.go"]
+		assert.Contains(t, pet, "type Pet struct")
+		assert.Contains(t, pet, "// This file holds types split out of api.gen.go, with `one-file-per-type`.\n\npackage api\n")
+		assert.NotContains(t, pet, "import", "only the imports the file uses are kept")
+		assert.True(t, IsTypeFile(pet, "gen/api.gen.go"))
+
+		kind := files["pet_kind.gen.go"]
+		assert.Contains(t, kind, "type PetKind string")
+		assert.Contains(t, kind, `Cat PetKind = "cat"`, "enum values are kept with their type")
+
+		main := files["api.gen.go"]
+		assert.Contains(t, main, "// Package api provides primitives")
+		assert.Contains(t, main, "type Client struct")
+		assert.NotContains(t, main, "type Pet struct")
+		assert.NotContains(t, main, "PetKind")
+		assert.False(t, IsTypeFile(main, "gen/api.gen.go"))
+	})
+}
+
+func TestTypeFileName(t *testing.T) {
+	for typeName, expected := range map[string]string{
+		"Pet":            "pet.gen.go",
+		"PetKind":        "pet_kind.gen.go",
+		"Pet_Kind":       "pet_kind.gen.go",
+		"PetID":          "pet_id.gen.go",
+		"HTTPError":      "http_error.gen.go",
+		"GetPets200JSON": "get_pets200_json.gen.go",
+	} {
+		assert.Equal(t, expected, typeFileName(typeName), typeName)
+	}
+}
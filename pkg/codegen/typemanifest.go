@@ -0,0 +1,219 @@
+package codegen
+
+import (
+	"encoding/json"
+	"go/parser"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+// TypeManifest describes the Go types generated for a spec, along with how they map to JSON, so that companion generators, such as of TypeScript clients or documentation, can follow the naming decisions made for the Go code. It's intended to be read by other tools, so is serialised as JSON
+type TypeManifest struct {
+	// Package is the Go package the types are generated in
+	Package string `json:"package"`
+	// Types are the generated types, by name
+	Types []TypeManifestType `json:"types"`
+}
+
+// TypeManifestType is a generated Go type
+type TypeManifestType struct {
+	Name string `json:"name"`
+	// Source is a JSON Pointer to the part of the spec the type was generated from, such as `#/components/schemas/Pet`, if known
+	Source string `json:"source,omitempty"`
+	// Kind is one of `struct`, `enum`, `union`, `alias`, for a type defined as an alias of another, such as `type Pets = []Pet`, or `type`, for any other type defined in terms of another, such as `type Name string`
+	Kind string `json:"kind"`
+	// GoType is the Go type the type is defined as, other than for a struct, such as `string` or `[]Pet`
+	GoType      string `json:"goType,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Fields are the fields of a struct, which each map to a property of the JSON object
+	Fields []TypeManifestField `json:"fields,omitempty"`
+	// Embeds are the types embedded in a struct, for an `allOf`, whose fields are part of the same JSON object
+	Embeds []string `json:"embeds,omitempty"`
+	// AdditionalProperties is the Go type of the values of the JSON object's additional properties, if it allows them
+	AdditionalProperties string `json:"additionalProperties,omitempty"`
+	// EnumValues are the constants generated for an enum
+	EnumValues []TypeManifestEnumValue `json:"enumValues,omitempty"`
+	// Variants are the Go types a union may hold
+	Variants []string `json:"variants,omitempty"`
+	// Discriminator is the JSON property which determines which of the variants a union holds, if it has one
+	Discriminator *TypeManifestDiscriminator `json:"discriminator,omitempty"`
+}
+
+// TypeManifestField is a field of a generated struct, and the property of the JSON object it maps to
+type TypeManifestField struct {
+	// Name is the name of the Go field
+	Name string `json:"name"`
+	// JSONName is the name of the JSON property
+	JSONName string `json:"jsonName"`
+	// GoType is the Go type of the field, including a pointer for an optional field
+	GoType      string `json:"goType"`
+	Required    bool   `json:"required"`
+	Nullable    bool   `json:"nullable"`
+	ReadOnly    bool   `json:"readOnly,omitempty"`
+	WriteOnly   bool   `json:"writeOnly,omitempty"`
+	Deprecated  bool   `json:"deprecated,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// TypeManifestEnumValue is a constant generated for one of the values of an enum
+type TypeManifestEnumValue struct {
+	// Name is the name of the Go constant
+	Name string `json:"name"`
+	// Value is the value of the enum, as it appears in JSON
+	Value interface{} `json:"value"`
+	// Description is the description of the value, from x-enum-descriptions
+	Description string `json:"description,omitempty"`
+}
+
+// TypeManifestDiscriminator is the JSON property which determines which of the variants a union holds
+type TypeManifestDiscriminator struct {
+	Property string `json:"property"`
+	// Mapping maps the values of the property to the Go type of the variant
+	Mapping map[string]string `json:"mapping,omitempty"`
+}
+
+// GenerateTypeManifest generates the code for a spec, as Generate does, but rather than returning the code, returns a TypeManifest of the generated types
+func GenerateTypeManifest(spec *openapi.T, opts Configuration) (*TypeManifest, error) {
+	manifest := &TypeManifest{
+		Package: opts.PackageName,
+		Types:   []TypeManifestType{},
+	}
+
+	globalState.typeManifest = manifest
+	defer func() {
+		globalState.typeManifest = nil
+	}()
+
+	if _, err := Generate(spec, opts); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(manifest.Types, func(i, j int) bool {
+		return manifest.Types[i].Name < manifest.Types[j].Name
+	})
+	return manifest, nil
+}
+
+// recordTypeManifestTypes records the types which are generated, when generating a TypeManifest
+func recordTypeManifestTypes(typeDefs []TypeDefinition) {
+	if globalState.typeManifest == nil {
+		return
+	}
+	seen := make(map[string]bool, len(globalState.typeManifest.Types))
+	for _, t := range globalState.typeManifest.Types {
+		seen[t.Name] = true
+	}
+	for _, td := range typeDefs {
+		if seen[td.TypeName] {
+			continue
+		}
+		seen[td.TypeName] = true
+		globalState.typeManifest.Types = append(globalState.typeManifest.Types, newTypeManifestType(td))
+	}
+}
+
+// recordTypeManifestEnums records the constants generated for each enum, when generating a TypeManifest
+func recordTypeManifestEnums(enums []EnumDefinition) {
+	if globalState.typeManifest == nil {
+		return
+	}
+	for _, enum := range enums {
+		values := enum.GetValues()
+		var constants []TypeManifestEnumValue
+		for _, name := range SortedMapKeys(values) {
+			constants = append(constants, TypeManifestEnumValue{
+				Name:        name,
+				Value:       typeManifestEnumValue(enum, values[name]),
+				Description: enum.ValueDescription(values[name]),
+			})
+		}
+		for i := range globalState.typeManifest.Types {
+			if globalState.typeManifest.Types[i].Name == enum.TypeName {
+				globalState.typeManifest.Types[i].EnumValues = constants
+			}
+		}
+	}
+}
+
+// newTypeManifestType describes a generated type
+func newTypeManifestType(td TypeDefinition) TypeManifestType {
+	s := td.Schema
+	t := TypeManifestType{
+		Name:        td.TypeName,
+		Source:      globalState.sources[td.TypeName].Pointer,
+		GoType:      typeManifestGoType(s.TypeDecl()),
+		Description: s.Description,
+	}
+
+	switch {
+	case len(s.EnumValues) > 0:
+		t.Kind = "enum"
+	case len(s.UnionElements) > 0:
+		t.Kind = "union"
+	case strings.HasPrefix(s.GoType, "struct"):
+		t.Kind = "struct"
+	case td.IsAlias():
+		t.Kind = "alias"
+	default:
+		t.Kind = "type"
+	}
+	if t.Kind == "union" || t.Kind == "struct" {
+		t.GoType = ""
+	}
+
+	for _, p := range s.Properties {
+		t.Fields = append(t.Fields, TypeManifestField{
+			Name:        p.GoFieldName(),
+			JSONName:    p.JsonFieldName,
+			GoType:      typeManifestGoType(p.GoTypeDef()),
+			Required:    p.Required,
+			Nullable:    p.Nullable,
+			ReadOnly:    p.ReadOnly,
+			WriteOnly:   p.WriteOnly,
+			Deprecated:  p.Deprecated,
+			Description: p.Description,
+		})
+	}
+	t.Embeds = s.EmbeddedTypes
+	if s.HasAdditionalProperties && strings.HasPrefix(s.GoType, "struct") {
+		t.AdditionalProperties = typeManifestGoType(mapValueSchema(s).TypeDecl())
+	}
+
+	for _, element := range s.UnionElements {
+		t.Variants = append(t.Variants, element.String())
+	}
+	if s.Discriminator != nil {
+		t.Discriminator = &TypeManifestDiscriminator{
+			Property: s.Discriminator.Property,
+			Mapping:  s.Discriminator.Mapping,
+		}
+	}
+	return t
+}
+
+// typeManifestEnumValue returns the value of an enum constant as it appears in JSON, which is a string for a string enum, or otherwise the number or boolean it's written as
+func typeManifestEnumValue(enum EnumDefinition, value string) interface{} {
+	if enum.ValueWrapper == `"` {
+		return value
+	}
+	if b, err := strconv.ParseBool(value); err == nil && enum.Schema.GoType == "bool" {
+		return b
+	}
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return json.Number(value)
+	}
+	return value
+}
+
+// typeManifestGoType returns the Go type on a single line, as an inline struct is declared over several
+func typeManifestGoType(goType string) string {
+	expr, err := parser.ParseExpr(goType)
+	if err != nil {
+		return strings.Join(strings.Fields(goType), " ")
+	}
+	return types.ExprString(expr)
+}
@@ -0,0 +1,96 @@
+package codegen
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+func TestGenerateTypeManifest(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(`openapi: 3.0.0
+info:
+  title: type-manifest
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: integer
+          format: int64
+        pet_name:
+          type: string
+          x-go-name: Nickname
+          nullable: true
+        status:
+          type: string
+          enum: [available, sold]
+    Priority:
+      type: integer
+      enum: [1, 2]
+    Animal:
+      oneOf:
+        - $ref: '#/components/schemas/Pet'
+        - $ref: '#/components/schemas/Priority'
+`))
+	require.NoError(t, err)
+
+	manifest, err := GenerateTypeManifest(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models: true,
+		},
+	})
+	require.NoError(t, err)
+
+	encoded, err := json.Marshal(manifest)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"package": "api",
+		"types": [
+			{
+				"name": "Animal",
+				"source": "#/components/schemas/Animal",
+				"kind": "union",
+				"variants": ["Pet", "Priority"]
+			},
+			{
+				"name": "Pet",
+				"source": "#/components/schemas/Pet",
+				"kind": "struct",
+				"fields": [
+					{"name": "Id", "jsonName": "id", "goType": "int64", "required": true, "nullable": false},
+					{"name": "Nickname", "jsonName": "pet_name", "goType": "*string", "required": false, "nullable": true},
+					{"name": "Status", "jsonName": "status", "goType": "*PetStatus", "required": false, "nullable": false}
+				]
+			},
+			{
+				"name": "PetStatus",
+				"source": "#/components/schemas/Pet",
+				"kind": "enum",
+				"goType": "string",
+				"enumValues": [
+					{"name": "Available", "value": "available"},
+					{"name": "Sold", "value": "sold"}
+				]
+			},
+			{
+				"name": "Priority",
+				"source": "#/components/schemas/Priority",
+				"kind": "enum",
+				"goType": "int",
+				"enumValues": [
+					{"name": "N1", "value": 1},
+					{"name": "N2", "value": 2}
+				]
+			}
+		]
+	}`, string(encoded))
+}
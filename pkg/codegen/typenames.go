@@ -0,0 +1,212 @@
+package codegen
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"strings"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+// TypeNameCollisionStrategy defines how two different types which would be generated with the same Go type name are handled
+type TypeNameCollisionStrategy string
+
+const (
+	// TypeNameCollisionStrategyUnset is the default case, where the `type-name-collisions` option hasn't been set.
+	//
+	// Type names are only checked for collisions once all the types have been generated, at which point a type which collides with an earlier one is renamed with a suffix describing it, such as `AccountTypeEnum`, or a number.
+	// NOTE that references to the renamed type aren't updated, so this may produce code that doesn't compile.
+	TypeNameCollisionStrategyUnset TypeNameCollisionStrategy = ""
+	// TypeNameCollisionStrategyError fails generation, reporting which parts of the spec would generate the same type name.
+	TypeNameCollisionStrategyError TypeNameCollisionStrategy = "error"
+	// TypeNameCollisionStrategyNumberedSuffix appends an increasing number to the name of each subsequent type which would collide.
+	//
+	// For instance, the schemas `account_type` and `AccountType` become:
+	//
+	// - `AccountType`
+	// - `AccountType2`
+	TypeNameCollisionStrategyNumberedSuffix TypeNameCollisionStrategy = "numbered-suffix"
+	// TypeNameCollisionStrategyPathPrefixed prefixes the name of a type which would collide with where it is defined, falling back to an increasing number if that also collides.
+	//
+	// For instance, a response called `Pet` which collides with the schema `Pet` becomes `ResponsePet`, and a type generated from an inline schema becomes i.e. `InlinePet_Status`.
+	TypeNameCollisionStrategyPathPrefixed TypeNameCollisionStrategy = "path-prefixed"
+)
+
+// componentTypeNamePrefixes are the prefixes used by `TypeNameCollisionStrategyPathPrefixed` for each section of `components`
+var componentTypeNamePrefixes = map[string]string{
+	"schemas":       "Schema",
+	"parameters":    "Parameter",
+	"responses":     "Response",
+	"requestBodies": "RequestBody",
+}
+
+// inlineTypeNamePrefix is the prefix used by `TypeNameCollisionStrategyPathPrefixed` for types generated from inline schemas
+const inlineTypeNamePrefix = "Inline"
+
+// typeNameRegistry keeps track of the Go type names which have been given out during generation, so that collisions can be resolved when a type is named, before anything refers to it.
+// A nil registry, used when no `TypeNameCollisionStrategy` is configured, leaves names as-is.
+type typeNameRegistry struct {
+	strategy TypeNameCollisionStrategy
+	// names maps the part of the spec a type is generated for to its type name
+	names map[string]string
+	// owners maps each type name to the part of the spec it was first given to
+	owners map[string]string
+}
+
+func newTypeNameRegistry(strategy TypeNameCollisionStrategy) *typeNameRegistry {
+	if strategy == TypeNameCollisionStrategyUnset {
+		return nil
+	}
+	return &typeNameRegistry{
+		strategy: strategy,
+		names:    make(map[string]string),
+		owners:   make(map[string]string),
+	}
+}
+
+// claim returns the type name to use for the type generated for owner, which would be named name, resolving any collision with a type generated for a different owner.
+// Names which were explicitly requested, i.e. with `x-go-type-name`, are never changed.
+// The same owner is always given the same name, so it is safe to claim a name again when regenerating a type.
+func (r *typeNameRegistry) claim(owner, name, prefix string, explicit bool) (string, error) {
+	if r == nil {
+		return name, nil
+	}
+	if claimed, ok := r.names[owner]; ok {
+		return claimed, nil
+	}
+
+	resolved := name
+	if other, taken := r.owners[name]; taken && !explicit {
+		switch r.strategy {
+		case TypeNameCollisionStrategyPathPrefixed:
+			resolved = r.numbered(prefix + name)
+		case TypeNameCollisionStrategyNumberedSuffix:
+			resolved = r.numbered(name)
+		default:
+			// Record the name anyway, so the collision is only reported once
+			r.names[owner] = name
+			return "", &typeNameCollisionError{TypeName: name, Owner: owner, Other: other}
+		}
+		logger().Debug("renamed colliding type", "type", name, "renamed", resolved, "first", other, "conflict", owner)
+		reportRename("type", owner, name, resolved, fmt.Sprintf("collides with the type generated for %s", other))
+	}
+
+	r.names[owner] = resolved
+	if _, taken := r.owners[resolved]; !taken {
+		r.owners[resolved] = owner
+	}
+	return resolved, nil
+}
+
+// typeNameCollisionError is returned by `TypeNameCollisionStrategyError` when two parts of the spec would generate the same type name
+type typeNameCollisionError struct {
+	TypeName string
+	Owner    string
+	Other    string
+}
+
+func (e *typeNameCollisionError) Error() string {
+	return fmt.Sprintf("type name %s for %s collides with the type generated for %s, use `x-go-type-name` to give one of them a different name", e.TypeName, e.Owner, e.Other)
+}
+
+// numbered returns name if it hasn't been taken, or otherwise name with the lowest number appended which hasn't been taken
+func (r *typeNameRegistry) numbered(name string) string {
+	candidate := name
+	for i := 2; ; i++ {
+		if _, taken := r.owners[candidate]; !taken {
+			return candidate
+		}
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+}
+
+// componentTypeName returns the type name to use for the component `#/components/<section>/<name>`, which would be named typeName
+func componentTypeName(section, name, typeName string, explicit bool) (string, error) {
+	return globalState.typeNames.claim("components/"+section+"/"+name, typeName, componentTypeNamePrefixes[section], explicit)
+}
+
+// inlineTypeName returns the type name to use for the type generated for the inline schema at path, which would be named typeName.
+// Unless a name has been explicitly chosen, it can be renamed with the `inline-type-names` option.
+func inlineTypeName(path []string, typeName string, explicit bool) (string, error) {
+	if renamed, ok := globalState.options.OutputOptions.InlineTypeNames[typeName]; ok && !explicit {
+		reportRename("type", strings.Join(path, "."), typeName, renamed, "renamed by inline-type-names")
+		typeName, explicit = renamed, true
+	}
+	return globalState.typeNames.claim(strings.Join(path, "."), typeName, inlineTypeNamePrefix, explicit)
+}
+
+// titleTypeName returns the type name to use for the inline schema at path from its `title`, followed by suffix, with `use-schema-titles`, along with whether it should be used.
+// It isn't used when the title doesn't give a valid Go identifier, or when the name is already given to a component schema, or to an inline schema elsewhere, in which case the type is named from its path as usual.
+func titleTypeName(schema *openapi.Schema, path []string, suffix string) (string, bool) {
+	if !globalState.options.OutputOptions.UseSchemaTitles || len(path) < 2 || schema.Schema == nil || strings.TrimSpace(schema.Title) == "" {
+		return "", false
+	}
+	owner := strings.Join(path, ".")
+	typeName := SchemaNameToTypeName(schema.Title) + suffix
+	if !token.IsIdentifier(typeName) {
+		logger().Debug("didn't name type from its title, as it isn't a valid identifier", "path", owner, "title", schema.Title, "type", typeName)
+		return "", false
+	}
+
+	if globalState.titleTypeNames == nil {
+		globalState.titleTypeNames = make(map[string]string)
+		if globalState.spec != nil && globalState.spec.Components != nil {
+			for name, schemaRef := range globalState.spec.Components.Schemas {
+				componentName := SchemaNameToTypeName(name)
+				if schemaRef.Value != nil {
+					if extension, ok := schemaRef.Value.Extensions[extGoName]; ok {
+						if goName, err := extTypeName(extension); err == nil {
+							componentName = goName
+						}
+					}
+				}
+				globalState.titleTypeNames[componentName] = "components/schemas/" + name
+			}
+		}
+	}
+	if other, taken := globalState.titleTypeNames[typeName]; taken && other != owner {
+		logger().Debug("didn't name type from its title, as the name is taken", "path", owner, "title", schema.Title, "type", typeName, "other", other)
+		return "", false
+	}
+	globalState.titleTypeNames[typeName] = owner
+	return typeName, true
+}
+
+// registerComponentTypeNames names the types for every component up front, so that components take precedence over types generated from inline schemas, and so that references to a component use the same name as its type.
+// Components are named in the order that their types are generated: schemas, parameters, responses, and then request bodies.
+//
+// Only collisions are reported here, as any other problem naming a component is reported when its type is generated.
+func registerComponentTypeNames(spec *openapi.T) error {
+	if globalState.typeNames == nil || spec.Components == nil {
+		return nil
+	}
+
+	var genErr Error
+	collision := func(artifact string, pos openapi.Position, err error) {
+		var collisionErr *typeNameCollisionError
+		if errors.As(err, &collisionErr) {
+			genErr.add(artifact, pos, err)
+		}
+	}
+
+	for _, name := range SortedSchemaKeys(spec.Components.Schemas) {
+		schemaRef := spec.Components.Schemas[name]
+		_, err := renameSchema(name, schemaRef)
+		collision("components/schemas/"+name, schemaRef.Position(), err)
+	}
+	for _, name := range SortedMapKeys(spec.Components.Parameters) {
+		paramRef := spec.Components.Parameters[name]
+		_, err := renameParameter(name, paramRef)
+		collision("components/parameters/"+name, paramRef.Value.Position(), err)
+	}
+	for _, name := range SortedMapKeys(spec.Components.Responses) {
+		_, err := renameResponse(name, spec.Components.Responses[name])
+		collision("components/responses/"+name, openapi.Position{}, err)
+	}
+	for _, name := range SortedMapKeys(spec.Components.RequestBodies) {
+		_, err := renameRequestBody(name, spec.Components.RequestBodies[name])
+		collision("components/requestBodies/"+name, openapi.Position{}, err)
+	}
+	return genErr.errOrNil()
+}
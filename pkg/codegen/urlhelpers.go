@@ -0,0 +1,36 @@
+package codegen
+
+import (
+	"text/template"
+)
+
+// URLHelpers is the data passed to the template which generates the `URLFor<Operation>` functions
+type URLHelpers struct {
+	Operations []OperationDefinition
+	// Styled is whether any of the path parameters are styled, so need the `styleURLPathParam` helper
+	Styled bool
+	// JSON is whether any of the path parameters are encoded as JSON, so need the `jsonURLPathParam` helper
+	JSON bool
+}
+
+// GenerateURLHelpers generates a `URLFor<Operation>` function for each operation, as configured with the `url-helpers` option, which returns the path of a request to the operation, with its path parameters styled and escaped as they are by the client
+func GenerateURLHelpers(t *template.Template, ops []OperationDefinition) (string, error) {
+	helpers := URLHelpers{Operations: ops}
+	for _, op := range ops {
+		for _, param := range op.PathParams {
+			switch {
+			case param.IsPassThrough():
+			case param.IsJson():
+				helpers.JSON = true
+			case param.IsStyled():
+				helpers.Styled = true
+			}
+		}
+	}
+	templates := []string{"url-helpers.tmpl"}
+	// The path parameters are escaped with the same `escapePathParam` helper as the client's, which is only generated here when the client isn't
+	if hasPathParams(ops) && !globalState.options.Generate.Client {
+		templates = append(templates, "path-params.tmpl")
+	}
+	return GenerateTemplates(templates, t, helpers)
+}
@@ -0,0 +1,142 @@
+package codegen
+
+import (
+	"go/importer"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const urlHelpersSpec = `openapi: 3.0.0
+info:
+  title: URL helpers
+  version: 1.0.0
+servers:
+  - url: https://api.example.com/v1
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '204':
+          description: OK
+  /pets/{id}:
+    get:
+      operationId: findPetByID
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+            format: int64
+      responses:
+        '204':
+          description: OK
+  /pets/{name}/tags/{tags}/{filter}:
+    get:
+      operationId: findTags
+      parameters:
+        - name: name
+          in: path
+          required: true
+          content:
+            text/plain:
+              schema:
+                type: string
+            text/csv:
+              schema:
+                type: string
+        - name: tags
+          in: path
+          required: true
+          style: label
+          explode: true
+          schema:
+            type: array
+            items:
+              type: string
+        - name: filter
+          in: path
+          required: true
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  kind:
+                    type: string
+      responses:
+        '204':
+          description: OK
+`
+
+func TestGenerateURLHelpers(t *testing.T) {
+	config := func(outputOptions OutputOptions) Configuration {
+		return Configuration{
+			PackageName: "api",
+			Generate: GenerateOptions{
+				Models:     true,
+				URLHelpers: true,
+			},
+			OutputOptions: outputOptions,
+		}
+	}
+
+	code, err := generateFromSpec(t, urlHelpersSpec, config(OutputOptions{}))
+	require.NoError(t, err)
+
+	t.Run("an operation without path parameters returns its path", func(t *testing.T) {
+		assert.Contains(t, code, "func URLForListPets() string {\n\treturn \"/pets\"\n}")
+	})
+
+	t.Run("path parameters are styled and escaped", func(t *testing.T) {
+		assert.Contains(t, code, "func URLForFindPetByID(id int64) string {")
+		assert.Contains(t, code, `return fmt.Sprintf("/pets/%s", styleURLPathParam("simple", false, "id", id, false))`)
+		assert.Contains(t, code, `fmt.Sprintf("/pets/%s/tags/%s/%s", escapePathParam(name, false), styleURLPathParam("label", true, "tags", tags, false), jsonURLPathParam(filter, false))`)
+		assert.Contains(t, code, "func styleURLPathParam(")
+		assert.Contains(t, code, "func jsonURLPathParam(")
+		assert.Contains(t, code, "func escapePathParam(")
+	})
+
+	t.Run("the escaping helpers are shared with the client", func(t *testing.T) {
+		code, err := generateFromSpec(t, urlHelpersSpec, Configuration{
+			PackageName: "api",
+			Generate: GenerateOptions{
+				Models:     true,
+				Client:     true,
+				URLHelpers: true,
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, strings.Count(code, "func escapePathParam("))
+	})
+
+	t.Run("the code type checks", func(t *testing.T) {
+		fset := token.NewFileSet()
+		typeCheck(t, fset, importer.ForCompiler(fset, "source", nil), code, `package api
+
+var _ string = URLForListPets()
+var _ string = URLForFindPetByID(1)
+`)
+	})
+
+	t.Run("paths include the server URL's path with base-path-strategy: keep", func(t *testing.T) {
+		code, err := generateFromSpec(t, urlHelpersSpec, config(OutputOptions{BasePathStrategy: string(BasePathStrategyKeep)}))
+		require.NoError(t, err)
+		assert.Contains(t, code, `return "/v1/pets"`)
+		assert.Contains(t, code, `return fmt.Sprintf("/v1/pets/%s", styleURLPathParam("simple", false, "id", id, false))`)
+	})
+
+	t.Run("nothing is generated unless configured", func(t *testing.T) {
+		code, err := generateFromSpec(t, urlHelpersSpec, Configuration{
+			PackageName: "api",
+			Generate:    GenerateOptions{Models: true},
+		})
+		require.NoError(t, err)
+		assert.NotContains(t, code, "URLFor")
+	})
+}
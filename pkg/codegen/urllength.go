@@ -0,0 +1,114 @@
+package codegen
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// formContentType is the content type of the body the query is sent in, to the fallback operation of an operation whose URL is too long
+const formContentType = "application/x-www-form-urlencoded"
+
+// URLLengthGuard describes what the client does when the URL of a request to an operation annotated with `x-max-url-length` is longer than its limit, which is to send the query in the body of a request to the fallback operation, if there is one, or else to return a `*URLTooLongError`, rather than send a request which the server rejects with a 414
+type URLLengthGuard struct {
+	// MaxLength is the longest URL, including the query, which is sent
+	MaxLength int
+	// Fallback is the `POST` operation which accepts the query as an `application/x-www-form-urlencoded` body, if any
+	Fallback *OperationDefinition
+	// FallbackPathParams are the indexes of the operation's path parameters which fill in each of the fallback operation's, in order
+	FallbackPathParams []int
+}
+
+// resolveURLLengthGuards sets the URLLengthGuard of each operation annotated with `x-max-url-length`, which must have query parameters, to the limit and the fallback operation it names, which must be a `POST`, accepting an `application/x-www-form-urlencoded` body, whose path parameters are all the operation's
+func resolveURLLengthGuards(ops []OperationDefinition) error {
+	var failures Error
+
+	for i := range ops {
+		op := &ops[i]
+		value, ok := op.Spec.Extensions[extMaxURLLength]
+		if !ok {
+			continue
+		}
+		artifact := op.Method + " " + op.Path
+
+		extension, err := extParseMaxURLLength(value)
+		if err != nil {
+			failures.add(artifact, op.Spec.Position(), fmt.Errorf("invalid value for %q: %w", extMaxURLLength, err))
+			continue
+		}
+		if len(op.QueryParams) == 0 {
+			failures.add(artifact, op.Spec.Position(), fmt.Errorf("%q requires query parameters, which make the URL longer", extMaxURLLength))
+			continue
+		}
+
+		guard := &URLLengthGuard{MaxLength: extension.MaxLength}
+		if extension.Fallback != "" {
+			fallback := findOperation(ops, extension.Fallback)
+			if fallback == nil {
+				failures.add(artifact, op.Spec.Position(), fmt.Errorf("%q names the fallback operation %q, which doesn't exist", extMaxURLLength, extension.Fallback))
+				continue
+			}
+			if err := checkURLLengthFallback(op, fallback); err != nil {
+				failures.add(artifact, op.Spec.Position(), fmt.Errorf("%q names the fallback operation %q, %w", extMaxURLLength, extension.Fallback, err))
+				continue
+			}
+			guard.Fallback = fallback
+			for _, param := range fallback.PathParams {
+				guard.FallbackPathParams = append(guard.FallbackPathParams, pathParamIndex(op.PathParams, param.ParamName))
+			}
+		}
+		op.URLLengthGuard = guard
+	}
+
+	return failures.errOrNil()
+}
+
+// checkURLLengthFallback returns why the fallback operation can't be sent the query of the operation in its body, if it can't
+func checkURLLengthFallback(op, fallback *OperationDefinition) error {
+	if op.HasBody() {
+		return fmt.Errorf("but the operation has a request body, which the query can't be sent in place of")
+	}
+	if fallback.Method != http.MethodPost {
+		return fmt.Errorf("which must be a POST, but is a %s", fallback.Method)
+	}
+	if !hasFormBody(fallback.Bodies) {
+		return fmt.Errorf("which must accept an %s request body, to send the query in", formContentType)
+	}
+	for _, param := range fallback.PathParams {
+		if pathParamIndex(op.PathParams, param.ParamName) < 0 {
+			return fmt.Errorf("which has the path parameter %q, which the operation doesn't", param.ParamName)
+		}
+	}
+	if required := requiredParams(fallback.Params()); len(required) != 0 {
+		return fmt.Errorf("which can't be sent the query as it requires the parameter %q", required[0].ParamName)
+	}
+	return nil
+}
+
+func hasFormBody(bodies []RequestBodyDefinition) bool {
+	for _, body := range bodies {
+		if strings.EqualFold(body.ContentType, formContentType) {
+			return true
+		}
+	}
+	return false
+}
+
+func pathParamIndex(params []ParameterDefinition, name string) int {
+	for i, param := range params {
+		if param.ParamName == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// hasURLTooLongErrors reports whether any of the operations is annotated with `x-max-url-length` without a fallback operation, for which the client gets the `URLTooLongError` type
+func hasURLTooLongErrors(ops []OperationDefinition) bool {
+	for _, op := range ops {
+		if op.URLLengthGuard != nil && op.URLLengthGuard.Fallback == nil {
+			return true
+		}
+	}
+	return false
+}
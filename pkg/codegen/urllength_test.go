@@ -0,0 +1,161 @@
+package codegen
+
+import (
+	"go/importer"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func urlLengthSpec(findLimit, listLimit string) string {
+	return `openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /stores/{store}/pets:
+    get:
+      operationId: findPets
+      x-max-url-length: ` + findLimit + `
+      parameters:
+        - name: store
+          in: path
+          required: true
+          schema:
+            type: string
+        - name: ids
+          in: query
+          schema:
+            type: array
+            items:
+              type: string
+      responses:
+        '200':
+          description: OK
+  /stores/{store}/pets/search:
+    post:
+      operationId: searchPets
+      parameters:
+        - name: store
+          in: path
+          required: true
+          schema:
+            type: string
+      requestBody:
+        content:
+          application/x-www-form-urlencoded:
+            schema:
+              type: object
+              properties:
+                ids:
+                  type: array
+                  items:
+                    type: string
+      responses:
+        '200':
+          description: OK
+  /pets:
+    get:
+      operationId: listPets
+      x-max-url-length: ` + listLimit + `
+      parameters:
+        - name: tags
+          in: query
+          schema:
+            type: array
+            items:
+              type: string
+      responses:
+        '200':
+          description: OK
+`
+}
+
+func TestGenerateURLLengthGuards(t *testing.T) {
+	opts := Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Client: true,
+			Models: true,
+		},
+	}
+
+	code, err := generateFromSpec(t, urlLengthSpec("{max-length: 4000, fallback: searchPets}", "2000"), opts)
+	require.NoError(t, err)
+
+	t.Run("a long query is sent to the fallback operation", func(t *testing.T) {
+		assert.Contains(t, code, "if urlLength := len(queryURL.String()); urlLength <= 4000 {")
+		assert.Contains(t, code, `fallbackPath := fmt.Sprintf("/stores/%s/pets/search", pathParam0)`)
+		assert.Contains(t, code, `req, err = http.NewRequest("POST", fallbackURL.String(), strings.NewReader(queryURL.RawQuery))`)
+	})
+
+	t.Run("a long query is an error without a fallback operation", func(t *testing.T) {
+		assert.Contains(t, code, "if urlLength := len(queryURL.String()); urlLength <= 2000 {")
+		assert.Contains(t, code, `return nil, &URLTooLongError{OperationID: "ListPets", Length: urlLength, MaxLength: 2000}`)
+		assert.Contains(t, code, "type URLTooLongError struct {")
+	})
+
+	t.Run("the code type checks", func(t *testing.T) {
+		fset := token.NewFileSet()
+		typeCheck(t, fset, importer.ForCompiler(fset, "source", nil), code, `package api
+
+import "errors"
+
+func tooLong(err error) bool {
+	var urlErr *URLTooLongError
+	return errors.As(err, &urlErr)
+}
+`)
+	})
+
+	t.Run("nothing is generated without x-max-url-length", func(t *testing.T) {
+		code, err := generateFromSpec(t, `openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - name: tags
+          in: query
+          schema:
+            type: array
+            items:
+              type: string
+      responses:
+        '200':
+          description: OK
+`, opts)
+		require.NoError(t, err)
+		assert.NotContains(t, code, "URLTooLongError")
+		assert.NotContains(t, code, "urlLength")
+	})
+
+	t.Run("the fallback operation must exist", func(t *testing.T) {
+		_, err := generateFromSpec(t, urlLengthSpec("{max-length: 4000, fallback: lookupPets}", "2000"), opts)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `"x-max-url-length" names the fallback operation "lookupPets", which doesn't exist`)
+	})
+
+	t.Run("the fallback operation must be a POST", func(t *testing.T) {
+		_, err := generateFromSpec(t, urlLengthSpec("2000", "{max-length: 4000, fallback: findPets}"), opts)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `"x-max-url-length" names the fallback operation "findPets", which must be a POST, but is a GET`)
+	})
+
+	t.Run("the fallback operation must have only the operation's path parameters", func(t *testing.T) {
+		_, err := generateFromSpec(t, urlLengthSpec("2000", "{max-length: 4000, fallback: searchPets}"), opts)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `"x-max-url-length" names the fallback operation "searchPets", which has the path parameter "store", which the operation doesn't`)
+	})
+
+	t.Run("the maximum length must be positive", func(t *testing.T) {
+		_, err := generateFromSpec(t, urlLengthSpec("{fallback: searchPets}", "2000"), opts)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid value for "x-max-url-length": the maximum length must be positive`)
+	})
+}
@@ -436,6 +436,16 @@ func schemaXOrder(v *openapi.SchemaRef) (int64, bool) {
 	return 0, false
 }
 
+// locationSuffix formats where an object is defined in the spec for use in an
+// error message, such as " (spec.yaml:214:9)", or returns an empty string if
+// the location isn't known
+func locationSuffix(pos openapi.Position) string {
+	if !pos.IsValid() {
+		return ""
+	}
+	return " (" + pos.String() + ")"
+}
+
 // StringInArray checks whether the specified string is present in an array
 // of strings
 func StringInArray(str string, array []string) bool {
@@ -705,6 +715,19 @@ func SortParamsByPath(path string, in []ParameterDefinition) ([]ParameterDefinit
 	return out, nil
 }
 
+// checkParamGoNames returns an error if two of the given parameters would have the same name in Go, as returned by goName, which the generated code wouldn't compile with
+func checkParamGoNames(params []ParameterDefinition, goName func(ParameterDefinition) string) error {
+	seen := make(map[string]string, len(params))
+	for _, param := range params {
+		name := goName(param)
+		if other, ok := seen[name]; ok {
+			return fmt.Errorf("parameters '%s' and '%s' would both be named %s in Go, so one of them needs renaming with %q", other, param.ParamName, name, extGoName)
+		}
+		seen[name] = param.ParamName
+	}
+	return nil
+}
+
 // IsGoKeyword returns whether the given string is a go keyword
 func IsGoKeyword(str string) bool {
 	return token.IsKeyword(str)
@@ -777,8 +800,71 @@ func SanitizeGoIdentity(str string) string {
 	return str
 }
 
+// enumSymbolNames are the words which spell out the symbols in an enum value, when it's transliterated into the name of its constant
+var enumSymbolNames = map[rune]string{
+	'!':  "Not",
+	'"':  "Quote",
+	'#':  "Hash",
+	'$':  "DollarSign",
+	'%':  "Percent",
+	'&':  "And",
+	'\'': "Apostrophe",
+	'(':  "LeftParenthesis",
+	')':  "RightParenthesis",
+	'*':  "Asterisk",
+	'+':  "Plus",
+	',':  "Comma",
+	'-':  "Minus",
+	'.':  "Dot",
+	'/':  "Slash",
+	':':  "Colon",
+	';':  "Semicolon",
+	'<':  "LessThan",
+	'=':  "Equal",
+	'>':  "GreaterThan",
+	'?':  "QuestionMark",
+	'@':  "At",
+	'[':  "LeftBracket",
+	'\\': "Backslash",
+	']':  "RightBracket",
+	'^':  "Caret",
+	'_':  "Underscore",
+	'`':  "Backtick",
+	'{':  "LeftBrace",
+	'|':  "Or",
+	'}':  "RightBrace",
+	'~':  "Tilde",
+	' ':  "Space",
+}
+
+// TransliterateEnumValue spells out each of the symbols of an enum value, such as `>=10` as `GreaterThanEqual10`, for the name of its constant, when the value's name would otherwise be empty, or the same as that of another of the enum's values. A symbol without a name, or a letter which isn't upper or lower case, such as an emoji or a CJK character, is spelled out as `U` and its Unicode code point in hexadecimal, such as `U1F436`
+func TransliterateEnumValue(value string) string {
+	var words []string
+	word := ""
+	for _, r := range value {
+		if unicode.IsUpper(r) || unicode.IsLower(r) || unicode.IsDigit(r) {
+			word += string(r)
+			continue
+		}
+		if word != "" {
+			words = append(words, word)
+			word = ""
+		}
+		if name, ok := enumSymbolNames[r]; ok {
+			words = append(words, name)
+		} else {
+			words = append(words, fmt.Sprintf("U%04X", r))
+		}
+	}
+	if word != "" {
+		words = append(words, word)
+	}
+	return SchemaNameToTypeName(strings.Join(words, "_"))
+}
+
 // SanitizeEnumNames fixes illegal chars in the enum names
-// and removes duplicates
+// and removes duplicates.
+// The name of a value is its name from enumNames, or otherwise the value itself, converted to a Go identifier, as with SchemaNameToTypeName. A value without a name of its own, whose converted name is empty, or the same as that of another value, is named with TransliterateEnumValue instead, and any names which are still the same have a number appended, from 1, skipping any which are already taken
 func SanitizeEnumNames(enumNames, enumValues []string) map[string]string {
 	dupCheck := make(map[string]int, len(enumValues))
 	deDup := make([][]string, 0, len(enumValues))
@@ -794,19 +880,39 @@ func SanitizeEnumNames(enumNames, enumValues []string) map[string]string {
 		dupCheck[n] = 0
 	}
 
-	dupCheck = make(map[string]int, len(deDup))
-	sanitizedDeDup := make(map[string]string, len(deDup))
-
-	for _, p := range deDup {
+	sanitizedNames := make([]string, len(deDup))
+	counts := make(map[string]int, len(deDup))
+	for i, p := range deDup {
+		sanitizedNames[i] = SanitizeGoIdentity(SchemaNameToTypeName(p[0]))
+		counts[sanitizedNames[i]]++
+	}
+	for i, p := range deDup {
 		n, v := p[0], p[1]
-		sanitized := SanitizeGoIdentity(SchemaNameToTypeName(n))
+		if n != v || v == "" {
+			continue
+		}
+		// A value made up of symbols, such as `!=`, is otherwise named by its prefix alone, if it has one
+		if nameNormalizer(v) == "" || counts[sanitizedNames[i]] > 1 {
+			sanitizedNames[i] = SanitizeGoIdentity(TransliterateEnumValue(v))
+		}
+	}
 
-		if _, dup := dupCheck[sanitized]; !dup {
-			sanitizedDeDup[sanitized] = v
-		} else {
-			sanitizedDeDup[sanitized+strconv.Itoa(dupCheck[sanitized])] = v
+	taken := make(map[string]bool, len(deDup))
+	for _, sanitized := range sanitizedNames {
+		taken[sanitized] = true
+	}
+	sanitizedDeDup := make(map[string]string, len(deDup))
+	for i, p := range deDup {
+		sanitized := sanitizedNames[i]
+		if _, dup := sanitizedDeDup[sanitized]; dup {
+			suffix := 1
+			for taken[sanitized+strconv.Itoa(suffix)] {
+				suffix++
+			}
+			sanitized += strconv.Itoa(suffix)
+			taken[sanitized] = true
 		}
-		dupCheck[sanitized]++
+		sanitizedDeDup[sanitized] = p[1]
 	}
 
 	return sanitizedDeDup
@@ -967,7 +1073,7 @@ func EscapePathElements(path string) string {
 func renameSchema(schemaName string, schemaRef *openapi.SchemaRef) (string, error) {
 	// References will not change type names.
 	if schemaRef.Ref != "" {
-		return SchemaNameToTypeName(schemaName), nil
+		return componentTypeName("schemas", schemaName, SchemaNameToTypeName(schemaName), false)
 	}
 	schema := schemaRef.Value
 
@@ -976,16 +1082,16 @@ func renameSchema(schemaName string, schemaRef *openapi.SchemaRef) (string, erro
 		if err != nil {
 			return "", fmt.Errorf("invalid value for %q: %w", extPropGoType, err)
 		}
-		return typeName, nil
+		return componentTypeName("schemas", schemaName, typeName, true)
 	}
-	return SchemaNameToTypeName(schemaName), nil
+	return componentTypeName("schemas", schemaName, SchemaNameToTypeName(schemaName), false)
 }
 
 // renameParameter generates the name for a parameter, taking x-go-name into
 // account
 func renameParameter(parameterName string, parameterRef *openapi.ParameterRef) (string, error) {
 	if parameterRef.Ref != "" {
-		return SchemaNameToTypeName(parameterName), nil
+		return componentTypeName("parameters", parameterName, SchemaNameToTypeName(parameterName), false)
 	}
 	parameter := parameterRef.Value
 
@@ -994,16 +1100,16 @@ func renameParameter(parameterName string, parameterRef *openapi.ParameterRef) (
 		if err != nil {
 			return "", fmt.Errorf("invalid value for %q: %w", extPropGoType, err)
 		}
-		return typeName, nil
+		return componentTypeName("parameters", parameterName, typeName, true)
 	}
-	return SchemaNameToTypeName(parameterName), nil
+	return componentTypeName("parameters", parameterName, SchemaNameToTypeName(parameterName), false)
 }
 
 // renameResponse generates the name for a parameter, taking x-go-name into
 // account
 func renameResponse(responseName string, responseRef *openapi.ResponseRef) (string, error) {
 	if responseRef.Ref != "" {
-		return SchemaNameToTypeName(responseName), nil
+		return componentTypeName("responses", responseName, SchemaNameToTypeName(responseName), false)
 	}
 	response := responseRef.Value
 
@@ -1012,16 +1118,16 @@ func renameResponse(responseName string, responseRef *openapi.ResponseRef) (stri
 		if err != nil {
 			return "", fmt.Errorf("invalid value for %q: %w", extPropGoType, err)
 		}
-		return typeName, nil
+		return componentTypeName("responses", responseName, typeName, true)
 	}
-	return SchemaNameToTypeName(responseName), nil
+	return componentTypeName("responses", responseName, SchemaNameToTypeName(responseName), false)
 }
 
 // renameRequestBody generates the name for a parameter, taking x-go-name into
 // account
 func renameRequestBody(requestBodyName string, requestBodyRef *openapi.RequestBodyRef) (string, error) {
 	if requestBodyRef.Ref != "" {
-		return SchemaNameToTypeName(requestBodyName), nil
+		return componentTypeName("requestBodies", requestBodyName, SchemaNameToTypeName(requestBodyName), false)
 	}
 	requestBody := requestBodyRef.Value
 
@@ -1030,9 +1136,9 @@ func renameRequestBody(requestBodyName string, requestBodyRef *openapi.RequestBo
 		if err != nil {
 			return "", fmt.Errorf("invalid value for %q: %w", extPropGoType, err)
 		}
-		return typeName, nil
+		return componentTypeName("requestBodies", requestBodyName, typeName, true)
 	}
-	return SchemaNameToTypeName(requestBodyName), nil
+	return componentTypeName("requestBodies", requestBodyName, SchemaNameToTypeName(requestBodyName), false)
 }
 
 // findSchemaByRefPath turns a $ref path into a schema. This will return ""
@@ -618,6 +618,60 @@ func TestSchemaNameToTypeName(t *testing.T) {
 	}
 }
 
+func TestTransliterateEnumValue(t *testing.T) {
+	t.Parallel()
+
+	for in, want := range map[string]string{
+		">=10":    "GreaterThanEqual10",
+		"-1.5":    "Minus1Dot5",
+		"!=":      "NotEqual",
+		"a b":     "ASpaceB",
+		"a/b":     "ASlashB",
+		"🐶":       "U1F436",
+		"中文":      "U4E2DU6587",
+		"Café au": "CaféSpaceAu",
+	} {
+		assert.Equal(t, want, TransliterateEnumValue(in), in)
+	}
+}
+
+func TestSanitizeEnumNames(t *testing.T) {
+	t.Parallel()
+
+	t.Run("values are named as they were, unless their names collide", func(t *testing.T) {
+		assert.Equal(t, map[string]string{
+			"Available":          "available",
+			"GreaterThanEqual10": ">=10",
+			"Minus1":             "-1",
+			"N1":                 "1",
+		}, SanitizeEnumNames(nil, []string{"available", ">=10", "-1", "1"}))
+	})
+
+	t.Run("colliding values are transliterated", func(t *testing.T) {
+		assert.Equal(t, map[string]string{
+			"AGreaterThanB": "a>b",
+			"ALessThanB":    "a<b",
+			"FooSpaceBar":   "Foo Bar",
+			"FooMinusBar":   "Foo-Bar",
+		}, SanitizeEnumNames(nil, []string{"a>b", "a<b", "Foo Bar", "Foo-Bar"}))
+	})
+
+	t.Run("values without a name are transliterated", func(t *testing.T) {
+		assert.Equal(t, map[string]string{
+			"NotEqual": "!=",
+			"U1F436":   "🐶",
+		}, SanitizeEnumNames(nil, []string{"!=", "🐶"}))
+	})
+
+	t.Run("colliding names are numbered, skipping names which are taken", func(t *testing.T) {
+		assert.Equal(t, map[string]string{
+			"Same":  "a",
+			"Same2": "b",
+			"Same1": "c",
+		}, SanitizeEnumNames([]string{"Same", "same", "Same1"}, []string{"a", "b", "c"}))
+	})
+}
+
 func TestTypeDefinitionsEquivalent(t *testing.T) {
 	def1 := TypeDefinition{TypeName: "name", Schema: Schema{
 		OAPISchema: &openapi.Schema{},
@@ -0,0 +1,482 @@
+package codegen
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// validateKind describes how a value is checked by the generated `Validate` methods
+type validateKind int
+
+const (
+	// validateKindNone is a value which has no constraints that are checked, such as a `bool` or `time.Time`
+	validateKindNone validateKind = iota
+	// validateKindMethods is a generated type, which has its own `Validate` method
+	validateKindMethods
+	// validateKindOpaque is a type we know nothing about, such as a type from another package, which is validated with its `Validate` method, if it has one
+	validateKindOpaque
+	validateKindString
+	validateKindNumber
+	validateKindSlice
+	validateKindMap
+	validateKindStruct
+	// validateKindNull is a `const: null`, which must be nil
+	validateKindNull
+)
+
+// numberGoTypes are the Go types generated for `integer` and `number` schemas
+var numberGoTypes = map[string]bool{
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+	"float32": true, "float64": true,
+}
+
+// ValidationPattern is a regular expression which is compiled once, into a variable, for the generated validation to match values against
+type ValidationPattern struct {
+	Name    string
+	Pattern string
+}
+
+// ValidateTypeDefinition is a type which has a `Validate` method generated for it
+type ValidateTypeDefinition struct {
+	TypeName string
+	// Validate is the body of the `Validate` method
+	Validate string
+	// Patterns are the regular expressions the `Validate` method uses
+	Patterns []ValidationPattern
+}
+
+// validationPath is the path to a value within the value being validated, such as `tags[%d].name`, which prefixes its errors
+type validationPath struct {
+	// format is the path as a format string, with a verb for each of args
+	format string
+	// plain is the path, when it doesn't have any args
+	plain string
+	args  []string
+}
+
+// field returns the path to the field of the value with the JSON name
+func (p validationPath) field(name string) validationPath {
+	if p.format != "" {
+		p.format += "."
+		p.plain += "."
+	}
+	p.format += strings.ReplaceAll(name, "%", "%%")
+	p.plain += name
+	return p
+}
+
+// index returns the path to the element of the value at the index or key arg, formatted with verb
+func (p validationPath) index(verb, arg string) validationPath {
+	p.format += "[" + verb + "]"
+	p.args = append(append([]string(nil), p.args...), arg)
+	return p
+}
+
+// errorf returns an expression for the error with message, prefixed with the path
+func (p validationPath) errorf(message string) string {
+	switch {
+	case p.format == "":
+		return fmt.Sprintf("errors.New(%q)", message)
+	case len(p.args) == 0:
+		return fmt.Sprintf("errors.New(%q)", p.plain+": "+message)
+	default:
+		return fmt.Sprintf("fmt.Errorf(%q, %s)", p.format+": "+strings.ReplaceAll(message, "%", "%%"), strings.Join(p.args, ", "))
+	}
+}
+
+// wrap returns an expression which prefixes the error err with the path
+func (p validationPath) wrap(err string) string {
+	if p.format == "" {
+		return err
+	}
+	args := append(append([]string(nil), p.args...), err)
+	return fmt.Sprintf("fmt.Errorf(%q, %s)", p.format+": %w", strings.Join(args, ", "))
+}
+
+// validationGenerator generates the statements which check values against the constraints of their schemas, by walking the schemas of each type
+type validationGenerator struct {
+	// methods are the types which get `Validate` methods
+	methods map[string]bool
+	// aliases are the schemas of the types which are defined as an alias, which don't get methods of their own
+	aliases map[string]Schema
+	// patternPrefix prefixes the names of the variables the patterns are compiled into
+	patternPrefix string
+	patterns      []ValidationPattern
+	// ret returns the statement which returns the error expression err from the generated code
+	ret func(err string) string
+}
+
+// GenerateValidateBoilerplate generates a `Validate` method, as configured with the `validation` option, for each of the types which isn't defined as an alias.
+func GenerateValidateBoilerplate(t *template.Template, typeDefs []TypeDefinition) (string, error) {
+	if !globalState.options.Generate.Validation {
+		return "", nil
+	}
+
+	methods := make(map[string]bool)
+	aliases := make(map[string]Schema)
+	var filteredTypes []TypeDefinition
+	for _, td := range typeDefs {
+		if methods[td.TypeName] {
+			continue
+		}
+		if _, found := aliases[td.TypeName]; found {
+			continue
+		}
+		if td.IsAlias() {
+			aliases[td.TypeName] = td.Schema
+			continue
+		}
+		methods[td.TypeName] = true
+		filteredTypes = append(filteredTypes, td)
+	}
+
+	var types []ValidateTypeDefinition
+	for _, td := range filteredTypes {
+		if slices.ContainsFunc(structFields(td.Schema), func(f copyField) bool { return f.Name == "Validate" }) {
+			return "", fmt.Errorf("%s has a field named Validate, which is the name of the generated method. Please rename the field with `x-go-name`, or disable `validation`", td.TypeName)
+		}
+
+		g := validationGenerator{
+			methods:       methods,
+			aliases:       aliases,
+			patternPrefix: "validate" + td.TypeName + "Pattern",
+		}
+		stmts, err := g.validateMethod(td)
+		if err != nil {
+			return "", fmt.Errorf("error generating Validate method for %s: %w", td.TypeName, err)
+		}
+		types = append(types, ValidateTypeDefinition{
+			TypeName: td.TypeName,
+			Validate: strings.Join(stmts, "\n"),
+			Patterns: g.patterns,
+		})
+	}
+
+	if len(types) == 0 {
+		return "", nil
+	}
+
+	context := struct {
+		Types []ValidateTypeDefinition
+	}{
+		Types: types,
+	}
+
+	return GenerateTemplates([]string{"validate.tmpl"}, t, context)
+}
+
+// RequestValidation is the validation of a request for an operation, by the client's `WithRequestValidation`
+type RequestValidation struct {
+	// Validate is the body of the function which validates the request
+	Validate string
+	// Patterns are the regular expressions the function uses
+	Patterns []ValidationPattern
+}
+
+// RequestValidation returns the validation of the parameters of a request for the operation, which returns a *ValidationError when they don't conform to the spec. The path parameters are checked against the constraints of their schemas in place, and the other parameters with their `Validate` method, if they have one
+func (o OperationDefinition) RequestValidation() (RequestValidation, error) {
+	g := validationGenerator{
+		patternPrefix: "validate" + o.OperationId + "RequestPattern",
+	}
+	var stmts []string
+	for _, param := range o.PathParams {
+		g.ret = func(err string) string {
+			return fmt.Sprintf("return &ValidationError{OperationID: %q, Field: %q, Err: %s}", o.OperationId, param.ParamName, err)
+		}
+		paramStmts, err := g.validateValue(param.GoVariableName(), validationPath{}, param.Schema, 0)
+		if err != nil {
+			return RequestValidation{}, fmt.Errorf("error generating validation of parameter %s: %w", param.ParamName, err)
+		}
+		stmts = append(stmts, paramStmts...)
+	}
+	if o.RequiresParamObject() {
+		stmts = append(stmts,
+			"if params != nil {",
+			fmt.Sprintf("if err := validateRequestValue(%q, \"params\", params); err != nil {", o.OperationId),
+			"return err",
+			"}",
+			"}",
+		)
+	}
+
+	return RequestValidation{
+		Validate: strings.Join(append(stmts, "return nil"), "\n"),
+		Patterns: g.patterns,
+	}, nil
+}
+
+// classify returns how a value of the schema's type is checked, along with the schema that describes it, following any aliases
+func (g *validationGenerator) classify(s Schema) (validateKind, Schema) {
+	seen := make(map[string]bool)
+	for {
+		name := s.TypeDecl()
+		if g.methods[name] {
+			return validateKindMethods, s
+		}
+		alias, found := g.aliases[name]
+		if !found || seen[name] {
+			break
+		}
+		seen[name] = true
+		s = alias
+	}
+
+	switch {
+	case s.RefType != "":
+		// A type which isn't generated here
+		return validateKindOpaque, s
+	case s.GoType == "*struct{}" && isConstNull(s.OAPISchema):
+		return validateKindNull, s
+	case s.GoType == "string":
+		return validateKindString, s
+	case numberGoTypes[s.GoType]:
+		return validateKindNumber, s
+	case s.ArrayType != nil && strings.HasPrefix(s.GoType, "[]"):
+		return validateKindSlice, s
+	case strings.HasPrefix(s.GoType, "map["):
+		return validateKindMap, s
+	case strings.HasPrefix(s.GoType, "struct"):
+		return validateKindStruct, s
+	default:
+		return validateKindNone, s
+	}
+}
+
+func (g *validationGenerator) validateMethod(td TypeDefinition) ([]string, error) {
+	kind, s := g.classify(td.Schema)
+	if kind == validateKindMethods {
+		underlying := s.TypeDecl()
+		if underlying == td.TypeName {
+			return []string{"return nil"}, nil
+		}
+		return []string{fmt.Sprintf("return %s(t).Validate()", underlying)}, nil
+	}
+	stmts, err := g.validateValue("t", validationPath{}, td.Schema, 0)
+	if err != nil {
+		return nil, err
+	}
+	return append(stmts, "return nil"), nil
+}
+
+// returnError returns the statement which returns the error expression err
+func (g *validationGenerator) returnError(err string) string {
+	if g.ret != nil {
+		return g.ret(err)
+	}
+	return "return " + err
+}
+
+// check returns the statements which return the error with message when cond holds
+func (g *validationGenerator) check(cond string, path validationPath, message string) []string {
+	return []string{
+		fmt.Sprintf("if %s {", cond),
+		g.returnError(path.errorf(message)),
+		"}",
+	}
+}
+
+// validateField returns the statements which check the field x, if it's set
+func (g *validationGenerator) validateField(x string, path validationPath, f copyField, depth int) ([]string, error) {
+	if f.Nullable == "" && !f.Pointer {
+		return g.validateValue(x, path, f.Schema, depth)
+	}
+
+	v := fmt.Sprintf("v%d", depth)
+	stmts, err := g.validateValue(v, path, f.Schema, depth+1)
+	if err != nil || len(stmts) == 0 {
+		return nil, err
+	}
+	if f.Nullable != "" {
+		stmts = append([]string{fmt.Sprintf("if %s, err := %s.Get(); err == nil {", v, x)}, stmts...)
+	} else {
+		stmts = append([]string{fmt.Sprintf("if %s != nil {", x), fmt.Sprintf("%s := *%s", v, x)}, stmts...)
+	}
+	return append(stmts, "}"), nil
+}
+
+// validateValue returns the statements which check x against the constraints of its schema
+func (g *validationGenerator) validateValue(x string, path validationPath, s Schema, depth int) ([]string, error) {
+	kind, s := g.classify(s)
+	switch kind {
+	case validateKindMethods:
+		return []string{
+			fmt.Sprintf("if err := %s.Validate(); err != nil {", operand(x)),
+			g.returnError(path.wrap("err")),
+			"}",
+		}, nil
+	case validateKindOpaque:
+		return []string{
+			fmt.Sprintf("if v, ok := any(%s).(interface{ Validate() error }); ok {", x),
+			"if err := v.Validate(); err != nil {",
+			g.returnError(path.wrap("err")),
+			"}",
+			"}",
+		}, nil
+	case validateKindString:
+		return g.validateString(x, path, s)
+	case validateKindNumber:
+		return g.validateNumber(x, path, s), nil
+	case validateKindNull:
+		return g.check(fmt.Sprintf("%s != nil", x), path, "must be null"), nil
+	case validateKindSlice:
+		var stmts []string
+		if o := s.OAPISchema; o != nil && o.Schema != nil {
+			if o.MinItems != nil && *o.MinItems > 0 {
+				stmts = append(stmts, g.check(fmt.Sprintf("len(%s) < %d", x, *o.MinItems), path, fmt.Sprintf("must have at least %d items", *o.MinItems))...)
+			}
+			if o.MaxItems != nil {
+				stmts = append(stmts, g.check(fmt.Sprintf("len(%s) > %d", x, *o.MaxItems), path, fmt.Sprintf("must have at most %d items", *o.MaxItems))...)
+			}
+		}
+		i := fmt.Sprintf("i%d", depth)
+		elemStmts, err := g.validateValue(operand(x)+"["+i+"]", path.index("%d", i), *s.ArrayType, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		if len(elemStmts) != 0 {
+			stmts = append(stmts, fmt.Sprintf("for %s := range %s {", i, x))
+			stmts = append(stmts, elemStmts...)
+			stmts = append(stmts, "}")
+		}
+		return stmts, nil
+	case validateKindMap:
+		k := fmt.Sprintf("k%d", depth)
+		v := fmt.Sprintf("v%d", depth)
+		valueStmts, err := g.validateValue(v, path.index("%q", k), mapValueSchema(s), depth+1)
+		if err != nil || len(valueStmts) == 0 {
+			return nil, err
+		}
+		stmts := []string{fmt.Sprintf("for %s, %s := range %s {", k, v, x)}
+		stmts = append(stmts, valueStmts...)
+		return append(stmts, "}"), nil
+	case validateKindStruct:
+		var stmts []string
+		for _, f := range structFields(s) {
+			// The errors of an embedded type, or the additional properties, aren't prefixed by the field's name
+			fieldPath := path
+			if name, ok := propertyJSONName(s, f.Name); ok {
+				fieldPath = path.field(name)
+			}
+			fieldStmts, err := g.validateField(operand(x)+"."+f.Name, fieldPath, f, depth)
+			if err != nil {
+				return nil, err
+			}
+			stmts = append(stmts, fieldStmts...)
+		}
+		return stmts, nil
+	default:
+		return nil, nil
+	}
+}
+
+// propertyJSONName returns the JSON name of the property which the field of the struct generated for the schema holds, if it holds one
+func propertyJSONName(s Schema, fieldName string) (string, bool) {
+	for _, p := range s.Properties {
+		if p.GoFieldName() == fieldName {
+			return p.JsonFieldName, true
+		}
+	}
+	return "", false
+}
+
+func (g *validationGenerator) validateString(x string, path validationPath, s Schema) ([]string, error) {
+	o := s.OAPISchema
+	if o == nil || o.Schema == nil {
+		return nil, nil
+	}
+
+	var stmts []string
+	if literal, ok := constLiteral(o, "string"); ok {
+		stmts = append(stmts, g.check(fmt.Sprintf("%s != %s", x, literal), path, "must be "+literal)...)
+	}
+	var values, conds []string
+	for _, value := range o.EnumStrings() {
+		literal := strconv.Quote(value)
+		if slices.Contains(values, literal) {
+			continue
+		}
+		values = append(values, literal)
+		conds = append(conds, fmt.Sprintf("%s != %s", x, literal))
+	}
+	if len(conds) != 0 {
+		stmts = append(stmts, g.check(strings.Join(conds, " && "), path, "must be one of "+strings.Join(values, ", "))...)
+	}
+	if o.MinLength != nil && *o.MinLength > 0 {
+		stmts = append(stmts, g.check(fmt.Sprintf("utf8.RuneCountInString(string(%s)) < %d", x, *o.MinLength), path, fmt.Sprintf("must be at least %d characters long", *o.MinLength))...)
+	}
+	if o.MaxLength != nil {
+		stmts = append(stmts, g.check(fmt.Sprintf("utf8.RuneCountInString(string(%s)) > %d", x, *o.MaxLength), path, fmt.Sprintf("must be at most %d characters long", *o.MaxLength))...)
+	}
+	if o.Pattern != "" {
+		if _, err := regexp.Compile(o.Pattern); err != nil {
+			return nil, fmt.Errorf("pattern %q isn't supported by Go's regexp package: %w", o.Pattern, err)
+		}
+		name := fmt.Sprintf("%s%d", g.patternPrefix, len(g.patterns))
+		g.patterns = append(g.patterns, ValidationPattern{Name: name, Pattern: o.Pattern})
+		stmts = append(stmts, g.check(fmt.Sprintf("!%s.MatchString(string(%s))", name, x), path, fmt.Sprintf("must match the pattern %q", o.Pattern))...)
+	}
+	return stmts, nil
+}
+
+func (g *validationGenerator) validateNumber(x string, path validationPath, s Schema) []string {
+	o := s.OAPISchema
+	if o == nil || o.Schema == nil {
+		return nil
+	}
+	// Every check converts the value to a float64, so that it can be compared with bounds which aren't integers
+	f := fmt.Sprintf("float64(%s)", x)
+	literal := func(v float64) string {
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+
+	var stmts []string
+	// The `const` is compared as the value's own type, rather than as a float64, so that it's equal to the value it's converted to
+	if constant, ok := constLiteral(o, s.GoType); ok {
+		stmts = append(stmts, g.check(fmt.Sprintf("%s != %s", x, constant), path, "must be "+constant)...)
+	}
+	var values, conds []string
+	for _, value := range o.Enum() {
+		var v float64
+		switch value := value.(type) {
+		case int:
+			v = float64(value)
+		case float64:
+			v = value
+		default:
+			continue
+		}
+		if slices.Contains(values, literal(v)) {
+			continue
+		}
+		values = append(values, literal(v))
+		conds = append(conds, fmt.Sprintf("%s != %s", f, literal(v)))
+	}
+	if len(conds) != 0 {
+		stmts = append(stmts, g.check(strings.Join(conds, " && "), path, "must be one of "+strings.Join(values, ", "))...)
+	}
+
+	bounds := o.Bounds()
+	if bounds.Minimum != nil {
+		if bounds.ExclusiveMinimum {
+			stmts = append(stmts, g.check(fmt.Sprintf("%s <= %s", f, literal(*bounds.Minimum)), path, "must be greater than "+literal(*bounds.Minimum))...)
+		} else {
+			stmts = append(stmts, g.check(fmt.Sprintf("%s < %s", f, literal(*bounds.Minimum)), path, "must be at least "+literal(*bounds.Minimum))...)
+		}
+	}
+	if bounds.Maximum != nil {
+		if bounds.ExclusiveMaximum {
+			stmts = append(stmts, g.check(fmt.Sprintf("%s >= %s", f, literal(*bounds.Maximum)), path, "must be less than "+literal(*bounds.Maximum))...)
+		} else {
+			stmts = append(stmts, g.check(fmt.Sprintf("%s > %s", f, literal(*bounds.Maximum)), path, "must be at most "+literal(*bounds.Maximum))...)
+		}
+	}
+	if o.MultipleOf != nil && *o.MultipleOf > 0 {
+		stmts = append(stmts, g.check(fmt.Sprintf("math.Mod(%s, %s) != 0", f, literal(*o.MultipleOf)), path, "must be a multiple of "+literal(*o.MultipleOf))...)
+	}
+	return stmts
+}
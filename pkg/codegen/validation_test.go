@@ -0,0 +1,315 @@
+package codegen
+
+import (
+	"go/importer"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+const validationSpec = `openapi: 3.0.0
+info:
+  title: validation
+  version: 1.0.0
+paths:
+  /pets/{petId}/{code}:
+    put:
+      operationId: updatePet
+      parameters:
+        - name: petId
+          in: path
+          required: true
+          schema:
+            type: integer
+            minimum: 1
+        - name: code
+          in: path
+          required: true
+          schema:
+            type: string
+            pattern: '^[a-z]+$'
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            maximum: 100
+            exclusiveMaximum: true
+        - name: kind
+          in: query
+          required: true
+          schema:
+            type: string
+            enum: [cat, dog]
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '204':
+          description: Updated
+components:
+  schemas:
+    Name:
+      type: string
+      minLength: 1
+    Pet:
+      type: object
+      required: [name, tags]
+      properties:
+        name:
+          $ref: '#/components/schemas/Name'
+        nickname:
+          type: string
+          maxLength: 10
+        age:
+          type: number
+          multipleOf: 0.5
+        tags:
+          type: array
+          minItems: 1
+          items:
+            type: string
+            pattern: '^[a-z]+$'
+        owner:
+          $ref: '#/components/schemas/Owner'
+        labels:
+          type: object
+          additionalProperties:
+            type: string
+            maxLength: 3
+        status:
+          type: string
+          enum: [available, sold]
+    Owner:
+      type: object
+      properties:
+        email:
+          type: string
+          format: email
+        phones:
+          type: array
+          items:
+            type: object
+            properties:
+              number:
+                type: string
+                minLength: 3
+`
+
+// validationUsage validates a model, and sends a request with the client's request validation, so that type checking it alongside the generated code ensures the validation compiles
+const validationUsage = `package api
+
+import (
+	"context"
+	"errors"
+)
+
+func updatePet(ctx context.Context, pet Pet) (string, error) {
+	if err := pet.Validate(); err != nil {
+		return "", err
+	}
+	client, err := NewClient("https://example.com", WithRequestValidation())
+	if err != nil {
+		return "", err
+	}
+	_, err = client.UpdatePet(ctx, 1, "code", &UpdatePetParams{Kind: Cat}, pet)
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr.Field, nil
+	}
+	return "", err
+}
+`
+
+func TestValidation(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(validationSpec))
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:     true,
+			Client:     true,
+			Validation: true,
+		},
+	})
+	require.NoError(t, err)
+
+	t.Run("models", func(t *testing.T) {
+		// A property which references a type with its own Validate method
+		assert.Contains(t, code, "if err := v0.Validate(); err != nil {\n\t\t\treturn fmt.Errorf(\"owner: %w\", err)")
+		// A property whose type is an alias, which is checked against the constraints of the aliased schema
+		assert.Contains(t, code, "if utf8.RuneCountInString(string(t.Name)) < 1 {\n\t\treturn errors.New(\"name: must be at least 1 characters long\")")
+		// The elements of an array, within an array
+		assert.Contains(t, code, "return fmt.Errorf(\"phones[%d].number: must be at least 3 characters long\", i1)")
+		assert.Contains(t, code, "var validatePetPattern0 = regexp.MustCompile(\"^[a-z]+$\")")
+		assert.Contains(t, code, "return errors.New(\"age: must be a multiple of 0.5\")")
+		// A 3.0 `exclusiveMaximum`
+		assert.Contains(t, code, "if float64(v0) >= 100 {\n\t\t\treturn errors.New(\"limit: must be less than 100\")")
+		assert.Contains(t, code, "if t != \"cat\" && t != \"dog\" {")
+	})
+
+	t.Run("client", func(t *testing.T) {
+		assert.Contains(t, code, "func validateUpdatePetRequest(petId int, code string, params *UpdatePetParams) error {")
+		assert.Contains(t, code, "return &ValidationError{OperationID: \"UpdatePet\", Field: \"petId\", Err: errors.New(\"must be at least 1\")}")
+		assert.Contains(t, code, "if err := validateRequestValue(\"UpdatePet\", \"body\", body); err != nil {")
+	})
+
+	t.Run("compiles", func(t *testing.T) {
+		fset := token.NewFileSet()
+		typeCheck(t, fset, importer.ForCompiler(fset, "source", nil), code, validationUsage)
+	})
+}
+
+func TestValidationOption(t *testing.T) {
+	opts := GenerateOptions{Validation: true}
+	assert.Contains(t, opts.Validate(), "validation")
+
+	opts.Client = true
+	assert.Empty(t, opts.Validate())
+}
+
+func TestValidationValidateField(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(`openapi: 3.0.0
+info:
+  title: validation
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Settings:
+      type: object
+      properties:
+        validate:
+          type: boolean
+`))
+	require.NoError(t, err)
+
+	_, err = Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:     true,
+			Validation: true,
+		},
+	})
+	assert.ErrorContains(t, err, "Settings has a field named Validate")
+}
+
+func TestConstValues(t *testing.T) {
+	swagger, err := openapi.NewLoader().LoadFromData([]byte(`openapi: 3.1.0
+info:
+  title: const
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Settings:
+      type: object
+      properties:
+        version:
+          const: 42
+        ratio:
+          const: 3.14
+        enabled:
+          const: true
+        kind:
+          const: settings
+        scale:
+          type: number
+          const: 0.1
+        count:
+          type: integer
+          const: 1e3
+        mode:
+          type: integer
+          const: fast
+`))
+	require.NoError(t, err)
+
+	code, err := Generate(swagger, Configuration{
+		PackageName: "api",
+		Generate: GenerateOptions{
+			Models:     true,
+			Validation: true,
+			Fuzz:       true,
+		},
+	})
+	require.NoError(t, err)
+
+	t.Run("types", func(t *testing.T) {
+		assert.Contains(t, code, "Enabled *bool ")
+		assert.Contains(t, code, "Kind    *string ")
+		assert.Contains(t, code, "Ratio   *float64 ")
+		assert.Contains(t, code, "Version *int ")
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		assert.Contains(t, code, "if v0 != 42 {\n\t\t\treturn errors.New(\"version: must be 42\")")
+		assert.Contains(t, code, "if v0 != 3.14 {")
+		assert.Contains(t, code, "if v0 != \"settings\" {\n\t\t\treturn errors.New(\"kind: must be \\\"settings\\\"\")")
+		assert.Contains(t, code, "if v0 != 0.1 {", "the value is compared as a float32, rather than as a float64")
+		assert.Contains(t, code, "if v0 != 1000 {")
+		assert.NotContains(t, code, "fast", "a value which can't be the type isn't checked")
+	})
+
+	t.Run("fuzz", func(t *testing.T) {
+		assert.Contains(t, code, "v0 = 42\n")
+		assert.Contains(t, code, "v0 = true\n")
+		assert.Contains(t, code, "v0 = \"settings\"\n")
+	})
+
+	t.Run("compiles", func(t *testing.T) {
+		fset := token.NewFileSet()
+		typeCheck(t, fset, importer.ForCompiler(fset, "source", nil), code, "package api\n")
+	})
+}
+
+func TestValidationBounds(t *testing.T) {
+	generate := func(version, bounds string) string {
+		swagger, err := openapi.NewLoader().LoadFromData([]byte("openapi: " + version + `
+info:
+  title: bounds
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Page:
+      type: object
+      required: [limit]
+      properties:
+        limit:
+          type: integer
+` + bounds))
+		require.NoError(t, err)
+		code, err := Generate(swagger, Configuration{
+			PackageName: "api",
+			Generate: GenerateOptions{
+				Models:     true,
+				Validation: true,
+			},
+		})
+		require.NoError(t, err)
+		return code
+	}
+
+	v30 := generate("3.0.0", "          minimum: 0\n          exclusiveMinimum: true\n          maximum: 100\n          exclusiveMaximum: true\n")
+	v31 := generate("3.1.0", "          exclusiveMinimum: 0\n          exclusiveMaximum: 100\n")
+	assert.Contains(t, v30, "if float64(t.Limit) <= 0 {\n\t\treturn errors.New(\"limit: must be greater than 0\")")
+	assert.Contains(t, v30, "if float64(t.Limit) >= 100 {\n\t\treturn errors.New(\"limit: must be less than 100\")")
+	validate := func(code string) string {
+		return code[strings.Index(code, "func (t Page) Validate() error"):]
+	}
+	assert.Equal(t, validate(v30), validate(v31), "both forms of exclusive bounds are validated the same way")
+
+	// Only the stricter of a `minimum` and a 3.1 `exclusiveMinimum` is checked
+	stricter := generate("3.1.0", "          minimum: 5\n          exclusiveMinimum: 0\n")
+	assert.Contains(t, stricter, "return errors.New(\"limit: must be at least 5\")")
+	assert.NotContains(t, stricter, "must be greater than 0")
+}
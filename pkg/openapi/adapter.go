@@ -17,21 +17,28 @@
 package openapi
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"math"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pb33f/libopenapi"
 	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	libopenapijson "github.com/pb33f/libopenapi/json"
 	"github.com/pb33f/libopenapi/orderedmap"
 	"gopkg.in/yaml.v3"
 )
@@ -40,6 +47,9 @@ import (
 var globalComponentSchemas map[string]*base.Schema
 var globalComponentSchemaNames map[*base.Schema]string
 
+// globalLogger receives debug logs about reference restoration, and is set from the Loader which is currently wrapping a document
+var globalLogger = slog.New(&nullHandler{})
+
 // nullHandler is a slog handler that discards all log messages
 type nullHandler struct{}
 
@@ -52,6 +62,8 @@ func (h *nullHandler) WithGroup(name string) slog.Handler        { return h }
 type T struct {
 	*v3.Document
 	version           string
+	versionMajor      int
+	versionMinor      int
 	Paths             *Paths
 	Components        *Components
 	OpenAPI           string               // OpenAPI version string for compatibility
@@ -59,6 +71,19 @@ type T struct {
 	JSONSchemaDialect string               // OpenAPI 3.1 $schema support
 	Info              *Info                // Enhanced Info object with OpenAPI 3.1 features
 	Servers           []*Server            // Enhanced Server objects with OpenAPI 3.1 features
+	Tags              []*Tag               // The top-level tags, which describe the operations tagged with them
+
+	loadResult LoadResult
+	// loader and basePath are what the document was loaded with, so that derived documents can be loaded the same way
+	loader   *Loader
+	basePath string
+}
+
+// LoadResult holds the diagnostics which were collected while loading a document
+type LoadResult struct {
+	// Warnings contains the non-fatal problems libopenapi reported when building the document model, such as circular references it was unable to resolve.
+	// These are returned as an error instead when `Loader.StrictMode` is set
+	Warnings []error
 }
 
 // Schema provides compatibility wrapper for OpenAPI schemas
@@ -76,12 +101,17 @@ type Schema struct {
 	AnyOf []*SchemaRef
 	OneOf []*SchemaRef
 
+	// Default is the schema's `default`, decoded as DecodeValue does
+	Default interface{}
+
 	// JSON Schema Draft 2020-12 keywords
+	// Const is the schema's `const`, decoded as DecodeValue does
 	Const                 interface{}
 	If                    *SchemaRef
 	Then                  *SchemaRef
 	Else                  *SchemaRef
 	PatternProperties     map[string]*SchemaRef
+	PropertyNames         *SchemaRef
 	UnevaluatedItems      *SchemaRef
 	UnevaluatedProperties *SchemaRef
 	Contains              *SchemaRef
@@ -117,11 +147,174 @@ func (s *Schema) Enum() []interface{} {
 	return result
 }
 
+// EnumRaw returns each of the enum values as it's written in the spec, in the same order as Enum, so that values such as `1.0`, or integers too large for an int64, are kept as they are, rather than being decoded. Null values are returned as `null`, and values which aren't scalars are returned as JSON
+func (s *Schema) EnumRaw() []string {
+	if s == nil || s.Schema == nil || s.Schema.Enum == nil {
+		return nil
+	}
+
+	result := make([]string, len(s.Schema.Enum))
+	for i, node := range s.Schema.Enum {
+		switch {
+		case node == nil || isNullNode(node):
+			result[i] = "null"
+		case node.Kind == yaml.ScalarNode:
+			result[i] = node.Value
+		default:
+			var value interface{}
+			if err := node.Decode(&value); err == nil {
+				if data, err := json.Marshal(value); err == nil {
+					result[i] = string(data)
+				}
+			}
+		}
+	}
+	return result
+}
+
+// EnumStrings returns the enum values which are scalars, as they're written in the spec, skipping nulls, so that a value such as `1.0` in an enum of strings is kept as `1.0`
+func (s *Schema) EnumStrings() []string {
+	if s == nil || s.Schema == nil {
+		return nil
+	}
+
+	var result []string
+	for _, node := range s.Schema.Enum {
+		if node == nil || node.Kind != yaml.ScalarNode || isNullNode(node) {
+			continue
+		}
+		result = append(result, node.Value)
+	}
+	return result
+}
+
+// EnumInts returns the enum values which are integers, including numbers such as `1e3` which have an integral value, skipping any others, along with those which don't fit in an int64
+func (s *Schema) EnumInts() []int64 {
+	if s == nil || s.Schema == nil {
+		return nil
+	}
+
+	var result []int64
+	for _, node := range s.Schema.Enum {
+		if node == nil || node.Kind != yaml.ScalarNode {
+			continue
+		}
+		switch node.ShortTag() {
+		case "!!int":
+			if i, err := strconv.ParseInt(node.Value, 0, 64); err == nil {
+				result = append(result, i)
+			}
+		case "!!float":
+			if f, err := strconv.ParseFloat(node.Value, 64); err == nil && f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 {
+				result = append(result, int64(f))
+			}
+		}
+	}
+	return result
+}
+
+// Bounds are the bounds of a number, either of which is nil if it isn't bounded, along with whether each of them is exclusive
+type Bounds struct {
+	Minimum          *float64
+	ExclusiveMinimum bool
+	Maximum          *float64
+	ExclusiveMaximum bool
+}
+
+// Bounds returns the schema's bounds, the same way for both forms of `exclusiveMinimum` and `exclusiveMaximum`: in OpenAPI 3.0 they're booleans which make `minimum` and `maximum` exclusive, whereas in 3.1 they're numbers, which are bounds of their own. If a schema has both a `minimum` and a numeric `exclusiveMinimum`, or likewise for the maximum, the stricter of them is the bound
+func (s *Schema) Bounds() Bounds {
+	var bounds Bounds
+	if s == nil || s.Schema == nil {
+		return bounds
+	}
+	bounds.Minimum, bounds.ExclusiveMinimum = normalizeBound(s.Schema.Minimum, s.Schema.ExclusiveMinimum, func(a, b float64) bool { return a > b })
+	bounds.Maximum, bounds.ExclusiveMaximum = normalizeBound(s.Schema.Maximum, s.Schema.ExclusiveMaximum, func(a, b float64) bool { return a < b })
+	return bounds
+}
+
+// normalizeBound returns the bound, and whether it's exclusive, from an inclusive bound and either form of the exclusive one, where stricter reports whether a bound is stricter than another
+func normalizeBound(bound *float64, exclusive *base.DynamicValue[bool, float64], stricter func(a, b float64) bool) (*float64, bool) {
+	if exclusive == nil {
+		return bound, false
+	}
+	if exclusive.IsA() {
+		return bound, bound != nil && exclusive.A
+	}
+	value := exclusive.B
+	if bound != nil && stricter(*bound, value) {
+		return bound, false
+	}
+	return &value, true
+}
+
+// DecodeValue decodes a value from the spec, such as a schema's `const` or `default`, keeping the type it's written with: integers are decoded as an int64, or a uint64 if they're too large for one, other numbers as a float64, booleans as a bool, strings as a string and nulls as nil. Objects and arrays are decoded as a map[string]interface{} and []interface{} of values decoded the same way
+func DecodeValue(node *yaml.Node) interface{} {
+	if node == nil {
+		return nil
+	}
+
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil
+		}
+		return DecodeValue(node.Content[0])
+	case yaml.AliasNode:
+		return DecodeValue(node.Alias)
+	case yaml.MappingNode:
+		result := make(map[string]interface{}, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			result[node.Content[i].Value] = DecodeValue(node.Content[i+1])
+		}
+		return result
+	case yaml.SequenceNode:
+		result := make([]interface{}, len(node.Content))
+		for i, item := range node.Content {
+			result[i] = DecodeValue(item)
+		}
+		return result
+	}
+
+	switch node.ShortTag() {
+	case "!!null":
+		return nil
+	case "!!bool":
+		var b bool
+		if err := node.Decode(&b); err == nil {
+			return b
+		}
+	case "!!int":
+		if i, err := strconv.ParseInt(node.Value, 0, 64); err == nil {
+			return i
+		}
+		if u, err := strconv.ParseUint(node.Value, 0, 64); err == nil {
+			return u
+		}
+		// Integers which don't fit in a uint64 can only be represented as a float64
+		if f, err := strconv.ParseFloat(node.Value, 64); err == nil {
+			return f
+		}
+	case "!!float":
+		var f float64
+		if err := node.Decode(&f); err == nil {
+			return f
+		}
+	}
+	return node.Value
+}
+
+// isNullNode returns whether a YAML node is a null, such as `null` or `~`
+func isNullNode(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.ShortTag() == "!!null"
+}
+
 // SchemaRef provides reference wrapper for schemas
 type SchemaRef struct {
 	Ref        string
 	Value      *Schema
 	Extensions map[string]interface{}
+	// Siblings holds the keywords alongside Ref, which override those of the referenced schema
+	Siblings *RefSiblings
 	// Additional fields for compatibility
 	Items                *SchemaRef
 	AdditionalProperties AdditionalPropertiesItem
@@ -202,6 +395,7 @@ type Operation struct {
 	RequestBody *RequestBodyRef
 	Callbacks   map[string]*CallbackRef
 	OperationID string // For compatibility with kin-openapi
+	Extensions  map[string]interface{}
 }
 
 // WrapOperation creates an Operation wrapper
@@ -219,6 +413,14 @@ func WrapOperation(operation *v3.Operation) *Operation {
 		wrapped.OperationID = operation.OperationId
 	}
 
+	// Convert Extensions from ordered map to regular map
+	if operation.Extensions != nil {
+		wrapped.Extensions = make(map[string]interface{})
+		for pair := operation.Extensions.First(); pair != nil; pair = pair.Next() {
+			wrapped.Extensions[pair.Key()] = pair.Value()
+		}
+	}
+
 	// Wrap responses
 	if operation.Responses != nil {
 		wrapped.Responses = &Responses{responses: operation.Responses}
@@ -244,23 +446,93 @@ func WrapOperation(operation *v3.Operation) *Operation {
 	return wrapped
 }
 
+// AddResponse adds the response for a status code to the operation, as Responses.Set does, creating the operation's responses if it has none
+func (o *Operation) AddResponse(code string, response *ResponseRef) {
+	if o == nil || o.Operation == nil {
+		return
+	}
+	if o.Operation.Responses == nil {
+		o.Operation.Responses = &v3.Responses{}
+	}
+	if o.Responses == nil || o.Responses.responses != o.Operation.Responses {
+		o.Responses = &Responses{responses: o.Operation.Responses}
+	}
+	o.Responses.Set(code, response)
+}
+
 // Responses represents OpenAPI responses
 type Responses struct {
 	responses *v3.Responses
 }
 
-// Map returns the responses as a map
+// Len returns the number of responses, including the default response
+func (r *Responses) Len() int {
+	if r == nil || r.responses == nil {
+		return 0
+	}
+	n := 0
+	if r.responses.Codes != nil {
+		n = r.responses.Codes.Len()
+	}
+	if r.responses.Default != nil {
+		n++
+	}
+	return n
+}
+
+// Set adds the response for a status code, which may be a range such as `2XX`, or `default` for the default response, replacing any response it already has. Setting a nil response removes it, as Delete does
+func (r *Responses) Set(code string, response *ResponseRef) {
+	if r == nil || r.responses == nil {
+		return
+	}
+	if response == nil || response.Value == nil || response.Value.Response == nil {
+		r.Delete(code)
+		return
+	}
+	if code == "default" {
+		r.responses.Default = response.Value.Response
+		return
+	}
+	if r.responses.Codes == nil {
+		r.responses.Codes = orderedmap.New[string, *v3.Response]()
+	}
+	r.responses.Codes.Set(code, response.Value.Response)
+}
+
+// Delete removes the response for a status code, or `default` for the default response
+func (r *Responses) Delete(code string) {
+	if r == nil || r.responses == nil {
+		return
+	}
+	if code == "default" {
+		r.responses.Default = nil
+		return
+	}
+	if r.responses.Codes != nil {
+		r.responses.Codes.Delete(code)
+	}
+}
+
+// Map returns the responses as a map, keyed by their status code, which may be a range such as `2XX`, or `default` for the default response
 func (r *Responses) Map() map[string]*ResponseRef {
-	if r == nil || r.responses == nil || r.responses.Codes == nil {
+	if r == nil || r.responses == nil || (r.responses.Codes == nil && r.responses.Default == nil) {
 		return nil
 	}
 
 	result := make(map[string]*ResponseRef)
-	for pair := r.responses.Codes.First(); pair != nil; pair = pair.Next() {
-		code := pair.Key()
-		response := pair.Value()
-		result[code] = &ResponseRef{
-			Value: WrapResponse(response),
+	if r.responses.Codes != nil {
+		for pair := r.responses.Codes.First(); pair != nil; pair = pair.Next() {
+			code := pair.Key()
+			response := pair.Value()
+			result[code] = &ResponseRef{
+				Value: WrapResponse(response),
+			}
+		}
+	}
+	// libopenapi holds the default response separately from those for a status code
+	if r.responses.Default != nil {
+		result["default"] = &ResponseRef{
+			Value: WrapResponse(r.responses.Default),
 		}
 	}
 	return result
@@ -268,7 +540,18 @@ func (r *Responses) Map() map[string]*ResponseRef {
 
 // Value returns a response by status code
 func (r *Responses) Value(code string) *ResponseRef {
-	if r.responses == nil || r.responses.Codes == nil {
+	if r.responses == nil {
+		return nil
+	}
+	if code == "default" {
+		if r.responses.Default == nil {
+			return nil
+		}
+		return &ResponseRef{
+			Value: WrapResponse(r.responses.Default),
+		}
+	}
+	if r.responses.Codes == nil {
 		return nil
 	}
 
@@ -559,6 +842,48 @@ func (d *Discriminator) MappingToMap() map[string]string {
 // Paths represents OpenAPI paths
 type Paths struct {
 	paths *v3.Paths
+	// document is the model the paths belong to, so that they can be created when a path is added to a document without any
+	document *v3.Document
+}
+
+// Len returns the number of paths
+func (p *Paths) Len() int {
+	if p == nil || p.paths == nil || p.paths.PathItems == nil {
+		return 0
+	}
+	return p.paths.PathItems.Len()
+}
+
+// Set adds a path, replacing the path item it already has, if any. Paths keep the order they're added in. Setting a nil path item removes it, as Delete does
+func (p *Paths) Set(path string, pathItem *PathItem) {
+	if p == nil {
+		return
+	}
+	if pathItem == nil || pathItem.PathItem == nil {
+		p.Delete(path)
+		return
+	}
+	if p.paths == nil {
+		if p.document == nil {
+			return
+		}
+		if p.document.Paths == nil {
+			p.document.Paths = &v3.Paths{}
+		}
+		p.paths = p.document.Paths
+	}
+	if p.paths.PathItems == nil {
+		p.paths.PathItems = orderedmap.New[string, *v3.PathItem]()
+	}
+	p.paths.PathItems.Set(path, pathItem.PathItem)
+}
+
+// Delete removes a path, along with each of its operations
+func (p *Paths) Delete(path string) {
+	if p == nil || p.paths == nil || p.paths.PathItems == nil {
+		return
+	}
+	p.paths.PathItems.Delete(path)
 }
 
 // Map returns the paths as a map
@@ -737,17 +1062,50 @@ func (pi *PathItem) GetOperation(method string) *Operation {
 type Loader struct {
 	IsExternalRefsAllowed bool
 	IgnoreMissingRefs     bool
+	// StrictMode fails the load if libopenapi reports any problems while building the document model, instead of recording them in the document's `LoadResult`.
+	// Problems are still tolerated when IgnoreMissingRefs is set
+	StrictMode bool
+	// Logger receives debug logs about how references are resolved, as well as any logs from libopenapi itself.
+	// If nil, nothing is logged
+	Logger *slog.Logger
+	// ConvertSwagger2 upgrades Swagger 2.0 documents to OpenAPI 3.0 before they are loaded, instead of rejecting them
+	ConvertSwagger2 bool
+	// Overlays are applied, in order, to each document before it's loaded, whether it's read from a file, a URI or data, so that relative references are still resolved from where the document is.
+	// They aren't applied to documents which were already parsed, as with LoadFromLibopenapi
+	Overlays []OverlaySource
+	// SimplifyCompositions unwraps a schema which is only an `allOf` of a single reference, or a `oneOf` of a single schema, into the schema it wraps, as such wrappers, which are often produced by other generators, would otherwise be generated as types of their own. It's set by NewLoader
+	SimplifyCompositions bool
+	// Timings receives how long each phase of loading a document takes, as it finishes: `load`, which reads the document, applies any Overlays, and parses it, followed by `resolve`, which builds its model, resolving its references. It's intended to guide optimising the loading of large documents.
+	// If nil, nothing is timed
+	Timings func(phase string, elapsed time.Duration)
 }
 
 // NewLoader creates a new OpenAPI document loader
 func NewLoader() *Loader {
 	return &Loader{
 		IsExternalRefsAllowed: true,
+		SimplifyCompositions:  true,
+	}
+}
+
+// logger returns the Loader's Logger, or a logger which discards everything if none was provided
+func (l *Loader) logger() *slog.Logger {
+	if l.Logger == nil {
+		return slog.New(&nullHandler{})
+	}
+	return l.Logger
+}
+
+// timePhase passes how long a phase of loading a document has taken, since start, to the Loader's Timings, if it has any
+func (l *Loader) timePhase(phase string, start time.Time) {
+	if l.Timings != nil {
+		l.Timings(phase, time.Since(start))
 	}
 }
 
 // LoadFromFile loads an OpenAPI document from a file
 func (l *Loader) LoadFromFile(filePath string) (*T, error) {
+	start := time.Now()
 	data, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
@@ -768,11 +1126,12 @@ func (l *Loader) LoadFromFile(filePath string) (*T, error) {
 		}
 	}
 
-	return l.LoadFromDataWithBasePath(data, basePath)
+	return l.loadFromData(data, basePath, filepath.Base(filePath), start)
 }
 
 // LoadFromURI loads an OpenAPI document from a URI
 func (l *Loader) LoadFromURI(uri *url.URL) (*T, error) {
+	start := time.Now()
 	// Use HTTP client to fetch the content
 	client := &http.Client{}
 	resp, err := client.Get(uri.String())
@@ -790,7 +1149,7 @@ func (l *Loader) LoadFromURI(uri *url.URL) (*T, error) {
 		return nil, fmt.Errorf("failed to read response body from %s: %w", uri.String(), err)
 	}
 
-	return l.LoadFromData(data)
+	return l.loadFromData(data, "", "", start)
 }
 
 // LoadFromData loads an OpenAPI document from byte data
@@ -800,6 +1159,26 @@ func (l *Loader) LoadFromData(data []byte) (*T, error) {
 
 // LoadFromDataWithBasePath loads an OpenAPI document from byte data with a base path for resolving references
 func (l *Loader) LoadFromDataWithBasePath(data []byte, basePath string) (*T, error) {
+	return l.loadFromData(data, basePath, "", time.Now())
+}
+
+// loadFromData loads an OpenAPI document from byte data, where specFile is the name of the file the data was read from within basePath, if any, and start is when the document started being read
+func (l *Loader) loadFromData(data []byte, basePath string, specFile string, start time.Time) (*T, error) {
+	// The overlays are written against the document as it is, so are applied before it's converted from Swagger 2.0
+	data, overlayWarnings, err := l.applyOverlays(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if l.ConvertSwagger2 && isSwagger2(data) {
+		var err error
+		data, err = convertSwagger2(data)
+		if err != nil {
+			return nil, err
+		}
+		l.logger().Debug("converted Swagger 2.0 document to OpenAPI 3.0")
+	}
+
 	// If IgnoreMissingRefs is enabled, preprocess the data to remove problematic example references
 	if l.IgnoreMissingRefs {
 		var err error
@@ -818,8 +1197,13 @@ func (l *Loader) LoadFromDataWithBasePath(data []byte, basePath string) (*T, err
 	// If IgnoreMissingRefs is enabled, configure a null logger to suppress file not found errors
 	if l.IgnoreMissingRefs {
 		config.Logger = slog.New(&nullHandler{})
+	} else if l.Logger != nil {
+		config.Logger = l.Logger
 	}
 
+	// Setting the file name means positions within the document refer to the actual file, rather than a placeholder
+	config.SpecFilePath = specFile
+
 	// Set base path for local file references
 	if basePath != "" {
 		// Convert to absolute path for better resolution
@@ -836,22 +1220,62 @@ func (l *Loader) LoadFromDataWithBasePath(data []byte, basePath string) (*T, err
 	if err != nil {
 		return nil, fmt.Errorf("failed to create document: %w", err)
 	}
+	l.timePhase("load", start)
+	doc, err := l.loadDocument(document, basePath)
+	if err != nil {
+		return nil, err
+	}
+	doc.loadResult.Warnings = append(overlayWarnings, doc.loadResult.Warnings...)
+	return doc, nil
+}
+
+// LoadFromLibopenapi loads a document which has already been parsed with libopenapi, such as by a linter or a registry, without serializing it back to bytes, so that any changes made to its model are kept. The document's own configuration is used to resolve its references, rather than the Loader's
+func (l *Loader) LoadFromLibopenapi(document libopenapi.Document) (*T, error) {
+	if document == nil {
+		return nil, errors.New("failed to load document: document is nil")
+	}
+	basePath := ""
+	if config := document.GetConfiguration(); config != nil {
+		basePath = config.BasePath
+	}
+	return l.loadDocument(document, basePath)
+}
+
+// FromLibopenapi wraps a document which has already been parsed with libopenapi, as a Loader created with NewLoader would with LoadFromLibopenapi
+func FromLibopenapi(document libopenapi.Document) (*T, error) {
+	return NewLoader().LoadFromLibopenapi(document)
+}
+
+// loadDocument builds the model of a libopenapi document, and wraps it, where basePath is the directory that its relative references are resolved from
+func (l *Loader) loadDocument(document libopenapi.Document, basePath string) (*T, error) {
+	start := time.Now()
+	if err := checkSpecVersion(document.GetSpecInfo()); err != nil {
+		return nil, err
+	}
 
 	// Build V3 model - this will handle reference resolution
 	docModel, errs := document.BuildV3Model()
 	if docModel == nil {
-		errMsg := "document model is nil"
 		if len(errs) > 0 {
-			errMsg = fmt.Sprintf("document model is nil, errors: %v", errs)
+			return nil, fmt.Errorf("failed to build document model: %w", errors.Join(errs...))
 		}
-		return nil, fmt.Errorf("failed to build document model: %s", errMsg)
+		return nil, errors.New("failed to build document model: document model is nil")
+	}
+	if len(errs) > 0 && l.StrictMode && !l.IgnoreMissingRefs {
+		return nil, fmt.Errorf("failed to build document model in strict mode: %w", errors.Join(errs...))
 	}
-	if len(errs) > 0 && !l.IgnoreMissingRefs {
-		// For now, continue with warnings but log them
-		// In the future we might want to make this configurable
+	for _, err := range errs {
+		l.logger().Debug("problem reported while building document model", "error", err)
 	}
 
-	return l.wrapDocument(&docModel.Model), nil
+	globalLogger = l.logger()
+	globalSimplifyCompositions = l.SimplifyCompositions
+	doc := l.wrapDocument(&docModel.Model)
+	doc.loadResult = LoadResult{Warnings: errs}
+	doc.loader = l
+	doc.basePath = basePath
+	l.timePhase("resolve", start)
+	return doc, nil
 }
 
 // LoadFromDataWithPath loads an OpenAPI document from byte data with a path context
@@ -876,6 +1300,12 @@ func (l *Loader) wrapDocument(model *v3.Document) *T {
 		version:  version,
 		OpenAPI:  version,
 	}
+	// The version has already been validated by checkSpecVersion, so fall back to 3.0 for any document built without it
+	if major, minor, err := parseVersion(version); err == nil {
+		doc.versionMajor, doc.versionMinor = major, minor
+	} else {
+		doc.versionMajor, doc.versionMinor = 3, 0
+	}
 
 	// Handle JSONSchemaDialect for OpenAPI 3.1
 	if model.JsonSchemaDialect != "" {
@@ -895,12 +1325,19 @@ func (l *Loader) wrapDocument(model *v3.Document) *T {
 		}
 	}
 
+	// Wrap the top-level tags, in the order they're declared
+	for _, tag := range model.Tags {
+		if tag != nil {
+			doc.Tags = append(doc.Tags, WrapTag(tag))
+		}
+	}
+
 	// Wrap paths if they exist (paths is optional in OpenAPI 3.1)
 	if model.Paths != nil {
-		doc.Paths = &Paths{paths: model.Paths}
+		doc.Paths = &Paths{paths: model.Paths, document: model}
 	} else {
-		// Even if paths is nil, create an empty wrapper for compatibility
-		doc.Paths = &Paths{paths: nil}
+		// Even if paths is nil, create an empty wrapper for compatibility, which creates them if a path is added
+		doc.Paths = &Paths{paths: nil, document: model}
 	}
 
 	// Wrap components if they exist
@@ -965,6 +1402,7 @@ func (l *Loader) removeExampleReferences(data interface{}) {
 				strings.Contains(ref, ".cs") ||
 				strings.Contains(ref, ".sh") {
 				// Replace with placeholder
+				l.logger().Debug("removing reference to unavailable external file", "ref", ref)
 				delete(v, "$ref")
 				v["placeholder"] = "External reference not available"
 				return
@@ -1041,6 +1479,18 @@ func (l *Loader) wrapComponents(components *v3.Components) *Components {
 		// Add request body wrapping logic
 	}
 
+	if components.SecuritySchemes != nil {
+		wrapped.SecuritySchemes = make(map[string]*SecuritySchemeRef)
+		for pair := components.SecuritySchemes.First(); pair != nil; pair = pair.Next() {
+			if pair.Value() == nil {
+				continue
+			}
+			wrapped.SecuritySchemes[pair.Key()] = &SecuritySchemeRef{
+				Value: &SecurityScheme{SecurityScheme: pair.Value()},
+			}
+		}
+	}
+
 	// Handle pathItems (OpenAPI 3.1 feature)
 	if components.PathItems != nil {
 		wrapped.PathItems = make(map[string]*PathItemRef)
@@ -1057,19 +1507,32 @@ func (l *Loader) wrapComponents(components *v3.Components) *Components {
 	return wrapped
 }
 
+// LoadResult returns the diagnostics collected while the document was loaded
+func (t *T) LoadResult() LoadResult {
+	return t.loadResult
+}
+
 // GetVersion returns the OpenAPI version of the document
 func (t *T) GetVersion() string {
 	return t.version
 }
 
-// IsOpenAPI31 returns true if this is an OpenAPI 3.1 document
+// IsOpenAPI31 returns true if this is an OpenAPI 3.1 document, of any patch version
 func (t *T) IsOpenAPI31() bool {
-	return t.version == "3.1.0" || t.version == "3.1"
+	return t.versionMajor == 3 && t.versionMinor == 1
 }
 
-// IsOpenAPI30 returns true if this is an OpenAPI 3.0 document
+// IsOpenAPI30 returns true if this is an OpenAPI 3.0 document, of any patch version
 func (t *T) IsOpenAPI30() bool {
-	return t.version == "3.0.0" || t.version == "3.0.1" || t.version == "3.0.2" || t.version == "3.0.3" || t.version == "3.0"
+	return t.versionMajor == 3 && t.versionMinor == 0
+}
+
+// VersionAtLeast returns true if the document's OpenAPI version is at least major.minor
+func (t *T) VersionAtLeast(major, minor int) bool {
+	if t.versionMajor != major {
+		return t.versionMajor > major
+	}
+	return t.versionMinor >= minor
 }
 
 // InternalizeRefs placeholder method for compatibility
@@ -1084,19 +1547,28 @@ func (t *T) MarshalJSON() ([]byte, error) {
 		return []byte("{}"), nil
 	}
 
-	// Use libopenapi's Render method which returns YAML and then convert to JSON
-	yamlBytes, err := t.Render()
+	// Convert straight from the rendered YAML node, so keys stay in document order and scalars keep their YAML types
+	node, err := t.MarshalYAML()
 	if err != nil {
 		return nil, err
 	}
-
-	// Convert YAML to JSON using standard yaml.v3 library
-	var yamlData interface{}
-	if err := yaml.Unmarshal(yamlBytes, &yamlData); err != nil {
+	indented, err := libopenapijson.YAMLNodeToJSON(node.(*yaml.Node), "")
+	if err != nil {
+		return nil, err
+	}
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, indented); err != nil {
 		return nil, err
 	}
+	return compacted.Bytes(), nil
+}
 
-	return json.Marshal(yamlData)
+// MarshalYAML renders the document as a YAML node, preserving the order of keys from the original document
+func (t *T) MarshalYAML() (interface{}, error) {
+	if t.Document == nil {
+		return newYamlMapping(), nil
+	}
+	return t.Document.MarshalYAML()
 }
 
 // WrapSchema converts a libopenapi schema to our wrapper
@@ -1114,7 +1586,8 @@ func WrapSchemaWithVisited(schema *base.Schema, visited map[*base.Schema]bool) *
 	if visited[schema] {
 		// Return a minimal schema reference to break the cycle
 		return &Schema{
-			Schema: schema,
+			Schema:   schema,
+			Nullable: isNullableSchema(schema),
 		}
 	}
 
@@ -1122,23 +1595,8 @@ func WrapSchemaWithVisited(schema *base.Schema, visited map[*base.Schema]bool) *
 	visited[schema] = true
 
 	wrapped := &Schema{
-		Schema: schema,
-	}
-
-	// Handle OpenAPI 3.1 vs 3.0 compatibility
-	if len(schema.Type) > 0 {
-		// Check for nullable in union types (OpenAPI 3.1)
-		for _, t := range schema.Type {
-			if t == "null" {
-				wrapped.Nullable = true
-				break
-			}
-		}
-	}
-
-	// Handle traditional nullable field (OpenAPI 3.0 style)
-	if schema.Nullable != nil && *schema.Nullable {
-		wrapped.Nullable = true
+		Schema:   schema,
+		Nullable: isNullableSchema(schema),
 	}
 
 	// Handle examples (OpenAPI 3.1 prefers examples array over singular example)
@@ -1222,7 +1680,10 @@ func WrapSchemaWithVisited(schema *base.Schema, visited map[*base.Schema]bool) *
 
 	// Handle JSON Schema Draft 2020-12 keywords with nil checks
 	if schema.Const != nil {
-		wrapped.Const = schema.Const.Value
+		wrapped.Const = DecodeValue(schema.Const)
+	}
+	if schema.Default != nil {
+		wrapped.Default = DecodeValue(schema.Default)
 	}
 
 	if schema.If != nil {
@@ -1273,6 +1734,13 @@ func WrapSchemaWithVisited(schema *base.Schema, visited map[*base.Schema]bool) *
 		}
 	}
 
+	if schema.PropertyNames != nil {
+		propertyNamesRef := SchemaProxyToRefWithVisited(schema.PropertyNames, visited)
+		if propertyNamesRef != nil {
+			wrapped.PropertyNames = propertyNamesRef
+		}
+	}
+
 	// Handle PrefixItems
 	if schema.PrefixItems != nil {
 		wrapped.PrefixItems = make([]*SchemaRef, 0, len(schema.PrefixItems))
@@ -1308,6 +1776,39 @@ func (s *Schema) TypeIs(typeStr string) bool {
 	return false
 }
 
+// IsNullable returns whether the schema allows null, whichever way it's written: with OpenAPI 3.0's `nullable: true`, or with `null` in an OpenAPI 3.1 `type`, such as `type: [string, "null"]`
+func (s *Schema) IsNullable() bool {
+	if s == nil {
+		return false
+	}
+	return s.Nullable || isNullableSchema(s.Schema)
+}
+
+// NonNullTypes returns the schema's types other than `null`, so that `type: [string, "null"]` and `type: string` with `nullable: true` both have the types `[string]`
+func (s *Schema) NonNullTypes() []string {
+	if s == nil || s.Schema == nil {
+		return nil
+	}
+	var types []string
+	for _, t := range s.Type {
+		if t != "null" {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+// isNullableSchema returns whether a libopenapi schema allows null, with either `nullable: true` or `null` in its `type`
+func isNullableSchema(schema *base.Schema) bool {
+	if schema == nil {
+		return false
+	}
+	if schema.Nullable != nil && *schema.Nullable {
+		return true
+	}
+	return slices.Contains(schema.Type, "null")
+}
+
 // TypeSlice returns the type slice (for OpenAPI 3.1 union types)
 func (s *Schema) TypeSlice() []string {
 	if s.Schema == nil {
@@ -1579,6 +2080,18 @@ func stringSlicesEqual(a, b []string) bool {
 	return true
 }
 
+// createdComponentRef resolves a reference to a component schema which was created in code, such as with `base.CreateSchemaProxyRef` on a document passed to FromLibopenapi, rather than loaded, as it has no index for the proxy to resolve it with
+func createdComponentRef(proxy *base.SchemaProxy) *base.Schema {
+	if proxy.GoLow() != nil || !proxy.IsReference() {
+		return nil
+	}
+	name, ok := strings.CutPrefix(proxy.GetReference(), "#/components/schemas/")
+	if !ok {
+		return nil
+	}
+	return globalComponentSchemas[name]
+}
+
 // SchemaProxyToRef converts a libopenapi SchemaProxy to our SchemaRef
 func SchemaProxyToRef(proxy *base.SchemaProxy) *SchemaRef {
 	return SchemaProxyToRefWithVisited(proxy, make(map[*base.Schema]bool))
@@ -1591,10 +2104,17 @@ func SchemaProxyToRefWithVisited(proxy *base.SchemaProxy, visited map[*base.Sche
 	}
 
 	schema := proxy.Schema()
+	if schema == nil {
+		schema = createdComponentRef(proxy)
+	}
 	if schema == nil {
 		return nil
 	}
 
+	if inner := singleComposition(proxy); inner != nil {
+		return SchemaProxyToRefWithVisited(inner, visited)
+	}
+
 	wrappedSchema := WrapSchemaWithVisited(schema, visited)
 	if wrappedSchema == nil {
 		return nil
@@ -1605,10 +2125,11 @@ func SchemaProxyToRefWithVisited(proxy *base.SchemaProxy, visited map[*base.Sche
 	}
 
 	// Handle $ref with siblings (OpenAPI 3.1 feature)
-	// If there's a reference, we can still have sibling properties
-	ref := proxy.GetReference()
-	if ref != "" {
-		schemaRef.Ref = ref
+	// If there's a reference, we can still have sibling properties.
+	// Proxies which were created in code, rather than loaded, have no low-level model, so are checked with IsReference first
+	if proxy.IsReference() {
+		schemaRef.Ref = proxy.GetReference()
+		schemaRef.Siblings = newRefSiblings(proxy.GetReferenceNode())
 		// In OpenAPI 3.1, properties can exist alongside $ref
 		// The schemaRef.Value will contain any sibling properties
 	} else if schema != nil && globalComponentSchemas != nil && globalComponentSchemaNames != nil {
@@ -1621,6 +2142,7 @@ func SchemaProxyToRefWithVisited(proxy *base.SchemaProxy, visited map[*base.Sche
 			matchedComponentName := findMatchingComponentSchema(schema)
 			if matchedComponentName != "" {
 				schemaRef.Ref = "#/components/schemas/" + matchedComponentName
+				globalLogger.Debug("restored reference to component schema", "ref", schemaRef.Ref)
 			}
 		}
 		// Note: When isComponentSchema is true, we skip reference restoration to prevent
@@ -1630,6 +2152,77 @@ func SchemaProxyToRefWithVisited(proxy *base.SchemaProxy, visited map[*base.Sche
 	return schemaRef
 }
 
+// RefSiblings holds the keywords alongside a `$ref`, which OpenAPI 3.1 allows,
+// and which override those of the referenced schema.
+type RefSiblings struct {
+	Description string
+	Nullable    bool
+	Deprecated  bool
+	// Constraints holds the validation keywords, such as `maxLength`, in the order they're written
+	Constraints []RefSiblingConstraint
+	// Extensions holds the extensions, such as `x-go-name`, by name
+	Extensions map[string]interface{}
+}
+
+// RefSiblingConstraint is a validation keyword alongside a `$ref`
+type RefSiblingConstraint struct {
+	Keyword string
+	Value   string
+}
+
+// refSiblingConstraintKeywords are the validation keywords which can be alongside a `$ref`
+var refSiblingConstraintKeywords = map[string]bool{
+	"minimum":          true,
+	"maximum":          true,
+	"exclusiveMinimum": true,
+	"exclusiveMaximum": true,
+	"multipleOf":       true,
+	"minLength":        true,
+	"maxLength":        true,
+	"pattern":          true,
+	"format":           true,
+	"minItems":         true,
+	"maxItems":         true,
+	"uniqueItems":      true,
+	"minProperties":    true,
+	"maxProperties":    true,
+}
+
+// newRefSiblings returns the keywords alongside the `$ref` in the given node, or nil if there are none
+func newRefSiblings(node *yaml.Node) *RefSiblings {
+	if node == nil || node.Kind != yaml.MappingNode || len(node.Content) <= 2 {
+		return nil
+	}
+
+	siblings := &RefSiblings{}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i].Value, node.Content[i+1]
+		switch {
+		case key == "description":
+			siblings.Description = value.Value
+		case key == "nullable":
+			siblings.Nullable = value.Value == "true"
+		case key == "deprecated":
+			siblings.Deprecated = value.Value == "true"
+		case key == "type":
+			// A type of `[..., "null"]` makes the reference nullable
+			for _, t := range append([]*yaml.Node{value}, value.Content...) {
+				if t.Kind == yaml.ScalarNode && t.Value == "null" {
+					siblings.Nullable = true
+				}
+			}
+		case refSiblingConstraintKeywords[key] && value.Kind == yaml.ScalarNode:
+			siblings.Constraints = append(siblings.Constraints, RefSiblingConstraint{Keyword: key, Value: value.Value})
+		case strings.HasPrefix(key, "x-"):
+			if siblings.Extensions == nil {
+				siblings.Extensions = make(map[string]interface{})
+			}
+			siblings.Extensions[key] = value
+		}
+	}
+	return siblings
+}
+
 // SecurityRequirements type alias for compatibility
 type SecurityRequirements []SecurityRequirement
 
@@ -1655,6 +2248,22 @@ func ConvertSecurityRequirements(reqs []*base.SecurityRequirement) SecurityRequi
 	return result
 }
 
+// SecurityRequirements returns the document's top-level security requirements, which apply to each operation which doesn't declare its own
+func (t *T) SecurityRequirements() SecurityRequirements {
+	if t.Document == nil {
+		return nil
+	}
+	return ConvertSecurityRequirements(t.Security)
+}
+
+// OperationSecurityRequirements returns the security requirements which apply to an operation, which are its own, if it declares any, or otherwise the document's. An operation's `security` replaces the document's, rather than adding to it, so an operation with an empty `security` needs no authentication, even when the document does. Any one of the requirements is sufficient, and an empty requirement makes authentication optional
+func (t *T) OperationSecurityRequirements(op *Operation) SecurityRequirements {
+	if op != nil && op.Operation != nil && op.Security != nil {
+		return ConvertSecurityRequirements(op.Security)
+	}
+	return t.SecurityRequirements()
+}
+
 // Server represents an OpenAPI server
 type Server struct {
 	*v3.Server
@@ -1690,6 +2299,29 @@ func WrapServer(server *v3.Server) *Server {
 	return wrapped
 }
 
+// Tag represents a tag in the document's top-level `tags`, with the description and external docs of the operations tagged with it
+type Tag struct {
+	*base.Tag
+}
+
+// WrapTag creates a Tag wrapper
+func WrapTag(tag *base.Tag) *Tag {
+	if tag == nil {
+		return nil
+	}
+	return &Tag{Tag: tag}
+}
+
+// Tag returns the top-level tag with the name, or nil if the tag isn't declared, as operations may use tags which aren't
+func (t *T) Tag(name string) *Tag {
+	for _, tag := range t.Tags {
+		if tag.Name == name {
+			return tag
+		}
+	}
+	return nil
+}
+
 // Info represents OpenAPI info object with 3.1 enhancements
 type Info struct {
 	*base.Info
@@ -0,0 +1,436 @@
+package openapi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const circularSpec = `
+openapi: 3.0.0
+info:
+  title: Circular
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Node:
+      type: object
+      required:
+        - next
+      properties:
+        next:
+          $ref: '#/components/schemas/Node'
+`
+
+func TestLoaderWarnings(t *testing.T) {
+	loader := NewLoader()
+	swagger, err := loader.LoadFromData([]byte(circularSpec))
+	require.NoError(t, err)
+	assert.NotEmpty(t, swagger.LoadResult().Warnings)
+}
+
+func TestLoaderTimings(t *testing.T) {
+	var phases []string
+	loader := NewLoader()
+	loader.Timings = func(phase string, elapsed time.Duration) {
+		assert.GreaterOrEqual(t, elapsed, time.Duration(0))
+		phases = append(phases, phase)
+	}
+	_, err := loader.LoadFromData([]byte(circularSpec))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"load", "resolve"}, phases)
+}
+
+func TestLoaderStrictMode(t *testing.T) {
+	loader := NewLoader()
+	loader.StrictMode = true
+	_, err := loader.LoadFromData([]byte(circularSpec))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "strict mode")
+
+	swagger, err := loader.LoadFromData([]byte(`
+openapi: 3.0.0
+info:
+  title: Simple
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Node:
+      type: object
+      properties:
+        name:
+          type: string
+`))
+	require.NoError(t, err)
+	assert.Empty(t, swagger.LoadResult().Warnings)
+}
+
+func TestResponsesDefault(t *testing.T) {
+	swagger, err := NewLoader().LoadFromData([]byte(`
+openapi: 3.0.0
+info:
+  title: Responses
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: OK
+        '4XX':
+          description: Client error
+        default:
+          description: Error
+`))
+	require.NoError(t, err)
+
+	responses := swagger.Paths.Find("/pets").Operations()["GET"].Responses
+	assert.ElementsMatch(t, []string{"200", "4XX", "default"}, keys(responses.Map()))
+	require.NotNil(t, responses.Value("default"))
+	assert.Equal(t, "Error", responses.Value("default").Value.Description)
+	assert.Equal(t, "Client error", responses.Value("4XX").Value.Description)
+}
+
+func TestTags(t *testing.T) {
+	swagger, err := NewLoader().LoadFromData([]byte(`
+openapi: 3.0.0
+info:
+  title: Tags
+  version: 1.0.0
+tags:
+  - name: pets
+    description: Everything about your pets
+    externalDocs:
+      description: Find out more
+      url: https://example.com/pets
+  - name: store
+paths: {}
+`))
+	require.NoError(t, err)
+
+	require.Len(t, swagger.Tags, 2)
+	assert.Equal(t, "pets", swagger.Tags[0].Name)
+	assert.Equal(t, "store", swagger.Tags[1].Name)
+
+	pets := swagger.Tag("pets")
+	require.NotNil(t, pets)
+	assert.Equal(t, "Everything about your pets", pets.Description)
+	require.NotNil(t, pets.ExternalDocs)
+	assert.Equal(t, "https://example.com/pets", pets.ExternalDocs.URL)
+	assert.Equal(t, "Find out more", pets.ExternalDocs.Description)
+
+	assert.Nil(t, swagger.Tag("users"))
+}
+
+func TestOperationSecurityRequirements(t *testing.T) {
+	swagger, err := NewLoader().LoadFromData([]byte(`
+openapi: 3.0.0
+info:
+  title: Security
+  version: 1.0.0
+security:
+  - api_key: []
+paths:
+  /inherited:
+    get:
+      responses:
+        '200':
+          description: OK
+  /overridden:
+    get:
+      security:
+        - oauth: [read:pets]
+      responses:
+        '200':
+          description: OK
+  /public:
+    get:
+      security: []
+      responses:
+        '200':
+          description: OK
+  /optional:
+    get:
+      security:
+        - {}
+        - api_key: []
+      responses:
+        '200':
+          description: OK
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, SecurityRequirements{{"api_key": nil}}, swagger.SecurityRequirements())
+
+	security := func(path string) SecurityRequirements {
+		return swagger.OperationSecurityRequirements(swagger.Paths.Find(path).Operations()["GET"])
+	}
+	assert.Equal(t, SecurityRequirements{{"api_key": nil}}, security("/inherited"))
+	assert.Equal(t, SecurityRequirements{{"oauth": {"read:pets"}}}, security("/overridden"))
+	assert.Equal(t, SecurityRequirements{}, security("/public"))
+	optional := security("/optional")
+	require.Len(t, optional, 2)
+	assert.Empty(t, optional[0])
+	assert.Contains(t, optional[1], "api_key")
+}
+
+func keys[V any](m map[string]V) []string {
+	var result []string
+	for k := range m {
+		result = append(result, k)
+	}
+	return result
+}
+
+func TestFromLibopenapi(t *testing.T) {
+	t.Run("changes to the model are kept", func(t *testing.T) {
+		document, err := libopenapi.NewDocument([]byte(`openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+`))
+		require.NoError(t, err)
+		model, errs := document.BuildV3Model()
+		require.Empty(t, errs)
+		model.Model.Info.Title = "Renamed"
+		model.Model.Components.Schemas.Set("Kind", base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}}))
+		pet := model.Model.Components.Schemas.GetOrZero("Pet").Schema()
+		pet.Properties = orderedmap.New[string, *base.SchemaProxy]()
+		pet.Properties.Set("kind", base.CreateSchemaProxyRef("#/components/schemas/Kind"))
+
+		swagger, err := FromLibopenapi(document)
+		require.NoError(t, err)
+		assert.Equal(t, "Renamed", swagger.Info.Title)
+		assert.Equal(t, "3.0.0", swagger.OpenAPI)
+		require.Contains(t, swagger.Components.Schemas, "Kind")
+		assert.True(t, swagger.Components.Schemas["Kind"].Value.TypeIs("string"))
+		require.Contains(t, swagger.Components.Schemas, "Pet")
+		kind := SchemaProxyToRef(swagger.Components.Schemas["Pet"].Value.Properties.GetOrZero("kind"))
+		require.NotNil(t, kind, "references created in code are resolved")
+		assert.Equal(t, "#/components/schemas/Kind", kind.Ref)
+		assert.True(t, kind.Value.TypeIs("string"))
+	})
+
+	t.Run("references are resolved with the document's configuration", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "pets.yaml"), []byte(positionExternalSpec), 0o644))
+
+		document, err := libopenapi.NewDocumentWithConfiguration([]byte(positionSpec), &datamodel.DocumentConfiguration{
+			AllowFileReferences: true,
+			BasePath:            dir,
+		})
+		require.NoError(t, err)
+
+		swagger, err := NewLoader().LoadFromLibopenapi(document)
+		require.NoError(t, err)
+		pet := swagger.Paths.Find("/pets").GetOperation("GET").Responses.Value("200").Value.Content["application/json"].Schema
+		assert.True(t, pet.Value.TypeIs("object"))
+		assert.Equal(t, []DocumentRef{{Location: filepath.Join(dir, "pets.yaml"), SHA256: sha256Hex(positionExternalSpec)}}, swagger.ExternalDocuments())
+	})
+
+	t.Run("Swagger 2.0 is rejected", func(t *testing.T) {
+		document, err := libopenapi.NewDocument([]byte(`swagger: "2.0"
+info:
+  title: Pets
+  version: 1.0.0
+paths: {}
+`))
+		require.NoError(t, err)
+		_, err = FromLibopenapi(document)
+		require.ErrorContains(t, err, "Swagger 2.0 are not supported")
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		_, err := FromLibopenapi(nil)
+		require.Error(t, err)
+	})
+}
+
+func TestSchemaEnumValues(t *testing.T) {
+	swagger, err := NewLoader().LoadFromData([]byte(`openapi: 3.0.0
+info:
+  title: Enums
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Mixed:
+      enum: [1.0, "two", 3, 1e3, 9007199254740993, true, null, {a: 1}]
+`))
+	require.NoError(t, err)
+	schema := swagger.Components.Schemas["Mixed"].Value
+
+	assert.Equal(t, []string{"1.0", "two", "3", "1e3", "9007199254740993", "true", "null", `{"a":1}`}, schema.EnumRaw())
+	assert.Len(t, schema.EnumRaw(), len(schema.Enum()), "the values line up with Enum")
+	assert.Equal(t, []string{"1.0", "two", "3", "1e3", "9007199254740993", "true"}, schema.EnumStrings())
+	assert.Equal(t, []int64{1, 3, 1000, 9007199254740993}, schema.EnumInts(), "numbers with an integral value are integers")
+}
+
+func TestSchemaConstAndDefault(t *testing.T) {
+	swagger, err := NewLoader().LoadFromData([]byte(`openapi: 3.1.0
+info:
+  title: Values
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Integer:
+      const: 42
+      default: 42
+    Big:
+      const: 18446744073709551615
+    Number:
+      default: 3.14
+    Boolean:
+      const: true
+    String:
+      const: "42"
+    Null:
+      const: null
+    Object:
+      default: {limit: 10, tags: [a, 1.5]}
+`))
+	require.NoError(t, err)
+	value := func(name string) *Schema {
+		return swagger.Components.Schemas[name].Value
+	}
+
+	assert.Equal(t, int64(42), value("Integer").Const)
+	assert.Equal(t, int64(42), value("Integer").Default)
+	assert.Equal(t, uint64(18446744073709551615), value("Big").Const)
+	assert.Equal(t, 3.14, value("Number").Default)
+	assert.Nil(t, value("Number").Const)
+	assert.Equal(t, true, value("Boolean").Const)
+	assert.Equal(t, "42", value("String").Const)
+	assert.Nil(t, value("Null").Const)
+	assert.Equal(t, map[string]interface{}{"limit": int64(10), "tags": []interface{}{"a", 1.5}}, value("Object").Default)
+}
+
+func TestSchemaBounds(t *testing.T) {
+	load := func(version, schemas string) map[string]*SchemaRef {
+		swagger, err := NewLoader().LoadFromData([]byte("openapi: " + version + "\ninfo:\n  title: Bounds\n  version: 1.0.0\npaths: {}\ncomponents:\n  schemas:\n" + schemas))
+		require.NoError(t, err)
+		return swagger.Components.Schemas
+	}
+	bound := func(v float64) *float64 {
+		return &v
+	}
+
+	v30 := load("3.0.0", `    Exclusive:
+      type: integer
+      minimum: 0
+      exclusiveMinimum: true
+      maximum: 10
+      exclusiveMaximum: true
+    Inclusive:
+      type: integer
+      minimum: 0
+      exclusiveMinimum: false
+      maximum: 10
+    Unbounded:
+      type: integer
+      exclusiveMinimum: true
+`)
+	v31 := load("3.1.0", `    Exclusive:
+      type: integer
+      exclusiveMinimum: 0
+      exclusiveMaximum: 10
+    Inclusive:
+      type: integer
+      minimum: 0
+      maximum: 10
+    Stricter:
+      type: integer
+      minimum: 5
+      exclusiveMinimum: 0
+      maximum: 10
+      exclusiveMaximum: 10
+`)
+
+	exclusive := Bounds{Minimum: bound(0), ExclusiveMinimum: true, Maximum: bound(10), ExclusiveMaximum: true}
+	inclusive := Bounds{Minimum: bound(0), Maximum: bound(10)}
+	assert.Equal(t, exclusive, v30["Exclusive"].Value.Bounds())
+	assert.Equal(t, exclusive, v31["Exclusive"].Value.Bounds(), "both forms have the same bounds")
+	assert.Equal(t, inclusive, v30["Inclusive"].Value.Bounds())
+	assert.Equal(t, inclusive, v31["Inclusive"].Value.Bounds())
+	assert.Equal(t, Bounds{}, v30["Unbounded"].Value.Bounds(), "a boolean exclusiveMinimum without a minimum isn't a bound")
+	assert.Equal(t, Bounds{Minimum: bound(5), Maximum: bound(10), ExclusiveMaximum: true}, v31["Stricter"].Value.Bounds(), "the stricter of the bounds applies")
+	assert.Equal(t, Bounds{}, (*Schema)(nil).Bounds())
+}
+
+func TestSchemaNullability(t *testing.T) {
+	load := func(version, schemas string) map[string]*SchemaRef {
+		swagger, err := NewLoader().LoadFromData([]byte("openapi: " + version + "\ninfo:\n  title: Nullability\n  version: 1.0.0\npaths: {}\ncomponents:\n  schemas:\n" + schemas))
+		require.NoError(t, err)
+		return swagger.Components.Schemas
+	}
+
+	v30 := load("3.0.3", `    Nullable:
+      type: string
+      nullable: true
+    NotNullable:
+      type: string
+      nullable: false
+    Plain:
+      type: string
+    Untyped:
+      nullable: true
+`)
+	v31 := load("3.1.0", `    Nullable:
+      type: [string, "null"]
+    NotNullable:
+      type: [string]
+    Plain:
+      type: string
+    Null:
+      type: "null"
+    Union:
+      type: [string, integer, "null"]
+`)
+
+	for _, test := range []struct {
+		name     string
+		schema   *SchemaRef
+		nullable bool
+		types    []string
+	}{
+		{"3.0 nullable", v30["Nullable"], true, []string{"string"}},
+		{"3.0 not nullable", v30["NotNullable"], false, []string{"string"}},
+		{"3.0 without nullable", v30["Plain"], false, []string{"string"}},
+		{"3.0 nullable without a type", v30["Untyped"], true, nil},
+		{"3.1 null union", v31["Nullable"], true, []string{"string"}},
+		{"3.1 type array without null", v31["NotNullable"], false, []string{"string"}},
+		{"3.1 type", v31["Plain"], false, []string{"string"}},
+		{"3.1 null", v31["Null"], true, nil},
+		{"3.1 union with null", v31["Union"], true, []string{"string", "integer"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			require.NotNil(t, test.schema)
+			assert.Equal(t, test.nullable, test.schema.Value.IsNullable())
+			assert.Equal(t, test.nullable, test.schema.Value.Nullable, "the field agrees with the method")
+			assert.Equal(t, test.types, test.schema.Value.NonNullTypes())
+		})
+	}
+
+	t.Run("an unwrapped schema", func(t *testing.T) {
+		schema := &Schema{Schema: v31["Nullable"].Value.Schema}
+		assert.True(t, schema.IsNullable(), "nullability doesn't depend on how the schema was wrapped")
+		assert.False(t, (*Schema)(nil).IsNullable())
+		assert.Nil(t, (*Schema)(nil).NonNullTypes())
+	})
+}
@@ -0,0 +1,165 @@
+package openapi
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	libopenapijson "github.com/pb33f/libopenapi/json"
+	"gopkg.in/yaml.v3"
+)
+
+// DocumentRef describes an external document which was pulled in while resolving the references of a document
+type DocumentRef struct {
+	// Location is the absolute path of the file, or the URL of the remote document
+	Location string `json:"location"`
+	// Remote is set when the document was fetched from a URL, rather than read from a file
+	Remote bool `json:"remote"`
+	// SHA256 is the hex encoded SHA-256 hash of the document's content, as it was read, or empty if it can no longer be read
+	SHA256 string `json:"sha256"`
+}
+
+// ExternalDocuments returns each of the external files and URLs which were pulled in while resolving the document's references, sorted by their location, so that build systems can declare them as inputs of the generated code. The document itself isn't included
+func (t *T) ExternalDocuments() []DocumentRef {
+	if t.Document == nil || t.Document.Rolodex == nil {
+		return nil
+	}
+	rolodex := t.Document.Rolodex
+
+	seen := make(map[string]bool)
+	if root := rolodex.GetRootIndex(); root != nil {
+		seen[root.GetSpecAbsolutePath()] = true
+	}
+	var refs []DocumentRef
+	for _, index := range rolodex.GetIndexes() {
+		location := index.GetSpecAbsolutePath()
+		if location == "" || seen[location] {
+			continue
+		}
+		seen[location] = true
+
+		ref := DocumentRef{
+			Location: location,
+			Remote:   strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://"),
+		}
+		// The rolodex has already read each of the documents, so opening them again returns their cached content
+		if file, err := rolodex.Open(location); err == nil {
+			sum := sha256.Sum256([]byte(file.GetContent()))
+			ref.SHA256 = hex.EncodeToString(sum[:])
+		}
+		refs = append(refs, ref)
+	}
+
+	sort.Slice(refs, func(i, j int) bool {
+		return refs[i].Location < refs[j].Location
+	})
+	return refs
+}
+
+// ContentHash returns the hex encoded SHA-256 hash of the document, along with each of its ExternalDocuments, as they were loaded. The hash is canonical, so it only changes when their content does, rather than when keys are reordered, comments change, or the documents are moved to another directory or read from another working directory
+func (t *T) ContentHash() (string, error) {
+	if t.Document == nil || t.Document.Rolodex == nil {
+		return "", errors.New("the document wasn't loaded with a Loader, so has no content to hash")
+	}
+	rolodex := t.Document.Rolodex
+
+	// Each document is canonicalized as JSON, which sorts the keys of objects, and keyed by its location relative to the document, or its URL
+	documents := make(map[string]json.RawMessage)
+	root, err := canonicalJSON(rolodex.GetRootNode())
+	if err != nil {
+		return "", fmt.Errorf("failed to canonicalize the document: %w", err)
+	}
+	documents[""] = root
+
+	rootDir := ""
+	if index := rolodex.GetRootIndex(); index != nil {
+		rootDir = filepath.Dir(index.GetSpecAbsolutePath())
+	}
+	for _, doc := range t.ExternalDocuments() {
+		file, err := rolodex.Open(doc.Location)
+		if err != nil {
+			return "", fmt.Errorf("failed to open external document %s: %w", doc.Location, err)
+		}
+		node, err := file.GetContentAsYAMLNode()
+		if err != nil {
+			return "", fmt.Errorf("failed to parse external document %s: %w", doc.Location, err)
+		}
+		content, err := canonicalJSON(node)
+		if err != nil {
+			return "", fmt.Errorf("failed to canonicalize external document %s: %w", doc.Location, err)
+		}
+		key := doc.Location
+		if !doc.Remote && rootDir != "" {
+			if rel, err := filepath.Rel(rootDir, doc.Location); err == nil {
+				key = filepath.ToSlash(rel)
+			}
+		}
+		documents[key] = content
+	}
+
+	data, err := json.Marshal(documents)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// canonicalJSON converts a YAML node to JSON, with the keys of each object sorted
+func canonicalJSON(node *yaml.Node) (json.RawMessage, error) {
+	if node == nil {
+		return json.RawMessage("null"), nil
+	}
+	data, err := libopenapijson.YAMLNodeToJSON(node, "")
+	if err != nil {
+		return nil, err
+	}
+	// Numbers are kept as they're written, rather than being rounded to a float64
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return json.Marshal(value)
+}
+
+// SourceHash returns the hex encoded SHA-256 hash of the document, along with each of its ExternalDocuments, exactly as they were loaded, from where they were loaded. Unlike ContentHash, it changes whenever anything which can be seen in the code generated from the document does, such as the line a schema is defined on, or the path of the file it's defined in, so that it can key a cache of the generated code
+func (t *T) SourceHash() (string, error) {
+	if t.Document == nil || t.Document.Rolodex == nil {
+		return "", errors.New("the document wasn't loaded with a Loader, so has no source to hash")
+	}
+	rolodex := t.Document.Rolodex
+
+	h := sha256.New()
+	if index := rolodex.GetRootIndex(); index != nil {
+		fmt.Fprintf(h, "document %q\n", index.GetSpecAbsolutePath())
+	}
+	// The document's nodes are hashed along with where they are, as its original content may not be held, such as when it's loaded from data
+	hashNode(h, rolodex.GetRootNode())
+	for _, doc := range t.ExternalDocuments() {
+		if doc.SHA256 == "" {
+			return "", fmt.Errorf("failed to read external document %s", doc.Location)
+		}
+		fmt.Fprintf(h, "external %q %s\n", doc.Location, doc.SHA256)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashNode writes each of a YAML node's descendants, with their position and comments, to w
+func hashNode(w io.Writer, node *yaml.Node) {
+	if node == nil {
+		return
+	}
+	fmt.Fprintf(w, "%d %q %q %d:%d %q %q %q %d\n", node.Kind, node.Tag, node.Value, node.Line, node.Column, node.HeadComment, node.LineComment, node.FootComment, len(node.Content))
+	for _, child := range node.Content {
+		hashNode(w, child)
+	}
+}
@@ -0,0 +1,134 @@
+package openapi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const dependenciesSpec = `openapi: 3.0.3
+info:
+  title: Dependencies
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: 'schemas/pet.yaml#/Pet'
+`
+
+const dependenciesPet = `Pet:
+  type: object
+  properties:
+    kind:
+      $ref: 'kind.yaml#/Kind'
+`
+
+const dependenciesKind = `Kind:
+  type: string
+`
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestExternalDocuments(t *testing.T) {
+	t.Run("files", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "schemas"), 0o755))
+		specFile := filepath.Join(dir, "spec.yaml")
+		require.NoError(t, os.WriteFile(specFile, []byte(dependenciesSpec), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "schemas", "pet.yaml"), []byte(dependenciesPet), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "schemas", "kind.yaml"), []byte(dependenciesKind), 0o644))
+
+		swagger, err := NewLoader().LoadFromFile(specFile)
+		require.NoError(t, err)
+
+		assert.Equal(t, []DocumentRef{
+			{Location: filepath.Join(dir, "schemas", "kind.yaml"), SHA256: sha256Hex(dependenciesKind)},
+			{Location: filepath.Join(dir, "schemas", "pet.yaml"), SHA256: sha256Hex(dependenciesPet)},
+		}, swagger.ExternalDocuments(), "references are followed transitively, and the spec itself isn't included")
+	})
+
+	t.Run("urls", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/schemas/pet.yaml":
+				_, _ = w.Write([]byte(dependenciesPet))
+			case "/schemas/kind.yaml":
+				_, _ = w.Write([]byte(dependenciesKind))
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+		defer server.Close()
+
+		spec := strings.Replace(dependenciesSpec, "schemas/pet.yaml", server.URL+"/schemas/pet.yaml", 1)
+		swagger, err := NewLoader().LoadFromData([]byte(spec))
+		require.NoError(t, err)
+
+		assert.Equal(t, []DocumentRef{
+			{Location: server.URL + "/schemas/kind.yaml", Remote: true, SHA256: sha256Hex(dependenciesKind)},
+			{Location: server.URL + "/schemas/pet.yaml", Remote: true, SHA256: sha256Hex(dependenciesPet)},
+		}, swagger.ExternalDocuments())
+	})
+
+	t.Run("none", func(t *testing.T) {
+		swagger, err := NewLoader().LoadFromData([]byte(`openapi: 3.0.3
+info:
+  title: Dependencies
+  version: 1.0.0
+paths: {}
+`))
+		require.NoError(t, err)
+		assert.Empty(t, swagger.ExternalDocuments())
+	})
+}
+
+func TestContentHash(t *testing.T) {
+	write := func(t *testing.T, dir, spec, pet string) string {
+		t.Helper()
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "schemas"), 0o755))
+		specFile := filepath.Join(dir, "spec.yaml")
+		require.NoError(t, os.WriteFile(specFile, []byte(spec), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "schemas", "pet.yaml"), []byte(pet), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "schemas", "kind.yaml"), []byte(dependenciesKind), 0o644))
+		return specFile
+	}
+	hash := func(t *testing.T, specFile string) string {
+		t.Helper()
+		swagger, err := NewLoader().LoadFromFile(specFile)
+		require.NoError(t, err)
+		hash, err := swagger.ContentHash()
+		require.NoError(t, err)
+		return hash
+	}
+
+	original := hash(t, write(t, t.TempDir(), dependenciesSpec, dependenciesPet))
+	assert.Len(t, original, 64)
+
+	assert.Equal(t, original, hash(t, write(t, t.TempDir(), dependenciesSpec, dependenciesPet)), "the hash doesn't depend on where the documents are")
+
+	reformatted := "# The pet\nPet:\n  properties: {kind: {$ref: 'kind.yaml#/Kind'}}\n  type: object\n"
+	assert.Equal(t, original, hash(t, write(t, t.TempDir(), dependenciesSpec, reformatted)), "the hash doesn't depend on the formatting of the documents")
+
+	changed := strings.Replace(dependenciesPet, "type: object", "type: object\n  required: [kind]", 1)
+	assert.NotEqual(t, original, hash(t, write(t, t.TempDir(), dependenciesSpec, changed)), "the hash changes when a referenced document does")
+
+	retitled := strings.Replace(dependenciesSpec, "title: Dependencies", "title: Pets", 1)
+	assert.NotEqual(t, original, hash(t, write(t, t.TempDir(), retitled, dependenciesPet)), "the hash changes when the document does")
+}
@@ -0,0 +1,187 @@
+package openapi
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// openAPI31OnlySchemaKeywords are the JSON Schema keywords which OpenAPI 3.1 schemas may use, but which have no equivalent in OpenAPI 3.0, so are dropped when downconverting
+var openAPI31OnlySchemaKeywords = []string{
+	"$schema", "$id", "$anchor", "$dynamicRef", "$dynamicAnchor", "$vocabulary", "$comment", "$defs",
+	"prefixItems", "contains", "minContains", "maxContains", "if", "then", "else",
+	"dependentRequired", "dependentSchemas", "unevaluatedItems", "unevaluatedProperties",
+	"propertyNames", "patternProperties", "contentSchema",
+}
+
+// To30 returns a copy of the document downconverted to OpenAPI 3.0, for consumers which don't understand OpenAPI 3.1.
+// Schemas are rewritten to use `nullable` instead of `null` types, boolean `exclusiveMinimum`/`exclusiveMaximum`, a single `example` instead of `examples`, and `enum` instead of `const`.
+// Anything else without an OpenAPI 3.0 equivalent, such as webhooks, is dropped.
+//
+// OpenAPI 3.0 documents are returned as-is
+func (t *T) To30() (*T, error) {
+	if !t.VersionAtLeast(3, 1) {
+		return t, nil
+	}
+
+	rendered, err := t.Render()
+	if err != nil {
+		return nil, fmt.Errorf("failed to render document: %w", err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(rendered, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse rendered document: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, fmt.Errorf("failed to downconvert document: the rendered document is empty")
+	}
+
+	root := doc.Content[0]
+	yamlMapSet(root, "openapi", newYamlString("3.0.3"))
+	yamlMapDelete(root, "webhooks")
+	yamlMapDelete(root, "jsonSchemaDialect")
+	if info := yamlMapGet(root, "info"); info != nil {
+		yamlMapDelete(info, "summary")
+		yamlMapDelete(yamlMapGet(info, "license"), "identifier")
+	}
+	downconvertNode(root)
+	if schemas := yamlMapGet(yamlMapGet(root, "components"), "schemas"); schemas != nil {
+		yamlForEach(schemas, func(_ string, schema *yaml.Node) {
+			downconvertSchema(schema)
+		})
+	}
+
+	converted, err := yaml.Marshal(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize downconverted document: %w", err)
+	}
+
+	// Reloading the document must not affect the reference restoration of the document being downconverted
+	componentSchemas, componentSchemaNames := globalComponentSchemas, globalComponentSchemaNames
+	defer func() {
+		globalComponentSchemas, globalComponentSchemaNames = componentSchemas, componentSchemaNames
+	}()
+
+	loader := t.loader
+	if loader == nil {
+		loader = NewLoader()
+	}
+	return loader.withoutOverlays().LoadFromDataWithBasePath(converted, t.basePath)
+}
+
+// downconvertNode walks the parts of the document which aren't schemas, downconverting each schema it finds
+func downconvertNode(n *yaml.Node) {
+	switch n.Kind {
+	case yaml.MappingNode:
+		yamlForEach(n, func(key string, value *yaml.Node) {
+			if key == "schema" {
+				downconvertSchema(value)
+				return
+			}
+			downconvertNode(value)
+		})
+	case yaml.SequenceNode:
+		for _, item := range n.Content {
+			downconvertNode(item)
+		}
+	}
+}
+
+// downconvertSchema rewrites an OpenAPI 3.1 schema, and any schemas nested within it, in place
+func downconvertSchema(schema *yaml.Node) {
+	if schema == nil || schema.Kind != yaml.MappingNode {
+		return
+	}
+
+	// `type: [string, "null"]` becomes `type: string` with `nullable: true`
+	if types := yamlMapGet(schema, "type"); types != nil && types.Kind == yaml.SequenceNode {
+		var nonNull []*yaml.Node
+		for _, typ := range types.Content {
+			if typ.Value == "null" {
+				yamlMapSet(schema, "nullable", newYamlBool(true))
+			} else {
+				nonNull = append(nonNull, typ)
+			}
+		}
+		switch len(nonNull) {
+		case 0:
+			yamlMapDelete(schema, "type")
+		case 1:
+			yamlMapSet(schema, "type", nonNull[0])
+		default:
+			// OpenAPI 3.0 only allows a single type, so multiple types are expressed as alternatives
+			alternatives := newYamlSequence()
+			for _, typ := range nonNull {
+				alternative := newYamlMapping()
+				yamlMapSet(alternative, "type", typ)
+				alternatives.Content = append(alternatives.Content, alternative)
+			}
+			yamlMapDelete(schema, "type")
+			yamlMapSet(schema, "anyOf", alternatives)
+		}
+	}
+
+	// A `null` alternative in `anyOf` or `oneOf` also becomes `nullable: true`
+	for _, key := range []string{"anyOf", "oneOf"} {
+		alternatives := yamlMapGet(schema, key)
+		if alternatives == nil || alternatives.Kind != yaml.SequenceNode {
+			continue
+		}
+		var remaining []*yaml.Node
+		for _, alternative := range alternatives.Content {
+			if yamlScalar(yamlMapGet(alternative, "type")) == "null" && len(alternative.Content) == 2 {
+				yamlMapSet(schema, "nullable", newYamlBool(true))
+				continue
+			}
+			remaining = append(remaining, alternative)
+		}
+		alternatives.Content = remaining
+	}
+
+	// Numeric `exclusiveMinimum`/`exclusiveMaximum` become the bound, with a boolean flag
+	for _, bound := range []struct{ exclusive, inclusive string }{
+		{"exclusiveMinimum", "minimum"},
+		{"exclusiveMaximum", "maximum"},
+	} {
+		value := yamlMapGet(schema, bound.exclusive)
+		if value == nil || value.Kind != yaml.ScalarNode || value.Tag == "!!bool" {
+			continue
+		}
+		yamlMapSet(schema, bound.inclusive, value)
+		yamlMapSet(schema, bound.exclusive, newYamlBool(true))
+	}
+
+	// Only a single `example` is allowed
+	if examples := yamlMapGet(schema, "examples"); examples != nil {
+		if examples.Kind == yaml.SequenceNode && len(examples.Content) > 0 && yamlMapGet(schema, "example") == nil {
+			yamlMapSet(schema, "example", examples.Content[0])
+		}
+		yamlMapDelete(schema, "examples")
+	}
+
+	if constValue := yamlMapGet(schema, "const"); constValue != nil {
+		enum := newYamlSequence()
+		enum.Content = append(enum.Content, constValue)
+		yamlMapSet(schema, "enum", enum)
+		yamlMapDelete(schema, "const")
+	}
+
+	for _, keyword := range openAPI31OnlySchemaKeywords {
+		yamlMapDelete(schema, keyword)
+	}
+
+	yamlForEach(schema, func(key string, value *yaml.Node) {
+		switch key {
+		case "properties":
+			yamlForEach(value, func(_ string, property *yaml.Node) {
+				downconvertSchema(property)
+			})
+		case "items", "additionalProperties", "not":
+			downconvertSchema(value)
+		case "allOf", "anyOf", "oneOf":
+			for _, s := range yamlSequence(value) {
+				downconvertSchema(s)
+			}
+		}
+	})
+}
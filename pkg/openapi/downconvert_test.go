@@ -0,0 +1,107 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const openAPI31Spec = `
+openapi: 3.1.0
+info:
+  title: Downconvert
+  summary: A 3.1 only summary
+  version: 1.0.0
+paths:
+  /things:
+    get:
+      operationId: getThings
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: [integer, "null"]
+            exclusiveMinimum: 0
+      responses:
+        "200":
+          description: Things
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Thing'
+webhooks:
+  newThing:
+    post:
+      responses:
+        "200":
+          description: OK
+components:
+  schemas:
+    Thing:
+      type: object
+      properties:
+        name:
+          type: [string, "null"]
+          examples: [widget, gadget]
+        kind:
+          const: thing
+        size:
+          type: number
+          exclusiveMaximum: 10
+        parent:
+          anyOf:
+            - $ref: '#/components/schemas/Thing'
+            - type: "null"
+`
+
+func TestTo30(t *testing.T) {
+	swagger, err := NewLoader().LoadFromData([]byte(openAPI31Spec))
+	require.NoError(t, err)
+	require.True(t, swagger.IsOpenAPI31())
+
+	downconverted, err := swagger.To30()
+	require.NoError(t, err)
+	assert.True(t, downconverted.IsOpenAPI30())
+	// The original document is left untouched
+	assert.True(t, swagger.IsOpenAPI31())
+
+	encoded, err := downconverted.MarshalJSON()
+	require.NoError(t, err)
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(encoded, &doc))
+
+	assert.Equal(t, "3.0.3", doc["openapi"])
+	assert.NotContains(t, doc, "webhooks")
+	assert.NotContains(t, doc["info"], "summary")
+
+	properties := doc["components"].(map[string]any)["schemas"].(map[string]any)["Thing"].(map[string]any)["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"type": "string", "nullable": true, "example": "widget"}, properties["name"])
+	assert.Equal(t, map[string]any{"enum": []any{"thing"}}, properties["kind"])
+	assert.Equal(t, map[string]any{"type": "number", "maximum": float64(10), "exclusiveMaximum": true}, properties["size"])
+	assert.Equal(t, map[string]any{
+		"anyOf":    []any{map[string]any{"$ref": "#/components/schemas/Thing"}},
+		"nullable": true,
+	}, properties["parent"])
+
+	limit := doc["paths"].(map[string]any)["/things"].(map[string]any)["get"].(map[string]any)["parameters"].([]any)[0].(map[string]any)["schema"]
+	assert.Equal(t, map[string]any{"type": "integer", "nullable": true, "minimum": float64(0), "exclusiveMinimum": true}, limit)
+}
+
+func TestTo30OpenAPI30(t *testing.T) {
+	swagger, err := NewLoader().LoadFromData([]byte(`
+openapi: 3.0.3
+info:
+  title: Already 3.0
+  version: 1.0.0
+paths: {}
+`))
+	require.NoError(t, err)
+
+	downconverted, err := swagger.To30()
+	require.NoError(t, err)
+	assert.Same(t, swagger, downconverted)
+}
@@ -0,0 +1,193 @@
+package openapi
+
+import (
+	"fmt"
+	"strings"
+
+	libopenapijson "github.com/pb33f/libopenapi/json"
+	"gopkg.in/yaml.v3"
+)
+
+// jsonSchemaDialect is the `$schema` of the standalone JSON Schema documents
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// componentSchemaRefPrefix is the prefix of a reference to a component schema, which is rewritten to a reference to one of a standalone JSON Schema document's `$defs`
+const componentSchemaRefPrefix = "#/components/schemas/"
+
+// openAPIOnlySchemaKeywords are the keywords which OpenAPI adds to its schemas, but which aren't part of JSON Schema, so are dropped when exporting schemas
+var openAPIOnlySchemaKeywords = []string{"nullable", "discriminator", "xml", "externalDocs", "example"}
+
+// JSONSchemas returns each of the document's component schemas, by name, as a standalone JSON Schema 2020-12 document, such that other services and languages can validate values against them.
+// The component schemas a schema references are included in its `$defs`, and the OpenAPI 3.0 keywords which differ from JSON Schema, such as `nullable` and a boolean `exclusiveMinimum`, are rewritten to their JSON Schema equivalents.
+// References to other documents are kept as they are
+func (t *T) JSONSchemas() (map[string][]byte, error) {
+	rendered, err := t.Render()
+	if err != nil {
+		return nil, fmt.Errorf("failed to render document: %w", err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(rendered, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse rendered document: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+
+	// The component schemas each of the component schemas references directly
+	schemas := yamlMapGet(yamlMapGet(doc.Content[0], "components"), "schemas")
+	refs := make(map[string][]string)
+	var names []string
+	yamlForEach(schemas, func(name string, schema *yaml.Node) {
+		names = append(names, name)
+		upconvertSchema(schema, func(ref string) {
+			refs[name] = append(refs[name], ref)
+		})
+	})
+
+	documents := make(map[string][]byte, len(names))
+	for _, name := range names {
+		schema := yamlMapGet(schemas, name)
+		root := newYamlMapping()
+		yamlMapSet(root, "$schema", newYamlString(jsonSchemaDialect))
+		if schema.Kind == yaml.MappingNode {
+			root.Content = append(root.Content, schema.Content...)
+		} else {
+			// A boolean schema can't have any other keywords, so is the only one of the schemas in the document
+			yamlMapSet(root, "allOf", &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: []*yaml.Node{schema}})
+		}
+
+		if defs := componentSchemaDefs(name, refs); len(defs) > 0 {
+			defsNode := yamlMapGet(root, "$defs")
+			if defsNode == nil {
+				defsNode = newYamlMapping()
+			} else {
+				// The schema's own `$defs` are kept, along with the component schemas it references
+				defsNode = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: append([]*yaml.Node(nil), defsNode.Content...)}
+			}
+			for _, def := range defs {
+				if referenced := yamlMapGet(schemas, def); referenced != nil {
+					yamlMapSet(defsNode, def, referenced)
+				}
+			}
+			yamlMapSet(root, "$defs", defsNode)
+		}
+
+		encoded, err := libopenapijson.YAMLNodeToJSON(root, "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode JSON Schema of %s: %w", name, err)
+		}
+		documents[name] = encoded
+	}
+	return documents, nil
+}
+
+// componentSchemaDefs returns the component schemas which the named component schema references, directly or through other component schemas, in the order they're first referenced. A recursive schema references itself
+func componentSchemaDefs(name string, refs map[string][]string) []string {
+	var defs []string
+	seen := make(map[string]bool)
+	queue := []string{name}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, ref := range refs[current] {
+			if seen[ref] {
+				continue
+			}
+			seen[ref] = true
+			defs = append(defs, ref)
+			queue = append(queue, ref)
+		}
+	}
+	return defs
+}
+
+// upconvertSchema rewrites an OpenAPI schema, and any schemas nested within it, in place, as a JSON Schema 2020-12 schema. Each reference to a component schema is rewritten to a reference to the `$defs` of the document, and passed to ref
+func upconvertSchema(schema *yaml.Node, ref func(name string)) {
+	if schema == nil || schema.Kind != yaml.MappingNode {
+		return
+	}
+
+	if target, found := strings.CutPrefix(yamlScalar(yamlMapGet(schema, "$ref")), componentSchemaRefPrefix); found {
+		yamlMapSet(schema, "$ref", newYamlString("#/$defs/"+target))
+		ref(target)
+	}
+
+	// A boolean `exclusiveMinimum`/`exclusiveMaximum` becomes the numeric bound
+	for _, bound := range []struct{ exclusive, inclusive string }{
+		{"exclusiveMinimum", "minimum"},
+		{"exclusiveMaximum", "maximum"},
+	} {
+		value := yamlMapGet(schema, bound.exclusive)
+		if value == nil || value.Kind != yaml.ScalarNode || value.Tag != "!!bool" {
+			continue
+		}
+		yamlMapDelete(schema, bound.exclusive)
+		if inclusive := yamlMapGet(schema, bound.inclusive); value.Value == "true" && inclusive != nil {
+			yamlMapSet(schema, bound.exclusive, inclusive)
+			yamlMapDelete(schema, bound.inclusive)
+		}
+	}
+
+	// A single `example` becomes one of the `examples`
+	if example := yamlMapGet(schema, "example"); example != nil && yamlMapGet(schema, "examples") == nil {
+		examples := newYamlSequence()
+		examples.Content = append(examples.Content, example)
+		yamlMapSet(schema, "examples", examples)
+	}
+
+	nullable := yamlScalar(yamlMapGet(schema, "nullable")) == "true"
+	for _, keyword := range openAPIOnlySchemaKeywords {
+		yamlMapDelete(schema, keyword)
+	}
+	for i := 0; i+1 < len(schema.Content); {
+		if strings.HasPrefix(schema.Content[i].Value, "x-") {
+			schema.Content = append(schema.Content[:i], schema.Content[i+2:]...)
+			continue
+		}
+		i += 2
+	}
+
+	yamlForEach(schema, func(key string, value *yaml.Node) {
+		switch key {
+		case "properties", "patternProperties", "$defs", "dependentSchemas":
+			yamlForEach(value, func(_ string, property *yaml.Node) {
+				upconvertSchema(property, ref)
+			})
+		case "items", "additionalProperties", "not", "if", "then", "else", "contains", "propertyNames",
+			"unevaluatedItems", "unevaluatedProperties", "contentSchema":
+			upconvertSchema(value, ref)
+		case "allOf", "anyOf", "oneOf", "prefixItems":
+			for _, s := range yamlSequence(value) {
+				upconvertSchema(s, ref)
+			}
+		}
+	})
+
+	if nullable {
+		upconvertNullable(schema)
+	}
+}
+
+// upconvertNullable allows a schema which was `nullable` to be `null`, by adding `null` to its type, and its `enum`, if it has one, or otherwise, such as for a reference, as an alternative to the schema
+func upconvertNullable(schema *yaml.Node) {
+	if enum := yamlMapGet(schema, "enum"); enum != nil && enum.Kind == yaml.SequenceNode {
+		enum.Content = append(enum.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"})
+	}
+
+	switch types := yamlMapGet(schema, "type"); {
+	case types != nil && types.Kind == yaml.ScalarNode:
+		sequence := newYamlSequence()
+		sequence.Content = append(sequence.Content, types, newYamlString("null"))
+		yamlMapSet(schema, "type", sequence)
+	case types != nil && types.Kind == yaml.SequenceNode:
+		types.Content = append(types.Content, newYamlString("null"))
+	default:
+		alternative := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: schema.Content}
+		null := newYamlMapping()
+		yamlMapSet(null, "type", newYamlString("null"))
+		alternatives := newYamlSequence()
+		alternatives.Content = append(alternatives.Content, alternative, null)
+		schema.Content = nil
+		yamlMapSet(schema, "anyOf", alternatives)
+	}
+}
@@ -0,0 +1,114 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONSchemas(t *testing.T) {
+	spec, err := NewLoader().LoadFromData([]byte(`
+openapi: 3.0.3
+info:
+  title: JSON Schemas
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      x-go-type-name: Animal
+      required: [id]
+      properties:
+        id:
+          type: integer
+          minimum: 0
+          exclusiveMinimum: true
+          example: 3
+        status:
+          type: string
+          enum: [available, sold]
+          nullable: true
+        owner:
+          allOf:
+            - $ref: '#/components/schemas/Owner'
+          nullable: true
+    Owner:
+      type: object
+      properties:
+        address:
+          $ref: '#/components/schemas/Address'
+    Address:
+      type: string
+`))
+	require.NoError(t, err)
+
+	documents, err := spec.JSONSchemas()
+	require.NoError(t, err)
+	require.Len(t, documents, 3)
+
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"required": ["id"],
+		"properties": {
+			"id": {"type": "integer", "exclusiveMinimum": 0, "examples": [3]},
+			"status": {"type": ["string", "null"], "enum": ["available", "sold", null]},
+			"owner": {"anyOf": [{"allOf": [{"$ref": "#/$defs/Owner"}]}, {"type": "null"}]}
+		},
+		"$defs": {
+			"Owner": {"type": "object", "properties": {"address": {"$ref": "#/$defs/Address"}}},
+			"Address": {"type": "string"}
+		}
+	}`, string(documents["Pet"]))
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "string"
+	}`, string(documents["Address"]))
+}
+
+func TestJSONSchemasRecursive(t *testing.T) {
+	spec, err := NewLoader().LoadFromData([]byte(`
+openapi: 3.1.0
+info:
+  title: JSON Schemas
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Node:
+      type: object
+      properties:
+        size:
+          type: [number, "null"]
+          exclusiveMaximum: 10
+        children:
+          type: array
+          items:
+            $ref: '#/components/schemas/Node'
+`))
+	require.NoError(t, err)
+
+	documents, err := spec.JSONSchemas()
+	require.NoError(t, err)
+
+	// A recursive schema is included in its own $defs, so that it can still be included in the $defs of others
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {
+			"size": {"type": ["number", "null"], "exclusiveMaximum": 10},
+			"children": {"type": "array", "items": {"$ref": "#/$defs/Node"}}
+		},
+		"$defs": {
+			"Node": {
+				"type": "object",
+				"properties": {
+					"size": {"type": ["number", "null"], "exclusiveMaximum": 10},
+					"children": {"type": "array", "items": {"$ref": "#/$defs/Node"}}
+				}
+			}
+		}
+	}`, string(documents["Node"]))
+}
@@ -0,0 +1,127 @@
+package openapi
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const roundTripSpec = `
+openapi: 3.0.3
+info:
+  title: Round trip
+  version: "1.0"
+paths:
+  /zebras:
+    get:
+      operationId: listZebras
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            default: 20
+            maximum: 100
+      responses:
+        "200":
+          description: Zebras
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Zebra'
+  /aardvarks:
+    get:
+      operationId: listAardvarks
+      responses:
+        "204":
+          description: Nothing
+components:
+  schemas:
+    Zebra:
+      type: object
+      required:
+        - stripes
+      properties:
+        stripes:
+          type: integer
+          format: int64
+          example: 9007199254740993
+        weight:
+          type: number
+          example: 1.50
+        wild:
+          type: boolean
+          default: true
+        code:
+          type: string
+          example: "0042"
+`
+
+// yamlToJSON converts YAML to JSON through generic values, for comparing documents semantically
+func yamlToJSON(t *testing.T, data string) string {
+	t.Helper()
+	var value any
+	require.NoError(t, yaml.Unmarshal([]byte(data), &value))
+	encoded, err := json.Marshal(value)
+	require.NoError(t, err)
+	return string(encoded)
+}
+
+func TestMarshalJSONRoundTrip(t *testing.T) {
+	swagger, err := NewLoader().LoadFromData([]byte(roundTripSpec))
+	require.NoError(t, err)
+
+	encoded, err := swagger.MarshalJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, yamlToJSON(t, roundTripSpec), string(encoded))
+
+	// Keys keep the order of the input document
+	assert.Less(t, strings.Index(string(encoded), `"/zebras"`), strings.Index(string(encoded), `"/aardvarks"`))
+	assert.Less(t, strings.Index(string(encoded), `"stripes":{`), strings.Index(string(encoded), `"weight":{`))
+
+	// Scalars keep their types, without losing precision
+	assert.Contains(t, string(encoded), `"example":9007199254740993`)
+	assert.Contains(t, string(encoded), `"example":"0042"`)
+	assert.Contains(t, string(encoded), `"version":"1.0"`)
+
+	// Loading the JSON again produces the same document
+	reloaded, err := NewLoader().LoadFromData(encoded)
+	require.NoError(t, err)
+	reencoded, err := reloaded.MarshalJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, string(encoded), string(reencoded))
+}
+
+func TestMarshalYAMLRoundTrip(t *testing.T) {
+	swagger, err := NewLoader().LoadFromData([]byte(roundTripSpec))
+	require.NoError(t, err)
+
+	encoded, err := yaml.Marshal(swagger)
+	require.NoError(t, err)
+	assert.JSONEq(t, yamlToJSON(t, roundTripSpec), yamlToJSON(t, string(encoded)))
+	assert.Less(t, strings.Index(string(encoded), "/zebras:"), strings.Index(string(encoded), "/aardvarks:"))
+
+	reloaded, err := NewLoader().LoadFromData(encoded)
+	require.NoError(t, err)
+	reencoded, err := yaml.Marshal(reloaded)
+	require.NoError(t, err)
+	assert.Equal(t, string(encoded), string(reencoded))
+}
+
+func TestMarshalEmptyDocument(t *testing.T) {
+	var swagger T
+
+	encoded, err := swagger.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, "{}", string(encoded))
+
+	yamlEncoded, err := yaml.Marshal(&swagger)
+	require.NoError(t, err)
+	assert.Equal(t, "{}\n", string(yamlEncoded))
+}
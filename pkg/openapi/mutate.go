@@ -0,0 +1,262 @@
+package openapi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"gopkg.in/yaml.v3"
+)
+
+// operationMethods are the HTTP methods which a path item can have an operation for, in lowercase
+var operationMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// isOperationMethod returns whether method, in any case, is one which a path item can have an operation for
+func isOperationMethod(method string) bool {
+	method = strings.ToLower(method)
+	for _, m := range operationMethods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// AddOperation adds an operation to the document, creating the path if it doesn't exist, such as to add a health check endpoint before generating code. It fails if the path already has an operation for the method, which can be removed with RemoveOperation first
+func (t *T) AddOperation(path, method string, operation *Operation) error {
+	if t.Document == nil {
+		return errors.New("failed to add operation: the document has no model")
+	}
+	if operation == nil || operation.Operation == nil {
+		return fmt.Errorf("failed to add operation %s %s: operation is nil", strings.ToUpper(method), path)
+	}
+	if !isOperationMethod(method) {
+		return fmt.Errorf("failed to add operation %s %s: unknown method %q", strings.ToUpper(method), path, method)
+	}
+	if !strings.HasPrefix(path, "/") {
+		return fmt.Errorf("failed to add operation %s %s: paths must start with a `/`", strings.ToUpper(method), path)
+	}
+
+	if t.Paths == nil {
+		t.Paths = &Paths{paths: t.Document.Paths, document: t.Document}
+	}
+	item := t.pathItem(path)
+	if item == nil {
+		item = &v3.PathItem{}
+		t.Paths.Set(path, WrapPathItem(item))
+	}
+	pathItem := WrapPathItem(item)
+	if pathItem.GetOperation(method) != nil {
+		return fmt.Errorf("failed to add operation %s %s: the path already has an operation for the method", strings.ToUpper(method), path)
+	}
+	pathItem.SetOperation(method, operation)
+	return nil
+}
+
+// RemoveOperation removes an operation from the document, such as an internal endpoint which shouldn't be exposed in the generated code, along with its path if it has no operations left. It returns whether there was an operation to remove
+func (t *T) RemoveOperation(path, method string) bool {
+	item := t.pathItem(path)
+	if item == nil {
+		return false
+	}
+	pathItem := WrapPathItem(item)
+	if pathItem.GetOperation(method) == nil {
+		return false
+	}
+	pathItem.SetOperation(method, nil)
+	if len(pathItem.Operations()) == 0 {
+		t.Paths.Delete(path)
+	}
+	return true
+}
+
+// RemovePath removes a path from the document, along with each of its operations. It returns whether there was a path to remove
+func (t *T) RemovePath(path string) bool {
+	if t.pathItem(path) == nil {
+		return false
+	}
+	t.Paths.Delete(path)
+	return true
+}
+
+// pathItem returns the model of the path, or nil if the document doesn't have it
+func (t *T) pathItem(path string) *v3.PathItem {
+	if t.Paths == nil || t.Paths.paths == nil || t.Paths.paths.PathItems == nil {
+		return nil
+	}
+	item, _ := t.Paths.paths.PathItems.Get(path)
+	return item
+}
+
+// AddSchema adds a schema to the document's components, so that it can be referenced as `#/components/schemas/<name>`, such as with `base.CreateSchemaProxyRef`. It fails if the components already have a schema with the name
+func (t *T) AddSchema(name string, schema *base.Schema) error {
+	if t.Document == nil {
+		return errors.New("failed to add schema: the document has no model")
+	}
+	if schema == nil {
+		return fmt.Errorf("failed to add schema %s: schema is nil", name)
+	}
+	if name == "" {
+		return errors.New("failed to add schema: name is empty")
+	}
+
+	if t.Document.Components == nil {
+		t.Document.Components = &v3.Components{}
+	}
+	if t.Document.Components.Schemas == nil {
+		t.Document.Components.Schemas = orderedmap.New[string, *base.SchemaProxy]()
+	}
+	if _, ok := t.Document.Components.Schemas.Get(name); ok {
+		return fmt.Errorf("failed to add schema %s: the components already have a schema with the name", name)
+	}
+	t.Document.Components.Schemas.Set(name, base.CreateSchemaProxy(schema))
+
+	// The schema is registered as a component first, so that references to it are restored, rather than it being inlined, and so that it isn't mistaken for a reference to itself
+	if globalComponentSchemas == nil || globalComponentSchemaNames == nil {
+		globalComponentSchemas = make(map[string]*base.Schema)
+		globalComponentSchemaNames = make(map[*base.Schema]string)
+	}
+	globalComponentSchemas[name] = schema
+	globalComponentSchemaNames[schema] = name
+
+	if t.Components == nil {
+		t.Components = &Components{}
+	}
+	if t.Components.Schemas == nil {
+		t.Components.Schemas = make(map[string]*SchemaRef)
+	}
+	t.Components.Schemas[name] = &SchemaRef{Value: WrapSchema(schema)}
+	return nil
+}
+
+// RemoveSchema removes a schema from the document's components, such as an internal model which shouldn't be exposed in the generated code. Any references to it must be removed as well, otherwise they can no longer be resolved. It returns whether there was a schema to remove
+func (t *T) RemoveSchema(name string) bool {
+	if t.Document == nil || t.Document.Components == nil || t.Document.Components.Schemas == nil {
+		return false
+	}
+	if _, ok := t.Document.Components.Schemas.Get(name); !ok {
+		return false
+	}
+	t.Document.Components.Schemas.Delete(name)
+
+	if schema, ok := globalComponentSchemas[name]; ok {
+		delete(globalComponentSchemas, name)
+		delete(globalComponentSchemaNames, schema)
+	}
+	if t.Components != nil {
+		delete(t.Components.Schemas, name)
+	}
+	return true
+}
+
+// Reload renders the document, including any changes made to it, and loads it again, the same way that it was originally loaded. The changes are reflected by the document's model as soon as they're made, but the source positions, ExternalDocuments and ContentHash of the document still refer to what it was loaded from, until it's reloaded
+func (t *T) Reload() (*T, error) {
+	if t.Document == nil {
+		return nil, errors.New("failed to reload document: the document has no model")
+	}
+	rendered, err := t.Render()
+	if err != nil {
+		return nil, fmt.Errorf("failed to render document: %w", err)
+	}
+	loader := t.loader
+	if loader == nil {
+		loader = NewLoader()
+	}
+	// The overlays were applied when the document was first loaded, so applying them again would apply them twice
+	reloaded, err := loader.withoutOverlays().LoadFromDataWithBasePath(rendered, t.basePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reload document: %w", err)
+	}
+	return reloaded, nil
+}
+
+// Rewrite renders the document as YAML, which rewrite changes in place, and loads the result the same way that the document was originally loaded, such as to normalize its schemas before generating code. As with Reload, the source positions of the rewritten document are those of the rendered YAML, rather than of what it was originally loaded from
+func (t *T) Rewrite(rewrite func(root *yaml.Node) error) (*T, error) {
+	if t.Document == nil {
+		return nil, errors.New("failed to rewrite document: the document has no model")
+	}
+	rendered, err := t.Render()
+	if err != nil {
+		return nil, fmt.Errorf("failed to render document: %w", err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(rendered, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse rendered document: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, errors.New("failed to rewrite document: the rendered document is empty")
+	}
+	if err := rewrite(doc.Content[0]); err != nil {
+		return nil, err
+	}
+	rewritten, err := yaml.Marshal(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize rewritten document: %w", err)
+	}
+
+	loader := t.loader
+	if loader == nil {
+		loader = NewLoader()
+	}
+	reloaded, err := loader.withoutOverlays().LoadFromDataWithBasePath(rewritten, t.basePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rewritten document: %w", err)
+	}
+	return reloaded, nil
+}
+
+// Clone returns a deep copy of the document, so that generating code from the copy, which filters and prunes it, leaves the original whole, such as to serve or embed the full spec. The copy is built from the document as it was loaded, so it keeps the same source positions, unless the document's model has been changed since, in which case the copy is of the changed document, rendered and loaded again as Reload does. As with loading a document, references to the components of the copy are what are restored while generating code, from then on
+func (t *T) Clone() (*T, error) {
+	if t.Document == nil {
+		return nil, errors.New("failed to clone document: the document has no model")
+	}
+	loader := t.loader
+	if loader == nil {
+		loader = NewLoader()
+	}
+
+	if low := t.Document.GoLow(); low != nil {
+		model := v3.NewDocument(low)
+		model.Rolodex = t.Document.Rolodex
+		rendered, err := t.Render()
+		if err != nil {
+			return nil, fmt.Errorf("failed to render document: %w", err)
+		}
+		renderedClone, err := model.Render()
+		if err != nil {
+			return nil, fmt.Errorf("failed to render cloned document: %w", err)
+		}
+		// The model built from the document as it was loaded only lacks any changes made to the original since
+		if bytes.Equal(rendered, renderedClone) {
+			clone := loader.wrapDocument(model)
+			clone.loadResult = LoadResult{Warnings: slices.Clone(t.loadResult.Warnings)}
+			clone.loader = loader
+			clone.basePath = t.basePath
+			return clone, nil
+		}
+	}
+
+	clone, err := t.Reload()
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone document: %w", err)
+	}
+	return clone, nil
+}
+
+// Snapshot returns a deep copy of the document as Clone does, but without changing which components' references are restored while generating code, so that the copy can be rendered, such as to embed the spec as it was before it was filtered, while code is still generated from the original. Code mustn't be generated from the copy itself, which Clone is for
+func (t *T) Snapshot() (*T, error) {
+	componentSchemas, componentSchemaNames := globalComponentSchemas, globalComponentSchemaNames
+	defer func() {
+		globalComponentSchemas, globalComponentSchemaNames = componentSchemas, componentSchemaNames
+	}()
+	snapshot, err := t.Clone()
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot document: %w", err)
+	}
+	return snapshot, nil
+}
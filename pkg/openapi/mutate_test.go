@@ -0,0 +1,193 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const mutateSpec = `openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: OK
+  /internal/debug:
+    get:
+      operationId: debug
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Debug'
+components:
+  schemas:
+    Pet:
+      type: object
+    Debug:
+      type: object
+`
+
+func TestMutations(t *testing.T) {
+	swagger, err := NewLoader().LoadFromData([]byte(mutateSpec))
+	require.NoError(t, err)
+
+	require.NoError(t, swagger.AddSchema("Health", &base.Schema{Type: []string{"string"}}))
+	require.ErrorContains(t, swagger.AddSchema("Pet", &base.Schema{}), "already have a schema")
+
+	content := orderedmap.New[string, *v3.MediaType]()
+	content.Set("application/json", &v3.MediaType{Schema: base.CreateSchemaProxyRef("#/components/schemas/Health")})
+	codes := orderedmap.New[string, *v3.Response]()
+	codes.Set("200", &v3.Response{Description: "OK", Content: content})
+	health := WrapOperation(&v3.Operation{OperationId: "health", Responses: &v3.Responses{Codes: codes}})
+	require.NoError(t, swagger.AddOperation("/healthz", "get", health))
+	require.ErrorContains(t, swagger.AddOperation("/pets", "GET", health), "already has an operation")
+	require.ErrorContains(t, swagger.AddOperation("/pets", "FETCH", health), "unknown method")
+
+	assert.True(t, swagger.RemoveOperation("/internal/debug", "GET"))
+	assert.False(t, swagger.RemoveOperation("/internal/debug", "GET"))
+	assert.True(t, swagger.RemoveSchema("Debug"))
+	assert.False(t, swagger.RemoveSchema("Debug"))
+
+	var paths []string
+	for path := range swagger.Document.Paths.PathItems.KeysFromOldest() {
+		paths = append(paths, path)
+	}
+	assert.Equal(t, []string{"/pets", "/healthz"}, paths, "the paths keep their order, and paths without operations are removed")
+	require.Contains(t, swagger.Components.Schemas, "Health")
+	assert.NotContains(t, swagger.Components.Schemas, "Debug")
+	schema := swagger.Paths.Find("/healthz").GetOperation("GET").Responses.Value("200").Value.Content["application/json"].Schema
+	assert.Equal(t, "#/components/schemas/Health", schema.Ref, "references to added schemas are kept")
+
+	rendered, err := swagger.Render()
+	require.NoError(t, err)
+	assert.NotContains(t, string(rendered), "debug")
+
+	reloaded, err := swagger.Reload()
+	require.NoError(t, err)
+	assert.Empty(t, reloaded.LoadResult().Warnings)
+	assert.ElementsMatch(t, []string{"/pets", "/healthz"}, keys(reloaded.Paths.Map()))
+	assert.ElementsMatch(t, []string{"Pet", "Health"}, keys(reloaded.Components.Schemas))
+	schema = reloaded.Paths.Find("/healthz").GetOperation("GET").Responses.Value("200").Value.Content["application/json"].Schema
+	assert.Equal(t, "#/components/schemas/Health", schema.Ref)
+	assert.True(t, schema.Value.TypeIs("string"))
+
+	t.Run("a document without paths or components", func(t *testing.T) {
+		swagger, err := NewLoader().LoadFromData([]byte("openapi: 3.1.0\ninfo:\n  title: Empty\n  version: 1.0.0\n"))
+		require.NoError(t, err)
+		require.NoError(t, swagger.AddSchema("Health", &base.Schema{Type: []string{"string"}}))
+		require.NoError(t, swagger.AddOperation("/healthz", "GET", health))
+		assert.NotNil(t, swagger.Paths.Find("/healthz").GetOperation("GET"))
+		assert.Contains(t, swagger.Components.Schemas, "Health")
+		assert.False(t, swagger.RemovePath("/pets"))
+		assert.True(t, swagger.RemovePath("/healthz"))
+	})
+}
+
+func TestPathsAndResponsesMutation(t *testing.T) {
+	swagger, err := NewLoader().LoadFromData([]byte(mutateSpec))
+	require.NoError(t, err)
+
+	t.Run("paths", func(t *testing.T) {
+		assert.Equal(t, 2, swagger.Paths.Len())
+		swagger.Paths.Set("/owners", WrapPathItem(&v3.PathItem{Get: &v3.Operation{OperationId: "listOwners"}}))
+		assert.Equal(t, 3, swagger.Paths.Len())
+		assert.Equal(t, "listOwners", swagger.Paths.Find("/owners").GetOperation("GET").OperationId)
+
+		swagger.Paths.Set("/owners", WrapPathItem(&v3.PathItem{Post: &v3.Operation{OperationId: "addOwner"}}))
+		assert.Nil(t, swagger.Paths.Find("/owners").GetOperation("GET"), "setting a path replaces its path item")
+		assert.Equal(t, 3, swagger.Paths.Len())
+
+		swagger.Paths.Delete("/internal/debug")
+		swagger.Paths.Set("/owners", nil)
+		assert.ElementsMatch(t, []string{"/pets"}, keys(swagger.Paths.Map()))
+
+		rendered, err := swagger.Render()
+		require.NoError(t, err)
+		assert.NotContains(t, string(rendered), "/owners")
+		assert.NotContains(t, string(rendered), "/internal/debug")
+	})
+
+	t.Run("responses", func(t *testing.T) {
+		op := swagger.Paths.Find("/pets").GetOperation("GET")
+		op.Responses.Set("404", &ResponseRef{Value: WrapResponse(&v3.Response{Description: "Not found"})})
+		op.Responses.Set("default", &ResponseRef{Value: WrapResponse(&v3.Response{Description: "Error"})})
+		assert.Equal(t, 3, op.Responses.Len())
+		assert.ElementsMatch(t, []string{"200", "404", "default"}, keys(op.Responses.Map()))
+
+		op.Responses.Delete("200")
+		op.Responses.Set("default", nil)
+		assert.ElementsMatch(t, []string{"404"}, keys(op.Responses.Map()))
+
+		reloaded, err := swagger.Reload()
+		require.NoError(t, err)
+		responses := reloaded.Paths.Find("/pets").GetOperation("GET").Responses
+		assert.ElementsMatch(t, []string{"404"}, keys(responses.Map()), "the changes are kept when the document is rendered")
+		assert.Equal(t, "Not found", responses.Value("404").Value.Description)
+	})
+
+	t.Run("an operation without responses", func(t *testing.T) {
+		op := WrapOperation(&v3.Operation{OperationId: "health"})
+		assert.Zero(t, op.Responses.Len())
+		op.AddResponse("204", &ResponseRef{Value: WrapResponse(&v3.Response{Description: "Healthy"})})
+		require.NotNil(t, op.Operation.Responses)
+		assert.Equal(t, "Healthy", op.Responses.Value("204").Value.Description)
+	})
+
+	t.Run("a document without paths", func(t *testing.T) {
+		swagger, err := NewLoader().LoadFromData([]byte("openapi: 3.1.0\ninfo:\n  title: Empty\n  version: 1.0.0\n"))
+		require.NoError(t, err)
+		assert.Zero(t, swagger.Paths.Len())
+		swagger.Paths.Set("/healthz", WrapPathItem(&v3.PathItem{Get: &v3.Operation{OperationId: "health"}}))
+		assert.Equal(t, 1, swagger.Paths.Len())
+		rendered, err := swagger.Render()
+		require.NoError(t, err)
+		assert.Contains(t, string(rendered), "/healthz")
+	})
+}
+
+func TestClone(t *testing.T) {
+	swagger, err := NewLoader().LoadFromData([]byte(mutateSpec))
+	require.NoError(t, err)
+
+	clone, err := swagger.Clone()
+	require.NoError(t, err)
+	assert.NotSame(t, swagger.Document, clone.Document)
+	assert.Equal(t, swagger.Components.Schemas["Pet"].Value.Position(), clone.Components.Schemas["Pet"].Value.Position(), "the clone keeps the source positions")
+
+	assert.True(t, clone.RemovePath("/internal/debug"))
+	assert.True(t, clone.RemoveSchema("Debug"))
+	clone.Paths.Find("/pets").SetOperation("GET", nil)
+	delete(clone.Components.Schemas, "Pet")
+
+	assert.ElementsMatch(t, []string{"/pets", "/internal/debug"}, keys(swagger.Paths.Map()), "changing the clone doesn't change the original")
+	assert.NotNil(t, swagger.Paths.Find("/pets").GetOperation("GET"))
+	assert.ElementsMatch(t, []string{"Pet", "Debug"}, keys(swagger.Components.Schemas))
+	rendered, err := swagger.Render()
+	require.NoError(t, err)
+	assert.Contains(t, string(rendered), "listPets")
+	assert.Contains(t, string(rendered), "Debug")
+
+	t.Run("a changed document", func(t *testing.T) {
+		swagger, err := NewLoader().LoadFromData([]byte(mutateSpec))
+		require.NoError(t, err)
+		require.NoError(t, swagger.AddSchema("Health", &base.Schema{Type: []string{"string"}}))
+
+		clone, err := swagger.Clone()
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"Pet", "Debug", "Health"}, keys(clone.Components.Schemas), "the clone has the changes")
+		assert.True(t, clone.RemoveSchema("Health"))
+		assert.Contains(t, swagger.Components.Schemas, "Health")
+	})
+}
@@ -0,0 +1,157 @@
+package normalize
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// annotationKeywords are the keywords of a schema which don't change what it describes, so are ignored when comparing schemas
+var annotationKeywords = map[string]bool{"description": true, "title": true, "example": true, "examples": true}
+
+// DedupeInlineSchemas replaces inline objects which are structurally identical, that is, which only differ by their `description`, `title` or examples, with a reference to a single component schema, so that a single type is generated for them.
+// When one of them is a component schema already, the others refer to it. Otherwise, a component is added for them, named from the `title` of the first of them, or the property or operation it belongs to, such as `Address` for a property called `address`. The `description` of each of them is kept alongside its reference
+type DedupeInlineSchemas struct{}
+
+func (DedupeInlineSchemas) Name() string {
+	return "dedupe-inline-schemas"
+}
+
+func (DedupeInlineSchemas) Apply(doc *Document) error {
+	// Each round replaces the inline objects of one group, so that the groups of the next round are found in the document as it's changed
+	for {
+		var order []string
+		groups := make(map[string][]schemaSite)
+		walkSchemas(doc.Root, func(site schemaSite) {
+			if mapGet(site.Node, "$ref") != nil || len(mapKeys(mapGet(site.Node, "properties"))) == 0 {
+				return
+			}
+			key, err := structuralKey(site.Node)
+			if err != nil {
+				return
+			}
+			if _, ok := groups[key]; !ok {
+				order = append(order, key)
+			}
+			groups[key] = append(groups[key], site)
+		})
+
+		deduped := false
+		for _, key := range order {
+			if dedupe(doc.Root, groups[key]) {
+				deduped = true
+				break
+			}
+		}
+		if !deduped {
+			return nil
+		}
+	}
+}
+
+// dedupe replaces each inline object of a group of structurally identical schemas with a reference to a component, returning false if there aren't any to replace
+func dedupe(root *yaml.Node, sites []schemaSite) bool {
+	var target string
+	var inline []schemaSite
+	for _, site := range sites {
+		if site.Component && target == "" {
+			target = site.Name
+		} else if !site.Component {
+			inline = append(inline, site)
+		}
+	}
+	if len(inline) == 0 || (target == "" && len(inline) < 2) {
+		return false
+	}
+
+	if target == "" {
+		components := mapGet(root, "components")
+		if components == nil {
+			components = newMapping()
+			mapSet(root, "components", components)
+		}
+		schemas := mapGet(components, "schemas")
+		if schemas == nil {
+			schemas = newMapping()
+			mapSet(components, "schemas", schemas)
+		}
+		target = componentName(schemas, inline[0])
+		// The description of each of them is kept alongside its reference instead
+		component := deepCopy(inline[0].Node)
+		mapDelete(component, "description")
+		mapSet(schemas, target, component)
+	}
+
+	for _, site := range inline {
+		ref := newMapping()
+		mapSet(ref, "$ref", newString("#/components/schemas/"+target))
+		if description := mapGet(site.Node, "description"); description != nil {
+			mapSet(ref, "description", deepCopy(description))
+		}
+		replace(site.Node, ref)
+	}
+	return true
+}
+
+// structuralKey returns what identifies the structure of a schema, which is the same for schemas which only differ by their annotations, or the order of their keywords
+func structuralKey(schema *yaml.Node) (string, error) {
+	stripped := deepCopy(schema)
+	for keyword := range annotationKeywords {
+		mapDelete(stripped, keyword)
+	}
+	sortKeys(stripped)
+	key, err := yaml.Marshal(stripped)
+	return string(key), err
+}
+
+// sortKeys sorts the keys of each mapping within n, in place
+func sortKeys(n *yaml.Node) {
+	for _, child := range n.Content {
+		sortKeys(child)
+	}
+	if n.Kind != yaml.MappingNode {
+		return
+	}
+	pairs := make([][2]*yaml.Node, 0, len(n.Content)/2)
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		pairs = append(pairs, [2]*yaml.Node{n.Content[i], n.Content[i+1]})
+	}
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return pairs[i][0].Value < pairs[j][0].Value
+	})
+	n.Content = n.Content[:0]
+	for _, pair := range pairs {
+		n.Content = append(n.Content, pair[0], pair[1])
+	}
+}
+
+// componentName returns a name for a component schema created for the schema at site, from its `title`, or otherwise the name of what it belongs to, which none of the existing schemas has
+func componentName(schemas *yaml.Node, site schemaSite) string {
+	source := site.Name
+	if title := mapGet(site.Node, "title"); title != nil && title.Value != "" {
+		source = title.Value
+	}
+
+	var name strings.Builder
+	for _, word := range strings.FieldsFunc(source, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		runes := []rune(word)
+		name.WriteString(strings.ToUpper(string(runes[0])) + string(runes[1:]))
+	}
+	base := name.String()
+	if base == "" {
+		base = "InlineSchema"
+	} else if unicode.IsDigit([]rune(base)[0]) {
+		base = "Schema" + base
+	}
+
+	candidate := base
+	for i := 2; mapGet(schemas, candidate) != nil; i++ {
+		candidate = fmt.Sprintf("%s%d", base, i)
+	}
+	return candidate
+}
@@ -0,0 +1,75 @@
+package normalize
+
+import (
+	"gopkg.in/yaml.v3"
+)
+
+// tinyEnumKeywords are the keywords which a component schema can have, other than its `enum`, to be inlined by InlineTinyEnums
+var tinyEnumKeywords = map[string]bool{"type": true, "enum": true, "description": true, "title": true, "nullable": true, "default": true, "example": true}
+
+// InlineTinyEnums replaces each reference to a component schema which is only an enum, of at most MaxValues values, with a copy of the enum, and removes the component, such as for the single value of a discriminator, which is often given a component of its own.
+// Any keywords alongside a reference are kept, unless the enum has them too, in which case the reference, and the component, are left as they are
+type InlineTinyEnums struct {
+	// MaxValues is the largest number of values of an enum which is inlined. It defaults to 1
+	MaxValues int
+}
+
+func (InlineTinyEnums) Name() string {
+	return "inline-tiny-enums"
+}
+
+func (p InlineTinyEnums) Apply(doc *Document) error {
+	maxValues := p.MaxValues
+	if maxValues <= 0 {
+		maxValues = 1
+	}
+
+	schemas := mapGet(mapGet(doc.Root, "components"), "schemas")
+	enums := make(map[string]*yaml.Node)
+	forEach(schemas, func(name string, schema *yaml.Node) {
+		if isTinyEnum(schema, maxValues) {
+			enums["#/components/schemas/"+name] = schema
+		}
+	})
+	if len(enums) == 0 {
+		return nil
+	}
+
+	kept := make(map[string]bool)
+	walkRefs(doc.Root, func(node *yaml.Node, ref string) {
+		enum, ok := enums[ref]
+		if !ok {
+			return
+		}
+		inlined := deepCopy(enum)
+		for _, sibling := range without(mapKeys(node), "$ref") {
+			if mapGet(enum, sibling) != nil {
+				kept[ref] = true
+				return
+			}
+			mapSet(inlined, sibling, deepCopy(mapGet(node, sibling)))
+		}
+		replace(node, inlined)
+	})
+
+	for _, name := range mapKeys(schemas) {
+		if _, ok := enums["#/components/schemas/"+name]; ok && !kept["#/components/schemas/"+name] {
+			mapDelete(schemas, name)
+		}
+	}
+	return nil
+}
+
+// isTinyEnum returns whether schema is only an enum, of at most maxValues values
+func isTinyEnum(schema *yaml.Node, maxValues int) bool {
+	enum := mapGet(schema, "enum")
+	if enum == nil || enum.Kind != yaml.SequenceNode || len(enum.Content) == 0 || len(enum.Content) > maxValues {
+		return false
+	}
+	for _, key := range mapKeys(schema) {
+		if !tinyEnumKeywords[key] {
+			return false
+		}
+	}
+	return true
+}
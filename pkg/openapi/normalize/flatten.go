@@ -0,0 +1,29 @@
+package normalize
+
+import (
+	"gopkg.in/yaml.v3"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+// FlattenCompositions replaces a schema which is only an `allOf` of a single reference, or a `oneOf` of a single schema, with that schema.
+// It unwraps exactly what the Loader does with SimplifyCompositions, as decided by openapi.SingleMemberComposition, so that a spec is generated the same way whether it's the pass or the Loader which unwraps it, such as with `compatibility.keep-single-member-compositions`, and `-dump-normalized` shows what's unwrapped
+type FlattenCompositions struct{}
+
+func (FlattenCompositions) Name() string {
+	return "flatten-compositions"
+}
+
+func (FlattenCompositions) Apply(doc *Document) error {
+	var schemas []*yaml.Node
+	walkSchemas(doc.Root, func(site schemaSite) {
+		schemas = append(schemas, site.Node)
+	})
+	// Nested schemas are flattened first, so that i.e. a `oneOf` of an `allOf` of a reference becomes the reference
+	for i := len(schemas) - 1; i >= 0; i-- {
+		if member := openapi.SingleMemberComposition(schemas[i]); member != nil {
+			replace(schemas[i], member)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+// Package normalize rewrites the schemas of an OpenAPI document into simpler, equivalent forms before code is generated for it, such as the schemas produced by other generators, which would otherwise generate needless types.
+//
+// Each rewrite is a Pass, which can be selected by name in Config, or written for a particular spec and passed to Normalize alongside the built-in passes.
+package normalize
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+// Document is a spec which passes rewrite, as YAML
+type Document struct {
+	// Root is the document's root mapping, which passes change in place
+	Root *yaml.Node
+	// OpenAPI31 is whether the spec is OpenAPI 3.1, whose schemas can use the `null` type, rather than `nullable`
+	OpenAPI31 bool
+}
+
+// Pass rewrites the schemas of a document
+type Pass interface {
+	// Name is how the pass is selected in Config
+	Name() string
+	// Apply rewrites the document in place
+	Apply(doc *Document) error
+}
+
+// Config selects which of the built-in passes normalize a spec, and configures them
+type Config struct {
+	// Passes are the names of the passes to run, in order. The built-in passes are:
+	//
+	// - `flatten-compositions`: a schema which is nothing but an `allOf` of a single reference, or a `oneOf` of a single schema, becomes that schema, as the Loader does with SimplifyCompositions
+	// - `pull-up-nullability`: an `anyOf` or `oneOf` of a schema and `null` becomes the schema, marked as nullable
+	// - `inline-tiny-enums`: a reference to a component schema which is only an enum, with at most `MaxInlineEnumValues` values, is replaced by a copy of the enum
+	// - `dedupe-inline-schemas`: inline objects which are structurally identical become a single component schema, which each of them refers to
+	Passes []string `yaml:"passes,omitempty"`
+	// MaxInlineEnumValues is the largest number of values of an enum which `inline-tiny-enums` inlines. It defaults to 1
+	MaxInlineEnumValues int `yaml:"max-inline-enum-values,omitempty"`
+}
+
+// builtinPasses creates each of the built-in passes, by name
+var builtinPasses = map[string]func(cfg Config) Pass{
+	"flatten-compositions":  func(Config) Pass { return FlattenCompositions{} },
+	"pull-up-nullability":   func(Config) Pass { return PullUpNullability{} },
+	"inline-tiny-enums":     func(cfg Config) Pass { return InlineTinyEnums{MaxValues: cfg.MaxInlineEnumValues} },
+	"dedupe-inline-schemas": func(Config) Pass { return DedupeInlineSchemas{} },
+}
+
+// PassNames returns the names of the built-in passes, sorted
+func PassNames() []string {
+	names := make([]string, 0, len(builtinPasses))
+	for name := range builtinPasses {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Build returns the passes selected by the Config, in order, or an error if any of them isn't a built-in pass
+func (c Config) Build() ([]Pass, error) {
+	passes := make([]Pass, 0, len(c.Passes))
+	for _, name := range c.Passes {
+		newPass, ok := builtinPasses[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown normalize pass %q, which must be one of %s", name, strings.Join(PassNames(), ", "))
+		}
+		passes = append(passes, newPass(c))
+	}
+	return passes, nil
+}
+
+// Normalize returns a copy of the spec which has been rewritten by each of the passes, in order, and loaded again, the same way the spec was.
+// As the rewritten spec is loaded from YAML, the positions in it, which errors while generating code refer to, are those of the rewritten YAML, rather than of the original spec. With no passes, the spec is returned as-is
+func Normalize(spec *openapi.T, passes ...Pass) (*openapi.T, error) {
+	if len(passes) == 0 {
+		return spec, nil
+	}
+	return spec.Rewrite(func(root *yaml.Node) error {
+		doc := &Document{
+			Root:      root,
+			OpenAPI31: spec.IsOpenAPI31(),
+		}
+		for _, pass := range passes {
+			if err := pass.Apply(doc); err != nil {
+				return fmt.Errorf("error in normalize pass %s: %w", pass.Name(), err)
+			}
+		}
+		return nil
+	})
+}
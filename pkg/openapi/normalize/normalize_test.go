@@ -0,0 +1,325 @@
+package normalize
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
+)
+
+// apply runs the pass over the spec, returning the rewritten spec as YAML
+func apply(t *testing.T, pass Pass, spec string, openAPI31 bool) string {
+	t.Helper()
+	var doc yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(spec), &doc))
+	require.NoError(t, pass.Apply(&Document{Root: doc.Content[0], OpenAPI31: openAPI31}))
+	var out strings.Builder
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	require.NoError(t, encoder.Encode(&doc))
+	return out.String()
+}
+
+func TestFlattenCompositions(t *testing.T) {
+	out := apply(t, FlattenCompositions{}, `components:
+  schemas:
+    Owner:
+      type: object
+    Pet:
+      type: object
+      properties:
+        owner:
+          oneOf:
+            - allOf:
+                - $ref: '#/components/schemas/Owner'
+        previousOwner:
+          allOf:
+            - $ref: '#/components/schemas/Owner'
+          description: Who owned the pet
+        tag:
+          oneOf:
+            - type: string
+              maxLength: 10
+        keeper:
+          allOf:
+            - $ref: '#/components/schemas/Owner'
+          readOnly: true
+        name:
+          anyOf:
+            - type: string
+          maxLength: 10
+        kind:
+          oneOf:
+            - $ref: '#/components/schemas/Owner'
+          discriminator:
+            propertyName: kind
+`, false)
+
+	assert.Equal(t, `components:
+  schemas:
+    Owner:
+      type: object
+    Pet:
+      type: object
+      properties:
+        owner:
+          $ref: '#/components/schemas/Owner'
+        previousOwner:
+          allOf:
+            - $ref: '#/components/schemas/Owner'
+          description: Who owned the pet
+        tag:
+          type: string
+          maxLength: 10
+        keeper:
+          allOf:
+            - $ref: '#/components/schemas/Owner'
+          readOnly: true
+        name:
+          anyOf:
+            - type: string
+          maxLength: 10
+        kind:
+          oneOf:
+            - $ref: '#/components/schemas/Owner'
+          discriminator:
+            propertyName: kind
+`, out)
+}
+
+func TestPullUpNullability(t *testing.T) {
+	spec := `components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        owner:
+          anyOf:
+            - $ref: '#/components/schemas/Owner'
+            - type: "null"
+        name:
+          oneOf:
+            - enum: [null]
+            - type: string
+              maxLength: 10
+        tags:
+          anyOf:
+            - type: string
+            - type: integer
+`
+
+	t.Run("OpenAPI 3.0", func(t *testing.T) {
+		out := apply(t, PullUpNullability{}, spec, false)
+		assert.Contains(t, out, `        owner:
+          $ref: '#/components/schemas/Owner'
+          nullable: true
+`)
+		assert.Contains(t, out, `        name:
+          type: string
+          maxLength: 10
+          nullable: true
+`)
+		assert.Contains(t, out, `        tags:
+          anyOf:
+`)
+	})
+
+	t.Run("OpenAPI 3.1", func(t *testing.T) {
+		out := apply(t, PullUpNullability{}, spec, true)
+		assert.Contains(t, out, `        owner:
+          $ref: '#/components/schemas/Owner'
+          nullable: true
+`)
+		assert.Contains(t, out, `        name:
+          type: [string, "null"]
+          maxLength: 10
+`)
+	})
+}
+
+func TestInlineTinyEnums(t *testing.T) {
+	spec := `paths:
+  /pets:
+    get:
+      parameters:
+        - name: kind
+          in: query
+          schema:
+            $ref: '#/components/schemas/Kind'
+components:
+  schemas:
+    Kind:
+      type: string
+      enum: [dog]
+    Colour:
+      type: string
+      enum: [black, white]
+    Pet:
+      type: object
+      properties:
+        kind:
+          $ref: '#/components/schemas/Kind'
+          description: The kind of pet
+        colour:
+          $ref: '#/components/schemas/Colour'
+`
+
+	t.Run("single values", func(t *testing.T) {
+		out := apply(t, InlineTinyEnums{}, spec, false)
+		assert.NotContains(t, out, "#/components/schemas/Kind")
+		assert.NotContains(t, out, "    Kind:")
+		assert.Contains(t, out, `          schema:
+            type: string
+            enum: [dog]
+`)
+		assert.Contains(t, out, `        kind:
+          type: string
+          enum: [dog]
+          description: The kind of pet
+`)
+		assert.Contains(t, out, "$ref: '#/components/schemas/Colour'")
+	})
+
+	t.Run("larger enums", func(t *testing.T) {
+		out := apply(t, InlineTinyEnums{MaxValues: 2}, spec, false)
+		assert.NotContains(t, out, "#/components/schemas/")
+	})
+
+	t.Run("conflicting keywords", func(t *testing.T) {
+		out := apply(t, InlineTinyEnums{}, `components:
+  schemas:
+    Kind:
+      type: string
+      enum: [dog]
+      description: A kind
+    Pet:
+      type: object
+      properties:
+        kind:
+          $ref: '#/components/schemas/Kind'
+          description: The kind of pet
+`, false)
+		assert.Contains(t, out, "    Kind:")
+		assert.Contains(t, out, "$ref: '#/components/schemas/Kind'")
+	})
+}
+
+func TestDedupeInlineSchemas(t *testing.T) {
+	out := apply(t, DedupeInlineSchemas{}, `paths:
+  /shops:
+    get:
+      operationId: listShops
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  street:
+                    type: string
+                  city:
+                    type: string
+components:
+  schemas:
+    Person:
+      type: object
+      properties:
+        home_address:
+          description: Where they live
+          type: object
+          properties:
+            street:
+              type: string
+            city:
+              type: string
+        work_address:
+          type: object
+          properties:
+            city:
+              type: string
+            street:
+              type: string
+    Location:
+      type: object
+      properties:
+        latitude:
+          type: number
+    Pet:
+      type: object
+      properties:
+        location:
+          type: object
+          properties:
+            latitude:
+              type: number
+`, false)
+
+	assert.Contains(t, out, `        home_address:
+          $ref: '#/components/schemas/HomeAddress'
+          description: Where they live
+        work_address:
+          $ref: '#/components/schemas/HomeAddress'
+`)
+	assert.Contains(t, out, `              schema:
+                $ref: '#/components/schemas/HomeAddress'
+`)
+	assert.Contains(t, out, `    HomeAddress:
+      type: object
+      properties:
+        street:
+          type: string
+        city:
+          type: string
+`)
+	assert.Contains(t, out, `        location:
+          $ref: '#/components/schemas/Location'
+`)
+}
+
+func TestConfigBuild(t *testing.T) {
+	passes, err := Config{Passes: []string{"inline-tiny-enums", "flatten-compositions"}, MaxInlineEnumValues: 3}.Build()
+	require.NoError(t, err)
+	assert.Equal(t, []Pass{InlineTinyEnums{MaxValues: 3}, FlattenCompositions{}}, passes)
+
+	_, err = Config{Passes: []string{"inline-everything"}}.Build()
+	assert.ErrorContains(t, err, `unknown normalize pass "inline-everything"`)
+}
+
+func TestNormalize(t *testing.T) {
+	spec, err := openapi.NewLoader().LoadFromData([]byte(`openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Kind:
+      type: string
+      enum: [dog]
+    Pet:
+      type: object
+      properties:
+        kind:
+          $ref: '#/components/schemas/Kind'
+`))
+	require.NoError(t, err)
+
+	same, err := Normalize(spec)
+	require.NoError(t, err)
+	assert.Same(t, spec, same)
+
+	normalized, err := Normalize(spec, InlineTinyEnums{})
+	require.NoError(t, err)
+	assert.NotNil(t, spec.Components.Schemas["Kind"])
+	assert.Nil(t, normalized.Components.Schemas["Kind"])
+	kind := normalized.Components.Schemas["Pet"].Value.PropertiesToMap()["kind"]
+	require.NotNil(t, kind)
+	assert.Empty(t, kind.Ref)
+	assert.Equal(t, []interface{}{"dog"}, kind.Value.Enum())
+}
@@ -0,0 +1,149 @@
+package normalize
+
+import (
+	"gopkg.in/yaml.v3"
+)
+
+// compositionKeywords are the keywords which compose a schema from others
+var compositionKeywords = map[string]bool{"allOf": true, "anyOf": true, "oneOf": true}
+
+// refSiblingKeywords are the keywords which can be kept alongside a reference which a schema is rewritten into, as the generated code still uses them
+var refSiblingKeywords = map[string]bool{"description": true, "nullable": true, "deprecated": true}
+
+// PullUpNullability replaces an `anyOf` or `oneOf` of a schema and `null` with the schema, marked as nullable, so that it's generated as a nullable field, rather than a union.
+// In OpenAPI 3.0, the schema is marked with `nullable: true`. In OpenAPI 3.1, `null` is added to the schema's `type`, unless it's a reference, which is marked with `nullable: true` alongside it, as the generated code does for either version. A schema of OpenAPI 3.1 without a `type` is left as it is.
+// `null` is a schema whose only keyword is a `type` of `null`, an `enum` of only `null`, or a `const` of `null`
+type PullUpNullability struct{}
+
+func (PullUpNullability) Name() string {
+	return "pull-up-nullability"
+}
+
+func (PullUpNullability) Apply(doc *Document) error {
+	var schemas []*yaml.Node
+	walkSchemas(doc.Root, func(site schemaSite) {
+		schemas = append(schemas, site.Node)
+	})
+	for i := len(schemas) - 1; i >= 0; i-- {
+		schema := schemas[i]
+		keyword, members, siblings := composition(schema)
+		if (keyword != "anyOf" && keyword != "oneOf") || len(members) != 2 || mapGet(schema, "discriminator") != nil {
+			continue
+		}
+
+		var member *yaml.Node
+		switch {
+		case isNullSchema(members[0]) && !isNullSchema(members[1]):
+			member = members[1]
+		case isNullSchema(members[1]) && !isNullSchema(members[0]):
+			member = members[0]
+		default:
+			continue
+		}
+		// Whether the schema is already nullable is replaced by it being nullable
+		siblings = without(siblings, "nullable")
+		pulled := mergeSiblings(member, schema, siblings)
+		if pulled == nil || !markNullable(pulled, doc.OpenAPI31) {
+			continue
+		}
+		replace(schema, pulled)
+	}
+	return nil
+}
+
+// composition returns the only composition keyword of a schema, along with its schemas and the schema's other keywords. The keyword is empty if the schema has none, or more than one
+func composition(schema *yaml.Node) (string, []*yaml.Node, []string) {
+	var keyword string
+	var members []*yaml.Node
+	var siblings []string
+	for _, key := range mapKeys(schema) {
+		if !compositionKeywords[key] {
+			siblings = append(siblings, key)
+			continue
+		}
+		if keyword != "" {
+			return "", nil, nil
+		}
+		list := mapGet(schema, key)
+		if list.Kind != yaml.SequenceNode {
+			return "", nil, nil
+		}
+		keyword, members = key, list.Content
+	}
+	return keyword, members, siblings
+}
+
+// mergeSiblings returns a copy of member with the siblings of the schema it's composed into, or nil if they can't be kept alongside it
+func mergeSiblings(member, schema *yaml.Node, siblings []string) *yaml.Node {
+	if member.Kind != yaml.MappingNode {
+		return nil
+	}
+	isRef := mapGet(member, "$ref") != nil
+	merged := deepCopy(member)
+	for _, sibling := range siblings {
+		if mapGet(member, sibling) != nil || (isRef && !refSiblingKeywords[sibling]) {
+			return nil
+		}
+		mapSet(merged, sibling, deepCopy(mapGet(schema, sibling)))
+	}
+	return merged
+}
+
+// isNullSchema returns whether schema only allows `null`
+func isNullSchema(schema *yaml.Node) bool {
+	if schema == nil || schema.Kind != yaml.MappingNode || len(schema.Content) != 2 {
+		return false
+	}
+	value := schema.Content[1]
+	switch schema.Content[0].Value {
+	case "type":
+		if value.Kind == yaml.SequenceNode && len(value.Content) == 1 {
+			value = value.Content[0]
+		}
+		return value.Kind == yaml.ScalarNode && value.Value == "null"
+	case "enum":
+		return value.Kind == yaml.SequenceNode && len(value.Content) == 1 && isNull(value.Content[0])
+	case "const":
+		return isNull(value)
+	}
+	return false
+}
+
+// markNullable marks schema as nullable, returning false if it can't be
+func markNullable(schema *yaml.Node, openAPI31 bool) bool {
+	if !openAPI31 || mapGet(schema, "$ref") != nil {
+		mapSet(schema, "nullable", newBool(true))
+		return true
+	}
+
+	types := mapGet(schema, "type")
+	switch {
+	case types == nil:
+		return false
+	case types.Kind == yaml.ScalarNode:
+		if types.Value != "null" {
+			list := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Style: yaml.FlowStyle}
+			list.Content = []*yaml.Node{newString(types.Value), newString("null")}
+			mapSet(schema, "type", list)
+		}
+	case types.Kind == yaml.SequenceNode:
+		for _, t := range types.Content {
+			if t.Value == "null" {
+				return true
+			}
+		}
+		types.Content = append(types.Content, newString("null"))
+	}
+	return true
+}
+
+// without returns the keys other than key
+func without(keys []string, key string) []string {
+	var others []string
+	for _, k := range keys {
+		if k != key {
+			others = append(others, k)
+		}
+	}
+	return others
+}
@@ -0,0 +1,110 @@
+package normalize
+
+import (
+	"gopkg.in/yaml.v3"
+)
+
+// schemaMapKeywords are the keywords whose value is a map of schemas
+var schemaMapKeywords = []string{"properties", "patternProperties", "$defs", "definitions", "dependentSchemas"}
+
+// schemaKeywords are the keywords whose value is a single schema
+var schemaKeywords = []string{"items", "additionalProperties", "not", "if", "then", "else", "contains", "propertyNames", "unevaluatedItems", "unevaluatedProperties", "contentSchema", "additionalItems"}
+
+// schemaListKeywords are the keywords whose value is a list of schemas
+var schemaListKeywords = []string{"allOf", "anyOf", "oneOf", "prefixItems"}
+
+// schemaSite is where a schema is in the document
+type schemaSite struct {
+	Node *yaml.Node
+	// Name is a name for the schema, from the component, property or operation it belongs to, which names a component created for it
+	Name string
+	// Component is whether the schema is one of the document's component schemas itself, rather than nested within one
+	Component bool
+}
+
+// walkSchemas calls fn with each schema in the document, whether it's a component schema or is within an operation or another component, before any of the schemas nested within it, and those of the components before those of the paths
+func walkSchemas(root *yaml.Node, fn func(site schemaSite)) {
+	var walkSchema func(schema *yaml.Node, name string, component bool)
+	walkSchema = func(schema *yaml.Node, name string, component bool) {
+		if schema == nil || schema.Kind != yaml.MappingNode {
+			return
+		}
+		fn(schemaSite{Node: schema, Name: name, Component: component})
+
+		for _, keyword := range schemaMapKeywords {
+			forEach(mapGet(schema, keyword), func(key string, value *yaml.Node) {
+				walkSchema(value, key, false)
+			})
+		}
+		for _, keyword := range schemaKeywords {
+			if value := mapGet(schema, keyword); value != nil {
+				walkSchema(value, name+"Item", false)
+			}
+		}
+		for _, keyword := range schemaListKeywords {
+			if list := mapGet(schema, keyword); list != nil && list.Kind == yaml.SequenceNode {
+				for _, item := range list.Content {
+					walkSchema(item, name, false)
+				}
+			}
+		}
+	}
+
+	// Anything other than a schema, such as a path, operation or response, is walked to find the schemas within it
+	var walkNode func(n *yaml.Node, name string)
+	walkNode = func(n *yaml.Node, name string) {
+		switch n.Kind {
+		case yaml.MappingNode:
+			if operationID := mapGet(n, "operationId"); operationID != nil && operationID.Kind == yaml.ScalarNode {
+				name = operationID.Value
+			}
+			forEach(n, func(key string, value *yaml.Node) {
+				switch key {
+				case "schema":
+					walkSchema(value, name, false)
+				case "example", "examples":
+					// Examples may have a property called `schema`, which isn't one
+				default:
+					walkNode(value, name)
+				}
+			})
+		case yaml.SequenceNode:
+			for _, item := range n.Content {
+				walkNode(item, name)
+			}
+		}
+	}
+
+	// The components are walked first, so that the schemas within them, which are named from their properties, come before those of operations
+	forEach(mapGet(root, "components"), func(section string, components *yaml.Node) {
+		forEach(components, func(name string, component *yaml.Node) {
+			if section == "schemas" {
+				walkSchema(component, name, true)
+			} else {
+				walkNode(component, name)
+			}
+		})
+	})
+	forEach(root, func(key string, value *yaml.Node) {
+		if key != "components" {
+			walkNode(value, "")
+		}
+	})
+}
+
+// walkRefs calls fn with each mapping in the document with a `$ref`, along with what it refers to
+func walkRefs(n *yaml.Node, fn func(node *yaml.Node, ref string)) {
+	switch n.Kind {
+	case yaml.MappingNode:
+		if ref := mapGet(n, "$ref"); ref != nil && ref.Kind == yaml.ScalarNode {
+			fn(n, ref.Value)
+		}
+		for i := 1; i < len(n.Content); i += 2 {
+			walkRefs(n.Content[i], fn)
+		}
+	case yaml.SequenceNode:
+		for _, item := range n.Content {
+			walkRefs(item, fn)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/speakeasy-api/openapi-overlay/pkg/overlay"
+	"gopkg.in/yaml.v3"
+)
+
+// OverlaySource is an OpenAPI Overlay (https://github.com/OAI/Overlay-Specification) which a Loader applies to a document before loading it, so that the document can be changed without editing it
+type OverlaySource struct {
+	// Path is the file the overlay is read from, when Data is empty
+	Path string
+	// Data is the overlay itself, as YAML or JSON
+	Data []byte
+	// Strict fails the load if any of the overlay's actions has a target which matches nothing in the document, rather than the action having no effect
+	Strict bool
+}
+
+// name returns how the overlay is referred to in errors and warnings
+func (s OverlaySource) name() string {
+	if len(s.Data) == 0 && s.Path != "" {
+		return s.Path
+	}
+	return "overlay"
+}
+
+// load parses the overlay, from Data, or from the file at Path
+func (s OverlaySource) load() (*overlay.Overlay, error) {
+	if len(s.Data) == 0 {
+		if s.Path == "" {
+			return nil, errors.New("the overlay has neither a path nor data")
+		}
+		return overlay.Parse(s.Path)
+	}
+	var o overlay.Overlay
+	if err := yaml.Unmarshal(s.Data, &o); err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+// applyOverlays applies the Loader's Overlays to data, in order, returning the changed document, along with any warnings about how the overlays were applied, such as their use of deprecated JSONPath behaviour
+func (l *Loader) applyOverlays(data []byte) ([]byte, []error, error) {
+	if len(l.Overlays) == 0 {
+		return data, nil, nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse document to apply overlays to: %w", err)
+	}
+
+	var warnings []error
+	for _, source := range l.Overlays {
+		o, err := source.load()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load overlay %s: %w", source.name(), err)
+		}
+		if !source.Strict {
+			if err := o.ApplyTo(&doc); err != nil {
+				return nil, nil, fmt.Errorf("failed to apply overlay %s: %w", source.name(), err)
+			}
+			continue
+		}
+		err, applyWarnings := o.ApplyToStrict(&doc)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to apply overlay %s: %w", source.name(), err)
+		}
+		for _, warning := range applyWarnings {
+			warnings = append(warnings, fmt.Errorf("overlay %s: %s", source.name(), warning))
+		}
+	}
+	l.logger().Debug("applied overlays", "count", len(l.Overlays))
+
+	applied, err := yaml.Marshal(&doc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to serialize document with overlays applied: %w", err)
+	}
+	return applied, warnings, nil
+}
+
+// withoutOverlays returns a copy of the Loader which doesn't apply any overlays, for loading a document which has already had them applied again, such as when it's reloaded
+func (l *Loader) withoutOverlays() *Loader {
+	copied := *l
+	copied.Overlays = nil
+	return &copied
+}
+
+// DiffOverlay returns an OpenAPI Overlay, as YAML, whose actions change original into edited, so that edits made to a copy of a spec can be kept as an overlay which is applied to the spec instead, such as with the Loader's Overlays. Either document can be YAML or JSON
+func DiffOverlay(title string, original, edited []byte) ([]byte, error) {
+	var originalDoc, editedDoc yaml.Node
+	if err := yaml.Unmarshal(original, &originalDoc); err != nil {
+		return nil, fmt.Errorf("failed to parse original document: %w", err)
+	}
+	if err := yaml.Unmarshal(edited, &editedDoc); err != nil {
+		return nil, fmt.Errorf("failed to parse edited document: %w", err)
+	}
+	if len(originalDoc.Content) == 0 || len(editedDoc.Content) == 0 {
+		return nil, errors.New("failed to compare documents: a document is empty")
+	}
+
+	o, err := overlay.Compare(title, &originalDoc, editedDoc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare documents: %w", err)
+	}
+	rendered, err := o.ToString()
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize overlay: %w", err)
+	}
+	return []byte(rendered), nil
+}
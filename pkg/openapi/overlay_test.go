@@ -0,0 +1,144 @@
+package openapi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const overlaySpec = `openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: OK
+  /internal/debug:
+    get:
+      operationId: debug
+      responses:
+        "200":
+          description: OK
+components:
+  schemas:
+    Pet:
+      $ref: './pet.yaml'
+`
+
+const removeDebugOverlay = `overlay: 1.0.0
+info:
+  title: Remove internal endpoints
+  version: 1.0.0
+actions:
+  - target: $.paths['/internal/debug']
+    remove: true
+  - target: $.info
+    update:
+      title: Public pets
+`
+
+func TestLoaderOverlays(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pet.yaml"), []byte("type: object\nproperties:\n  name:\n    type: string\n"), 0o644))
+	specPath := filepath.Join(dir, "spec.yaml")
+	require.NoError(t, os.WriteFile(specPath, []byte(overlaySpec), 0o644))
+	overlayPath := filepath.Join(dir, "overlay.yaml")
+	require.NoError(t, os.WriteFile(overlayPath, []byte(removeDebugOverlay), 0o644))
+
+	t.Run("from a file", func(t *testing.T) {
+		loader := NewLoader()
+		loader.Overlays = []OverlaySource{{Path: overlayPath, Strict: true}}
+		swagger, err := loader.LoadFromFile(specPath)
+		require.NoError(t, err)
+
+		assert.Equal(t, "Public pets", swagger.Info.Title)
+		assert.Nil(t, swagger.Paths.Find("/internal/debug"))
+		assert.NotNil(t, swagger.Paths.Find("/pets"))
+		require.NotNil(t, swagger.Components.Schemas["Pet"].Value)
+		assert.Contains(t, swagger.Components.Schemas["Pet"].Value.PropertiesToMap(), "name", "relative references are resolved from the spec's directory")
+		assert.Equal(t, specPath, swagger.Paths.Find("/pets").GetOperation("GET").Position().File, "positions refer to the spec itself")
+
+		reloaded, err := swagger.Reload()
+		require.NoError(t, err, "the overlays aren't applied again when the document is reloaded")
+		assert.Equal(t, "Public pets", reloaded.Info.Title)
+	})
+
+	t.Run("from data", func(t *testing.T) {
+		loader := NewLoader()
+		loader.Overlays = []OverlaySource{
+			{Data: []byte(removeDebugOverlay)},
+			{Data: []byte("overlay: 1.0.0\ninfo:\n  title: Version\n  version: 1.0.0\nactions:\n  - target: $.info\n    update:\n      version: 2.0.0\n")},
+		}
+		swagger, err := loader.LoadFromDataWithBasePath([]byte(overlaySpec), dir)
+		require.NoError(t, err)
+		assert.Equal(t, "Public pets", swagger.Info.Title)
+		assert.Equal(t, "2.0.0", swagger.Info.Version, "each of the overlays is applied, in order")
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		missing := []byte("overlay: 1.0.0\ninfo:\n  title: Missing\n  version: 1.0.0\nactions:\n  - target: $.paths['/owners']\n    remove: true\n")
+
+		loader := NewLoader()
+		loader.Overlays = []OverlaySource{{Data: missing, Strict: true}}
+		_, err := loader.LoadFromDataWithBasePath([]byte(overlaySpec), dir)
+		require.ErrorContains(t, err, "did not match any targets")
+
+		loader.Overlays = []OverlaySource{{Data: missing}}
+		swagger, err := loader.LoadFromDataWithBasePath([]byte(overlaySpec), dir)
+		require.NoError(t, err, "an action without any targets has no effect unless the overlay is strict")
+		assert.NotNil(t, swagger.Paths.Find("/internal/debug"))
+	})
+
+	t.Run("a missing overlay", func(t *testing.T) {
+		loader := NewLoader()
+		loader.Overlays = []OverlaySource{{Path: filepath.Join(dir, "missing.yaml")}}
+		_, err := loader.LoadFromFile(specPath)
+		require.ErrorContains(t, err, "failed to load overlay")
+	})
+}
+
+func TestDiffOverlay(t *testing.T) {
+	edited := `openapi: 3.0.0
+info:
+  title: Public pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      x-go-name: ListAllPets
+      responses:
+        "200":
+          description: OK
+components:
+  schemas:
+    Pet:
+      $ref: './pet.yaml'
+`
+	generated, err := DiffOverlay("Hand edits", []byte(overlaySpec), []byte(edited))
+	require.NoError(t, err)
+	assert.Contains(t, string(generated), "title: Hand edits")
+	assert.Contains(t, string(generated), "remove: true")
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pet.yaml"), []byte("type: object\n"), 0o644))
+	loader := NewLoader()
+	loader.Overlays = []OverlaySource{{Data: generated, Strict: true}}
+	swagger, err := loader.LoadFromDataWithBasePath([]byte(overlaySpec), dir)
+	require.NoError(t, err)
+	assert.Equal(t, "Public pets", swagger.Info.Title)
+	assert.Nil(t, swagger.Paths.Find("/internal/debug"))
+	rendered, err := swagger.Render()
+	require.NoError(t, err)
+	assert.Contains(t, string(rendered), "x-go-name: ListAllPets", "applying the overlay to the original makes the same edits")
+
+	_, err = DiffOverlay("Empty", []byte(""), []byte(edited))
+	require.Error(t, err)
+}
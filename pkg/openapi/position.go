@@ -0,0 +1,124 @@
+package openapi
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pb33f/libopenapi/index"
+	"gopkg.in/yaml.v3"
+)
+
+// Position is the location of an object within the document it was loaded from
+type Position struct {
+	// File is the path of the file the object is defined in, which is empty when the document wasn't loaded from a file
+	File   string
+	Line   int
+	Column int
+}
+
+// IsValid returns true if the position is known
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+// String formats the position as `file:line:column`, or `line:column` if the file is unknown
+func (p Position) String() string {
+	if !p.IsValid() {
+		return ""
+	}
+	if p.File == "" {
+		return fmt.Sprintf("%d:%d", p.Line, p.Column)
+	}
+	return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+}
+
+// nodePosition returns the position of a node, within the file which idx was built from
+func nodePosition(node *yaml.Node, idx *index.SpecIndex) Position {
+	if node == nil {
+		return Position{}
+	}
+
+	pos := Position{
+		Line:   node.Line,
+		Column: node.Column,
+	}
+	if idx != nil {
+		// Documents loaded from data, rather than a file, are indexed under a placeholder file name which doesn't exist, so isn't worth reporting
+		if path := idx.GetSpecAbsolutePath(); path != "" {
+			if _, err := os.Stat(path); err == nil {
+				pos.File = relativePath(path)
+			}
+		}
+	}
+	return pos
+}
+
+// relativePath returns path relative to the working directory, if it is within it, as that's shorter to read in error messages
+func relativePath(path string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(wd, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+	return rel
+}
+
+// Position returns where the schema is defined. For a referenced schema, this is the location of the referenced definition
+func (s *Schema) Position() Position {
+	if s == nil || s.Schema == nil || s.Schema.GoLow() == nil {
+		return Position{}
+	}
+	low := s.Schema.GoLow()
+	return nodePosition(low.RootNode, low.Index)
+}
+
+// KeywordPosition returns where the given keyword is used within the schema, or the position of the schema itself if the keyword can't be found
+func (s *Schema) KeywordPosition(keyword string) Position {
+	if s == nil || s.Schema == nil || s.Schema.GoLow() == nil {
+		return Position{}
+	}
+	low := s.Schema.GoLow()
+	if root := low.RootNode; root != nil && root.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(root.Content); i += 2 {
+			if root.Content[i].Value == keyword {
+				return nodePosition(root.Content[i], low.Index)
+			}
+		}
+	}
+	return nodePosition(low.RootNode, low.Index)
+}
+
+// Position returns where the referenced schema is defined
+func (sr *SchemaRef) Position() Position {
+	if sr == nil {
+		return Position{}
+	}
+	return sr.Value.Position()
+}
+
+// Position returns where the operation is defined, which is the line of its HTTP method
+func (o *Operation) Position() Position {
+	if o == nil || o.Operation == nil || o.Operation.GoLow() == nil {
+		return Position{}
+	}
+	low := o.Operation.GoLow()
+	node := low.KeyNode
+	if node == nil {
+		node = low.RootNode
+	}
+	return nodePosition(node, low.GetIndex())
+}
+
+// Position returns where the parameter is defined
+func (p *Parameter) Position() Position {
+	if p == nil || p.Parameter == nil || p.Parameter.GoLow() == nil {
+		return Position{}
+	}
+	low := p.Parameter.GoLow()
+	return nodePosition(low.RootNode, low.GetIndex())
+}
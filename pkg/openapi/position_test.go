@@ -0,0 +1,78 @@
+package openapi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const positionSpec = `openapi: 3.0.3
+info:
+  title: Positions
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: 'pets.yaml#/components/schemas/Pet'
+components:
+  schemas:
+    Error:
+      type: object
+`
+
+const positionExternalSpec = `components:
+  schemas:
+    Pet:
+      type: object
+`
+
+func TestPosition(t *testing.T) {
+	dir := t.TempDir()
+	specFile := filepath.Join(dir, "spec.yaml")
+	require.NoError(t, os.WriteFile(specFile, []byte(positionSpec), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pets.yaml"), []byte(positionExternalSpec), 0o644))
+
+	swagger, err := NewLoader().LoadFromFile(specFile)
+	require.NoError(t, err)
+
+	op := swagger.Paths.Find("/pets").GetOperation("GET")
+	assert.Equal(t, Position{File: specFile, Line: 7, Column: 5}, op.Position())
+	assert.Equal(t, specFile+":7:5", op.Position().String())
+
+	assert.Equal(t, Position{File: specFile, Line: 19, Column: 7}, swagger.Components.Schemas["Error"].Position())
+
+	pet := op.Responses.Value("200").Value.Content["application/json"].Schema
+	assert.Equal(t, Position{File: filepath.Join(dir, "pets.yaml"), Line: 4, Column: 7}, pet.Position())
+}
+
+func TestPositionFromData(t *testing.T) {
+	swagger, err := NewLoader().LoadFromData([]byte(`openapi: 3.0.3
+info:
+  title: Positions
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Error:
+      type: object
+`))
+	require.NoError(t, err)
+
+	pos := swagger.Components.Schemas["Error"].Position()
+	assert.Equal(t, Position{Line: 9, Column: 7}, pos)
+	assert.Equal(t, "9:7", pos.String())
+
+	var unknown *SchemaRef
+	assert.False(t, unknown.Position().IsValid())
+	assert.Equal(t, "", unknown.Position().String())
+}
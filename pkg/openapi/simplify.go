@@ -0,0 +1,74 @@
+package openapi
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"gopkg.in/yaml.v3"
+)
+
+// globalSimplifyCompositions is set from the Loader which is currently wrapping a document, with its SimplifyCompositions
+var globalSimplifyCompositions bool
+
+// SingleMemberComposition returns the member of a schema which has nothing but an `allOf` of a single reference, or a `oneOf` of a single schema, which the schema can be unwrapped into. Otherwise, it returns nil.
+// A reference itself is never unwrapped, so that it still refers to the component it names, nor is a schema with any other keyword, such as a `description` or `nullable`, as it would be lost.
+// It's how both the Loader, with SimplifyCompositions, and the `flatten-compositions` pass of `pkg/openapi/normalize` decide what to unwrap, so that they unwrap the same schemas
+func SingleMemberComposition(schema *yaml.Node) *yaml.Node {
+	if schema == nil || schema.Kind != yaml.MappingNode || len(schema.Content) != 2 {
+		return nil
+	}
+	members := schema.Content[1]
+	if members.Kind != yaml.SequenceNode || len(members.Content) != 1 {
+		return nil
+	}
+	member := members.Content[0]
+	switch schema.Content[0].Value {
+	case "allOf":
+		if !isReferenceNode(member) {
+			return nil
+		}
+	case "oneOf":
+	default:
+		return nil
+	}
+	return member
+}
+
+// isReferenceNode returns whether a schema is a `$ref`
+func isReferenceNode(schema *yaml.Node) bool {
+	if schema.Kind != yaml.MappingNode {
+		return false
+	}
+	for i := 0; i+1 < len(schema.Content); i += 2 {
+		if schema.Content[i].Value == "$ref" {
+			return true
+		}
+	}
+	return false
+}
+
+// singleComposition returns the schema which the schema of proxy wraps, as SingleMemberComposition decides, when the Loader's SimplifyCompositions is set. Otherwise, it returns nil.
+// Only schemas which were loaded are unwrapped, as the keywords of a schema created in code aren't known
+func singleComposition(proxy *base.SchemaProxy) *base.SchemaProxy {
+	if !globalSimplifyCompositions || proxy.IsReference() {
+		return nil
+	}
+	node := proxy.GetValueNode()
+	if SingleMemberComposition(node) == nil {
+		return nil
+	}
+	schema := proxy.Schema()
+	if schema == nil {
+		return nil
+	}
+
+	var inner *base.SchemaProxy
+	switch keyword := node.Content[0].Value; {
+	case keyword == "allOf" && len(schema.AllOf) == 1:
+		inner = schema.AllOf[0]
+	case keyword == "oneOf" && len(schema.OneOf) == 1:
+		inner = schema.OneOf[0]
+	default:
+		return nil
+	}
+	globalLogger.Debug("unwrapped single-member composition", "keyword", node.Content[0].Value, "line", node.Line, "column", node.Column)
+	return inner
+}
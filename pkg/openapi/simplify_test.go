@@ -0,0 +1,105 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const simplifySpec = `openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                allOf:
+                  - $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Owner:
+      type: object
+    Pet:
+      type: object
+      properties:
+        owner:
+          oneOf:
+            - allOf:
+                - $ref: '#/components/schemas/Owner'
+        keeper:
+          allOf:
+            - $ref: '#/components/schemas/Owner'
+          description: Looks after the pet
+        toy:
+          allOf:
+            - type: object
+    Keeper:
+      allOf:
+        - $ref: '#/components/schemas/Owner'
+`
+
+func TestSimplifyCompositions(t *testing.T) {
+	t.Run("single-member compositions are unwrapped", func(t *testing.T) {
+		swagger, err := NewLoader().LoadFromData([]byte(simplifySpec))
+		require.NoError(t, err)
+
+		assert.Equal(t, "#/components/schemas/Owner", swagger.Components.Schemas["Keeper"].Ref)
+
+		properties := swagger.Components.Schemas["Pet"].Value.PropertiesToMap()
+		assert.Equal(t, "#/components/schemas/Owner", properties["owner"].Ref)
+		assert.Empty(t, properties["owner"].Value.OneOf)
+
+		response := swagger.Paths.Find("/pets").GetOperation("GET").Responses.Value("200")
+		assert.Equal(t, "#/components/schemas/Pet", response.Value.Content["application/json"].Schema.Ref)
+	})
+
+	t.Run("compositions with other keywords or inline members are kept", func(t *testing.T) {
+		swagger, err := NewLoader().LoadFromData([]byte(simplifySpec))
+		require.NoError(t, err)
+
+		properties := swagger.Components.Schemas["Pet"].Value.PropertiesToMap()
+		assert.Empty(t, properties["keeper"].Ref)
+		assert.Len(t, properties["keeper"].Value.AllOf, 1)
+		assert.Len(t, properties["toy"].Value.AllOf, 1)
+	})
+
+	t.Run("nothing is unwrapped without SimplifyCompositions", func(t *testing.T) {
+		loader := NewLoader()
+		loader.SimplifyCompositions = false
+		swagger, err := loader.LoadFromData([]byte(simplifySpec))
+		require.NoError(t, err)
+
+		assert.Empty(t, swagger.Components.Schemas["Keeper"].Ref)
+		assert.Len(t, swagger.Components.Schemas["Keeper"].Value.AllOf, 1)
+		assert.Len(t, swagger.Components.Schemas["Pet"].Value.PropertiesToMap()["owner"].Value.OneOf, 1)
+	})
+}
+
+func TestSingleMemberComposition(t *testing.T) {
+	member := func(t *testing.T, schema string) *yaml.Node {
+		t.Helper()
+		var doc yaml.Node
+		require.NoError(t, yaml.Unmarshal([]byte(schema), &doc))
+		return SingleMemberComposition(doc.Content[0])
+	}
+
+	unwrapped := member(t, "allOf: [{$ref: '#/components/schemas/Owner'}]")
+	require.NotNil(t, unwrapped)
+	assert.Equal(t, "$ref", unwrapped.Content[0].Value)
+	assert.NotNil(t, member(t, "oneOf: [{type: string}]"))
+
+	assert.Nil(t, member(t, "allOf: [{type: object}]"), "an inline allOf member")
+	assert.Nil(t, member(t, "anyOf: [{type: string}]"))
+	assert.Nil(t, member(t, "oneOf: [{type: string}, {type: integer}]"))
+	assert.Nil(t, member(t, "{oneOf: [{type: string}], description: A name}"), "a composition with another keyword")
+	assert.Nil(t, member(t, "$ref: '#/components/schemas/Owner'"))
+}
@@ -0,0 +1,619 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// swagger2SchemaFields are the Swagger 2.0 parameter and header fields which describe the value's schema, and which live under `schema` in OpenAPI 3.0
+var swagger2SchemaFields = []string{
+	"type", "format", "items", "enum", "default", "maximum", "exclusiveMaximum", "minimum", "exclusiveMinimum",
+	"maxLength", "minLength", "pattern", "maxItems", "minItems", "uniqueItems", "multipleOf", "x-nullable",
+}
+
+// swagger2OAuthFlows maps Swagger 2.0 OAuth2 flow names to their OpenAPI 3.0 equivalents
+var swagger2OAuthFlows = map[string]string{
+	"implicit":    "implicit",
+	"password":    "password",
+	"application": "clientCredentials",
+	"accessCode":  "authorizationCode",
+}
+
+// isSwagger2 returns true if the data is a Swagger 2.0 document
+func isSwagger2(data []byte) bool {
+	var header struct {
+		Swagger string `json:"swagger" yaml:"swagger"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		if err := yaml.Unmarshal(data, &header); err != nil {
+			return false
+		}
+	}
+	return strings.HasPrefix(header.Swagger, "2.")
+}
+
+// convertSwagger2 upgrades a Swagger 2.0 document to an equivalent OpenAPI 3.0 document.
+// The conversion covers everything which affects code generation, so anything without an OpenAPI 3.0 equivalent, such as parameter examples, is dropped
+func convertSwagger2(data []byte) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse Swagger 2.0 document: %w", err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("failed to convert Swagger 2.0 document: the document is not an object")
+	}
+
+	c := newSwagger2Converter(doc.Content[0])
+	converted, err := yaml.Marshal(c.convert())
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize converted Swagger 2.0 document: %w", err)
+	}
+	return converted, nil
+}
+
+// swagger2Converter holds the document-wide state needed while converting a Swagger 2.0 document
+type swagger2Converter struct {
+	root     *yaml.Node
+	consumes []string
+	produces []string
+	// parameters are the document's global parameters, which need to be looked up when a body or form parameter is referenced
+	parameters *yaml.Node
+}
+
+func newSwagger2Converter(root *yaml.Node) *swagger2Converter {
+	return &swagger2Converter{
+		root:       root,
+		consumes:   yamlStringSlice(yamlMapGet(root, "consumes")),
+		produces:   yamlStringSlice(yamlMapGet(root, "produces")),
+		parameters: yamlMapGet(root, "parameters"),
+	}
+}
+
+func (c *swagger2Converter) convert() *yaml.Node {
+	out := newYamlMapping()
+	yamlMapSet(out, "openapi", newYamlString("3.0.3"))
+	if info := yamlMapGet(c.root, "info"); info != nil {
+		yamlMapSet(out, "info", info)
+	}
+	if servers := c.convertServers(); servers != nil {
+		yamlMapSet(out, "servers", servers)
+	}
+
+	components := newYamlMapping()
+	yamlForEach(c.root, func(key string, value *yaml.Node) {
+		switch key {
+		case "swagger", "info", "host", "basePath", "schemes", "consumes", "produces":
+			// Handled above
+		case "paths":
+			yamlMapSet(out, "paths", c.convertPaths(value))
+		case "definitions":
+			yamlMapSet(components, "schemas", yamlMapValues(value, c.convertSchema))
+		case "parameters":
+			c.convertGlobalParameters(value, components)
+		case "responses":
+			yamlMapSet(components, "responses", yamlMapValues(value, c.convertResponse))
+		case "securityDefinitions":
+			yamlMapSet(components, "securitySchemes", yamlMapValues(value, c.convertSecurityScheme))
+		default:
+			yamlMapSet(out, key, value)
+		}
+	})
+	if len(components.Content) > 0 {
+		yamlMapSet(out, "components", components)
+	}
+
+	return out
+}
+
+// convertServers builds the `servers` from `host`, `basePath` and `schemes`
+func (c *swagger2Converter) convertServers() *yaml.Node {
+	host := yamlScalar(yamlMapGet(c.root, "host"))
+	basePath := yamlScalar(yamlMapGet(c.root, "basePath"))
+	if host == "" && basePath == "" {
+		return nil
+	}
+
+	servers := newYamlSequence()
+	if host == "" {
+		server := newYamlMapping()
+		yamlMapSet(server, "url", newYamlString(basePath))
+		servers.Content = append(servers.Content, server)
+		return servers
+	}
+
+	schemes := yamlStringSlice(yamlMapGet(c.root, "schemes"))
+	if len(schemes) == 0 {
+		schemes = []string{"https"}
+	}
+	for _, scheme := range schemes {
+		server := newYamlMapping()
+		yamlMapSet(server, "url", newYamlString(scheme+"://"+host+basePath))
+		servers.Content = append(servers.Content, server)
+	}
+	return servers
+}
+
+// convertGlobalParameters splits the global parameters into `components.parameters` and `components.requestBodies`.
+// Form parameters have no equivalent component, so they are inlined wherever they are referenced
+func (c *swagger2Converter) convertGlobalParameters(parameters *yaml.Node, components *yaml.Node) {
+	params := newYamlMapping()
+	bodies := newYamlMapping()
+	yamlForEach(parameters, func(name string, param *yaml.Node) {
+		switch yamlScalar(yamlMapGet(param, "in")) {
+		case "body":
+			yamlMapSet(bodies, name, c.convertBodyParameter(param, c.consumes))
+		case "formData":
+		default:
+			yamlMapSet(params, name, c.convertParameter(param))
+		}
+	})
+	if len(params.Content) > 0 {
+		yamlMapSet(components, "parameters", params)
+	}
+	if len(bodies.Content) > 0 {
+		yamlMapSet(components, "requestBodies", bodies)
+	}
+}
+
+func (c *swagger2Converter) convertPaths(paths *yaml.Node) *yaml.Node {
+	out := newYamlMapping()
+	yamlForEach(paths, func(path string, pathItem *yaml.Node) {
+		if !strings.HasPrefix(path, "/") {
+			// Extensions
+			yamlMapSet(out, path, pathItem)
+			return
+		}
+		yamlMapSet(out, path, c.convertPathItem(pathItem))
+	})
+	return out
+}
+
+func (c *swagger2Converter) convertPathItem(pathItem *yaml.Node) *yaml.Node {
+	if ref := yamlMapGet(pathItem, "$ref"); ref != nil {
+		return pathItem
+	}
+
+	// Body and form parameters can't be declared on a path item in OpenAPI 3.0, so they're pushed down to each operation
+	var sharedParams, bodyParams []*yaml.Node
+	for _, param := range yamlSequence(yamlMapGet(pathItem, "parameters")) {
+		if c.isRequestBodyParameter(param) {
+			bodyParams = append(bodyParams, param)
+		} else {
+			sharedParams = append(sharedParams, param)
+		}
+	}
+
+	out := newYamlMapping()
+	yamlForEach(pathItem, func(key string, value *yaml.Node) {
+		switch key {
+		case "parameters":
+			if len(sharedParams) > 0 {
+				yamlMapSet(out, "parameters", c.convertParameters(sharedParams))
+			}
+		case "get", "put", "post", "delete", "options", "head", "patch":
+			yamlMapSet(out, key, c.convertOperation(value, bodyParams))
+		default:
+			yamlMapSet(out, key, value)
+		}
+	})
+	return out
+}
+
+func (c *swagger2Converter) convertOperation(op *yaml.Node, pathBodyParams []*yaml.Node) *yaml.Node {
+	consumes := c.consumes
+	if opConsumes := yamlMapGet(op, "consumes"); opConsumes != nil {
+		consumes = yamlStringSlice(opConsumes)
+	}
+	produces := c.produces
+	if opProduces := yamlMapGet(op, "produces"); opProduces != nil {
+		produces = yamlStringSlice(opProduces)
+	}
+
+	var params []*yaml.Node
+	bodyParams := append([]*yaml.Node{}, pathBodyParams...)
+	for _, param := range yamlSequence(yamlMapGet(op, "parameters")) {
+		if c.isRequestBodyParameter(param) {
+			bodyParams = append(bodyParams, param)
+		} else {
+			params = append(params, param)
+		}
+	}
+
+	out := newYamlMapping()
+	requestBody := c.convertRequestBody(bodyParams, consumes)
+	yamlForEach(op, func(key string, value *yaml.Node) {
+		switch key {
+		case "consumes", "produces", "schemes":
+		case "parameters":
+			if len(params) > 0 {
+				yamlMapSet(out, "parameters", c.convertParameters(params))
+			}
+			if requestBody != nil {
+				yamlMapSet(out, "requestBody", requestBody)
+				requestBody = nil
+			}
+		case "responses":
+			if requestBody != nil {
+				yamlMapSet(out, "requestBody", requestBody)
+				requestBody = nil
+			}
+			responses := newYamlMapping()
+			yamlForEach(value, func(code string, response *yaml.Node) {
+				if strings.HasPrefix(code, "x-") {
+					yamlMapSet(responses, code, response)
+					return
+				}
+				yamlMapSet(responses, code, c.convertResponseWithProduces(response, produces))
+			})
+			yamlMapSet(out, "responses", responses)
+		default:
+			yamlMapSet(out, key, value)
+		}
+	})
+	if requestBody != nil {
+		yamlMapSet(out, "requestBody", requestBody)
+	}
+	return out
+}
+
+// isRequestBodyParameter returns true if the parameter, or the global parameter it references, is a body or form parameter
+func (c *swagger2Converter) isRequestBodyParameter(param *yaml.Node) bool {
+	in := yamlScalar(yamlMapGet(c.resolveParameter(param), "in"))
+	return in == "body" || in == "formData"
+}
+
+// resolveParameter returns the global parameter which param references, or param itself if it's not a local reference
+func (c *swagger2Converter) resolveParameter(param *yaml.Node) *yaml.Node {
+	ref := yamlScalar(yamlMapGet(param, "$ref"))
+	if name, ok := strings.CutPrefix(ref, "#/parameters/"); ok {
+		if resolved := yamlMapGet(c.parameters, name); resolved != nil {
+			return resolved
+		}
+	}
+	return param
+}
+
+func (c *swagger2Converter) convertParameters(params []*yaml.Node) *yaml.Node {
+	out := newYamlSequence()
+	for _, param := range params {
+		out.Content = append(out.Content, c.convertParameter(param))
+	}
+	return out
+}
+
+func (c *swagger2Converter) convertParameter(param *yaml.Node) *yaml.Node {
+	if ref := yamlMapGet(param, "$ref"); ref != nil {
+		return c.convertRef(param)
+	}
+
+	out := newYamlMapping()
+	yamlForEach(param, func(key string, value *yaml.Node) {
+		switch {
+		case key == "collectionFormat":
+			c.convertCollectionFormat(yamlScalar(value), yamlScalar(yamlMapGet(param, "in")), out)
+		case isSwagger2SchemaField(key):
+		default:
+			yamlMapSet(out, key, value)
+		}
+	})
+	yamlMapSet(out, "schema", c.convertSimpleSchema(param))
+	return out
+}
+
+// convertCollectionFormat translates a Swagger 2.0 `collectionFormat` into the equivalent `style` and `explode`
+func (c *swagger2Converter) convertCollectionFormat(format string, in string, out *yaml.Node) {
+	switch format {
+	case "ssv":
+		yamlMapSet(out, "style", newYamlString("spaceDelimited"))
+		yamlMapSet(out, "explode", newYamlBool(false))
+	case "pipes":
+		yamlMapSet(out, "style", newYamlString("pipeDelimited"))
+		yamlMapSet(out, "explode", newYamlBool(false))
+	case "multi":
+		yamlMapSet(out, "style", newYamlString("form"))
+		yamlMapSet(out, "explode", newYamlBool(true))
+	default:
+		// csv is the default, which for query and cookie parameters needs explode turned off
+		if in == "query" || in == "cookie" || in == "formData" {
+			yamlMapSet(out, "style", newYamlString("form"))
+			yamlMapSet(out, "explode", newYamlBool(false))
+		}
+	}
+}
+
+func (c *swagger2Converter) convertBodyParameter(param *yaml.Node, consumes []string) *yaml.Node {
+	if len(consumes) == 0 {
+		consumes = []string{"application/json"}
+	}
+
+	out := newYamlMapping()
+	if description := yamlMapGet(param, "description"); description != nil {
+		yamlMapSet(out, "description", description)
+	}
+	content := newYamlMapping()
+	for _, mediaType := range consumes {
+		mediaTypeObj := newYamlMapping()
+		yamlMapSet(mediaTypeObj, "schema", c.convertSchema(yamlMapGet(param, "schema")))
+		yamlMapSet(content, mediaType, mediaTypeObj)
+	}
+	yamlMapSet(out, "content", content)
+	if required := yamlMapGet(param, "required"); required != nil {
+		yamlMapSet(out, "required", required)
+	}
+	if name := yamlMapGet(param, "name"); name != nil {
+		yamlMapSet(out, "x-codegen-request-body-name", name)
+	}
+	yamlForEach(param, func(key string, value *yaml.Node) {
+		if strings.HasPrefix(key, "x-") {
+			yamlMapSet(out, key, value)
+		}
+	})
+	return out
+}
+
+// convertRequestBody builds an operation's `requestBody` from its body or form parameters
+func (c *swagger2Converter) convertRequestBody(params []*yaml.Node, consumes []string) *yaml.Node {
+	if len(params) == 0 {
+		return nil
+	}
+
+	var formParams []*yaml.Node
+	for _, param := range params {
+		resolved := c.resolveParameter(param)
+		if yamlScalar(yamlMapGet(resolved, "in")) == "body" {
+			if resolved != param {
+				return c.convertRef(param)
+			}
+			return c.convertBodyParameter(param, consumes)
+		}
+		formParams = append(formParams, resolved)
+	}
+
+	properties := newYamlMapping()
+	required := newYamlSequence()
+	multipart := false
+	for _, param := range formParams {
+		name := yamlScalar(yamlMapGet(param, "name"))
+		if yamlScalar(yamlMapGet(param, "type")) == "file" {
+			multipart = true
+		}
+		property := c.convertSimpleSchema(param)
+		if description := yamlMapGet(param, "description"); description != nil {
+			yamlMapSet(property, "description", description)
+		}
+		yamlMapSet(properties, name, property)
+		if yamlScalar(yamlMapGet(param, "required")) == "true" {
+			required.Content = append(required.Content, newYamlString(name))
+		}
+	}
+
+	schema := newYamlMapping()
+	yamlMapSet(schema, "type", newYamlString("object"))
+	yamlMapSet(schema, "properties", properties)
+	if len(required.Content) > 0 {
+		yamlMapSet(schema, "required", required)
+	}
+
+	mediaTypes := []string{}
+	for _, mediaType := range consumes {
+		if mediaType == "multipart/form-data" || mediaType == "application/x-www-form-urlencoded" {
+			mediaTypes = append(mediaTypes, mediaType)
+		}
+	}
+	if len(mediaTypes) == 0 {
+		if multipart {
+			mediaTypes = []string{"multipart/form-data"}
+		} else {
+			mediaTypes = []string{"application/x-www-form-urlencoded"}
+		}
+	}
+
+	content := newYamlMapping()
+	for _, mediaType := range mediaTypes {
+		mediaTypeObj := newYamlMapping()
+		yamlMapSet(mediaTypeObj, "schema", schema)
+		yamlMapSet(content, mediaType, mediaTypeObj)
+	}
+	out := newYamlMapping()
+	yamlMapSet(out, "content", content)
+	if len(required.Content) > 0 {
+		yamlMapSet(out, "required", newYamlBool(true))
+	}
+	return out
+}
+
+func (c *swagger2Converter) convertResponse(response *yaml.Node) *yaml.Node {
+	return c.convertResponseWithProduces(response, c.produces)
+}
+
+func (c *swagger2Converter) convertResponseWithProduces(response *yaml.Node, produces []string) *yaml.Node {
+	if ref := yamlMapGet(response, "$ref"); ref != nil {
+		return c.convertRef(response)
+	}
+	if len(produces) == 0 {
+		produces = []string{"application/json"}
+	}
+
+	out := newYamlMapping()
+	// description is optional in Swagger 2.0, but required in OpenAPI 3.0
+	yamlMapSet(out, "description", newYamlString(""))
+	yamlForEach(response, func(key string, value *yaml.Node) {
+		switch key {
+		case "schema":
+			examples := yamlMapGet(response, "examples")
+			content := newYamlMapping()
+			for _, mediaType := range produces {
+				mediaTypeObj := newYamlMapping()
+				yamlMapSet(mediaTypeObj, "schema", c.convertSchema(value))
+				if example := yamlMapGet(examples, mediaType); example != nil {
+					yamlMapSet(mediaTypeObj, "example", example)
+				}
+				yamlMapSet(content, mediaType, mediaTypeObj)
+			}
+			yamlMapSet(out, "content", content)
+		case "headers":
+			yamlMapSet(out, "headers", yamlMapValues(value, c.convertHeader))
+		case "examples":
+			// Moved under content above
+		default:
+			yamlMapSet(out, key, value)
+		}
+	})
+	return out
+}
+
+func (c *swagger2Converter) convertHeader(header *yaml.Node) *yaml.Node {
+	out := newYamlMapping()
+	yamlForEach(header, func(key string, value *yaml.Node) {
+		if key == "collectionFormat" || isSwagger2SchemaField(key) {
+			return
+		}
+		yamlMapSet(out, key, value)
+	})
+	yamlMapSet(out, "schema", c.convertSimpleSchema(header))
+	return out
+}
+
+func (c *swagger2Converter) convertSecurityScheme(scheme *yaml.Node) *yaml.Node {
+	out := newYamlMapping()
+	switch yamlScalar(yamlMapGet(scheme, "type")) {
+	case "basic":
+		yamlMapSet(out, "type", newYamlString("http"))
+		yamlMapSet(out, "scheme", newYamlString("basic"))
+	case "oauth2":
+		yamlMapSet(out, "type", newYamlString("oauth2"))
+		flow := newYamlMapping()
+		for _, key := range []string{"authorizationUrl", "tokenUrl", "scopes"} {
+			if value := yamlMapGet(scheme, key); value != nil {
+				yamlMapSet(flow, key, value)
+			}
+		}
+		if yamlMapGet(flow, "scopes") == nil {
+			yamlMapSet(flow, "scopes", newYamlMapping())
+		}
+		flows := newYamlMapping()
+		yamlMapSet(flows, swagger2OAuthFlows[yamlScalar(yamlMapGet(scheme, "flow"))], flow)
+		yamlMapSet(out, "flows", flows)
+	default:
+		yamlForEach(scheme, func(key string, value *yaml.Node) {
+			if key != "description" && !strings.HasPrefix(key, "x-") {
+				yamlMapSet(out, key, value)
+			}
+		})
+	}
+	yamlForEach(scheme, func(key string, value *yaml.Node) {
+		if key == "description" || strings.HasPrefix(key, "x-") {
+			yamlMapSet(out, key, value)
+		}
+	})
+	return out
+}
+
+// convertSimpleSchema builds a schema from the schema fields of a Swagger 2.0 parameter, header or items object
+func (c *swagger2Converter) convertSimpleSchema(n *yaml.Node) *yaml.Node {
+	out := newYamlMapping()
+	yamlForEach(n, func(key string, value *yaml.Node) {
+		if !isSwagger2SchemaField(key) {
+			return
+		}
+		if key == "items" {
+			yamlMapSet(out, "items", c.convertSimpleSchema(value))
+			return
+		}
+		yamlMapSet(out, key, value)
+	})
+	return c.convertSchema(out)
+}
+
+// convertSchema converts a Swagger 2.0 schema, which differs from an OpenAPI 3.0 schema in its references, `x-nullable`, `type: file` and its `discriminator`
+func (c *swagger2Converter) convertSchema(schema *yaml.Node) *yaml.Node {
+	if schema == nil || schema.Kind != yaml.MappingNode {
+		return schema
+	}
+
+	out := newYamlMapping()
+	yamlForEach(schema, func(key string, value *yaml.Node) {
+		switch key {
+		case "$ref":
+			yamlMapSet(out, key, newYamlString(c.rewriteRef(yamlScalar(value))))
+		case "x-nullable":
+			yamlMapSet(out, "nullable", value)
+		case "type":
+			if yamlScalar(value) == "file" {
+				yamlMapSet(out, "type", newYamlString("string"))
+				yamlMapSet(out, "format", newYamlString("binary"))
+				return
+			}
+			yamlMapSet(out, key, value)
+		case "format":
+			if yamlScalar(yamlMapGet(schema, "type")) == "file" {
+				return
+			}
+			yamlMapSet(out, key, value)
+		case "discriminator":
+			if value.Kind == yaml.ScalarNode {
+				discriminator := newYamlMapping()
+				yamlMapSet(discriminator, "propertyName", value)
+				value = discriminator
+			}
+			yamlMapSet(out, key, value)
+		case "properties", "patternProperties":
+			yamlMapSet(out, key, yamlMapValues(value, c.convertSchema))
+		case "items", "additionalProperties", "not":
+			yamlMapSet(out, key, c.convertSchema(value))
+		case "allOf", "anyOf", "oneOf":
+			schemas := newYamlSequence()
+			for _, s := range yamlSequence(value) {
+				schemas.Content = append(schemas.Content, c.convertSchema(s))
+			}
+			yamlMapSet(out, key, schemas)
+		default:
+			yamlMapSet(out, key, value)
+		}
+	})
+	return out
+}
+
+// convertRef converts a reference object, so that it points to where the referenced object lives in an OpenAPI 3.0 document
+func (c *swagger2Converter) convertRef(n *yaml.Node) *yaml.Node {
+	out := newYamlMapping()
+	yamlMapSet(out, "$ref", newYamlString(c.rewriteRef(yamlScalar(yamlMapGet(n, "$ref")))))
+	return out
+}
+
+// rewriteRef rewrites the JSON pointer of a reference from a Swagger 2.0 location to the OpenAPI 3.0 location
+func (c *swagger2Converter) rewriteRef(ref string) string {
+	location, pointer, found := strings.Cut(ref, "#")
+	if !found {
+		return ref
+	}
+
+	switch {
+	case strings.HasPrefix(pointer, "/definitions/"):
+		pointer = "/components/schemas/" + strings.TrimPrefix(pointer, "/definitions/")
+	case strings.HasPrefix(pointer, "/responses/"):
+		pointer = "/components/responses/" + strings.TrimPrefix(pointer, "/responses/")
+	case strings.HasPrefix(pointer, "/parameters/"):
+		name := strings.TrimPrefix(pointer, "/parameters/")
+		// Only local body parameters can be identified, so remote ones are assumed to be regular parameters
+		if location == "" && yamlScalar(yamlMapGet(yamlMapGet(c.parameters, name), "in")) == "body" {
+			pointer = "/components/requestBodies/" + name
+		} else {
+			pointer = "/components/parameters/" + name
+		}
+	}
+	return location + "#" + pointer
+}
+
+func isSwagger2SchemaField(key string) bool {
+	for _, field := range swagger2SchemaFields {
+		if key == field {
+			return true
+		}
+	}
+	return false
+}
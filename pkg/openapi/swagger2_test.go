@@ -0,0 +1,163 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const swagger2Spec = `
+swagger: "2.0"
+info:
+  title: Pets
+  version: 1.0.0
+host: petstore.example.com
+basePath: /v1
+schemes:
+  - https
+consumes:
+  - application/json
+produces:
+  - application/json
+securityDefinitions:
+  basicAuth:
+    type: basic
+  oauth:
+    type: oauth2
+    flow: application
+    tokenUrl: https://petstore.example.com/token
+    scopes:
+      read: Read access
+parameters:
+  PetBody:
+    name: pet
+    in: body
+    required: true
+    schema:
+      $ref: '#/definitions/Pet'
+  Limit:
+    name: limit
+    in: query
+    type: integer
+    format: int32
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - $ref: '#/parameters/Limit'
+        - name: tags
+          in: query
+          type: array
+          items:
+            type: string
+          collectionFormat: multi
+      responses:
+        "200":
+          description: The pets
+          headers:
+            X-Total:
+              type: integer
+          schema:
+            type: array
+            items:
+              $ref: '#/definitions/Pet'
+    post:
+      operationId: createPet
+      parameters:
+        - $ref: '#/parameters/PetBody'
+      responses:
+        "201":
+          description: Created
+  /pets/{id}/photo:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        type: string
+    put:
+      operationId: uploadPhoto
+      consumes:
+        - multipart/form-data
+      parameters:
+        - name: photo
+          in: formData
+          required: true
+          type: file
+      responses:
+        default:
+          $ref: '#/responses/Error'
+responses:
+  Error:
+    description: An error
+    schema:
+      $ref: '#/definitions/Error'
+definitions:
+  Pet:
+    type: object
+    discriminator: kind
+    required:
+      - kind
+    properties:
+      kind:
+        type: string
+      nickname:
+        type: string
+        x-nullable: true
+  Error:
+    type: object
+    properties:
+      message:
+        type: string
+`
+
+func TestLoaderConvertSwagger2(t *testing.T) {
+	_, err := NewLoader().LoadFromData([]byte(swagger2Spec))
+	require.Error(t, err, "Swagger 2.0 documents should be rejected unless conversion is enabled")
+
+	loader := NewLoader()
+	loader.ConvertSwagger2 = true
+	swagger, err := loader.LoadFromData([]byte(swagger2Spec))
+	require.NoError(t, err)
+
+	assert.True(t, swagger.IsOpenAPI30())
+	require.Len(t, swagger.Servers, 1)
+	assert.Equal(t, "https://petstore.example.com/v1", swagger.Servers[0].URL)
+
+	pet := swagger.Components.Schemas["Pet"]
+	require.NotNil(t, pet)
+	require.NotNil(t, pet.Value.Discriminator)
+	assert.Equal(t, "kind", pet.Value.Discriminator.PropertyName)
+	assert.True(t, pet.Value.PropertiesToMap()["nickname"].Value.Nullable)
+
+	list := swagger.Paths.Find("/pets").GetOperation("GET")
+	require.NotNil(t, list)
+	params := ParametersToRefSlice(list.Parameters)
+	require.Len(t, params, 2)
+	assert.Equal(t, "limit", params[0].Value.Name)
+	assert.Equal(t, "tags", params[1].Value.Name)
+	assert.Equal(t, "form", params[1].Value.Style)
+	require.NotNil(t, params[1].Value.Explode)
+	assert.True(t, *params[1].Value.Explode)
+	ok := list.Responses.Value("200")
+	require.NotNil(t, ok)
+	require.Contains(t, ok.Value.Content, "application/json")
+	assert.Equal(t, "array", ok.Value.Content["application/json"].Schema.Value.Type[0])
+
+	create := swagger.Paths.Find("/pets").GetOperation("POST")
+	require.NotNil(t, create)
+	require.NotNil(t, create.RequestBody)
+	require.NotNil(t, create.RequestBody.Value)
+	assert.True(t, create.RequestBody.Value.Required != nil && *create.RequestBody.Value.Required)
+	assert.Contains(t, create.RequestBody.Value.Content, "application/json")
+
+	upload := swagger.Paths.Find("/pets/{id}/photo").GetOperation("PUT")
+	require.NotNil(t, upload)
+	require.NotNil(t, upload.RequestBody)
+	form := upload.RequestBody.Value.Content["multipart/form-data"]
+	require.NotNil(t, form)
+	photo := form.Schema.Value.PropertiesToMap()["photo"]
+	require.NotNil(t, photo)
+	assert.Equal(t, "binary", photo.Value.Format)
+}
@@ -0,0 +1,62 @@
+package openapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/utils"
+)
+
+// Highest OpenAPI version which can be built into a document model
+const (
+	maxSupportedMajorVersion = 3
+	maxSupportedMinorVersion = 1
+)
+
+// parseVersion parses an OpenAPI version string such as `3.0.4`, `3.1` or `3.1.0-rc1` into its major and minor components
+func parseVersion(version string) (major, minor int, err error) {
+	version = strings.TrimSpace(version)
+	// Drop any pre-release or build metadata, as it doesn't affect which features are supported
+	if idx := strings.IndexAny(version, "-+"); idx != -1 {
+		version = version[:idx]
+	}
+
+	parts := strings.Split(version, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, 0, fmt.Errorf("invalid OpenAPI version %q: expected major.minor[.patch]", version)
+	}
+
+	numbers := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return 0, 0, fmt.Errorf("invalid OpenAPI version %q: %q is not a valid version number", version, part)
+		}
+		numbers[i] = n
+	}
+
+	return numbers[0], numbers[1], nil
+}
+
+// checkSpecVersion returns an error if the document is not an OpenAPI version which can be built into a document model
+func checkSpecVersion(specInfo *datamodel.SpecInfo) error {
+	if specInfo == nil {
+		return nil
+	}
+
+	if specInfo.SpecType == utils.OpenApi2 {
+		return fmt.Errorf("documents using Swagger %s are not supported, the document must be converted to OpenAPI 3.0 or 3.1, or loaded with Loader.ConvertSwagger2 (--convert-swagger2)", specInfo.Version)
+	}
+
+	major, minor, err := parseVersion(specInfo.Version)
+	if err != nil {
+		return err
+	}
+	if major != maxSupportedMajorVersion || minor > maxSupportedMinorVersion {
+		return fmt.Errorf("unsupported OpenAPI version %s: only OpenAPI 3.0 and 3.1 are supported", specInfo.Version)
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		major   int
+		minor   int
+		wantErr bool
+	}{
+		{version: "3.0", major: 3, minor: 0},
+		{version: "3.0.4", major: 3, minor: 0},
+		{version: "3.1.1", major: 3, minor: 1},
+		{version: "3.2.0", major: 3, minor: 2},
+		{version: "3.1.0-rc1", major: 3, minor: 1},
+		{version: "3", wantErr: true},
+		{version: "3.x.0", wantErr: true},
+		{version: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			major, minor, err := parseVersion(tt.version)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.major, major)
+			assert.Equal(t, tt.minor, minor)
+		})
+	}
+}
+
+func TestLoaderVersionDetection(t *testing.T) {
+	load := func(header string) (*T, error) {
+		return NewLoader().LoadFromData([]byte(header + `
+info:
+  title: Versions
+  version: 1.0.0
+paths: {}
+`))
+	}
+
+	t.Run("3.0.4", func(t *testing.T) {
+		swagger, err := load("openapi: 3.0.4")
+		require.NoError(t, err)
+		assert.True(t, swagger.IsOpenAPI30())
+		assert.False(t, swagger.IsOpenAPI31())
+		assert.True(t, swagger.VersionAtLeast(3, 0))
+		assert.False(t, swagger.VersionAtLeast(3, 1))
+	})
+
+	t.Run("3.1.1", func(t *testing.T) {
+		swagger, err := load("openapi: 3.1.1")
+		require.NoError(t, err)
+		assert.True(t, swagger.IsOpenAPI31())
+		assert.False(t, swagger.IsOpenAPI30())
+		assert.True(t, swagger.VersionAtLeast(3, 1))
+		assert.False(t, swagger.VersionAtLeast(3, 2))
+		assert.False(t, swagger.VersionAtLeast(4, 0))
+	})
+
+	t.Run("swagger 2.0", func(t *testing.T) {
+		_, err := load("swagger: '2.0'")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Swagger 2.0 are not supported")
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := load("openapi: 4.0.0")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported OpenAPI version 4.0.0")
+	})
+}
@@ -0,0 +1,101 @@
+package openapi
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+func newYamlMapping() *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+}
+
+func newYamlSequence() *yaml.Node {
+	return &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+}
+
+func newYamlString(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+func newYamlBool(value bool) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: fmt.Sprint(value)}
+}
+
+// yamlMapGet returns the value for key in a mapping node, or nil if it is not present
+func yamlMapGet(n *yaml.Node, key string) *yaml.Node {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// yamlMapSet sets the value for key in a mapping node, replacing any existing value
+func yamlMapSet(n *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			n.Content[i+1] = value
+			return
+		}
+	}
+	n.Content = append(n.Content, newYamlString(key), value)
+}
+
+// yamlMapDelete removes key from a mapping node, if it is present
+func yamlMapDelete(n *yaml.Node, key string) {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			n.Content = append(n.Content[:i], n.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+// yamlForEach calls fn with each key and value of a mapping node, in document order
+func yamlForEach(n *yaml.Node, fn func(key string, value *yaml.Node)) {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		fn(n.Content[i].Value, n.Content[i+1])
+	}
+}
+
+// yamlMapValues returns a copy of a mapping node with each value transformed by fn
+func yamlMapValues(n *yaml.Node, fn func(*yaml.Node) *yaml.Node) *yaml.Node {
+	out := newYamlMapping()
+	yamlForEach(n, func(key string, value *yaml.Node) {
+		yamlMapSet(out, key, fn(value))
+	})
+	return out
+}
+
+func yamlSequence(n *yaml.Node) []*yaml.Node {
+	if n == nil || n.Kind != yaml.SequenceNode {
+		return nil
+	}
+	return n.Content
+}
+
+func yamlScalar(n *yaml.Node) string {
+	if n == nil || n.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return n.Value
+}
+
+func yamlStringSlice(n *yaml.Node) []string {
+	var values []string
+	for _, item := range yamlSequence(n) {
+		values = append(values, yamlScalar(item))
+	}
+	return values
+}
@@ -3,15 +3,13 @@ package util
 import (
 	"fmt"
 	"io"
-	"net/http"
+	"log/slog"
 	"net/url"
 	"os"
 	"path/filepath"
-	"strings"
+	"time"
 
 	"github.com/oapi-codegen/oapi-codegen/v2/pkg/openapi"
-	"github.com/speakeasy-api/openapi-overlay/pkg/loader"
-	"gopkg.in/yaml.v3"
 )
 
 func LoadSwagger(filePath string) (swagger *openapi.T, err error) {
@@ -38,118 +36,70 @@ func LoadSwaggerWithCircularReferenceCount(filePath string, _ int) (swagger *ope
 	return LoadSwagger(filePath)
 }
 
+// StdinPath is the path which reads the spec from stdin, rather than from a file or URL
+const StdinPath = "-"
+
 type LoadSwaggerWithOverlayOpts struct {
-	Path              string
+	// Path is the overlay which is applied to the spec, if any
+	Path string
+	// Strict fails loading the spec if any of the overlay's actions has a target which matches nothing in the spec
 	Strict            bool
 	IgnoreMissingRefs bool
+	// ConvertSwagger2 upgrades Swagger 2.0 specs to OpenAPI 3.0 before loading them
+	ConvertSwagger2 bool
+	// KeepSingleMemberCompositions keeps a schema which is only an `allOf` of a single reference, or a `oneOf` of a single schema, rather than unwrapping it into the schema it wraps
+	KeepSingleMemberCompositions bool
+	// BasePath is the directory that relative references are resolved from, when the spec is read from stdin. Defaults to the working directory
+	BasePath string
+	// Stdin is read from when the spec's path is StdinPath. Defaults to os.Stdin
+	Stdin io.Reader
+	// Logger receives debug logs about how the spec's references are resolved, if set
+	Logger *slog.Logger
+	// Timings receives how long each phase of loading the spec takes, as the Loader's Timings do, if set
+	Timings func(phase string, elapsed time.Duration)
 }
 
 func LoadSwaggerWithOverlay(filePath string, opts LoadSwaggerWithOverlayOpts) (swagger *openapi.T, err error) {
-	if opts.Path == "" {
-		return LoadSwagger(filePath)
+	loader := openapi.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.IgnoreMissingRefs = opts.IgnoreMissingRefs
+	loader.ConvertSwagger2 = opts.ConvertSwagger2
+	loader.SimplifyCompositions = !opts.KeepSingleMemberCompositions
+	loader.Logger = opts.Logger
+	loader.Timings = opts.Timings
+	if opts.Path != "" {
+		loader.Overlays = []openapi.OverlaySource{{Path: opts.Path, Strict: opts.Strict}}
 	}
 
-	// Load the overlay
-	overlay, err := loader.LoadOverlay(opts.Path)
-	if err != nil {
-		return nil, fmt.Errorf("failed to load overlay: %w", err)
+	if filePath == StdinPath {
+		return loadSwaggerFromStdin(loader, opts)
 	}
-	
 
-	// Check if filePath is a URL, if so download the content to a temporary file
-	var actualFilePath string
-	var tempFile *os.File
-	
 	u, err := url.Parse(filePath)
 	if err == nil && u.Scheme != "" && u.Host != "" {
-		// It's a URL, download the content to a temporary file
-		client := &http.Client{}
-		resp, err := client.Get(filePath)
-		if err != nil {
-			return nil, fmt.Errorf("failed to fetch spec from URL %s: %w", filePath, err)
-		}
-		defer resp.Body.Close()
-		
-		if resp.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("failed to fetch spec from URL %s: status %d", filePath, resp.StatusCode)
-		}
-		
-		// Create a temporary file
-		tempFile, err = os.CreateTemp("", "openapi-spec-*.json")
-		if err != nil {
-			return nil, fmt.Errorf("failed to create temporary file: %w", err)
-		}
-		defer os.Remove(tempFile.Name()) // Clean up
-		defer tempFile.Close()
-		
-		// Copy the response to the temporary file
-		_, err = io.Copy(tempFile, resp.Body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to write spec to temporary file: %w", err)
-		}
-		
-		actualFilePath = tempFile.Name()
-	} else {
-		// It's already a file path
-		actualFilePath = filePath
+		return loader.LoadFromURI(u)
 	}
+	return loader.LoadFromFile(filePath)
+}
 
-
-	// Load the specification
-	specNode, _, err := loader.LoadEitherSpecification(actualFilePath, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to load specification: %w", err)
+// loadSwaggerFromStdin loads the spec from opts.Stdin, resolving its relative references from opts.BasePath
+func loadSwaggerFromStdin(loader *openapi.Loader, opts LoadSwaggerWithOverlayOpts) (*openapi.T, error) {
+	stdin := opts.Stdin
+	if stdin == nil {
+		stdin = os.Stdin
 	}
-	
-	// Apply the overlay to the specification
-	err = overlay.ApplyTo(specNode)
+	data, err := io.ReadAll(stdin)
 	if err != nil {
-		return nil, fmt.Errorf("failed to apply overlay: %w", err)
+		return nil, fmt.Errorf("failed to read spec from stdin: %w", err)
 	}
-	
-	overlayedNode := specNode
-
 
-	// Convert the YAML node back to bytes
-	if overlayedNode != nil {
-		// Serialize the overlayed node back to YAML bytes
-		overlayedBytes, err := yaml.Marshal(overlayedNode)
-		if err != nil {
-			return nil, fmt.Errorf("failed to serialize overlayed spec: %w", err)
-		}
-
-
-		// Load the overlayed spec using our normal loader with base path preservation
-		loader := openapi.NewLoader()
-		loader.IsExternalRefsAllowed = true
-		loader.IgnoreMissingRefs = opts.IgnoreMissingRefs
-
-		// Extract base path from the original file path for reference resolution
-		basePath := ""
-		
-		// Check if the original filePath was a URL
-		if u, err := url.Parse(filePath); err == nil && u.Scheme != "" && u.Host != "" {
-			// For URLs, use the URL's base path
-			u.Path = filepath.Dir(u.Path)
-			basePath = u.String()
-		} else {
-			// For file paths, extract directory path
-			if idx := strings.LastIndex(filePath, "/"); idx != -1 {
-				basePath = filePath[:idx]
-			} else if idx := strings.LastIndex(filePath, "\\"); idx != -1 {
-				basePath = filePath[:idx]
-			}
-
-			// Convert to absolute path if we have a base path
-			if basePath != "" {
-				if absBasePath, err := filepath.Abs(basePath); err == nil {
-					basePath = absBasePath
-				}
-			}
-		}
-
-		return loader.LoadFromDataWithBasePath(overlayedBytes, basePath)
+	basePath := opts.BasePath
+	if basePath == "" {
+		basePath = "."
 	}
-
-	return LoadSwagger(filePath)
+	basePath, err = filepath.Abs(basePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve base path %s: %w", opts.BasePath, err)
+	}
+	return loader.LoadFromDataWithBasePath(data, basePath)
 }
@@ -0,0 +1,59 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const stdinSpec = `openapi: 3.0.0
+info:
+  title: stdin
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      $ref: './pet.yaml'
+`
+
+func TestLoadSwaggerFromStdin(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pet.yaml"), []byte("type: object\nproperties:\n  name:\n    type: string\n"), 0o644))
+
+	swagger, err := LoadSwaggerWithOverlay(StdinPath, LoadSwaggerWithOverlayOpts{
+		BasePath: dir,
+		Stdin:    strings.NewReader(stdinSpec),
+	})
+	require.NoError(t, err)
+
+	pet := swagger.Components.Schemas["Pet"]
+	require.NotNil(t, pet)
+	require.NotNil(t, pet.Value)
+	assert.Contains(t, pet.Value.PropertiesToMap(), "name")
+}
+
+func TestLoadSwaggerFromStdinWithOverlay(t *testing.T) {
+	dir := t.TempDir()
+	overlayPath := filepath.Join(dir, "overlay.yaml")
+	require.NoError(t, os.WriteFile(overlayPath, []byte(`overlay: 1.0.0
+info:
+  title: rename
+  version: 1.0.0
+actions:
+  - target: $.info
+    update:
+      title: overlaid
+`), 0o644))
+
+	swagger, err := LoadSwaggerWithOverlay(StdinPath, LoadSwaggerWithOverlayOpts{
+		Path:  overlayPath,
+		Stdin: strings.NewReader("openapi: 3.0.0\ninfo:\n  title: stdin\n  version: 1.0.0\npaths: {}\n"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "overlaid", swagger.Info.Title)
+}